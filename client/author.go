@@ -0,0 +1,162 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+type CreateAuthorParams struct {
+	FirstName  string `json:"first_name"`
+	LastName   string `json:"last_name"`
+	MiddleName string `json:"middle_name,omitempty"`
+}
+
+// CreateAuthor adds an author, it is not retried
+func (c *Client) CreateAuthor(ctx context.Context, arg CreateAuthorParams) (*Author, error) {
+	var res Author
+	err := c.do(ctx, request{
+		method:  http.MethodPost,
+		path:    "/authors",
+		body:    arg,
+		wantOK:  http.StatusCreated,
+		outBody: &res,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+func (c *Client) GetAuthor(ctx context.Context, id int64) (*Author, error) {
+	var res Author
+	err := c.do(ctx, request{
+		method:  http.MethodGet,
+		path:    "/authors/" + strconv.FormatInt(id, 10),
+		wantOK:  http.StatusOK,
+		outBody: &res,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// ListAuthorsParams filters the authors, zero values are left out
+type ListAuthorsParams struct {
+	UpdatedSince   time.Time
+	IncludeDeleted bool
+	Page           int32 // 1 by default
+	PerPage        int32 // 5 by default
+}
+
+func (p ListAuthorsParams) values() url.Values {
+	q := queryValues{url.Values{}}
+	q.time("updated_since", p.UpdatedSince)
+	q.bool("include_deleted", p.IncludeDeleted)
+	q.int("page", p.Page)
+	q.int("per_page", p.PerPage)
+
+	return q.Values
+}
+
+func (c *Client) ListAuthors(ctx context.Context, arg ListAuthorsParams) (*Page[Author], error) {
+	var res Page[Author]
+	err := c.do(ctx, request{
+		method:  http.MethodGet,
+		path:    "/authors",
+		query:   arg.values(),
+		wantOK:  http.StatusOK,
+		outBody: &res,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// IterAuthors walks all the pages of authors from arg.Page on
+func (c *Client) IterAuthors(ctx context.Context, arg ListAuthorsParams) *Iterator[Author] {
+	return newIterator(ctx, arg.Page, func(ctx context.Context, page int32) (*Page[Author], error) {
+		arg.Page = page
+		return c.ListAuthors(ctx, arg)
+	})
+}
+
+// UpdateAuthorParams changes the fields that are set
+type UpdateAuthorParams struct {
+	FirstName  string `json:"first_name,omitempty"`
+	LastName   string `json:"last_name,omitempty"`
+	MiddleName string `json:"middle_name,omitempty"`
+}
+
+// UpdateAuthor changes the author at version, see Author.Version. It fails
+// with ErrVersionMismatch when the author was changed since.
+func (c *Client) UpdateAuthor(ctx context.Context, id int64, version int64, arg UpdateAuthorParams) (*Author, error) {
+	var res Author
+	err := c.do(ctx, request{
+		method:  http.MethodPut,
+		path:    "/authors/" + strconv.FormatInt(id, 10),
+		body:    arg,
+		header:  ifMatch(version),
+		wantOK:  http.StatusOK,
+		outBody: &res,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// DeleteAuthor deletes the author at version, see Author.Version
+func (c *Client) DeleteAuthor(ctx context.Context, id int64, version int64) error {
+	return c.do(ctx, request{
+		method: http.MethodDelete,
+		path:   "/authors/" + strconv.FormatInt(id, 10),
+		header: ifMatch(version),
+		wantOK: http.StatusNoContent,
+	})
+}
+
+// PatchAuthor applies a merge patch to the author at version, see
+// Author.Version. It fails with ErrVersionMismatch when the author was
+// changed since.
+func (c *Client) PatchAuthor(ctx context.Context, id int64, version int64, patch Patch) (*Author, error) {
+	var res Author
+	err := c.do(ctx, request{
+		method:      http.MethodPatch,
+		path:        "/authors/" + strconv.FormatInt(id, 10),
+		body:        patch,
+		contentType: mergePatchType,
+		header:      ifMatch(version),
+		wantOK:      http.StatusOK,
+		outBody:     &res,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// RestoreAuthor brings back a deleted author
+func (c *Client) RestoreAuthor(ctx context.Context, id int64) (*Author, error) {
+	var res Author
+	err := c.do(ctx, request{
+		method:  http.MethodPost,
+		path:    "/authors/" + strconv.FormatInt(id, 10) + "/restore",
+		wantOK:  http.StatusOK,
+		outBody: &res,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
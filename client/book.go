@@ -0,0 +1,262 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+type BookParams struct {
+	Title           string  `json:"title"`
+	ISBN13          string  `json:"isbn13,omitempty"`
+	ISBN10          string  `json:"isbn10,omitempty"`
+	Price           float64 `json:"price"`
+	PublicationYear int64   `json:"publication_year"`
+	ImageUrl        string  `json:"image_url,omitempty"`
+	Edition         string  `json:"edition,omitempty"`
+	Language        string  `json:"language,omitempty"`
+	Format          string  `json:"format,omitempty"`
+	PageCount       int64   `json:"page_count,omitempty"`
+	SeriesName      string  `json:"series_name,omitempty"`
+	SeriesNumber    int64   `json:"series_number,omitempty"`
+	Description     string  `json:"description,omitempty"`
+}
+
+type CreateBookParams struct {
+	Book         BookParams    `json:"book"`
+	Authors      []string      `json:"authors,omitempty"`
+	Contributors []Contributor `json:"contributors,omitempty"` // credited after the authors
+	Publisher    string        `json:"publisher"`
+	Subjects     []string      `json:"subjects,omitempty"`
+}
+
+// CreateBook adds a book, it is not retried
+func (c *Client) CreateBook(ctx context.Context, arg CreateBookParams) (*Book, error) {
+	var res Book
+	err := c.do(ctx, request{
+		method:  http.MethodPost,
+		path:    "/books",
+		body:    arg,
+		wantOK:  http.StatusCreated,
+		outBody: &res,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+func (c *Client) GetBook(ctx context.Context, isbn13 string) (*Book, error) {
+	var res Book
+	err := c.do(ctx, request{
+		method:  http.MethodGet,
+		path:    "/books/" + url.PathEscape(isbn13),
+		wantOK:  http.StatusOK,
+		outBody: &res,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// ListBooksParams filters the books, zero values are left out
+type ListBooksParams struct {
+	Title              string
+	MinPrice           *float64
+	MaxPrice           *float64
+	MinPublicationYear int32
+	MaxPublicationYear int32
+	Author             string
+	Publisher          string
+	Language           string
+	Format             string
+	MinPageCount       int32
+	MaxPageCount       int32
+	SeriesName         string
+	SeriesNumber       int32
+	Description        string
+	Subject            string
+	UpdatedSince       time.Time
+	IncludeDeleted     bool
+	Page               int32 // 1 by default
+	PerPage            int32 // 5 by default, at most 30
+}
+
+func (p ListBooksParams) values() url.Values {
+	q := queryValues{url.Values{}}
+	q.string("title", p.Title)
+	q.float("min_price", p.MinPrice)
+	q.float("max_price", p.MaxPrice)
+	q.int("min_publication_year", p.MinPublicationYear)
+	q.int("max_publication_year", p.MaxPublicationYear)
+	q.string("author", p.Author)
+	q.string("publisher", p.Publisher)
+	q.string("language", p.Language)
+	q.string("format", p.Format)
+	q.int("min_page_count", p.MinPageCount)
+	q.int("max_page_count", p.MaxPageCount)
+	q.string("series_name", p.SeriesName)
+	q.int("series_number", p.SeriesNumber)
+	q.string("description", p.Description)
+	q.string("subject", p.Subject)
+	q.time("updated_since", p.UpdatedSince)
+	q.bool("include_deleted", p.IncludeDeleted)
+	q.int("page", p.Page)
+	q.int("per_page", p.PerPage)
+
+	return q.Values
+}
+
+func (c *Client) ListBooks(ctx context.Context, arg ListBooksParams) (*Page[Book], error) {
+	var res Page[Book]
+	err := c.do(ctx, request{
+		method:  http.MethodGet,
+		path:    "/books",
+		query:   arg.values(),
+		wantOK:  http.StatusOK,
+		outBody: &res,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// IterBooks walks all the pages of books from arg.Page on
+func (c *Client) IterBooks(ctx context.Context, arg ListBooksParams) *Iterator[Book] {
+	return newIterator(ctx, arg.Page, func(ctx context.Context, page int32) (*Page[Book], error) {
+		arg.Page = page
+		return c.ListBooks(ctx, arg)
+	})
+}
+
+// UpdateBookParams changes the fields that are set, subjects replace all
+// the subjects of the book when given
+type UpdateBookParams struct {
+	Title           string   `json:"title,omitempty"`
+	NewISBN13       string   `json:"isbn13,omitempty"`
+	NewISBN10       string   `json:"isbn10,omitempty"`
+	Price           float64  `json:"price,omitempty"`
+	PublicationYear int32    `json:"publication_year,omitempty"`
+	ImageUrl        string   `json:"image_url,omitempty"`
+	Language        string   `json:"language,omitempty"`
+	Format          string   `json:"format,omitempty"`
+	PageCount       int64    `json:"page_count,omitempty"`
+	SeriesName      string   `json:"series_name,omitempty"`
+	SeriesNumber    int64    `json:"series_number,omitempty"`
+	Description     string   `json:"description,omitempty"`
+	Subjects        []string `json:"subjects,omitempty"`
+}
+
+// UpdateBook changes the book at version, see Book.Version. It fails with
+// ErrVersionMismatch when the book was changed since.
+func (c *Client) UpdateBook(ctx context.Context, isbn13 string, version int64, arg UpdateBookParams) (*Book, error) {
+	var res Book
+	err := c.do(ctx, request{
+		method:  http.MethodPut,
+		path:    "/books/" + url.PathEscape(isbn13),
+		body:    arg,
+		header:  ifMatch(version),
+		wantOK:  http.StatusOK,
+		outBody: &res,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// DeleteBook deletes the book at version, see Book.Version
+func (c *Client) DeleteBook(ctx context.Context, isbn13 string, version int64) error {
+	return c.do(ctx, request{
+		method: http.MethodDelete,
+		path:   "/books/" + url.PathEscape(isbn13),
+		header: ifMatch(version),
+		wantOK: http.StatusNoContent,
+	})
+}
+
+// PatchBook applies a merge patch to the book at version, see Book.Version.
+// It fails with ErrVersionMismatch when the book was changed since.
+func (c *Client) PatchBook(ctx context.Context, isbn13 string, version int64, patch Patch) (*Book, error) {
+	var res Book
+	err := c.do(ctx, request{
+		method:      http.MethodPatch,
+		path:        "/books/" + url.PathEscape(isbn13),
+		body:        patch,
+		contentType: mergePatchType,
+		header:      ifMatch(version),
+		wantOK:      http.StatusOK,
+		outBody:     &res,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// RestoreBook brings back a deleted book
+func (c *Client) RestoreBook(ctx context.Context, isbn13 string) (*Book, error) {
+	var res Book
+	err := c.do(ctx, request{
+		method:  http.MethodPost,
+		path:    "/books/" + url.PathEscape(isbn13) + "/restore",
+		wantOK:  http.StatusOK,
+		outBody: &res,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// UploadBookCover sets the cover of the book to a JPEG, PNG or WebP image,
+// it is not retried
+func (c *Client) UploadBookCover(ctx context.Context, isbn13 string, filename string, image io.Reader) (*Book, error) {
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	part, err := w.CreateFormFile("cover", filename)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := io.Copy(part, image); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+
+	var res Book
+	err = c.do(ctx, request{
+		method:      http.MethodPost,
+		path:        "/books/" + url.PathEscape(isbn13) + "/cover",
+		rawBody:     body.Bytes(),
+		contentType: w.FormDataContentType(),
+		wantOK:      http.StatusOK,
+		outBody:     &res,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+func (c *Client) DeleteBookCover(ctx context.Context, isbn13 string) error {
+	return c.do(ctx, request{
+		method: http.MethodDelete,
+		path:   "/books/" + url.PathEscape(isbn13) + "/cover",
+		wantOK: http.StatusNoContent,
+	})
+}
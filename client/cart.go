@@ -0,0 +1,84 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// GetCart returns the cart of the client. The cart is kept by a cookie, so
+// the HTTP client needs a cookie jar to see the same cart across calls, e.g.
+//
+//	jar, _ := cookiejar.New(nil)
+//	c := client.New(baseURL, client.WithHTTPClient(&http.Client{Jar: jar}))
+func (c *Client) GetCart(ctx context.Context) (*Cart, error) {
+	var res Cart
+	err := c.do(ctx, request{
+		method:  http.MethodGet,
+		path:    "/cart",
+		wantOK:  http.StatusOK,
+		outBody: &res,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+type AddCartItemParams struct {
+	ISBN13   string `json:"isbn13"`
+	Quantity int64  `json:"quantity,omitempty"` // 1 by default, at most 99
+}
+
+// AddCartItem adds copies of a book to the cart, it is not retried
+func (c *Client) AddCartItem(ctx context.Context, arg AddCartItemParams) (*Cart, error) {
+	var res Cart
+	err := c.do(ctx, request{
+		method:  http.MethodPost,
+		path:    "/cart/items",
+		body:    arg,
+		wantOK:  http.StatusOK,
+		outBody: &res,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// UpdateCartItem sets the number of copies of a book in the cart, zero
+// removes it
+func (c *Client) UpdateCartItem(ctx context.Context, isbn13 string, quantity int64) (*Cart, error) {
+	var res Cart
+	err := c.do(ctx, request{
+		method: http.MethodPut,
+		path:   "/cart/items/" + url.PathEscape(isbn13),
+		body: struct {
+			Quantity int64 `json:"quantity"`
+		}{quantity},
+		wantOK:  http.StatusOK,
+		outBody: &res,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+func (c *Client) RemoveCartItem(ctx context.Context, isbn13 string) (*Cart, error) {
+	var res Cart
+	err := c.do(ctx, request{
+		method:  http.MethodDelete,
+		path:    "/cart/items/" + url.PathEscape(isbn13),
+		wantOK:  http.StatusOK,
+		outBody: &res,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
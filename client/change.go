@@ -0,0 +1,41 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// ListChangesParams reads the changes after Since, zero values are left out
+type ListChangesParams struct {
+	Since time.Time
+	Limit int32 // 100 by default, at most 1000
+}
+
+func (p ListChangesParams) values() url.Values {
+	q := queryValues{url.Values{}}
+	q.time("since", p.Since)
+	q.int("limit", p.Limit)
+
+	return q.Values
+}
+
+// ListChanges lists the books, authors and publishers changed after
+// arg.Since, oldest first. Pass ChangeFeed.Since as the next arg.Since to
+// read on.
+func (c *Client) ListChanges(ctx context.Context, arg ListChangesParams) (*ChangeFeed, error) {
+	var res ChangeFeed
+	err := c.do(ctx, request{
+		method:  http.MethodGet,
+		path:    "/changes",
+		query:   arg.values(),
+		wantOK:  http.StatusOK,
+		outBody: &res,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
@@ -0,0 +1,237 @@
+// Package client is the Go client of the XYZ Books JSON API. It covers the
+// endpoints of internal/docs/api/swagger.yaml, retries idempotent requests
+// on transient failures and answers API errors as *APIError.
+//
+//	c := client.New("http://localhost:3000/api/v1")
+//	it := c.IterBooks(ctx, client.ListBooksParams{Author: "Le Guin"})
+//	for it.Next() {
+//		fmt.Println(it.Value().Title)
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultMaxRetries = 3
+	defaultRetryDelay = 200 * time.Millisecond
+	maxRetryDelay     = 10 * time.Second
+)
+
+// HTTPClient sends the requests, *http.Client by default
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+type Client struct {
+	baseURL    string
+	http       HTTPClient
+	maxRetries int
+	retryDelay time.Duration
+	apiKey     string
+}
+
+type Option func(*Client)
+
+// WithHTTPClient sends the requests through c, e.g. to set timeouts or
+// transport options
+func WithHTTPClient(c HTTPClient) Option {
+	return func(client *Client) {
+		client.http = c
+	}
+}
+
+// WithRetries sets how many times an idempotent request is retried after a
+// network error or a 429, 502, 503 or 504 answer, and the delay before the
+// first retry, doubled after each. Retry-After headers take precedence.
+func WithRetries(max int, delay time.Duration) Option {
+	return func(client *Client) {
+		client.maxRetries = max
+		client.retryDelay = delay
+	}
+}
+
+// WithAPIKey sends key as X-API-Key, admins need it to see deleted records
+func WithAPIKey(key string) Option {
+	return func(client *Client) {
+		client.apiKey = key
+	}
+}
+
+// New returns a client of the API at baseURL, which includes the base path,
+// e.g. http://localhost:3000/api/v1
+func New(baseURL string, opts ...Option) *Client {
+	c := &Client{
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		http:       http.DefaultClient,
+		maxRetries: defaultMaxRetries,
+		retryDelay: defaultRetryDelay,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// request is one call of the API, the body is encoded once and sent again
+// on retries
+type request struct {
+	method      string
+	path        string
+	query       url.Values
+	body        any
+	rawBody     []byte // sent as is instead of body
+	contentType string // of the body, application/json when empty
+	header      http.Header
+	wantOK      int
+	outBody     any
+}
+
+func (c *Client) do(ctx context.Context, r request) error {
+	body := r.rawBody
+	if r.body != nil {
+		var err error
+		body, err = json.Marshal(r.body)
+		if err != nil {
+			return fmt.Errorf("encoding request: %w", err)
+		}
+	}
+
+	u := c.baseURL + r.path
+	if len(r.query) > 0 {
+		u += "?" + r.query.Encode()
+	}
+
+	retries := 0
+	if r.method != http.MethodPost {
+		retries = c.maxRetries
+	}
+	delay := c.retryDelay
+
+	for attempt := 0; ; attempt++ {
+		req, err := http.NewRequestWithContext(ctx, r.method, u, bytes.NewReader(body))
+		if err != nil {
+			return err
+		}
+		for k, v := range r.header {
+			req.Header[k] = v
+		}
+		req.Header.Set("Accept", "application/json")
+		if len(c.apiKey) > 0 {
+			req.Header.Set("X-API-Key", c.apiKey)
+		}
+		if len(r.contentType) > 0 {
+			req.Header.Set("Content-Type", r.contentType)
+		} else if r.body != nil {
+			req.Header.Set("Content-Type", "application/json")
+		}
+
+		res, err := c.http.Do(req)
+		if err == nil && !retryable(res.StatusCode) {
+			return decodeResponse(res, r.wantOK, r.outBody)
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if attempt >= retries {
+			if err != nil {
+				return err
+			}
+			return decodeResponse(res, r.wantOK, r.outBody)
+		}
+
+		wait := delay
+		if err == nil {
+			if d, ok := retryAfter(res); ok {
+				wait = d
+			}
+			drain(res)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+		delay = min(2*delay, maxRetryDelay)
+	}
+}
+
+// decodeResponse reads the body into out on the wanted status, and into an
+// *APIError otherwise. The body is always closed.
+func decodeResponse(res *http.Response, wantOK int, out any) error {
+	defer drain(res)
+
+	if res.StatusCode != wantOK {
+		return newAPIError(res)
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(res.Body).Decode(out); err != nil {
+		return fmt.Errorf("decoding response: %w", err)
+	}
+
+	return nil
+}
+
+// drain reads what is left of the body so the connection can be reused
+func drain(res *http.Response) {
+	io.Copy(io.Discard, io.LimitReader(res.Body, 1<<16))
+	res.Body.Close()
+}
+
+func retryable(status int) bool {
+	switch status {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+
+	return false
+}
+
+func retryAfter(res *http.Response) (time.Duration, bool) {
+	header := res.Header.Get("Retry-After")
+	if len(header) == 0 {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(header); err == nil && seconds >= 0 {
+		return min(time.Duration(seconds)*time.Second, maxRetryDelay), true
+	}
+	if t, err := http.ParseTime(header); err == nil {
+		return min(max(time.Until(t), 0), maxRetryDelay), true
+	}
+
+	return 0, false
+}
+
+// Patch is a JSON merge patch (RFC 7396) of a book, author or publisher:
+// members left out are unchanged and a nil member clears the field, e.g.
+// Patch{"price": 0, "image_url": nil}
+type Patch map[string]any
+
+const mergePatchType = "application/merge-patch+json"
+
+// ifMatch is the precondition of a write, version 0 writes whatever the
+// current version is
+func ifMatch(version int64) http.Header {
+	tag := "*"
+	if version > 0 {
+		tag = `"` + strconv.FormatInt(version, 10) + `"`
+	}
+
+	return http.Header{"If-Match": []string{tag}}
+}
@@ -0,0 +1,382 @@
+package client_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/atsuyaourt/xyz-books/client"
+	db "github.com/atsuyaourt/xyz-books/internal/db/sqlc"
+	"github.com/atsuyaourt/xyz-books/internal/handlers"
+	mockdb "github.com/atsuyaourt/xyz-books/internal/mocks/db"
+	"github.com/atsuyaourt/xyz-books/internal/util"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestClient serves the API handlers over the store and returns a client
+// of them
+func newTestClient(t *testing.T, store db.Store) *client.Client {
+	h, err := handlers.NewDefaultHandler(store)
+	require.NoError(t, err)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	api := router.Group("/api/v1")
+	api.GET("/books", h.ListBooks)
+	api.GET("/books/:isbn", h.GetBook)
+	api.PUT("/books/:isbn", h.UpdateBook)
+	api.GET("/authors/:id", h.GetAuthor)
+	api.DELETE("/publishers/:id", h.DeletePublisher)
+
+	srv := httptest.NewServer(router)
+	t.Cleanup(srv.Close)
+
+	return client.New(srv.URL+"/api/v1", client.WithRetries(0, 0))
+}
+
+func TestGetBook(t *testing.T) {
+	book := randomBook(t)
+
+	testCases := []struct {
+		name       string
+		buildStubs func(store *mockdb.MockStore)
+		check      func(res *client.Book, err error)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetBookByISBN(mock.Anything, mock.Anything).Return(db.GetBookByISBNRow{
+					Book:         book,
+					Authors:      "Zed Zulu",
+					Contributors: "author:Zed Zulu,illustrator:Amy Adams",
+				}, nil)
+			},
+			check: func(res *client.Book, err error) {
+				require.NoError(t, err)
+				require.Equal(t, book.Isbn13.String, res.ISBN13)
+				require.Equal(t, book.Title, res.Title)
+				require.Equal(t, []string{"Zed Zulu"}, res.Authors)
+				require.Len(t, res.Contributors, 2)
+				require.Equal(t, book.Version, res.Version)
+			},
+		},
+		{
+			name: "NotFound",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetBookByISBN(mock.Anything, mock.Anything).Return(db.GetBookByISBNRow{}, db.ErrRecordNotFound)
+			},
+			check: func(res *client.Book, err error) {
+				require.ErrorIs(t, err, client.ErrNotFound)
+				var apiErr *client.APIError
+				require.ErrorAs(t, err, &apiErr)
+				require.Equal(t, "book not found", apiErr.Message)
+			},
+		},
+		{
+			name: "InternalError",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetBookByISBN(mock.Anything, mock.Anything).Return(db.GetBookByISBNRow{}, sql.ErrConnDone)
+			},
+			check: func(res *client.Book, err error) {
+				var apiErr *client.APIError
+				require.ErrorAs(t, err, &apiErr)
+				require.Equal(t, http.StatusInternalServerError, apiErr.StatusCode)
+				require.NotErrorIs(t, err, client.ErrNotFound)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			store := mockdb.NewMockStore(t)
+			tc.buildStubs(store)
+
+			c := newTestClient(t, store)
+			res, err := c.GetBook(context.Background(), book.Isbn13.String)
+			tc.check(res, err)
+		})
+	}
+}
+
+func TestIterBooks(t *testing.T) {
+	n := 7
+	rows := make([]db.ListBooksRow, n)
+	for i := range rows {
+		rows[i] = db.ListBooksRow{Book: randomBook(t)}
+	}
+
+	store := mockdb.NewMockStore(t)
+	store.EXPECT().ListBooks(mock.Anything, mock.MatchedBy(func(arg db.ListBooksParams) bool {
+		return arg.Author.String == "Zulu"
+	})).RunAndReturn(func(ctx context.Context, arg db.ListBooksParams) ([]db.ListBooksRow, error) {
+		end := min(int(arg.Offset+arg.Limit), n)
+		return rows[arg.Offset:end], nil
+	}).Times(3)
+	store.EXPECT().CountBooks(mock.Anything, mock.Anything).Return(int64(n), nil).Times(3)
+
+	c := newTestClient(t, store)
+	it := c.IterBooks(context.Background(), client.ListBooksParams{Author: "Zulu", PerPage: 3})
+
+	var isbns []string
+	for it.Next() {
+		isbns = append(isbns, it.Value().ISBN13)
+	}
+	require.NoError(t, it.Err())
+	require.Len(t, isbns, n)
+	for i, row := range rows {
+		require.Equal(t, row.Book.Isbn13.String, isbns[i])
+	}
+}
+
+func TestIterBooksError(t *testing.T) {
+	store := mockdb.NewMockStore(t)
+	store.EXPECT().ListBooks(mock.Anything, mock.Anything).Return(nil, sql.ErrConnDone)
+
+	c := newTestClient(t, store)
+	it := c.IterBooks(context.Background(), client.ListBooksParams{})
+
+	require.False(t, it.Next())
+	require.Error(t, it.Err())
+	require.False(t, it.Next())
+}
+
+func TestUpdateBook(t *testing.T) {
+	book := randomBook(t)
+
+	testCases := []struct {
+		name       string
+		version    int64
+		buildStubs func(store *mockdb.MockStore)
+		check      func(res *client.Book, err error)
+	}{
+		{
+			name:    "OK",
+			version: 1,
+			buildStubs: func(store *mockdb.MockStore) {
+				updated := book
+				updated.Title = "New Title"
+				updated.Version = 2
+				store.EXPECT().GetBookByISBN(mock.Anything, mock.Anything).Return(db.GetBookByISBNRow{Book: book}, nil).Once()
+				store.EXPECT().UpdateBookTx(mock.Anything, mock.MatchedBy(func(arg db.UpdateBookTxParams) bool {
+					return arg.Book.Version.Int64 == 1 && arg.Book.Title.String == "New Title"
+				})).Return(updated, nil)
+				store.EXPECT().GetBookByISBN(mock.Anything, mock.Anything).Return(db.GetBookByISBNRow{Book: updated}, nil).Once()
+			},
+			check: func(res *client.Book, err error) {
+				require.NoError(t, err)
+				require.Equal(t, "New Title", res.Title)
+				require.Equal(t, int64(2), res.Version)
+			},
+		},
+		{
+			name:    "VersionMismatch",
+			version: 3,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetBookByISBN(mock.Anything, mock.Anything).Return(db.GetBookByISBNRow{Book: book}, nil)
+			},
+			check: func(res *client.Book, err error) {
+				require.ErrorIs(t, err, client.ErrVersionMismatch)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			store := mockdb.NewMockStore(t)
+			tc.buildStubs(store)
+
+			c := newTestClient(t, store)
+			res, err := c.UpdateBook(context.Background(), book.Isbn13.String, tc.version, client.UpdateBookParams{Title: "New Title"})
+			tc.check(res, err)
+		})
+	}
+}
+
+func TestRetries(t *testing.T) {
+	testCases := []struct {
+		name      string
+		do        func(c *client.Client) error
+		failures  int32
+		wantCalls int32
+		wantErr   bool
+	}{
+		{
+			name: "GetRecovers",
+			do: func(c *client.Client) error {
+				_, err := c.GetAuthor(context.Background(), 1)
+				return err
+			},
+			failures:  2,
+			wantCalls: 3,
+		},
+		{
+			name: "GetGivesUp",
+			do: func(c *client.Client) error {
+				_, err := c.GetAuthor(context.Background(), 1)
+				return err
+			},
+			failures:  5,
+			wantCalls: 4,
+			wantErr:   true,
+		},
+		{
+			name: "PostNotRetried",
+			do: func(c *client.Client) error {
+				_, err := c.CreateAuthor(context.Background(), client.CreateAuthorParams{FirstName: "Zed", LastName: "Zulu"})
+				return err
+			},
+			failures:  1,
+			wantCalls: 1,
+			wantErr:   true,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			var calls atomic.Int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if calls.Add(1) <= tc.failures {
+					w.Header().Set("Retry-After", "0")
+					w.WriteHeader(http.StatusServiceUnavailable)
+					return
+				}
+				w.Header().Set("Content-Type", "application/json")
+				if r.Method == http.MethodPost {
+					w.WriteHeader(http.StatusCreated)
+				}
+				w.Write([]byte(`{"id":1,"first_name":"Zed","last_name":"Zulu","version":1}`))
+			}))
+			defer srv.Close()
+
+			c := client.New(srv.URL, client.WithRetries(3, time.Millisecond))
+			err := tc.do(c)
+			if tc.wantErr {
+				var apiErr *client.APIError
+				require.ErrorAs(t, err, &apiErr)
+				require.Equal(t, http.StatusServiceUnavailable, apiErr.StatusCode)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tc.wantCalls, calls.Load())
+		})
+	}
+}
+
+func TestRetriesCanceled(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	c := client.New(srv.URL, client.WithRetries(3, time.Hour))
+	_, err := c.GetPublisher(ctx, 1)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestAPIKey(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "admin-key", r.Header.Get("X-API-Key"))
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	c := client.New(srv.URL, client.WithAPIKey("admin-key"))
+	_, err := c.GetPublisher(context.Background(), 1)
+	require.ErrorIs(t, err, client.ErrNotFound)
+}
+
+func TestPatchBook(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPatch, r.Method)
+		require.Equal(t, "/books/9781891830853", r.URL.Path)
+		require.Equal(t, "application/merge-patch+json", r.Header.Get("Content-Type"))
+		require.Equal(t, `"2"`, r.Header.Get("If-Match"))
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		require.JSONEq(t, `{"price": 0, "image_url": null}`, string(body))
+		w.Write([]byte(`{"isbn13": "9781891830853", "version": 3}`))
+	}))
+	defer srv.Close()
+
+	c := client.New(srv.URL)
+	res, err := c.PatchBook(context.Background(), "9781891830853", 2, client.Patch{"price": 0, "image_url": nil})
+	require.NoError(t, err)
+	require.Equal(t, int64(3), res.Version)
+}
+
+func TestUploadBookCover(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, "/books/9781891830853/cover", r.URL.Path)
+		f, fh, err := r.FormFile("cover")
+		require.NoError(t, err)
+		defer f.Close()
+		require.Equal(t, "cover.png", fh.Filename)
+		data, err := io.ReadAll(f)
+		require.NoError(t, err)
+		require.Equal(t, "image", string(data))
+		w.Write([]byte(`{"isbn13": "9781891830853", "cover": {"url": "/covers/a.png"}}`))
+	}))
+	defer srv.Close()
+
+	c := client.New(srv.URL)
+	res, err := c.UploadBookCover(context.Background(), "9781891830853", "cover.png", strings.NewReader("image"))
+	require.NoError(t, err)
+	require.Equal(t, "/covers/a.png", res.Cover.Url)
+}
+
+func TestDeletePublisher(t *testing.T) {
+	publisher := db.Publisher{PublisherID: util.RandomInt(1, 111), PublisherName: util.RandomString(24), Version: 1}
+
+	store := mockdb.NewMockStore(t)
+	store.EXPECT().GetPublisher(mock.Anything, mock.Anything).Return(publisher, nil)
+	store.EXPECT().ExecTx(mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, fn func(db.Querier) error) error {
+			return fn(store)
+		})
+	store.EXPECT().DeletePublisher(mock.Anything, mock.MatchedBy(func(arg db.DeletePublisherParams) bool {
+		return arg.PublisherID == publisher.PublisherID && arg.Version.Int64 == 1
+	})).Return(1, nil)
+
+	c := newTestClient(t, store)
+	err := c.DeletePublisher(context.Background(), publisher.PublisherID, 0)
+	require.NoError(t, err)
+}
+
+func TestAPIErrorIs(t *testing.T) {
+	require.True(t, errors.Is(&client.APIError{StatusCode: http.StatusNotFound}, client.ErrNotFound))
+	require.True(t, errors.Is(&client.APIError{StatusCode: http.StatusBadRequest, Message: "author not found"}, client.ErrNotFound))
+	require.False(t, errors.Is(&client.APIError{StatusCode: http.StatusBadRequest, Message: "invalid isbn"}, client.ErrNotFound))
+	require.True(t, errors.Is(&client.APIError{StatusCode: http.StatusPreconditionFailed}, client.ErrVersionMismatch))
+}
+
+func randomBook(t *testing.T) db.Book {
+	isbn := util.NewISBN(util.RandomISBN13())
+	return db.Book{
+		BookID:          util.RandomInt(1, 111),
+		Title:           util.RandomString(24),
+		Isbn13:          sql.NullString{String: isbn.ISBN13, Valid: true},
+		Isbn10:          sql.NullString{String: isbn.ISBN10, Valid: true},
+		Price:           float64(util.RandomFloat(10.0, 1500.0)),
+		PublicationYear: util.RandomInt(1000, 9999),
+		Version:         1,
+	}
+}
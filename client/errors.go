@@ -0,0 +1,67 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+var (
+	// ErrNotFound matches the errors of records that do not exist or are
+	// deleted
+	ErrNotFound = errors.New("not found")
+	// ErrVersionMismatch matches the errors of writes to a record that was
+	// changed since it was read
+	ErrVersionMismatch = errors.New("record was changed")
+)
+
+// APIError is an answer of the API with an unexpected status
+type APIError struct {
+	StatusCode int
+	Message    string // the error member of the body, or the body itself
+}
+
+func (e *APIError) Error() string {
+	if len(e.Message) == 0 {
+		return fmt.Sprintf("api: %d %s", e.StatusCode, http.StatusText(e.StatusCode))
+	}
+
+	return fmt.Sprintf("api: %d %s", e.StatusCode, e.Message)
+}
+
+// Is matches ErrNotFound and ErrVersionMismatch. The API answers 400 for
+// some records that are not found, these are told apart by the message.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound ||
+			(e.StatusCode == http.StatusBadRequest && strings.HasSuffix(e.Message, "not found"))
+	case ErrVersionMismatch:
+		return e.StatusCode == http.StatusPreconditionFailed
+	}
+
+	return false
+}
+
+func newAPIError(res *http.Response) *APIError {
+	e := &APIError{StatusCode: res.StatusCode}
+
+	data, err := io.ReadAll(io.LimitReader(res.Body, 1<<16))
+	if err != nil || len(data) == 0 {
+		return e
+	}
+
+	var body struct {
+		Error string `json:"error"`
+	}
+	if json.Unmarshal(data, &body) == nil && len(body.Error) > 0 {
+		e.Message = body.Error
+	} else {
+		e.Message = strings.TrimSpace(string(data))
+	}
+
+	return e
+}
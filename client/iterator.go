@@ -0,0 +1,67 @@
+package client
+
+import "context"
+
+// Page is a page of a list, page numbers start at 1 and NextPage is 0 on
+// the last page
+type Page[T any] struct {
+	CurrentPage int32 `json:"current_page"`
+	PerPage     int32 `json:"per_page"`
+	TotalPages  int32 `json:"total_pages"`
+	NextPage    int32 `json:"next_page"`
+	PrevPage    int32 `json:"prev_page"`
+	TotalItems  int32 `json:"total_items"`
+	Items       []T   `json:"items"`
+}
+
+// Iterator walks the items of a list, fetching the next page when the
+// current one is used up. Records changed while iterating may be skipped or
+// seen twice, as pages are fetched by offset.
+type Iterator[T any] struct {
+	ctx   context.Context
+	fetch func(ctx context.Context, page int32) (*Page[T], error)
+	page  *Page[T]
+	next  int32
+	i     int
+	err   error
+}
+
+func newIterator[T any](ctx context.Context, first int32, fetch func(ctx context.Context, page int32) (*Page[T], error)) *Iterator[T] {
+	if first < 1 {
+		first = 1
+	}
+
+	return &Iterator[T]{ctx: ctx, fetch: fetch, next: first, i: -1}
+}
+
+// Next advances to the next item, it returns false at the end of the list
+// or on an error, which Err then returns
+func (it *Iterator[T]) Next() bool {
+	if it.err != nil {
+		return false
+	}
+
+	it.i++
+	for it.page == nil || it.i >= len(it.page.Items) {
+		if it.next == 0 {
+			return false
+		}
+		page, err := it.fetch(it.ctx, it.next)
+		if err != nil {
+			it.err = err
+			return false
+		}
+		it.page, it.next, it.i = page, page.NextPage, 0
+	}
+
+	return true
+}
+
+// Value is the current item
+func (it *Iterator[T]) Value() T {
+	return it.page.Items[it.i]
+}
+
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
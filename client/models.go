@@ -0,0 +1,140 @@
+package client
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type Book struct {
+	Title           string        `json:"title"`
+	ISBN13          string        `json:"isbn13"`
+	ISBN10          string        `json:"isbn10"`
+	Price           float64       `json:"price"`
+	PublicationYear int64         `json:"publication_year"`
+	ImageUrl        string        `json:"image_url"`
+	Cover           *Cover        `json:"cover,omitempty"`
+	Edition         string        `json:"edition"`
+	Language        string        `json:"language"`
+	Format          string        `json:"format"`
+	PageCount       int64         `json:"page_count"`
+	SeriesName      string        `json:"series_name"`
+	SeriesNumber    int64         `json:"series_number"`
+	Description     string        `json:"description"`
+	Authors         []string      `json:"authors"`
+	Contributors    []Contributor `json:"contributors"` // authors and other contributors, in credit order
+	Publisher       string        `json:"publisher"`
+	Subjects        []string      `json:"subjects"`
+	CreatedAt       time.Time     `json:"created_at"`
+	UpdatedAt       time.Time     `json:"updated_at"`
+	DeletedAt       *time.Time    `json:"deleted_at,omitempty"`
+	Version         int64         `json:"version"` // pass to writes of the book
+}
+
+type Contributor struct {
+	Name string `json:"name"`
+	Role string `json:"role"` // author, illustrator, translator, editor, colorist or letterer
+}
+
+type Cover struct {
+	Url        string           `json:"url"`
+	Thumbnails []CoverThumbnail `json:"thumbnails"`
+}
+
+type CoverThumbnail struct {
+	Width int    `json:"width"`
+	Url   string `json:"url"`
+}
+
+type Author struct {
+	ID         int64      `json:"id"`
+	FirstName  string     `json:"first_name"`
+	LastName   string     `json:"last_name"`
+	MiddleName string     `json:"middle_name"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+	DeletedAt  *time.Time `json:"deleted_at,omitempty"`
+	Version    int64      `json:"version"`
+}
+
+type Publisher struct {
+	ID            int64      `json:"id"`
+	PublisherName string     `json:"publisher_name"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+	DeletedAt     *time.Time `json:"deleted_at,omitempty"`
+	Version       int64      `json:"version"`
+}
+
+// Change is the latest change to a book, author or publisher
+type Change struct {
+	Type      string    `json:"type"`           // book, author or publisher
+	ID        string    `json:"id"`             // ID of the book, author or publisher, kept when a book's ISBNs change
+	ISBN      string    `json:"isbn,omitempty"` // current ISBN-13 of a book, or its ISBN-10 when it has none
+	Version   int64     `json:"version"`
+	UpdatedAt time.Time `json:"updated_at"`
+	Deleted   bool      `json:"deleted"`
+}
+
+type ChangeFeed struct {
+	Items   []Change  `json:"items"`
+	Since   time.Time `json:"since"`    // pass as since to read the changes that follow
+	HasMore bool      `json:"has_more"` // more changes are ready to be read
+}
+
+type CartItem struct {
+	Title    string  `json:"title"`
+	ISBN13   string  `json:"isbn13"`
+	ISBN10   string  `json:"isbn10"`
+	ImageUrl string  `json:"image_url"`
+	Price    float64 `json:"price"`
+	Quantity int64   `json:"quantity"`
+	Subtotal float64 `json:"subtotal"`
+}
+
+type Cart struct {
+	Items     []CartItem `json:"items"`
+	ItemCount int64      `json:"item_count"`
+	Total     float64    `json:"total"`
+}
+
+type OrderItem struct {
+	Title    string  `json:"title"`
+	ISBN13   string  `json:"isbn13"`
+	ISBN10   string  `json:"isbn10"`
+	Price    float64 `json:"price"`
+	Quantity int64   `json:"quantity"`
+	Subtotal float64 `json:"subtotal"`
+}
+
+type Order struct {
+	OrderID         int64       `json:"order_id"`
+	CustomerName    string      `json:"customer_name"`
+	Email           string      `json:"email"`
+	ShippingAddress string      `json:"shipping_address"`
+	Status          string      `json:"status"` // pending, paid, shipped, delivered or cancelled
+	Total           float64     `json:"total"`
+	PaymentRef      string      `json:"payment_ref"`
+	CreatedAt       time.Time   `json:"created_at"`
+	Items           []OrderItem `json:"items"`
+}
+
+type Webhook struct {
+	ID        int64     `json:"id"`
+	Url       string    `json:"url"`
+	Events    []string  `json:"events"`
+	Secret    string    `json:"secret,omitempty"` // only returned when the webhook is created
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type WebhookDelivery struct {
+	ID            int64           `json:"id"`
+	WebhookID     int64           `json:"webhook_id"`
+	EventID       string          `json:"event_id"`
+	Event         string          `json:"event"`
+	Status        string          `json:"status"` // pending, delivered or dead
+	Attempts      int64           `json:"attempts"`
+	NextAttemptAt *time.Time      `json:"next_attempt_at,omitempty"` // set on pending deliveries
+	LastError     string          `json:"last_error,omitempty"`
+	Payload       json.RawMessage `json:"payload"`
+	CreatedAt     time.Time       `json:"created_at"`
+}
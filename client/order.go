@@ -0,0 +1,125 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+type CheckoutParams struct {
+	CustomerName    string `json:"customer_name"`
+	Email           string `json:"email"`
+	ShippingAddress string `json:"shipping_address"`
+}
+
+// Checkout turns the cart into an order and pays for it, it is not retried.
+// See GetCart for the cookie that keeps the cart.
+func (c *Client) Checkout(ctx context.Context, arg CheckoutParams) (*Order, error) {
+	var res Order
+	err := c.do(ctx, request{
+		method:  http.MethodPost,
+		path:    "/orders",
+		body:    arg,
+		wantOK:  http.StatusCreated,
+		outBody: &res,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+func (c *Client) GetOrder(ctx context.Context, id int64) (*Order, error) {
+	var res Order
+	err := c.do(ctx, request{
+		method:  http.MethodGet,
+		path:    "/orders/" + strconv.FormatInt(id, 10),
+		wantOK:  http.StatusOK,
+		outBody: &res,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// ListOrdersParams filters the orders, zero values are left out
+type ListOrdersParams struct {
+	Status  string // pending, paid, shipped, delivered or cancelled
+	Page    int32  // 1 by default
+	PerPage int32  // 5 by default, at most 30
+}
+
+func (p ListOrdersParams) values() url.Values {
+	q := queryValues{url.Values{}}
+	q.string("status", p.Status)
+	q.int("page", p.Page)
+	q.int("per_page", p.PerPage)
+
+	return q.Values
+}
+
+// ListOrders lists the orders of the cart's owner
+func (c *Client) ListOrders(ctx context.Context, arg ListOrdersParams) (*Page[Order], error) {
+	return c.listOrders(ctx, "/orders", arg)
+}
+
+// IterOrders walks all the pages of orders from arg.Page on
+func (c *Client) IterOrders(ctx context.Context, arg ListOrdersParams) *Iterator[Order] {
+	return newIterator(ctx, arg.Page, func(ctx context.Context, page int32) (*Page[Order], error) {
+		arg.Page = page
+		return c.ListOrders(ctx, arg)
+	})
+}
+
+// ListAllOrders lists the orders of every customer, admins only
+func (c *Client) ListAllOrders(ctx context.Context, arg ListOrdersParams) (*Page[Order], error) {
+	return c.listOrders(ctx, "/admin/orders", arg)
+}
+
+// IterAllOrders walks all the pages of orders of every customer from
+// arg.Page on, admins only
+func (c *Client) IterAllOrders(ctx context.Context, arg ListOrdersParams) *Iterator[Order] {
+	return newIterator(ctx, arg.Page, func(ctx context.Context, page int32) (*Page[Order], error) {
+		arg.Page = page
+		return c.ListAllOrders(ctx, arg)
+	})
+}
+
+func (c *Client) listOrders(ctx context.Context, path string, arg ListOrdersParams) (*Page[Order], error) {
+	var res Page[Order]
+	err := c.do(ctx, request{
+		method:  http.MethodGet,
+		path:    path,
+		query:   arg.values(),
+		wantOK:  http.StatusOK,
+		outBody: &res,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// UpdateOrderStatus moves an order along its lifecycle, admins only
+func (c *Client) UpdateOrderStatus(ctx context.Context, id int64, status string) (*Order, error) {
+	var res Order
+	err := c.do(ctx, request{
+		method: http.MethodPut,
+		path:   "/admin/orders/" + strconv.FormatInt(id, 10) + "/status",
+		body: struct {
+			Status string `json:"status"`
+		}{status},
+		wantOK:  http.StatusOK,
+		outBody: &res,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
@@ -0,0 +1,157 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+type CreatePublisherParams struct {
+	PublisherName string `json:"publisher_name"`
+}
+
+// CreatePublisher adds a publisher, it is not retried
+func (c *Client) CreatePublisher(ctx context.Context, arg CreatePublisherParams) (*Publisher, error) {
+	var res Publisher
+	err := c.do(ctx, request{
+		method:  http.MethodPost,
+		path:    "/publishers",
+		body:    arg,
+		wantOK:  http.StatusCreated,
+		outBody: &res,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+func (c *Client) GetPublisher(ctx context.Context, id int64) (*Publisher, error) {
+	var res Publisher
+	err := c.do(ctx, request{
+		method:  http.MethodGet,
+		path:    "/publishers/" + strconv.FormatInt(id, 10),
+		wantOK:  http.StatusOK,
+		outBody: &res,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// ListPublishersParams filters the publishers, zero values are left out
+type ListPublishersParams struct {
+	UpdatedSince   time.Time
+	IncludeDeleted bool
+	Page           int32 // 1 by default
+	PerPage        int32 // 5 by default, at most 30
+}
+
+func (p ListPublishersParams) values() url.Values {
+	q := queryValues{url.Values{}}
+	q.time("updated_since", p.UpdatedSince)
+	q.bool("include_deleted", p.IncludeDeleted)
+	q.int("page", p.Page)
+	q.int("per_page", p.PerPage)
+
+	return q.Values
+}
+
+func (c *Client) ListPublishers(ctx context.Context, arg ListPublishersParams) (*Page[Publisher], error) {
+	var res Page[Publisher]
+	err := c.do(ctx, request{
+		method:  http.MethodGet,
+		path:    "/publishers",
+		query:   arg.values(),
+		wantOK:  http.StatusOK,
+		outBody: &res,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// IterPublishers walks all the pages of publishers from arg.Page on
+func (c *Client) IterPublishers(ctx context.Context, arg ListPublishersParams) *Iterator[Publisher] {
+	return newIterator(ctx, arg.Page, func(ctx context.Context, page int32) (*Page[Publisher], error) {
+		arg.Page = page
+		return c.ListPublishers(ctx, arg)
+	})
+}
+
+type UpdatePublisherParams struct {
+	PublisherName string `json:"publisher_name,omitempty"`
+}
+
+// UpdatePublisher changes the publisher at version, see Publisher.Version.
+// It fails with ErrVersionMismatch when the publisher was changed since.
+func (c *Client) UpdatePublisher(ctx context.Context, id int64, version int64, arg UpdatePublisherParams) (*Publisher, error) {
+	var res Publisher
+	err := c.do(ctx, request{
+		method:  http.MethodPut,
+		path:    "/publishers/" + strconv.FormatInt(id, 10),
+		body:    arg,
+		header:  ifMatch(version),
+		wantOK:  http.StatusOK,
+		outBody: &res,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// DeletePublisher deletes the publisher at version, see Publisher.Version
+func (c *Client) DeletePublisher(ctx context.Context, id int64, version int64) error {
+	return c.do(ctx, request{
+		method: http.MethodDelete,
+		path:   "/publishers/" + strconv.FormatInt(id, 10),
+		header: ifMatch(version),
+		wantOK: http.StatusNoContent,
+	})
+}
+
+// PatchPublisher applies a merge patch to the publisher at version, see
+// Publisher.Version. It fails with ErrVersionMismatch when the publisher was
+// changed since.
+func (c *Client) PatchPublisher(ctx context.Context, id int64, version int64, patch Patch) (*Publisher, error) {
+	var res Publisher
+	err := c.do(ctx, request{
+		method:      http.MethodPatch,
+		path:        "/publishers/" + strconv.FormatInt(id, 10),
+		body:        patch,
+		contentType: mergePatchType,
+		header:      ifMatch(version),
+		wantOK:      http.StatusOK,
+		outBody:     &res,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// RestorePublisher brings back a deleted publisher
+func (c *Client) RestorePublisher(ctx context.Context, id int64) (*Publisher, error) {
+	var res Publisher
+	err := c.do(ctx, request{
+		method:  http.MethodPost,
+		path:    "/publishers/" + strconv.FormatInt(id, 10) + "/restore",
+		wantOK:  http.StatusOK,
+		outBody: &res,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
@@ -0,0 +1,43 @@
+package client
+
+import (
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// queryValues sets the parameters that are not zero, so the API applies its
+// defaults to the others
+type queryValues struct {
+	url.Values
+}
+
+func (q queryValues) string(key, v string) {
+	if len(v) > 0 {
+		q.Set(key, v)
+	}
+}
+
+func (q queryValues) int(key string, v int32) {
+	if v != 0 {
+		q.Set(key, strconv.FormatInt(int64(v), 10))
+	}
+}
+
+func (q queryValues) float(key string, v *float64) {
+	if v != nil {
+		q.Set(key, strconv.FormatFloat(*v, 'f', -1, 64))
+	}
+}
+
+func (q queryValues) bool(key string, v bool) {
+	if v {
+		q.Set(key, "true")
+	}
+}
+
+func (q queryValues) time(key string, v time.Time) {
+	if !v.IsZero() {
+		q.Set(key, v.Format(time.RFC3339Nano))
+	}
+}
@@ -0,0 +1,117 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+type CreateWebhookParams struct {
+	Url    string   `json:"url"`              // a public http or https URL
+	Secret string   `json:"secret,omitempty"` // signs the deliveries, generated when left out
+	Events []string `json:"events"`           // book.created, book.updated, book.deleted, book.restored or price.changed
+}
+
+// CreateWebhook registers a webhook, it is not retried. The secret is only
+// returned here. The webhook endpoints are for admins only, see WithAPIKey.
+func (c *Client) CreateWebhook(ctx context.Context, arg CreateWebhookParams) (*Webhook, error) {
+	var res Webhook
+	err := c.do(ctx, request{
+		method:  http.MethodPost,
+		path:    "/webhooks",
+		body:    arg,
+		wantOK:  http.StatusCreated,
+		outBody: &res,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+func (c *Client) ListWebhooks(ctx context.Context) ([]Webhook, error) {
+	var res []Webhook
+	err := c.do(ctx, request{
+		method:  http.MethodGet,
+		path:    "/webhooks",
+		wantOK:  http.StatusOK,
+		outBody: &res,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (c *Client) DeleteWebhook(ctx context.Context, id int64) error {
+	return c.do(ctx, request{
+		method: http.MethodDelete,
+		path:   "/webhooks/" + strconv.FormatInt(id, 10),
+		wantOK: http.StatusNoContent,
+	})
+}
+
+// ListWebhookDeliveriesParams filters the deliveries, zero values are left
+// out
+type ListWebhookDeliveriesParams struct {
+	WebhookID int64
+	Status    string // pending, delivered or dead
+	Page      int32  // 1 by default
+	PerPage   int32  // 5 by default, at most 100
+}
+
+func (p ListWebhookDeliveriesParams) values() url.Values {
+	q := queryValues{url.Values{}}
+	if p.WebhookID != 0 {
+		q.Set("webhook_id", strconv.FormatInt(p.WebhookID, 10))
+	}
+	q.string("status", p.Status)
+	q.int("page", p.Page)
+	q.int("per_page", p.PerPage)
+
+	return q.Values
+}
+
+// ListWebhookDeliveries lists deliveries, newest first
+func (c *Client) ListWebhookDeliveries(ctx context.Context, arg ListWebhookDeliveriesParams) (*Page[WebhookDelivery], error) {
+	var res Page[WebhookDelivery]
+	err := c.do(ctx, request{
+		method:  http.MethodGet,
+		path:    "/webhooks/deliveries",
+		query:   arg.values(),
+		wantOK:  http.StatusOK,
+		outBody: &res,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// IterWebhookDeliveries walks all the pages of deliveries from arg.Page on
+func (c *Client) IterWebhookDeliveries(ctx context.Context, arg ListWebhookDeliveriesParams) *Iterator[WebhookDelivery] {
+	return newIterator(ctx, arg.Page, func(ctx context.Context, page int32) (*Page[WebhookDelivery], error) {
+		arg.Page = page
+		return c.ListWebhookDeliveries(ctx, arg)
+	})
+}
+
+// RedeliverWebhookDelivery sends a delivery again, e.g. a dead one
+func (c *Client) RedeliverWebhookDelivery(ctx context.Context, id int64) (*WebhookDelivery, error) {
+	var res WebhookDelivery
+	err := c.do(ctx, request{
+		method:  http.MethodPost,
+		path:    "/webhooks/deliveries/" + strconv.FormatInt(id, 10) + "/redeliver",
+		wantOK:  http.StatusAccepted,
+		outBody: &res,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
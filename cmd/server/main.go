@@ -3,18 +3,25 @@ package main
 import (
 	"context"
 	"database/sql"
+	"flag"
 	"fmt"
-	"log"
+	"log/slog"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/atsuyaourt/xyz-books/internal"
 	db "github.com/atsuyaourt/xyz-books/internal/db/sqlc"
 	docs "github.com/atsuyaourt/xyz-books/internal/docs/api"
+	"github.com/atsuyaourt/xyz-books/internal/logging"
+	"github.com/atsuyaourt/xyz-books/internal/metrics"
+	"github.com/atsuyaourt/xyz-books/internal/tracing"
 	"github.com/atsuyaourt/xyz-books/internal/util"
+	_ "github.com/golang-migrate/migrate/v4/database/pgx/v5"
 	_ "github.com/golang-migrate/migrate/v4/database/sqlite"
 	_ "github.com/golang-migrate/migrate/v4/source/file"
+	_ "github.com/jackc/pgx/v5/stdlib"
 	"golang.org/x/sync/errgroup"
 	_ "modernc.org/sqlite"
 )
@@ -25,56 +32,161 @@ var interruptSignals = []os.Signal{
 	syscall.SIGINT,
 }
 
+const defaultPurgeDays = 30
+
 // XYZBooksAPI
 //
-//	@title			XYZ Books API
-//	@version		1.0
-//	@description	XYZ Books API
-//	@contact.name	Emilio Gozo
-//	@contact.email	emiliogozo@proton.me
+//	@title						XYZ Books API
+//	@version					1.0
+//	@description				XYZ Books API
+//	@contact.name				Emilio Gozo
+//	@contact.email				emiliogozo@proton.me
+//
+//	@securityDefinitions.apikey	AdminKey
+//	@in							header
+//	@name						X-API-Key
 func main() {
 	config, err := util.LoadConfig(".")
 	if err != nil {
-		log.Fatalf("cannot load config: %s", err)
+		fatal("cannot load config", err)
+	}
+
+	logger, err := logging.New(os.Stderr, config.LogLevel, config.LogFormat)
+	if err != nil {
+		fatal("cannot set up logging", err)
 	}
+	// the log package writes through it too
+	slog.SetDefault(logger)
+
 	docs.SwaggerInfo.BasePath = config.APIBasePath
 
+	if len(os.Args) > 1 && os.Args[1] == "purge" {
+		runPurge(config, os.Args[2:])
+		return
+	}
+
 	ctx, stop := signal.NotifyContext(context.Background(), interruptSignals...)
 	defer stop()
 
+	shutdownTracing, err := tracing.Setup(ctx, config.TraceExporter)
+	if err != nil {
+		fatal("cannot set up tracing", err)
+	}
+	defer func() {
+		if err := shutdownTracing(context.Background()); err != nil {
+			slog.Error("cannot flush spans", slog.Any("error", err))
+		}
+	}()
+
+	store, err := openStore(config)
+	if err != nil {
+		fatal("cannot open store", err)
+	}
+
+	g, ctx := errgroup.WithContext(ctx)
+	runGinServer(ctx, g, config, store)
+
+	err = g.Wait()
+	if err != nil {
+		fatal("error from wait group", err)
+	}
+}
+
+// openStore connects to the database selected by DB_DRIVER, either a
+// SQLite file (created if missing, with separate read and write pools)
+// or a PostgreSQL URL, and migrates it.
+func openStore(config util.Config) (db.Store, error) {
+	isolation, err := db.ParseIsolationLevel(config.DBTxIsolation)
+	if err != nil {
+		return nil, fmt.Errorf("invalid DB_TX_ISOLATION: %w", err)
+	}
+	txOptions := db.WithTxOptions(db.TxOptions{
+		MaxRetries: config.DBTxMaxRetries,
+		RetryDelay: config.DBTxRetryDelay,
+		Isolation:  isolation,
+	})
+
+	if config.DBDriver == util.DBDriverPostgres {
+		conn, err := sql.Open("pgx", config.DBSource)
+		if err != nil {
+			return nil, fmt.Errorf("cannot connect to db: %w", err)
+		}
+
+		dbSource, err := util.PostgresMigrationSource(config.DBSource)
+		if err != nil {
+			return nil, fmt.Errorf("migration error: %w", err)
+		}
+		err = util.DBMigrationUp(config.MigrationSrc, dbSource)
+		if err != nil {
+			return nil, fmt.Errorf("migration error: %w", err)
+		}
+
+		metrics.RegisterDB("postgres", conn)
+		return db.NewPostgresStore(conn, txOptions), nil
+	}
+
 	_, err = os.Stat(config.DBSource)
 	if os.IsNotExist(err) {
 		f, _ := os.Create(config.DBSource)
 		f.Close()
 	}
 
-	conn, err := sql.Open(config.DBDriver, config.DBSource)
+	conn, err := util.OpenSQLite(config)
 	if err != nil {
-		log.Fatalf("cannot connect to db: %s", err)
+		return nil, fmt.Errorf("cannot connect to db: %w", err)
 	}
 
 	dbSource := fmt.Sprintf("%s://%s?query", config.DBDriver, config.DBSource)
 	err = util.DBMigrationUp(config.MigrationSrc, dbSource)
 	if err != nil {
-		log.Fatalf("migration error: %s", err)
+		return nil, fmt.Errorf("migration error: %w", err)
 	}
 
-	store := db.NewStore(conn)
+	metrics.RegisterDB("write", conn.Write)
+	metrics.RegisterDB("read", conn.Read)
+	return db.NewStoreWithReader(conn.Write, conn.Read, txOptions), nil
+}
 
-	g, ctx := errgroup.WithContext(ctx)
-	runGinServer(ctx, g, config, store)
+// runPurge permanently removes the books, authors and publishers that were
+// deleted more than -days days ago
+func runPurge(config util.Config, args []string) {
+	fs := flag.NewFlagSet("purge", flag.ExitOnError)
+	days := fs.Int("days", defaultPurgeDays, "purge records deleted more than this many days ago")
+	fs.Parse(args)
 
-	err = g.Wait()
+	if *days < 0 {
+		fatal("invalid number of days", fmt.Errorf("%d is negative", *days))
+	}
+
+	store, err := openStore(config)
+	if err != nil {
+		fatal("cannot open store", err)
+	}
+
+	deletedBefore := time.Now().AddDate(0, 0, -*days)
+	res, err := store.PurgeTx(context.Background(), deletedBefore)
 	if err != nil {
-		log.Fatalf("error from wait group: %s", err)
+		fatal("cannot purge deleted records", err)
 	}
+
+	slog.Info("purged deleted records",
+		slog.Time("deleted_before", deletedBefore),
+		slog.Int64("books", res.Books),
+		slog.Int64("authors", res.Authors),
+		slog.Int64("publishers", res.Publishers))
 }
 
 func runGinServer(ctx context.Context, g *errgroup.Group, config util.Config, store db.Store) {
 	server, err := internal.NewServer(config, store)
 	if err != nil {
-		log.Fatalf("cannot create server: %s", err)
+		fatal("cannot create server", err)
 	}
 
 	server.Start(ctx, g)
 }
+
+// fatal logs err and exits
+func fatal(msg string, err error) {
+	slog.Error(msg, slog.Any("error", err))
+	os.Exit(1)
+}
@@ -5,4 +5,6 @@ DATE_STR="$(date +%Y%m%d)"
 
 # yarn build
 
-docker build -t ${BASE_TAG} -t ${BASE_TAG}:"${DATE_STR}" .
+docker build -t ${BASE_TAG} -t ${BASE_TAG}:"${DATE_STR}" \
+    --build-arg COMMIT="$(git rev-parse HEAD)" \
+    --build-arg BUILD_TIME="$(date -u +%Y-%m-%dT%H:%M:%SZ)" .
@@ -0,0 +1,123 @@
+// Package auth tells the clients and admins of the API apart by the API
+// keys of the config. Other clients are anonymous.
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// APIKeyHeader carries the API key of a client
+const APIKeyHeader = "X-API-Key"
+
+// adminKey is the gin context key set on the requests of admins
+const adminKey = "auth.admin"
+
+// userIDKey is the gin context key of the ID of the client of a request
+const userIDKey = "auth.user_id"
+
+// ErrAdminRequired is returned for what only admins may do
+var ErrAdminRequired = errors.New("admin API key required")
+
+// adminContextKey marks the contexts of the requests of admins, for the
+// code that only gets a context.Context
+type adminContextKey struct{}
+
+// NewContext returns a copy of ctx marked as made by an admin or not
+func NewContext(ctx context.Context, admin bool) context.Context {
+	return context.WithValue(ctx, adminContextKey{}, admin)
+}
+
+// FromContext reports whether ctx was marked as made by an admin
+func FromContext(ctx context.Context) bool {
+	admin, _ := ctx.Value(adminContextKey{}).(bool)
+	return admin
+}
+
+// Keys is a set of API keys, compared in constant time
+type Keys struct {
+	hashes [][sha256.Size]byte
+}
+
+func NewKeys(keys []string) Keys {
+	var k Keys
+	for _, key := range keys {
+		if len(key) > 0 {
+			k.hashes = append(k.hashes, sha256.Sum256([]byte(key)))
+		}
+	}
+
+	return k
+}
+
+// Contains reports whether key is one of the keys. Every key is compared,
+// hashed, so that the time taken tells nothing about them.
+func (k Keys) Contains(key string) bool {
+	if len(key) == 0 {
+		return false
+	}
+
+	hash := sha256.Sum256([]byte(key))
+	found := 0
+	for i := range k.hashes {
+		found |= subtle.ConstantTimeCompare(hash[:], k.hashes[i][:])
+	}
+
+	return found == 1
+}
+
+// ID returns the ID of the client sending key, or false when key is not one
+// of the keys. The ID is made from the hash of the key, so that it can be
+// stored without giving the key away.
+func (k Keys) ID(key string) (string, bool) {
+	if !k.Contains(key) {
+		return "", false
+	}
+
+	hash := sha256.Sum256([]byte(key))
+	return "key:" + hex.EncodeToString(hash[:16]), true
+}
+
+// Middleware signs in the requests whose API key is one of clientKeys, and
+// marks those whose key is one of adminKeys
+func Middleware(adminKeys, clientKeys Keys) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		key := ctx.GetHeader(APIKeyHeader)
+		if id, ok := clientKeys.ID(key); ok {
+			ctx.Set(userIDKey, id)
+		}
+		if adminKeys.Contains(key) {
+			ctx.Set(adminKey, true)
+			ctx.Request = ctx.Request.WithContext(NewContext(ctx.Request.Context(), true))
+		}
+	}
+}
+
+// UserID returns the ID of the signed-in client of the request, empty for
+// anonymous requests
+func UserID(ctx *gin.Context) string {
+	return ctx.GetString(userIDKey)
+}
+
+// IsAdmin reports whether the request was made with an admin API key
+func IsAdmin(ctx *gin.Context) bool {
+	return ctx.GetBool(adminKey)
+}
+
+// RequireAdmin answers the requests not made by admins with 401
+func RequireAdmin() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if !IsAdmin(ctx) {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": ErrAdminRequired.Error()})
+			return
+		}
+
+		ctx.Next()
+	}
+}
@@ -0,0 +1,64 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeys(t *testing.T) {
+	keys := NewKeys([]string{"key-1", "", "key-2"})
+	require.True(t, keys.Contains("key-1"))
+	require.True(t, keys.Contains("key-2"))
+	require.False(t, keys.Contains("key-3"))
+	require.False(t, keys.Contains(""))
+
+	require.False(t, NewKeys(nil).Contains("key-1"))
+
+	id1, ok := keys.ID("key-1")
+	require.True(t, ok)
+	require.NotContains(t, id1, "key-1")
+	id2, ok := keys.ID("key-2")
+	require.True(t, ok)
+	require.NotEqual(t, id1, id2)
+	_, ok = keys.ID("key-3")
+	require.False(t, ok)
+}
+
+func TestRequireAdmin(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(Middleware(NewKeys([]string{"admin-key"}), NewKeys([]string{"admin-key", "client-key"})))
+	router.GET("/admin", RequireAdmin(), func(ctx *gin.Context) {
+		require.True(t, FromContext(ctx.Request.Context()))
+		ctx.Status(http.StatusNoContent)
+	})
+
+	testCases := []struct {
+		name   string
+		key    string
+		status int
+	}{
+		{name: "Admin", key: "admin-key", status: http.StatusNoContent},
+		{name: "ClientKey", key: "client-key", status: http.StatusUnauthorized},
+		{name: "OtherKey", key: "other-key", status: http.StatusUnauthorized},
+		{name: "NoKey", status: http.StatusUnauthorized},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodGet, "/admin", nil)
+			if len(tc.key) > 0 {
+				request.Header.Set(APIKeyHeader, tc.key)
+			}
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, request)
+			require.Equal(t, tc.status, recorder.Code)
+		})
+	}
+}
@@ -0,0 +1,54 @@
+// Package buildinfo describes the build of the server. Commit and BuildTime
+// are set when building, e.g.
+//
+//	go build -ldflags "-X github.com/atsuyaourt/xyz-books/internal/buildinfo.Commit=$(git rev-parse HEAD) \
+//		-X github.com/atsuyaourt/xyz-books/internal/buildinfo.BuildTime=$(date -u +%Y-%m-%dT%H:%M:%SZ)" ./cmd/server
+package buildinfo
+
+import (
+	"runtime"
+	"runtime/debug"
+)
+
+const unknown = "unknown"
+
+// Set with -ldflags -X
+var (
+	Commit    string
+	BuildTime string
+)
+
+// Info is the build of the server and the version of the schema it expects
+type Info struct {
+	Commit        string `json:"commit"`
+	BuildTime     string `json:"build_time"`
+	GoVersion     string `json:"go_version"`
+	SchemaVersion uint   `json:"schema_version"`
+}
+
+// Get describes the build. When Commit was not set it falls back to the
+// revision stamped by the go command, unset fields are unknown.
+func Get(schemaVersion uint) Info {
+	info := Info{
+		Commit:        Commit,
+		BuildTime:     BuildTime,
+		GoVersion:     runtime.Version(),
+		SchemaVersion: schemaVersion,
+	}
+
+	if bi, ok := debug.ReadBuildInfo(); ok && len(info.Commit) == 0 {
+		for _, s := range bi.Settings {
+			if s.Key == "vcs.revision" {
+				info.Commit = s.Value
+			}
+		}
+	}
+	if len(info.Commit) == 0 {
+		info.Commit = unknown
+	}
+	if len(info.BuildTime) == 0 {
+		info.BuildTime = unknown
+	}
+
+	return info
+}
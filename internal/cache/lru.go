@@ -0,0 +1,141 @@
+// Package cache keeps recently read values in memory.
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// Stats counts the lookups of a cache since it was made
+type Stats struct {
+	Hits      uint64 `json:"hits"`
+	Misses    uint64 `json:"misses"`
+	Evictions uint64 `json:"evictions"` // entries dropped to make room, expired entries are not counted
+	Size      int    `json:"size"`
+}
+
+type entry struct {
+	key       string
+	value     any
+	expiresAt time.Time
+}
+
+// LRU holds up to a number of entries for a time to live, dropping the least
+// recently used entry when full. It is safe for concurrent use.
+type LRU struct {
+	mu    sync.Mutex
+	size  int
+	ttl   time.Duration
+	items map[string]*list.Element
+	order *list.List // most recently used first
+	gen   uint64     // bumped by Purge
+	stats Stats
+	now   func() time.Time
+}
+
+// NewLRU makes a cache of size entries, which live for ttl, or until they
+// are dropped when ttl is zero
+func NewLRU(size int, ttl time.Duration) *LRU {
+	return &LRU{
+		size:  size,
+		ttl:   ttl,
+		items: make(map[string]*list.Element),
+		order: list.New(),
+		now:   time.Now,
+	}
+}
+
+// Get returns the value of key, if it is cached and has not expired
+func (c *LRU) Get(key string) (any, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.items[key]
+	if ok && c.ttl > 0 && c.now().After(el.Value.(*entry).expiresAt) {
+		c.remove(el)
+		ok = false
+	}
+	if !ok {
+		c.stats.Misses++
+		return nil, false
+	}
+
+	c.stats.Hits++
+	c.order.MoveToFront(el)
+	return el.Value.(*entry).value, true
+}
+
+// Set caches the value of key
+func (c *LRU) Set(key string, value any) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.set(key, value)
+}
+
+func (c *LRU) set(key string, value any) {
+	e := &entry{key: key, value: value, expiresAt: c.now().Add(c.ttl)}
+	if el, ok := c.items[key]; ok {
+		el.Value = e
+		c.order.MoveToFront(el)
+		return
+	}
+
+	c.items[key] = c.order.PushFront(e)
+	for c.order.Len() > c.size {
+		c.remove(c.order.Back())
+		c.stats.Evictions++
+	}
+}
+
+func (c *LRU) remove(el *list.Element) {
+	c.order.Remove(el)
+	delete(c.items, el.Value.(*entry).key)
+}
+
+// Load returns the value of key, loading and caching it when it is not
+// cached. Errors are not cached. A value loaded while the cache was purged is
+// returned but not cached, as it may have been read before the change that
+// purged the cache.
+func (c *LRU) Load(key string, load func() (any, error)) (any, error) {
+	if value, ok := c.Get(key); ok {
+		return value, nil
+	}
+
+	c.mu.Lock()
+	gen := c.gen
+	c.mu.Unlock()
+
+	value, err := load()
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.gen == gen {
+		c.set(key, value)
+	}
+
+	return value, nil
+}
+
+// Purge drops every entry
+func (c *LRU) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.items = make(map[string]*list.Element)
+	c.order.Init()
+	c.gen++
+}
+
+func (c *LRU) Stats() Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stats := c.stats
+	stats.Size = c.order.Len()
+	return stats
+}
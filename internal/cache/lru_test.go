@@ -0,0 +1,84 @@
+package cache
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLRU(t *testing.T) {
+	c := NewLRU(2, 0)
+
+	_, ok := c.Get("a")
+	require.False(t, ok)
+
+	c.Set("a", 1)
+	c.Set("b", 2)
+	value, ok := c.Get("a")
+	require.True(t, ok)
+	require.Equal(t, 1, value)
+
+	// b is the least recently used
+	c.Set("c", 3)
+	_, ok = c.Get("b")
+	require.False(t, ok)
+	_, ok = c.Get("a")
+	require.True(t, ok)
+
+	require.Equal(t, Stats{Hits: 2, Misses: 2, Evictions: 1, Size: 2}, c.Stats())
+
+	c.Purge()
+	_, ok = c.Get("a")
+	require.False(t, ok)
+	require.Equal(t, 0, c.Stats().Size)
+}
+
+func TestLRUExpiry(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := NewLRU(2, time.Minute)
+	c.now = func() time.Time { return now }
+
+	c.Set("a", 1)
+	now = now.Add(time.Minute)
+	_, ok := c.Get("a")
+	require.True(t, ok)
+
+	now = now.Add(time.Second)
+	_, ok = c.Get("a")
+	require.False(t, ok)
+	require.Equal(t, Stats{Hits: 1, Misses: 1}, c.Stats())
+}
+
+func TestLRULoad(t *testing.T) {
+	c := NewLRU(2, 0)
+	loads := 0
+	load := func() (any, error) {
+		loads++
+		return loads, nil
+	}
+
+	value, err := c.Load("a", load)
+	require.NoError(t, err)
+	require.Equal(t, 1, value)
+	value, err = c.Load("a", load)
+	require.NoError(t, err)
+	require.Equal(t, 1, value)
+
+	// errors are not cached
+	_, err = c.Load("b", func() (any, error) { return nil, errors.New("failed") })
+	require.Error(t, err)
+	_, ok := c.Get("b")
+	require.False(t, ok)
+
+	// a value loaded while the cache was purged is not cached
+	value, err = c.Load("c", func() (any, error) {
+		c.Purge()
+		return "stale", nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, "stale", value)
+	_, ok = c.Get("c")
+	require.False(t, ok)
+}
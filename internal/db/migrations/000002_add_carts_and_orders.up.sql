@@ -0,0 +1,43 @@
+-- Create carts table
+CREATE TABLE carts (
+    cart_id INTEGER PRIMARY KEY,
+    token TEXT NOT NULL UNIQUE,
+    user_id TEXT UNIQUE
+);
+
+-- Create cart_items table
+CREATE TABLE cart_items (
+    cart_id INTEGER NOT NULL,
+    book_id INTEGER NOT NULL,
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    PRIMARY KEY (cart_id, book_id),
+    FOREIGN KEY (cart_id) REFERENCES carts(cart_id) ON DELETE CASCADE,
+    FOREIGN KEY (book_id) REFERENCES books(book_id)
+);
+
+-- Create orders table
+CREATE TABLE orders (
+    order_id INTEGER PRIMARY KEY,
+    user_id TEXT,
+    customer_name TEXT NOT NULL,
+    email TEXT NOT NULL,
+    shipping_address TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'pending',
+    total REAL NOT NULL,
+    payment_ref TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create order_items table, title/ISBN/price are copied from books at checkout
+CREATE TABLE order_items (
+    order_item_id INTEGER PRIMARY KEY,
+    order_id INTEGER NOT NULL,
+    book_id INTEGER,
+    title TEXT NOT NULL,
+    isbn13 TEXT,
+    isbn10 TEXT,
+    price REAL NOT NULL,
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    FOREIGN KEY (order_id) REFERENCES orders(order_id) ON DELETE CASCADE,
+    FOREIGN KEY (book_id) REFERENCES books(book_id) ON DELETE SET NULL
+);
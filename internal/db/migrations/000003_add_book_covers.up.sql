@@ -0,0 +1,2 @@
+-- Key of the uploaded cover image in the blob store
+ALTER TABLE books ADD COLUMN cover_key TEXT;
@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS book_subject;
+DROP TABLE IF EXISTS subjects;
+
+ALTER TABLE books DROP COLUMN description;
+ALTER TABLE books DROP COLUMN series_number;
+ALTER TABLE books DROP COLUMN series_name;
+ALTER TABLE books DROP COLUMN page_count;
+ALTER TABLE books DROP COLUMN format;
+ALTER TABLE books DROP COLUMN language;
@@ -0,0 +1,22 @@
+-- Bibliographic metadata
+ALTER TABLE books ADD COLUMN language TEXT;
+ALTER TABLE books ADD COLUMN format TEXT CHECK (format IN ('hardcover', 'paperback', 'ebook', 'audiobook'));
+ALTER TABLE books ADD COLUMN page_count INTEGER CHECK (page_count > 0);
+ALTER TABLE books ADD COLUMN series_name TEXT;
+ALTER TABLE books ADD COLUMN series_number INTEGER;
+ALTER TABLE books ADD COLUMN description TEXT;
+
+-- Create subjects table
+CREATE TABLE subjects (
+    subject_id INTEGER PRIMARY KEY,
+    subject_name TEXT NOT NULL UNIQUE COLLATE NOCASE
+);
+
+-- Create book_subject junction table for many-to-many relationship
+CREATE TABLE book_subject (
+    book_id INTEGER NOT NULL,
+    subject_id INTEGER NOT NULL,
+    PRIMARY KEY (book_id, subject_id),
+    FOREIGN KEY (book_id) REFERENCES books(book_id) ON DELETE CASCADE,
+    FOREIGN KEY (subject_id) REFERENCES subjects(subject_id)
+);
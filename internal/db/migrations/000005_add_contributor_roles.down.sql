@@ -0,0 +1,13 @@
+CREATE TABLE author_book_old (
+    author_id INTEGER NOT NULL,
+    book_id INTEGER NOT NULL,
+    PRIMARY KEY (author_id, book_id),
+    FOREIGN KEY (author_id) REFERENCES authors(author_id),
+    FOREIGN KEY (book_id) REFERENCES books(book_id)
+);
+
+INSERT OR IGNORE INTO author_book_old (author_id, book_id)
+SELECT author_id, book_id FROM author_book ORDER BY book_id, position;
+
+DROP TABLE author_book;
+ALTER TABLE author_book_old RENAME TO author_book;
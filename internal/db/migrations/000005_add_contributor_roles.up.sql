@@ -0,0 +1,21 @@
+-- Rebuild author_book so that the same person can have several roles on a book
+CREATE TABLE author_book_new (
+    author_id INTEGER NOT NULL,
+    book_id INTEGER NOT NULL,
+    role TEXT NOT NULL DEFAULT 'author',
+    position INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (author_id, book_id, role),
+    FOREIGN KEY (author_id) REFERENCES authors(author_id),
+    FOREIGN KEY (book_id) REFERENCES books(book_id)
+);
+
+INSERT INTO author_book_new (author_id, book_id, role, position)
+SELECT
+    author_id,
+    book_id,
+    'author',
+    ROW_NUMBER() OVER (PARTITION BY book_id ORDER BY rowid) - 1
+FROM author_book;
+
+DROP TABLE author_book;
+ALTER TABLE author_book_new RENAME TO author_book;
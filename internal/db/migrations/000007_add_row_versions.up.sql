@@ -0,0 +1,4 @@
+-- Incremented on every change, used as the ETag of the record
+ALTER TABLE books ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE authors ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE publishers ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
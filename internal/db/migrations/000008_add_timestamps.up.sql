@@ -0,0 +1,22 @@
+-- Timestamps are UTC text with millisecond precision so that they sort as
+-- strings, existing rows are stamped with the time of the migration
+ALTER TABLE books ADD COLUMN created_at TIMESTAMP NOT NULL DEFAULT '1970-01-01 00:00:00.000';
+ALTER TABLE books ADD COLUMN updated_at TIMESTAMP NOT NULL DEFAULT '1970-01-01 00:00:00.000';
+ALTER TABLE authors ADD COLUMN created_at TIMESTAMP NOT NULL DEFAULT '1970-01-01 00:00:00.000';
+ALTER TABLE authors ADD COLUMN updated_at TIMESTAMP NOT NULL DEFAULT '1970-01-01 00:00:00.000';
+ALTER TABLE publishers ADD COLUMN created_at TIMESTAMP NOT NULL DEFAULT '1970-01-01 00:00:00.000';
+ALTER TABLE publishers ADD COLUMN updated_at TIMESTAMP NOT NULL DEFAULT '1970-01-01 00:00:00.000';
+
+UPDATE books SET
+  created_at = strftime('%Y-%m-%d %H:%M:%f', 'now'),
+  updated_at = strftime('%Y-%m-%d %H:%M:%f', 'now');
+UPDATE authors SET
+  created_at = strftime('%Y-%m-%d %H:%M:%f', 'now'),
+  updated_at = strftime('%Y-%m-%d %H:%M:%f', 'now');
+UPDATE publishers SET
+  created_at = strftime('%Y-%m-%d %H:%M:%f', 'now'),
+  updated_at = strftime('%Y-%m-%d %H:%M:%f', 'now');
+
+CREATE INDEX books_updated_at_idx ON books (updated_at);
+CREATE INDEX authors_updated_at_idx ON authors (updated_at);
+CREATE INDEX publishers_updated_at_idx ON publishers (updated_at);
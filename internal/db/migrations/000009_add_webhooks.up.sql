@@ -0,0 +1,29 @@
+-- Subscriptions to catalog events, events is a comma separated list of
+-- event types such as book.created
+CREATE TABLE webhooks (
+    webhook_id INTEGER PRIMARY KEY,
+    url TEXT NOT NULL,
+    secret TEXT NOT NULL,
+    events TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- One row per event and webhook, written when the event happens and sent by
+-- the webhook worker. Deliveries are pending until sent, and dead once they
+-- run out of attempts. next_attempt_at is in unix milliseconds.
+CREATE TABLE webhook_deliveries (
+    delivery_id INTEGER PRIMARY KEY,
+    webhook_id INTEGER NOT NULL,
+    event_id TEXT NOT NULL,
+    event TEXT NOT NULL,
+    payload TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'pending',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at INTEGER NOT NULL,
+    last_error TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (webhook_id) REFERENCES webhooks(webhook_id) ON DELETE CASCADE
+);
+
+CREATE INDEX webhook_deliveries_due_idx ON webhook_deliveries (status, next_attempt_at);
+CREATE INDEX webhook_deliveries_webhook_id_idx ON webhook_deliveries (webhook_id);
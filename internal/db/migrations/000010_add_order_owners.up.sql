@@ -0,0 +1,6 @@
+-- Token of the cart an order was checked out from, orders are only shown to
+-- the owner of that cart or to the user who placed them
+ALTER TABLE orders ADD COLUMN cart_token TEXT;
+
+CREATE INDEX orders_cart_token_idx ON orders (cart_token);
+CREATE INDEX orders_user_id_idx ON orders (user_id);
@@ -0,0 +1,8 @@
+// Package migrations holds the SQLite migrations, embedded so that the server
+// knows the version of the schema its queries were generated for
+package migrations
+
+import "embed"
+
+//go:embed *.sql
+var FS embed.FS
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS author_book;
+DROP TABLE IF EXISTS books;
+DROP TABLE IF EXISTS publishers;
+DROP TABLE IF EXISTS authors;
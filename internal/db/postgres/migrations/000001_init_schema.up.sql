@@ -0,0 +1,37 @@
+-- Create authors table
+CREATE TABLE authors (
+    author_id BIGSERIAL PRIMARY KEY,
+    first_name TEXT NOT NULL,
+    last_name TEXT NOT NULL,
+    middle_name TEXT DEFAULT '' NOT NULL,
+    UNIQUE(first_name, middle_name, last_name)
+);
+
+-- Create publishers table
+CREATE TABLE publishers (
+    publisher_id BIGSERIAL PRIMARY KEY,
+    publisher_name TEXT NOT NULL
+);
+
+-- Create books table
+CREATE TABLE books (
+    book_id BIGSERIAL PRIMARY KEY,
+    title TEXT NOT NULL UNIQUE,
+    isbn13 TEXT UNIQUE,
+    isbn10 TEXT UNIQUE,
+    price DOUBLE PRECISION NOT NULL,
+    publication_year BIGINT NOT NULL,
+    image_url TEXT,
+    edition TEXT,
+    publisher_id BIGINT NOT NULL,
+    FOREIGN KEY (publisher_id) REFERENCES publishers(publisher_id)
+);
+
+-- Create author_book junction table for many-to-many relationship
+CREATE TABLE author_book (
+    author_id BIGINT NOT NULL,
+    book_id BIGINT NOT NULL,
+    PRIMARY KEY (author_id, book_id),
+    FOREIGN KEY (author_id) REFERENCES authors(author_id) ON DELETE CASCADE,
+    FOREIGN KEY (book_id) REFERENCES books(book_id) ON DELETE CASCADE
+);
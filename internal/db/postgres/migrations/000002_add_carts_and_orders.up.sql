@@ -0,0 +1,43 @@
+-- Create carts table
+CREATE TABLE carts (
+    cart_id BIGSERIAL PRIMARY KEY,
+    token TEXT NOT NULL UNIQUE,
+    user_id TEXT UNIQUE
+);
+
+-- Create cart_items table
+CREATE TABLE cart_items (
+    cart_id BIGINT NOT NULL,
+    book_id BIGINT NOT NULL,
+    quantity BIGINT NOT NULL CHECK (quantity > 0),
+    PRIMARY KEY (cart_id, book_id),
+    FOREIGN KEY (cart_id) REFERENCES carts(cart_id) ON DELETE CASCADE,
+    FOREIGN KEY (book_id) REFERENCES books(book_id) ON DELETE CASCADE
+);
+
+-- Create orders table
+CREATE TABLE orders (
+    order_id BIGSERIAL PRIMARY KEY,
+    user_id TEXT,
+    customer_name TEXT NOT NULL,
+    email TEXT NOT NULL,
+    shipping_address TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'pending',
+    total DOUBLE PRECISION NOT NULL,
+    payment_ref TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create order_items table, title/ISBN/price are copied from books at checkout
+CREATE TABLE order_items (
+    order_item_id BIGSERIAL PRIMARY KEY,
+    order_id BIGINT NOT NULL,
+    book_id BIGINT,
+    title TEXT NOT NULL,
+    isbn13 TEXT,
+    isbn10 TEXT,
+    price DOUBLE PRECISION NOT NULL,
+    quantity BIGINT NOT NULL CHECK (quantity > 0),
+    FOREIGN KEY (order_id) REFERENCES orders(order_id) ON DELETE CASCADE,
+    FOREIGN KEY (book_id) REFERENCES books(book_id) ON DELETE SET NULL
+);
@@ -0,0 +1,24 @@
+-- Bibliographic metadata
+ALTER TABLE books ADD COLUMN language TEXT;
+ALTER TABLE books ADD COLUMN format TEXT CHECK (format IN ('hardcover', 'paperback', 'ebook', 'audiobook'));
+ALTER TABLE books ADD COLUMN page_count BIGINT CHECK (page_count > 0);
+ALTER TABLE books ADD COLUMN series_name TEXT;
+ALTER TABLE books ADD COLUMN series_number BIGINT;
+ALTER TABLE books ADD COLUMN description TEXT;
+
+-- Create subjects table, subject names are unique regardless of case
+CREATE TABLE subjects (
+    subject_id BIGSERIAL PRIMARY KEY,
+    subject_name TEXT NOT NULL
+);
+
+CREATE UNIQUE INDEX subjects_subject_name_key ON subjects (lower(subject_name));
+
+-- Create book_subject junction table for many-to-many relationship
+CREATE TABLE book_subject (
+    book_id BIGINT NOT NULL,
+    subject_id BIGINT NOT NULL,
+    PRIMARY KEY (book_id, subject_id),
+    FOREIGN KEY (book_id) REFERENCES books(book_id) ON DELETE CASCADE,
+    FOREIGN KEY (subject_id) REFERENCES subjects(subject_id)
+);
@@ -0,0 +1,12 @@
+DELETE FROM author_book ab
+USING author_book other
+WHERE
+    ab.author_id = other.author_id
+    AND ab.book_id = other.book_id
+    AND ab.position > other.position;
+
+ALTER TABLE author_book DROP CONSTRAINT author_book_pkey;
+ALTER TABLE author_book ADD PRIMARY KEY (author_id, book_id);
+
+ALTER TABLE author_book DROP COLUMN position;
+ALTER TABLE author_book DROP COLUMN role;
@@ -0,0 +1,17 @@
+-- Allow the same person to have several roles on a book
+ALTER TABLE author_book ADD COLUMN role TEXT NOT NULL DEFAULT 'author';
+ALTER TABLE author_book ADD COLUMN position BIGINT NOT NULL DEFAULT 0;
+
+UPDATE author_book ab
+SET position = t.position
+FROM (
+    SELECT
+        author_id,
+        book_id,
+        ROW_NUMBER() OVER (PARTITION BY book_id ORDER BY author_id) - 1 AS position
+    FROM author_book
+) t
+WHERE ab.author_id = t.author_id AND ab.book_id = t.book_id;
+
+ALTER TABLE author_book DROP CONSTRAINT author_book_pkey;
+ALTER TABLE author_book ADD PRIMARY KEY (author_id, book_id, role);
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS publishers_deleted_at_idx;
+DROP INDEX IF EXISTS authors_deleted_at_idx;
+DROP INDEX IF EXISTS books_deleted_at_idx;
+
+ALTER TABLE publishers DROP COLUMN deleted_at;
+ALTER TABLE authors DROP COLUMN deleted_at;
+ALTER TABLE books DROP COLUMN deleted_at;
@@ -0,0 +1,8 @@
+-- Deleted rows are kept until purged, deleted_at is NULL for live rows
+ALTER TABLE books ADD COLUMN deleted_at TIMESTAMPTZ;
+ALTER TABLE authors ADD COLUMN deleted_at TIMESTAMPTZ;
+ALTER TABLE publishers ADD COLUMN deleted_at TIMESTAMPTZ;
+
+CREATE INDEX books_deleted_at_idx ON books (deleted_at);
+CREATE INDEX authors_deleted_at_idx ON authors (deleted_at);
+CREATE INDEX publishers_deleted_at_idx ON publishers (deleted_at);
@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS publishers_updated_at_idx;
+DROP INDEX IF EXISTS authors_updated_at_idx;
+DROP INDEX IF EXISTS books_updated_at_idx;
+
+ALTER TABLE publishers DROP COLUMN updated_at;
+ALTER TABLE publishers DROP COLUMN created_at;
+ALTER TABLE authors DROP COLUMN updated_at;
+ALTER TABLE authors DROP COLUMN created_at;
+ALTER TABLE books DROP COLUMN updated_at;
+ALTER TABLE books DROP COLUMN created_at;
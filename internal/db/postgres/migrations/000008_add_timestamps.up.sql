@@ -0,0 +1,12 @@
+-- Timestamps are kept to millisecond precision, as in SQLite, existing rows
+-- are stamped with the time of the migration
+ALTER TABLE books ADD COLUMN created_at TIMESTAMPTZ NOT NULL DEFAULT date_trunc('milliseconds', CURRENT_TIMESTAMP);
+ALTER TABLE books ADD COLUMN updated_at TIMESTAMPTZ NOT NULL DEFAULT date_trunc('milliseconds', CURRENT_TIMESTAMP);
+ALTER TABLE authors ADD COLUMN created_at TIMESTAMPTZ NOT NULL DEFAULT date_trunc('milliseconds', CURRENT_TIMESTAMP);
+ALTER TABLE authors ADD COLUMN updated_at TIMESTAMPTZ NOT NULL DEFAULT date_trunc('milliseconds', CURRENT_TIMESTAMP);
+ALTER TABLE publishers ADD COLUMN created_at TIMESTAMPTZ NOT NULL DEFAULT date_trunc('milliseconds', CURRENT_TIMESTAMP);
+ALTER TABLE publishers ADD COLUMN updated_at TIMESTAMPTZ NOT NULL DEFAULT date_trunc('milliseconds', CURRENT_TIMESTAMP);
+
+CREATE INDEX books_updated_at_idx ON books (updated_at);
+CREATE INDEX authors_updated_at_idx ON authors (updated_at);
+CREATE INDEX publishers_updated_at_idx ON publishers (updated_at);
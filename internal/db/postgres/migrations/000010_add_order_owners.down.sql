@@ -0,0 +1,4 @@
+DROP INDEX orders_user_id_idx;
+DROP INDEX orders_cart_token_idx;
+
+ALTER TABLE orders DROP COLUMN cart_token;
@@ -0,0 +1,79 @@
+-- name: CreateAuthor :one
+INSERT INTO authors (
+  first_name,
+  last_name,
+  middle_name
+) VALUES (
+  $1, $2, $3
+) RETURNING *;
+
+-- name: GetAuthor :one
+SELECT * FROM authors
+WHERE
+  author_id = sqlc.arg(author_id)
+  AND (deleted_at IS NULL OR sqlc.arg(include_deleted)::boolean)
+LIMIT 1;
+
+-- name: GetAuthorByName :one
+SELECT * FROM authors
+WHERE
+  first_name = @first_name AND
+  last_name = @last_name AND
+  middle_name = COALESCE(@middle_name, middle_name)
+  AND (deleted_at IS NULL OR sqlc.arg(include_deleted)::boolean)
+LIMIT 1;
+
+-- name: ListAuthors :many
+SELECT * FROM authors
+WHERE
+  (deleted_at IS NULL OR sqlc.arg(include_deleted)::boolean)
+  AND (updated_at > sqlc.narg(updated_since)::timestamptz OR sqlc.narg(updated_since)::timestamptz IS NULL)
+ORDER BY author_id
+LIMIT sqlc.arg('limit')::bigint
+OFFSET sqlc.arg('offset')::bigint;
+
+-- name: UpdateAuthor :one
+UPDATE authors
+SET
+  first_name = COALESCE(sqlc.narg(first_name)::text, first_name),
+  last_name = COALESCE(sqlc.narg(last_name)::text, last_name),
+  middle_name = COALESCE(sqlc.narg(middle_name)::text, middle_name),
+  version = version + 1,
+  updated_at = date_trunc('milliseconds', CURRENT_TIMESTAMP)
+WHERE
+  author_id = sqlc.arg(author_id)
+  AND deleted_at IS NULL
+  AND (version = sqlc.narg(version)::bigint OR sqlc.narg(version)::bigint IS NULL)
+RETURNING *;
+
+-- name: DeleteAuthor :execrows
+UPDATE authors
+SET
+  deleted_at = CURRENT_TIMESTAMP,
+  version = version + 1,
+  updated_at = date_trunc('milliseconds', CURRENT_TIMESTAMP)
+WHERE
+  author_id = sqlc.arg(author_id)
+  AND deleted_at IS NULL
+  AND (version = sqlc.narg(version)::bigint OR sqlc.narg(version)::bigint IS NULL);
+
+-- name: RestoreAuthor :one
+UPDATE authors
+SET
+  deleted_at = NULL,
+  version = version + 1,
+  updated_at = date_trunc('milliseconds', CURRENT_TIMESTAMP)
+WHERE author_id = $1 AND deleted_at IS NOT NULL
+RETURNING *;
+
+-- name: PurgeAuthors :execrows
+DELETE FROM authors
+WHERE
+  authors.deleted_at < sqlc.arg(deleted_before)
+  AND NOT EXISTS (SELECT 1 FROM author_book ab WHERE ab.author_id = authors.author_id);
+
+-- name: CountAuthors :one
+SELECT count(*) FROM authors
+WHERE
+  (deleted_at IS NULL OR sqlc.arg(include_deleted)::boolean)
+  AND (updated_at > sqlc.narg(updated_since)::timestamptz OR sqlc.narg(updated_since)::timestamptz IS NULL);
@@ -0,0 +1,34 @@
+-- name: CreateAuthorBookRel :exec
+INSERT INTO author_book (
+  author_id,
+  book_id,
+  role,
+  position
+) VALUES (
+  $1, $2, COALESCE(sqlc.narg(role)::text, 'author'), COALESCE(sqlc.narg(position)::bigint, 0)
+)
+ON CONFLICT DO NOTHING;
+
+-- name: ListAuthorsWithBookID :many
+SELECT sqlc.embed(a)
+FROM
+  author_book ab
+  JOIN authors a ON ab.author_id = a.author_id
+WHERE ab.book_id = $1
+ORDER BY ab.position;
+
+-- name: DeletePurgedAuthorBookRels :exec
+DELETE FROM author_book
+WHERE book_id IN (SELECT book_id FROM books WHERE deleted_at < sqlc.arg(deleted_before));
+
+-- name: ListBookAuthors :many
+SELECT
+  ab.book_id,
+  sqlc.embed(a)
+FROM
+  author_book ab
+  JOIN authors a ON ab.author_id = a.author_id
+WHERE
+  ab.book_id IN (SELECT jsonb_array_elements_text(sqlc.arg(book_ids)::jsonb)::bigint)
+  AND ab.role = 'author'
+ORDER BY ab.book_id, ab.position;
@@ -0,0 +1,264 @@
+-- name: CreateBook :one
+INSERT INTO books (
+  title,
+  isbn13,
+  isbn10,
+  price,
+  publication_year,
+  image_url,
+  edition,
+  publisher_id,
+  language,
+  format,
+  page_count,
+  series_name,
+  series_number,
+  description
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14
+) RETURNING *;
+
+-- name: GetBookByISBN :one
+SELECT
+	sqlc.embed(b),
+	COALESCE((
+		SELECT string_agg(ca.first_name || CASE WHEN ca.middle_name <> '' THEN ' ' || ca.middle_name ELSE '' END || ' ' || ca.last_name, ',' ORDER BY cab.position)
+		FROM author_book cab JOIN authors ca ON cab.author_id = ca.author_id
+		WHERE cab.book_id = b.book_id AND cab.role = 'author'
+	), '')::text AS authors,
+	COALESCE((
+		SELECT string_agg(cab.role || ':' || ca.first_name || CASE WHEN ca.middle_name <> '' THEN ' ' || ca.middle_name ELSE '' END || ' ' || ca.last_name, ',' ORDER BY cab.position)
+		FROM author_book cab JOIN authors ca ON cab.author_id = ca.author_id
+		WHERE cab.book_id = b.book_id
+	), '')::text AS contributors,
+	p.publisher_name AS publisher_name,
+	COALESCE((
+		SELECT string_agg(s.subject_name, ',' ORDER BY s.subject_name)
+		FROM book_subject bs JOIN subjects s ON bs.subject_id = s.subject_id
+		WHERE bs.book_id = b.book_id
+	), '')::text AS subjects
+FROM
+	books AS b
+	JOIN author_book AS ab ON b.book_id = ab.book_id
+	JOIN authors AS a ON ab.author_id = a.author_id
+	JOIN publishers AS p ON b.publisher_id = p.publisher_id
+WHERE
+	(b.isbn13 = sqlc.narg(isbn13)::text OR b.isbn10 = sqlc.narg(isbn10)::text)
+	AND (b.deleted_at IS NULL OR sqlc.arg(include_deleted)::boolean)
+GROUP BY
+	b.book_id,
+	p.publisher_id;
+
+-- name: ListBooks :many
+SELECT
+  sqlc.embed(b),
+  COALESCE((
+    SELECT string_agg(ca.first_name || CASE WHEN ca.middle_name <> '' THEN ' ' || ca.middle_name ELSE '' END || ' ' || ca.last_name, ',' ORDER BY cab.position)
+    FROM author_book cab JOIN authors ca ON cab.author_id = ca.author_id
+    WHERE cab.book_id = b.book_id AND cab.role = 'author'
+  ), '')::text AS authors,
+  COALESCE((
+    SELECT string_agg(cab.role || ':' || ca.first_name || CASE WHEN ca.middle_name <> '' THEN ' ' || ca.middle_name ELSE '' END || ' ' || ca.last_name, ',' ORDER BY cab.position)
+    FROM author_book cab JOIN authors ca ON cab.author_id = ca.author_id
+    WHERE cab.book_id = b.book_id
+  ), '')::text AS contributors,
+  p.publisher_name AS publisher_name,
+  COALESCE((
+    SELECT string_agg(s.subject_name, ',' ORDER BY s.subject_name)
+    FROM book_subject bs JOIN subjects s ON bs.subject_id = s.subject_id
+    WHERE bs.book_id = b.book_id
+  ), '')::text AS subjects
+FROM
+  books b
+JOIN author_book ab ON b.book_id = ab.book_id
+JOIN authors a ON ab.author_id = a.author_id
+JOIN publishers p ON b.publisher_id = p.publisher_id
+WHERE
+  (b.title ILIKE '%' || sqlc.narg(title)::text || '%' OR sqlc.narg(title)::text IS NULL)
+  AND (b.price >= sqlc.narg(min_price)::float8 OR sqlc.narg(min_price)::float8 IS NULL)
+  AND (b.price <= sqlc.narg(max_price)::float8 OR sqlc.narg(max_price)::float8 IS NULL)
+  AND (b.publication_year >= sqlc.narg(min_publication_year)::bigint OR sqlc.narg(min_publication_year)::bigint IS NULL)
+  AND (b.publication_year <= sqlc.narg(max_publication_year)::bigint OR sqlc.narg(max_publication_year)::bigint IS NULL)
+  AND ((ab.role = 'author' AND a.first_name || ' ' || a.middle_name || ' ' || a.last_name ILIKE '%' || sqlc.narg(author)::text || '%') OR sqlc.narg(author)::text IS NULL)
+  AND (p.publisher_name ILIKE '%' || sqlc.narg(publisher)::text || '%' OR sqlc.narg(publisher)::text IS NULL)
+  AND (b.language = sqlc.narg(language)::text OR b.language ILIKE sqlc.narg(language)::text || '-%' OR sqlc.narg(language)::text IS NULL)
+  AND (b.format = sqlc.narg(format)::text OR sqlc.narg(format)::text IS NULL)
+  AND (b.page_count >= sqlc.narg(min_page_count)::bigint OR sqlc.narg(min_page_count)::bigint IS NULL)
+  AND (b.page_count <= sqlc.narg(max_page_count)::bigint OR sqlc.narg(max_page_count)::bigint IS NULL)
+  AND (b.series_name ILIKE '%' || sqlc.narg(series_name)::text || '%' OR sqlc.narg(series_name)::text IS NULL)
+  AND (b.series_number = sqlc.narg(series_number)::bigint OR sqlc.narg(series_number)::bigint IS NULL)
+  AND (b.description ILIKE '%' || sqlc.narg(description)::text || '%' OR sqlc.narg(description)::text IS NULL)
+  AND (EXISTS (
+    SELECT 1 FROM book_subject bs JOIN subjects s ON bs.subject_id = s.subject_id
+    WHERE bs.book_id = b.book_id AND lower(s.subject_name) = lower(sqlc.narg(subject)::text)
+  ) OR sqlc.narg(subject)::text IS NULL)
+  AND (b.deleted_at IS NULL OR sqlc.arg(include_deleted)::boolean)
+  AND (b.updated_at > sqlc.narg(updated_since)::timestamptz OR sqlc.narg(updated_since)::timestamptz IS NULL)
+  AND (b.title > sqlc.narg(after_title)::text OR (b.title = sqlc.narg(after_title)::text AND b.book_id > sqlc.narg(after_id)::bigint) OR sqlc.narg(after_title)::text IS NULL)
+GROUP BY
+	b.book_id,
+	p.publisher_id
+ORDER BY b.title, b.book_id
+LIMIT sqlc.arg('limit')::bigint
+OFFSET sqlc.arg('offset')::bigint;
+
+-- name: UpdateBookByISBN :one
+UPDATE books
+SET
+  title = COALESCE(sqlc.narg(title)::text, title),
+  isbn13 = CASE WHEN sqlc.arg(clear_isbn13)::boolean THEN NULL ELSE COALESCE(sqlc.narg(new_isbn13)::text, isbn13) END,
+  isbn10 = CASE WHEN sqlc.arg(clear_isbn10)::boolean THEN NULL ELSE COALESCE(sqlc.narg(new_isbn10)::text, isbn10) END,
+  price = COALESCE(sqlc.narg(price)::float8, price),
+  publication_year = COALESCE(sqlc.narg(publication_year)::bigint, publication_year),
+  image_url = CASE WHEN sqlc.arg(clear_image_url)::boolean THEN NULL ELSE COALESCE(sqlc.narg(image_url)::text, image_url) END,
+  language = CASE WHEN sqlc.arg(clear_language)::boolean THEN NULL ELSE COALESCE(sqlc.narg(language)::text, language) END,
+  format = CASE WHEN sqlc.arg(clear_format)::boolean THEN NULL ELSE COALESCE(sqlc.narg(format)::text, format) END,
+  page_count = CASE WHEN sqlc.arg(clear_page_count)::boolean THEN NULL ELSE COALESCE(sqlc.narg(page_count)::bigint, page_count) END,
+  series_name = CASE WHEN sqlc.arg(clear_series_name)::boolean THEN NULL ELSE COALESCE(sqlc.narg(series_name)::text, series_name) END,
+  series_number = CASE WHEN sqlc.arg(clear_series_number)::boolean THEN NULL ELSE COALESCE(sqlc.narg(series_number)::bigint, series_number) END,
+  description = CASE WHEN sqlc.arg(clear_description)::boolean THEN NULL ELSE COALESCE(sqlc.narg(description)::text, description) END,
+  version = version + 1,
+  updated_at = date_trunc('milliseconds', CURRENT_TIMESTAMP)
+WHERE
+  (isbn13 = @isbn13 OR isbn10 = @isbn10)
+  AND deleted_at IS NULL
+  AND (version = sqlc.narg(version)::bigint OR sqlc.narg(version)::bigint IS NULL)
+RETURNING *;
+
+-- name: DeleteBookByISBN :execrows
+UPDATE books
+SET
+  deleted_at = CURRENT_TIMESTAMP,
+  version = version + 1,
+  updated_at = date_trunc('milliseconds', CURRENT_TIMESTAMP)
+WHERE
+  (isbn13 = sqlc.narg(isbn13)::text OR isbn10 = sqlc.narg(isbn10)::text)
+  AND deleted_at IS NULL
+  AND (version = sqlc.narg(version)::bigint OR sqlc.narg(version)::bigint IS NULL);
+
+-- name: RestoreBookByISBN :one
+UPDATE books
+SET
+  deleted_at = NULL,
+  version = version + 1,
+  updated_at = date_trunc('milliseconds', CURRENT_TIMESTAMP)
+WHERE
+  (isbn13 = $1 OR isbn10 = $2)
+  AND deleted_at IS NOT NULL
+RETURNING *;
+
+-- name: PurgeBooks :execrows
+DELETE FROM books
+WHERE deleted_at < sqlc.arg(deleted_before);
+
+-- name: CountBooks :one
+SELECT
+  COUNT(DISTINCT b.book_id)
+FROM
+  books b
+JOIN author_book ab ON b.book_id = ab.book_id
+JOIN authors a ON ab.author_id = a.author_id
+JOIN publishers p ON b.publisher_id = p.publisher_id
+WHERE
+  (b.title ILIKE '%' || sqlc.narg(title)::text || '%' OR sqlc.narg(title)::text IS NULL)
+  AND (b.price >= sqlc.narg(min_price)::float8 OR sqlc.narg(min_price)::float8 IS NULL)
+  AND (b.price <= sqlc.narg(max_price)::float8 OR sqlc.narg(max_price)::float8 IS NULL)
+  AND (b.publication_year >= sqlc.narg(min_publication_year)::bigint OR sqlc.narg(min_publication_year)::bigint IS NULL)
+  AND (b.publication_year <= sqlc.narg(max_publication_year)::bigint OR sqlc.narg(max_publication_year)::bigint IS NULL)
+  AND ((ab.role = 'author' AND a.first_name || ' ' || a.middle_name || ' ' || a.last_name ILIKE '%' || sqlc.narg(author)::text || '%') OR sqlc.narg(author)::text IS NULL)
+  AND (p.publisher_name ILIKE '%' || sqlc.narg(publisher)::text || '%' OR sqlc.narg(publisher)::text IS NULL)
+  AND (b.language = sqlc.narg(language)::text OR b.language ILIKE sqlc.narg(language)::text || '-%' OR sqlc.narg(language)::text IS NULL)
+  AND (b.format = sqlc.narg(format)::text OR sqlc.narg(format)::text IS NULL)
+  AND (b.page_count >= sqlc.narg(min_page_count)::bigint OR sqlc.narg(min_page_count)::bigint IS NULL)
+  AND (b.page_count <= sqlc.narg(max_page_count)::bigint OR sqlc.narg(max_page_count)::bigint IS NULL)
+  AND (b.series_name ILIKE '%' || sqlc.narg(series_name)::text || '%' OR sqlc.narg(series_name)::text IS NULL)
+  AND (b.series_number = sqlc.narg(series_number)::bigint OR sqlc.narg(series_number)::bigint IS NULL)
+  AND (b.description ILIKE '%' || sqlc.narg(description)::text || '%' OR sqlc.narg(description)::text IS NULL)
+  AND (EXISTS (
+    SELECT 1 FROM book_subject bs JOIN subjects s ON bs.subject_id = s.subject_id
+    WHERE bs.book_id = b.book_id AND lower(s.subject_name) = lower(sqlc.narg(subject)::text)
+  ) OR sqlc.narg(subject)::text IS NULL)
+  AND (b.deleted_at IS NULL OR sqlc.arg(include_deleted)::boolean)
+  AND (b.updated_at > sqlc.narg(updated_since)::timestamptz OR sqlc.narg(updated_since)::timestamptz IS NULL);
+
+-- name: SetBookCover :one
+UPDATE books
+SET
+  cover_key = sqlc.narg(cover_key)::text,
+  version = version + 1,
+  updated_at = date_trunc('milliseconds', CURRENT_TIMESTAMP)
+WHERE
+  book_id = sqlc.arg(book_id)
+RETURNING *;
+
+-- name: BumpAuthorBookVersions :exec
+UPDATE books
+SET
+  version = version + 1,
+  updated_at = date_trunc('milliseconds', CURRENT_TIMESTAMP)
+WHERE book_id IN (SELECT ab.book_id FROM author_book ab WHERE ab.author_id = $1);
+
+-- name: BumpPublisherBookVersions :exec
+UPDATE books
+SET
+  version = version + 1,
+  updated_at = date_trunc('milliseconds', CURRENT_TIMESTAMP)
+WHERE publisher_id = $1;
+
+-- name: ListAuthorBooks :many
+SELECT
+  ab.author_id,
+  sqlc.embed(b),
+  COALESCE((
+    SELECT string_agg(ca.first_name || CASE WHEN ca.middle_name <> '' THEN ' ' || ca.middle_name ELSE '' END || ' ' || ca.last_name, ',' ORDER BY cab.position)
+    FROM author_book cab JOIN authors ca ON cab.author_id = ca.author_id
+    WHERE cab.book_id = b.book_id AND cab.role = 'author'
+  ), '')::text AS authors,
+  COALESCE((
+    SELECT string_agg(cab.role || ':' || ca.first_name || CASE WHEN ca.middle_name <> '' THEN ' ' || ca.middle_name ELSE '' END || ' ' || ca.last_name, ',' ORDER BY cab.position)
+    FROM author_book cab JOIN authors ca ON cab.author_id = ca.author_id
+    WHERE cab.book_id = b.book_id
+  ), '')::text AS contributors,
+  p.publisher_name AS publisher_name,
+  COALESCE((
+    SELECT string_agg(s.subject_name, ',' ORDER BY s.subject_name)
+    FROM book_subject bs JOIN subjects s ON bs.subject_id = s.subject_id
+    WHERE bs.book_id = b.book_id
+  ), '')::text AS subjects
+FROM
+  books b
+JOIN author_book ab ON b.book_id = ab.book_id
+JOIN publishers p ON b.publisher_id = p.publisher_id
+WHERE
+  ab.author_id IN (SELECT jsonb_array_elements_text(sqlc.arg(author_ids)::jsonb)::bigint)
+  AND ab.role = 'author'
+  AND b.deleted_at IS NULL
+ORDER BY ab.author_id, b.publication_year, b.book_id;
+
+-- name: ListPublisherBooks :many
+SELECT
+  b.publisher_id,
+  sqlc.embed(b),
+  COALESCE((
+    SELECT string_agg(ca.first_name || CASE WHEN ca.middle_name <> '' THEN ' ' || ca.middle_name ELSE '' END || ' ' || ca.last_name, ',' ORDER BY cab.position)
+    FROM author_book cab JOIN authors ca ON cab.author_id = ca.author_id
+    WHERE cab.book_id = b.book_id AND cab.role = 'author'
+  ), '')::text AS authors,
+  COALESCE((
+    SELECT string_agg(cab.role || ':' || ca.first_name || CASE WHEN ca.middle_name <> '' THEN ' ' || ca.middle_name ELSE '' END || ' ' || ca.last_name, ',' ORDER BY cab.position)
+    FROM author_book cab JOIN authors ca ON cab.author_id = ca.author_id
+    WHERE cab.book_id = b.book_id
+  ), '')::text AS contributors,
+  p.publisher_name AS publisher_name,
+  COALESCE((
+    SELECT string_agg(s.subject_name, ',' ORDER BY s.subject_name)
+    FROM book_subject bs JOIN subjects s ON bs.subject_id = s.subject_id
+    WHERE bs.book_id = b.book_id
+  ), '')::text AS subjects
+FROM
+  books b
+JOIN publishers p ON b.publisher_id = p.publisher_id
+WHERE
+  b.publisher_id IN (SELECT jsonb_array_elements_text(sqlc.arg(publisher_ids)::jsonb)::bigint)
+  AND b.deleted_at IS NULL
+ORDER BY b.publisher_id, b.publication_year, b.book_id;
@@ -0,0 +1,81 @@
+-- name: CreateCart :one
+INSERT INTO carts (
+  token,
+  user_id
+) VALUES (
+  $1, $2
+) RETURNING *;
+
+-- name: GetCartByToken :one
+SELECT * FROM carts
+WHERE token = $1 LIMIT 1;
+
+-- name: GetCartByUserID :one
+SELECT * FROM carts
+WHERE user_id = $1 LIMIT 1;
+
+-- name: SetCartUserID :one
+UPDATE carts
+SET
+  user_id = sqlc.arg(user_id)
+WHERE
+  cart_id = sqlc.arg(cart_id)
+RETURNING *;
+
+-- name: DeleteCart :exec
+DELETE FROM carts WHERE cart_id = $1;
+
+-- name: AddCartItem :exec
+-- Adds to the quantity of a book in a cart, up to the 99 that AddCartItemReq allows
+INSERT INTO cart_items (
+  cart_id,
+  book_id,
+  quantity
+) VALUES (
+  $1, $2, $3
+)
+ON CONFLICT (cart_id, book_id) DO UPDATE
+SET quantity = LEAST(cart_items.quantity + excluded.quantity, 99);
+
+-- name: SetCartItemQuantity :execrows
+UPDATE cart_items
+SET
+  quantity = sqlc.arg(quantity)
+WHERE
+  cart_id = sqlc.arg(cart_id) AND book_id = sqlc.arg(book_id);
+
+-- name: DeleteCartItem :exec
+DELETE FROM cart_items
+WHERE cart_id = $1 AND book_id = $2;
+
+-- name: ListCartItems :many
+SELECT
+  sqlc.embed(b),
+  ci.quantity
+FROM
+  cart_items ci
+  JOIN books b ON ci.book_id = b.book_id
+WHERE b.deleted_at IS NULL AND ci.cart_id = $1
+ORDER BY b.title;
+
+-- name: MergeCartItems :exec
+-- Adds the items of a cart to another, up to 99 of a book
+INSERT INTO cart_items (
+  cart_id,
+  book_id,
+  quantity
+)
+SELECT
+  sqlc.arg(to_cart_id)::bigint, ci.book_id, ci.quantity
+FROM
+  cart_items ci
+WHERE ci.cart_id = sqlc.arg(from_cart_id)
+ON CONFLICT (cart_id, book_id) DO UPDATE
+SET quantity = LEAST(cart_items.quantity + excluded.quantity, 99);
+
+-- name: ClearCartItems :exec
+DELETE FROM cart_items WHERE cart_id = $1;
+
+-- name: DeletePurgedCartItems :exec
+DELETE FROM cart_items
+WHERE book_id IN (SELECT book_id FROM books WHERE deleted_at < sqlc.arg(deleted_before));
@@ -0,0 +1,30 @@
+-- name: ListChanges :many
+-- updated_at is the start of the transaction that made the change, which
+-- may commit after later ones. Changes are only listed up to the start of
+-- the oldest transaction still running, so that none is skipped by a reader
+-- that went past it before it committed. Books are listed by their ID,
+-- which is kept when their ISBNs change, along with their current ISBN.
+WITH horizon AS (
+  SELECT date_trunc('milliseconds', COALESCE(MIN(xact_start), CURRENT_TIMESTAMP)) AS started_at
+  FROM pg_catalog.pg_stat_activity
+  WHERE datname = current_database()
+)
+SELECT
+  'book'::text AS entity,
+  book_id::text AS entity_id,
+  COALESCE(isbn13, isbn10)::text AS isbn,
+  version,
+  updated_at,
+  (deleted_at IS NOT NULL)::boolean AS deleted
+FROM books
+WHERE updated_at > sqlc.arg(since)::timestamptz AND updated_at < (SELECT started_at FROM horizon)
+UNION ALL
+SELECT 'author', author_id::text, '', version, updated_at, deleted_at IS NOT NULL
+FROM authors
+WHERE updated_at > sqlc.arg(since)::timestamptz AND updated_at < (SELECT started_at FROM horizon)
+UNION ALL
+SELECT 'publisher', publisher_id::text, '', version, updated_at, deleted_at IS NOT NULL
+FROM publishers
+WHERE updated_at > sqlc.arg(since)::timestamptz AND updated_at < (SELECT started_at FROM horizon)
+ORDER BY updated_at, entity, entity_id
+LIMIT sqlc.arg('limit')::bigint;
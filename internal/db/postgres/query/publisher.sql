@@ -0,0 +1,82 @@
+-- name: CreatePublisher :one
+INSERT INTO publishers (
+  publisher_name
+) VALUES (
+  $1
+) RETURNING *;
+
+-- name: GetPublisher :one
+SELECT * FROM publishers
+WHERE
+  publisher_id = sqlc.arg(publisher_id)
+  AND (deleted_at IS NULL OR sqlc.arg(include_deleted)::boolean)
+LIMIT 1;
+
+-- name: GetPublisherByName :one
+SELECT * FROM publishers
+WHERE
+  publisher_name = sqlc.arg(publisher_name)
+  AND (deleted_at IS NULL OR sqlc.arg(include_deleted)::boolean)
+LIMIT 1;
+
+-- name: ListPublishers :many
+SELECT * FROM publishers
+WHERE
+  (deleted_at IS NULL OR sqlc.arg(include_deleted)::boolean)
+  AND (updated_at > sqlc.narg(updated_since)::timestamptz OR sqlc.narg(updated_since)::timestamptz IS NULL)
+ORDER BY publisher_id
+LIMIT sqlc.arg('limit')::bigint
+OFFSET sqlc.arg('offset')::bigint;
+
+-- name: UpdatePublisher :one
+UPDATE publishers
+SET
+  publisher_name = COALESCE(sqlc.narg(publisher_name)::text, publisher_name),
+  version = version + 1,
+  updated_at = date_trunc('milliseconds', CURRENT_TIMESTAMP)
+WHERE
+  publisher_id = sqlc.arg(publisher_id)
+  AND deleted_at IS NULL
+  AND (version = sqlc.narg(version)::bigint OR sqlc.narg(version)::bigint IS NULL)
+RETURNING *;
+
+-- name: DeletePublisher :execrows
+UPDATE publishers
+SET
+  deleted_at = CURRENT_TIMESTAMP,
+  version = version + 1,
+  updated_at = date_trunc('milliseconds', CURRENT_TIMESTAMP)
+WHERE
+  publisher_id = sqlc.arg(publisher_id)
+  AND deleted_at IS NULL
+  AND (version = sqlc.narg(version)::bigint OR sqlc.narg(version)::bigint IS NULL);
+
+-- name: RestorePublisher :one
+UPDATE publishers
+SET
+  deleted_at = NULL,
+  version = version + 1,
+  updated_at = date_trunc('milliseconds', CURRENT_TIMESTAMP)
+WHERE publisher_id = $1 AND deleted_at IS NOT NULL
+RETURNING *;
+
+-- name: PurgePublishers :execrows
+DELETE FROM publishers
+WHERE
+  publishers.deleted_at < sqlc.arg(deleted_before)
+  AND NOT EXISTS (SELECT 1 FROM books b WHERE b.publisher_id = publishers.publisher_id);
+
+-- name: CountPublishers :one
+SELECT count(*) FROM publishers
+WHERE
+  (deleted_at IS NULL OR sqlc.arg(include_deleted)::boolean)
+  AND (updated_at > sqlc.narg(updated_since)::timestamptz OR sqlc.narg(updated_since)::timestamptz IS NULL);
+
+-- name: ListBookPublishers :many
+SELECT
+  b.book_id,
+  sqlc.embed(p)
+FROM
+  books b
+  JOIN publishers p ON b.publisher_id = p.publisher_id
+WHERE b.book_id IN (SELECT jsonb_array_elements_text(sqlc.arg(book_ids)::jsonb)::bigint);
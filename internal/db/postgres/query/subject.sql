@@ -0,0 +1,25 @@
+-- name: UpsertSubject :one
+INSERT INTO subjects (
+  subject_name
+) VALUES (
+  $1
+)
+ON CONFLICT ((lower(subject_name))) DO UPDATE SET subject_name = subjects.subject_name
+RETURNING *;
+
+-- name: CreateBookSubjectRel :exec
+INSERT INTO book_subject (
+  book_id,
+  subject_id
+) VALUES (
+  $1, $2
+)
+ON CONFLICT DO NOTHING;
+
+-- name: DeleteBookSubjectRels :exec
+DELETE FROM book_subject
+WHERE book_id = $1;
+
+-- name: DeletePurgedBookSubjectRels :exec
+DELETE FROM book_subject
+WHERE book_id IN (SELECT book_id FROM books WHERE deleted_at < sqlc.arg(deleted_before));
@@ -0,0 +1,97 @@
+-- name: CreateWebhook :one
+INSERT INTO webhooks (
+  url,
+  secret,
+  events
+) VALUES (
+  $1, $2, $3
+) RETURNING *;
+
+-- name: GetWebhook :one
+SELECT * FROM webhooks
+WHERE webhook_id = $1 LIMIT 1;
+
+-- name: ListWebhooks :many
+SELECT * FROM webhooks
+ORDER BY webhook_id;
+
+-- name: DeleteWebhook :execrows
+DELETE FROM webhooks
+WHERE webhook_id = $1;
+
+-- name: DeleteWebhookDeliveries :exec
+DELETE FROM webhook_deliveries
+WHERE webhook_id = $1;
+
+-- name: EnqueueWebhookDeliveries :execrows
+INSERT INTO webhook_deliveries (
+  webhook_id,
+  event_id,
+  event,
+  payload,
+  next_attempt_at
+)
+SELECT
+  webhook_id,
+  sqlc.arg(event_id)::text,
+  sqlc.arg(event)::text,
+  sqlc.arg(payload)::text,
+  sqlc.arg(next_attempt_at)::bigint
+FROM webhooks
+WHERE ',' || events || ',' LIKE '%,' || sqlc.arg(event)::text || ',%';
+
+-- name: ListDueWebhookDeliveries :many
+SELECT
+  sqlc.embed(d),
+  w.url,
+  w.secret
+FROM webhook_deliveries d
+JOIN webhooks w ON d.webhook_id = w.webhook_id
+WHERE
+  d.status = 'pending'
+  AND d.next_attempt_at <= sqlc.arg(now)
+ORDER BY d.next_attempt_at, d.delivery_id
+LIMIT sqlc.arg('limit')::bigint;
+
+-- name: LeaseWebhookDelivery :execrows
+UPDATE webhook_deliveries
+SET next_attempt_at = sqlc.arg(lease_until)
+WHERE
+  delivery_id = sqlc.arg(delivery_id)
+  AND status = 'pending'
+  AND next_attempt_at = sqlc.arg(next_attempt_at);
+
+-- name: SetWebhookDeliveryResult :one
+UPDATE webhook_deliveries
+SET
+  status = sqlc.arg(status),
+  attempts = attempts + 1,
+  next_attempt_at = sqlc.arg(next_attempt_at),
+  last_error = sqlc.narg(last_error)
+WHERE delivery_id = sqlc.arg(delivery_id)
+RETURNING *;
+
+-- name: RedeliverWebhookDelivery :one
+UPDATE webhook_deliveries
+SET
+  status = 'pending',
+  attempts = 0,
+  next_attempt_at = sqlc.arg(next_attempt_at),
+  last_error = NULL
+WHERE delivery_id = sqlc.arg(delivery_id)
+RETURNING *;
+
+-- name: ListWebhookDeliveries :many
+SELECT * FROM webhook_deliveries
+WHERE
+  (webhook_id = sqlc.narg(webhook_id) OR sqlc.narg(webhook_id)::bigint IS NULL)
+  AND (status = sqlc.narg(status) OR sqlc.narg(status)::text IS NULL)
+ORDER BY delivery_id DESC
+LIMIT sqlc.arg('limit')::bigint
+OFFSET sqlc.arg('offset')::bigint;
+
+-- name: CountWebhookDeliveries :one
+SELECT count(*) FROM webhook_deliveries
+WHERE
+  (webhook_id = sqlc.narg(webhook_id) OR sqlc.narg(webhook_id)::bigint IS NULL)
+  AND (status = sqlc.narg(status) OR sqlc.narg(status)::text IS NULL);
@@ -0,0 +1,294 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.26.0
+// source: author.sql
+
+package pgdb
+
+import (
+	"context"
+	"database/sql"
+)
+
+const countAuthors = `-- name: CountAuthors :one
+SELECT count(*) FROM authors
+WHERE
+  (deleted_at IS NULL OR $1::boolean)
+  AND (updated_at > $2::timestamptz OR $2::timestamptz IS NULL)
+`
+
+type CountAuthorsParams struct {
+	IncludeDeleted bool         `json:"include_deleted"`
+	UpdatedSince   sql.NullTime `json:"updated_since"`
+}
+
+func (q *Queries) CountAuthors(ctx context.Context, arg CountAuthorsParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countAuthors, arg.IncludeDeleted, arg.UpdatedSince)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const createAuthor = `-- name: CreateAuthor :one
+INSERT INTO authors (
+  first_name,
+  last_name,
+  middle_name
+) VALUES (
+  $1, $2, $3
+) RETURNING author_id, first_name, last_name, middle_name, deleted_at, version, created_at, updated_at
+`
+
+type CreateAuthorParams struct {
+	FirstName  string `json:"first_name"`
+	LastName   string `json:"last_name"`
+	MiddleName string `json:"middle_name"`
+}
+
+func (q *Queries) CreateAuthor(ctx context.Context, arg CreateAuthorParams) (Author, error) {
+	row := q.db.QueryRowContext(ctx, createAuthor, arg.FirstName, arg.LastName, arg.MiddleName)
+	var i Author
+	err := row.Scan(
+		&i.AuthorID,
+		&i.FirstName,
+		&i.LastName,
+		&i.MiddleName,
+		&i.DeletedAt,
+		&i.Version,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const deleteAuthor = `-- name: DeleteAuthor :execrows
+UPDATE authors
+SET
+  deleted_at = CURRENT_TIMESTAMP,
+  version = version + 1,
+  updated_at = date_trunc('milliseconds', CURRENT_TIMESTAMP)
+WHERE
+  author_id = $1
+  AND deleted_at IS NULL
+  AND (version = $2::bigint OR $2::bigint IS NULL)
+`
+
+type DeleteAuthorParams struct {
+	AuthorID int64         `json:"author_id"`
+	Version  sql.NullInt64 `json:"version"`
+}
+
+func (q *Queries) DeleteAuthor(ctx context.Context, arg DeleteAuthorParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteAuthor, arg.AuthorID, arg.Version)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const getAuthor = `-- name: GetAuthor :one
+SELECT author_id, first_name, last_name, middle_name, deleted_at, version, created_at, updated_at FROM authors
+WHERE
+  author_id = $1
+  AND (deleted_at IS NULL OR $2::boolean)
+LIMIT 1
+`
+
+type GetAuthorParams struct {
+	AuthorID       int64 `json:"author_id"`
+	IncludeDeleted bool  `json:"include_deleted"`
+}
+
+func (q *Queries) GetAuthor(ctx context.Context, arg GetAuthorParams) (Author, error) {
+	row := q.db.QueryRowContext(ctx, getAuthor, arg.AuthorID, arg.IncludeDeleted)
+	var i Author
+	err := row.Scan(
+		&i.AuthorID,
+		&i.FirstName,
+		&i.LastName,
+		&i.MiddleName,
+		&i.DeletedAt,
+		&i.Version,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getAuthorByName = `-- name: GetAuthorByName :one
+SELECT author_id, first_name, last_name, middle_name, deleted_at, version, created_at, updated_at FROM authors
+WHERE
+  first_name = $1 AND
+  last_name = $2 AND
+  middle_name = COALESCE($3, middle_name)
+  AND (deleted_at IS NULL OR $4::boolean)
+LIMIT 1
+`
+
+type GetAuthorByNameParams struct {
+	FirstName      string `json:"first_name"`
+	LastName       string `json:"last_name"`
+	MiddleName     string `json:"middle_name"`
+	IncludeDeleted bool   `json:"include_deleted"`
+}
+
+func (q *Queries) GetAuthorByName(ctx context.Context, arg GetAuthorByNameParams) (Author, error) {
+	row := q.db.QueryRowContext(ctx, getAuthorByName,
+		arg.FirstName,
+		arg.LastName,
+		arg.MiddleName,
+		arg.IncludeDeleted,
+	)
+	var i Author
+	err := row.Scan(
+		&i.AuthorID,
+		&i.FirstName,
+		&i.LastName,
+		&i.MiddleName,
+		&i.DeletedAt,
+		&i.Version,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const listAuthors = `-- name: ListAuthors :many
+SELECT author_id, first_name, last_name, middle_name, deleted_at, version, created_at, updated_at FROM authors
+WHERE
+  (deleted_at IS NULL OR $1::boolean)
+  AND (updated_at > $2::timestamptz OR $2::timestamptz IS NULL)
+ORDER BY author_id
+LIMIT $4::bigint
+OFFSET $3::bigint
+`
+
+type ListAuthorsParams struct {
+	IncludeDeleted bool         `json:"include_deleted"`
+	UpdatedSince   sql.NullTime `json:"updated_since"`
+	Offset         int64        `json:"offset"`
+	Limit          int64        `json:"limit"`
+}
+
+func (q *Queries) ListAuthors(ctx context.Context, arg ListAuthorsParams) ([]Author, error) {
+	rows, err := q.db.QueryContext(ctx, listAuthors,
+		arg.IncludeDeleted,
+		arg.UpdatedSince,
+		arg.Offset,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Author{}
+	for rows.Next() {
+		var i Author
+		if err := rows.Scan(
+			&i.AuthorID,
+			&i.FirstName,
+			&i.LastName,
+			&i.MiddleName,
+			&i.DeletedAt,
+			&i.Version,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const purgeAuthors = `-- name: PurgeAuthors :execrows
+DELETE FROM authors
+WHERE
+  authors.deleted_at < $1
+  AND NOT EXISTS (SELECT 1 FROM author_book ab WHERE ab.author_id = authors.author_id)
+`
+
+func (q *Queries) PurgeAuthors(ctx context.Context, deletedBefore sql.NullTime) (int64, error) {
+	result, err := q.db.ExecContext(ctx, purgeAuthors, deletedBefore)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const restoreAuthor = `-- name: RestoreAuthor :one
+UPDATE authors
+SET
+  deleted_at = NULL,
+  version = version + 1,
+  updated_at = date_trunc('milliseconds', CURRENT_TIMESTAMP)
+WHERE author_id = $1 AND deleted_at IS NOT NULL
+RETURNING author_id, first_name, last_name, middle_name, deleted_at, version, created_at, updated_at
+`
+
+func (q *Queries) RestoreAuthor(ctx context.Context, authorID int64) (Author, error) {
+	row := q.db.QueryRowContext(ctx, restoreAuthor, authorID)
+	var i Author
+	err := row.Scan(
+		&i.AuthorID,
+		&i.FirstName,
+		&i.LastName,
+		&i.MiddleName,
+		&i.DeletedAt,
+		&i.Version,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const updateAuthor = `-- name: UpdateAuthor :one
+UPDATE authors
+SET
+  first_name = COALESCE($1::text, first_name),
+  last_name = COALESCE($2::text, last_name),
+  middle_name = COALESCE($3::text, middle_name),
+  version = version + 1,
+  updated_at = date_trunc('milliseconds', CURRENT_TIMESTAMP)
+WHERE
+  author_id = $4
+  AND deleted_at IS NULL
+  AND (version = $5::bigint OR $5::bigint IS NULL)
+RETURNING author_id, first_name, last_name, middle_name, deleted_at, version, created_at, updated_at
+`
+
+type UpdateAuthorParams struct {
+	FirstName  sql.NullString `json:"first_name"`
+	LastName   sql.NullString `json:"last_name"`
+	MiddleName sql.NullString `json:"middle_name"`
+	AuthorID   int64          `json:"author_id"`
+	Version    sql.NullInt64  `json:"version"`
+}
+
+func (q *Queries) UpdateAuthor(ctx context.Context, arg UpdateAuthorParams) (Author, error) {
+	row := q.db.QueryRowContext(ctx, updateAuthor,
+		arg.FirstName,
+		arg.LastName,
+		arg.MiddleName,
+		arg.AuthorID,
+		arg.Version,
+	)
+	var i Author
+	err := row.Scan(
+		&i.AuthorID,
+		&i.FirstName,
+		&i.LastName,
+		&i.MiddleName,
+		&i.DeletedAt,
+		&i.Version,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
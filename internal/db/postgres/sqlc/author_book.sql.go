@@ -0,0 +1,147 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.26.0
+// source: author_book.sql
+
+package pgdb
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+)
+
+const createAuthorBookRel = `-- name: CreateAuthorBookRel :exec
+INSERT INTO author_book (
+  author_id,
+  book_id,
+  role,
+  position
+) VALUES (
+  $1, $2, COALESCE($3::text, 'author'), COALESCE($4::bigint, 0)
+)
+ON CONFLICT DO NOTHING
+`
+
+type CreateAuthorBookRelParams struct {
+	AuthorID int64          `json:"author_id"`
+	BookID   int64          `json:"book_id"`
+	Role     sql.NullString `json:"role"`
+	Position sql.NullInt64  `json:"position"`
+}
+
+func (q *Queries) CreateAuthorBookRel(ctx context.Context, arg CreateAuthorBookRelParams) error {
+	_, err := q.db.ExecContext(ctx, createAuthorBookRel,
+		arg.AuthorID,
+		arg.BookID,
+		arg.Role,
+		arg.Position,
+	)
+	return err
+}
+
+const deletePurgedAuthorBookRels = `-- name: DeletePurgedAuthorBookRels :exec
+DELETE FROM author_book
+WHERE book_id IN (SELECT book_id FROM books WHERE deleted_at < $1)
+`
+
+func (q *Queries) DeletePurgedAuthorBookRels(ctx context.Context, deletedBefore sql.NullTime) error {
+	_, err := q.db.ExecContext(ctx, deletePurgedAuthorBookRels, deletedBefore)
+	return err
+}
+
+const listAuthorsWithBookID = `-- name: ListAuthorsWithBookID :many
+SELECT a.author_id, a.first_name, a.last_name, a.middle_name, a.deleted_at, a.version, a.created_at, a.updated_at
+FROM
+  author_book ab
+  JOIN authors a ON ab.author_id = a.author_id
+WHERE ab.book_id = $1
+ORDER BY ab.position
+`
+
+type ListAuthorsWithBookIDRow struct {
+	Author Author `json:"author"`
+}
+
+func (q *Queries) ListAuthorsWithBookID(ctx context.Context, bookID int64) ([]ListAuthorsWithBookIDRow, error) {
+	rows, err := q.db.QueryContext(ctx, listAuthorsWithBookID, bookID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListAuthorsWithBookIDRow{}
+	for rows.Next() {
+		var i ListAuthorsWithBookIDRow
+		if err := rows.Scan(
+			&i.Author.AuthorID,
+			&i.Author.FirstName,
+			&i.Author.LastName,
+			&i.Author.MiddleName,
+			&i.Author.DeletedAt,
+			&i.Author.Version,
+			&i.Author.CreatedAt,
+			&i.Author.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listBookAuthors = `-- name: ListBookAuthors :many
+SELECT
+  ab.book_id,
+  a.author_id, a.first_name, a.last_name, a.middle_name, a.deleted_at, a.version, a.created_at, a.updated_at
+FROM
+  author_book ab
+  JOIN authors a ON ab.author_id = a.author_id
+WHERE
+  ab.book_id IN (SELECT jsonb_array_elements_text($1::jsonb)::bigint)
+  AND ab.role = 'author'
+ORDER BY ab.book_id, ab.position
+`
+
+type ListBookAuthorsRow struct {
+	BookID int64  `json:"book_id"`
+	Author Author `json:"author"`
+}
+
+func (q *Queries) ListBookAuthors(ctx context.Context, bookIds json.RawMessage) ([]ListBookAuthorsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listBookAuthors, bookIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListBookAuthorsRow{}
+	for rows.Next() {
+		var i ListBookAuthorsRow
+		if err := rows.Scan(
+			&i.BookID,
+			&i.Author.AuthorID,
+			&i.Author.FirstName,
+			&i.Author.LastName,
+			&i.Author.MiddleName,
+			&i.Author.DeletedAt,
+			&i.Author.Version,
+			&i.Author.CreatedAt,
+			&i.Author.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
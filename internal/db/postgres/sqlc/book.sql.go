@@ -0,0 +1,837 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.26.0
+// source: book.sql
+
+package pgdb
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+)
+
+const bumpAuthorBookVersions = `-- name: BumpAuthorBookVersions :exec
+UPDATE books
+SET
+  version = version + 1,
+  updated_at = date_trunc('milliseconds', CURRENT_TIMESTAMP)
+WHERE book_id IN (SELECT ab.book_id FROM author_book ab WHERE ab.author_id = $1)
+`
+
+func (q *Queries) BumpAuthorBookVersions(ctx context.Context, authorID int64) error {
+	_, err := q.db.ExecContext(ctx, bumpAuthorBookVersions, authorID)
+	return err
+}
+
+const bumpPublisherBookVersions = `-- name: BumpPublisherBookVersions :exec
+UPDATE books
+SET
+  version = version + 1,
+  updated_at = date_trunc('milliseconds', CURRENT_TIMESTAMP)
+WHERE publisher_id = $1
+`
+
+func (q *Queries) BumpPublisherBookVersions(ctx context.Context, publisherID int64) error {
+	_, err := q.db.ExecContext(ctx, bumpPublisherBookVersions, publisherID)
+	return err
+}
+
+const countBooks = `-- name: CountBooks :one
+SELECT
+  COUNT(DISTINCT b.book_id)
+FROM
+  books b
+JOIN author_book ab ON b.book_id = ab.book_id
+JOIN authors a ON ab.author_id = a.author_id
+JOIN publishers p ON b.publisher_id = p.publisher_id
+WHERE
+  (b.title ILIKE '%' || $1::text || '%' OR $1::text IS NULL)
+  AND (b.price >= $2::float8 OR $2::float8 IS NULL)
+  AND (b.price <= $3::float8 OR $3::float8 IS NULL)
+  AND (b.publication_year >= $4::bigint OR $4::bigint IS NULL)
+  AND (b.publication_year <= $5::bigint OR $5::bigint IS NULL)
+  AND ((ab.role = 'author' AND a.first_name || ' ' || a.middle_name || ' ' || a.last_name ILIKE '%' || $6::text || '%') OR $6::text IS NULL)
+  AND (p.publisher_name ILIKE '%' || $7::text || '%' OR $7::text IS NULL)
+  AND (b.language = $8::text OR b.language ILIKE $8::text || '-%' OR $8::text IS NULL)
+  AND (b.format = $9::text OR $9::text IS NULL)
+  AND (b.page_count >= $10::bigint OR $10::bigint IS NULL)
+  AND (b.page_count <= $11::bigint OR $11::bigint IS NULL)
+  AND (b.series_name ILIKE '%' || $12::text || '%' OR $12::text IS NULL)
+  AND (b.series_number = $13::bigint OR $13::bigint IS NULL)
+  AND (b.description ILIKE '%' || $14::text || '%' OR $14::text IS NULL)
+  AND (EXISTS (
+    SELECT 1 FROM book_subject bs JOIN subjects s ON bs.subject_id = s.subject_id
+    WHERE bs.book_id = b.book_id AND lower(s.subject_name) = lower($15::text)
+  ) OR $15::text IS NULL)
+  AND (b.deleted_at IS NULL OR $16::boolean)
+  AND (b.updated_at > $17::timestamptz OR $17::timestamptz IS NULL)
+`
+
+type CountBooksParams struct {
+	Title              sql.NullString  `json:"title"`
+	MinPrice           sql.NullFloat64 `json:"min_price"`
+	MaxPrice           sql.NullFloat64 `json:"max_price"`
+	MinPublicationYear sql.NullInt64   `json:"min_publication_year"`
+	MaxPublicationYear sql.NullInt64   `json:"max_publication_year"`
+	Author             sql.NullString  `json:"author"`
+	Publisher          sql.NullString  `json:"publisher"`
+	Language           sql.NullString  `json:"language"`
+	Format             sql.NullString  `json:"format"`
+	MinPageCount       sql.NullInt64   `json:"min_page_count"`
+	MaxPageCount       sql.NullInt64   `json:"max_page_count"`
+	SeriesName         sql.NullString  `json:"series_name"`
+	SeriesNumber       sql.NullInt64   `json:"series_number"`
+	Description        sql.NullString  `json:"description"`
+	Subject            sql.NullString  `json:"subject"`
+	IncludeDeleted     bool            `json:"include_deleted"`
+	UpdatedSince       sql.NullTime    `json:"updated_since"`
+}
+
+func (q *Queries) CountBooks(ctx context.Context, arg CountBooksParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countBooks,
+		arg.Title,
+		arg.MinPrice,
+		arg.MaxPrice,
+		arg.MinPublicationYear,
+		arg.MaxPublicationYear,
+		arg.Author,
+		arg.Publisher,
+		arg.Language,
+		arg.Format,
+		arg.MinPageCount,
+		arg.MaxPageCount,
+		arg.SeriesName,
+		arg.SeriesNumber,
+		arg.Description,
+		arg.Subject,
+		arg.IncludeDeleted,
+		arg.UpdatedSince,
+	)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const createBook = `-- name: CreateBook :one
+INSERT INTO books (
+  title,
+  isbn13,
+  isbn10,
+  price,
+  publication_year,
+  image_url,
+  edition,
+  publisher_id,
+  language,
+  format,
+  page_count,
+  series_name,
+  series_number,
+  description
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14
+) RETURNING book_id, title, isbn13, isbn10, price, publication_year, image_url, edition, publisher_id, cover_key, language, format, page_count, series_name, series_number, description, deleted_at, version, created_at, updated_at
+`
+
+type CreateBookParams struct {
+	Title           string         `json:"title"`
+	Isbn13          sql.NullString `json:"isbn13"`
+	Isbn10          sql.NullString `json:"isbn10"`
+	Price           float64        `json:"price"`
+	PublicationYear int64          `json:"publication_year"`
+	ImageUrl        sql.NullString `json:"image_url"`
+	Edition         sql.NullString `json:"edition"`
+	PublisherID     int64          `json:"publisher_id"`
+	Language        sql.NullString `json:"language"`
+	Format          sql.NullString `json:"format"`
+	PageCount       sql.NullInt64  `json:"page_count"`
+	SeriesName      sql.NullString `json:"series_name"`
+	SeriesNumber    sql.NullInt64  `json:"series_number"`
+	Description     sql.NullString `json:"description"`
+}
+
+func (q *Queries) CreateBook(ctx context.Context, arg CreateBookParams) (Book, error) {
+	row := q.db.QueryRowContext(ctx, createBook,
+		arg.Title,
+		arg.Isbn13,
+		arg.Isbn10,
+		arg.Price,
+		arg.PublicationYear,
+		arg.ImageUrl,
+		arg.Edition,
+		arg.PublisherID,
+		arg.Language,
+		arg.Format,
+		arg.PageCount,
+		arg.SeriesName,
+		arg.SeriesNumber,
+		arg.Description,
+	)
+	var i Book
+	err := row.Scan(
+		&i.BookID,
+		&i.Title,
+		&i.Isbn13,
+		&i.Isbn10,
+		&i.Price,
+		&i.PublicationYear,
+		&i.ImageUrl,
+		&i.Edition,
+		&i.PublisherID,
+		&i.CoverKey,
+		&i.Language,
+		&i.Format,
+		&i.PageCount,
+		&i.SeriesName,
+		&i.SeriesNumber,
+		&i.Description,
+		&i.DeletedAt,
+		&i.Version,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const deleteBookByISBN = `-- name: DeleteBookByISBN :execrows
+UPDATE books
+SET
+  deleted_at = CURRENT_TIMESTAMP,
+  version = version + 1,
+  updated_at = date_trunc('milliseconds', CURRENT_TIMESTAMP)
+WHERE
+  (isbn13 = $1::text OR isbn10 = $2::text)
+  AND deleted_at IS NULL
+  AND (version = $3::bigint OR $3::bigint IS NULL)
+`
+
+type DeleteBookByISBNParams struct {
+	Isbn13  sql.NullString `json:"isbn13"`
+	Isbn10  sql.NullString `json:"isbn10"`
+	Version sql.NullInt64  `json:"version"`
+}
+
+func (q *Queries) DeleteBookByISBN(ctx context.Context, arg DeleteBookByISBNParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteBookByISBN, arg.Isbn13, arg.Isbn10, arg.Version)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const getBookByISBN = `-- name: GetBookByISBN :one
+SELECT
+	b.book_id, b.title, b.isbn13, b.isbn10, b.price, b.publication_year, b.image_url, b.edition, b.publisher_id, b.cover_key, b.language, b.format, b.page_count, b.series_name, b.series_number, b.description, b.deleted_at, b.version, b.created_at, b.updated_at,
+	COALESCE((
+		SELECT string_agg(ca.first_name || CASE WHEN ca.middle_name <> '' THEN ' ' || ca.middle_name ELSE '' END || ' ' || ca.last_name, ',' ORDER BY cab.position)
+		FROM author_book cab JOIN authors ca ON cab.author_id = ca.author_id
+		WHERE cab.book_id = b.book_id AND cab.role = 'author'
+	), '')::text AS authors,
+	COALESCE((
+		SELECT string_agg(cab.role || ':' || ca.first_name || CASE WHEN ca.middle_name <> '' THEN ' ' || ca.middle_name ELSE '' END || ' ' || ca.last_name, ',' ORDER BY cab.position)
+		FROM author_book cab JOIN authors ca ON cab.author_id = ca.author_id
+		WHERE cab.book_id = b.book_id
+	), '')::text AS contributors,
+	p.publisher_name AS publisher_name,
+	COALESCE((
+		SELECT string_agg(s.subject_name, ',' ORDER BY s.subject_name)
+		FROM book_subject bs JOIN subjects s ON bs.subject_id = s.subject_id
+		WHERE bs.book_id = b.book_id
+	), '')::text AS subjects
+FROM
+	books AS b
+	JOIN author_book AS ab ON b.book_id = ab.book_id
+	JOIN authors AS a ON ab.author_id = a.author_id
+	JOIN publishers AS p ON b.publisher_id = p.publisher_id
+WHERE
+	(b.isbn13 = $1::text OR b.isbn10 = $2::text)
+	AND (b.deleted_at IS NULL OR $3::boolean)
+GROUP BY
+	b.book_id,
+	p.publisher_id
+`
+
+type GetBookByISBNParams struct {
+	Isbn13         sql.NullString `json:"isbn13"`
+	Isbn10         sql.NullString `json:"isbn10"`
+	IncludeDeleted bool           `json:"include_deleted"`
+}
+
+type GetBookByISBNRow struct {
+	Book          Book   `json:"book"`
+	Authors       string `json:"authors"`
+	Contributors  string `json:"contributors"`
+	PublisherName string `json:"publisher_name"`
+	Subjects      string `json:"subjects"`
+}
+
+func (q *Queries) GetBookByISBN(ctx context.Context, arg GetBookByISBNParams) (GetBookByISBNRow, error) {
+	row := q.db.QueryRowContext(ctx, getBookByISBN, arg.Isbn13, arg.Isbn10, arg.IncludeDeleted)
+	var i GetBookByISBNRow
+	err := row.Scan(
+		&i.Book.BookID,
+		&i.Book.Title,
+		&i.Book.Isbn13,
+		&i.Book.Isbn10,
+		&i.Book.Price,
+		&i.Book.PublicationYear,
+		&i.Book.ImageUrl,
+		&i.Book.Edition,
+		&i.Book.PublisherID,
+		&i.Book.CoverKey,
+		&i.Book.Language,
+		&i.Book.Format,
+		&i.Book.PageCount,
+		&i.Book.SeriesName,
+		&i.Book.SeriesNumber,
+		&i.Book.Description,
+		&i.Book.DeletedAt,
+		&i.Book.Version,
+		&i.Book.CreatedAt,
+		&i.Book.UpdatedAt,
+		&i.Authors,
+		&i.Contributors,
+		&i.PublisherName,
+		&i.Subjects,
+	)
+	return i, err
+}
+
+const listAuthorBooks = `-- name: ListAuthorBooks :many
+SELECT
+  ab.author_id,
+  b.book_id, b.title, b.isbn13, b.isbn10, b.price, b.publication_year, b.image_url, b.edition, b.publisher_id, b.cover_key, b.language, b.format, b.page_count, b.series_name, b.series_number, b.description, b.deleted_at, b.version, b.created_at, b.updated_at,
+  COALESCE((
+    SELECT string_agg(ca.first_name || CASE WHEN ca.middle_name <> '' THEN ' ' || ca.middle_name ELSE '' END || ' ' || ca.last_name, ',' ORDER BY cab.position)
+    FROM author_book cab JOIN authors ca ON cab.author_id = ca.author_id
+    WHERE cab.book_id = b.book_id AND cab.role = 'author'
+  ), '')::text AS authors,
+  COALESCE((
+    SELECT string_agg(cab.role || ':' || ca.first_name || CASE WHEN ca.middle_name <> '' THEN ' ' || ca.middle_name ELSE '' END || ' ' || ca.last_name, ',' ORDER BY cab.position)
+    FROM author_book cab JOIN authors ca ON cab.author_id = ca.author_id
+    WHERE cab.book_id = b.book_id
+  ), '')::text AS contributors,
+  p.publisher_name AS publisher_name,
+  COALESCE((
+    SELECT string_agg(s.subject_name, ',' ORDER BY s.subject_name)
+    FROM book_subject bs JOIN subjects s ON bs.subject_id = s.subject_id
+    WHERE bs.book_id = b.book_id
+  ), '')::text AS subjects
+FROM
+  books b
+JOIN author_book ab ON b.book_id = ab.book_id
+JOIN publishers p ON b.publisher_id = p.publisher_id
+WHERE
+  ab.author_id IN (SELECT jsonb_array_elements_text($1::jsonb)::bigint)
+  AND ab.role = 'author'
+  AND b.deleted_at IS NULL
+ORDER BY ab.author_id, b.publication_year, b.book_id
+`
+
+type ListAuthorBooksRow struct {
+	AuthorID      int64  `json:"author_id"`
+	Book          Book   `json:"book"`
+	Authors       string `json:"authors"`
+	Contributors  string `json:"contributors"`
+	PublisherName string `json:"publisher_name"`
+	Subjects      string `json:"subjects"`
+}
+
+func (q *Queries) ListAuthorBooks(ctx context.Context, authorIds json.RawMessage) ([]ListAuthorBooksRow, error) {
+	rows, err := q.db.QueryContext(ctx, listAuthorBooks, authorIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListAuthorBooksRow{}
+	for rows.Next() {
+		var i ListAuthorBooksRow
+		if err := rows.Scan(
+			&i.AuthorID,
+			&i.Book.BookID,
+			&i.Book.Title,
+			&i.Book.Isbn13,
+			&i.Book.Isbn10,
+			&i.Book.Price,
+			&i.Book.PublicationYear,
+			&i.Book.ImageUrl,
+			&i.Book.Edition,
+			&i.Book.PublisherID,
+			&i.Book.CoverKey,
+			&i.Book.Language,
+			&i.Book.Format,
+			&i.Book.PageCount,
+			&i.Book.SeriesName,
+			&i.Book.SeriesNumber,
+			&i.Book.Description,
+			&i.Book.DeletedAt,
+			&i.Book.Version,
+			&i.Book.CreatedAt,
+			&i.Book.UpdatedAt,
+			&i.Authors,
+			&i.Contributors,
+			&i.PublisherName,
+			&i.Subjects,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listBooks = `-- name: ListBooks :many
+SELECT
+  b.book_id, b.title, b.isbn13, b.isbn10, b.price, b.publication_year, b.image_url, b.edition, b.publisher_id, b.cover_key, b.language, b.format, b.page_count, b.series_name, b.series_number, b.description, b.deleted_at, b.version, b.created_at, b.updated_at,
+  COALESCE((
+    SELECT string_agg(ca.first_name || CASE WHEN ca.middle_name <> '' THEN ' ' || ca.middle_name ELSE '' END || ' ' || ca.last_name, ',' ORDER BY cab.position)
+    FROM author_book cab JOIN authors ca ON cab.author_id = ca.author_id
+    WHERE cab.book_id = b.book_id AND cab.role = 'author'
+  ), '')::text AS authors,
+  COALESCE((
+    SELECT string_agg(cab.role || ':' || ca.first_name || CASE WHEN ca.middle_name <> '' THEN ' ' || ca.middle_name ELSE '' END || ' ' || ca.last_name, ',' ORDER BY cab.position)
+    FROM author_book cab JOIN authors ca ON cab.author_id = ca.author_id
+    WHERE cab.book_id = b.book_id
+  ), '')::text AS contributors,
+  p.publisher_name AS publisher_name,
+  COALESCE((
+    SELECT string_agg(s.subject_name, ',' ORDER BY s.subject_name)
+    FROM book_subject bs JOIN subjects s ON bs.subject_id = s.subject_id
+    WHERE bs.book_id = b.book_id
+  ), '')::text AS subjects
+FROM
+  books b
+JOIN author_book ab ON b.book_id = ab.book_id
+JOIN authors a ON ab.author_id = a.author_id
+JOIN publishers p ON b.publisher_id = p.publisher_id
+WHERE
+  (b.title ILIKE '%' || $1::text || '%' OR $1::text IS NULL)
+  AND (b.price >= $2::float8 OR $2::float8 IS NULL)
+  AND (b.price <= $3::float8 OR $3::float8 IS NULL)
+  AND (b.publication_year >= $4::bigint OR $4::bigint IS NULL)
+  AND (b.publication_year <= $5::bigint OR $5::bigint IS NULL)
+  AND ((ab.role = 'author' AND a.first_name || ' ' || a.middle_name || ' ' || a.last_name ILIKE '%' || $6::text || '%') OR $6::text IS NULL)
+  AND (p.publisher_name ILIKE '%' || $7::text || '%' OR $7::text IS NULL)
+  AND (b.language = $8::text OR b.language ILIKE $8::text || '-%' OR $8::text IS NULL)
+  AND (b.format = $9::text OR $9::text IS NULL)
+  AND (b.page_count >= $10::bigint OR $10::bigint IS NULL)
+  AND (b.page_count <= $11::bigint OR $11::bigint IS NULL)
+  AND (b.series_name ILIKE '%' || $12::text || '%' OR $12::text IS NULL)
+  AND (b.series_number = $13::bigint OR $13::bigint IS NULL)
+  AND (b.description ILIKE '%' || $14::text || '%' OR $14::text IS NULL)
+  AND (EXISTS (
+    SELECT 1 FROM book_subject bs JOIN subjects s ON bs.subject_id = s.subject_id
+    WHERE bs.book_id = b.book_id AND lower(s.subject_name) = lower($15::text)
+  ) OR $15::text IS NULL)
+  AND (b.deleted_at IS NULL OR $16::boolean)
+  AND (b.updated_at > $17::timestamptz OR $17::timestamptz IS NULL)
+  AND (b.title > $18::text OR (b.title = $18::text AND b.book_id > $19::bigint) OR $18::text IS NULL)
+GROUP BY
+	b.book_id,
+	p.publisher_id
+ORDER BY b.title, b.book_id
+LIMIT $21::bigint
+OFFSET $20::bigint
+`
+
+type ListBooksParams struct {
+	Title              sql.NullString  `json:"title"`
+	MinPrice           sql.NullFloat64 `json:"min_price"`
+	MaxPrice           sql.NullFloat64 `json:"max_price"`
+	MinPublicationYear sql.NullInt64   `json:"min_publication_year"`
+	MaxPublicationYear sql.NullInt64   `json:"max_publication_year"`
+	Author             sql.NullString  `json:"author"`
+	Publisher          sql.NullString  `json:"publisher"`
+	Language           sql.NullString  `json:"language"`
+	Format             sql.NullString  `json:"format"`
+	MinPageCount       sql.NullInt64   `json:"min_page_count"`
+	MaxPageCount       sql.NullInt64   `json:"max_page_count"`
+	SeriesName         sql.NullString  `json:"series_name"`
+	SeriesNumber       sql.NullInt64   `json:"series_number"`
+	Description        sql.NullString  `json:"description"`
+	Subject            sql.NullString  `json:"subject"`
+	IncludeDeleted     bool            `json:"include_deleted"`
+	UpdatedSince       sql.NullTime    `json:"updated_since"`
+	AfterTitle         sql.NullString  `json:"after_title"`
+	AfterID            sql.NullInt64   `json:"after_id"`
+	Offset             int64           `json:"offset"`
+	Limit              int64           `json:"limit"`
+}
+
+type ListBooksRow struct {
+	Book          Book   `json:"book"`
+	Authors       string `json:"authors"`
+	Contributors  string `json:"contributors"`
+	PublisherName string `json:"publisher_name"`
+	Subjects      string `json:"subjects"`
+}
+
+func (q *Queries) ListBooks(ctx context.Context, arg ListBooksParams) ([]ListBooksRow, error) {
+	rows, err := q.db.QueryContext(ctx, listBooks,
+		arg.Title,
+		arg.MinPrice,
+		arg.MaxPrice,
+		arg.MinPublicationYear,
+		arg.MaxPublicationYear,
+		arg.Author,
+		arg.Publisher,
+		arg.Language,
+		arg.Format,
+		arg.MinPageCount,
+		arg.MaxPageCount,
+		arg.SeriesName,
+		arg.SeriesNumber,
+		arg.Description,
+		arg.Subject,
+		arg.IncludeDeleted,
+		arg.UpdatedSince,
+		arg.AfterTitle,
+		arg.AfterID,
+		arg.Offset,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListBooksRow{}
+	for rows.Next() {
+		var i ListBooksRow
+		if err := rows.Scan(
+			&i.Book.BookID,
+			&i.Book.Title,
+			&i.Book.Isbn13,
+			&i.Book.Isbn10,
+			&i.Book.Price,
+			&i.Book.PublicationYear,
+			&i.Book.ImageUrl,
+			&i.Book.Edition,
+			&i.Book.PublisherID,
+			&i.Book.CoverKey,
+			&i.Book.Language,
+			&i.Book.Format,
+			&i.Book.PageCount,
+			&i.Book.SeriesName,
+			&i.Book.SeriesNumber,
+			&i.Book.Description,
+			&i.Book.DeletedAt,
+			&i.Book.Version,
+			&i.Book.CreatedAt,
+			&i.Book.UpdatedAt,
+			&i.Authors,
+			&i.Contributors,
+			&i.PublisherName,
+			&i.Subjects,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPublisherBooks = `-- name: ListPublisherBooks :many
+SELECT
+  b.publisher_id,
+  b.book_id, b.title, b.isbn13, b.isbn10, b.price, b.publication_year, b.image_url, b.edition, b.publisher_id, b.cover_key, b.language, b.format, b.page_count, b.series_name, b.series_number, b.description, b.deleted_at, b.version, b.created_at, b.updated_at,
+  COALESCE((
+    SELECT string_agg(ca.first_name || CASE WHEN ca.middle_name <> '' THEN ' ' || ca.middle_name ELSE '' END || ' ' || ca.last_name, ',' ORDER BY cab.position)
+    FROM author_book cab JOIN authors ca ON cab.author_id = ca.author_id
+    WHERE cab.book_id = b.book_id AND cab.role = 'author'
+  ), '')::text AS authors,
+  COALESCE((
+    SELECT string_agg(cab.role || ':' || ca.first_name || CASE WHEN ca.middle_name <> '' THEN ' ' || ca.middle_name ELSE '' END || ' ' || ca.last_name, ',' ORDER BY cab.position)
+    FROM author_book cab JOIN authors ca ON cab.author_id = ca.author_id
+    WHERE cab.book_id = b.book_id
+  ), '')::text AS contributors,
+  p.publisher_name AS publisher_name,
+  COALESCE((
+    SELECT string_agg(s.subject_name, ',' ORDER BY s.subject_name)
+    FROM book_subject bs JOIN subjects s ON bs.subject_id = s.subject_id
+    WHERE bs.book_id = b.book_id
+  ), '')::text AS subjects
+FROM
+  books b
+JOIN publishers p ON b.publisher_id = p.publisher_id
+WHERE
+  b.publisher_id IN (SELECT jsonb_array_elements_text($1::jsonb)::bigint)
+  AND b.deleted_at IS NULL
+ORDER BY b.publisher_id, b.publication_year, b.book_id
+`
+
+type ListPublisherBooksRow struct {
+	PublisherID   int64  `json:"publisher_id"`
+	Book          Book   `json:"book"`
+	Authors       string `json:"authors"`
+	Contributors  string `json:"contributors"`
+	PublisherName string `json:"publisher_name"`
+	Subjects      string `json:"subjects"`
+}
+
+func (q *Queries) ListPublisherBooks(ctx context.Context, publisherIds json.RawMessage) ([]ListPublisherBooksRow, error) {
+	rows, err := q.db.QueryContext(ctx, listPublisherBooks, publisherIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListPublisherBooksRow{}
+	for rows.Next() {
+		var i ListPublisherBooksRow
+		if err := rows.Scan(
+			&i.PublisherID,
+			&i.Book.BookID,
+			&i.Book.Title,
+			&i.Book.Isbn13,
+			&i.Book.Isbn10,
+			&i.Book.Price,
+			&i.Book.PublicationYear,
+			&i.Book.ImageUrl,
+			&i.Book.Edition,
+			&i.Book.PublisherID,
+			&i.Book.CoverKey,
+			&i.Book.Language,
+			&i.Book.Format,
+			&i.Book.PageCount,
+			&i.Book.SeriesName,
+			&i.Book.SeriesNumber,
+			&i.Book.Description,
+			&i.Book.DeletedAt,
+			&i.Book.Version,
+			&i.Book.CreatedAt,
+			&i.Book.UpdatedAt,
+			&i.Authors,
+			&i.Contributors,
+			&i.PublisherName,
+			&i.Subjects,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const purgeBooks = `-- name: PurgeBooks :execrows
+DELETE FROM books
+WHERE deleted_at < $1
+`
+
+func (q *Queries) PurgeBooks(ctx context.Context, deletedBefore sql.NullTime) (int64, error) {
+	result, err := q.db.ExecContext(ctx, purgeBooks, deletedBefore)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const restoreBookByISBN = `-- name: RestoreBookByISBN :one
+UPDATE books
+SET
+  deleted_at = NULL,
+  version = version + 1,
+  updated_at = date_trunc('milliseconds', CURRENT_TIMESTAMP)
+WHERE
+  (isbn13 = $1 OR isbn10 = $2)
+  AND deleted_at IS NOT NULL
+RETURNING book_id, title, isbn13, isbn10, price, publication_year, image_url, edition, publisher_id, cover_key, language, format, page_count, series_name, series_number, description, deleted_at, version, created_at, updated_at
+`
+
+type RestoreBookByISBNParams struct {
+	Isbn13 sql.NullString `json:"isbn13"`
+	Isbn10 sql.NullString `json:"isbn10"`
+}
+
+func (q *Queries) RestoreBookByISBN(ctx context.Context, arg RestoreBookByISBNParams) (Book, error) {
+	row := q.db.QueryRowContext(ctx, restoreBookByISBN, arg.Isbn13, arg.Isbn10)
+	var i Book
+	err := row.Scan(
+		&i.BookID,
+		&i.Title,
+		&i.Isbn13,
+		&i.Isbn10,
+		&i.Price,
+		&i.PublicationYear,
+		&i.ImageUrl,
+		&i.Edition,
+		&i.PublisherID,
+		&i.CoverKey,
+		&i.Language,
+		&i.Format,
+		&i.PageCount,
+		&i.SeriesName,
+		&i.SeriesNumber,
+		&i.Description,
+		&i.DeletedAt,
+		&i.Version,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const setBookCover = `-- name: SetBookCover :one
+UPDATE books
+SET
+  cover_key = $1::text,
+  version = version + 1,
+  updated_at = date_trunc('milliseconds', CURRENT_TIMESTAMP)
+WHERE
+  book_id = $2
+RETURNING book_id, title, isbn13, isbn10, price, publication_year, image_url, edition, publisher_id, cover_key, language, format, page_count, series_name, series_number, description, deleted_at, version, created_at, updated_at
+`
+
+type SetBookCoverParams struct {
+	CoverKey sql.NullString `json:"cover_key"`
+	BookID   int64          `json:"book_id"`
+}
+
+func (q *Queries) SetBookCover(ctx context.Context, arg SetBookCoverParams) (Book, error) {
+	row := q.db.QueryRowContext(ctx, setBookCover, arg.CoverKey, arg.BookID)
+	var i Book
+	err := row.Scan(
+		&i.BookID,
+		&i.Title,
+		&i.Isbn13,
+		&i.Isbn10,
+		&i.Price,
+		&i.PublicationYear,
+		&i.ImageUrl,
+		&i.Edition,
+		&i.PublisherID,
+		&i.CoverKey,
+		&i.Language,
+		&i.Format,
+		&i.PageCount,
+		&i.SeriesName,
+		&i.SeriesNumber,
+		&i.Description,
+		&i.DeletedAt,
+		&i.Version,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const updateBookByISBN = `-- name: UpdateBookByISBN :one
+UPDATE books
+SET
+  title = COALESCE($1::text, title),
+  isbn13 = CASE WHEN $2::boolean THEN NULL ELSE COALESCE($3::text, isbn13) END,
+  isbn10 = CASE WHEN $4::boolean THEN NULL ELSE COALESCE($5::text, isbn10) END,
+  price = COALESCE($6::float8, price),
+  publication_year = COALESCE($7::bigint, publication_year),
+  image_url = CASE WHEN $8::boolean THEN NULL ELSE COALESCE($9::text, image_url) END,
+  language = CASE WHEN $10::boolean THEN NULL ELSE COALESCE($11::text, language) END,
+  format = CASE WHEN $12::boolean THEN NULL ELSE COALESCE($13::text, format) END,
+  page_count = CASE WHEN $14::boolean THEN NULL ELSE COALESCE($15::bigint, page_count) END,
+  series_name = CASE WHEN $16::boolean THEN NULL ELSE COALESCE($17::text, series_name) END,
+  series_number = CASE WHEN $18::boolean THEN NULL ELSE COALESCE($19::bigint, series_number) END,
+  description = CASE WHEN $20::boolean THEN NULL ELSE COALESCE($21::text, description) END,
+  version = version + 1,
+  updated_at = date_trunc('milliseconds', CURRENT_TIMESTAMP)
+WHERE
+  (isbn13 = $22 OR isbn10 = $23)
+  AND deleted_at IS NULL
+  AND (version = $24::bigint OR $24::bigint IS NULL)
+RETURNING book_id, title, isbn13, isbn10, price, publication_year, image_url, edition, publisher_id, cover_key, language, format, page_count, series_name, series_number, description, deleted_at, version, created_at, updated_at
+`
+
+type UpdateBookByISBNParams struct {
+	Title             sql.NullString  `json:"title"`
+	ClearIsbn13       bool            `json:"clear_isbn13"`
+	NewIsbn13         sql.NullString  `json:"new_isbn13"`
+	ClearIsbn10       bool            `json:"clear_isbn10"`
+	NewIsbn10         sql.NullString  `json:"new_isbn10"`
+	Price             sql.NullFloat64 `json:"price"`
+	PublicationYear   sql.NullInt64   `json:"publication_year"`
+	ClearImageUrl     bool            `json:"clear_image_url"`
+	ImageUrl          sql.NullString  `json:"image_url"`
+	ClearLanguage     bool            `json:"clear_language"`
+	Language          sql.NullString  `json:"language"`
+	ClearFormat       bool            `json:"clear_format"`
+	Format            sql.NullString  `json:"format"`
+	ClearPageCount    bool            `json:"clear_page_count"`
+	PageCount         sql.NullInt64   `json:"page_count"`
+	ClearSeriesName   bool            `json:"clear_series_name"`
+	SeriesName        sql.NullString  `json:"series_name"`
+	ClearSeriesNumber bool            `json:"clear_series_number"`
+	SeriesNumber      sql.NullInt64   `json:"series_number"`
+	ClearDescription  bool            `json:"clear_description"`
+	Description       sql.NullString  `json:"description"`
+	Isbn13            sql.NullString  `json:"isbn13"`
+	Isbn10            sql.NullString  `json:"isbn10"`
+	Version           sql.NullInt64   `json:"version"`
+}
+
+func (q *Queries) UpdateBookByISBN(ctx context.Context, arg UpdateBookByISBNParams) (Book, error) {
+	row := q.db.QueryRowContext(ctx, updateBookByISBN,
+		arg.Title,
+		arg.ClearIsbn13,
+		arg.NewIsbn13,
+		arg.ClearIsbn10,
+		arg.NewIsbn10,
+		arg.Price,
+		arg.PublicationYear,
+		arg.ClearImageUrl,
+		arg.ImageUrl,
+		arg.ClearLanguage,
+		arg.Language,
+		arg.ClearFormat,
+		arg.Format,
+		arg.ClearPageCount,
+		arg.PageCount,
+		arg.ClearSeriesName,
+		arg.SeriesName,
+		arg.ClearSeriesNumber,
+		arg.SeriesNumber,
+		arg.ClearDescription,
+		arg.Description,
+		arg.Isbn13,
+		arg.Isbn10,
+		arg.Version,
+	)
+	var i Book
+	err := row.Scan(
+		&i.BookID,
+		&i.Title,
+		&i.Isbn13,
+		&i.Isbn10,
+		&i.Price,
+		&i.PublicationYear,
+		&i.ImageUrl,
+		&i.Edition,
+		&i.PublisherID,
+		&i.CoverKey,
+		&i.Language,
+		&i.Format,
+		&i.PageCount,
+		&i.SeriesName,
+		&i.SeriesNumber,
+		&i.Description,
+		&i.DeletedAt,
+		&i.Version,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
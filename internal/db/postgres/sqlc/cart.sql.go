@@ -0,0 +1,253 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.26.0
+// source: cart.sql
+
+package pgdb
+
+import (
+	"context"
+	"database/sql"
+)
+
+const addCartItem = `-- name: AddCartItem :exec
+INSERT INTO cart_items (
+  cart_id,
+  book_id,
+  quantity
+) VALUES (
+  $1, $2, $3
+)
+ON CONFLICT (cart_id, book_id) DO UPDATE
+SET quantity = LEAST(cart_items.quantity + excluded.quantity, 99)
+`
+
+type AddCartItemParams struct {
+	CartID   int64 `json:"cart_id"`
+	BookID   int64 `json:"book_id"`
+	Quantity int64 `json:"quantity"`
+}
+
+// Adds to the quantity of a book in a cart, up to the 99 that AddCartItemReq allows
+func (q *Queries) AddCartItem(ctx context.Context, arg AddCartItemParams) error {
+	_, err := q.db.ExecContext(ctx, addCartItem, arg.CartID, arg.BookID, arg.Quantity)
+	return err
+}
+
+const clearCartItems = `-- name: ClearCartItems :exec
+DELETE FROM cart_items WHERE cart_id = $1
+`
+
+func (q *Queries) ClearCartItems(ctx context.Context, cartID int64) error {
+	_, err := q.db.ExecContext(ctx, clearCartItems, cartID)
+	return err
+}
+
+const createCart = `-- name: CreateCart :one
+INSERT INTO carts (
+  token,
+  user_id
+) VALUES (
+  $1, $2
+) RETURNING cart_id, token, user_id
+`
+
+type CreateCartParams struct {
+	Token  string         `json:"token"`
+	UserID sql.NullString `json:"user_id"`
+}
+
+func (q *Queries) CreateCart(ctx context.Context, arg CreateCartParams) (Cart, error) {
+	row := q.db.QueryRowContext(ctx, createCart, arg.Token, arg.UserID)
+	var i Cart
+	err := row.Scan(&i.CartID, &i.Token, &i.UserID)
+	return i, err
+}
+
+const deleteCart = `-- name: DeleteCart :exec
+DELETE FROM carts WHERE cart_id = $1
+`
+
+func (q *Queries) DeleteCart(ctx context.Context, cartID int64) error {
+	_, err := q.db.ExecContext(ctx, deleteCart, cartID)
+	return err
+}
+
+const deleteCartItem = `-- name: DeleteCartItem :exec
+DELETE FROM cart_items
+WHERE cart_id = $1 AND book_id = $2
+`
+
+type DeleteCartItemParams struct {
+	CartID int64 `json:"cart_id"`
+	BookID int64 `json:"book_id"`
+}
+
+func (q *Queries) DeleteCartItem(ctx context.Context, arg DeleteCartItemParams) error {
+	_, err := q.db.ExecContext(ctx, deleteCartItem, arg.CartID, arg.BookID)
+	return err
+}
+
+const deletePurgedCartItems = `-- name: DeletePurgedCartItems :exec
+DELETE FROM cart_items
+WHERE book_id IN (SELECT book_id FROM books WHERE deleted_at < $1)
+`
+
+func (q *Queries) DeletePurgedCartItems(ctx context.Context, deletedBefore sql.NullTime) error {
+	_, err := q.db.ExecContext(ctx, deletePurgedCartItems, deletedBefore)
+	return err
+}
+
+const getCartByToken = `-- name: GetCartByToken :one
+SELECT cart_id, token, user_id FROM carts
+WHERE token = $1 LIMIT 1
+`
+
+func (q *Queries) GetCartByToken(ctx context.Context, token string) (Cart, error) {
+	row := q.db.QueryRowContext(ctx, getCartByToken, token)
+	var i Cart
+	err := row.Scan(&i.CartID, &i.Token, &i.UserID)
+	return i, err
+}
+
+const getCartByUserID = `-- name: GetCartByUserID :one
+SELECT cart_id, token, user_id FROM carts
+WHERE user_id = $1 LIMIT 1
+`
+
+func (q *Queries) GetCartByUserID(ctx context.Context, userID sql.NullString) (Cart, error) {
+	row := q.db.QueryRowContext(ctx, getCartByUserID, userID)
+	var i Cart
+	err := row.Scan(&i.CartID, &i.Token, &i.UserID)
+	return i, err
+}
+
+const listCartItems = `-- name: ListCartItems :many
+SELECT
+  b.book_id, b.title, b.isbn13, b.isbn10, b.price, b.publication_year, b.image_url, b.edition, b.publisher_id, b.cover_key, b.language, b.format, b.page_count, b.series_name, b.series_number, b.description, b.deleted_at, b.version, b.created_at, b.updated_at,
+  ci.quantity
+FROM
+  cart_items ci
+  JOIN books b ON ci.book_id = b.book_id
+WHERE b.deleted_at IS NULL AND ci.cart_id = $1
+ORDER BY b.title
+`
+
+type ListCartItemsRow struct {
+	Book     Book  `json:"book"`
+	Quantity int64 `json:"quantity"`
+}
+
+func (q *Queries) ListCartItems(ctx context.Context, cartID int64) ([]ListCartItemsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listCartItems, cartID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListCartItemsRow{}
+	for rows.Next() {
+		var i ListCartItemsRow
+		if err := rows.Scan(
+			&i.Book.BookID,
+			&i.Book.Title,
+			&i.Book.Isbn13,
+			&i.Book.Isbn10,
+			&i.Book.Price,
+			&i.Book.PublicationYear,
+			&i.Book.ImageUrl,
+			&i.Book.Edition,
+			&i.Book.PublisherID,
+			&i.Book.CoverKey,
+			&i.Book.Language,
+			&i.Book.Format,
+			&i.Book.PageCount,
+			&i.Book.SeriesName,
+			&i.Book.SeriesNumber,
+			&i.Book.Description,
+			&i.Book.DeletedAt,
+			&i.Book.Version,
+			&i.Book.CreatedAt,
+			&i.Book.UpdatedAt,
+			&i.Quantity,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const mergeCartItems = `-- name: MergeCartItems :exec
+INSERT INTO cart_items (
+  cart_id,
+  book_id,
+  quantity
+)
+SELECT
+  $1::bigint, ci.book_id, ci.quantity
+FROM
+  cart_items ci
+WHERE ci.cart_id = $2
+ON CONFLICT (cart_id, book_id) DO UPDATE
+SET quantity = LEAST(cart_items.quantity + excluded.quantity, 99)
+`
+
+type MergeCartItemsParams struct {
+	ToCartID   int64 `json:"to_cart_id"`
+	FromCartID int64 `json:"from_cart_id"`
+}
+
+// Adds the items of a cart to another, up to 99 of a book
+func (q *Queries) MergeCartItems(ctx context.Context, arg MergeCartItemsParams) error {
+	_, err := q.db.ExecContext(ctx, mergeCartItems, arg.ToCartID, arg.FromCartID)
+	return err
+}
+
+const setCartItemQuantity = `-- name: SetCartItemQuantity :execrows
+UPDATE cart_items
+SET
+  quantity = $1
+WHERE
+  cart_id = $2 AND book_id = $3
+`
+
+type SetCartItemQuantityParams struct {
+	Quantity int64 `json:"quantity"`
+	CartID   int64 `json:"cart_id"`
+	BookID   int64 `json:"book_id"`
+}
+
+func (q *Queries) SetCartItemQuantity(ctx context.Context, arg SetCartItemQuantityParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, setCartItemQuantity, arg.Quantity, arg.CartID, arg.BookID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const setCartUserID = `-- name: SetCartUserID :one
+UPDATE carts
+SET
+  user_id = $1
+WHERE
+  cart_id = $2
+RETURNING cart_id, token, user_id
+`
+
+type SetCartUserIDParams struct {
+	UserID sql.NullString `json:"user_id"`
+	CartID int64          `json:"cart_id"`
+}
+
+func (q *Queries) SetCartUserID(ctx context.Context, arg SetCartUserIDParams) (Cart, error) {
+	row := q.db.QueryRowContext(ctx, setCartUserID, arg.UserID, arg.CartID)
+	var i Cart
+	err := row.Scan(&i.CartID, &i.Token, &i.UserID)
+	return i, err
+}
@@ -0,0 +1,87 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.26.0
+// source: change.sql
+
+package pgdb
+
+import (
+	"context"
+	"time"
+)
+
+const listChanges = `-- name: ListChanges :many
+WITH horizon AS (
+  SELECT date_trunc('milliseconds', COALESCE(MIN(xact_start), CURRENT_TIMESTAMP)) AS started_at
+  FROM pg_catalog.pg_stat_activity
+  WHERE datname = current_database()
+)
+SELECT
+  'book'::text AS entity,
+  book_id::text AS entity_id,
+  COALESCE(isbn13, isbn10)::text AS isbn,
+  version,
+  updated_at,
+  (deleted_at IS NOT NULL)::boolean AS deleted
+FROM books
+WHERE updated_at > $2::timestamptz AND updated_at < (SELECT started_at FROM horizon)
+UNION ALL
+SELECT 'author', author_id::text, '', version, updated_at, deleted_at IS NOT NULL
+FROM authors
+WHERE updated_at > $2::timestamptz AND updated_at < (SELECT started_at FROM horizon)
+UNION ALL
+SELECT 'publisher', publisher_id::text, '', version, updated_at, deleted_at IS NOT NULL
+FROM publishers
+WHERE updated_at > $2::timestamptz AND updated_at < (SELECT started_at FROM horizon)
+ORDER BY updated_at, entity, entity_id
+LIMIT $1::bigint
+`
+
+type ListChangesParams struct {
+	Limit int64     `json:"limit"`
+	Since time.Time `json:"since"`
+}
+
+type ListChangesRow struct {
+	Entity    string    `json:"entity"`
+	EntityID  string    `json:"entity_id"`
+	Isbn      string    `json:"isbn"`
+	Version   int64     `json:"version"`
+	UpdatedAt time.Time `json:"updated_at"`
+	Deleted   bool      `json:"deleted"`
+}
+
+// updated_at is the start of the transaction that made the change, which
+// may commit after later ones. Changes are only listed up to the start of
+// the oldest transaction still running, so that none is skipped by a reader
+// that went past it before it committed. Books are listed by their ID,
+// which is kept when their ISBNs change, along with their current ISBN.
+func (q *Queries) ListChanges(ctx context.Context, arg ListChangesParams) ([]ListChangesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listChanges, arg.Limit, arg.Since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListChangesRow{}
+	for rows.Next() {
+		var i ListChangesRow
+		if err := rows.Scan(
+			&i.Entity,
+			&i.EntityID,
+			&i.Isbn,
+			&i.Version,
+			&i.UpdatedAt,
+			&i.Deleted,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
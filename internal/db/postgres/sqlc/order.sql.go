@@ -0,0 +1,325 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.26.0
+// source: order.sql
+
+package pgdb
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+)
+
+const countOrders = `-- name: CountOrders :one
+SELECT count(*) FROM orders
+WHERE
+  (
+    user_id = $1::text
+    OR cart_token = $2::text
+    OR ($1::text IS NULL AND $2::text IS NULL)
+  )
+  AND (status = $3::text OR $3::text IS NULL)
+`
+
+type CountOrdersParams struct {
+	UserID    sql.NullString `json:"user_id"`
+	CartToken sql.NullString `json:"cart_token"`
+	Status    sql.NullString `json:"status"`
+}
+
+func (q *Queries) CountOrders(ctx context.Context, arg CountOrdersParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countOrders, arg.UserID, arg.CartToken, arg.Status)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const createOrder = `-- name: CreateOrder :one
+INSERT INTO orders (
+  user_id,
+  customer_name,
+  email,
+  shipping_address,
+  total,
+  cart_token
+) VALUES (
+  $1, $2, $3, $4, $5, $6
+) RETURNING order_id, user_id, customer_name, email, shipping_address, status, total, payment_ref, created_at, cart_token
+`
+
+type CreateOrderParams struct {
+	UserID          sql.NullString `json:"user_id"`
+	CustomerName    string         `json:"customer_name"`
+	Email           string         `json:"email"`
+	ShippingAddress string         `json:"shipping_address"`
+	Total           float64        `json:"total"`
+	CartToken       sql.NullString `json:"cart_token"`
+}
+
+func (q *Queries) CreateOrder(ctx context.Context, arg CreateOrderParams) (Order, error) {
+	row := q.db.QueryRowContext(ctx, createOrder,
+		arg.UserID,
+		arg.CustomerName,
+		arg.Email,
+		arg.ShippingAddress,
+		arg.Total,
+		arg.CartToken,
+	)
+	var i Order
+	err := row.Scan(
+		&i.OrderID,
+		&i.UserID,
+		&i.CustomerName,
+		&i.Email,
+		&i.ShippingAddress,
+		&i.Status,
+		&i.Total,
+		&i.PaymentRef,
+		&i.CreatedAt,
+		&i.CartToken,
+	)
+	return i, err
+}
+
+const createOrderItemsFromCart = `-- name: CreateOrderItemsFromCart :exec
+INSERT INTO order_items (
+  order_id,
+  book_id,
+  title,
+  isbn13,
+  isbn10,
+  price,
+  quantity
+)
+SELECT
+  $1::bigint, b.book_id, b.title, b.isbn13, b.isbn10, b.price, ci.quantity
+FROM
+  cart_items ci
+  JOIN books b ON ci.book_id = b.book_id
+WHERE b.deleted_at IS NULL AND ci.cart_id = $2
+`
+
+type CreateOrderItemsFromCartParams struct {
+	OrderID int64 `json:"order_id"`
+	CartID  int64 `json:"cart_id"`
+}
+
+func (q *Queries) CreateOrderItemsFromCart(ctx context.Context, arg CreateOrderItemsFromCartParams) error {
+	_, err := q.db.ExecContext(ctx, createOrderItemsFromCart, arg.OrderID, arg.CartID)
+	return err
+}
+
+const detachPurgedOrderItems = `-- name: DetachPurgedOrderItems :exec
+UPDATE order_items
+SET book_id = NULL
+WHERE book_id IN (SELECT book_id FROM books WHERE deleted_at < $1)
+`
+
+func (q *Queries) DetachPurgedOrderItems(ctx context.Context, deletedBefore sql.NullTime) error {
+	_, err := q.db.ExecContext(ctx, detachPurgedOrderItems, deletedBefore)
+	return err
+}
+
+const getOrder = `-- name: GetOrder :one
+SELECT order_id, user_id, customer_name, email, shipping_address, status, total, payment_ref, created_at, cart_token FROM orders
+WHERE order_id = $1 LIMIT 1
+`
+
+func (q *Queries) GetOrder(ctx context.Context, orderID int64) (Order, error) {
+	row := q.db.QueryRowContext(ctx, getOrder, orderID)
+	var i Order
+	err := row.Scan(
+		&i.OrderID,
+		&i.UserID,
+		&i.CustomerName,
+		&i.Email,
+		&i.ShippingAddress,
+		&i.Status,
+		&i.Total,
+		&i.PaymentRef,
+		&i.CreatedAt,
+		&i.CartToken,
+	)
+	return i, err
+}
+
+const listOrderItems = `-- name: ListOrderItems :many
+SELECT order_item_id, order_id, book_id, title, isbn13, isbn10, price, quantity FROM order_items
+WHERE order_id = $1
+ORDER BY order_item_id
+`
+
+func (q *Queries) ListOrderItems(ctx context.Context, orderID int64) ([]OrderItem, error) {
+	rows, err := q.db.QueryContext(ctx, listOrderItems, orderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []OrderItem{}
+	for rows.Next() {
+		var i OrderItem
+		if err := rows.Scan(
+			&i.OrderItemID,
+			&i.OrderID,
+			&i.BookID,
+			&i.Title,
+			&i.Isbn13,
+			&i.Isbn10,
+			&i.Price,
+			&i.Quantity,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listOrderItemsByOrderIDs = `-- name: ListOrderItemsByOrderIDs :many
+SELECT order_item_id, order_id, book_id, title, isbn13, isbn10, price, quantity FROM order_items
+WHERE order_id IN (SELECT jsonb_array_elements_text($1::jsonb)::bigint)
+ORDER BY order_id, order_item_id
+`
+
+func (q *Queries) ListOrderItemsByOrderIDs(ctx context.Context, orderIds json.RawMessage) ([]OrderItem, error) {
+	rows, err := q.db.QueryContext(ctx, listOrderItemsByOrderIDs, orderIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []OrderItem{}
+	for rows.Next() {
+		var i OrderItem
+		if err := rows.Scan(
+			&i.OrderItemID,
+			&i.OrderID,
+			&i.BookID,
+			&i.Title,
+			&i.Isbn13,
+			&i.Isbn10,
+			&i.Price,
+			&i.Quantity,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listOrders = `-- name: ListOrders :many
+SELECT order_id, user_id, customer_name, email, shipping_address, status, total, payment_ref, created_at, cart_token FROM orders
+WHERE
+  (
+    user_id = $1::text
+    OR cart_token = $2::text
+    OR ($1::text IS NULL AND $2::text IS NULL)
+  )
+  AND (status = $3::text OR $3::text IS NULL)
+ORDER BY order_id DESC
+LIMIT $5::bigint
+OFFSET $4::bigint
+`
+
+type ListOrdersParams struct {
+	UserID    sql.NullString `json:"user_id"`
+	CartToken sql.NullString `json:"cart_token"`
+	Status    sql.NullString `json:"status"`
+	Offset    int64          `json:"offset"`
+	Limit     int64          `json:"limit"`
+}
+
+// Orders of a user or of a cart token, or all of them when both are NULL
+func (q *Queries) ListOrders(ctx context.Context, arg ListOrdersParams) ([]Order, error) {
+	rows, err := q.db.QueryContext(ctx, listOrders,
+		arg.UserID,
+		arg.CartToken,
+		arg.Status,
+		arg.Offset,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Order{}
+	for rows.Next() {
+		var i Order
+		if err := rows.Scan(
+			&i.OrderID,
+			&i.UserID,
+			&i.CustomerName,
+			&i.Email,
+			&i.ShippingAddress,
+			&i.Status,
+			&i.Total,
+			&i.PaymentRef,
+			&i.CreatedAt,
+			&i.CartToken,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateOrderStatus = `-- name: UpdateOrderStatus :one
+UPDATE orders
+SET
+  status = $1,
+  payment_ref = COALESCE($2::text, payment_ref)
+WHERE
+  order_id = $3
+  AND (status = $4::text OR $4::text IS NULL)
+RETURNING order_id, user_id, customer_name, email, shipping_address, status, total, payment_ref, created_at, cart_token
+`
+
+type UpdateOrderStatusParams struct {
+	Status     string         `json:"status"`
+	PaymentRef sql.NullString `json:"payment_ref"`
+	OrderID    int64          `json:"order_id"`
+	FromStatus sql.NullString `json:"from_status"`
+}
+
+func (q *Queries) UpdateOrderStatus(ctx context.Context, arg UpdateOrderStatusParams) (Order, error) {
+	row := q.db.QueryRowContext(ctx, updateOrderStatus,
+		arg.Status,
+		arg.PaymentRef,
+		arg.OrderID,
+		arg.FromStatus,
+	)
+	var i Order
+	err := row.Scan(
+		&i.OrderID,
+		&i.UserID,
+		&i.CustomerName,
+		&i.Email,
+		&i.ShippingAddress,
+		&i.Status,
+		&i.Total,
+		&i.PaymentRef,
+		&i.CreatedAt,
+		&i.CartToken,
+	)
+	return i, err
+}
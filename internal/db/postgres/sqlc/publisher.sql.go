@@ -0,0 +1,302 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.26.0
+// source: publisher.sql
+
+package pgdb
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+)
+
+const countPublishers = `-- name: CountPublishers :one
+SELECT count(*) FROM publishers
+WHERE
+  (deleted_at IS NULL OR $1::boolean)
+  AND (updated_at > $2::timestamptz OR $2::timestamptz IS NULL)
+`
+
+type CountPublishersParams struct {
+	IncludeDeleted bool         `json:"include_deleted"`
+	UpdatedSince   sql.NullTime `json:"updated_since"`
+}
+
+func (q *Queries) CountPublishers(ctx context.Context, arg CountPublishersParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countPublishers, arg.IncludeDeleted, arg.UpdatedSince)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const createPublisher = `-- name: CreatePublisher :one
+INSERT INTO publishers (
+  publisher_name
+) VALUES (
+  $1
+) RETURNING publisher_id, publisher_name, deleted_at, version, created_at, updated_at
+`
+
+func (q *Queries) CreatePublisher(ctx context.Context, publisherName string) (Publisher, error) {
+	row := q.db.QueryRowContext(ctx, createPublisher, publisherName)
+	var i Publisher
+	err := row.Scan(
+		&i.PublisherID,
+		&i.PublisherName,
+		&i.DeletedAt,
+		&i.Version,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const deletePublisher = `-- name: DeletePublisher :execrows
+UPDATE publishers
+SET
+  deleted_at = CURRENT_TIMESTAMP,
+  version = version + 1,
+  updated_at = date_trunc('milliseconds', CURRENT_TIMESTAMP)
+WHERE
+  publisher_id = $1
+  AND deleted_at IS NULL
+  AND (version = $2::bigint OR $2::bigint IS NULL)
+`
+
+type DeletePublisherParams struct {
+	PublisherID int64         `json:"publisher_id"`
+	Version     sql.NullInt64 `json:"version"`
+}
+
+func (q *Queries) DeletePublisher(ctx context.Context, arg DeletePublisherParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deletePublisher, arg.PublisherID, arg.Version)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const getPublisher = `-- name: GetPublisher :one
+SELECT publisher_id, publisher_name, deleted_at, version, created_at, updated_at FROM publishers
+WHERE
+  publisher_id = $1
+  AND (deleted_at IS NULL OR $2::boolean)
+LIMIT 1
+`
+
+type GetPublisherParams struct {
+	PublisherID    int64 `json:"publisher_id"`
+	IncludeDeleted bool  `json:"include_deleted"`
+}
+
+func (q *Queries) GetPublisher(ctx context.Context, arg GetPublisherParams) (Publisher, error) {
+	row := q.db.QueryRowContext(ctx, getPublisher, arg.PublisherID, arg.IncludeDeleted)
+	var i Publisher
+	err := row.Scan(
+		&i.PublisherID,
+		&i.PublisherName,
+		&i.DeletedAt,
+		&i.Version,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getPublisherByName = `-- name: GetPublisherByName :one
+SELECT publisher_id, publisher_name, deleted_at, version, created_at, updated_at FROM publishers
+WHERE
+  publisher_name = $1
+  AND (deleted_at IS NULL OR $2::boolean)
+LIMIT 1
+`
+
+type GetPublisherByNameParams struct {
+	PublisherName  string `json:"publisher_name"`
+	IncludeDeleted bool   `json:"include_deleted"`
+}
+
+func (q *Queries) GetPublisherByName(ctx context.Context, arg GetPublisherByNameParams) (Publisher, error) {
+	row := q.db.QueryRowContext(ctx, getPublisherByName, arg.PublisherName, arg.IncludeDeleted)
+	var i Publisher
+	err := row.Scan(
+		&i.PublisherID,
+		&i.PublisherName,
+		&i.DeletedAt,
+		&i.Version,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const listBookPublishers = `-- name: ListBookPublishers :many
+SELECT
+  b.book_id,
+  p.publisher_id, p.publisher_name, p.deleted_at, p.version, p.created_at, p.updated_at
+FROM
+  books b
+  JOIN publishers p ON b.publisher_id = p.publisher_id
+WHERE b.book_id IN (SELECT jsonb_array_elements_text($1::jsonb)::bigint)
+`
+
+type ListBookPublishersRow struct {
+	BookID    int64     `json:"book_id"`
+	Publisher Publisher `json:"publisher"`
+}
+
+func (q *Queries) ListBookPublishers(ctx context.Context, bookIds json.RawMessage) ([]ListBookPublishersRow, error) {
+	rows, err := q.db.QueryContext(ctx, listBookPublishers, bookIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListBookPublishersRow{}
+	for rows.Next() {
+		var i ListBookPublishersRow
+		if err := rows.Scan(
+			&i.BookID,
+			&i.Publisher.PublisherID,
+			&i.Publisher.PublisherName,
+			&i.Publisher.DeletedAt,
+			&i.Publisher.Version,
+			&i.Publisher.CreatedAt,
+			&i.Publisher.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPublishers = `-- name: ListPublishers :many
+SELECT publisher_id, publisher_name, deleted_at, version, created_at, updated_at FROM publishers
+WHERE
+  (deleted_at IS NULL OR $1::boolean)
+  AND (updated_at > $2::timestamptz OR $2::timestamptz IS NULL)
+ORDER BY publisher_id
+LIMIT $4::bigint
+OFFSET $3::bigint
+`
+
+type ListPublishersParams struct {
+	IncludeDeleted bool         `json:"include_deleted"`
+	UpdatedSince   sql.NullTime `json:"updated_since"`
+	Offset         int64        `json:"offset"`
+	Limit          int64        `json:"limit"`
+}
+
+func (q *Queries) ListPublishers(ctx context.Context, arg ListPublishersParams) ([]Publisher, error) {
+	rows, err := q.db.QueryContext(ctx, listPublishers,
+		arg.IncludeDeleted,
+		arg.UpdatedSince,
+		arg.Offset,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Publisher{}
+	for rows.Next() {
+		var i Publisher
+		if err := rows.Scan(
+			&i.PublisherID,
+			&i.PublisherName,
+			&i.DeletedAt,
+			&i.Version,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const purgePublishers = `-- name: PurgePublishers :execrows
+DELETE FROM publishers
+WHERE
+  publishers.deleted_at < $1
+  AND NOT EXISTS (SELECT 1 FROM books b WHERE b.publisher_id = publishers.publisher_id)
+`
+
+func (q *Queries) PurgePublishers(ctx context.Context, deletedBefore sql.NullTime) (int64, error) {
+	result, err := q.db.ExecContext(ctx, purgePublishers, deletedBefore)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const restorePublisher = `-- name: RestorePublisher :one
+UPDATE publishers
+SET
+  deleted_at = NULL,
+  version = version + 1,
+  updated_at = date_trunc('milliseconds', CURRENT_TIMESTAMP)
+WHERE publisher_id = $1 AND deleted_at IS NOT NULL
+RETURNING publisher_id, publisher_name, deleted_at, version, created_at, updated_at
+`
+
+func (q *Queries) RestorePublisher(ctx context.Context, publisherID int64) (Publisher, error) {
+	row := q.db.QueryRowContext(ctx, restorePublisher, publisherID)
+	var i Publisher
+	err := row.Scan(
+		&i.PublisherID,
+		&i.PublisherName,
+		&i.DeletedAt,
+		&i.Version,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const updatePublisher = `-- name: UpdatePublisher :one
+UPDATE publishers
+SET
+  publisher_name = COALESCE($1::text, publisher_name),
+  version = version + 1,
+  updated_at = date_trunc('milliseconds', CURRENT_TIMESTAMP)
+WHERE
+  publisher_id = $2
+  AND deleted_at IS NULL
+  AND (version = $3::bigint OR $3::bigint IS NULL)
+RETURNING publisher_id, publisher_name, deleted_at, version, created_at, updated_at
+`
+
+type UpdatePublisherParams struct {
+	PublisherName sql.NullString `json:"publisher_name"`
+	PublisherID   int64          `json:"publisher_id"`
+	Version       sql.NullInt64  `json:"version"`
+}
+
+func (q *Queries) UpdatePublisher(ctx context.Context, arg UpdatePublisherParams) (Publisher, error) {
+	row := q.db.QueryRowContext(ctx, updatePublisher, arg.PublisherName, arg.PublisherID, arg.Version)
+	var i Publisher
+	err := row.Scan(
+		&i.PublisherID,
+		&i.PublisherName,
+		&i.DeletedAt,
+		&i.Version,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
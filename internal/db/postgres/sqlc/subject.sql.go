@@ -0,0 +1,68 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.26.0
+// source: subject.sql
+
+package pgdb
+
+import (
+	"context"
+	"database/sql"
+)
+
+const createBookSubjectRel = `-- name: CreateBookSubjectRel :exec
+INSERT INTO book_subject (
+  book_id,
+  subject_id
+) VALUES (
+  $1, $2
+)
+ON CONFLICT DO NOTHING
+`
+
+type CreateBookSubjectRelParams struct {
+	BookID    int64 `json:"book_id"`
+	SubjectID int64 `json:"subject_id"`
+}
+
+func (q *Queries) CreateBookSubjectRel(ctx context.Context, arg CreateBookSubjectRelParams) error {
+	_, err := q.db.ExecContext(ctx, createBookSubjectRel, arg.BookID, arg.SubjectID)
+	return err
+}
+
+const deleteBookSubjectRels = `-- name: DeleteBookSubjectRels :exec
+DELETE FROM book_subject
+WHERE book_id = $1
+`
+
+func (q *Queries) DeleteBookSubjectRels(ctx context.Context, bookID int64) error {
+	_, err := q.db.ExecContext(ctx, deleteBookSubjectRels, bookID)
+	return err
+}
+
+const deletePurgedBookSubjectRels = `-- name: DeletePurgedBookSubjectRels :exec
+DELETE FROM book_subject
+WHERE book_id IN (SELECT book_id FROM books WHERE deleted_at < $1)
+`
+
+func (q *Queries) DeletePurgedBookSubjectRels(ctx context.Context, deletedBefore sql.NullTime) error {
+	_, err := q.db.ExecContext(ctx, deletePurgedBookSubjectRels, deletedBefore)
+	return err
+}
+
+const upsertSubject = `-- name: UpsertSubject :one
+INSERT INTO subjects (
+  subject_name
+) VALUES (
+  $1
+)
+ON CONFLICT ((lower(subject_name))) DO UPDATE SET subject_name = subjects.subject_name
+RETURNING subject_id, subject_name
+`
+
+func (q *Queries) UpsertSubject(ctx context.Context, subjectName string) (Subject, error) {
+	row := q.db.QueryRowContext(ctx, upsertSubject, subjectName)
+	var i Subject
+	err := row.Scan(&i.SubjectID, &i.SubjectName)
+	return i, err
+}
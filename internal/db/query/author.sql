@@ -2,14 +2,21 @@
 INSERT INTO authors (
   first_name,
   last_name,
-  middle_name
+  middle_name,
+  created_at,
+  updated_at
 ) VALUES (
-  ?1, ?2, ?3
+  ?1, ?2, ?3,
+  strftime('%Y-%m-%d %H:%M:%f', 'now'),
+  strftime('%Y-%m-%d %H:%M:%f', 'now')
 ) RETURNING *;
 
 -- name: GetAuthor :one
 SELECT * FROM authors
-WHERE author_id = ?1 LIMIT 1;
+WHERE
+  author_id = sqlc.arg(author_id)
+  AND (deleted_at IS NULL OR CAST(sqlc.arg(include_deleted) AS BOOLEAN))
+LIMIT 1;
 
 -- name: GetAuthorByName :one
 SELECT * FROM authors
@@ -17,26 +24,60 @@ WHERE
   first_name = @first_name AND
   last_name = @last_name AND
   middle_name = COALESCE(@middle_name, middle_name)
+  AND (deleted_at IS NULL OR CAST(sqlc.arg(include_deleted) AS BOOLEAN))
 LIMIT 1;
 
 -- name: ListAuthors :many
 SELECT * FROM authors
+WHERE
+  (deleted_at IS NULL OR CAST(sqlc.arg(include_deleted) AS BOOLEAN))
+  AND (updated_at > sqlc.narg(updated_since) OR sqlc.narg(updated_since) IS NULL)
 ORDER BY author_id
-LIMIT ?1
-OFFSET ?2;
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
 
 -- name: UpdateAuthor :one
 UPDATE authors
 SET
   first_name = COALESCE(sqlc.narg(first_name), first_name),
   last_name = COALESCE(sqlc.narg(last_name), last_name),
-  middle_name = COALESCE(sqlc.narg(middle_name), middle_name)
+  middle_name = COALESCE(sqlc.narg(middle_name), middle_name),
+  version = version + 1,
+  updated_at = strftime('%Y-%m-%d %H:%M:%f', 'now')
 WHERE
   author_id = sqlc.arg(author_id)
+  AND deleted_at IS NULL
+  AND (version = sqlc.narg(version) OR sqlc.narg(version) IS NULL)
 RETURNING *;
 
--- name: DeleteAuthor :exec
-DELETE FROM authors WHERE author_id = ?1;
+-- name: DeleteAuthor :execrows
+UPDATE authors
+SET
+  deleted_at = CURRENT_TIMESTAMP,
+  version = version + 1,
+  updated_at = strftime('%Y-%m-%d %H:%M:%f', 'now')
+WHERE
+  author_id = sqlc.arg(author_id)
+  AND deleted_at IS NULL
+  AND (version = sqlc.narg(version) OR sqlc.narg(version) IS NULL);
+
+-- name: RestoreAuthor :one
+UPDATE authors
+SET
+  deleted_at = NULL,
+  version = version + 1,
+  updated_at = strftime('%Y-%m-%d %H:%M:%f', 'now')
+WHERE author_id = ?1 AND deleted_at IS NOT NULL
+RETURNING *;
+
+-- name: PurgeAuthors :execrows
+DELETE FROM authors
+WHERE
+  authors.deleted_at < sqlc.arg(deleted_before)
+  AND NOT EXISTS (SELECT 1 FROM author_book ab WHERE ab.author_id = authors.author_id);
 
 -- name: CountAuthors :one
-SELECT count(*) FROM authors;
\ No newline at end of file
+SELECT count(*) FROM authors
+WHERE
+  (deleted_at IS NULL OR CAST(sqlc.arg(include_deleted) AS BOOLEAN))
+  AND (updated_at > sqlc.narg(updated_since) OR sqlc.narg(updated_since) IS NULL);
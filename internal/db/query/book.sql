@@ -7,27 +7,59 @@ INSERT INTO books (
   publication_year,
   image_url,
   edition,
-  publisher_id
+  publisher_id,
+  language,
+  format,
+  page_count,
+  series_name,
+  series_number,
+  description,
+  created_at,
+  updated_at
 ) VALUES (
-  ?1, ?2, ?3, ?4, ?5, ?6, ?7, ?8
+  ?1, ?2, ?3, ?4, ?5, ?6, ?7, ?8, ?9, ?10, ?11, ?12, ?13, ?14,
+  strftime('%Y-%m-%d %H:%M:%f', 'now'),
+  strftime('%Y-%m-%d %H:%M:%f', 'now')
 ) RETURNING *;
 
 -- name: GetBookByISBN :one
 SELECT
 	sqlc.embed(b),
-	GROUP_CONCAT(a.first_name || CASE WHEN a.middle_name IS NOT NULL THEN
-			' ' || a.middle_name || ' '
-		ELSE
-			' '
-		END || a.last_name) AS authors,
-	p.publisher_name AS publisher_name
+	-- GROUP_CONCAT follows the order of its window, it may ignore the
+	-- order of a subquery
+	CAST(COALESCE((
+		SELECT GROUP_CONCAT(name) OVER (ORDER BY position ROWS BETWEEN UNBOUNDED PRECEDING AND UNBOUNDED FOLLOWING) FROM (
+			SELECT ca.first_name || CASE WHEN ca.middle_name <> '' THEN ' ' || ca.middle_name ELSE '' END || ' ' || ca.last_name AS name, cab.position
+			FROM author_book cab JOIN authors ca ON cab.author_id = ca.author_id
+			WHERE cab.book_id = b.book_id AND cab.role = 'author'
+		) AS t
+		LIMIT 1
+	), '') AS TEXT) AS authors,
+	CAST(COALESCE((
+		SELECT GROUP_CONCAT(contributor) OVER (ORDER BY position ROWS BETWEEN UNBOUNDED PRECEDING AND UNBOUNDED FOLLOWING) FROM (
+			SELECT cab.role || ':' || ca.first_name || CASE WHEN ca.middle_name <> '' THEN ' ' || ca.middle_name ELSE '' END || ' ' || ca.last_name AS contributor, cab.position
+			FROM author_book cab JOIN authors ca ON cab.author_id = ca.author_id
+			WHERE cab.book_id = b.book_id
+		) AS t
+		LIMIT 1
+	), '') AS TEXT) AS contributors,
+	p.publisher_name AS publisher_name,
+	CAST(COALESCE((
+		SELECT GROUP_CONCAT(subject_name) OVER (ORDER BY subject_name ROWS BETWEEN UNBOUNDED PRECEDING AND UNBOUNDED FOLLOWING) FROM (
+			SELECT s.subject_name
+			FROM book_subject bs JOIN subjects s ON bs.subject_id = s.subject_id
+			WHERE bs.book_id = b.book_id
+		) AS t
+		LIMIT 1
+	), '') AS TEXT) AS subjects
 FROM
 	books AS b
 	JOIN author_book AS ab ON b.book_id = ab.book_id
 	JOIN authors AS a ON ab.author_id = a.author_id
 	JOIN publishers AS p ON b.publisher_id = p.publisher_id
 WHERE
-	b.isbn13 = ?1 OR b.isbn10 = ?2
+	(b.isbn13 = sqlc.narg(isbn13) OR b.isbn10 = sqlc.narg(isbn10))
+	AND (b.deleted_at IS NULL OR CAST(sqlc.arg(include_deleted) AS BOOLEAN))
 GROUP BY
 	b.title,
 	p.publisher_name;
@@ -35,12 +67,31 @@ GROUP BY
 -- name: ListBooks :many
 SELECT
   sqlc.embed(b),
-  GROUP_CONCAT(a.first_name || CASE WHEN a.middle_name IS NOT NULL THEN
-			' ' || a.middle_name || ' '
-		ELSE
-			' '
-		END || a.last_name) AS authors,
-  p.publisher_name AS publisher_name
+  CAST(COALESCE((
+    SELECT GROUP_CONCAT(name) OVER (ORDER BY position ROWS BETWEEN UNBOUNDED PRECEDING AND UNBOUNDED FOLLOWING) FROM (
+      SELECT ca.first_name || CASE WHEN ca.middle_name <> '' THEN ' ' || ca.middle_name ELSE '' END || ' ' || ca.last_name AS name, cab.position
+      FROM author_book cab JOIN authors ca ON cab.author_id = ca.author_id
+      WHERE cab.book_id = b.book_id AND cab.role = 'author'
+    ) AS t
+    LIMIT 1
+  ), '') AS TEXT) AS authors,
+  CAST(COALESCE((
+    SELECT GROUP_CONCAT(contributor) OVER (ORDER BY position ROWS BETWEEN UNBOUNDED PRECEDING AND UNBOUNDED FOLLOWING) FROM (
+      SELECT cab.role || ':' || ca.first_name || CASE WHEN ca.middle_name <> '' THEN ' ' || ca.middle_name ELSE '' END || ' ' || ca.last_name AS contributor, cab.position
+      FROM author_book cab JOIN authors ca ON cab.author_id = ca.author_id
+      WHERE cab.book_id = b.book_id
+    ) AS t
+    LIMIT 1
+  ), '') AS TEXT) AS contributors,
+  p.publisher_name AS publisher_name,
+  CAST(COALESCE((
+    SELECT GROUP_CONCAT(subject_name) OVER (ORDER BY subject_name ROWS BETWEEN UNBOUNDED PRECEDING AND UNBOUNDED FOLLOWING) FROM (
+      SELECT s.subject_name
+      FROM book_subject bs JOIN subjects s ON bs.subject_id = s.subject_id
+      WHERE bs.book_id = b.book_id
+    ) AS t
+    LIMIT 1
+  ), '') AS TEXT) AS subjects
 FROM
   books b
 JOIN author_book ab ON b.book_id = ab.book_id
@@ -52,11 +103,26 @@ WHERE
   AND (b.price <= sqlc.narg(max_price) OR sqlc.narg(max_price) IS NULL)
   AND (b.publication_year >= sqlc.narg(min_publication_year) OR sqlc.narg(min_publication_year) IS NULL)
   AND (b.publication_year <= sqlc.narg(max_publication_year) OR sqlc.narg(max_publication_year) IS NULL)
-  AND (a.first_name || ' ' || a.middle_name || ' ' || a.last_name LIKE '%' || sqlc.narg(author) || '%' OR sqlc.narg(author) IS NULL)
+  AND ((ab.role = 'author' AND a.first_name || ' ' || a.middle_name || ' ' || a.last_name LIKE '%' || sqlc.narg(author) || '%') OR sqlc.narg(author) IS NULL)
   AND (p.publisher_name LIKE '%' || sqlc.narg(publisher) || '%' OR sqlc.narg(publisher) IS NULL)
+  AND (b.language = sqlc.narg(language) OR b.language LIKE sqlc.narg(language) || '-%' OR sqlc.narg(language) IS NULL)
+  AND (b.format = sqlc.narg(format) OR sqlc.narg(format) IS NULL)
+  AND (b.page_count >= sqlc.narg(min_page_count) OR sqlc.narg(min_page_count) IS NULL)
+  AND (b.page_count <= sqlc.narg(max_page_count) OR sqlc.narg(max_page_count) IS NULL)
+  AND (b.series_name LIKE '%' || sqlc.narg(series_name) || '%' OR sqlc.narg(series_name) IS NULL)
+  AND (b.series_number = sqlc.narg(series_number) OR sqlc.narg(series_number) IS NULL)
+  AND (b.description LIKE '%' || sqlc.narg(description) || '%' OR sqlc.narg(description) IS NULL)
+  AND (EXISTS (
+    SELECT 1 FROM book_subject bs JOIN subjects s ON bs.subject_id = s.subject_id
+    WHERE bs.book_id = b.book_id AND s.subject_name = sqlc.narg(subject)
+  ) OR sqlc.narg(subject) IS NULL)
+  AND (b.deleted_at IS NULL OR CAST(sqlc.arg(include_deleted) AS BOOLEAN))
+  AND (b.updated_at > sqlc.narg(updated_since) OR sqlc.narg(updated_since) IS NULL)
+  AND (b.title > sqlc.narg(after_title) OR (b.title = sqlc.narg(after_title) AND b.book_id > sqlc.narg(after_id)) OR sqlc.narg(after_title) IS NULL)
 GROUP BY
 	b.title,
 	p.publisher_name
+ORDER BY b.title, b.book_id
 LIMIT sqlc.arg('limit')
 OFFSET sqlc.arg('offset');
 
@@ -64,20 +130,50 @@ OFFSET sqlc.arg('offset');
 UPDATE books
 SET
   title = COALESCE(sqlc.narg(title), title),
-  isbn13 = COALESCE(sqlc.narg(new_isbn13), isbn13),
-  isbn10 = COALESCE(sqlc.narg(new_isbn10), isbn10),
+  isbn13 = CASE WHEN CAST(sqlc.arg(clear_isbn13) AS BOOLEAN) THEN NULL ELSE COALESCE(sqlc.narg(new_isbn13), isbn13) END,
+  isbn10 = CASE WHEN CAST(sqlc.arg(clear_isbn10) AS BOOLEAN) THEN NULL ELSE COALESCE(sqlc.narg(new_isbn10), isbn10) END,
   price = COALESCE(sqlc.narg(price), price),
   publication_year = COALESCE(sqlc.narg(publication_year), publication_year),
-  image_url = COALESCE(sqlc.narg(image_url), image_url)
+  image_url = CASE WHEN CAST(sqlc.arg(clear_image_url) AS BOOLEAN) THEN NULL ELSE COALESCE(sqlc.narg(image_url), image_url) END,
+  language = CASE WHEN CAST(sqlc.arg(clear_language) AS BOOLEAN) THEN NULL ELSE COALESCE(sqlc.narg(language), language) END,
+  format = CASE WHEN CAST(sqlc.arg(clear_format) AS BOOLEAN) THEN NULL ELSE COALESCE(sqlc.narg(format), format) END,
+  page_count = CASE WHEN CAST(sqlc.arg(clear_page_count) AS BOOLEAN) THEN NULL ELSE COALESCE(sqlc.narg(page_count), page_count) END,
+  series_name = CASE WHEN CAST(sqlc.arg(clear_series_name) AS BOOLEAN) THEN NULL ELSE COALESCE(sqlc.narg(series_name), series_name) END,
+  series_number = CASE WHEN CAST(sqlc.arg(clear_series_number) AS BOOLEAN) THEN NULL ELSE COALESCE(sqlc.narg(series_number), series_number) END,
+  description = CASE WHEN CAST(sqlc.arg(clear_description) AS BOOLEAN) THEN NULL ELSE COALESCE(sqlc.narg(description), description) END,
+  version = version + 1,
+  updated_at = strftime('%Y-%m-%d %H:%M:%f', 'now')
 WHERE
-  isbn13 = @isbn13 OR isbn10 = @isbn10
+  (isbn13 = @isbn13 OR isbn10 = @isbn10)
+  AND deleted_at IS NULL
+  AND (version = sqlc.narg(version) OR sqlc.narg(version) IS NULL)
 RETURNING *;
 
--- name: DeleteBookByISBN :exec
-DELETE FROM books 
-WHERE 
-  isbn13 = ?1
-  OR isbn10 = ?2;
+-- name: DeleteBookByISBN :execrows
+UPDATE books
+SET
+  deleted_at = CURRENT_TIMESTAMP,
+  version = version + 1,
+  updated_at = strftime('%Y-%m-%d %H:%M:%f', 'now')
+WHERE
+  (isbn13 = sqlc.narg(isbn13) OR isbn10 = sqlc.narg(isbn10))
+  AND deleted_at IS NULL
+  AND (version = sqlc.narg(version) OR sqlc.narg(version) IS NULL);
+
+-- name: RestoreBookByISBN :one
+UPDATE books
+SET
+  deleted_at = NULL,
+  version = version + 1,
+  updated_at = strftime('%Y-%m-%d %H:%M:%f', 'now')
+WHERE
+  (isbn13 = ?1 OR isbn10 = ?2)
+  AND deleted_at IS NOT NULL
+RETURNING *;
+
+-- name: PurgeBooks :execrows
+DELETE FROM books
+WHERE deleted_at < sqlc.arg(deleted_before);
 
 -- name: CountBooks :one
 SELECT
@@ -93,5 +189,118 @@ WHERE
   AND (b.price <= sqlc.narg(max_price) OR sqlc.narg(max_price) IS NULL)
   AND (b.publication_year >= sqlc.narg(min_publication_year) OR sqlc.narg(min_publication_year) IS NULL)
   AND (b.publication_year <= sqlc.narg(max_publication_year) OR sqlc.narg(max_publication_year) IS NULL)
-  AND (a.first_name || ' ' || a.middle_name || ' ' || a.last_name LIKE '%' || sqlc.narg(author) || '%' OR sqlc.narg(author) IS NULL)
-  AND (p.publisher_name LIKE '%' || sqlc.narg(publisher) || '%' OR sqlc.narg(publisher) IS NULL);
+  AND ((ab.role = 'author' AND a.first_name || ' ' || a.middle_name || ' ' || a.last_name LIKE '%' || sqlc.narg(author) || '%') OR sqlc.narg(author) IS NULL)
+  AND (p.publisher_name LIKE '%' || sqlc.narg(publisher) || '%' OR sqlc.narg(publisher) IS NULL)
+  AND (b.language = sqlc.narg(language) OR b.language LIKE sqlc.narg(language) || '-%' OR sqlc.narg(language) IS NULL)
+  AND (b.format = sqlc.narg(format) OR sqlc.narg(format) IS NULL)
+  AND (b.page_count >= sqlc.narg(min_page_count) OR sqlc.narg(min_page_count) IS NULL)
+  AND (b.page_count <= sqlc.narg(max_page_count) OR sqlc.narg(max_page_count) IS NULL)
+  AND (b.series_name LIKE '%' || sqlc.narg(series_name) || '%' OR sqlc.narg(series_name) IS NULL)
+  AND (b.series_number = sqlc.narg(series_number) OR sqlc.narg(series_number) IS NULL)
+  AND (b.description LIKE '%' || sqlc.narg(description) || '%' OR sqlc.narg(description) IS NULL)
+  AND (EXISTS (
+    SELECT 1 FROM book_subject bs JOIN subjects s ON bs.subject_id = s.subject_id
+    WHERE bs.book_id = b.book_id AND s.subject_name = sqlc.narg(subject)
+  ) OR sqlc.narg(subject) IS NULL)
+  AND (b.deleted_at IS NULL OR CAST(sqlc.arg(include_deleted) AS BOOLEAN))
+  AND (b.updated_at > sqlc.narg(updated_since) OR sqlc.narg(updated_since) IS NULL);
+
+-- name: SetBookCover :one
+UPDATE books
+SET
+  cover_key = sqlc.narg(cover_key),
+  version = version + 1,
+  updated_at = strftime('%Y-%m-%d %H:%M:%f', 'now')
+WHERE
+  book_id = sqlc.arg(book_id)
+RETURNING *;
+
+-- name: BumpAuthorBookVersions :exec
+UPDATE books
+SET
+  version = version + 1,
+  updated_at = strftime('%Y-%m-%d %H:%M:%f', 'now')
+WHERE book_id IN (SELECT ab.book_id FROM author_book ab WHERE ab.author_id = ?1);
+
+-- name: BumpPublisherBookVersions :exec
+UPDATE books
+SET
+  version = version + 1,
+  updated_at = strftime('%Y-%m-%d %H:%M:%f', 'now')
+WHERE publisher_id = ?1;
+
+-- name: ListAuthorBooks :many
+SELECT
+  ab.author_id,
+  sqlc.embed(b),
+  CAST(COALESCE((
+    SELECT GROUP_CONCAT(name) OVER (ORDER BY position ROWS BETWEEN UNBOUNDED PRECEDING AND UNBOUNDED FOLLOWING) FROM (
+      SELECT ca.first_name || CASE WHEN ca.middle_name <> '' THEN ' ' || ca.middle_name ELSE '' END || ' ' || ca.last_name AS name, cab.position
+      FROM author_book cab JOIN authors ca ON cab.author_id = ca.author_id
+      WHERE cab.book_id = b.book_id AND cab.role = 'author'
+    ) AS t
+    LIMIT 1
+  ), '') AS TEXT) AS authors,
+  CAST(COALESCE((
+    SELECT GROUP_CONCAT(contributor) OVER (ORDER BY position ROWS BETWEEN UNBOUNDED PRECEDING AND UNBOUNDED FOLLOWING) FROM (
+      SELECT cab.role || ':' || ca.first_name || CASE WHEN ca.middle_name <> '' THEN ' ' || ca.middle_name ELSE '' END || ' ' || ca.last_name AS contributor, cab.position
+      FROM author_book cab JOIN authors ca ON cab.author_id = ca.author_id
+      WHERE cab.book_id = b.book_id
+    ) AS t
+    LIMIT 1
+  ), '') AS TEXT) AS contributors,
+  p.publisher_name AS publisher_name,
+  CAST(COALESCE((
+    SELECT GROUP_CONCAT(subject_name) OVER (ORDER BY subject_name ROWS BETWEEN UNBOUNDED PRECEDING AND UNBOUNDED FOLLOWING) FROM (
+      SELECT s.subject_name
+      FROM book_subject bs JOIN subjects s ON bs.subject_id = s.subject_id
+      WHERE bs.book_id = b.book_id
+    ) AS t
+    LIMIT 1
+  ), '') AS TEXT) AS subjects
+FROM
+  books b
+JOIN author_book ab ON b.book_id = ab.book_id
+JOIN publishers p ON b.publisher_id = p.publisher_id
+WHERE
+  ab.author_id IN (sqlc.slice(author_ids))
+  AND ab.role = 'author'
+  AND b.deleted_at IS NULL
+ORDER BY ab.author_id, b.publication_year, b.book_id;
+
+-- name: ListPublisherBooks :many
+SELECT
+  b.publisher_id,
+  sqlc.embed(b),
+  CAST(COALESCE((
+    SELECT GROUP_CONCAT(name) OVER (ORDER BY position ROWS BETWEEN UNBOUNDED PRECEDING AND UNBOUNDED FOLLOWING) FROM (
+      SELECT ca.first_name || CASE WHEN ca.middle_name <> '' THEN ' ' || ca.middle_name ELSE '' END || ' ' || ca.last_name AS name, cab.position
+      FROM author_book cab JOIN authors ca ON cab.author_id = ca.author_id
+      WHERE cab.book_id = b.book_id AND cab.role = 'author'
+    ) AS t
+    LIMIT 1
+  ), '') AS TEXT) AS authors,
+  CAST(COALESCE((
+    SELECT GROUP_CONCAT(contributor) OVER (ORDER BY position ROWS BETWEEN UNBOUNDED PRECEDING AND UNBOUNDED FOLLOWING) FROM (
+      SELECT cab.role || ':' || ca.first_name || CASE WHEN ca.middle_name <> '' THEN ' ' || ca.middle_name ELSE '' END || ' ' || ca.last_name AS contributor, cab.position
+      FROM author_book cab JOIN authors ca ON cab.author_id = ca.author_id
+      WHERE cab.book_id = b.book_id
+    ) AS t
+    LIMIT 1
+  ), '') AS TEXT) AS contributors,
+  p.publisher_name AS publisher_name,
+  CAST(COALESCE((
+    SELECT GROUP_CONCAT(subject_name) OVER (ORDER BY subject_name ROWS BETWEEN UNBOUNDED PRECEDING AND UNBOUNDED FOLLOWING) FROM (
+      SELECT s.subject_name
+      FROM book_subject bs JOIN subjects s ON bs.subject_id = s.subject_id
+      WHERE bs.book_id = b.book_id
+    ) AS t
+    LIMIT 1
+  ), '') AS TEXT) AS subjects
+FROM
+  books b
+JOIN publishers p ON b.publisher_id = p.publisher_id
+WHERE
+  b.publisher_id IN (sqlc.slice(publisher_ids))
+  AND b.deleted_at IS NULL
+ORDER BY b.publisher_id, b.publication_year, b.book_id;
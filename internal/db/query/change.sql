@@ -0,0 +1,22 @@
+-- name: ListChanges :many
+-- Books are listed by their ID, which is kept when their ISBNs change, along
+-- with their current ISBN.
+SELECT
+  'book' AS entity,
+  CAST(book_id AS TEXT) AS entity_id,
+  CAST(COALESCE(isbn13, isbn10) AS TEXT) AS isbn,
+  version,
+  updated_at,
+  CAST(deleted_at IS NOT NULL AS BOOLEAN) AS deleted
+FROM books
+WHERE books.updated_at > sqlc.arg(since)
+UNION ALL
+SELECT 'author', CAST(author_id AS TEXT), '', version, updated_at, CAST(deleted_at IS NOT NULL AS BOOLEAN)
+FROM authors
+WHERE authors.updated_at > sqlc.arg(since)
+UNION ALL
+SELECT 'publisher', CAST(publisher_id AS TEXT), '', version, updated_at, CAST(deleted_at IS NOT NULL AS BOOLEAN)
+FROM publishers
+WHERE publishers.updated_at > sqlc.arg(since)
+ORDER BY updated_at, entity, entity_id
+LIMIT sqlc.arg('limit');
@@ -0,0 +1,81 @@
+-- name: CreateOrder :one
+INSERT INTO orders (
+  user_id,
+  customer_name,
+  email,
+  shipping_address,
+  total,
+  cart_token
+) VALUES (
+  ?1, ?2, ?3, ?4, ?5, ?6
+) RETURNING *;
+
+-- name: GetOrder :one
+SELECT * FROM orders
+WHERE order_id = ?1 LIMIT 1;
+
+-- Orders of a user or of a cart token, or all of them when both are NULL
+-- name: ListOrders :many
+SELECT * FROM orders
+WHERE
+  (
+    user_id = sqlc.narg(user_id)
+    OR cart_token = sqlc.narg(cart_token)
+    OR (sqlc.narg(user_id) IS NULL AND sqlc.narg(cart_token) IS NULL)
+  )
+  AND (status = sqlc.narg(status) OR sqlc.narg(status) IS NULL)
+ORDER BY order_id DESC
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
+
+-- name: CountOrders :one
+SELECT count(*) FROM orders
+WHERE
+  (
+    user_id = sqlc.narg(user_id)
+    OR cart_token = sqlc.narg(cart_token)
+    OR (sqlc.narg(user_id) IS NULL AND sqlc.narg(cart_token) IS NULL)
+  )
+  AND (status = sqlc.narg(status) OR sqlc.narg(status) IS NULL);
+
+-- name: UpdateOrderStatus :one
+UPDATE orders
+SET
+  status = sqlc.arg(status),
+  payment_ref = COALESCE(sqlc.narg(payment_ref), payment_ref)
+WHERE
+  order_id = sqlc.arg(order_id)
+  AND (status = sqlc.narg(from_status) OR sqlc.narg(from_status) IS NULL)
+RETURNING *;
+
+-- name: CreateOrderItemsFromCart :exec
+INSERT INTO order_items (
+  order_id,
+  book_id,
+  title,
+  isbn13,
+  isbn10,
+  price,
+  quantity
+)
+SELECT
+  sqlc.arg(order_id), b.book_id, b.title, b.isbn13, b.isbn10, b.price, ci.quantity
+FROM
+  cart_items ci
+  JOIN books b ON ci.book_id = b.book_id
+WHERE b.deleted_at IS NULL AND ci.cart_id = sqlc.arg(cart_id);
+
+-- name: ListOrderItems :many
+SELECT * FROM order_items
+WHERE order_id = ?1
+ORDER BY order_item_id;
+
+-- name: ListOrderItemsByOrderIDs :many
+SELECT * FROM order_items
+WHERE order_id IN (sqlc.slice(order_ids))
+ORDER BY order_id, order_item_id;
+
+-- name: DetachPurgedOrderItems :exec
+UPDATE order_items
+SET book_id = NULL
+WHERE book_id IN (SELECT book_id FROM books WHERE deleted_at < sqlc.arg(deleted_before));
@@ -1,34 +1,86 @@
 -- name: CreatePublisher :one
 INSERT INTO publishers (
-  publisher_name
+  publisher_name,
+  created_at,
+  updated_at
 ) VALUES (
-  ?1
+  ?1,
+  strftime('%Y-%m-%d %H:%M:%f', 'now'),
+  strftime('%Y-%m-%d %H:%M:%f', 'now')
 ) RETURNING *;
 
 -- name: GetPublisher :one
 SELECT * FROM publishers
-WHERE publisher_id = ?1 LIMIT 1;
+WHERE
+  publisher_id = sqlc.arg(publisher_id)
+  AND (deleted_at IS NULL OR CAST(sqlc.arg(include_deleted) AS BOOLEAN))
+LIMIT 1;
 
 -- name: GetPublisherByName :one
 SELECT * FROM publishers
-WHERE publisher_name = ?1 LIMIT 1;
+WHERE
+  publisher_name = sqlc.arg(publisher_name)
+  AND (deleted_at IS NULL OR CAST(sqlc.arg(include_deleted) AS BOOLEAN))
+LIMIT 1;
 
 -- name: ListPublishers :many
 SELECT * FROM publishers
+WHERE
+  (deleted_at IS NULL OR CAST(sqlc.arg(include_deleted) AS BOOLEAN))
+  AND (updated_at > sqlc.narg(updated_since) OR sqlc.narg(updated_since) IS NULL)
 ORDER BY publisher_id
-LIMIT ?1
-OFFSET ?2;
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
 
 -- name: UpdatePublisher :one
 UPDATE publishers
 SET
-  publisher_name = COALESCE(sqlc.narg(publisher_name), publisher_name)
+  publisher_name = COALESCE(sqlc.narg(publisher_name), publisher_name),
+  version = version + 1,
+  updated_at = strftime('%Y-%m-%d %H:%M:%f', 'now')
+WHERE
+  publisher_id = sqlc.arg(publisher_id)
+  AND deleted_at IS NULL
+  AND (version = sqlc.narg(version) OR sqlc.narg(version) IS NULL)
+RETURNING *;
+
+-- name: DeletePublisher :execrows
+UPDATE publishers
+SET
+  deleted_at = CURRENT_TIMESTAMP,
+  version = version + 1,
+  updated_at = strftime('%Y-%m-%d %H:%M:%f', 'now')
 WHERE
   publisher_id = sqlc.arg(publisher_id)
+  AND deleted_at IS NULL
+  AND (version = sqlc.narg(version) OR sqlc.narg(version) IS NULL);
+
+-- name: RestorePublisher :one
+UPDATE publishers
+SET
+  deleted_at = NULL,
+  version = version + 1,
+  updated_at = strftime('%Y-%m-%d %H:%M:%f', 'now')
+WHERE publisher_id = ?1 AND deleted_at IS NOT NULL
 RETURNING *;
 
--- name: DeletePublisher :exec
-DELETE FROM publishers WHERE publisher_id = ?1;
+-- name: PurgePublishers :execrows
+DELETE FROM publishers
+WHERE
+  publishers.deleted_at < sqlc.arg(deleted_before)
+  AND NOT EXISTS (SELECT 1 FROM books b WHERE b.publisher_id = publishers.publisher_id);
 
 -- name: CountPublishers :one
-SELECT count(*) FROM publishers;
\ No newline at end of file
+SELECT count(*) FROM publishers
+WHERE
+  (deleted_at IS NULL OR CAST(sqlc.arg(include_deleted) AS BOOLEAN))
+  AND (updated_at > sqlc.narg(updated_since) OR sqlc.narg(updated_since) IS NULL);
+
+-- name: ListBookPublishers :many
+SELECT
+  b.book_id,
+  sqlc.embed(p)
+FROM
+  books b
+  JOIN publishers p ON b.publisher_id = p.publisher_id
+WHERE b.book_id IN (sqlc.slice(book_ids));
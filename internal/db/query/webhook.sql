@@ -0,0 +1,97 @@
+-- name: CreateWebhook :one
+INSERT INTO webhooks (
+  url,
+  secret,
+  events
+) VALUES (
+  ?1, ?2, ?3
+) RETURNING *;
+
+-- name: GetWebhook :one
+SELECT * FROM webhooks
+WHERE webhook_id = ?1 LIMIT 1;
+
+-- name: ListWebhooks :many
+SELECT * FROM webhooks
+ORDER BY webhook_id;
+
+-- name: DeleteWebhook :execrows
+DELETE FROM webhooks
+WHERE webhook_id = ?1;
+
+-- name: DeleteWebhookDeliveries :exec
+DELETE FROM webhook_deliveries
+WHERE webhook_id = ?1;
+
+-- name: EnqueueWebhookDeliveries :execrows
+INSERT INTO webhook_deliveries (
+  webhook_id,
+  event_id,
+  event,
+  payload,
+  next_attempt_at
+)
+SELECT
+  webhook_id,
+  CAST(sqlc.arg(event_id) AS TEXT),
+  CAST(sqlc.arg(event) AS TEXT),
+  CAST(sqlc.arg(payload) AS TEXT),
+  CAST(sqlc.arg(next_attempt_at) AS INTEGER)
+FROM webhooks
+WHERE ',' || events || ',' LIKE '%,' || sqlc.arg(event) || ',%';
+
+-- name: ListDueWebhookDeliveries :many
+SELECT
+  sqlc.embed(d),
+  w.url,
+  w.secret
+FROM webhook_deliveries d
+JOIN webhooks w ON d.webhook_id = w.webhook_id
+WHERE
+  d.status = 'pending'
+  AND d.next_attempt_at <= sqlc.arg(now)
+ORDER BY d.next_attempt_at, d.delivery_id
+LIMIT sqlc.arg('limit');
+
+-- name: LeaseWebhookDelivery :execrows
+UPDATE webhook_deliveries
+SET next_attempt_at = sqlc.arg(lease_until)
+WHERE
+  delivery_id = sqlc.arg(delivery_id)
+  AND status = 'pending'
+  AND next_attempt_at = sqlc.arg(next_attempt_at);
+
+-- name: SetWebhookDeliveryResult :one
+UPDATE webhook_deliveries
+SET
+  status = sqlc.arg(status),
+  attempts = attempts + 1,
+  next_attempt_at = sqlc.arg(next_attempt_at),
+  last_error = sqlc.narg(last_error)
+WHERE delivery_id = sqlc.arg(delivery_id)
+RETURNING *;
+
+-- name: RedeliverWebhookDelivery :one
+UPDATE webhook_deliveries
+SET
+  status = 'pending',
+  attempts = 0,
+  next_attempt_at = sqlc.arg(next_attempt_at),
+  last_error = NULL
+WHERE delivery_id = sqlc.arg(delivery_id)
+RETURNING *;
+
+-- name: ListWebhookDeliveries :many
+SELECT * FROM webhook_deliveries
+WHERE
+  (webhook_id = sqlc.narg(webhook_id) OR sqlc.narg(webhook_id) IS NULL)
+  AND (status = sqlc.narg(status) OR sqlc.narg(status) IS NULL)
+ORDER BY delivery_id DESC
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
+
+-- name: CountWebhookDeliveries :one
+SELECT count(*) FROM webhook_deliveries
+WHERE
+  (webhook_id = sqlc.narg(webhook_id) OR sqlc.narg(webhook_id) IS NULL)
+  AND (status = sqlc.narg(status) OR sqlc.narg(status) IS NULL);
@@ -12,10 +12,18 @@ import (
 
 const countAuthors = `-- name: CountAuthors :one
 SELECT count(*) FROM authors
+WHERE
+  (deleted_at IS NULL OR CAST(?1 AS BOOLEAN))
+  AND (updated_at > ?2 OR ?2 IS NULL)
 `
 
-func (q *Queries) CountAuthors(ctx context.Context) (int64, error) {
-	row := q.db.QueryRowContext(ctx, countAuthors)
+type CountAuthorsParams struct {
+	IncludeDeleted bool         `json:"include_deleted"`
+	UpdatedSince   sql.NullTime `json:"updated_since"`
+}
+
+func (q *Queries) CountAuthors(ctx context.Context, arg CountAuthorsParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countAuthors, arg.IncludeDeleted, arg.UpdatedSince)
 	var count int64
 	err := row.Scan(&count)
 	return count, err
@@ -25,10 +33,14 @@ const createAuthor = `-- name: CreateAuthor :one
 INSERT INTO authors (
   first_name,
   last_name,
-  middle_name
+  middle_name,
+  created_at,
+  updated_at
 ) VALUES (
-  ?1, ?2, ?3
-) RETURNING author_id, first_name, last_name, middle_name
+  ?1, ?2, ?3,
+  strftime('%Y-%m-%d %H:%M:%f', 'now'),
+  strftime('%Y-%m-%d %H:%M:%f', 'now')
+) RETURNING author_id, first_name, last_name, middle_name, deleted_at, version, created_at, updated_at
 `
 
 type CreateAuthorParams struct {
@@ -45,77 +57,130 @@ func (q *Queries) CreateAuthor(ctx context.Context, arg CreateAuthorParams) (Aut
 		&i.FirstName,
 		&i.LastName,
 		&i.MiddleName,
+		&i.DeletedAt,
+		&i.Version,
+		&i.CreatedAt,
+		&i.UpdatedAt,
 	)
 	return i, err
 }
 
-const deleteAuthor = `-- name: DeleteAuthor :exec
-DELETE FROM authors WHERE author_id = ?1
+const deleteAuthor = `-- name: DeleteAuthor :execrows
+UPDATE authors
+SET
+  deleted_at = CURRENT_TIMESTAMP,
+  version = version + 1,
+  updated_at = strftime('%Y-%m-%d %H:%M:%f', 'now')
+WHERE
+  author_id = ?1
+  AND deleted_at IS NULL
+  AND (version = ?2 OR ?2 IS NULL)
 `
 
-func (q *Queries) DeleteAuthor(ctx context.Context, authorID int64) error {
-	_, err := q.db.ExecContext(ctx, deleteAuthor, authorID)
-	return err
+type DeleteAuthorParams struct {
+	AuthorID int64         `json:"author_id"`
+	Version  sql.NullInt64 `json:"version"`
+}
+
+func (q *Queries) DeleteAuthor(ctx context.Context, arg DeleteAuthorParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteAuthor, arg.AuthorID, arg.Version)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
 }
 
 const getAuthor = `-- name: GetAuthor :one
-SELECT author_id, first_name, last_name, middle_name FROM authors
-WHERE author_id = ?1 LIMIT 1
+SELECT author_id, first_name, last_name, middle_name, deleted_at, version, created_at, updated_at FROM authors
+WHERE
+  author_id = ?1
+  AND (deleted_at IS NULL OR CAST(?2 AS BOOLEAN))
+LIMIT 1
 `
 
-func (q *Queries) GetAuthor(ctx context.Context, authorID int64) (Author, error) {
-	row := q.db.QueryRowContext(ctx, getAuthor, authorID)
+type GetAuthorParams struct {
+	AuthorID       int64 `json:"author_id"`
+	IncludeDeleted bool  `json:"include_deleted"`
+}
+
+func (q *Queries) GetAuthor(ctx context.Context, arg GetAuthorParams) (Author, error) {
+	row := q.db.QueryRowContext(ctx, getAuthor, arg.AuthorID, arg.IncludeDeleted)
 	var i Author
 	err := row.Scan(
 		&i.AuthorID,
 		&i.FirstName,
 		&i.LastName,
 		&i.MiddleName,
+		&i.DeletedAt,
+		&i.Version,
+		&i.CreatedAt,
+		&i.UpdatedAt,
 	)
 	return i, err
 }
 
 const getAuthorByName = `-- name: GetAuthorByName :one
-SELECT author_id, first_name, last_name, middle_name FROM authors
+SELECT author_id, first_name, last_name, middle_name, deleted_at, version, created_at, updated_at FROM authors
 WHERE
   first_name = ?1 AND
   last_name = ?2 AND
   middle_name = COALESCE(?3, middle_name)
+  AND (deleted_at IS NULL OR CAST(?4 AS BOOLEAN))
 LIMIT 1
 `
 
 type GetAuthorByNameParams struct {
-	FirstName  string `json:"first_name"`
-	LastName   string `json:"last_name"`
-	MiddleName string `json:"middle_name"`
+	FirstName      string `json:"first_name"`
+	LastName       string `json:"last_name"`
+	MiddleName     string `json:"middle_name"`
+	IncludeDeleted bool   `json:"include_deleted"`
 }
 
 func (q *Queries) GetAuthorByName(ctx context.Context, arg GetAuthorByNameParams) (Author, error) {
-	row := q.db.QueryRowContext(ctx, getAuthorByName, arg.FirstName, arg.LastName, arg.MiddleName)
+	row := q.db.QueryRowContext(ctx, getAuthorByName,
+		arg.FirstName,
+		arg.LastName,
+		arg.MiddleName,
+		arg.IncludeDeleted,
+	)
 	var i Author
 	err := row.Scan(
 		&i.AuthorID,
 		&i.FirstName,
 		&i.LastName,
 		&i.MiddleName,
+		&i.DeletedAt,
+		&i.Version,
+		&i.CreatedAt,
+		&i.UpdatedAt,
 	)
 	return i, err
 }
 
 const listAuthors = `-- name: ListAuthors :many
-SELECT author_id, first_name, last_name, middle_name FROM authors
+SELECT author_id, first_name, last_name, middle_name, deleted_at, version, created_at, updated_at FROM authors
+WHERE
+  (deleted_at IS NULL OR CAST(?1 AS BOOLEAN))
+  AND (updated_at > ?2 OR ?2 IS NULL)
 ORDER BY author_id
-LIMIT ?1
-OFFSET ?2
+LIMIT ?4
+OFFSET ?3
 `
 
 type ListAuthorsParams struct {
-	Limit  int64 `json:"limit"`
-	Offset int64 `json:"offset"`
+	IncludeDeleted bool         `json:"include_deleted"`
+	UpdatedSince   sql.NullTime `json:"updated_since"`
+	Offset         int64        `json:"offset"`
+	Limit          int64        `json:"limit"`
 }
 
 func (q *Queries) ListAuthors(ctx context.Context, arg ListAuthorsParams) ([]Author, error) {
-	rows, err := q.db.QueryContext(ctx, listAuthors, arg.Limit, arg.Offset)
+	rows, err := q.db.QueryContext(ctx, listAuthors,
+		arg.IncludeDeleted,
+		arg.UpdatedSince,
+		arg.Offset,
+		arg.Limit,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -128,6 +193,10 @@ func (q *Queries) ListAuthors(ctx context.Context, arg ListAuthorsParams) ([]Aut
 			&i.FirstName,
 			&i.LastName,
 			&i.MiddleName,
+			&i.DeletedAt,
+			&i.Version,
+			&i.CreatedAt,
+			&i.UpdatedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -142,15 +211,60 @@ func (q *Queries) ListAuthors(ctx context.Context, arg ListAuthorsParams) ([]Aut
 	return items, nil
 }
 
+const purgeAuthors = `-- name: PurgeAuthors :execrows
+DELETE FROM authors
+WHERE
+  authors.deleted_at < ?1
+  AND NOT EXISTS (SELECT 1 FROM author_book ab WHERE ab.author_id = authors.author_id)
+`
+
+func (q *Queries) PurgeAuthors(ctx context.Context, deletedBefore sql.NullTime) (int64, error) {
+	result, err := q.db.ExecContext(ctx, purgeAuthors, deletedBefore)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const restoreAuthor = `-- name: RestoreAuthor :one
+UPDATE authors
+SET
+  deleted_at = NULL,
+  version = version + 1,
+  updated_at = strftime('%Y-%m-%d %H:%M:%f', 'now')
+WHERE author_id = ?1 AND deleted_at IS NOT NULL
+RETURNING author_id, first_name, last_name, middle_name, deleted_at, version, created_at, updated_at
+`
+
+func (q *Queries) RestoreAuthor(ctx context.Context, authorID int64) (Author, error) {
+	row := q.db.QueryRowContext(ctx, restoreAuthor, authorID)
+	var i Author
+	err := row.Scan(
+		&i.AuthorID,
+		&i.FirstName,
+		&i.LastName,
+		&i.MiddleName,
+		&i.DeletedAt,
+		&i.Version,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
 const updateAuthor = `-- name: UpdateAuthor :one
 UPDATE authors
 SET
   first_name = COALESCE(?1, first_name),
   last_name = COALESCE(?2, last_name),
-  middle_name = COALESCE(?3, middle_name)
+  middle_name = COALESCE(?3, middle_name),
+  version = version + 1,
+  updated_at = strftime('%Y-%m-%d %H:%M:%f', 'now')
 WHERE
   author_id = ?4
-RETURNING author_id, first_name, last_name, middle_name
+  AND deleted_at IS NULL
+  AND (version = ?5 OR ?5 IS NULL)
+RETURNING author_id, first_name, last_name, middle_name, deleted_at, version, created_at, updated_at
 `
 
 type UpdateAuthorParams struct {
@@ -158,6 +272,7 @@ type UpdateAuthorParams struct {
 	LastName   sql.NullString `json:"last_name"`
 	MiddleName sql.NullString `json:"middle_name"`
 	AuthorID   int64          `json:"author_id"`
+	Version    sql.NullInt64  `json:"version"`
 }
 
 func (q *Queries) UpdateAuthor(ctx context.Context, arg UpdateAuthorParams) (Author, error) {
@@ -166,6 +281,7 @@ func (q *Queries) UpdateAuthor(ctx context.Context, arg UpdateAuthorParams) (Aut
 		arg.LastName,
 		arg.MiddleName,
 		arg.AuthorID,
+		arg.Version,
 	)
 	var i Author
 	err := row.Scan(
@@ -173,6 +289,10 @@ func (q *Queries) UpdateAuthor(ctx context.Context, arg UpdateAuthorParams) (Aut
 		&i.FirstName,
 		&i.LastName,
 		&i.MiddleName,
+		&i.DeletedAt,
+		&i.Version,
+		&i.CreatedAt,
+		&i.UpdatedAt,
 	)
 	return i, err
 }
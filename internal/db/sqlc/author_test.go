@@ -35,7 +35,7 @@ func (ts *AuthorTestSuite) TestGetAuthor() {
 	t := ts.T()
 	author := createRandomAuthor(ts.T())
 
-	gotAuthor, err := testStore.GetAuthor(context.Background(), author.AuthorID)
+	gotAuthor, err := testStore.GetAuthor(context.Background(), GetAuthorParams{AuthorID: author.AuthorID})
 	require.NoError(t, err)
 	requireAuthorEqual(t, author, gotAuthor)
 }
@@ -229,12 +229,53 @@ func (ts *AuthorTestSuite) TestDeleteAuthor() {
 	t := ts.T()
 	author := createRandomAuthor(t)
 
-	err := testStore.DeleteAuthor(context.Background(), author.AuthorID)
+	n, err := testStore.DeleteAuthor(context.Background(), DeleteAuthorParams{
+		AuthorID: author.AuthorID,
+		Version:  sql.NullInt64{Int64: author.Version + 1, Valid: true},
+	})
 	require.NoError(t, err)
+	require.Zero(t, n, "stale version")
 
-	gotAuthor, err := testStore.GetAuthor(context.Background(), author.AuthorID)
-	require.Error(t, err)
+	n, err = testStore.DeleteAuthor(context.Background(), DeleteAuthorParams{
+		AuthorID: author.AuthorID,
+		Version:  sql.NullInt64{Int64: author.Version, Valid: true},
+	})
+	require.NoError(t, err)
+	require.EqualValues(t, 1, n)
+
+	gotAuthor, err := testStore.GetAuthor(context.Background(), GetAuthorParams{AuthorID: author.AuthorID})
+	require.ErrorIs(t, err, ErrRecordNotFound)
 	require.Empty(t, gotAuthor)
+
+	gotAuthor, err = testStore.GetAuthor(context.Background(), GetAuthorParams{
+		AuthorID:       author.AuthorID,
+		IncludeDeleted: true,
+	})
+	require.NoError(t, err)
+	require.True(t, gotAuthor.DeletedAt.Valid)
+	require.Equal(t, author.Version+1, gotAuthor.Version)
+
+	_, err = testStore.GetAuthorByName(context.Background(), GetAuthorByNameParams{
+		FirstName:  author.FirstName,
+		LastName:   author.LastName,
+		MiddleName: author.MiddleName,
+	})
+	require.ErrorIs(t, err, ErrRecordNotFound)
+
+	restoredAuthor, err := testStore.RestoreAuthor(context.Background(), author.AuthorID)
+	require.NoError(t, err)
+	require.False(t, restoredAuthor.DeletedAt.Valid)
+
+	gotAuthor, err = testStore.GetAuthor(context.Background(), GetAuthorParams{AuthorID: author.AuthorID})
+	require.NoError(t, err)
+	require.Equal(t, author.AuthorID, gotAuthor.AuthorID)
+
+	// a live author is left as is
+	_, err = testStore.RestoreAuthor(context.Background(), author.AuthorID)
+	require.ErrorIs(t, err, ErrRecordNotFound)
+	gotAuthor, err = testStore.GetAuthor(context.Background(), GetAuthorParams{AuthorID: author.AuthorID})
+	require.NoError(t, err)
+	require.Equal(t, restoredAuthor.Version, gotAuthor.Version)
 }
 
 func createRandomAuthor(t *testing.T) Author {
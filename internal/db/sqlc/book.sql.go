@@ -8,8 +8,35 @@ package db
 import (
 	"context"
 	"database/sql"
+	"strings"
 )
 
+const bumpAuthorBookVersions = `-- name: BumpAuthorBookVersions :exec
+UPDATE books
+SET
+  version = version + 1,
+  updated_at = strftime('%Y-%m-%d %H:%M:%f', 'now')
+WHERE book_id IN (SELECT ab.book_id FROM author_book ab WHERE ab.author_id = ?1)
+`
+
+func (q *Queries) BumpAuthorBookVersions(ctx context.Context, authorID int64) error {
+	_, err := q.db.ExecContext(ctx, bumpAuthorBookVersions, authorID)
+	return err
+}
+
+const bumpPublisherBookVersions = `-- name: BumpPublisherBookVersions :exec
+UPDATE books
+SET
+  version = version + 1,
+  updated_at = strftime('%Y-%m-%d %H:%M:%f', 'now')
+WHERE publisher_id = ?1
+`
+
+func (q *Queries) BumpPublisherBookVersions(ctx context.Context, publisherID int64) error {
+	_, err := q.db.ExecContext(ctx, bumpPublisherBookVersions, publisherID)
+	return err
+}
+
 const countBooks = `-- name: CountBooks :one
 SELECT
   COUNT(DISTINCT b.book_id)
@@ -24,8 +51,21 @@ WHERE
   AND (b.price <= ?3 OR ?3 IS NULL)
   AND (b.publication_year >= ?4 OR ?4 IS NULL)
   AND (b.publication_year <= ?5 OR ?5 IS NULL)
-  AND (a.first_name || ' ' || a.middle_name || ' ' || a.last_name LIKE '%' || ?6 || '%' OR ?6 IS NULL)
+  AND ((ab.role = 'author' AND a.first_name || ' ' || a.middle_name || ' ' || a.last_name LIKE '%' || ?6 || '%') OR ?6 IS NULL)
   AND (p.publisher_name LIKE '%' || ?7 || '%' OR ?7 IS NULL)
+  AND (b.language = ?8 OR b.language LIKE ?8 || '-%' OR ?8 IS NULL)
+  AND (b.format = ?9 OR ?9 IS NULL)
+  AND (b.page_count >= ?10 OR ?10 IS NULL)
+  AND (b.page_count <= ?11 OR ?11 IS NULL)
+  AND (b.series_name LIKE '%' || ?12 || '%' OR ?12 IS NULL)
+  AND (b.series_number = ?13 OR ?13 IS NULL)
+  AND (b.description LIKE '%' || ?14 || '%' OR ?14 IS NULL)
+  AND (EXISTS (
+    SELECT 1 FROM book_subject bs JOIN subjects s ON bs.subject_id = s.subject_id
+    WHERE bs.book_id = b.book_id AND s.subject_name = ?15
+  ) OR ?15 IS NULL)
+  AND (b.deleted_at IS NULL OR CAST(?16 AS BOOLEAN))
+  AND (b.updated_at > ?17 OR ?17 IS NULL)
 `
 
 type CountBooksParams struct {
@@ -36,6 +76,16 @@ type CountBooksParams struct {
 	MaxPublicationYear sql.NullInt64   `json:"max_publication_year"`
 	Author             sql.NullString  `json:"author"`
 	Publisher          sql.NullString  `json:"publisher"`
+	Language           sql.NullString  `json:"language"`
+	Format             sql.NullString  `json:"format"`
+	MinPageCount       sql.NullInt64   `json:"min_page_count"`
+	MaxPageCount       sql.NullInt64   `json:"max_page_count"`
+	SeriesName         sql.NullString  `json:"series_name"`
+	SeriesNumber       sql.NullInt64   `json:"series_number"`
+	Description        sql.NullString  `json:"description"`
+	Subject            sql.NullString  `json:"subject"`
+	IncludeDeleted     bool            `json:"include_deleted"`
+	UpdatedSince       sql.NullTime    `json:"updated_since"`
 }
 
 func (q *Queries) CountBooks(ctx context.Context, arg CountBooksParams) (int64, error) {
@@ -47,6 +97,16 @@ func (q *Queries) CountBooks(ctx context.Context, arg CountBooksParams) (int64,
 		arg.MaxPublicationYear,
 		arg.Author,
 		arg.Publisher,
+		arg.Language,
+		arg.Format,
+		arg.MinPageCount,
+		arg.MaxPageCount,
+		arg.SeriesName,
+		arg.SeriesNumber,
+		arg.Description,
+		arg.Subject,
+		arg.IncludeDeleted,
+		arg.UpdatedSince,
 	)
 	var count int64
 	err := row.Scan(&count)
@@ -62,10 +122,20 @@ INSERT INTO books (
   publication_year,
   image_url,
   edition,
-  publisher_id
+  publisher_id,
+  language,
+  format,
+  page_count,
+  series_name,
+  series_number,
+  description,
+  created_at,
+  updated_at
 ) VALUES (
-  ?1, ?2, ?3, ?4, ?5, ?6, ?7, ?8
-) RETURNING book_id, title, isbn13, isbn10, price, publication_year, image_url, edition, publisher_id
+  ?1, ?2, ?3, ?4, ?5, ?6, ?7, ?8, ?9, ?10, ?11, ?12, ?13, ?14,
+  strftime('%Y-%m-%d %H:%M:%f', 'now'),
+  strftime('%Y-%m-%d %H:%M:%f', 'now')
+) RETURNING book_id, title, isbn13, isbn10, price, publication_year, image_url, edition, publisher_id, cover_key, language, format, page_count, series_name, series_number, description, deleted_at, version, created_at, updated_at
 `
 
 type CreateBookParams struct {
@@ -77,6 +147,12 @@ type CreateBookParams struct {
 	ImageUrl        sql.NullString `json:"image_url"`
 	Edition         sql.NullString `json:"edition"`
 	PublisherID     int64          `json:"publisher_id"`
+	Language        sql.NullString `json:"language"`
+	Format          sql.NullString `json:"format"`
+	PageCount       sql.NullInt64  `json:"page_count"`
+	SeriesName      sql.NullString `json:"series_name"`
+	SeriesNumber    sql.NullInt64  `json:"series_number"`
+	Description     sql.NullString `json:"description"`
 }
 
 func (q *Queries) CreateBook(ctx context.Context, arg CreateBookParams) (Book, error) {
@@ -89,6 +165,12 @@ func (q *Queries) CreateBook(ctx context.Context, arg CreateBookParams) (Book, e
 		arg.ImageUrl,
 		arg.Edition,
 		arg.PublisherID,
+		arg.Language,
+		arg.Format,
+		arg.PageCount,
+		arg.SeriesName,
+		arg.SeriesNumber,
+		arg.Description,
 	)
 	var i Book
 	err := row.Scan(
@@ -101,61 +183,106 @@ func (q *Queries) CreateBook(ctx context.Context, arg CreateBookParams) (Book, e
 		&i.ImageUrl,
 		&i.Edition,
 		&i.PublisherID,
+		&i.CoverKey,
+		&i.Language,
+		&i.Format,
+		&i.PageCount,
+		&i.SeriesName,
+		&i.SeriesNumber,
+		&i.Description,
+		&i.DeletedAt,
+		&i.Version,
+		&i.CreatedAt,
+		&i.UpdatedAt,
 	)
 	return i, err
 }
 
-const deleteBookByISBN = `-- name: DeleteBookByISBN :exec
-DELETE FROM books 
-WHERE 
-  isbn13 = ?1
-  OR isbn10 = ?2
+const deleteBookByISBN = `-- name: DeleteBookByISBN :execrows
+UPDATE books
+SET
+  deleted_at = CURRENT_TIMESTAMP,
+  version = version + 1,
+  updated_at = strftime('%Y-%m-%d %H:%M:%f', 'now')
+WHERE
+  (isbn13 = ?1 OR isbn10 = ?2)
+  AND deleted_at IS NULL
+  AND (version = ?3 OR ?3 IS NULL)
 `
 
 type DeleteBookByISBNParams struct {
-	Isbn13 sql.NullString `json:"isbn13"`
-	Isbn10 sql.NullString `json:"isbn10"`
+	Isbn13  sql.NullString `json:"isbn13"`
+	Isbn10  sql.NullString `json:"isbn10"`
+	Version sql.NullInt64  `json:"version"`
 }
 
-func (q *Queries) DeleteBookByISBN(ctx context.Context, arg DeleteBookByISBNParams) error {
-	_, err := q.db.ExecContext(ctx, deleteBookByISBN, arg.Isbn13, arg.Isbn10)
-	return err
+func (q *Queries) DeleteBookByISBN(ctx context.Context, arg DeleteBookByISBNParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteBookByISBN, arg.Isbn13, arg.Isbn10, arg.Version)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
 }
 
 const getBookByISBN = `-- name: GetBookByISBN :one
 SELECT
-	b.book_id, b.title, b.isbn13, b.isbn10, b.price, b.publication_year, b.image_url, b.edition, b.publisher_id,
-	GROUP_CONCAT(a.first_name || CASE WHEN a.middle_name IS NOT NULL THEN
-			' ' || a.middle_name || ' '
-		ELSE
-			' '
-		END || a.last_name) AS authors,
-	p.publisher_name AS publisher_name
+	b.book_id, b.title, b.isbn13, b.isbn10, b.price, b.publication_year, b.image_url, b.edition, b.publisher_id, b.cover_key, b.language, b.format, b.page_count, b.series_name, b.series_number, b.description, b.deleted_at, b.version, b.created_at, b.updated_at,
+	-- GROUP_CONCAT follows the order of its window, it may ignore the
+	-- order of a subquery
+	CAST(COALESCE((
+		SELECT GROUP_CONCAT(name) OVER (ORDER BY position ROWS BETWEEN UNBOUNDED PRECEDING AND UNBOUNDED FOLLOWING) FROM (
+			SELECT ca.first_name || CASE WHEN ca.middle_name <> '' THEN ' ' || ca.middle_name ELSE '' END || ' ' || ca.last_name AS name, cab.position
+			FROM author_book cab JOIN authors ca ON cab.author_id = ca.author_id
+			WHERE cab.book_id = b.book_id AND cab.role = 'author'
+		) AS t
+		LIMIT 1
+	), '') AS TEXT) AS authors,
+	CAST(COALESCE((
+		SELECT GROUP_CONCAT(contributor) OVER (ORDER BY position ROWS BETWEEN UNBOUNDED PRECEDING AND UNBOUNDED FOLLOWING) FROM (
+			SELECT cab.role || ':' || ca.first_name || CASE WHEN ca.middle_name <> '' THEN ' ' || ca.middle_name ELSE '' END || ' ' || ca.last_name AS contributor, cab.position
+			FROM author_book cab JOIN authors ca ON cab.author_id = ca.author_id
+			WHERE cab.book_id = b.book_id
+		) AS t
+		LIMIT 1
+	), '') AS TEXT) AS contributors,
+	p.publisher_name AS publisher_name,
+	CAST(COALESCE((
+		SELECT GROUP_CONCAT(subject_name) OVER (ORDER BY subject_name ROWS BETWEEN UNBOUNDED PRECEDING AND UNBOUNDED FOLLOWING) FROM (
+			SELECT s.subject_name
+			FROM book_subject bs JOIN subjects s ON bs.subject_id = s.subject_id
+			WHERE bs.book_id = b.book_id
+		) AS t
+		LIMIT 1
+	), '') AS TEXT) AS subjects
 FROM
 	books AS b
 	JOIN author_book AS ab ON b.book_id = ab.book_id
 	JOIN authors AS a ON ab.author_id = a.author_id
 	JOIN publishers AS p ON b.publisher_id = p.publisher_id
 WHERE
-	b.isbn13 = ?1 OR b.isbn10 = ?2
+	(b.isbn13 = ?1 OR b.isbn10 = ?2)
+	AND (b.deleted_at IS NULL OR CAST(?3 AS BOOLEAN))
 GROUP BY
 	b.title,
 	p.publisher_name
 `
 
 type GetBookByISBNParams struct {
-	Isbn13 sql.NullString `json:"isbn13"`
-	Isbn10 sql.NullString `json:"isbn10"`
+	Isbn13         sql.NullString `json:"isbn13"`
+	Isbn10         sql.NullString `json:"isbn10"`
+	IncludeDeleted bool           `json:"include_deleted"`
 }
 
 type GetBookByISBNRow struct {
 	Book          Book   `json:"book"`
 	Authors       string `json:"authors"`
+	Contributors  string `json:"contributors"`
 	PublisherName string `json:"publisher_name"`
+	Subjects      string `json:"subjects"`
 }
 
 func (q *Queries) GetBookByISBN(ctx context.Context, arg GetBookByISBNParams) (GetBookByISBNRow, error) {
-	row := q.db.QueryRowContext(ctx, getBookByISBN, arg.Isbn13, arg.Isbn10)
+	row := q.db.QueryRowContext(ctx, getBookByISBN, arg.Isbn13, arg.Isbn10, arg.IncludeDeleted)
 	var i GetBookByISBNRow
 	err := row.Scan(
 		&i.Book.BookID,
@@ -167,21 +294,161 @@ func (q *Queries) GetBookByISBN(ctx context.Context, arg GetBookByISBNParams) (G
 		&i.Book.ImageUrl,
 		&i.Book.Edition,
 		&i.Book.PublisherID,
+		&i.Book.CoverKey,
+		&i.Book.Language,
+		&i.Book.Format,
+		&i.Book.PageCount,
+		&i.Book.SeriesName,
+		&i.Book.SeriesNumber,
+		&i.Book.Description,
+		&i.Book.DeletedAt,
+		&i.Book.Version,
+		&i.Book.CreatedAt,
+		&i.Book.UpdatedAt,
 		&i.Authors,
+		&i.Contributors,
 		&i.PublisherName,
+		&i.Subjects,
 	)
 	return i, err
 }
 
+const listAuthorBooks = `-- name: ListAuthorBooks :many
+SELECT
+  ab.author_id,
+  b.book_id, b.title, b.isbn13, b.isbn10, b.price, b.publication_year, b.image_url, b.edition, b.publisher_id, b.cover_key, b.language, b.format, b.page_count, b.series_name, b.series_number, b.description, b.deleted_at, b.version, b.created_at, b.updated_at,
+  CAST(COALESCE((
+    SELECT GROUP_CONCAT(name) OVER (ORDER BY position ROWS BETWEEN UNBOUNDED PRECEDING AND UNBOUNDED FOLLOWING) FROM (
+      SELECT ca.first_name || CASE WHEN ca.middle_name <> '' THEN ' ' || ca.middle_name ELSE '' END || ' ' || ca.last_name AS name, cab.position
+      FROM author_book cab JOIN authors ca ON cab.author_id = ca.author_id
+      WHERE cab.book_id = b.book_id AND cab.role = 'author'
+    ) AS t
+    LIMIT 1
+  ), '') AS TEXT) AS authors,
+  CAST(COALESCE((
+    SELECT GROUP_CONCAT(contributor) OVER (ORDER BY position ROWS BETWEEN UNBOUNDED PRECEDING AND UNBOUNDED FOLLOWING) FROM (
+      SELECT cab.role || ':' || ca.first_name || CASE WHEN ca.middle_name <> '' THEN ' ' || ca.middle_name ELSE '' END || ' ' || ca.last_name AS contributor, cab.position
+      FROM author_book cab JOIN authors ca ON cab.author_id = ca.author_id
+      WHERE cab.book_id = b.book_id
+    ) AS t
+    LIMIT 1
+  ), '') AS TEXT) AS contributors,
+  p.publisher_name AS publisher_name,
+  CAST(COALESCE((
+    SELECT GROUP_CONCAT(subject_name) OVER (ORDER BY subject_name ROWS BETWEEN UNBOUNDED PRECEDING AND UNBOUNDED FOLLOWING) FROM (
+      SELECT s.subject_name
+      FROM book_subject bs JOIN subjects s ON bs.subject_id = s.subject_id
+      WHERE bs.book_id = b.book_id
+    ) AS t
+    LIMIT 1
+  ), '') AS TEXT) AS subjects
+FROM
+  books b
+JOIN author_book ab ON b.book_id = ab.book_id
+JOIN publishers p ON b.publisher_id = p.publisher_id
+WHERE
+  ab.author_id IN (/*SLICE:author_ids*/?)
+  AND ab.role = 'author'
+  AND b.deleted_at IS NULL
+ORDER BY ab.author_id, b.publication_year, b.book_id
+`
+
+type ListAuthorBooksRow struct {
+	AuthorID      int64  `json:"author_id"`
+	Book          Book   `json:"book"`
+	Authors       string `json:"authors"`
+	Contributors  string `json:"contributors"`
+	PublisherName string `json:"publisher_name"`
+	Subjects      string `json:"subjects"`
+}
+
+func (q *Queries) ListAuthorBooks(ctx context.Context, authorIds []int64) ([]ListAuthorBooksRow, error) {
+	query := listAuthorBooks
+	var queryParams []interface{}
+	if len(authorIds) > 0 {
+		for _, v := range authorIds {
+			queryParams = append(queryParams, v)
+		}
+		query = strings.Replace(query, "/*SLICE:author_ids*/?", strings.Repeat(",?", len(authorIds))[1:], 1)
+	} else {
+		query = strings.Replace(query, "/*SLICE:author_ids*/?", "NULL", 1)
+	}
+	rows, err := q.db.QueryContext(ctx, query, queryParams...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListAuthorBooksRow{}
+	for rows.Next() {
+		var i ListAuthorBooksRow
+		if err := rows.Scan(
+			&i.AuthorID,
+			&i.Book.BookID,
+			&i.Book.Title,
+			&i.Book.Isbn13,
+			&i.Book.Isbn10,
+			&i.Book.Price,
+			&i.Book.PublicationYear,
+			&i.Book.ImageUrl,
+			&i.Book.Edition,
+			&i.Book.PublisherID,
+			&i.Book.CoverKey,
+			&i.Book.Language,
+			&i.Book.Format,
+			&i.Book.PageCount,
+			&i.Book.SeriesName,
+			&i.Book.SeriesNumber,
+			&i.Book.Description,
+			&i.Book.DeletedAt,
+			&i.Book.Version,
+			&i.Book.CreatedAt,
+			&i.Book.UpdatedAt,
+			&i.Authors,
+			&i.Contributors,
+			&i.PublisherName,
+			&i.Subjects,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listBooks = `-- name: ListBooks :many
 SELECT
-  b.book_id, b.title, b.isbn13, b.isbn10, b.price, b.publication_year, b.image_url, b.edition, b.publisher_id,
-  GROUP_CONCAT(a.first_name || CASE WHEN a.middle_name IS NOT NULL THEN
-			' ' || a.middle_name || ' '
-		ELSE
-			' '
-		END || a.last_name) AS authors,
-  p.publisher_name AS publisher_name
+  b.book_id, b.title, b.isbn13, b.isbn10, b.price, b.publication_year, b.image_url, b.edition, b.publisher_id, b.cover_key, b.language, b.format, b.page_count, b.series_name, b.series_number, b.description, b.deleted_at, b.version, b.created_at, b.updated_at,
+  CAST(COALESCE((
+    SELECT GROUP_CONCAT(name) OVER (ORDER BY position ROWS BETWEEN UNBOUNDED PRECEDING AND UNBOUNDED FOLLOWING) FROM (
+      SELECT ca.first_name || CASE WHEN ca.middle_name <> '' THEN ' ' || ca.middle_name ELSE '' END || ' ' || ca.last_name AS name, cab.position
+      FROM author_book cab JOIN authors ca ON cab.author_id = ca.author_id
+      WHERE cab.book_id = b.book_id AND cab.role = 'author'
+    ) AS t
+    LIMIT 1
+  ), '') AS TEXT) AS authors,
+  CAST(COALESCE((
+    SELECT GROUP_CONCAT(contributor) OVER (ORDER BY position ROWS BETWEEN UNBOUNDED PRECEDING AND UNBOUNDED FOLLOWING) FROM (
+      SELECT cab.role || ':' || ca.first_name || CASE WHEN ca.middle_name <> '' THEN ' ' || ca.middle_name ELSE '' END || ' ' || ca.last_name AS contributor, cab.position
+      FROM author_book cab JOIN authors ca ON cab.author_id = ca.author_id
+      WHERE cab.book_id = b.book_id
+    ) AS t
+    LIMIT 1
+  ), '') AS TEXT) AS contributors,
+  p.publisher_name AS publisher_name,
+  CAST(COALESCE((
+    SELECT GROUP_CONCAT(subject_name) OVER (ORDER BY subject_name ROWS BETWEEN UNBOUNDED PRECEDING AND UNBOUNDED FOLLOWING) FROM (
+      SELECT s.subject_name
+      FROM book_subject bs JOIN subjects s ON bs.subject_id = s.subject_id
+      WHERE bs.book_id = b.book_id
+    ) AS t
+    LIMIT 1
+  ), '') AS TEXT) AS subjects
 FROM
   books b
 JOIN author_book ab ON b.book_id = ab.book_id
@@ -193,13 +460,28 @@ WHERE
   AND (b.price <= ?3 OR ?3 IS NULL)
   AND (b.publication_year >= ?4 OR ?4 IS NULL)
   AND (b.publication_year <= ?5 OR ?5 IS NULL)
-  AND (a.first_name || ' ' || a.middle_name || ' ' || a.last_name LIKE '%' || ?6 || '%' OR ?6 IS NULL)
+  AND ((ab.role = 'author' AND a.first_name || ' ' || a.middle_name || ' ' || a.last_name LIKE '%' || ?6 || '%') OR ?6 IS NULL)
   AND (p.publisher_name LIKE '%' || ?7 || '%' OR ?7 IS NULL)
+  AND (b.language = ?8 OR b.language LIKE ?8 || '-%' OR ?8 IS NULL)
+  AND (b.format = ?9 OR ?9 IS NULL)
+  AND (b.page_count >= ?10 OR ?10 IS NULL)
+  AND (b.page_count <= ?11 OR ?11 IS NULL)
+  AND (b.series_name LIKE '%' || ?12 || '%' OR ?12 IS NULL)
+  AND (b.series_number = ?13 OR ?13 IS NULL)
+  AND (b.description LIKE '%' || ?14 || '%' OR ?14 IS NULL)
+  AND (EXISTS (
+    SELECT 1 FROM book_subject bs JOIN subjects s ON bs.subject_id = s.subject_id
+    WHERE bs.book_id = b.book_id AND s.subject_name = ?15
+  ) OR ?15 IS NULL)
+  AND (b.deleted_at IS NULL OR CAST(?16 AS BOOLEAN))
+  AND (b.updated_at > ?17 OR ?17 IS NULL)
+  AND (b.title > ?18 OR (b.title = ?18 AND b.book_id > ?19) OR ?18 IS NULL)
 GROUP BY
 	b.title,
 	p.publisher_name
-LIMIT ?9
-OFFSET ?8
+ORDER BY b.title, b.book_id
+LIMIT ?21
+OFFSET ?20
 `
 
 type ListBooksParams struct {
@@ -210,6 +492,18 @@ type ListBooksParams struct {
 	MaxPublicationYear sql.NullInt64   `json:"max_publication_year"`
 	Author             sql.NullString  `json:"author"`
 	Publisher          sql.NullString  `json:"publisher"`
+	Language           sql.NullString  `json:"language"`
+	Format             sql.NullString  `json:"format"`
+	MinPageCount       sql.NullInt64   `json:"min_page_count"`
+	MaxPageCount       sql.NullInt64   `json:"max_page_count"`
+	SeriesName         sql.NullString  `json:"series_name"`
+	SeriesNumber       sql.NullInt64   `json:"series_number"`
+	Description        sql.NullString  `json:"description"`
+	Subject            sql.NullString  `json:"subject"`
+	IncludeDeleted     bool            `json:"include_deleted"`
+	UpdatedSince       sql.NullTime    `json:"updated_since"`
+	AfterTitle         sql.NullString  `json:"after_title"`
+	AfterID            sql.NullInt64   `json:"after_id"`
 	Offset             int64           `json:"offset"`
 	Limit              int64           `json:"limit"`
 }
@@ -217,7 +511,9 @@ type ListBooksParams struct {
 type ListBooksRow struct {
 	Book          Book   `json:"book"`
 	Authors       string `json:"authors"`
+	Contributors  string `json:"contributors"`
 	PublisherName string `json:"publisher_name"`
+	Subjects      string `json:"subjects"`
 }
 
 func (q *Queries) ListBooks(ctx context.Context, arg ListBooksParams) ([]ListBooksRow, error) {
@@ -229,6 +525,18 @@ func (q *Queries) ListBooks(ctx context.Context, arg ListBooksParams) ([]ListBoo
 		arg.MaxPublicationYear,
 		arg.Author,
 		arg.Publisher,
+		arg.Language,
+		arg.Format,
+		arg.MinPageCount,
+		arg.MaxPageCount,
+		arg.SeriesName,
+		arg.SeriesNumber,
+		arg.Description,
+		arg.Subject,
+		arg.IncludeDeleted,
+		arg.UpdatedSince,
+		arg.AfterTitle,
+		arg.AfterID,
 		arg.Offset,
 		arg.Limit,
 	)
@@ -249,8 +557,21 @@ func (q *Queries) ListBooks(ctx context.Context, arg ListBooksParams) ([]ListBoo
 			&i.Book.ImageUrl,
 			&i.Book.Edition,
 			&i.Book.PublisherID,
+			&i.Book.CoverKey,
+			&i.Book.Language,
+			&i.Book.Format,
+			&i.Book.PageCount,
+			&i.Book.SeriesName,
+			&i.Book.SeriesNumber,
+			&i.Book.Description,
+			&i.Book.DeletedAt,
+			&i.Book.Version,
+			&i.Book.CreatedAt,
+			&i.Book.UpdatedAt,
 			&i.Authors,
+			&i.Contributors,
 			&i.PublisherName,
+			&i.Subjects,
 		); err != nil {
 			return nil, err
 		}
@@ -265,41 +586,291 @@ func (q *Queries) ListBooks(ctx context.Context, arg ListBooksParams) ([]ListBoo
 	return items, nil
 }
 
+const listPublisherBooks = `-- name: ListPublisherBooks :many
+SELECT
+  b.publisher_id,
+  b.book_id, b.title, b.isbn13, b.isbn10, b.price, b.publication_year, b.image_url, b.edition, b.publisher_id, b.cover_key, b.language, b.format, b.page_count, b.series_name, b.series_number, b.description, b.deleted_at, b.version, b.created_at, b.updated_at,
+  CAST(COALESCE((
+    SELECT GROUP_CONCAT(name) OVER (ORDER BY position ROWS BETWEEN UNBOUNDED PRECEDING AND UNBOUNDED FOLLOWING) FROM (
+      SELECT ca.first_name || CASE WHEN ca.middle_name <> '' THEN ' ' || ca.middle_name ELSE '' END || ' ' || ca.last_name AS name, cab.position
+      FROM author_book cab JOIN authors ca ON cab.author_id = ca.author_id
+      WHERE cab.book_id = b.book_id AND cab.role = 'author'
+    ) AS t
+    LIMIT 1
+  ), '') AS TEXT) AS authors,
+  CAST(COALESCE((
+    SELECT GROUP_CONCAT(contributor) OVER (ORDER BY position ROWS BETWEEN UNBOUNDED PRECEDING AND UNBOUNDED FOLLOWING) FROM (
+      SELECT cab.role || ':' || ca.first_name || CASE WHEN ca.middle_name <> '' THEN ' ' || ca.middle_name ELSE '' END || ' ' || ca.last_name AS contributor, cab.position
+      FROM author_book cab JOIN authors ca ON cab.author_id = ca.author_id
+      WHERE cab.book_id = b.book_id
+    ) AS t
+    LIMIT 1
+  ), '') AS TEXT) AS contributors,
+  p.publisher_name AS publisher_name,
+  CAST(COALESCE((
+    SELECT GROUP_CONCAT(subject_name) OVER (ORDER BY subject_name ROWS BETWEEN UNBOUNDED PRECEDING AND UNBOUNDED FOLLOWING) FROM (
+      SELECT s.subject_name
+      FROM book_subject bs JOIN subjects s ON bs.subject_id = s.subject_id
+      WHERE bs.book_id = b.book_id
+    ) AS t
+    LIMIT 1
+  ), '') AS TEXT) AS subjects
+FROM
+  books b
+JOIN publishers p ON b.publisher_id = p.publisher_id
+WHERE
+  b.publisher_id IN (/*SLICE:publisher_ids*/?)
+  AND b.deleted_at IS NULL
+ORDER BY b.publisher_id, b.publication_year, b.book_id
+`
+
+type ListPublisherBooksRow struct {
+	PublisherID   int64  `json:"publisher_id"`
+	Book          Book   `json:"book"`
+	Authors       string `json:"authors"`
+	Contributors  string `json:"contributors"`
+	PublisherName string `json:"publisher_name"`
+	Subjects      string `json:"subjects"`
+}
+
+func (q *Queries) ListPublisherBooks(ctx context.Context, publisherIds []int64) ([]ListPublisherBooksRow, error) {
+	query := listPublisherBooks
+	var queryParams []interface{}
+	if len(publisherIds) > 0 {
+		for _, v := range publisherIds {
+			queryParams = append(queryParams, v)
+		}
+		query = strings.Replace(query, "/*SLICE:publisher_ids*/?", strings.Repeat(",?", len(publisherIds))[1:], 1)
+	} else {
+		query = strings.Replace(query, "/*SLICE:publisher_ids*/?", "NULL", 1)
+	}
+	rows, err := q.db.QueryContext(ctx, query, queryParams...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListPublisherBooksRow{}
+	for rows.Next() {
+		var i ListPublisherBooksRow
+		if err := rows.Scan(
+			&i.PublisherID,
+			&i.Book.BookID,
+			&i.Book.Title,
+			&i.Book.Isbn13,
+			&i.Book.Isbn10,
+			&i.Book.Price,
+			&i.Book.PublicationYear,
+			&i.Book.ImageUrl,
+			&i.Book.Edition,
+			&i.Book.PublisherID,
+			&i.Book.CoverKey,
+			&i.Book.Language,
+			&i.Book.Format,
+			&i.Book.PageCount,
+			&i.Book.SeriesName,
+			&i.Book.SeriesNumber,
+			&i.Book.Description,
+			&i.Book.DeletedAt,
+			&i.Book.Version,
+			&i.Book.CreatedAt,
+			&i.Book.UpdatedAt,
+			&i.Authors,
+			&i.Contributors,
+			&i.PublisherName,
+			&i.Subjects,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const purgeBooks = `-- name: PurgeBooks :execrows
+DELETE FROM books
+WHERE deleted_at < ?1
+`
+
+func (q *Queries) PurgeBooks(ctx context.Context, deletedBefore sql.NullTime) (int64, error) {
+	result, err := q.db.ExecContext(ctx, purgeBooks, deletedBefore)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const restoreBookByISBN = `-- name: RestoreBookByISBN :one
+UPDATE books
+SET
+  deleted_at = NULL,
+  version = version + 1,
+  updated_at = strftime('%Y-%m-%d %H:%M:%f', 'now')
+WHERE
+  (isbn13 = ?1 OR isbn10 = ?2)
+  AND deleted_at IS NOT NULL
+RETURNING book_id, title, isbn13, isbn10, price, publication_year, image_url, edition, publisher_id, cover_key, language, format, page_count, series_name, series_number, description, deleted_at, version, created_at, updated_at
+`
+
+type RestoreBookByISBNParams struct {
+	Isbn13 sql.NullString `json:"isbn13"`
+	Isbn10 sql.NullString `json:"isbn10"`
+}
+
+func (q *Queries) RestoreBookByISBN(ctx context.Context, arg RestoreBookByISBNParams) (Book, error) {
+	row := q.db.QueryRowContext(ctx, restoreBookByISBN, arg.Isbn13, arg.Isbn10)
+	var i Book
+	err := row.Scan(
+		&i.BookID,
+		&i.Title,
+		&i.Isbn13,
+		&i.Isbn10,
+		&i.Price,
+		&i.PublicationYear,
+		&i.ImageUrl,
+		&i.Edition,
+		&i.PublisherID,
+		&i.CoverKey,
+		&i.Language,
+		&i.Format,
+		&i.PageCount,
+		&i.SeriesName,
+		&i.SeriesNumber,
+		&i.Description,
+		&i.DeletedAt,
+		&i.Version,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const setBookCover = `-- name: SetBookCover :one
+UPDATE books
+SET
+  cover_key = ?1,
+  version = version + 1,
+  updated_at = strftime('%Y-%m-%d %H:%M:%f', 'now')
+WHERE
+  book_id = ?2
+RETURNING book_id, title, isbn13, isbn10, price, publication_year, image_url, edition, publisher_id, cover_key, language, format, page_count, series_name, series_number, description, deleted_at, version, created_at, updated_at
+`
+
+type SetBookCoverParams struct {
+	CoverKey sql.NullString `json:"cover_key"`
+	BookID   int64          `json:"book_id"`
+}
+
+func (q *Queries) SetBookCover(ctx context.Context, arg SetBookCoverParams) (Book, error) {
+	row := q.db.QueryRowContext(ctx, setBookCover, arg.CoverKey, arg.BookID)
+	var i Book
+	err := row.Scan(
+		&i.BookID,
+		&i.Title,
+		&i.Isbn13,
+		&i.Isbn10,
+		&i.Price,
+		&i.PublicationYear,
+		&i.ImageUrl,
+		&i.Edition,
+		&i.PublisherID,
+		&i.CoverKey,
+		&i.Language,
+		&i.Format,
+		&i.PageCount,
+		&i.SeriesName,
+		&i.SeriesNumber,
+		&i.Description,
+		&i.DeletedAt,
+		&i.Version,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
 const updateBookByISBN = `-- name: UpdateBookByISBN :one
 UPDATE books
 SET
   title = COALESCE(?1, title),
-  isbn13 = COALESCE(?2, isbn13),
-  isbn10 = COALESCE(?3, isbn10),
-  price = COALESCE(?4, price),
-  publication_year = COALESCE(?5, publication_year),
-  image_url = COALESCE(?6, image_url)
+  isbn13 = CASE WHEN CAST(?2 AS BOOLEAN) THEN NULL ELSE COALESCE(?3, isbn13) END,
+  isbn10 = CASE WHEN CAST(?4 AS BOOLEAN) THEN NULL ELSE COALESCE(?5, isbn10) END,
+  price = COALESCE(?6, price),
+  publication_year = COALESCE(?7, publication_year),
+  image_url = CASE WHEN CAST(?8 AS BOOLEAN) THEN NULL ELSE COALESCE(?9, image_url) END,
+  language = CASE WHEN CAST(?10 AS BOOLEAN) THEN NULL ELSE COALESCE(?11, language) END,
+  format = CASE WHEN CAST(?12 AS BOOLEAN) THEN NULL ELSE COALESCE(?13, format) END,
+  page_count = CASE WHEN CAST(?14 AS BOOLEAN) THEN NULL ELSE COALESCE(?15, page_count) END,
+  series_name = CASE WHEN CAST(?16 AS BOOLEAN) THEN NULL ELSE COALESCE(?17, series_name) END,
+  series_number = CASE WHEN CAST(?18 AS BOOLEAN) THEN NULL ELSE COALESCE(?19, series_number) END,
+  description = CASE WHEN CAST(?20 AS BOOLEAN) THEN NULL ELSE COALESCE(?21, description) END,
+  version = version + 1,
+  updated_at = strftime('%Y-%m-%d %H:%M:%f', 'now')
 WHERE
-  isbn13 = ?7 OR isbn10 = ?8
-RETURNING book_id, title, isbn13, isbn10, price, publication_year, image_url, edition, publisher_id
+  (isbn13 = ?22 OR isbn10 = ?23)
+  AND deleted_at IS NULL
+  AND (version = ?24 OR ?24 IS NULL)
+RETURNING book_id, title, isbn13, isbn10, price, publication_year, image_url, edition, publisher_id, cover_key, language, format, page_count, series_name, series_number, description, deleted_at, version, created_at, updated_at
 `
 
 type UpdateBookByISBNParams struct {
-	Title           sql.NullString  `json:"title"`
-	NewIsbn13       sql.NullString  `json:"new_isbn13"`
-	NewIsbn10       sql.NullString  `json:"new_isbn10"`
-	Price           sql.NullFloat64 `json:"price"`
-	PublicationYear sql.NullInt64   `json:"publication_year"`
-	ImageUrl        sql.NullString  `json:"image_url"`
-	Isbn13          sql.NullString  `json:"isbn13"`
-	Isbn10          sql.NullString  `json:"isbn10"`
+	Title             sql.NullString  `json:"title"`
+	ClearIsbn13       bool            `json:"clear_isbn13"`
+	NewIsbn13         sql.NullString  `json:"new_isbn13"`
+	ClearIsbn10       bool            `json:"clear_isbn10"`
+	NewIsbn10         sql.NullString  `json:"new_isbn10"`
+	Price             sql.NullFloat64 `json:"price"`
+	PublicationYear   sql.NullInt64   `json:"publication_year"`
+	ClearImageUrl     bool            `json:"clear_image_url"`
+	ImageUrl          sql.NullString  `json:"image_url"`
+	ClearLanguage     bool            `json:"clear_language"`
+	Language          sql.NullString  `json:"language"`
+	ClearFormat       bool            `json:"clear_format"`
+	Format            sql.NullString  `json:"format"`
+	ClearPageCount    bool            `json:"clear_page_count"`
+	PageCount         sql.NullInt64   `json:"page_count"`
+	ClearSeriesName   bool            `json:"clear_series_name"`
+	SeriesName        sql.NullString  `json:"series_name"`
+	ClearSeriesNumber bool            `json:"clear_series_number"`
+	SeriesNumber      sql.NullInt64   `json:"series_number"`
+	ClearDescription  bool            `json:"clear_description"`
+	Description       sql.NullString  `json:"description"`
+	Isbn13            sql.NullString  `json:"isbn13"`
+	Isbn10            sql.NullString  `json:"isbn10"`
+	Version           sql.NullInt64   `json:"version"`
 }
 
 func (q *Queries) UpdateBookByISBN(ctx context.Context, arg UpdateBookByISBNParams) (Book, error) {
 	row := q.db.QueryRowContext(ctx, updateBookByISBN,
 		arg.Title,
+		arg.ClearIsbn13,
 		arg.NewIsbn13,
+		arg.ClearIsbn10,
 		arg.NewIsbn10,
 		arg.Price,
 		arg.PublicationYear,
+		arg.ClearImageUrl,
 		arg.ImageUrl,
+		arg.ClearLanguage,
+		arg.Language,
+		arg.ClearFormat,
+		arg.Format,
+		arg.ClearPageCount,
+		arg.PageCount,
+		arg.ClearSeriesName,
+		arg.SeriesName,
+		arg.ClearSeriesNumber,
+		arg.SeriesNumber,
+		arg.ClearDescription,
+		arg.Description,
 		arg.Isbn13,
 		arg.Isbn10,
+		arg.Version,
 	)
 	var i Book
 	err := row.Scan(
@@ -312,6 +883,17 @@ func (q *Queries) UpdateBookByISBN(ctx context.Context, arg UpdateBookByISBNPara
 		&i.ImageUrl,
 		&i.Edition,
 		&i.PublisherID,
+		&i.CoverKey,
+		&i.Language,
+		&i.Format,
+		&i.PageCount,
+		&i.SeriesName,
+		&i.SeriesNumber,
+		&i.Description,
+		&i.DeletedAt,
+		&i.Version,
+		&i.CreatedAt,
+		&i.UpdatedAt,
 	)
 	return i, err
 }
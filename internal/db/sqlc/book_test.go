@@ -4,8 +4,12 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"os"
+	"slices"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/atsuyaourt/xyz-books/internal/util"
 	"github.com/stretchr/testify/require"
@@ -152,6 +156,14 @@ func (ts *BookTestSuite) TestListBooks() {
 		require.NotEmpty(t, books[i])
 	}
 
+	sorted := slices.Clone(books)
+	slices.SortFunc(sorted, func(a, b Book) int {
+		if c := strings.Compare(a.Title, b.Title); c != 0 {
+			return c
+		}
+		return int(a.BookID - b.BookID)
+	})
+
 	testCases := []struct {
 		name        string
 		arg         ListBooksParams
@@ -188,6 +200,21 @@ func (ts *BookTestSuite) TestListBooks() {
 				require.Len(t, gotBooks, len(books)-2)
 			},
 		},
+		{
+			name: "After",
+			arg: ListBooksParams{
+				Limit:      int64(len(books)),
+				AfterTitle: sql.NullString{String: sorted[1].Title, Valid: true},
+				AfterID:    sql.NullInt64{Int64: sorted[1].BookID, Valid: true},
+			},
+			checkResult: func(gotBooks []ListBooksRow, err error) {
+				require.NoError(t, err)
+				require.Len(t, gotBooks, len(books)-2)
+				for i, row := range gotBooks {
+					require.Equal(t, sorted[i+2].BookID, row.Book.BookID)
+				}
+			},
+		},
 		{
 			name: "Title",
 			arg: ListBooksParams{
@@ -491,15 +518,587 @@ func (ts *BookTestSuite) TestDeleteBookByISBN() {
 
 		t.Run(tc.name, func(t *testing.T) {
 			ctx := context.Background()
-			err := testStore.DeleteBookByISBN(ctx, tc.arg)
+			n, err := testStore.DeleteBookByISBN(ctx, tc.arg)
 			require.NoError(t, err)
-			book, err := testStore.GetBookByISBN(ctx, GetBookByISBNParams(tc.arg))
-			require.Error(t, err)
+			require.EqualValues(t, 1, n)
+			book, err := testStore.GetBookByISBN(ctx, GetBookByISBNParams{
+				Isbn13: tc.arg.Isbn13,
+				Isbn10: tc.arg.Isbn10,
+			})
+			require.ErrorIs(t, err, ErrRecordNotFound)
 			require.Empty(t, book)
+
+			book, err = testStore.GetBookByISBN(ctx, GetBookByISBNParams{
+				Isbn13:         tc.arg.Isbn13,
+				Isbn10:         tc.arg.Isbn10,
+				IncludeDeleted: true,
+			})
+			require.NoError(t, err)
+			require.True(t, book.Book.DeletedAt.Valid)
+
+			restored, err := testStore.RestoreBookByISBN(ctx, RestoreBookByISBNParams{
+				Isbn13: tc.arg.Isbn13,
+				Isbn10: tc.arg.Isbn10,
+			})
+			require.NoError(t, err)
+			require.False(t, restored.DeletedAt.Valid)
+
+			_, err = testStore.GetBookByISBN(ctx, GetBookByISBNParams{
+				Isbn13: tc.arg.Isbn13,
+				Isbn10: tc.arg.Isbn10,
+			})
+			require.NoError(t, err)
+
+			// a live book is left as is
+			_, err = testStore.RestoreBookByISBN(ctx, RestoreBookByISBNParams{
+				Isbn13: tc.arg.Isbn13,
+				Isbn10: tc.arg.Isbn10,
+			})
+			require.ErrorIs(t, err, ErrRecordNotFound)
+			book, err = testStore.GetBookByISBN(ctx, GetBookByISBNParams{
+				Isbn13: tc.arg.Isbn13,
+				Isbn10: tc.arg.Isbn10,
+			})
+			require.NoError(t, err)
+			require.Equal(t, restored.Version, book.Book.Version)
+		})
+	}
+}
+
+func (ts *BookTestSuite) TestPurgeTx() {
+	t := ts.T()
+	ctx := context.Background()
+
+	books := make([]Book, 2)
+	for i := range books {
+		books[i] = createRandomBook(t)
+		_, err := testStore.DeleteBookByISBN(ctx, DeleteBookByISBNParams{Isbn13: books[i].Isbn13})
+		require.NoError(t, err)
+	}
+
+	// the first book's author and publisher are deleted too, the second's are kept
+	authors, err := testStore.ListAuthorsWithBookID(ctx, books[0].BookID)
+	require.NoError(t, err)
+	require.Len(t, authors, 1)
+	_, err = testStore.DeleteAuthor(ctx, DeleteAuthorParams{AuthorID: authors[0].Author.AuthorID})
+	require.NoError(t, err)
+	_, err = testStore.DeletePublisher(ctx, DeletePublisherParams{PublisherID: books[0].PublisherID})
+	require.NoError(t, err)
+
+	res, err := testStore.PurgeTx(ctx, time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, PurgeTxResult{}, res, "nothing was deleted an hour ago")
+
+	res, err = testStore.PurgeTx(ctx, time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	require.Equal(t, PurgeTxResult{Books: 2, Authors: 1, Publishers: 1}, res)
+
+	for _, book := range books {
+		_, err = testStore.GetBookByISBN(ctx, GetBookByISBNParams{
+			Isbn13:         book.Isbn13,
+			IncludeDeleted: true,
+		})
+		require.ErrorIs(t, err, ErrRecordNotFound)
+
+		authors, err := testStore.ListAuthorsWithBookID(ctx, book.BookID)
+		require.NoError(t, err)
+		require.Empty(t, authors)
+	}
+
+	_, err = testStore.GetPublisher(ctx, GetPublisherParams{
+		PublisherID:    books[1].PublisherID,
+		IncludeDeleted: true,
+	})
+	require.NoError(t, err)
+}
+
+func (ts *BookTestSuite) TestBookMetadata() {
+	t := ts.T()
+	ctx := context.Background()
+	publisher := createRandomPublisher(t)
+
+	createBook := func(language, format string, pageCount int64, subjects []string) Book {
+		isbn := util.NewISBN(util.RandomISBN13())
+		book, err := testStore.CreateBookTx(ctx, CreateBookTxParams{
+			Book: CreateBookParams{
+				Title:           util.RandomString(24),
+				Isbn13:          sql.NullString{String: isbn.ISBN13, Valid: true},
+				Isbn10:          sql.NullString{String: isbn.ISBN10, Valid: true},
+				Price:           float64(util.RandomFloat(50.0, 999.9)),
+				PublicationYear: util.RandomInt(1111, 2222),
+				Language:        sql.NullString{String: language, Valid: true},
+				Format:          sql.NullString{String: format, Valid: true},
+				PageCount:       sql.NullInt64{Int64: pageCount, Valid: true},
+				SeriesName:      sql.NullString{String: "Discworld", Valid: true},
+				SeriesNumber:    sql.NullInt64{Int64: pageCount / 100, Valid: true},
+			},
+			Authors:   []util.Name{*util.NewName("Terry Pratchett")},
+			Publisher: publisher.PublisherName,
+			Subjects:  subjects,
+		})
+		require.NoError(t, err)
+		return book
+	}
+
+	book1 := createBook("en-GB", "paperback", 300, []string{"Humor", "Fantasy"})
+	book2 := createBook("en", "hardcover", 500, []string{"fantasy"})
+	createBook("de", "ebook", 200, []string{})
+
+	row, err := testStore.GetBookByISBN(ctx, GetBookByISBNParams{Isbn13: book1.Isbn13})
+	require.NoError(t, err)
+	require.Equal(t, "Fantasy,Humor", row.Subjects)
+	require.Equal(t, "paperback", row.Book.Format.String)
+	require.Equal(t, int64(300), row.Book.PageCount.Int64)
+
+	testCases := []struct {
+		name     string
+		arg      ListBooksParams
+		expected []Book
+	}{
+		{
+			name:     "Subject",
+			arg:      ListBooksParams{Subject: sql.NullString{String: "FANTASY", Valid: true}},
+			expected: []Book{book1, book2},
+		},
+		{
+			name:     "Language",
+			arg:      ListBooksParams{Language: sql.NullString{String: "en", Valid: true}},
+			expected: []Book{book1, book2},
+		},
+		{
+			name:     "LanguageRegion",
+			arg:      ListBooksParams{Language: sql.NullString{String: "en-GB", Valid: true}},
+			expected: []Book{book1},
+		},
+		{
+			name:     "Format",
+			arg:      ListBooksParams{Format: sql.NullString{String: "hardcover", Valid: true}},
+			expected: []Book{book2},
+		},
+		{
+			name: "PageCount",
+			arg: ListBooksParams{
+				MinPageCount: sql.NullInt64{Int64: 250, Valid: true},
+				MaxPageCount: sql.NullInt64{Int64: 400, Valid: true},
+			},
+			expected: []Book{book1},
+		},
+		{
+			name:     "SeriesNumber",
+			arg:      ListBooksParams{SeriesNumber: sql.NullInt64{Int64: 5, Valid: true}},
+			expected: []Book{book2},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			tc.arg.Limit = 10
+			rows, err := testStore.ListBooks(ctx, tc.arg)
+			require.NoError(t, err)
+			require.Len(t, rows, len(tc.expected))
+
+			count, err := testStore.CountBooks(ctx, CountBooksParams{
+				Language:     tc.arg.Language,
+				Format:       tc.arg.Format,
+				MinPageCount: tc.arg.MinPageCount,
+				MaxPageCount: tc.arg.MaxPageCount,
+				SeriesNumber: tc.arg.SeriesNumber,
+				Subject:      tc.arg.Subject,
+			})
+			require.NoError(t, err)
+			require.Equal(t, int64(len(tc.expected)), count)
+
+			var titles []string
+			for _, row := range rows {
+				titles = append(titles, row.Book.Title)
+			}
+			for _, book := range tc.expected {
+				require.Contains(t, titles, book.Title)
+			}
 		})
 	}
 }
 
+func (ts *BookTestSuite) TestUpdateBookTx() {
+	t := ts.T()
+	ctx := context.Background()
+	book := createRandomBook(t)
+
+	arg := UpdateBookByISBNParams{
+		Isbn13:      book.Isbn13,
+		Description: sql.NullString{String: util.RandomString(64), Valid: true},
+	}
+
+	_, err := testStore.UpdateBookTx(ctx, UpdateBookTxParams{
+		Book:     arg,
+		Subjects: []string{"Fantasy", "Humor"},
+	})
+	require.NoError(t, err)
+
+	row, err := testStore.GetBookByISBN(ctx, GetBookByISBNParams{Isbn13: book.Isbn13})
+	require.NoError(t, err)
+	require.Equal(t, arg.Description, row.Book.Description)
+	require.ElementsMatch(t, []string{"Fantasy", "Humor"}, strings.Split(row.Subjects, ","))
+
+	// nil subjects are left unchanged
+	_, err = testStore.UpdateBookTx(ctx, UpdateBookTxParams{Book: arg})
+	require.NoError(t, err)
+	row, err = testStore.GetBookByISBN(ctx, GetBookByISBNParams{Isbn13: book.Isbn13})
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"Fantasy", "Humor"}, strings.Split(row.Subjects, ","))
+
+	_, err = testStore.UpdateBookTx(ctx, UpdateBookTxParams{Book: arg, Subjects: []string{}})
+	require.NoError(t, err)
+	row, err = testStore.GetBookByISBN(ctx, GetBookByISBNParams{Isbn13: book.Isbn13})
+	require.NoError(t, err)
+	require.Empty(t, row.Subjects)
+}
+
+func (ts *BookTestSuite) TestUpdateBookTxClear() {
+	t := ts.T()
+	ctx := context.Background()
+	book := createRandomBook(t)
+
+	_, err := testStore.UpdateBookTx(ctx, UpdateBookTxParams{
+		Book: UpdateBookByISBNParams{
+			Isbn13:      book.Isbn13,
+			Price:       sql.NullFloat64{Float64: 0, Valid: true},
+			ImageUrl:    sql.NullString{String: "https://example.com/cover.jpg", Valid: true},
+			Description: sql.NullString{String: util.RandomString(32), Valid: true},
+		},
+	})
+	require.NoError(t, err)
+
+	updated, err := testStore.UpdateBookTx(ctx, UpdateBookTxParams{
+		Book: UpdateBookByISBNParams{
+			Isbn13:           book.Isbn13,
+			ClearImageUrl:    true,
+			ClearDescription: true,
+			ClearIsbn10:      true,
+		},
+	})
+	require.NoError(t, err)
+	require.Zero(t, updated.Price)
+	require.False(t, updated.ImageUrl.Valid)
+	require.False(t, updated.Description.Valid)
+	require.False(t, updated.Isbn10.Valid)
+	require.Equal(t, book.Isbn13, updated.Isbn13)
+
+	// the last ISBN cannot go
+	_, err = testStore.UpdateBookTx(ctx, UpdateBookTxParams{
+		Book: UpdateBookByISBNParams{
+			Isbn13:      book.Isbn13,
+			ClearIsbn13: true,
+		},
+	})
+	require.ErrorIs(t, err, ErrMissingISBN)
+
+	row, err := testStore.GetBookByISBN(ctx, GetBookByISBNParams{Isbn13: book.Isbn13})
+	require.NoError(t, err)
+	require.Equal(t, updated.Version, row.Book.Version, "rolled back")
+}
+
+func (ts *BookTestSuite) TestUpdateBookTxVersion() {
+	t := ts.T()
+	ctx := context.Background()
+	book := createRandomBook(t)
+	require.EqualValues(t, 1, book.Version)
+
+	arg := UpdateBookByISBNParams{
+		Isbn13:  book.Isbn13,
+		Title:   sql.NullString{String: util.RandomString(24), Valid: true},
+		Version: sql.NullInt64{Int64: book.Version, Valid: true},
+	}
+
+	updated, err := testStore.UpdateBookTx(ctx, UpdateBookTxParams{Book: arg})
+	require.NoError(t, err)
+	require.Equal(t, book.Version+1, updated.Version)
+
+	// a second writer still holding the first version
+	_, err = testStore.UpdateBookTx(ctx, UpdateBookTxParams{Book: arg})
+	require.ErrorIs(t, err, ErrVersionChanged)
+
+	arg.Isbn13 = sql.NullString{String: util.RandomISBN13(), Valid: true}
+	_, err = testStore.UpdateBookTx(ctx, UpdateBookTxParams{Book: arg})
+	require.ErrorIs(t, err, ErrRecordNotFound)
+
+	// author changes show in the book, so its version moves on
+	authors, err := testStore.ListAuthorsWithBookID(ctx, book.BookID)
+	require.NoError(t, err)
+	err = testStore.BumpAuthorBookVersions(ctx, authors[0].Author.AuthorID)
+	require.NoError(t, err)
+	row, err := testStore.GetBookByISBN(ctx, GetBookByISBNParams{Isbn13: book.Isbn13})
+	require.NoError(t, err)
+	require.Equal(t, updated.Version+1, row.Book.Version)
+}
+
+func (ts *BookTestSuite) TestBookTxHooks() {
+	t := ts.T()
+	ctx := context.Background()
+	createRandomWebhook(t, "book.created,book.updated")
+	book := createRandomBook(t)
+
+	enqueue := func(q Querier, event string) error {
+		_, err := q.EnqueueWebhookDeliveries(ctx, EnqueueWebhookDeliveriesParams{
+			EventID:       util.RandomString(8),
+			Event:         event,
+			Payload:       "{}",
+			NextAttemptAt: 1000,
+		})
+		return err
+	}
+	requireDeliveries := func(n int) {
+		rows, err := testStore.ListDueWebhookDeliveries(ctx, ListDueWebhookDeliveriesParams{Now: 1000, Limit: 10})
+		require.NoError(t, err)
+		require.Len(t, rows, n)
+	}
+
+	price := book.Price + 1
+	var old Book
+	_, err := testStore.UpdateBookTx(ctx, UpdateBookTxParams{
+		Book: UpdateBookByISBNParams{
+			Isbn13: book.Isbn13,
+			Price:  sql.NullFloat64{Float64: price, Valid: true},
+		},
+		AfterUpdate: func(q Querier, o, updated Book) error {
+			old = o
+			require.Equal(t, price, updated.Price)
+			return enqueue(q, "book.updated")
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, book.Price, old.Price)
+	requireDeliveries(1)
+
+	// a failing hook rolls back the change along with its deliveries
+	errHook := errors.New("hook failed")
+	_, err = testStore.UpdateBookTx(ctx, UpdateBookTxParams{
+		Book: UpdateBookByISBNParams{
+			Isbn13: book.Isbn13,
+			Price:  sql.NullFloat64{Float64: price + 1, Valid: true},
+		},
+		AfterUpdate: func(q Querier, _, _ Book) error {
+			require.NoError(t, enqueue(q, "book.updated"))
+			return errHook
+		},
+	})
+	require.ErrorIs(t, err, errHook)
+	row, err := testStore.GetBookByISBN(ctx, GetBookByISBNParams{Isbn13: book.Isbn13})
+	require.NoError(t, err)
+	require.Equal(t, price, row.Book.Price)
+	requireDeliveries(1)
+
+	isbn := sql.NullString{String: util.RandomISBN13(), Valid: true}
+	_, err = testStore.CreateBookTx(ctx, CreateBookTxParams{
+		Book: CreateBookParams{
+			Title:           util.RandomString(24),
+			Isbn13:          isbn,
+			Price:           price,
+			PublicationYear: util.RandomInt(1111, 2222),
+		},
+		Authors:   []util.Name{*util.NewName("Zed Zulu")},
+		Publisher: util.RandomString(12),
+		AfterCreate: func(q Querier, _ Book) error {
+			require.NoError(t, enqueue(q, "book.created"))
+			return errHook
+		},
+	})
+	require.ErrorIs(t, err, errHook)
+	_, err = testStore.GetBookByISBN(ctx, GetBookByISBNParams{Isbn13: isbn})
+	require.ErrorIs(t, err, ErrRecordNotFound)
+	requireDeliveries(1)
+}
+
+func (ts *BookTestSuite) TestBookContributors() {
+	t := ts.T()
+	ctx := context.Background()
+	publisher := createRandomPublisher(t)
+
+	isbn := util.NewISBN(util.RandomISBN13())
+	_, err := testStore.CreateBookTx(ctx, CreateBookTxParams{
+		Book: CreateBookParams{
+			Title:           util.RandomString(24),
+			Isbn13:          sql.NullString{String: isbn.ISBN13, Valid: true},
+			Isbn10:          sql.NullString{String: isbn.ISBN10, Valid: true},
+			Price:           float64(util.RandomFloat(50.0, 999.9)),
+			PublicationYear: util.RandomInt(1111, 2222),
+		},
+		Authors: []util.Name{*util.NewName("Zed Zulu"), *util.NewName("Amy Adams")},
+		Contributors: []Contributor{
+			{Name: *util.NewName("Bob B. Brown"), Role: "illustrator"},
+			{Name: *util.NewName("Amy Adams"), Role: "colorist"},
+			{Name: *util.NewName("Cat Crane"), Role: ContributorRoleAuthor},
+		},
+		Publisher: publisher.PublisherName,
+	})
+	require.NoError(t, err)
+
+	row, err := testStore.GetBookByISBN(ctx, GetBookByISBNParams{Isbn13: sql.NullString{String: isbn.ISBN13, Valid: true}})
+	require.NoError(t, err)
+	require.Equal(t, "Zed Zulu,Amy Adams,Cat Crane", row.Authors)
+	require.Equal(t, "author:Zed Zulu,author:Amy Adams,illustrator:Bob B. Brown,colorist:Amy Adams,author:Cat Crane", row.Contributors)
+
+	// the author filter leaves out the other contributors
+	rows, err := testStore.ListBooks(ctx, ListBooksParams{
+		Author: sql.NullString{String: "Brown", Valid: true},
+		Limit:  10,
+	})
+	require.NoError(t, err)
+	require.Empty(t, rows)
+
+	rows, err = testStore.ListBooks(ctx, ListBooksParams{
+		Author: sql.NullString{String: "Crane", Valid: true},
+		Limit:  10,
+	})
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	require.Equal(t, row.Authors, rows[0].Authors)
+	require.Equal(t, row.Contributors, rows[0].Contributors)
+
+	count, err := testStore.CountBooks(ctx, CountBooksParams{
+		Author: sql.NullString{String: "Brown", Valid: true},
+	})
+	require.NoError(t, err)
+	require.Zero(t, count)
+}
+
+func (ts *BookTestSuite) TestCreateBookTxRestores() {
+	t := ts.T()
+	ctx := context.Background()
+	author := createRandomAuthor(t)
+	publisher := createRandomPublisher(t)
+
+	_, err := testStore.DeleteAuthor(ctx, DeleteAuthorParams{AuthorID: author.AuthorID})
+	require.NoError(t, err)
+	_, err = testStore.DeletePublisher(ctx, DeletePublisherParams{PublisherID: publisher.PublisherID})
+	require.NoError(t, err)
+
+	isbn := util.NewISBN(util.RandomISBN13())
+	arg := CreateBookTxParams{
+		Book: CreateBookParams{
+			Title:           util.RandomString(24),
+			Isbn13:          sql.NullString{String: isbn.ISBN13, Valid: true},
+			Isbn10:          sql.NullString{String: isbn.ISBN10, Valid: true},
+			Price:           float64(util.RandomFloat(50.0, 999.9)),
+			PublicationYear: util.RandomInt(1111, 2222),
+		},
+		Authors: []util.Name{{
+			FirstName:  author.FirstName,
+			LastName:   author.LastName,
+			MiddleName: author.MiddleName,
+		}},
+		Publisher: publisher.PublisherName,
+	}
+	book, err := testStore.CreateBookTx(ctx, arg)
+	require.NoError(t, err)
+	require.Equal(t, publisher.PublisherID, book.PublisherID)
+
+	// crediting them brought the same records back
+	gotAuthor, err := testStore.GetAuthor(ctx, GetAuthorParams{AuthorID: author.AuthorID})
+	require.NoError(t, err)
+	require.False(t, gotAuthor.DeletedAt.Valid)
+
+	gotPublisher, err := testStore.GetPublisher(ctx, GetPublisherParams{PublisherID: publisher.PublisherID})
+	require.NoError(t, err)
+	require.False(t, gotPublisher.DeletedAt.Valid)
+
+	// a deleted book is only brought back by restoring it
+	_, err = testStore.DeleteBookByISBN(ctx, DeleteBookByISBNParams{Isbn13: book.Isbn13})
+	require.NoError(t, err)
+	arg.Book.Title = util.RandomString(24)
+	_, err = testStore.CreateBookTx(ctx, arg)
+	require.ErrorIs(t, err, ErrRecordDeleted)
+}
+
+func (ts *BookTestSuite) TestBookRelationships() {
+	t := ts.T()
+	ctx := context.Background()
+	publisher := createRandomPublisher(t)
+
+	createBook := func(year int64, authors ...string) Book {
+		isbn := util.NewISBN(util.RandomISBN13())
+		names := make([]util.Name, len(authors))
+		for i, a := range authors {
+			names[i] = *util.NewName(a)
+		}
+		book, err := testStore.CreateBookTx(ctx, CreateBookTxParams{
+			Book: CreateBookParams{
+				Title:           util.RandomString(24),
+				Isbn13:          sql.NullString{String: isbn.ISBN13, Valid: true},
+				Isbn10:          sql.NullString{String: isbn.ISBN10, Valid: true},
+				Price:           float64(util.RandomFloat(50.0, 999.9)),
+				PublicationYear: year,
+			},
+			Authors:   names,
+			Publisher: publisher.PublisherName,
+		})
+		require.NoError(t, err)
+		return book
+	}
+	book1 := createBook(2001, "Zed Zulu", "Amy Adams")
+	book2 := createBook(1999, "Amy Adams")
+	deleted := createBook(2005, "Amy Adams")
+	_, err := testStore.DeleteBookByISBN(ctx, DeleteBookByISBNParams{Isbn13: deleted.Isbn13})
+	require.NoError(t, err)
+
+	authors, err := testStore.ListBookAuthors(ctx, []int64{book1.BookID, book2.BookID})
+	require.NoError(t, err)
+	require.Len(t, authors, 3)
+	require.Equal(t, book1.BookID, authors[0].BookID)
+	require.Equal(t, "Zed", authors[0].Author.FirstName)
+	require.Equal(t, "Amy", authors[1].Author.FirstName)
+	require.Equal(t, book2.BookID, authors[2].BookID)
+	amy := authors[1].Author.AuthorID
+	require.Equal(t, amy, authors[2].Author.AuthorID)
+
+	publishers, err := testStore.ListBookPublishers(ctx, []int64{book1.BookID, book2.BookID})
+	require.NoError(t, err)
+	require.Len(t, publishers, 2)
+	for _, row := range publishers {
+		require.Equal(t, publisher.PublisherID, row.Publisher.PublisherID)
+	}
+
+	// deleted books are left out, the rest come by publication year
+	authorBooks, err := testStore.ListAuthorBooks(ctx, []int64{amy})
+	require.NoError(t, err)
+	require.Len(t, authorBooks, 2)
+	require.Equal(t, book2.BookID, authorBooks[0].Book.BookID)
+	require.Equal(t, book1.BookID, authorBooks[1].Book.BookID)
+	require.Equal(t, "Zed Zulu,Amy Adams", authorBooks[1].Authors)
+
+	publisherBooks, err := testStore.ListPublisherBooks(ctx, []int64{publisher.PublisherID})
+	require.NoError(t, err)
+	require.Len(t, publisherBooks, 2)
+	require.Equal(t, book2.BookID, publisherBooks[0].Book.BookID)
+	require.Equal(t, publisher.PublisherName, publisherBooks[0].PublisherName)
+}
+
+func (ts *BookTestSuite) TestSetBookCover() {
+	t := ts.T()
+	book := createRandomBook(t)
+	ctx := context.Background()
+
+	key := sql.NullString{
+		String: "covers/" + util.RandomString(16) + ".png",
+		Valid:  true,
+	}
+	updatedBook, err := testStore.SetBookCover(ctx, SetBookCoverParams{
+		BookID:   book.BookID,
+		CoverKey: key,
+	})
+	require.NoError(t, err)
+	requireBookEqual(t, book, updatedBook)
+	require.Equal(t, key, updatedBook.CoverKey)
+
+	updatedBook, err = testStore.SetBookCover(ctx, SetBookCoverParams{
+		BookID: book.BookID,
+	})
+	require.NoError(t, err)
+	require.False(t, updatedBook.CoverKey.Valid)
+}
+
 func createRandomBook(t *testing.T) Book {
 	isbn := util.NewISBN(util.RandomISBN13())
 	publisher := createRandomPublisher(t)
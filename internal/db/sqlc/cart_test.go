@@ -0,0 +1,174 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/atsuyaourt/xyz-books/internal/util"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type CartTestSuite struct {
+	suite.Suite
+}
+
+func TestCartTestSuite(t *testing.T) {
+	suite.Run(t, new(CartTestSuite))
+}
+
+func (ts *CartTestSuite) SetupTest() {
+	err := util.DBMigrationUp(testConfig.MigrationSrc, testDBUrl)
+	require.NoError(ts.T(), err, "db migration problem")
+}
+
+func (ts *CartTestSuite) TearDownTest() {
+	err := util.DBMigrationDown(testConfig.MigrationSrc, testDBUrl)
+	require.NoError(ts.T(), err, "reverse db migration problem")
+}
+
+func (ts *CartTestSuite) TestCreateCart() {
+	createRandomCart(ts.T())
+}
+
+func (ts *CartTestSuite) TestGetCartByUserID() {
+	t := ts.T()
+	ctx := context.Background()
+	cart := createRandomCart(t)
+
+	userID := sql.NullString{String: util.RandomString(8), Valid: true}
+	updatedCart, err := testStore.SetCartUserID(ctx, SetCartUserIDParams{
+		CartID: cart.CartID,
+		UserID: userID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, userID, updatedCart.UserID)
+
+	gotCart, err := testStore.GetCartByUserID(ctx, userID)
+	require.NoError(t, err)
+	require.Equal(t, cart.CartID, gotCart.CartID)
+	require.Equal(t, cart.Token, gotCart.Token)
+}
+
+func (ts *CartTestSuite) TestAddCartItem() {
+	t := ts.T()
+	ctx := context.Background()
+	cart := createRandomCart(t)
+	book := createRandomBook(t)
+
+	for i := 0; i < 2; i++ {
+		err := testStore.AddCartItem(ctx, AddCartItemParams{
+			CartID:   cart.CartID,
+			BookID:   book.BookID,
+			Quantity: 2,
+		})
+		require.NoError(t, err)
+	}
+
+	items, err := testStore.ListCartItems(ctx, cart.CartID)
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	require.Equal(t, int64(4), items[0].Quantity)
+	requireBookEqual(t, book, items[0].Book)
+
+	// the quantity stops at 99
+	err = testStore.AddCartItem(ctx, AddCartItemParams{
+		CartID:   cart.CartID,
+		BookID:   book.BookID,
+		Quantity: 99,
+	})
+	require.NoError(t, err)
+
+	items, err = testStore.ListCartItems(ctx, cart.CartID)
+	require.NoError(t, err)
+	require.Equal(t, int64(99), items[0].Quantity)
+}
+
+func (ts *CartTestSuite) TestSetCartItemQuantity() {
+	t := ts.T()
+	ctx := context.Background()
+	cart := createRandomCart(t)
+	book := createRandomBook(t)
+
+	n, err := testStore.SetCartItemQuantity(ctx, SetCartItemQuantityParams{
+		CartID:   cart.CartID,
+		BookID:   book.BookID,
+		Quantity: 3,
+	})
+	require.NoError(t, err)
+	require.Zero(t, n)
+
+	err = testStore.AddCartItem(ctx, AddCartItemParams{
+		CartID:   cart.CartID,
+		BookID:   book.BookID,
+		Quantity: 1,
+	})
+	require.NoError(t, err)
+
+	n, err = testStore.SetCartItemQuantity(ctx, SetCartItemQuantityParams{
+		CartID:   cart.CartID,
+		BookID:   book.BookID,
+		Quantity: 3,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), n)
+
+	items, err := testStore.ListCartItems(ctx, cart.CartID)
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	require.Equal(t, int64(3), items[0].Quantity)
+}
+
+func (ts *CartTestSuite) TestMergeCartTx() {
+	t := ts.T()
+	ctx := context.Background()
+	fromCart := createRandomCart(t)
+	toCart := createRandomCart(t)
+	sharedBook := createRandomBook(t)
+	otherBook := createRandomBook(t)
+	fullBook := createRandomBook(t)
+
+	for _, arg := range []AddCartItemParams{
+		{CartID: fromCart.CartID, BookID: sharedBook.BookID, Quantity: 1},
+		{CartID: fromCart.CartID, BookID: otherBook.BookID, Quantity: 2},
+		{CartID: toCart.CartID, BookID: sharedBook.BookID, Quantity: 3},
+		{CartID: fromCart.CartID, BookID: fullBook.BookID, Quantity: 60},
+		{CartID: toCart.CartID, BookID: fullBook.BookID, Quantity: 60},
+	} {
+		require.NoError(t, testStore.AddCartItem(ctx, arg))
+	}
+
+	err := testStore.MergeCartTx(ctx, MergeCartTxParams{
+		FromCartID: fromCart.CartID,
+		ToCartID:   toCart.CartID,
+	})
+	require.NoError(t, err)
+
+	items, err := testStore.ListCartItems(ctx, toCart.CartID)
+	require.NoError(t, err)
+	require.Len(t, items, 3)
+
+	quantities := map[int64]int64{}
+	for _, item := range items {
+		quantities[item.Book.BookID] = item.Quantity
+	}
+	require.Equal(t, int64(4), quantities[sharedBook.BookID])
+	require.Equal(t, int64(2), quantities[otherBook.BookID])
+	require.Equal(t, int64(99), quantities[fullBook.BookID])
+
+	_, err = testStore.GetCartByToken(ctx, fromCart.Token)
+	require.ErrorIs(t, err, ErrRecordNotFound)
+}
+
+func createRandomCart(t *testing.T) Cart {
+	token := util.RandomString(32)
+
+	cart, err := testStore.CreateCart(context.Background(), CreateCartParams{Token: token})
+	require.NoError(t, err)
+	require.NotEmpty(t, cart)
+	require.Equal(t, token, cart.Token)
+	require.False(t, cart.UserID.Valid)
+
+	return cart
+}
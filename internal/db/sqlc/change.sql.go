@@ -0,0 +1,79 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.26.0
+// source: change.sql
+
+package db
+
+import (
+	"context"
+	"time"
+)
+
+const listChanges = `-- name: ListChanges :many
+SELECT
+  'book' AS entity,
+  CAST(book_id AS TEXT) AS entity_id,
+  CAST(COALESCE(isbn13, isbn10) AS TEXT) AS isbn,
+  version,
+  updated_at,
+  CAST(deleted_at IS NOT NULL AS BOOLEAN) AS deleted
+FROM books
+WHERE books.updated_at > ?2
+UNION ALL
+SELECT 'author', CAST(author_id AS TEXT), '', version, updated_at, CAST(deleted_at IS NOT NULL AS BOOLEAN)
+FROM authors
+WHERE authors.updated_at > ?2
+UNION ALL
+SELECT 'publisher', CAST(publisher_id AS TEXT), '', version, updated_at, CAST(deleted_at IS NOT NULL AS BOOLEAN)
+FROM publishers
+WHERE publishers.updated_at > ?2
+ORDER BY updated_at, entity, entity_id
+LIMIT ?1
+`
+
+type ListChangesParams struct {
+	Limit int64     `json:"limit"`
+	Since time.Time `json:"since"`
+}
+
+type ListChangesRow struct {
+	Entity    string    `json:"entity"`
+	EntityID  string    `json:"entity_id"`
+	Isbn      string    `json:"isbn"`
+	Version   int64     `json:"version"`
+	UpdatedAt time.Time `json:"updated_at"`
+	Deleted   bool      `json:"deleted"`
+}
+
+// Books are listed by their ID, which is kept when their ISBNs change, along
+// with their current ISBN.
+func (q *Queries) ListChanges(ctx context.Context, arg ListChangesParams) ([]ListChangesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listChanges, arg.Limit, arg.Since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListChangesRow{}
+	for rows.Next() {
+		var i ListChangesRow
+		if err := rows.Scan(
+			&i.Entity,
+			&i.EntityID,
+			&i.Isbn,
+			&i.Version,
+			&i.UpdatedAt,
+			&i.Deleted,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
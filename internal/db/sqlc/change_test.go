@@ -0,0 +1,187 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/atsuyaourt/xyz-books/internal/util"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type ChangeTestSuite struct {
+	suite.Suite
+}
+
+func TestChangeTestSuite(t *testing.T) {
+	suite.Run(t, new(ChangeTestSuite))
+}
+
+func (ts *ChangeTestSuite) SetupTest() {
+	err := util.DBMigrationUp(testConfig.MigrationSrc, testDBUrl)
+	require.NoError(ts.T(), err, "db migration problem")
+}
+
+func (ts *ChangeTestSuite) TearDownTest() {
+	err := util.DBMigrationDown(testConfig.MigrationSrc, testDBUrl)
+	require.NoError(ts.T(), err, "reverse db migration problem")
+}
+
+func (ts *ChangeTestSuite) TestTimestamps() {
+	t := ts.T()
+
+	publisher := createRandomPublisher(t)
+	require.WithinDuration(t, time.Now(), publisher.CreatedAt, time.Minute)
+	require.Equal(t, publisher.CreatedAt, publisher.UpdatedAt)
+
+	waitNextMillisecond()
+
+	updated, err := testStore.UpdatePublisher(context.Background(), UpdatePublisherParams{
+		PublisherID: publisher.PublisherID,
+		PublisherName: sql.NullString{
+			String: util.RandomString(22),
+			Valid:  true,
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, publisher.CreatedAt, updated.CreatedAt)
+	require.True(t, updated.UpdatedAt.After(publisher.UpdatedAt))
+}
+
+func (ts *ChangeTestSuite) TestListUpdatedSince() {
+	t := ts.T()
+
+	old := createRandomPublisher(t)
+	waitNextMillisecond()
+	publisher := createRandomPublisher(t)
+
+	// a change at exactly the given time is not listed, whatever its zone
+	since := sql.NullTime{Time: After(old.UpdatedAt.In(time.FixedZone("UTC+8", 8*60*60))), Valid: true}
+	publishers, err := testStore.ListPublishers(context.Background(), ListPublishersParams{
+		UpdatedSince: since,
+		Limit:        10,
+	})
+	require.NoError(t, err)
+	require.Len(t, publishers, 1)
+	require.Equal(t, publisher.PublisherID, publishers[0].PublisherID)
+
+	count, err := testStore.CountPublishers(context.Background(), CountPublishersParams{UpdatedSince: since})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), count)
+
+	count, err = testStore.CountPublishers(context.Background(), CountPublishersParams{})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), count)
+}
+
+func (ts *ChangeTestSuite) TestListChanges() {
+	t := ts.T()
+
+	publisher := createRandomPublisher(t)
+	since := publisher.UpdatedAt
+	waitNextMillisecond()
+
+	author := createRandomAuthor(t)
+	waitNextMillisecond()
+
+	_, err := testStore.DeletePublisher(context.Background(), DeletePublisherParams{PublisherID: publisher.PublisherID})
+	require.NoError(t, err)
+	waitNextMillisecond()
+
+	changes, err := testStore.ListChanges(context.Background(), ListChangesParams{
+		Since: After(since),
+		Limit: 10,
+	})
+	require.NoError(t, err)
+	require.Len(t, changes, 2)
+
+	require.Equal(t, "author", changes[0].Entity)
+	require.Equal(t, strconv.FormatInt(author.AuthorID, 10), changes[0].EntityID)
+	require.Equal(t, author.Version, changes[0].Version)
+	require.False(t, changes[0].Deleted)
+
+	require.Equal(t, "publisher", changes[1].Entity)
+	require.Equal(t, strconv.FormatInt(publisher.PublisherID, 10), changes[1].EntityID)
+	require.Equal(t, publisher.Version+1, changes[1].Version)
+	require.True(t, changes[1].Deleted)
+	require.True(t, changes[1].UpdatedAt.After(changes[0].UpdatedAt))
+
+	// reading on from the last change
+	changes, err = testStore.ListChanges(context.Background(), ListChangesParams{
+		Since: After(changes[1].UpdatedAt),
+		Limit: 10,
+	})
+	require.NoError(t, err)
+	require.Empty(t, changes)
+}
+
+func (ts *ChangeTestSuite) TestListChangesBookISBNChanged() {
+	t := ts.T()
+
+	book := createRandomBook(t)
+	since := book.UpdatedAt
+	waitNextMillisecond()
+
+	isbn := util.NewISBN(util.RandomISBN13())
+	updated, err := testStore.UpdateBookByISBN(context.Background(), UpdateBookByISBNParams{
+		NewIsbn13: sql.NullString{String: isbn.ISBN13, Valid: true},
+		Isbn13:    book.Isbn13,
+	})
+	require.NoError(t, err)
+
+	changes, err := testStore.ListChanges(context.Background(), ListChangesParams{
+		Since: After(since),
+		Limit: 10,
+	})
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+
+	// the book is listed under the same id, with its new ISBN
+	require.Equal(t, "book", changes[0].Entity)
+	require.Equal(t, strconv.FormatInt(book.BookID, 10), changes[0].EntityID)
+	require.Equal(t, isbn.ISBN13, changes[0].Isbn)
+	require.Equal(t, updated.Version, changes[0].Version)
+}
+
+func (ts *ChangeTestSuite) TestListChangesRunningTx() {
+	t := ts.T()
+	if testConfig.DBDriver != util.DBDriverPostgres {
+		t.Skip("SQLite runs one writer at a time")
+	}
+
+	// a transaction started before a change, but committed after it
+	other, err := sql.Open("pgx", testConfig.DBSource)
+	require.NoError(t, err)
+	defer other.Close()
+	tx, err := other.Begin()
+	require.NoError(t, err)
+	defer tx.Rollback()
+	_, err = tx.Exec("INSERT INTO publishers (publisher_name) VALUES ('slow')")
+	require.NoError(t, err)
+	waitNextMillisecond()
+
+	publisher := createRandomPublisher(t)
+	waitNextMillisecond()
+
+	// nothing is listed past the start of the running transaction
+	changes, err := testStore.ListChanges(context.Background(), ListChangesParams{Limit: 10})
+	require.NoError(t, err)
+	require.Empty(t, changes)
+
+	require.NoError(t, tx.Commit())
+	waitNextMillisecond()
+
+	changes, err = testStore.ListChanges(context.Background(), ListChangesParams{Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, changes, 2)
+	require.Equal(t, strconv.FormatInt(publisher.PublisherID, 10), changes[1].EntityID)
+}
+
+// waitNextMillisecond makes sure the next change is stamped later than the
+// last one, timestamps are stored to the millisecond
+func waitNextMillisecond() {
+	time.Sleep(2 * time.Millisecond)
+}
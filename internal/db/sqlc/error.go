@@ -2,8 +2,17 @@ package db
 
 import (
 	"database/sql"
+	"errors"
 )
 
 var (
 	ErrRecordNotFound = sql.ErrNoRows
+	ErrEmptyCart      = errors.New("cart is empty")
+	ErrVersionChanged = errors.New("record was changed by someone else")
+	ErrMissingISBN    = errors.New("book needs an ISBN-13 or ISBN-10")
+	// ErrRecordDeleted is returned when a record would take the keys of a
+	// deleted one, which only restoring it brings back
+	ErrRecordDeleted = errors.New("a deleted record has the same key, restore it instead")
+	// ErrNotDeleted is returned when restoring a record that is not deleted
+	ErrNotDeleted = errors.New("record is not deleted")
 )
@@ -2,24 +2,140 @@ package db
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
+	"log/slog"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/atsuyaourt/xyz-books/internal/logging"
+	"github.com/atsuyaourt/xyz-books/internal/tracing"
+	"github.com/jackc/pgx/v5/pgconn"
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+const (
+	defaultTxMaxRetries = 3
+	defaultTxRetryDelay = 20 * time.Millisecond
+	maxTxRetryDelay     = time.Second
 )
 
-// ExecTx executes a function within a database transaction
-func (store *SQLStore) execTx(ctx context.Context, fn func(*Queries) error) error {
-	tx, err := store.db.BeginTx(ctx, nil)
+// TxOptions controls how ExecTx runs a transaction, zero values keep the
+// defaults
+type TxOptions struct {
+	MaxRetries int                // extra attempts after a retryable error, none when negative
+	RetryDelay time.Duration      // first backoff delay, doubled after each attempt
+	Isolation  sql.IsolationLevel // the database default when zero, ignored by SQLite, whose transactions are serializable
+}
+
+type StoreOption func(*SQLStore)
+
+// WithTxOptions replaces the default retry and isolation settings. Zero
+// values keep the defaults, a negative MaxRetries turns retries off.
+func WithTxOptions(opts TxOptions) StoreOption {
+	return func(s *SQLStore) {
+		if opts.MaxRetries != 0 {
+			s.txOptions.MaxRetries = max(opts.MaxRetries, 0)
+		}
+		if opts.RetryDelay > 0 {
+			s.txOptions.RetryDelay = opts.RetryDelay
+		}
+		if opts.Isolation != sql.LevelDefault {
+			s.txOptions.Isolation = opts.Isolation
+		}
+	}
+}
+
+// ParseIsolationLevel reads an isolation level by name, e.g. "repeatable
+// read" or "serializable", in any case and with spaces, dashes or
+// underscores. Empty is the database default.
+func ParseIsolationLevel(name string) (sql.IsolationLevel, error) {
+	if len(name) == 0 {
+		return sql.LevelDefault, nil
+	}
+
+	name = strings.NewReplacer("_", " ", "-", " ").Replace(name)
+	for level := sql.LevelDefault; level <= sql.LevelLinearizable; level++ {
+		if strings.EqualFold(name, level.String()) {
+			return level, nil
+		}
+	}
+
+	return sql.LevelDefault, fmt.Errorf("unknown isolation level %q", name)
+}
+
+// ExecTx executes fn within a database transaction. When the transaction
+// fails because of lock contention or a serialization conflict it is rolled
+// back and fn runs again, so fn must not have side effects outside of q.
+func (store *SQLStore) ExecTx(ctx context.Context, fn func(q Querier) error) error {
+	delay := store.txOptions.RetryDelay
+	for attempt := 0; ; attempt++ {
+		err := store.execTx(ctx, fn)
+		if err == nil || attempt >= store.txOptions.MaxRetries || !isRetryableTxError(err) {
+			return err
+		}
+
+		// full jitter, so that conflicting transactions do not retry in lockstep
+		wait := time.Duration(rand.Int63n(int64(delay) + 1))
+		logging.FromContext(ctx).Warn("retrying transaction",
+			slog.Int("attempt", attempt+1), slog.Duration("delay", wait), slog.Any("error", err))
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("%w (after: %v)", ctx.Err(), err)
+		case <-timer.C:
+		}
+
+		delay = min(delay*2, maxTxRetryDelay)
+	}
+}
+
+func (store *SQLStore) execTx(ctx context.Context, fn func(Querier) error) (err error) {
+	ctx, span := tracing.Start(ctx, "execTx")
+	defer func() { tracing.End(span, err) }()
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	tx, err := store.db.BeginTx(ctx, &sql.TxOptions{Isolation: store.txOptions.Isolation})
 	if err != nil {
 		return err
 	}
 
-	q := New(tx)
+	q := store.newQuerier(tx)
 	err = fn(q)
 	if err != nil {
 		if rbErr := tx.Rollback(); rbErr != nil {
-			return fmt.Errorf("tx err: %v, rb err: %v", err, rbErr)
+			return fmt.Errorf("tx err: %w, rb err: %v", err, rbErr)
 		}
 		return err
 	}
 
 	return tx.Commit()
 }
+
+// isRetryableTxError reports whether running the transaction again may succeed
+func isRetryableTxError(err error) bool {
+	var sqliteErr *sqlite.Error
+	if errors.As(err, &sqliteErr) {
+		// the primary result code is in the low byte of extended codes
+		switch sqliteErr.Code() & 0xff {
+		case sqlite3.SQLITE_BUSY, sqlite3.SQLITE_LOCKED:
+			return true
+		}
+		return false
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		// serialization_failure and deadlock_detected
+		return pgErr.Code == "40001" || pgErr.Code == "40P01"
+	}
+
+	return false
+}
@@ -0,0 +1,205 @@
+package db
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/atsuyaourt/xyz-books/internal/logging"
+	"github.com/atsuyaourt/xyz-books/internal/util"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type ExecTxTestSuite struct {
+	suite.Suite
+}
+
+func TestExecTxTestSuite(t *testing.T) {
+	suite.Run(t, new(ExecTxTestSuite))
+}
+
+func (ts *ExecTxTestSuite) SetupTest() {
+	err := util.DBMigrationUp(testConfig.MigrationSrc, testDBUrl)
+	require.NoError(ts.T(), err, "db migration problem")
+}
+
+func (ts *ExecTxTestSuite) TearDownTest() {
+	err := util.DBMigrationDown(testConfig.MigrationSrc, testDBUrl)
+	require.NoError(ts.T(), err, "reverse db migration problem")
+}
+
+func (ts *ExecTxTestSuite) TestRetry() {
+	t := ts.T()
+	conflict := &pgconn.PgError{Code: "40001"}
+
+	var attempts int
+	err := testStore.ExecTx(context.Background(), func(q Querier) error {
+		attempts++
+		if attempts < 3 {
+			return conflict
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, 3, attempts)
+
+	attempts = 0
+	err = testStore.ExecTx(context.Background(), func(q Querier) error {
+		attempts++
+		return conflict
+	})
+	require.ErrorIs(t, err, conflict)
+	require.Equal(t, defaultTxMaxRetries+1, attempts)
+}
+
+func (ts *ExecTxTestSuite) TestNotRetryable() {
+	t := ts.T()
+	publisherName := util.RandomString(12)
+	errFailed := errors.New("failed")
+
+	var attempts int
+	err := testStore.ExecTx(context.Background(), func(q Querier) error {
+		attempts++
+		_, err := q.CreatePublisher(context.Background(), publisherName)
+		require.NoError(t, err)
+		return errFailed
+	})
+	require.ErrorIs(t, err, errFailed)
+	require.Equal(t, 1, attempts)
+
+	// the insert was rolled back
+	_, err = testStore.GetPublisherByName(context.Background(), GetPublisherByNameParams{
+		PublisherName: publisherName,
+	})
+	require.ErrorIs(t, err, ErrRecordNotFound)
+}
+
+func (ts *ExecTxTestSuite) TestCanceled() {
+	t := ts.T()
+	ctx, cancel := context.WithCancel(context.Background())
+
+	store := *testStore.(*SQLStore)
+	newSQLStore(&store, []StoreOption{
+		WithTxOptions(TxOptions{MaxRetries: 10, RetryDelay: time.Hour}),
+	})
+
+	var attempts int
+	err := store.ExecTx(ctx, func(q Querier) error {
+		attempts++
+		cancel()
+		return &pgconn.PgError{Code: "40P01"}
+	})
+	require.ErrorIs(t, err, context.Canceled)
+	require.Equal(t, 1, attempts)
+
+	err = store.ExecTx(ctx, func(q Querier) error {
+		t.Fatal("must not run after the context is done")
+		return nil
+	})
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func (ts *ExecTxTestSuite) TestBusy() {
+	t := ts.T()
+	if testConfig.DBDriver == util.DBDriverPostgres {
+		t.Skip("SQLite locking")
+	}
+
+	conn, err := util.OpenSQLite(util.Config{
+		DBDriver:      testConfig.DBDriver,
+		DBSource:      testConfig.DBSource,
+		DBBusyTimeout: time.Millisecond,
+	})
+	require.NoError(t, err)
+	defer conn.Close()
+
+	store := NewStoreWithReader(conn.Write, conn.Read, WithTxOptions(TxOptions{
+		MaxRetries: 20,
+		RetryDelay: 10 * time.Millisecond,
+	}))
+
+	// another process holding the write lock
+	other, err := sql.Open(testConfig.DBDriver, testConfig.DBSource)
+	require.NoError(t, err)
+	defer other.Close()
+	lock, err := other.Begin()
+	require.NoError(t, err)
+	_, err = lock.Exec("INSERT INTO publishers (publisher_name) VALUES ('lock')")
+	require.NoError(t, err)
+
+	time.AfterFunc(100*time.Millisecond, func() {
+		lock.Rollback()
+	})
+
+	// the retries are only seen in the log, as BEGIN IMMEDIATE fails
+	// before fn runs
+	var logs bytes.Buffer
+	ctx := logging.WithLogger(context.Background(), slog.New(slog.NewTextHandler(&logs, nil)))
+
+	var attempts int
+	err = store.ExecTx(ctx, func(q Querier) error {
+		attempts++
+		_, err := q.CreatePublisher(ctx, util.RandomString(12))
+		return err
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, attempts)
+	require.Contains(t, logs.String(), "retrying transaction")
+	require.Contains(t, logs.String(), "SQLITE_BUSY")
+}
+
+func TestWithTxOptions(t *testing.T) {
+	defaults := TxOptions{MaxRetries: defaultTxMaxRetries, RetryDelay: defaultTxRetryDelay}
+
+	testCases := []struct {
+		name     string
+		opts     TxOptions
+		expected TxOptions
+	}{
+		{
+			name:     "ZeroKeepsDefaults",
+			expected: defaults,
+		},
+		{
+			name:     "NegativeTurnsRetriesOff",
+			opts:     TxOptions{MaxRetries: -1},
+			expected: TxOptions{MaxRetries: 0, RetryDelay: defaultTxRetryDelay},
+		},
+		{
+			name:     "Set",
+			opts:     TxOptions{MaxRetries: 5, RetryDelay: time.Second, Isolation: sql.LevelRepeatableRead},
+			expected: TxOptions{MaxRetries: 5, RetryDelay: time.Second, Isolation: sql.LevelRepeatableRead},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			store := newSQLStore(&SQLStore{}, []StoreOption{WithTxOptions(tc.opts)})
+			require.Equal(t, tc.expected, store.txOptions)
+		})
+	}
+}
+
+func TestParseIsolationLevel(t *testing.T) {
+	for name, level := range map[string]sql.IsolationLevel{
+		"":                sql.LevelDefault,
+		"read committed":  sql.LevelReadCommitted,
+		"REPEATABLE_READ": sql.LevelRepeatableRead,
+		"Serializable":    sql.LevelSerializable,
+	} {
+		got, err := ParseIsolationLevel(name)
+		require.NoError(t, err, name)
+		require.Equal(t, level, got, name)
+	}
+
+	_, err := ParseIsolationLevel("dirty")
+	require.Error(t, err)
+}
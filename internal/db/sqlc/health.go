@@ -0,0 +1,89 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"io/fs"
+	"os"
+)
+
+// SchemaVersion is the last migration applied to the database, Dirty when
+// it failed halfway and the schema has to be fixed by hand
+type SchemaVersion struct {
+	Version uint `json:"version"`
+	Dirty   bool `json:"dirty"`
+}
+
+// The health checks bypass the instrumented queriers, so that probes do not
+// show up in the query metrics and traces.
+
+// Ping checks that the connections to the database are alive
+func (s *SQLStore) Ping(ctx context.Context) error {
+	if err := s.db.PingContext(ctx); err != nil {
+		return err
+	}
+
+	return s.readDB.PingContext(ctx)
+}
+
+// SchemaVersion reads the version golang-migrate recorded, 0 when no
+// migration was applied
+func (s *SQLStore) SchemaVersion(ctx context.Context) (SchemaVersion, error) {
+	var v SchemaVersion
+	err := s.db.QueryRowContext(ctx, "SELECT version, dirty FROM schema_migrations LIMIT 1").Scan(&v.Version, &v.Dirty)
+	if errors.Is(err, sql.ErrNoRows) {
+		return SchemaVersion{}, nil
+	}
+
+	return v, err
+}
+
+// CheckWritable fails when the database is read-only
+func (s *SQLStore) CheckWritable(ctx context.Context) error {
+	return s.checkWritable(ctx)
+}
+
+// checkFilesWritable opens the files of a SQLite database for writing. It
+// reads their paths on the reader, as SQLite has a single writer and a
+// probe taking it would queue behind the writes and hold them up.
+func (s *SQLStore) checkFilesWritable(ctx context.Context) error {
+	var (
+		seq        int
+		name, file string
+	)
+	err := s.readDB.QueryRowContext(ctx, "SELECT seq, name, file FROM pragma_database_list WHERE name = 'main'").Scan(&seq, &name, &file)
+	if err != nil {
+		return err
+	}
+	// in-memory databases have no file
+	if len(file) == 0 {
+		return nil
+	}
+
+	for _, path := range []string{file, file + "-wal"} {
+		f, err := os.OpenFile(path, os.O_RDWR, 0)
+		if errors.Is(err, fs.ErrNotExist) && path != file {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		f.Close()
+	}
+
+	return nil
+}
+
+// checkWriteRollsBack makes a write that is rolled back, it fails when the
+// database is read-only or its write lock cannot be taken
+func (s *SQLStore) checkWriteRollsBack(ctx context.Context) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, "UPDATE schema_migrations SET dirty = dirty")
+	return err
+}
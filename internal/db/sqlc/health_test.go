@@ -0,0 +1,55 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	sqlitemigrations "github.com/atsuyaourt/xyz-books/internal/db/migrations"
+	postgresmigrations "github.com/atsuyaourt/xyz-books/internal/db/postgres/migrations"
+	"github.com/atsuyaourt/xyz-books/internal/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealth(t *testing.T) {
+	ctx := context.Background()
+	require.NoError(t, testStore.Ping(ctx))
+
+	migrations := sqlitemigrations.FS
+	if testConfig.DBDriver == util.DBDriverPostgres {
+		migrations = postgresmigrations.FS
+	}
+	latest, err := util.LatestMigration(migrations)
+	require.NoError(t, err)
+	require.NotZero(t, latest)
+
+	require.NoError(t, util.DBMigrationUp(testConfig.MigrationSrc, testDBUrl), "db migration problem")
+	t.Cleanup(func() {
+		require.NoError(t, util.DBMigrationDown(testConfig.MigrationSrc, testDBUrl), "reverse db migration problem")
+	})
+
+	version, err := testStore.SchemaVersion(ctx)
+	require.NoError(t, err)
+	require.Equal(t, SchemaVersion{Version: latest}, version)
+
+	// nothing is written
+	require.NoError(t, testStore.CheckWritable(ctx))
+	version, err = testStore.SchemaVersion(ctx)
+	require.NoError(t, err)
+	require.Equal(t, SchemaVersion{Version: latest}, version)
+}
+
+func TestCheckWritableBusy(t *testing.T) {
+	if testConfig.DBDriver == util.DBDriverPostgres {
+		t.Skip("only SQLite has a single writer")
+	}
+
+	// a transaction holds the only connection of the writer
+	tx, err := testStore.(*SQLStore).db.BeginTx(context.Background(), nil)
+	require.NoError(t, err)
+	defer tx.Rollback()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+	require.NoError(t, testStore.CheckWritable(ctx))
+}
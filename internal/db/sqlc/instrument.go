@@ -0,0 +1,94 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/atsuyaourt/xyz-books/internal/logging"
+	"github.com/atsuyaourt/xyz-books/internal/metrics"
+	"github.com/atsuyaourt/xyz-books/internal/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumentedDB times the queries run through it by their sqlc name, traces
+// them, and logs them at debug level with the logger of the request.
+//
+// A query returning rows is recorded once its first row is ready, as
+// *sql.Rows cannot be wrapped to see when it is closed. The time taken to
+// read the rest of the rows is left out, so a large list looks faster than
+// it is.
+type instrumentedDB struct {
+	db     DBTX
+	system attribute.KeyValue // the database, as named by OpenTelemetry
+}
+
+func instrument(db DBTX, system attribute.KeyValue) DBTX {
+	return &instrumentedDB{db: db, system: system}
+}
+
+// queryName reads the name sqlc puts at the start of a query,
+// "-- name: GetBookByISBN :one"
+func queryName(query string) string {
+	rest, ok := strings.CutPrefix(query, "-- name: ")
+	if !ok {
+		return "other"
+	}
+	name, _, _ := strings.Cut(rest, " ")
+
+	return name
+}
+
+// start starts a query, the returned function records it once it is done
+func (i *instrumentedDB) start(ctx context.Context, query string) (context.Context, func(error)) {
+	name := queryName(query)
+	start := time.Now()
+	ctx, span := tracing.Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(i.system, semconv.DBOperationName(name)),
+	)
+
+	return ctx, func(err error) {
+		tracing.End(span, err)
+		metrics.ObserveQuery(name, start)
+
+		logger := logging.FromContext(ctx)
+		if logger.Enabled(ctx, slog.LevelDebug) {
+			logger.LogAttrs(ctx, slog.LevelDebug, "query",
+				slog.String("query", name), slog.Duration("duration", time.Since(start)))
+		}
+	}
+}
+
+func (i *instrumentedDB) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	ctx, done := i.start(ctx, query)
+	res, err := i.db.ExecContext(ctx, query, args...)
+	done(err)
+	return res, err
+}
+
+func (i *instrumentedDB) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	return i.db.PrepareContext(ctx, query)
+}
+
+// QueryContext records the query when its first row is ready, before the
+// rows are read
+func (i *instrumentedDB) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	ctx, done := i.start(ctx, query)
+	rows, err := i.db.QueryContext(ctx, query, args...)
+	done(err)
+	return rows, err
+}
+
+// QueryRowContext records the query when its row is ready, before it is
+// scanned
+func (i *instrumentedDB) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	ctx, done := i.start(ctx, query)
+	row := i.db.QueryRowContext(ctx, query, args...)
+	done(row.Err())
+	return row
+}
@@ -0,0 +1,59 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/atsuyaourt/xyz-books/internal/tracing"
+	"github.com/atsuyaourt/xyz-books/internal/tracing/tracingtest"
+	"github.com/atsuyaourt/xyz-books/internal/util"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+)
+
+func TestQueryName(t *testing.T) {
+	require.Equal(t, "GetBookByISBN", queryName(getBookByISBN))
+	require.Equal(t, "DeleteBookByISBN", queryName(deleteBookByISBN))
+	require.Equal(t, "other", queryName("PRAGMA foreign_keys = ON"))
+}
+
+func TestQueryTracing(t *testing.T) {
+	require.NoError(t, util.DBMigrationUp(testConfig.MigrationSrc, testDBUrl), "db migration problem")
+	t.Cleanup(func() {
+		require.NoError(t, util.DBMigrationDown(testConfig.MigrationSrc, testDBUrl), "reverse db migration problem")
+	})
+	recorder := tracingtest.Record(t)
+
+	ctx, parent := tracing.Start(context.Background(), "parent")
+	publisher, err := testStore.CreatePublisher(ctx, util.RandomString(16))
+	require.NoError(t, err)
+	err = testStore.ExecTx(ctx, func(q Querier) error {
+		_, err := q.GetPublisherByName(ctx, GetPublisherByNameParams{PublisherName: util.RandomString(20)})
+		return err
+	})
+	require.ErrorIs(t, err, ErrRecordNotFound)
+	parent.End()
+
+	spans := map[string]sdktrace.ReadOnlySpan{}
+	for _, span := range recorder.Ended() {
+		spans[span.Name()] = span
+	}
+
+	// queries are traced by their sqlc name, under the span of the caller
+	create := spans["CreatePublisher"]
+	require.NotNil(t, create)
+	require.Equal(t, parent.SpanContext().SpanID(), create.Parent().SpanID())
+	system := semconv.DBSystemSqlite
+	if testConfig.DBDriver == util.DBDriverPostgres {
+		system = semconv.DBSystemPostgreSQL
+	}
+	require.Contains(t, create.Attributes(), system)
+	require.NotZero(t, publisher.PublisherID)
+
+	tx := spans["execTx"]
+	require.NotNil(t, tx)
+	require.Equal(t, codes.Error, tx.Status().Code)
+	require.Contains(t, spans, "GetPublisherByName")
+}
@@ -10,6 +10,7 @@ import (
 	"testing"
 
 	"github.com/atsuyaourt/xyz-books/internal/util"
+	_ "github.com/jackc/pgx/v5/stdlib"
 )
 
 var (
@@ -18,7 +19,14 @@ var (
 	testStore  Store
 )
 
+// TestMain runs the tests against SQLite, or against PostgreSQL when
+// TEST_DB_DRIVER=postgres and TEST_DB_SOURCE is a postgres:// URL. The tests
+// are skipped if that database cannot be reached.
 func TestMain(m *testing.M) {
+	if os.Getenv("TEST_DB_DRIVER") == util.DBDriverPostgres {
+		os.Exit(testMainPostgres(m))
+	}
+
 	tempFile, _ := os.CreateTemp("", "test.db")
 	cleanup := func() {
 		fmt.Println("Cleaning up...")
@@ -36,13 +44,13 @@ func TestMain(m *testing.M) {
 
 	testDBUrl = fmt.Sprintf("%s://%s?query", testConfig.DBDriver, testConfig.DBSource)
 
-	testDB, err := sql.Open(testConfig.DBDriver, testConfig.DBSource)
+	testDB, err := util.OpenSQLite(testConfig)
 	if err != nil {
 		log.Fatal("cannot connect to db:", err)
 	}
 	defer testDB.Close()
 
-	testStore = NewStore(testDB)
+	testStore = NewStoreWithReader(testDB.Write, testDB.Read)
 
 	c := make(chan os.Signal, 1)
 	signal.Notify(c, os.Interrupt)
@@ -58,3 +66,31 @@ func TestMain(m *testing.M) {
 	cleanup()
 	os.Exit(exitVal)
 }
+
+func testMainPostgres(m *testing.M) int {
+	testConfig = util.Config{
+		MigrationSrc: "../../db/postgres/migrations",
+		DBDriver:     util.DBDriverPostgres,
+		DBSource:     os.Getenv("TEST_DB_SOURCE"),
+	}
+
+	testDB, err := sql.Open("pgx", testConfig.DBSource)
+	if err != nil {
+		log.Fatal("cannot connect to db:", err)
+	}
+	defer testDB.Close()
+
+	if len(testConfig.DBSource) == 0 || testDB.Ping() != nil {
+		fmt.Println("PostgreSQL is not available, skipping")
+		return 0
+	}
+
+	testDBUrl, err = util.PostgresMigrationSource(testConfig.DBSource)
+	if err != nil {
+		log.Fatal("invalid db source:", err)
+	}
+
+	testStore = NewPostgresStore(testDB)
+
+	return m.Run()
+}
@@ -6,18 +6,25 @@ package db
 
 import (
 	"database/sql"
+	"time"
 )
 
 type Author struct {
-	AuthorID   int64  `json:"author_id"`
-	FirstName  string `json:"first_name"`
-	LastName   string `json:"last_name"`
-	MiddleName string `json:"middle_name"`
+	AuthorID   int64        `json:"author_id"`
+	FirstName  string       `json:"first_name"`
+	LastName   string       `json:"last_name"`
+	MiddleName string       `json:"middle_name"`
+	DeletedAt  sql.NullTime `json:"deleted_at"`
+	Version    int64        `json:"version"`
+	CreatedAt  time.Time    `json:"created_at"`
+	UpdatedAt  time.Time    `json:"updated_at"`
 }
 
 type AuthorBook struct {
-	AuthorID int64 `json:"author_id"`
-	BookID   int64 `json:"book_id"`
+	AuthorID int64  `json:"author_id"`
+	BookID   int64  `json:"book_id"`
+	Role     string `json:"role"`
+	Position int64  `json:"position"`
 }
 
 type Book struct {
@@ -30,9 +37,91 @@ type Book struct {
 	ImageUrl        sql.NullString `json:"image_url"`
 	Edition         sql.NullString `json:"edition"`
 	PublisherID     int64          `json:"publisher_id"`
+	CoverKey        sql.NullString `json:"cover_key"`
+	Language        sql.NullString `json:"language"`
+	Format          sql.NullString `json:"format"`
+	PageCount       sql.NullInt64  `json:"page_count"`
+	SeriesName      sql.NullString `json:"series_name"`
+	SeriesNumber    sql.NullInt64  `json:"series_number"`
+	Description     sql.NullString `json:"description"`
+	DeletedAt       sql.NullTime   `json:"deleted_at"`
+	Version         int64          `json:"version"`
+	CreatedAt       time.Time      `json:"created_at"`
+	UpdatedAt       time.Time      `json:"updated_at"`
+}
+
+type BookSubject struct {
+	BookID    int64 `json:"book_id"`
+	SubjectID int64 `json:"subject_id"`
+}
+
+type Cart struct {
+	CartID int64          `json:"cart_id"`
+	Token  string         `json:"token"`
+	UserID sql.NullString `json:"user_id"`
+}
+
+type CartItem struct {
+	CartID   int64 `json:"cart_id"`
+	BookID   int64 `json:"book_id"`
+	Quantity int64 `json:"quantity"`
+}
+
+type Order struct {
+	OrderID         int64          `json:"order_id"`
+	UserID          sql.NullString `json:"user_id"`
+	CustomerName    string         `json:"customer_name"`
+	Email           string         `json:"email"`
+	ShippingAddress string         `json:"shipping_address"`
+	Status          string         `json:"status"`
+	Total           float64        `json:"total"`
+	PaymentRef      sql.NullString `json:"payment_ref"`
+	CreatedAt       time.Time      `json:"created_at"`
+	CartToken       sql.NullString `json:"cart_token"`
+}
+
+type OrderItem struct {
+	OrderItemID int64          `json:"order_item_id"`
+	OrderID     int64          `json:"order_id"`
+	BookID      sql.NullInt64  `json:"book_id"`
+	Title       string         `json:"title"`
+	Isbn13      sql.NullString `json:"isbn13"`
+	Isbn10      sql.NullString `json:"isbn10"`
+	Price       float64        `json:"price"`
+	Quantity    int64          `json:"quantity"`
 }
 
 type Publisher struct {
-	PublisherID   int64  `json:"publisher_id"`
-	PublisherName string `json:"publisher_name"`
+	PublisherID   int64        `json:"publisher_id"`
+	PublisherName string       `json:"publisher_name"`
+	DeletedAt     sql.NullTime `json:"deleted_at"`
+	Version       int64        `json:"version"`
+	CreatedAt     time.Time    `json:"created_at"`
+	UpdatedAt     time.Time    `json:"updated_at"`
+}
+
+type Subject struct {
+	SubjectID   int64  `json:"subject_id"`
+	SubjectName string `json:"subject_name"`
+}
+
+type Webhook struct {
+	WebhookID int64     `json:"webhook_id"`
+	Url       string    `json:"url"`
+	Secret    string    `json:"secret"`
+	Events    string    `json:"events"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type WebhookDelivery struct {
+	DeliveryID    int64          `json:"delivery_id"`
+	WebhookID     int64          `json:"webhook_id"`
+	EventID       string         `json:"event_id"`
+	Event         string         `json:"event"`
+	Payload       string         `json:"payload"`
+	Status        string         `json:"status"`
+	Attempts      int64          `json:"attempts"`
+	NextAttemptAt int64          `json:"next_attempt_at"`
+	LastError     sql.NullString `json:"last_error"`
+	CreatedAt     time.Time      `json:"created_at"`
 }
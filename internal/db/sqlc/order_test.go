@@ -0,0 +1,209 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/atsuyaourt/xyz-books/internal/util"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type OrderTestSuite struct {
+	suite.Suite
+}
+
+func TestOrderTestSuite(t *testing.T) {
+	suite.Run(t, new(OrderTestSuite))
+}
+
+func (ts *OrderTestSuite) SetupTest() {
+	err := util.DBMigrationUp(testConfig.MigrationSrc, testDBUrl)
+	require.NoError(ts.T(), err, "db migration problem")
+}
+
+func (ts *OrderTestSuite) TearDownTest() {
+	err := util.DBMigrationDown(testConfig.MigrationSrc, testDBUrl)
+	require.NoError(ts.T(), err, "reverse db migration problem")
+}
+
+func (ts *OrderTestSuite) TestCheckoutTx() {
+	t := ts.T()
+	ctx := context.Background()
+	cart := createRandomCart(t)
+	books := []Book{createRandomBook(t), createRandomBook(t)}
+
+	var total float64
+	for i, book := range books {
+		quantity := int64(i + 1)
+		err := testStore.AddCartItem(ctx, AddCartItemParams{
+			CartID:   cart.CartID,
+			BookID:   book.BookID,
+			Quantity: quantity,
+		})
+		require.NoError(t, err)
+		total += book.Price * float64(quantity)
+	}
+
+	res, err := testStore.CheckoutTx(ctx, CheckoutTxParams{
+		CartID: cart.CartID,
+		Order: CreateOrderParams{
+			CustomerName:    util.RandomString(12),
+			Email:           util.RandomString(8) + "@example.com",
+			ShippingAddress: util.RandomString(24),
+		},
+	})
+	require.NoError(t, err)
+	require.NotZero(t, res.Order.OrderID)
+	require.Equal(t, "pending", res.Order.Status)
+	require.InDelta(t, total, res.Order.Total, 0.01)
+	require.NotZero(t, res.Order.CreatedAt)
+	require.Len(t, res.Items, len(books))
+
+	// price changes after checkout must not affect the order
+	_, err = testStore.UpdateBookByISBN(ctx, UpdateBookByISBNParams{
+		Isbn13: books[0].Isbn13,
+		Price: sql.NullFloat64{
+			Float64: books[0].Price + 100,
+			Valid:   true,
+		},
+	})
+	require.NoError(t, err)
+
+	items, err := testStore.ListOrderItems(ctx, res.Order.OrderID)
+	require.NoError(t, err)
+	require.Len(t, items, len(books))
+	for i, item := range items {
+		require.Equal(t, books[i].Title, item.Title)
+		require.Equal(t, books[i].Isbn13, item.Isbn13)
+		require.InDelta(t, books[i].Price, item.Price, 0.001)
+		require.Equal(t, int64(i+1), item.Quantity)
+	}
+
+	// the cart is only emptied once the order is paid
+	cartItems, err := testStore.ListCartItems(ctx, cart.CartID)
+	require.NoError(t, err)
+	require.Len(t, cartItems, len(books))
+}
+
+func (ts *OrderTestSuite) TestCheckoutTxEmptyCart() {
+	t := ts.T()
+	cart := createRandomCart(t)
+
+	_, err := testStore.CheckoutTx(context.Background(), CheckoutTxParams{
+		CartID: cart.CartID,
+		Order: CreateOrderParams{
+			CustomerName:    util.RandomString(12),
+			Email:           util.RandomString(8) + "@example.com",
+			ShippingAddress: util.RandomString(24),
+		},
+	})
+	require.ErrorIs(t, err, ErrEmptyCart)
+
+	count, err := testStore.CountOrders(context.Background(), CountOrdersParams{})
+	require.NoError(t, err)
+	require.Zero(t, count)
+}
+
+func (ts *OrderTestSuite) TestUpdateOrderStatus() {
+	t := ts.T()
+	ctx := context.Background()
+	order := createRandomOrder(t)
+
+	updatedOrder, err := testStore.UpdateOrderStatus(ctx, UpdateOrderStatusParams{
+		OrderID:    order.OrderID,
+		Status:     "paid",
+		FromStatus: sql.NullString{String: "pending", Valid: true},
+		PaymentRef: sql.NullString{String: "ref", Valid: true},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "paid", updatedOrder.Status)
+	require.Equal(t, "ref", updatedOrder.PaymentRef.String)
+
+	_, err = testStore.UpdateOrderStatus(ctx, UpdateOrderStatusParams{
+		OrderID:    order.OrderID,
+		Status:     "cancelled",
+		FromStatus: sql.NullString{String: "pending", Valid: true},
+	})
+	require.ErrorIs(t, err, ErrRecordNotFound)
+}
+
+func (ts *OrderTestSuite) TestListOrders() {
+	t := ts.T()
+	ctx := context.Background()
+
+	n := 5
+	for i := 0; i < n; i++ {
+		order := createRandomOrder(t)
+		if i%2 == 0 {
+			_, err := testStore.UpdateOrderStatus(ctx, UpdateOrderStatusParams{
+				OrderID: order.OrderID,
+				Status:  "paid",
+			})
+			require.NoError(t, err)
+		}
+	}
+
+	orders, err := testStore.ListOrders(ctx, ListOrdersParams{Limit: int64(n)})
+	require.NoError(t, err)
+	require.Len(t, orders, n)
+
+	paid := sql.NullString{String: "paid", Valid: true}
+	orders, err = testStore.ListOrders(ctx, ListOrdersParams{Limit: int64(n), Status: paid})
+	require.NoError(t, err)
+	require.Len(t, orders, 3)
+
+	count, err := testStore.CountOrders(ctx, CountOrdersParams{Status: paid})
+	require.NoError(t, err)
+	require.Equal(t, int64(3), count)
+}
+
+func (ts *OrderTestSuite) TestListOrdersOfOwner() {
+	t := ts.T()
+	ctx := context.Background()
+
+	token := sql.NullString{String: util.RandomString(32), Valid: true}
+	userID := sql.NullString{String: util.RandomString(12), Valid: true}
+	byToken := createRandomOrderOf(t, sql.NullString{}, token)
+	byUser := createRandomOrderOf(t, userID, sql.NullString{String: util.RandomString(32), Valid: true})
+	createRandomOrder(t)
+
+	orders, err := testStore.ListOrders(ctx, ListOrdersParams{Limit: 10, CartToken: token})
+	require.NoError(t, err)
+	require.Len(t, orders, 1)
+	require.Equal(t, byToken.OrderID, orders[0].OrderID)
+
+	orders, err = testStore.ListOrders(ctx, ListOrdersParams{Limit: 10, UserID: userID, CartToken: token})
+	require.NoError(t, err)
+	require.Len(t, orders, 2)
+	require.Equal(t, byUser.OrderID, orders[0].OrderID)
+
+	count, err := testStore.CountOrders(ctx, CountOrdersParams{CartToken: sql.NullString{String: util.RandomString(32), Valid: true}})
+	require.NoError(t, err)
+	require.Zero(t, count)
+
+	count, err = testStore.CountOrders(ctx, CountOrdersParams{})
+	require.NoError(t, err)
+	require.Equal(t, int64(3), count)
+}
+
+func createRandomOrder(t *testing.T) Order {
+	return createRandomOrderOf(t, sql.NullString{}, sql.NullString{})
+}
+
+func createRandomOrderOf(t *testing.T, userID, cartToken sql.NullString) Order {
+	order, err := testStore.CreateOrder(context.Background(), CreateOrderParams{
+		UserID:          userID,
+		CartToken:       cartToken,
+		CustomerName:    util.RandomString(12),
+		Email:           util.RandomString(8) + "@example.com",
+		ShippingAddress: util.RandomString(24),
+		Total:           float64(util.RandomFloat(10.0, 999.9)),
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, order)
+	require.Equal(t, "pending", order.Status)
+
+	return order
+}
@@ -0,0 +1,447 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	pgdb "github.com/atsuyaourt/xyz-books/internal/db/postgres/sqlc"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+)
+
+// postgresQuerier runs the queries generated for PostgreSQL, whose params and
+// rows mirror the SQLite ones, and returns them as the types of this package.
+type postgresQuerier struct {
+	q *pgdb.Queries
+}
+
+var _ Querier = (*postgresQuerier)(nil)
+
+func newPostgresQuerier(db DBTX) Querier {
+	return &postgresQuerier{q: pgdb.New(instrument(db, semconv.DBSystemPostgreSQL))}
+}
+
+func convertRows[T, U any](items []T, fn func(T) U) []U {
+	res := make([]U, len(items))
+	for i := range items {
+		res[i] = fn(items[i])
+	}
+
+	return res
+}
+
+// idList passes ids to the PostgreSQL queries as a jsonb array, which
+// database/sql sends as is, unlike a Go slice.
+func idList(ids []int64) json.RawMessage {
+	if ids == nil {
+		ids = []int64{}
+	}
+	res, _ := json.Marshal(ids)
+	return res
+}
+
+func newGetBookByISBNRow(row pgdb.GetBookByISBNRow) GetBookByISBNRow {
+	return GetBookByISBNRow{
+		Book:          Book(row.Book),
+		Authors:       row.Authors,
+		Contributors:  row.Contributors,
+		PublisherName: row.PublisherName,
+		Subjects:      row.Subjects,
+	}
+}
+
+func newListBooksRow(row pgdb.ListBooksRow) ListBooksRow {
+	return ListBooksRow{
+		Book:          Book(row.Book),
+		Authors:       row.Authors,
+		Contributors:  row.Contributors,
+		PublisherName: row.PublisherName,
+		Subjects:      row.Subjects,
+	}
+}
+
+func newListAuthorBooksRow(row pgdb.ListAuthorBooksRow) ListAuthorBooksRow {
+	return ListAuthorBooksRow{
+		AuthorID:      row.AuthorID,
+		Book:          Book(row.Book),
+		Authors:       row.Authors,
+		Contributors:  row.Contributors,
+		PublisherName: row.PublisherName,
+		Subjects:      row.Subjects,
+	}
+}
+
+func newListPublisherBooksRow(row pgdb.ListPublisherBooksRow) ListPublisherBooksRow {
+	return ListPublisherBooksRow{
+		PublisherID:   row.PublisherID,
+		Book:          Book(row.Book),
+		Authors:       row.Authors,
+		Contributors:  row.Contributors,
+		PublisherName: row.PublisherName,
+		Subjects:      row.Subjects,
+	}
+}
+
+func newListCartItemsRow(row pgdb.ListCartItemsRow) ListCartItemsRow {
+	return ListCartItemsRow{
+		Book:     Book(row.Book),
+		Quantity: row.Quantity,
+	}
+}
+
+func newListDueWebhookDeliveriesRow(row pgdb.ListDueWebhookDeliveriesRow) ListDueWebhookDeliveriesRow {
+	return ListDueWebhookDeliveriesRow{
+		WebhookDelivery: WebhookDelivery(row.WebhookDelivery),
+		Url:             row.Url,
+		Secret:          row.Secret,
+	}
+}
+
+func newListAuthorsWithBookIDRow(row pgdb.ListAuthorsWithBookIDRow) ListAuthorsWithBookIDRow {
+	return ListAuthorsWithBookIDRow{
+		Author: Author(row.Author),
+	}
+}
+
+func (p *postgresQuerier) AddCartItem(ctx context.Context, arg AddCartItemParams) error {
+	return p.q.AddCartItem(ctx, pgdb.AddCartItemParams(arg))
+}
+
+func (p *postgresQuerier) BumpAuthorBookVersions(ctx context.Context, authorID int64) error {
+	return p.q.BumpAuthorBookVersions(ctx, authorID)
+}
+
+func (p *postgresQuerier) BumpPublisherBookVersions(ctx context.Context, publisherID int64) error {
+	return p.q.BumpPublisherBookVersions(ctx, publisherID)
+}
+
+func (p *postgresQuerier) ClearCartItems(ctx context.Context, cartID int64) error {
+	return p.q.ClearCartItems(ctx, cartID)
+}
+
+func (p *postgresQuerier) CountAuthors(ctx context.Context, arg CountAuthorsParams) (int64, error) {
+	return p.q.CountAuthors(ctx, pgdb.CountAuthorsParams(arg))
+}
+
+func (p *postgresQuerier) CountBooks(ctx context.Context, arg CountBooksParams) (int64, error) {
+	return p.q.CountBooks(ctx, pgdb.CountBooksParams(arg))
+}
+
+func (p *postgresQuerier) CountOrders(ctx context.Context, arg CountOrdersParams) (int64, error) {
+	return p.q.CountOrders(ctx, pgdb.CountOrdersParams(arg))
+}
+
+func (p *postgresQuerier) CountPublishers(ctx context.Context, arg CountPublishersParams) (int64, error) {
+	return p.q.CountPublishers(ctx, pgdb.CountPublishersParams(arg))
+}
+
+func (p *postgresQuerier) CountWebhookDeliveries(ctx context.Context, arg CountWebhookDeliveriesParams) (int64, error) {
+	return p.q.CountWebhookDeliveries(ctx, pgdb.CountWebhookDeliveriesParams(arg))
+}
+
+func (p *postgresQuerier) CreateAuthor(ctx context.Context, arg CreateAuthorParams) (Author, error) {
+	i, err := p.q.CreateAuthor(ctx, pgdb.CreateAuthorParams(arg))
+	return Author(i), err
+}
+
+func (p *postgresQuerier) CreateAuthorBookRel(ctx context.Context, arg CreateAuthorBookRelParams) error {
+	return p.q.CreateAuthorBookRel(ctx, pgdb.CreateAuthorBookRelParams(arg))
+}
+
+func (p *postgresQuerier) CreateBook(ctx context.Context, arg CreateBookParams) (Book, error) {
+	i, err := p.q.CreateBook(ctx, pgdb.CreateBookParams(arg))
+	return Book(i), err
+}
+
+func (p *postgresQuerier) CreateBookSubjectRel(ctx context.Context, arg CreateBookSubjectRelParams) error {
+	return p.q.CreateBookSubjectRel(ctx, pgdb.CreateBookSubjectRelParams(arg))
+}
+
+func (p *postgresQuerier) CreateCart(ctx context.Context, arg CreateCartParams) (Cart, error) {
+	i, err := p.q.CreateCart(ctx, pgdb.CreateCartParams(arg))
+	return Cart(i), err
+}
+
+func (p *postgresQuerier) CreateOrder(ctx context.Context, arg CreateOrderParams) (Order, error) {
+	i, err := p.q.CreateOrder(ctx, pgdb.CreateOrderParams(arg))
+	return Order(i), err
+}
+
+func (p *postgresQuerier) CreateOrderItemsFromCart(ctx context.Context, arg CreateOrderItemsFromCartParams) error {
+	return p.q.CreateOrderItemsFromCart(ctx, pgdb.CreateOrderItemsFromCartParams(arg))
+}
+
+func (p *postgresQuerier) CreatePublisher(ctx context.Context, publisherName string) (Publisher, error) {
+	i, err := p.q.CreatePublisher(ctx, publisherName)
+	return Publisher(i), err
+}
+
+func (p *postgresQuerier) CreateWebhook(ctx context.Context, arg CreateWebhookParams) (Webhook, error) {
+	i, err := p.q.CreateWebhook(ctx, pgdb.CreateWebhookParams(arg))
+	return Webhook(i), err
+}
+
+func (p *postgresQuerier) DeleteAuthor(ctx context.Context, arg DeleteAuthorParams) (int64, error) {
+	return p.q.DeleteAuthor(ctx, pgdb.DeleteAuthorParams(arg))
+}
+
+func (p *postgresQuerier) DeleteBookByISBN(ctx context.Context, arg DeleteBookByISBNParams) (int64, error) {
+	return p.q.DeleteBookByISBN(ctx, pgdb.DeleteBookByISBNParams(arg))
+}
+
+func (p *postgresQuerier) DeleteBookSubjectRels(ctx context.Context, bookID int64) error {
+	return p.q.DeleteBookSubjectRels(ctx, bookID)
+}
+
+func (p *postgresQuerier) DeleteCart(ctx context.Context, cartID int64) error {
+	return p.q.DeleteCart(ctx, cartID)
+}
+
+func (p *postgresQuerier) DeleteCartItem(ctx context.Context, arg DeleteCartItemParams) error {
+	return p.q.DeleteCartItem(ctx, pgdb.DeleteCartItemParams(arg))
+}
+
+func (p *postgresQuerier) DeletePublisher(ctx context.Context, arg DeletePublisherParams) (int64, error) {
+	return p.q.DeletePublisher(ctx, pgdb.DeletePublisherParams(arg))
+}
+
+func (p *postgresQuerier) DeletePurgedAuthorBookRels(ctx context.Context, deletedBefore sql.NullTime) error {
+	return p.q.DeletePurgedAuthorBookRels(ctx, deletedBefore)
+}
+
+func (p *postgresQuerier) DeletePurgedBookSubjectRels(ctx context.Context, deletedBefore sql.NullTime) error {
+	return p.q.DeletePurgedBookSubjectRels(ctx, deletedBefore)
+}
+
+func (p *postgresQuerier) DeletePurgedCartItems(ctx context.Context, deletedBefore sql.NullTime) error {
+	return p.q.DeletePurgedCartItems(ctx, deletedBefore)
+}
+
+func (p *postgresQuerier) DeleteWebhook(ctx context.Context, webhookID int64) (int64, error) {
+	return p.q.DeleteWebhook(ctx, webhookID)
+}
+
+func (p *postgresQuerier) DeleteWebhookDeliveries(ctx context.Context, webhookID int64) error {
+	return p.q.DeleteWebhookDeliveries(ctx, webhookID)
+}
+
+func (p *postgresQuerier) DetachPurgedOrderItems(ctx context.Context, deletedBefore sql.NullTime) error {
+	return p.q.DetachPurgedOrderItems(ctx, deletedBefore)
+}
+
+func (p *postgresQuerier) EnqueueWebhookDeliveries(ctx context.Context, arg EnqueueWebhookDeliveriesParams) (int64, error) {
+	return p.q.EnqueueWebhookDeliveries(ctx, pgdb.EnqueueWebhookDeliveriesParams(arg))
+}
+
+func (p *postgresQuerier) GetAuthor(ctx context.Context, arg GetAuthorParams) (Author, error) {
+	i, err := p.q.GetAuthor(ctx, pgdb.GetAuthorParams(arg))
+	return Author(i), err
+}
+
+func (p *postgresQuerier) GetAuthorByName(ctx context.Context, arg GetAuthorByNameParams) (Author, error) {
+	i, err := p.q.GetAuthorByName(ctx, pgdb.GetAuthorByNameParams(arg))
+	return Author(i), err
+}
+
+func (p *postgresQuerier) GetBookByISBN(ctx context.Context, arg GetBookByISBNParams) (GetBookByISBNRow, error) {
+	i, err := p.q.GetBookByISBN(ctx, pgdb.GetBookByISBNParams(arg))
+	return newGetBookByISBNRow(i), err
+}
+
+func (p *postgresQuerier) GetCartByToken(ctx context.Context, token string) (Cart, error) {
+	i, err := p.q.GetCartByToken(ctx, token)
+	return Cart(i), err
+}
+
+func (p *postgresQuerier) GetCartByUserID(ctx context.Context, userID sql.NullString) (Cart, error) {
+	i, err := p.q.GetCartByUserID(ctx, userID)
+	return Cart(i), err
+}
+
+func (p *postgresQuerier) GetOrder(ctx context.Context, orderID int64) (Order, error) {
+	i, err := p.q.GetOrder(ctx, orderID)
+	return Order(i), err
+}
+
+func (p *postgresQuerier) GetPublisher(ctx context.Context, arg GetPublisherParams) (Publisher, error) {
+	i, err := p.q.GetPublisher(ctx, pgdb.GetPublisherParams(arg))
+	return Publisher(i), err
+}
+
+func (p *postgresQuerier) GetPublisherByName(ctx context.Context, arg GetPublisherByNameParams) (Publisher, error) {
+	i, err := p.q.GetPublisherByName(ctx, pgdb.GetPublisherByNameParams(arg))
+	return Publisher(i), err
+}
+
+func (p *postgresQuerier) GetWebhook(ctx context.Context, webhookID int64) (Webhook, error) {
+	i, err := p.q.GetWebhook(ctx, webhookID)
+	return Webhook(i), err
+}
+
+func (p *postgresQuerier) LeaseWebhookDelivery(ctx context.Context, arg LeaseWebhookDeliveryParams) (int64, error) {
+	return p.q.LeaseWebhookDelivery(ctx, pgdb.LeaseWebhookDeliveryParams(arg))
+}
+
+func (p *postgresQuerier) ListAuthorBooks(ctx context.Context, authorIds []int64) ([]ListAuthorBooksRow, error) {
+	items, err := p.q.ListAuthorBooks(ctx, idList(authorIds))
+	return convertRows(items, newListAuthorBooksRow), err
+}
+
+func (p *postgresQuerier) ListAuthors(ctx context.Context, arg ListAuthorsParams) ([]Author, error) {
+	items, err := p.q.ListAuthors(ctx, pgdb.ListAuthorsParams(arg))
+	return convertRows(items, func(i pgdb.Author) Author { return Author(i) }), err
+}
+
+func (p *postgresQuerier) ListAuthorsWithBookID(ctx context.Context, bookID int64) ([]ListAuthorsWithBookIDRow, error) {
+	items, err := p.q.ListAuthorsWithBookID(ctx, bookID)
+	return convertRows(items, newListAuthorsWithBookIDRow), err
+}
+
+func (p *postgresQuerier) ListBookAuthors(ctx context.Context, bookIds []int64) ([]ListBookAuthorsRow, error) {
+	items, err := p.q.ListBookAuthors(ctx, idList(bookIds))
+	return convertRows(items, func(i pgdb.ListBookAuthorsRow) ListBookAuthorsRow {
+		return ListBookAuthorsRow{BookID: i.BookID, Author: Author(i.Author)}
+	}), err
+}
+
+func (p *postgresQuerier) ListBookPublishers(ctx context.Context, bookIds []int64) ([]ListBookPublishersRow, error) {
+	items, err := p.q.ListBookPublishers(ctx, idList(bookIds))
+	return convertRows(items, func(i pgdb.ListBookPublishersRow) ListBookPublishersRow {
+		return ListBookPublishersRow{BookID: i.BookID, Publisher: Publisher(i.Publisher)}
+	}), err
+}
+
+func (p *postgresQuerier) ListBooks(ctx context.Context, arg ListBooksParams) ([]ListBooksRow, error) {
+	items, err := p.q.ListBooks(ctx, pgdb.ListBooksParams(arg))
+	return convertRows(items, newListBooksRow), err
+}
+
+func (p *postgresQuerier) ListCartItems(ctx context.Context, cartID int64) ([]ListCartItemsRow, error) {
+	items, err := p.q.ListCartItems(ctx, cartID)
+	return convertRows(items, newListCartItemsRow), err
+}
+
+func (p *postgresQuerier) ListChanges(ctx context.Context, arg ListChangesParams) ([]ListChangesRow, error) {
+	items, err := p.q.ListChanges(ctx, pgdb.ListChangesParams(arg))
+	return convertRows(items, func(i pgdb.ListChangesRow) ListChangesRow { return ListChangesRow(i) }), err
+}
+
+func (p *postgresQuerier) ListDueWebhookDeliveries(ctx context.Context, arg ListDueWebhookDeliveriesParams) ([]ListDueWebhookDeliveriesRow, error) {
+	items, err := p.q.ListDueWebhookDeliveries(ctx, pgdb.ListDueWebhookDeliveriesParams(arg))
+	return convertRows(items, newListDueWebhookDeliveriesRow), err
+}
+
+func (p *postgresQuerier) ListOrderItems(ctx context.Context, orderID int64) ([]OrderItem, error) {
+	items, err := p.q.ListOrderItems(ctx, orderID)
+	return convertRows(items, func(i pgdb.OrderItem) OrderItem { return OrderItem(i) }), err
+}
+
+func (p *postgresQuerier) ListOrderItemsByOrderIDs(ctx context.Context, orderIds []int64) ([]OrderItem, error) {
+	items, err := p.q.ListOrderItemsByOrderIDs(ctx, idList(orderIds))
+	return convertRows(items, func(i pgdb.OrderItem) OrderItem { return OrderItem(i) }), err
+}
+
+func (p *postgresQuerier) ListOrders(ctx context.Context, arg ListOrdersParams) ([]Order, error) {
+	items, err := p.q.ListOrders(ctx, pgdb.ListOrdersParams(arg))
+	return convertRows(items, func(i pgdb.Order) Order { return Order(i) }), err
+}
+
+func (p *postgresQuerier) ListPublisherBooks(ctx context.Context, publisherIds []int64) ([]ListPublisherBooksRow, error) {
+	items, err := p.q.ListPublisherBooks(ctx, idList(publisherIds))
+	return convertRows(items, newListPublisherBooksRow), err
+}
+
+func (p *postgresQuerier) ListPublishers(ctx context.Context, arg ListPublishersParams) ([]Publisher, error) {
+	items, err := p.q.ListPublishers(ctx, pgdb.ListPublishersParams(arg))
+	return convertRows(items, func(i pgdb.Publisher) Publisher { return Publisher(i) }), err
+}
+
+func (p *postgresQuerier) ListWebhookDeliveries(ctx context.Context, arg ListWebhookDeliveriesParams) ([]WebhookDelivery, error) {
+	items, err := p.q.ListWebhookDeliveries(ctx, pgdb.ListWebhookDeliveriesParams(arg))
+	return convertRows(items, func(i pgdb.WebhookDelivery) WebhookDelivery { return WebhookDelivery(i) }), err
+}
+
+func (p *postgresQuerier) ListWebhooks(ctx context.Context) ([]Webhook, error) {
+	items, err := p.q.ListWebhooks(ctx)
+	return convertRows(items, func(i pgdb.Webhook) Webhook { return Webhook(i) }), err
+}
+
+func (p *postgresQuerier) MergeCartItems(ctx context.Context, arg MergeCartItemsParams) error {
+	return p.q.MergeCartItems(ctx, pgdb.MergeCartItemsParams(arg))
+}
+
+func (p *postgresQuerier) PurgeAuthors(ctx context.Context, deletedBefore sql.NullTime) (int64, error) {
+	return p.q.PurgeAuthors(ctx, deletedBefore)
+}
+
+func (p *postgresQuerier) PurgeBooks(ctx context.Context, deletedBefore sql.NullTime) (int64, error) {
+	return p.q.PurgeBooks(ctx, deletedBefore)
+}
+
+func (p *postgresQuerier) PurgePublishers(ctx context.Context, deletedBefore sql.NullTime) (int64, error) {
+	return p.q.PurgePublishers(ctx, deletedBefore)
+}
+
+func (p *postgresQuerier) RedeliverWebhookDelivery(ctx context.Context, arg RedeliverWebhookDeliveryParams) (WebhookDelivery, error) {
+	i, err := p.q.RedeliverWebhookDelivery(ctx, pgdb.RedeliverWebhookDeliveryParams(arg))
+	return WebhookDelivery(i), err
+}
+
+func (p *postgresQuerier) RestoreAuthor(ctx context.Context, authorID int64) (Author, error) {
+	i, err := p.q.RestoreAuthor(ctx, authorID)
+	return Author(i), err
+}
+
+func (p *postgresQuerier) RestoreBookByISBN(ctx context.Context, arg RestoreBookByISBNParams) (Book, error) {
+	i, err := p.q.RestoreBookByISBN(ctx, pgdb.RestoreBookByISBNParams(arg))
+	return Book(i), err
+}
+
+func (p *postgresQuerier) RestorePublisher(ctx context.Context, publisherID int64) (Publisher, error) {
+	i, err := p.q.RestorePublisher(ctx, publisherID)
+	return Publisher(i), err
+}
+
+func (p *postgresQuerier) SetBookCover(ctx context.Context, arg SetBookCoverParams) (Book, error) {
+	i, err := p.q.SetBookCover(ctx, pgdb.SetBookCoverParams(arg))
+	return Book(i), err
+}
+
+func (p *postgresQuerier) SetCartItemQuantity(ctx context.Context, arg SetCartItemQuantityParams) (int64, error) {
+	return p.q.SetCartItemQuantity(ctx, pgdb.SetCartItemQuantityParams(arg))
+}
+
+func (p *postgresQuerier) SetCartUserID(ctx context.Context, arg SetCartUserIDParams) (Cart, error) {
+	i, err := p.q.SetCartUserID(ctx, pgdb.SetCartUserIDParams(arg))
+	return Cart(i), err
+}
+
+func (p *postgresQuerier) SetWebhookDeliveryResult(ctx context.Context, arg SetWebhookDeliveryResultParams) (WebhookDelivery, error) {
+	i, err := p.q.SetWebhookDeliveryResult(ctx, pgdb.SetWebhookDeliveryResultParams(arg))
+	return WebhookDelivery(i), err
+}
+
+func (p *postgresQuerier) UpdateAuthor(ctx context.Context, arg UpdateAuthorParams) (Author, error) {
+	i, err := p.q.UpdateAuthor(ctx, pgdb.UpdateAuthorParams(arg))
+	return Author(i), err
+}
+
+func (p *postgresQuerier) UpdateBookByISBN(ctx context.Context, arg UpdateBookByISBNParams) (Book, error) {
+	i, err := p.q.UpdateBookByISBN(ctx, pgdb.UpdateBookByISBNParams(arg))
+	return Book(i), err
+}
+
+func (p *postgresQuerier) UpdateOrderStatus(ctx context.Context, arg UpdateOrderStatusParams) (Order, error) {
+	i, err := p.q.UpdateOrderStatus(ctx, pgdb.UpdateOrderStatusParams(arg))
+	return Order(i), err
+}
+
+func (p *postgresQuerier) UpdatePublisher(ctx context.Context, arg UpdatePublisherParams) (Publisher, error) {
+	i, err := p.q.UpdatePublisher(ctx, pgdb.UpdatePublisherParams(arg))
+	return Publisher(i), err
+}
+
+func (p *postgresQuerier) UpsertSubject(ctx context.Context, subjectName string) (Subject, error) {
+	i, err := p.q.UpsertSubject(ctx, subjectName)
+	return Subject(i), err
+}
@@ -8,14 +8,23 @@ package db
 import (
 	"context"
 	"database/sql"
+	"strings"
 )
 
 const countPublishers = `-- name: CountPublishers :one
 SELECT count(*) FROM publishers
+WHERE
+  (deleted_at IS NULL OR CAST(?1 AS BOOLEAN))
+  AND (updated_at > ?2 OR ?2 IS NULL)
 `
 
-func (q *Queries) CountPublishers(ctx context.Context) (int64, error) {
-	row := q.db.QueryRowContext(ctx, countPublishers)
+type CountPublishersParams struct {
+	IncludeDeleted bool         `json:"include_deleted"`
+	UpdatedSince   sql.NullTime `json:"updated_since"`
+}
+
+func (q *Queries) CountPublishers(ctx context.Context, arg CountPublishersParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countPublishers, arg.IncludeDeleted, arg.UpdatedSince)
 	var count int64
 	err := row.Scan(&count)
 	return count, err
@@ -23,66 +32,189 @@ func (q *Queries) CountPublishers(ctx context.Context) (int64, error) {
 
 const createPublisher = `-- name: CreatePublisher :one
 INSERT INTO publishers (
-  publisher_name
+  publisher_name,
+  created_at,
+  updated_at
 ) VALUES (
-  ?1
-) RETURNING publisher_id, publisher_name
+  ?1,
+  strftime('%Y-%m-%d %H:%M:%f', 'now'),
+  strftime('%Y-%m-%d %H:%M:%f', 'now')
+) RETURNING publisher_id, publisher_name, deleted_at, version, created_at, updated_at
 `
 
 func (q *Queries) CreatePublisher(ctx context.Context, publisherName string) (Publisher, error) {
 	row := q.db.QueryRowContext(ctx, createPublisher, publisherName)
 	var i Publisher
-	err := row.Scan(&i.PublisherID, &i.PublisherName)
+	err := row.Scan(
+		&i.PublisherID,
+		&i.PublisherName,
+		&i.DeletedAt,
+		&i.Version,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
 	return i, err
 }
 
-const deletePublisher = `-- name: DeletePublisher :exec
-DELETE FROM publishers WHERE publisher_id = ?1
+const deletePublisher = `-- name: DeletePublisher :execrows
+UPDATE publishers
+SET
+  deleted_at = CURRENT_TIMESTAMP,
+  version = version + 1,
+  updated_at = strftime('%Y-%m-%d %H:%M:%f', 'now')
+WHERE
+  publisher_id = ?1
+  AND deleted_at IS NULL
+  AND (version = ?2 OR ?2 IS NULL)
 `
 
-func (q *Queries) DeletePublisher(ctx context.Context, publisherID int64) error {
-	_, err := q.db.ExecContext(ctx, deletePublisher, publisherID)
-	return err
+type DeletePublisherParams struct {
+	PublisherID int64         `json:"publisher_id"`
+	Version     sql.NullInt64 `json:"version"`
+}
+
+func (q *Queries) DeletePublisher(ctx context.Context, arg DeletePublisherParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deletePublisher, arg.PublisherID, arg.Version)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
 }
 
 const getPublisher = `-- name: GetPublisher :one
-SELECT publisher_id, publisher_name FROM publishers
-WHERE publisher_id = ?1 LIMIT 1
+SELECT publisher_id, publisher_name, deleted_at, version, created_at, updated_at FROM publishers
+WHERE
+  publisher_id = ?1
+  AND (deleted_at IS NULL OR CAST(?2 AS BOOLEAN))
+LIMIT 1
 `
 
-func (q *Queries) GetPublisher(ctx context.Context, publisherID int64) (Publisher, error) {
-	row := q.db.QueryRowContext(ctx, getPublisher, publisherID)
+type GetPublisherParams struct {
+	PublisherID    int64 `json:"publisher_id"`
+	IncludeDeleted bool  `json:"include_deleted"`
+}
+
+func (q *Queries) GetPublisher(ctx context.Context, arg GetPublisherParams) (Publisher, error) {
+	row := q.db.QueryRowContext(ctx, getPublisher, arg.PublisherID, arg.IncludeDeleted)
 	var i Publisher
-	err := row.Scan(&i.PublisherID, &i.PublisherName)
+	err := row.Scan(
+		&i.PublisherID,
+		&i.PublisherName,
+		&i.DeletedAt,
+		&i.Version,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
 	return i, err
 }
 
 const getPublisherByName = `-- name: GetPublisherByName :one
-SELECT publisher_id, publisher_name FROM publishers
-WHERE publisher_name = ?1 LIMIT 1
+SELECT publisher_id, publisher_name, deleted_at, version, created_at, updated_at FROM publishers
+WHERE
+  publisher_name = ?1
+  AND (deleted_at IS NULL OR CAST(?2 AS BOOLEAN))
+LIMIT 1
 `
 
-func (q *Queries) GetPublisherByName(ctx context.Context, publisherName string) (Publisher, error) {
-	row := q.db.QueryRowContext(ctx, getPublisherByName, publisherName)
+type GetPublisherByNameParams struct {
+	PublisherName  string `json:"publisher_name"`
+	IncludeDeleted bool   `json:"include_deleted"`
+}
+
+func (q *Queries) GetPublisherByName(ctx context.Context, arg GetPublisherByNameParams) (Publisher, error) {
+	row := q.db.QueryRowContext(ctx, getPublisherByName, arg.PublisherName, arg.IncludeDeleted)
 	var i Publisher
-	err := row.Scan(&i.PublisherID, &i.PublisherName)
+	err := row.Scan(
+		&i.PublisherID,
+		&i.PublisherName,
+		&i.DeletedAt,
+		&i.Version,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
 	return i, err
 }
 
+const listBookPublishers = `-- name: ListBookPublishers :many
+SELECT
+  b.book_id,
+  p.publisher_id, p.publisher_name, p.deleted_at, p.version, p.created_at, p.updated_at
+FROM
+  books b
+  JOIN publishers p ON b.publisher_id = p.publisher_id
+WHERE b.book_id IN (/*SLICE:book_ids*/?)
+`
+
+type ListBookPublishersRow struct {
+	BookID    int64     `json:"book_id"`
+	Publisher Publisher `json:"publisher"`
+}
+
+func (q *Queries) ListBookPublishers(ctx context.Context, bookIds []int64) ([]ListBookPublishersRow, error) {
+	query := listBookPublishers
+	var queryParams []interface{}
+	if len(bookIds) > 0 {
+		for _, v := range bookIds {
+			queryParams = append(queryParams, v)
+		}
+		query = strings.Replace(query, "/*SLICE:book_ids*/?", strings.Repeat(",?", len(bookIds))[1:], 1)
+	} else {
+		query = strings.Replace(query, "/*SLICE:book_ids*/?", "NULL", 1)
+	}
+	rows, err := q.db.QueryContext(ctx, query, queryParams...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListBookPublishersRow{}
+	for rows.Next() {
+		var i ListBookPublishersRow
+		if err := rows.Scan(
+			&i.BookID,
+			&i.Publisher.PublisherID,
+			&i.Publisher.PublisherName,
+			&i.Publisher.DeletedAt,
+			&i.Publisher.Version,
+			&i.Publisher.CreatedAt,
+			&i.Publisher.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listPublishers = `-- name: ListPublishers :many
-SELECT publisher_id, publisher_name FROM publishers
+SELECT publisher_id, publisher_name, deleted_at, version, created_at, updated_at FROM publishers
+WHERE
+  (deleted_at IS NULL OR CAST(?1 AS BOOLEAN))
+  AND (updated_at > ?2 OR ?2 IS NULL)
 ORDER BY publisher_id
-LIMIT ?1
-OFFSET ?2
+LIMIT ?4
+OFFSET ?3
 `
 
 type ListPublishersParams struct {
-	Limit  int64 `json:"limit"`
-	Offset int64 `json:"offset"`
+	IncludeDeleted bool         `json:"include_deleted"`
+	UpdatedSince   sql.NullTime `json:"updated_since"`
+	Offset         int64        `json:"offset"`
+	Limit          int64        `json:"limit"`
 }
 
 func (q *Queries) ListPublishers(ctx context.Context, arg ListPublishersParams) ([]Publisher, error) {
-	rows, err := q.db.QueryContext(ctx, listPublishers, arg.Limit, arg.Offset)
+	rows, err := q.db.QueryContext(ctx, listPublishers,
+		arg.IncludeDeleted,
+		arg.UpdatedSince,
+		arg.Offset,
+		arg.Limit,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -90,7 +222,14 @@ func (q *Queries) ListPublishers(ctx context.Context, arg ListPublishersParams)
 	items := []Publisher{}
 	for rows.Next() {
 		var i Publisher
-		if err := rows.Scan(&i.PublisherID, &i.PublisherName); err != nil {
+		if err := rows.Scan(
+			&i.PublisherID,
+			&i.PublisherName,
+			&i.DeletedAt,
+			&i.Version,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -104,23 +243,74 @@ func (q *Queries) ListPublishers(ctx context.Context, arg ListPublishersParams)
 	return items, nil
 }
 
+const purgePublishers = `-- name: PurgePublishers :execrows
+DELETE FROM publishers
+WHERE
+  publishers.deleted_at < ?1
+  AND NOT EXISTS (SELECT 1 FROM books b WHERE b.publisher_id = publishers.publisher_id)
+`
+
+func (q *Queries) PurgePublishers(ctx context.Context, deletedBefore sql.NullTime) (int64, error) {
+	result, err := q.db.ExecContext(ctx, purgePublishers, deletedBefore)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const restorePublisher = `-- name: RestorePublisher :one
+UPDATE publishers
+SET
+  deleted_at = NULL,
+  version = version + 1,
+  updated_at = strftime('%Y-%m-%d %H:%M:%f', 'now')
+WHERE publisher_id = ?1 AND deleted_at IS NOT NULL
+RETURNING publisher_id, publisher_name, deleted_at, version, created_at, updated_at
+`
+
+func (q *Queries) RestorePublisher(ctx context.Context, publisherID int64) (Publisher, error) {
+	row := q.db.QueryRowContext(ctx, restorePublisher, publisherID)
+	var i Publisher
+	err := row.Scan(
+		&i.PublisherID,
+		&i.PublisherName,
+		&i.DeletedAt,
+		&i.Version,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
 const updatePublisher = `-- name: UpdatePublisher :one
 UPDATE publishers
 SET
-  publisher_name = COALESCE(?1, publisher_name)
+  publisher_name = COALESCE(?1, publisher_name),
+  version = version + 1,
+  updated_at = strftime('%Y-%m-%d %H:%M:%f', 'now')
 WHERE
   publisher_id = ?2
-RETURNING publisher_id, publisher_name
+  AND deleted_at IS NULL
+  AND (version = ?3 OR ?3 IS NULL)
+RETURNING publisher_id, publisher_name, deleted_at, version, created_at, updated_at
 `
 
 type UpdatePublisherParams struct {
 	PublisherName sql.NullString `json:"publisher_name"`
 	PublisherID   int64          `json:"publisher_id"`
+	Version       sql.NullInt64  `json:"version"`
 }
 
 func (q *Queries) UpdatePublisher(ctx context.Context, arg UpdatePublisherParams) (Publisher, error) {
-	row := q.db.QueryRowContext(ctx, updatePublisher, arg.PublisherName, arg.PublisherID)
+	row := q.db.QueryRowContext(ctx, updatePublisher, arg.PublisherName, arg.PublisherID, arg.Version)
 	var i Publisher
-	err := row.Scan(&i.PublisherID, &i.PublisherName)
+	err := row.Scan(
+		&i.PublisherID,
+		&i.PublisherName,
+		&i.DeletedAt,
+		&i.Version,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
 	return i, err
 }
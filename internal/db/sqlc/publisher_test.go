@@ -35,7 +35,7 @@ func (ts *PublisherTestSuite) TestGetPublisher() {
 	t := ts.T()
 	publisher := createRandomPublisher(ts.T())
 
-	gotPublisher, err := testStore.GetPublisher(context.Background(), publisher.PublisherID)
+	gotPublisher, err := testStore.GetPublisher(context.Background(), GetPublisherParams{PublisherID: publisher.PublisherID})
 	require.NoError(t, err)
 	requirePublisherEqual(t, publisher, gotPublisher)
 }
@@ -44,7 +44,9 @@ func (ts *PublisherTestSuite) TestGetPublisherByName() {
 	t := ts.T()
 	publisher := createRandomPublisher(ts.T())
 
-	gotPublisher, err := testStore.GetPublisherByName(context.Background(), publisher.PublisherName)
+	gotPublisher, err := testStore.GetPublisherByName(context.Background(), GetPublisherByNameParams{
+		PublisherName: publisher.PublisherName,
+	})
 	require.NoError(t, err)
 	requirePublisherEqual(t, publisher, gotPublisher)
 }
@@ -94,12 +96,43 @@ func (ts *PublisherTestSuite) TestDeletePublisher() {
 	t := ts.T()
 	publisher := createRandomPublisher(t)
 
-	err := testStore.DeletePublisher(context.Background(), publisher.PublisherID)
+	n, err := testStore.DeletePublisher(context.Background(), DeletePublisherParams{
+		PublisherID: publisher.PublisherID,
+		Version:     sql.NullInt64{Int64: publisher.Version + 1, Valid: true},
+	})
 	require.NoError(t, err)
+	require.Zero(t, n, "stale version")
 
-	gotPublisher, err := testStore.GetPublisher(context.Background(), publisher.PublisherID)
-	require.Error(t, err)
+	n, err = testStore.DeletePublisher(context.Background(), DeletePublisherParams{PublisherID: publisher.PublisherID})
+	require.NoError(t, err)
+	require.EqualValues(t, 1, n)
+
+	gotPublisher, err := testStore.GetPublisher(context.Background(), GetPublisherParams{PublisherID: publisher.PublisherID})
+	require.ErrorIs(t, err, ErrRecordNotFound)
 	require.Empty(t, gotPublisher)
+
+	gotPublisher, err = testStore.GetPublisher(context.Background(), GetPublisherParams{
+		PublisherID:    publisher.PublisherID,
+		IncludeDeleted: true,
+	})
+	require.NoError(t, err)
+	require.True(t, gotPublisher.DeletedAt.Valid)
+
+	_, err = testStore.GetPublisherByName(context.Background(), GetPublisherByNameParams{
+		PublisherName: publisher.PublisherName,
+	})
+	require.ErrorIs(t, err, ErrRecordNotFound)
+
+	restoredPublisher, err := testStore.RestorePublisher(context.Background(), publisher.PublisherID)
+	require.NoError(t, err)
+	require.False(t, restoredPublisher.DeletedAt.Valid)
+
+	// a live publisher is left as is
+	_, err = testStore.RestorePublisher(context.Background(), publisher.PublisherID)
+	require.ErrorIs(t, err, ErrRecordNotFound)
+	gotPublisher, err = testStore.GetPublisher(context.Background(), GetPublisherParams{PublisherID: publisher.PublisherID})
+	require.NoError(t, err)
+	require.Equal(t, restoredPublisher.Version, gotPublisher.Version)
 }
 
 func createRandomPublisher(t *testing.T) Publisher {
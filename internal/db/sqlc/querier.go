@@ -6,31 +6,89 @@ package db
 
 import (
 	"context"
+	"database/sql"
 )
 
 type Querier interface {
-	CountAuthors(ctx context.Context) (int64, error)
+	// Adds to the quantity of a book in a cart, up to the 99 that AddCartItemReq allows
+	AddCartItem(ctx context.Context, arg AddCartItemParams) error
+	BumpAuthorBookVersions(ctx context.Context, authorID int64) error
+	BumpPublisherBookVersions(ctx context.Context, publisherID int64) error
+	ClearCartItems(ctx context.Context, cartID int64) error
+	CountAuthors(ctx context.Context, arg CountAuthorsParams) (int64, error)
 	CountBooks(ctx context.Context, arg CountBooksParams) (int64, error)
-	CountPublishers(ctx context.Context) (int64, error)
+	CountOrders(ctx context.Context, arg CountOrdersParams) (int64, error)
+	CountPublishers(ctx context.Context, arg CountPublishersParams) (int64, error)
+	CountWebhookDeliveries(ctx context.Context, arg CountWebhookDeliveriesParams) (int64, error)
 	CreateAuthor(ctx context.Context, arg CreateAuthorParams) (Author, error)
 	CreateAuthorBookRel(ctx context.Context, arg CreateAuthorBookRelParams) error
 	CreateBook(ctx context.Context, arg CreateBookParams) (Book, error)
+	CreateBookSubjectRel(ctx context.Context, arg CreateBookSubjectRelParams) error
+	CreateCart(ctx context.Context, arg CreateCartParams) (Cart, error)
+	CreateOrder(ctx context.Context, arg CreateOrderParams) (Order, error)
+	CreateOrderItemsFromCart(ctx context.Context, arg CreateOrderItemsFromCartParams) error
 	CreatePublisher(ctx context.Context, publisherName string) (Publisher, error)
-	DeleteAuthor(ctx context.Context, authorID int64) error
-	DeleteBookByISBN(ctx context.Context, arg DeleteBookByISBNParams) error
-	DeletePublisher(ctx context.Context, publisherID int64) error
-	GetAuthor(ctx context.Context, authorID int64) (Author, error)
+	CreateWebhook(ctx context.Context, arg CreateWebhookParams) (Webhook, error)
+	DeleteAuthor(ctx context.Context, arg DeleteAuthorParams) (int64, error)
+	DeleteBookByISBN(ctx context.Context, arg DeleteBookByISBNParams) (int64, error)
+	DeleteBookSubjectRels(ctx context.Context, bookID int64) error
+	DeleteCart(ctx context.Context, cartID int64) error
+	DeleteCartItem(ctx context.Context, arg DeleteCartItemParams) error
+	DeletePublisher(ctx context.Context, arg DeletePublisherParams) (int64, error)
+	DeletePurgedAuthorBookRels(ctx context.Context, deletedBefore sql.NullTime) error
+	DeletePurgedBookSubjectRels(ctx context.Context, deletedBefore sql.NullTime) error
+	DeletePurgedCartItems(ctx context.Context, deletedBefore sql.NullTime) error
+	DeleteWebhook(ctx context.Context, webhookID int64) (int64, error)
+	DeleteWebhookDeliveries(ctx context.Context, webhookID int64) error
+	DetachPurgedOrderItems(ctx context.Context, deletedBefore sql.NullTime) error
+	EnqueueWebhookDeliveries(ctx context.Context, arg EnqueueWebhookDeliveriesParams) (int64, error)
+	GetAuthor(ctx context.Context, arg GetAuthorParams) (Author, error)
 	GetAuthorByName(ctx context.Context, arg GetAuthorByNameParams) (Author, error)
 	GetBookByISBN(ctx context.Context, arg GetBookByISBNParams) (GetBookByISBNRow, error)
-	GetPublisher(ctx context.Context, publisherID int64) (Publisher, error)
-	GetPublisherByName(ctx context.Context, publisherName string) (Publisher, error)
+	GetCartByToken(ctx context.Context, token string) (Cart, error)
+	GetCartByUserID(ctx context.Context, userID sql.NullString) (Cart, error)
+	GetOrder(ctx context.Context, orderID int64) (Order, error)
+	GetPublisher(ctx context.Context, arg GetPublisherParams) (Publisher, error)
+	GetPublisherByName(ctx context.Context, arg GetPublisherByNameParams) (Publisher, error)
+	GetWebhook(ctx context.Context, webhookID int64) (Webhook, error)
+	LeaseWebhookDelivery(ctx context.Context, arg LeaseWebhookDeliveryParams) (int64, error)
+	ListAuthorBooks(ctx context.Context, authorIds []int64) ([]ListAuthorBooksRow, error)
 	ListAuthors(ctx context.Context, arg ListAuthorsParams) ([]Author, error)
 	ListAuthorsWithBookID(ctx context.Context, bookID int64) ([]ListAuthorsWithBookIDRow, error)
+	ListBookAuthors(ctx context.Context, bookIds []int64) ([]ListBookAuthorsRow, error)
+	ListBookPublishers(ctx context.Context, bookIds []int64) ([]ListBookPublishersRow, error)
 	ListBooks(ctx context.Context, arg ListBooksParams) ([]ListBooksRow, error)
+	ListCartItems(ctx context.Context, cartID int64) ([]ListCartItemsRow, error)
+	// Books are listed by their ID, which is kept when their ISBNs change, along
+	// with their current ISBN.
+	ListChanges(ctx context.Context, arg ListChangesParams) ([]ListChangesRow, error)
+	ListDueWebhookDeliveries(ctx context.Context, arg ListDueWebhookDeliveriesParams) ([]ListDueWebhookDeliveriesRow, error)
+	ListOrderItems(ctx context.Context, orderID int64) ([]OrderItem, error)
+	ListOrderItemsByOrderIDs(ctx context.Context, orderIds []int64) ([]OrderItem, error)
+	// Orders of a user or of a cart token, or all of them when both are NULL
+	ListOrders(ctx context.Context, arg ListOrdersParams) ([]Order, error)
+	ListPublisherBooks(ctx context.Context, publisherIds []int64) ([]ListPublisherBooksRow, error)
 	ListPublishers(ctx context.Context, arg ListPublishersParams) ([]Publisher, error)
+	ListWebhookDeliveries(ctx context.Context, arg ListWebhookDeliveriesParams) ([]WebhookDelivery, error)
+	ListWebhooks(ctx context.Context) ([]Webhook, error)
+	// Adds the items of a cart to another, up to 99 of a book
+	MergeCartItems(ctx context.Context, arg MergeCartItemsParams) error
+	PurgeAuthors(ctx context.Context, deletedBefore sql.NullTime) (int64, error)
+	PurgeBooks(ctx context.Context, deletedBefore sql.NullTime) (int64, error)
+	PurgePublishers(ctx context.Context, deletedBefore sql.NullTime) (int64, error)
+	RedeliverWebhookDelivery(ctx context.Context, arg RedeliverWebhookDeliveryParams) (WebhookDelivery, error)
+	RestoreAuthor(ctx context.Context, authorID int64) (Author, error)
+	RestoreBookByISBN(ctx context.Context, arg RestoreBookByISBNParams) (Book, error)
+	RestorePublisher(ctx context.Context, publisherID int64) (Publisher, error)
+	SetBookCover(ctx context.Context, arg SetBookCoverParams) (Book, error)
+	SetCartItemQuantity(ctx context.Context, arg SetCartItemQuantityParams) (int64, error)
+	SetCartUserID(ctx context.Context, arg SetCartUserIDParams) (Cart, error)
+	SetWebhookDeliveryResult(ctx context.Context, arg SetWebhookDeliveryResultParams) (WebhookDelivery, error)
 	UpdateAuthor(ctx context.Context, arg UpdateAuthorParams) (Author, error)
 	UpdateBookByISBN(ctx context.Context, arg UpdateBookByISBNParams) (Book, error)
+	UpdateOrderStatus(ctx context.Context, arg UpdateOrderStatusParams) (Order, error)
 	UpdatePublisher(ctx context.Context, arg UpdatePublisherParams) (Publisher, error)
+	UpsertSubject(ctx context.Context, subjectName string) (Subject, error)
 }
 
 var _ Querier = (*Queries)(nil)
@@ -3,24 +3,80 @@ package db
 import (
 	"context"
 	"database/sql"
+	"time"
+
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
 )
 
 // Store defines all functions to execute db queries and transactions
 type Store interface {
 	Querier
+	ExecTx(ctx context.Context, fn func(q Querier) error) error
 	CreateBookTx(ctx context.Context, arg CreateBookTxParams) (book Book, err error)
+	UpdateBookTx(ctx context.Context, arg UpdateBookTxParams) (book Book, err error)
+	MergeCartTx(ctx context.Context, arg MergeCartTxParams) error
+	CheckoutTx(ctx context.Context, arg CheckoutTxParams) (CheckoutTxResult, error)
+	PurgeTx(ctx context.Context, deletedBefore time.Time) (PurgeTxResult, error)
+	Ping(ctx context.Context) error
+	SchemaVersion(ctx context.Context) (SchemaVersion, error)
+	CheckWritable(ctx context.Context) error
 }
 
-// SQLStore provides all functions to execute SQL queries and transactions
+// SQLStore provides all functions to execute SQL queries and transactions.
+// Reads outside of transactions go to the reader, everything else to db.
 type SQLStore struct {
-	db *sql.DB
-	*Queries
+	db     *sql.DB
+	readDB *sql.DB
+	Querier
+	reader     Querier
+	newQuerier func(DBTX) Querier
+	txOptions  TxOptions
+	// checkWritable is CheckWritable for the database of the store
+	checkWritable func(ctx context.Context) error
+}
+
+// NewStore creates a new store backed by SQLite
+func NewStore(db *sql.DB, opts ...StoreOption) Store {
+	return NewStoreWithReader(db, db, opts...)
+}
+
+// NewStoreWithReader creates a new store backed by SQLite that sends writes
+// and transactions to db and other reads to readDB
+func NewStoreWithReader(db, readDB *sql.DB, opts ...StoreOption) Store {
+	store := &SQLStore{
+		db:         db,
+		readDB:     readDB,
+		Querier:    New(instrument(db, semconv.DBSystemSqlite)),
+		reader:     New(instrument(readDB, semconv.DBSystemSqlite)),
+		newQuerier: func(db DBTX) Querier { return New(instrument(db, semconv.DBSystemSqlite)) },
+	}
+	store.checkWritable = store.checkFilesWritable
+
+	return newSQLStore(store, opts)
 }
 
-// NewStore creates a new store
-func NewStore(db *sql.DB) Store {
-	return &SQLStore{
-		db:      db,
-		Queries: New(db),
+// NewPostgresStore creates a new store backed by PostgreSQL
+func NewPostgresStore(db *sql.DB, opts ...StoreOption) Store {
+	store := &SQLStore{
+		db:         db,
+		readDB:     db,
+		Querier:    newPostgresQuerier(db),
+		reader:     newPostgresQuerier(db),
+		newQuerier: newPostgresQuerier,
 	}
+	store.checkWritable = store.checkWriteRollsBack
+
+	return newSQLStore(store, opts)
+}
+
+func newSQLStore(store *SQLStore, opts []StoreOption) *SQLStore {
+	store.txOptions = TxOptions{
+		MaxRetries: defaultTxMaxRetries,
+		RetryDelay: defaultTxRetryDelay,
+	}
+	for _, opt := range opts {
+		opt(store)
+	}
+
+	return store
 }
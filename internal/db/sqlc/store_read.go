@@ -0,0 +1,129 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+)
+
+// The queries below only read, so they are sent to the read pool.
+// TestReadQueriesUseReader fails for a read query left out.
+
+func (store *SQLStore) CountAuthors(ctx context.Context, arg CountAuthorsParams) (int64, error) {
+	return store.reader.CountAuthors(ctx, arg)
+}
+
+func (store *SQLStore) CountBooks(ctx context.Context, arg CountBooksParams) (int64, error) {
+	return store.reader.CountBooks(ctx, arg)
+}
+
+func (store *SQLStore) CountOrders(ctx context.Context, arg CountOrdersParams) (int64, error) {
+	return store.reader.CountOrders(ctx, arg)
+}
+
+func (store *SQLStore) CountPublishers(ctx context.Context, arg CountPublishersParams) (int64, error) {
+	return store.reader.CountPublishers(ctx, arg)
+}
+
+func (store *SQLStore) CountWebhookDeliveries(ctx context.Context, arg CountWebhookDeliveriesParams) (int64, error) {
+	return store.reader.CountWebhookDeliveries(ctx, arg)
+}
+
+func (store *SQLStore) GetAuthor(ctx context.Context, arg GetAuthorParams) (Author, error) {
+	return store.reader.GetAuthor(ctx, arg)
+}
+
+func (store *SQLStore) GetAuthorByName(ctx context.Context, arg GetAuthorByNameParams) (Author, error) {
+	return store.reader.GetAuthorByName(ctx, arg)
+}
+
+func (store *SQLStore) GetBookByISBN(ctx context.Context, arg GetBookByISBNParams) (GetBookByISBNRow, error) {
+	return store.reader.GetBookByISBN(ctx, arg)
+}
+
+func (store *SQLStore) GetCartByToken(ctx context.Context, token string) (Cart, error) {
+	return store.reader.GetCartByToken(ctx, token)
+}
+
+func (store *SQLStore) GetCartByUserID(ctx context.Context, userID sql.NullString) (Cart, error) {
+	return store.reader.GetCartByUserID(ctx, userID)
+}
+
+func (store *SQLStore) GetOrder(ctx context.Context, orderID int64) (Order, error) {
+	return store.reader.GetOrder(ctx, orderID)
+}
+
+func (store *SQLStore) GetPublisher(ctx context.Context, arg GetPublisherParams) (Publisher, error) {
+	return store.reader.GetPublisher(ctx, arg)
+}
+
+func (store *SQLStore) GetPublisherByName(ctx context.Context, arg GetPublisherByNameParams) (Publisher, error) {
+	return store.reader.GetPublisherByName(ctx, arg)
+}
+
+func (store *SQLStore) GetWebhook(ctx context.Context, webhookID int64) (Webhook, error) {
+	return store.reader.GetWebhook(ctx, webhookID)
+}
+
+func (store *SQLStore) ListAuthorBooks(ctx context.Context, authorIds []int64) ([]ListAuthorBooksRow, error) {
+	return store.reader.ListAuthorBooks(ctx, authorIds)
+}
+
+func (store *SQLStore) ListAuthors(ctx context.Context, arg ListAuthorsParams) ([]Author, error) {
+	return store.reader.ListAuthors(ctx, arg)
+}
+
+func (store *SQLStore) ListAuthorsWithBookID(ctx context.Context, bookID int64) ([]ListAuthorsWithBookIDRow, error) {
+	return store.reader.ListAuthorsWithBookID(ctx, bookID)
+}
+
+func (store *SQLStore) ListBookAuthors(ctx context.Context, bookIds []int64) ([]ListBookAuthorsRow, error) {
+	return store.reader.ListBookAuthors(ctx, bookIds)
+}
+
+func (store *SQLStore) ListBookPublishers(ctx context.Context, bookIds []int64) ([]ListBookPublishersRow, error) {
+	return store.reader.ListBookPublishers(ctx, bookIds)
+}
+
+func (store *SQLStore) ListBooks(ctx context.Context, arg ListBooksParams) ([]ListBooksRow, error) {
+	return store.reader.ListBooks(ctx, arg)
+}
+
+func (store *SQLStore) ListCartItems(ctx context.Context, cartID int64) ([]ListCartItemsRow, error) {
+	return store.reader.ListCartItems(ctx, cartID)
+}
+
+func (store *SQLStore) ListChanges(ctx context.Context, arg ListChangesParams) ([]ListChangesRow, error) {
+	return store.reader.ListChanges(ctx, arg)
+}
+
+func (store *SQLStore) ListDueWebhookDeliveries(ctx context.Context, arg ListDueWebhookDeliveriesParams) ([]ListDueWebhookDeliveriesRow, error) {
+	return store.reader.ListDueWebhookDeliveries(ctx, arg)
+}
+
+func (store *SQLStore) ListOrderItems(ctx context.Context, orderID int64) ([]OrderItem, error) {
+	return store.reader.ListOrderItems(ctx, orderID)
+}
+
+func (store *SQLStore) ListOrderItemsByOrderIDs(ctx context.Context, orderIds []int64) ([]OrderItem, error) {
+	return store.reader.ListOrderItemsByOrderIDs(ctx, orderIds)
+}
+
+func (store *SQLStore) ListOrders(ctx context.Context, arg ListOrdersParams) ([]Order, error) {
+	return store.reader.ListOrders(ctx, arg)
+}
+
+func (store *SQLStore) ListPublisherBooks(ctx context.Context, publisherIds []int64) ([]ListPublisherBooksRow, error) {
+	return store.reader.ListPublisherBooks(ctx, publisherIds)
+}
+
+func (store *SQLStore) ListPublishers(ctx context.Context, arg ListPublishersParams) ([]Publisher, error) {
+	return store.reader.ListPublishers(ctx, arg)
+}
+
+func (store *SQLStore) ListWebhookDeliveries(ctx context.Context, arg ListWebhookDeliveriesParams) ([]WebhookDelivery, error) {
+	return store.reader.ListWebhookDeliveries(ctx, arg)
+}
+
+func (store *SQLStore) ListWebhooks(ctx context.Context) ([]Webhook, error) {
+	return store.reader.ListWebhooks(ctx)
+}
@@ -0,0 +1,119 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var errNotSent = errors.New("not sent")
+
+// countingDB is a DBTX counting the queries sent to it, without running them
+type countingDB struct {
+	queries int
+}
+
+func (db *countingDB) ExecContext(context.Context, string, ...interface{}) (sql.Result, error) {
+	db.queries++
+	return nil, errNotSent
+}
+
+func (db *countingDB) PrepareContext(context.Context, string) (*sql.Stmt, error) {
+	db.queries++
+	return nil, errNotSent
+}
+
+func (db *countingDB) QueryContext(context.Context, string, ...interface{}) (*sql.Rows, error) {
+	db.queries++
+	return nil, errNotSent
+}
+
+func (db *countingDB) QueryRowContext(context.Context, string, ...interface{}) *sql.Row {
+	db.queries++
+	return nil
+}
+
+var queryNameRegexp = regexp.MustCompile(`(?m)^-- name: (\w+) :(\w+)$`)
+
+// readQueries returns the names of the :one and :many queries of the SQLite
+// query files that are plain SELECTs
+func readQueries(t *testing.T) []string {
+	files, err := filepath.Glob("../query/*.sql")
+	require.NoError(t, err)
+	require.NotEmpty(t, files)
+
+	var names []string
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		require.NoError(t, err)
+
+		src := string(data)
+		matches := queryNameRegexp.FindAllStringSubmatchIndex(src, -1)
+		for i, m := range matches {
+			name, kind := src[m[2]:m[3]], src[m[4]:m[5]]
+			if kind != "one" && kind != "many" {
+				continue
+			}
+
+			end := len(src)
+			if i+1 < len(matches) {
+				end = matches[i+1][0]
+			}
+			if firstKeyword(src[m[1]:end]) == "SELECT" {
+				names = append(names, name)
+			}
+		}
+	}
+
+	return names
+}
+
+// firstKeyword returns the first word of a statement, past its comments
+func firstKeyword(stmt string) string {
+	for _, line := range strings.Split(stmt, "\n") {
+		line = strings.TrimSpace(line)
+		if len(line) == 0 || strings.HasPrefix(line, "--") {
+			continue
+		}
+
+		return strings.ToUpper(strings.Fields(line)[0])
+	}
+
+	return ""
+}
+
+func TestReadQueriesUseReader(t *testing.T) {
+	names := readQueries(t)
+	require.NotEmpty(t, names)
+
+	for _, name := range names {
+		t.Run(name, func(t *testing.T) {
+			writer, reader := &countingDB{}, &countingDB{}
+			store := &SQLStore{Querier: New(writer), reader: New(reader)}
+
+			method := reflect.ValueOf(store).MethodByName(name)
+			require.True(t, method.IsValid(), "no method for query %s", name)
+
+			args := []reflect.Value{reflect.ValueOf(context.Background())}
+			for i := 1; i < method.Type().NumIn(); i++ {
+				args = append(args, reflect.Zero(method.Type().In(i)))
+			}
+			func() {
+				// a :one query scans the nil row it gets back
+				defer func() { _ = recover() }()
+				method.Call(args)
+			}()
+
+			require.Equal(t, 1, reader.queries, "%s is not sent to the read pool, add it to store_read.go", name)
+			require.Zero(t, writer.queries)
+		})
+	}
+}
@@ -0,0 +1,12 @@
+package db
+
+import "time"
+
+// After returns the bound for an "updated_at > t" comparison. Timestamps are
+// stored to the millisecond and SQLite compares them as text, while the driver
+// formats a bound time with only as many fractional digits as it needs. Just
+// past the millisecond, in UTC, the bound always has nine digits and sorts
+// after a stored value of the same millisecond.
+func After(t time.Time) time.Time {
+	return t.UTC().Truncate(time.Millisecond).Add(time.Nanosecond)
+}
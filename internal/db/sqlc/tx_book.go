@@ -2,69 +2,211 @@ package db
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 
 	"github.com/atsuyaourt/xyz-books/internal/util"
 )
 
+// ContributorRoleAuthor is the role given to Authors of CreateBookTxParams
+const ContributorRoleAuthor = "author"
+
+type Contributor struct {
+	Name util.Name
+	Role string
+}
+
 type CreateBookTxParams struct {
-	Book      CreateBookParams
-	Authors   []util.Name
-	Publisher string
+	Book         CreateBookParams
+	Authors      []util.Name
+	Contributors []Contributor // listed after the authors, in order
+	Publisher    string
+	Subjects     []string
+	// AfterCreate runs in the transaction once the book is saved, e.g. to
+	// queue its events. The book is not created when it fails.
+	AfterCreate func(q Querier, book Book) error
 }
 
 func (store *SQLStore) CreateBookTx(ctx context.Context, arg CreateBookTxParams) (book Book, err error) {
-	err = store.execTx(ctx, func(q *Queries) error {
-		authors := make([]Author, len(arg.Authors))
-		for i, authorInfo := range arg.Authors {
-			authors[i], err = store.GetAuthorByName(ctx, GetAuthorByNameParams(authorInfo))
+	err = store.ExecTx(ctx, func(q Querier) error {
+		contributors := make([]Contributor, 0, len(arg.Authors)+len(arg.Contributors))
+		for _, name := range arg.Authors {
+			contributors = append(contributors, Contributor{Name: name, Role: ContributorRoleAuthor})
+		}
+		contributors = append(contributors, arg.Contributors...)
+
+		authors := make([]Author, len(contributors))
+		for i, c := range contributors {
+			// deleted authors are found too, as their names are still
+			// taken, and restored below
+			authors[i], err = q.GetAuthorByName(ctx, GetAuthorByNameParams{
+				FirstName:      c.Name.FirstName,
+				LastName:       c.Name.LastName,
+				MiddleName:     c.Name.MiddleName,
+				IncludeDeleted: true,
+			})
 			if err != nil {
 				if !errors.Is(err, ErrRecordNotFound) {
 					return err
 				}
-				authors[i], err = store.CreateAuthor(ctx, CreateAuthorParams(authorInfo))
+				authors[i], err = q.CreateAuthor(ctx, CreateAuthorParams(c.Name))
+				if err != nil {
+					return err
+				}
+			} else if authors[i].DeletedAt.Valid {
+				// crediting a deleted author brings it back
+				authors[i], err = q.RestoreAuthor(ctx, authors[i].AuthorID)
 				if err != nil {
 					return err
 				}
 			}
 		}
 
-		publisher, err := store.GetPublisherByName(ctx, arg.Publisher)
+		publisher, err := q.GetPublisherByName(ctx, GetPublisherByNameParams{
+			PublisherName:  arg.Publisher,
+			IncludeDeleted: true,
+		})
 		if err != nil {
 			if !errors.Is(err, ErrRecordNotFound) {
 				return err
 			}
-			publisher, err = store.CreatePublisher(ctx, arg.Publisher)
+			publisher, err = q.CreatePublisher(ctx, arg.Publisher)
+			if err != nil {
+				return err
+			}
+		} else if publisher.DeletedAt.Valid {
+			publisher, err = q.RestorePublisher(ctx, publisher.PublisherID)
 			if err != nil {
 				return err
 			}
 		}
 
-		book, err = store.CreateBook(ctx, CreateBookParams{
-			Title:           arg.Book.Title,
-			Isbn13:          arg.Book.Isbn13,
-			Isbn10:          arg.Book.Isbn10,
-			Price:           arg.Book.Price,
-			PublicationYear: arg.Book.PublicationYear,
-			ImageUrl:        arg.Book.ImageUrl,
-			Edition:         arg.Book.Edition,
-			PublisherID:     publisher.PublisherID,
+		// the ISBNs of deleted books stay taken until they are purged
+		deleted, err := q.GetBookByISBN(ctx, GetBookByISBNParams{
+			Isbn13:         arg.Book.Isbn13,
+			Isbn10:         arg.Book.Isbn10,
+			IncludeDeleted: true,
 		})
+		if err == nil && deleted.Book.DeletedAt.Valid {
+			return ErrRecordDeleted
+		}
+		if err != nil && !errors.Is(err, ErrRecordNotFound) {
+			return err
+		}
+
+		bookArg := arg.Book
+		bookArg.PublisherID = publisher.PublisherID
+		book, err = q.CreateBook(ctx, bookArg)
 		if err != nil {
 			return err
 		}
 
 		for i := range authors {
-			err = store.CreateAuthorBookRel(ctx, CreateAuthorBookRelParams{
+			err = q.CreateAuthorBookRel(ctx, CreateAuthorBookRelParams{
 				BookID:   book.BookID,
 				AuthorID: authors[i].AuthorID,
+				Role: sql.NullString{
+					String: contributors[i].Role,
+					Valid:  len(contributors[i].Role) > 0,
+				},
+				Position: sql.NullInt64{
+					Int64: int64(i),
+					Valid: true,
+				},
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		err = setBookSubjects(ctx, q, book.BookID, arg.Subjects)
+		if err != nil || arg.AfterCreate == nil {
+			return err
+		}
+
+		return arg.AfterCreate(q, book)
+	})
+
+	return
+}
+
+type UpdateBookTxParams struct {
+	Book     UpdateBookByISBNParams
+	Subjects []string // nil leaves the subjects unchanged
+	// AfterUpdate runs in the transaction once the book is saved, with the
+	// book as it was before, e.g. to queue its events. The update is rolled
+	// back when it fails.
+	AfterUpdate func(q Querier, old, book Book) error
+}
+
+func (store *SQLStore) UpdateBookTx(ctx context.Context, arg UpdateBookTxParams) (book Book, err error) {
+	err = store.ExecTx(ctx, func(q Querier) error {
+		var old GetBookByISBNRow
+		if arg.AfterUpdate != nil {
+			old, err = q.GetBookByISBN(ctx, GetBookByISBNParams{
+				Isbn13: arg.Book.Isbn13,
+				Isbn10: arg.Book.Isbn10,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		book, err = q.UpdateBookByISBN(ctx, arg.Book)
+		if errors.Is(err, ErrRecordNotFound) && arg.Book.Version.Valid {
+			// tell a stale version apart from a missing book
+			_, getErr := q.GetBookByISBN(ctx, GetBookByISBNParams{
+				Isbn13: arg.Book.Isbn13,
+				Isbn10: arg.Book.Isbn10,
 			})
+			if getErr == nil {
+				return ErrVersionChanged
+			}
+		}
+		if err != nil {
+			return err
+		}
+		if !book.Isbn13.Valid && !book.Isbn10.Valid {
+			return ErrMissingISBN
+		}
+
+		if arg.Subjects != nil {
+			err = q.DeleteBookSubjectRels(ctx, book.BookID)
+			if err != nil {
+				return err
+			}
+
+			err = setBookSubjects(ctx, q, book.BookID, arg.Subjects)
 			if err != nil {
 				return err
 			}
 		}
-		return nil
+
+		if arg.AfterUpdate == nil {
+			return nil
+		}
+
+		return arg.AfterUpdate(q, old.Book, book)
 	})
 
 	return
 }
+
+func setBookSubjects(ctx context.Context, q Querier, bookID int64, subjects []string) error {
+	for _, name := range subjects {
+		subject, err := q.UpsertSubject(ctx, name)
+		if err != nil {
+			return err
+		}
+
+		err = q.CreateBookSubjectRel(ctx, CreateBookSubjectRelParams{
+			BookID:    bookID,
+			SubjectID: subject.SubjectID,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,28 @@
+package db
+
+import "context"
+
+type MergeCartTxParams struct {
+	FromCartID int64
+	ToCartID   int64
+}
+
+// MergeCartTx moves the items of one cart into another and removes the source cart
+func (store *SQLStore) MergeCartTx(ctx context.Context, arg MergeCartTxParams) error {
+	return store.ExecTx(ctx, func(q Querier) error {
+		err := q.MergeCartItems(ctx, MergeCartItemsParams{
+			ToCartID:   arg.ToCartID,
+			FromCartID: arg.FromCartID,
+		})
+		if err != nil {
+			return err
+		}
+
+		err = q.ClearCartItems(ctx, arg.FromCartID)
+		if err != nil {
+			return err
+		}
+
+		return q.DeleteCart(ctx, arg.FromCartID)
+	})
+}
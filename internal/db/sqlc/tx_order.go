@@ -0,0 +1,58 @@
+package db
+
+import (
+	"context"
+	"math"
+)
+
+type CheckoutTxParams struct {
+	CartID int64
+	Order  CreateOrderParams
+}
+
+type CheckoutTxResult struct {
+	Order Order
+	Items []OrderItem
+}
+
+// CheckoutTx creates a pending order from the contents of a cart. Title,
+// ISBNs and price are copied from the books so later catalog changes do not
+// alter the order. The cart is left as is, to be emptied once the order is
+// paid.
+func (store *SQLStore) CheckoutTx(ctx context.Context, arg CheckoutTxParams) (res CheckoutTxResult, err error) {
+	err = store.ExecTx(ctx, func(q Querier) error {
+		cartItems, err := q.ListCartItems(ctx, arg.CartID)
+		if err != nil {
+			return err
+		}
+		if len(cartItems) == 0 {
+			return ErrEmptyCart
+		}
+
+		var total float64
+		for _, item := range cartItems {
+			total += item.Book.Price * float64(item.Quantity)
+		}
+
+		orderArg := arg.Order
+		orderArg.Total = math.Round(total*100) / 100
+
+		res.Order, err = q.CreateOrder(ctx, orderArg)
+		if err != nil {
+			return err
+		}
+
+		err = q.CreateOrderItemsFromCart(ctx, CreateOrderItemsFromCartParams{
+			OrderID: res.Order.OrderID,
+			CartID:  arg.CartID,
+		})
+		if err != nil {
+			return err
+		}
+
+		res.Items, err = q.ListOrderItems(ctx, res.Order.OrderID)
+		return err
+	})
+
+	return
+}
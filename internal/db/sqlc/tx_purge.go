@@ -0,0 +1,55 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+type PurgeTxResult struct {
+	Books      int64
+	Authors    int64
+	Publishers int64
+}
+
+// PurgeTx permanently removes the books, authors and publishers deleted
+// before the given time. Books go first, with their author, subject and
+// cart links, ordered books are kept in orders with their copied details.
+// Authors and publishers that still have books are kept.
+func (store *SQLStore) PurgeTx(ctx context.Context, deletedBefore time.Time) (res PurgeTxResult, err error) {
+	arg := sql.NullTime{
+		Time:  deletedBefore.UTC(),
+		Valid: true,
+	}
+
+	err = store.ExecTx(ctx, func(q Querier) error {
+		if err := q.DeletePurgedAuthorBookRels(ctx, arg); err != nil {
+			return err
+		}
+		if err := q.DeletePurgedBookSubjectRels(ctx, arg); err != nil {
+			return err
+		}
+		if err := q.DeletePurgedCartItems(ctx, arg); err != nil {
+			return err
+		}
+		if err := q.DetachPurgedOrderItems(ctx, arg); err != nil {
+			return err
+		}
+
+		var err error
+		res.Books, err = q.PurgeBooks(ctx, arg)
+		if err != nil {
+			return err
+		}
+
+		res.Authors, err = q.PurgeAuthors(ctx, arg)
+		if err != nil {
+			return err
+		}
+
+		res.Publishers, err = q.PurgePublishers(ctx, arg)
+		return err
+	})
+
+	return
+}
@@ -0,0 +1,387 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.26.0
+// source: webhook.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+)
+
+const countWebhookDeliveries = `-- name: CountWebhookDeliveries :one
+SELECT count(*) FROM webhook_deliveries
+WHERE
+  (webhook_id = ?1 OR ?1 IS NULL)
+  AND (status = ?2 OR ?2 IS NULL)
+`
+
+type CountWebhookDeliveriesParams struct {
+	WebhookID sql.NullInt64  `json:"webhook_id"`
+	Status    sql.NullString `json:"status"`
+}
+
+func (q *Queries) CountWebhookDeliveries(ctx context.Context, arg CountWebhookDeliveriesParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countWebhookDeliveries, arg.WebhookID, arg.Status)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const createWebhook = `-- name: CreateWebhook :one
+INSERT INTO webhooks (
+  url,
+  secret,
+  events
+) VALUES (
+  ?1, ?2, ?3
+) RETURNING webhook_id, url, secret, events, created_at
+`
+
+type CreateWebhookParams struct {
+	Url    string `json:"url"`
+	Secret string `json:"secret"`
+	Events string `json:"events"`
+}
+
+func (q *Queries) CreateWebhook(ctx context.Context, arg CreateWebhookParams) (Webhook, error) {
+	row := q.db.QueryRowContext(ctx, createWebhook, arg.Url, arg.Secret, arg.Events)
+	var i Webhook
+	err := row.Scan(
+		&i.WebhookID,
+		&i.Url,
+		&i.Secret,
+		&i.Events,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const deleteWebhook = `-- name: DeleteWebhook :execrows
+DELETE FROM webhooks
+WHERE webhook_id = ?1
+`
+
+func (q *Queries) DeleteWebhook(ctx context.Context, webhookID int64) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteWebhook, webhookID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteWebhookDeliveries = `-- name: DeleteWebhookDeliveries :exec
+DELETE FROM webhook_deliveries
+WHERE webhook_id = ?1
+`
+
+func (q *Queries) DeleteWebhookDeliveries(ctx context.Context, webhookID int64) error {
+	_, err := q.db.ExecContext(ctx, deleteWebhookDeliveries, webhookID)
+	return err
+}
+
+const enqueueWebhookDeliveries = `-- name: EnqueueWebhookDeliveries :execrows
+INSERT INTO webhook_deliveries (
+  webhook_id,
+  event_id,
+  event,
+  payload,
+  next_attempt_at
+)
+SELECT
+  webhook_id,
+  CAST(?1 AS TEXT),
+  CAST(?2 AS TEXT),
+  CAST(?3 AS TEXT),
+  CAST(?4 AS INTEGER)
+FROM webhooks
+WHERE ',' || events || ',' LIKE '%,' || ?2 || ',%'
+`
+
+type EnqueueWebhookDeliveriesParams struct {
+	EventID       string `json:"event_id"`
+	Event         string `json:"event"`
+	Payload       string `json:"payload"`
+	NextAttemptAt int64  `json:"next_attempt_at"`
+}
+
+func (q *Queries) EnqueueWebhookDeliveries(ctx context.Context, arg EnqueueWebhookDeliveriesParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, enqueueWebhookDeliveries,
+		arg.EventID,
+		arg.Event,
+		arg.Payload,
+		arg.NextAttemptAt,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const getWebhook = `-- name: GetWebhook :one
+SELECT webhook_id, url, secret, events, created_at FROM webhooks
+WHERE webhook_id = ?1 LIMIT 1
+`
+
+func (q *Queries) GetWebhook(ctx context.Context, webhookID int64) (Webhook, error) {
+	row := q.db.QueryRowContext(ctx, getWebhook, webhookID)
+	var i Webhook
+	err := row.Scan(
+		&i.WebhookID,
+		&i.Url,
+		&i.Secret,
+		&i.Events,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const leaseWebhookDelivery = `-- name: LeaseWebhookDelivery :execrows
+UPDATE webhook_deliveries
+SET next_attempt_at = ?1
+WHERE
+  delivery_id = ?2
+  AND status = 'pending'
+  AND next_attempt_at = ?3
+`
+
+type LeaseWebhookDeliveryParams struct {
+	LeaseUntil    int64 `json:"lease_until"`
+	DeliveryID    int64 `json:"delivery_id"`
+	NextAttemptAt int64 `json:"next_attempt_at"`
+}
+
+func (q *Queries) LeaseWebhookDelivery(ctx context.Context, arg LeaseWebhookDeliveryParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, leaseWebhookDelivery, arg.LeaseUntil, arg.DeliveryID, arg.NextAttemptAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const listDueWebhookDeliveries = `-- name: ListDueWebhookDeliveries :many
+SELECT
+  d.delivery_id, d.webhook_id, d.event_id, d.event, d.payload, d.status, d.attempts, d.next_attempt_at, d.last_error, d.created_at,
+  w.url,
+  w.secret
+FROM webhook_deliveries d
+JOIN webhooks w ON d.webhook_id = w.webhook_id
+WHERE
+  d.status = 'pending'
+  AND d.next_attempt_at <= ?1
+ORDER BY d.next_attempt_at, d.delivery_id
+LIMIT ?2
+`
+
+type ListDueWebhookDeliveriesParams struct {
+	Now   int64 `json:"now"`
+	Limit int64 `json:"limit"`
+}
+
+type ListDueWebhookDeliveriesRow struct {
+	WebhookDelivery WebhookDelivery `json:"webhook_delivery"`
+	Url             string          `json:"url"`
+	Secret          string          `json:"secret"`
+}
+
+func (q *Queries) ListDueWebhookDeliveries(ctx context.Context, arg ListDueWebhookDeliveriesParams) ([]ListDueWebhookDeliveriesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listDueWebhookDeliveries, arg.Now, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListDueWebhookDeliveriesRow{}
+	for rows.Next() {
+		var i ListDueWebhookDeliveriesRow
+		if err := rows.Scan(
+			&i.WebhookDelivery.DeliveryID,
+			&i.WebhookDelivery.WebhookID,
+			&i.WebhookDelivery.EventID,
+			&i.WebhookDelivery.Event,
+			&i.WebhookDelivery.Payload,
+			&i.WebhookDelivery.Status,
+			&i.WebhookDelivery.Attempts,
+			&i.WebhookDelivery.NextAttemptAt,
+			&i.WebhookDelivery.LastError,
+			&i.WebhookDelivery.CreatedAt,
+			&i.Url,
+			&i.Secret,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listWebhookDeliveries = `-- name: ListWebhookDeliveries :many
+SELECT delivery_id, webhook_id, event_id, event, payload, status, attempts, next_attempt_at, last_error, created_at FROM webhook_deliveries
+WHERE
+  (webhook_id = ?1 OR ?1 IS NULL)
+  AND (status = ?2 OR ?2 IS NULL)
+ORDER BY delivery_id DESC
+LIMIT ?4
+OFFSET ?3
+`
+
+type ListWebhookDeliveriesParams struct {
+	WebhookID sql.NullInt64  `json:"webhook_id"`
+	Status    sql.NullString `json:"status"`
+	Offset    int64          `json:"offset"`
+	Limit     int64          `json:"limit"`
+}
+
+func (q *Queries) ListWebhookDeliveries(ctx context.Context, arg ListWebhookDeliveriesParams) ([]WebhookDelivery, error) {
+	rows, err := q.db.QueryContext(ctx, listWebhookDeliveries,
+		arg.WebhookID,
+		arg.Status,
+		arg.Offset,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []WebhookDelivery{}
+	for rows.Next() {
+		var i WebhookDelivery
+		if err := rows.Scan(
+			&i.DeliveryID,
+			&i.WebhookID,
+			&i.EventID,
+			&i.Event,
+			&i.Payload,
+			&i.Status,
+			&i.Attempts,
+			&i.NextAttemptAt,
+			&i.LastError,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listWebhooks = `-- name: ListWebhooks :many
+SELECT webhook_id, url, secret, events, created_at FROM webhooks
+ORDER BY webhook_id
+`
+
+func (q *Queries) ListWebhooks(ctx context.Context) ([]Webhook, error) {
+	rows, err := q.db.QueryContext(ctx, listWebhooks)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Webhook{}
+	for rows.Next() {
+		var i Webhook
+		if err := rows.Scan(
+			&i.WebhookID,
+			&i.Url,
+			&i.Secret,
+			&i.Events,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const redeliverWebhookDelivery = `-- name: RedeliverWebhookDelivery :one
+UPDATE webhook_deliveries
+SET
+  status = 'pending',
+  attempts = 0,
+  next_attempt_at = ?1,
+  last_error = NULL
+WHERE delivery_id = ?2
+RETURNING delivery_id, webhook_id, event_id, event, payload, status, attempts, next_attempt_at, last_error, created_at
+`
+
+type RedeliverWebhookDeliveryParams struct {
+	NextAttemptAt int64 `json:"next_attempt_at"`
+	DeliveryID    int64 `json:"delivery_id"`
+}
+
+func (q *Queries) RedeliverWebhookDelivery(ctx context.Context, arg RedeliverWebhookDeliveryParams) (WebhookDelivery, error) {
+	row := q.db.QueryRowContext(ctx, redeliverWebhookDelivery, arg.NextAttemptAt, arg.DeliveryID)
+	var i WebhookDelivery
+	err := row.Scan(
+		&i.DeliveryID,
+		&i.WebhookID,
+		&i.EventID,
+		&i.Event,
+		&i.Payload,
+		&i.Status,
+		&i.Attempts,
+		&i.NextAttemptAt,
+		&i.LastError,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const setWebhookDeliveryResult = `-- name: SetWebhookDeliveryResult :one
+UPDATE webhook_deliveries
+SET
+  status = ?1,
+  attempts = attempts + 1,
+  next_attempt_at = ?2,
+  last_error = ?3
+WHERE delivery_id = ?4
+RETURNING delivery_id, webhook_id, event_id, event, payload, status, attempts, next_attempt_at, last_error, created_at
+`
+
+type SetWebhookDeliveryResultParams struct {
+	Status        string         `json:"status"`
+	NextAttemptAt int64          `json:"next_attempt_at"`
+	LastError     sql.NullString `json:"last_error"`
+	DeliveryID    int64          `json:"delivery_id"`
+}
+
+func (q *Queries) SetWebhookDeliveryResult(ctx context.Context, arg SetWebhookDeliveryResultParams) (WebhookDelivery, error) {
+	row := q.db.QueryRowContext(ctx, setWebhookDeliveryResult,
+		arg.Status,
+		arg.NextAttemptAt,
+		arg.LastError,
+		arg.DeliveryID,
+	)
+	var i WebhookDelivery
+	err := row.Scan(
+		&i.DeliveryID,
+		&i.WebhookID,
+		&i.EventID,
+		&i.Event,
+		&i.Payload,
+		&i.Status,
+		&i.Attempts,
+		&i.NextAttemptAt,
+		&i.LastError,
+		&i.CreatedAt,
+	)
+	return i, err
+}
@@ -0,0 +1,165 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/atsuyaourt/xyz-books/internal/util"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type WebhookTestSuite struct {
+	suite.Suite
+}
+
+func TestWebhookTestSuite(t *testing.T) {
+	suite.Run(t, new(WebhookTestSuite))
+}
+
+func (ts *WebhookTestSuite) SetupTest() {
+	err := util.DBMigrationUp(testConfig.MigrationSrc, testDBUrl)
+	require.NoError(ts.T(), err, "db migration problem")
+}
+
+func (ts *WebhookTestSuite) TearDownTest() {
+	err := util.DBMigrationDown(testConfig.MigrationSrc, testDBUrl)
+	require.NoError(ts.T(), err, "reverse db migration problem")
+}
+
+func createRandomWebhook(t *testing.T, events string) Webhook {
+	arg := CreateWebhookParams{
+		Url:    "https://example.com/" + util.RandomString(8),
+		Secret: util.RandomString(16),
+		Events: events,
+	}
+
+	webhook, err := testStore.CreateWebhook(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotZero(t, webhook.WebhookID)
+	require.Equal(t, arg.Url, webhook.Url)
+	require.Equal(t, arg.Secret, webhook.Secret)
+	require.Equal(t, arg.Events, webhook.Events)
+
+	return webhook
+}
+
+func (ts *WebhookTestSuite) TestEnqueueWebhookDeliveries() {
+	t := ts.T()
+
+	subscribed := createRandomWebhook(t, "book.created,price.changed")
+	createRandomWebhook(t, "book.updated")
+
+	n, err := testStore.EnqueueWebhookDeliveries(context.Background(), EnqueueWebhookDeliveriesParams{
+		EventID:       util.RandomString(8),
+		Event:         "price.changed",
+		Payload:       "{}",
+		NextAttemptAt: 1000,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), n)
+
+	// a prefix of a subscribed event is no match
+	n, err = testStore.EnqueueWebhookDeliveries(context.Background(), EnqueueWebhookDeliveriesParams{
+		EventID:       util.RandomString(8),
+		Event:         "book",
+		Payload:       "{}",
+		NextAttemptAt: 1000,
+	})
+	require.NoError(t, err)
+	require.Zero(t, n)
+
+	rows, err := testStore.ListDueWebhookDeliveries(context.Background(), ListDueWebhookDeliveriesParams{
+		Now:   999,
+		Limit: 10,
+	})
+	require.NoError(t, err)
+	require.Empty(t, rows)
+
+	rows, err = testStore.ListDueWebhookDeliveries(context.Background(), ListDueWebhookDeliveriesParams{
+		Now:   1000,
+		Limit: 10,
+	})
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	require.Equal(t, subscribed.WebhookID, rows[0].WebhookDelivery.WebhookID)
+	require.Equal(t, subscribed.Url, rows[0].Url)
+	require.Equal(t, subscribed.Secret, rows[0].Secret)
+	require.Equal(t, "pending", rows[0].WebhookDelivery.Status)
+}
+
+func (ts *WebhookTestSuite) TestDeliveryLifecycle() {
+	t := ts.T()
+
+	webhook := createRandomWebhook(t, "book.created")
+	_, err := testStore.EnqueueWebhookDeliveries(context.Background(), EnqueueWebhookDeliveriesParams{
+		EventID:       util.RandomString(8),
+		Event:         "book.created",
+		Payload:       "{}",
+		NextAttemptAt: 1000,
+	})
+	require.NoError(t, err)
+
+	rows, err := testStore.ListDueWebhookDeliveries(context.Background(), ListDueWebhookDeliveriesParams{
+		Now:   1000,
+		Limit: 10,
+	})
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	delivery := rows[0].WebhookDelivery
+
+	lease := LeaseWebhookDeliveryParams{
+		DeliveryID:    delivery.DeliveryID,
+		NextAttemptAt: delivery.NextAttemptAt,
+		LeaseUntil:    5000,
+	}
+	n, err := testStore.LeaseWebhookDelivery(context.Background(), lease)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), n)
+
+	// only one worker gets the lease
+	n, err = testStore.LeaseWebhookDelivery(context.Background(), lease)
+	require.NoError(t, err)
+	require.Zero(t, n)
+
+	dead, err := testStore.SetWebhookDeliveryResult(context.Background(), SetWebhookDeliveryResultParams{
+		DeliveryID:    delivery.DeliveryID,
+		Status:        "dead",
+		NextAttemptAt: 2000,
+		LastError:     sql.NullString{String: "receiver answered 410 Gone", Valid: true},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "dead", dead.Status)
+	require.Equal(t, int64(1), dead.Attempts)
+	require.Equal(t, "receiver answered 410 Gone", dead.LastError.String)
+
+	deliveries, err := testStore.ListWebhookDeliveries(context.Background(), ListWebhookDeliveriesParams{
+		WebhookID: sql.NullInt64{Int64: webhook.WebhookID, Valid: true},
+		Status:    sql.NullString{String: "dead", Valid: true},
+		Limit:     10,
+	})
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+
+	redelivered, err := testStore.RedeliverWebhookDelivery(context.Background(), RedeliverWebhookDeliveryParams{
+		DeliveryID:    delivery.DeliveryID,
+		NextAttemptAt: 3000,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "pending", redelivered.Status)
+	require.Zero(t, redelivered.Attempts)
+	require.False(t, redelivered.LastError.Valid)
+
+	count, err := testStore.CountWebhookDeliveries(context.Background(), CountWebhookDeliveriesParams{
+		Status: sql.NullString{String: "dead", Valid: true},
+	})
+	require.NoError(t, err)
+	require.Zero(t, count)
+
+	_, err = testStore.RedeliverWebhookDelivery(context.Background(), RedeliverWebhookDeliveryParams{
+		DeliveryID:    delivery.DeliveryID + 100,
+		NextAttemptAt: 3000,
+	})
+	require.ErrorIs(t, err, ErrRecordNotFound)
+}
@@ -18,6 +18,107 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/admin/orders": {
+            "get": {
+                "security": [
+                    {
+                        "AdminKey": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "List the orders of every customer",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 30,
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "limit",
+                        "name": "per_page",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "pending",
+                            "paid",
+                            "shipped",
+                            "delivered",
+                            "cancelled"
+                        ],
+                        "type": "string",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/PaginatedOrders"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/orders/{id}/status": {
+            "put": {
+                "security": [
+                    {
+                        "AdminKey": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Update order status",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update order status parameters",
+                        "name": "req",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/UpdateOrderStatusParams"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Order"
+                        }
+                    }
+                }
+            }
+        },
         "/authors": {
             "get": {
                 "consumes": [
@@ -31,6 +132,12 @@ const docTemplate = `{
                 ],
                 "summary": "List authors",
                 "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "also list deleted authors",
+                        "name": "include_deleted",
+                        "in": "query"
+                    },
                     {
                         "minimum": 1,
                         "type": "integer",
@@ -44,6 +151,12 @@ const docTemplate = `{
                         "description": "limit",
                         "name": "per_page",
                         "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "only list authors changed after this time (RFC 3339)",
+                        "name": "updated_since",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -106,6 +219,18 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "also find a deleted author, admins only",
+                        "name": "include_deleted",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of a cached copy",
+                        "name": "If-None-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -114,6 +239,9 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/Author"
                         }
+                    },
+                    "304": {
+                        "description": "Not Modified"
                     }
                 }
             },
@@ -136,6 +264,13 @@ const docTemplate = `{
                         "in": "path",
                         "required": true
                     },
+                    {
+                        "type": "string",
+                        "description": "ETag of the author being changed",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    },
                     {
                         "description": "Update author parameters",
                         "name": "req",
@@ -152,6 +287,12 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/Author"
                         }
+                    },
+                    "412": {
+                        "description": "Precondition Failed"
+                    },
+                    "428": {
+                        "description": "Precondition Required"
                     }
                 }
             },
@@ -173,11 +314,113 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the author being deleted",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "204": {
                         "description": "No Content"
+                    },
+                    "412": {
+                        "description": "Precondition Failed"
+                    },
+                    "428": {
+                        "description": "Precondition Required"
+                    }
+                }
+            },
+            "patch": {
+                "description": "Applies a JSON merge patch, members left out are unchanged and null clears a field",
+                "consumes": [
+                    "application/merge-patch+json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "authors"
+                ],
+                "summary": "Patch author",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "author ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the author being changed",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Patch author parameters",
+                        "name": "req",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/PatchAuthorParams"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Author"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed"
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type"
+                    },
+                    "428": {
+                        "description": "Precondition Required"
+                    }
+                }
+            }
+        },
+        "/authors/{id}/restore": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "authors"
+                ],
+                "summary": "Restore a deleted author",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "author ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Author"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict"
                     }
                 }
             }
@@ -194,12 +437,47 @@ const docTemplate = `{
                     "books"
                 ],
                 "summary": "List books",
+                "deprecated": true,
                 "parameters": [
                     {
                         "type": "string",
                         "name": "author",
                         "in": "query"
                     },
+                    {
+                        "type": "string",
+                        "name": "description",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "hardcover",
+                            "paperback",
+                            "ebook",
+                            "audiobook"
+                        ],
+                        "type": "string",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "also list deleted books",
+                        "name": "include_deleted",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "matches more specific tags too, en matches en-US",
+                        "name": "language",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "name": "max_page_count",
+                        "in": "query"
+                    },
                     {
                         "type": "number",
                         "name": "max_price",
@@ -210,6 +488,12 @@ const docTemplate = `{
                         "name": "max_publication_year",
                         "in": "query"
                     },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "name": "min_page_count",
+                        "in": "query"
+                    },
                     {
                         "type": "number",
                         "name": "min_price",
@@ -240,10 +524,32 @@ const docTemplate = `{
                         "name": "publisher",
                         "in": "query"
                     },
+                    {
+                        "type": "string",
+                        "name": "series_name",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "name": "series_number",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "name": "subject",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
                         "name": "title",
                         "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "only list books changed after this time (RFC 3339)",
+                        "name": "updated_since",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -283,6 +589,9 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/Book"
                         }
+                    },
+                    "409": {
+                        "description": "Conflict"
                     }
                 }
             }
@@ -299,6 +608,7 @@ const docTemplate = `{
                     "books"
                 ],
                 "summary": "Get book",
+                "deprecated": true,
                 "parameters": [
                     {
                         "type": "string",
@@ -306,6 +616,18 @@ const docTemplate = `{
                         "name": "isbn",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "also find a deleted book, admins only",
+                        "name": "include_deleted",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of a cached copy",
+                        "name": "If-None-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -314,6 +636,9 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/Book"
                         }
+                    },
+                    "304": {
+                        "description": "Not Modified"
                     }
                 }
             },
@@ -336,6 +661,13 @@ const docTemplate = `{
                         "in": "path",
                         "required": true
                     },
+                    {
+                        "type": "string",
+                        "description": "ETag of the book being changed",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    },
                     {
                         "description": "Update book parameters",
                         "name": "req",
@@ -352,6 +684,12 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/Book"
                         }
+                    },
+                    "412": {
+                        "description": "Precondition Failed"
+                    },
+                    "428": {
+                        "description": "Precondition Required"
                     }
                 }
             },
@@ -373,54 +711,800 @@ const docTemplate = `{
                         "name": "isbn",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the book being deleted",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "204": {
                         "description": "No Content"
+                    },
+                    "412": {
+                        "description": "Precondition Failed"
+                    },
+                    "428": {
+                        "description": "Precondition Required"
                     }
                 }
-            }
-        },
-        "/publishers": {
-            "get": {
+            },
+            "patch": {
+                "description": "Applies a JSON merge patch, members left out are unchanged and null clears a field",
                 "consumes": [
-                    "application/json"
+                    "application/merge-patch+json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "publishers"
+                    "books"
                 ],
-                "summary": "List publishers",
+                "summary": "Patch book",
                 "parameters": [
                     {
-                        "minimum": 1,
-                        "type": "integer",
-                        "description": "page number",
-                        "name": "page",
-                        "in": "query"
+                        "type": "string",
+                        "description": "ISBN-13",
+                        "name": "isbn",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "maximum": 30,
+                        "type": "string",
+                        "description": "ETag of the book being changed",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Patch book parameters",
+                        "name": "req",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/PatchBookParams"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Book"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed"
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type"
+                    },
+                    "428": {
+                        "description": "Precondition Required"
+                    }
+                }
+            }
+        },
+        "/books/{isbn}/cover": {
+            "post": {
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "books"
+                ],
+                "summary": "Upload book cover",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ISBN-13",
+                        "name": "isbn",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "JPEG, PNG or WebP image",
+                        "name": "cover",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Book"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "books"
+                ],
+                "summary": "Delete book cover",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ISBN-13",
+                        "name": "isbn",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    }
+                }
+            }
+        },
+        "/books/{isbn}/restore": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "books"
+                ],
+                "summary": "Restore a deleted book",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ISBN-13",
+                        "name": "isbn",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Book"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict"
+                    }
+                }
+            }
+        },
+        "/cart": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cart"
+                ],
+                "summary": "Get cart",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Cart"
+                        }
+                    }
+                }
+            }
+        },
+        "/cart/items": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cart"
+                ],
+                "summary": "Add book to cart",
+                "parameters": [
+                    {
+                        "description": "Add cart item parameters",
+                        "name": "req",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/AddCartItemParams"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Cart"
+                        }
+                    }
+                }
+            }
+        },
+        "/cart/items/{isbn}": {
+            "put": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cart"
+                ],
+                "summary": "Update cart item quantity",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ISBN-13",
+                        "name": "isbn",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update cart item parameters",
+                        "name": "req",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/UpdateCartItemParams"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Cart"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cart"
+                ],
+                "summary": "Remove book from cart",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ISBN-13",
+                        "name": "isbn",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Cart"
+                        }
+                    }
+                }
+            }
+        },
+        "/changes": {
+            "get": {
+                "description": "Books, authors and publishers changed after a time, oldest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "changes"
+                ],
+                "summary": "List changes",
+                "parameters": [
+                    {
+                        "maximum": 1000,
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "most changes listed",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "only list changes after this time (RFC 3339)",
+                        "name": "since",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ChangeFeed"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "orders"
+                ],
+                "summary": "List orders",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 30,
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "limit",
+                        "name": "per_page",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "pending",
+                            "paid",
+                            "shipped",
+                            "delivered",
+                            "cancelled"
+                        ],
+                        "type": "string",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/PaginatedOrders"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "orders"
+                ],
+                "summary": "Checkout the cart",
+                "parameters": [
+                    {
+                        "description": "Checkout parameters",
+                        "name": "req",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/CheckoutParams"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/Order"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{id}": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "orders"
+                ],
+                "summary": "Get order",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Order"
+                        }
+                    }
+                }
+            }
+        },
+        "/publishers": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "publishers"
+                ],
+                "summary": "List publishers",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "also list deleted publishers",
+                        "name": "include_deleted",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 30,
                         "minimum": 1,
                         "type": "integer",
                         "description": "limit",
                         "name": "per_page",
                         "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "only list publishers changed after this time (RFC 3339)",
+                        "name": "updated_since",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/PaginatedPublishers"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "publishers"
+                ],
+                "summary": "Create publisher",
+                "parameters": [
+                    {
+                        "description": "Create publisher parameters",
+                        "name": "req",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/CreateAuthorParams"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/Publisher"
+                        }
+                    }
+                }
+            }
+        },
+        "/publishers/{id}": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "publishers"
+                ],
+                "summary": "Get publisher",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "publisher ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "also find a deleted publisher, admins only",
+                        "name": "include_deleted",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of a cached copy",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Publisher"
+                        }
+                    },
+                    "304": {
+                        "description": "Not Modified"
+                    }
+                }
+            },
+            "put": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "publishers"
+                ],
+                "summary": "Update publisher",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "publisher ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the publisher being changed",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Update publisher parameters",
+                        "name": "req",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/UpdatePublisherParams"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Publisher"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed"
+                    },
+                    "428": {
+                        "description": "Precondition Required"
+                    }
+                }
+            },
+            "delete": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "publishers"
+                ],
+                "summary": "Delete publisher",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "publisher ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the publisher being deleted",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "412": {
+                        "description": "Precondition Failed"
+                    },
+                    "428": {
+                        "description": "Precondition Required"
+                    }
+                }
+            },
+            "patch": {
+                "description": "Applies a JSON merge patch, members left out are unchanged and null clears a field",
+                "consumes": [
+                    "application/merge-patch+json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "publishers"
+                ],
+                "summary": "Patch publisher",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "publisher ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the publisher being changed",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Patch publisher parameters",
+                        "name": "req",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/PatchPublisherParams"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Publisher"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed"
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type"
+                    },
+                    "428": {
+                        "description": "Precondition Required"
+                    }
+                }
+            }
+        },
+        "/publishers/{id}/restore": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "publishers"
+                ],
+                "summary": "Restore a deleted publisher",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "publisher ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Publisher"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict"
                     }
+                }
+            }
+        },
+        "/webhooks": {
+            "get": {
+                "security": [
+                    {
+                        "AdminKey": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
                 ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "List webhooks",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/PaginatedPublishers"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/Webhook"
+                            }
                         }
                     }
                 }
             },
             "post": {
+                "security": [
+                    {
+                        "AdminKey": []
+                    }
+                ],
+                "description": "Subscribes a public http or https URL to catalog events. Deliveries are signed with the secret, which is only returned here.",
                 "consumes": [
                     "application/json"
                 ],
@@ -428,17 +1512,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "publishers"
+                    "webhooks"
                 ],
-                "summary": "Create publisher",
+                "summary": "Create webhook",
                 "parameters": [
                     {
-                        "description": "Create publisher parameters",
+                        "description": "Create webhook parameters",
                         "name": "req",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/CreateAuthorParams"
+                            "$ref": "#/definitions/CreateWebhookParams"
                         }
                     }
                 ],
@@ -446,14 +1530,20 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/Publisher"
+                            "$ref": "#/definitions/Webhook"
                         }
                     }
                 }
             }
         },
-        "/publishers/{id}": {
+        "/webhooks/deliveries": {
             "get": {
+                "security": [
+                    {
+                        "AdminKey": []
+                    }
+                ],
+                "description": "Newest first, status=dead lists the deliveries that ran out of attempts",
                 "consumes": [
                     "application/json"
                 ],
@@ -461,28 +1551,61 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "publishers"
+                    "webhooks"
                 ],
-                "summary": "Get publisher",
+                "summary": "List webhook deliveries",
                 "parameters": [
                     {
+                        "minimum": 1,
                         "type": "integer",
-                        "description": "publisher ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "limit",
+                        "name": "per_page",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "pending",
+                            "delivered",
+                            "dead"
+                        ],
+                        "type": "string",
+                        "description": "dead lists the deliveries that ran out of attempts",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "name": "webhook_id",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/Publisher"
+                            "$ref": "#/definitions/PaginatedWebhookDeliveries"
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/webhooks/deliveries/{id}/redeliver": {
+            "post": {
+                "security": [
+                    {
+                        "AdminKey": []
+                    }
+                ],
+                "description": "Queues a delivery, dead or not, to be sent again with a fresh set of attempts",
                 "consumes": [
                     "application/json"
                 ],
@@ -490,37 +1613,35 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "publishers"
+                    "webhooks"
                 ],
-                "summary": "Update publisher",
+                "summary": "Redeliver webhook delivery",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "publisher ID",
+                        "description": "delivery ID",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Update publisher parameters",
-                        "name": "req",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/UpdatePublisherParams"
-                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "202": {
+                        "description": "Accepted",
                         "schema": {
-                            "$ref": "#/definitions/Publisher"
+                            "$ref": "#/definitions/WebhookDelivery"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/webhooks/{id}": {
             "delete": {
+                "security": [
+                    {
+                        "AdminKey": []
+                    }
+                ],
                 "consumes": [
                     "application/json"
                 ],
@@ -528,13 +1649,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "publishers"
+                    "webhooks"
                 ],
-                "summary": "Delete publisher",
+                "summary": "Delete webhook",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "publisher ID",
+                        "description": "webhook ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -549,52 +1670,304 @@ const docTemplate = `{
         }
     },
     "definitions": {
+        "AddCartItemParams": {
+            "type": "object",
+            "required": [
+                "isbn13"
+            ],
+            "properties": {
+                "isbn13": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer",
+                    "maximum": 99,
+                    "minimum": 1
+                }
+            }
+        },
         "Author": {
             "type": "object",
             "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "type": "string"
+                },
                 "first_name": {
                     "type": "string"
                 },
+                "id": {
+                    "type": "integer"
+                },
                 "last_name": {
                     "type": "string"
                 },
-                "middle_name": {
-                    "type": "string"
+                "middle_name": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "Book": {
+            "type": "object",
+            "properties": {
+                "authors": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "contributors": {
+                    "description": "authors and other contributors, in credit order",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/Contributor"
+                    }
+                },
+                "cover": {
+                    "description": "the uploaded cover",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/Cover"
+                        }
+                    ]
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "description": "set on deleted books, which are only listed on request",
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "edition": {
+                    "type": "string"
+                },
+                "format": {
+                    "type": "string"
+                },
+                "image_url": {
+                    "description": "the uploaded cover, else the image set by clients, else the generated cover",
+                    "type": "string"
+                },
+                "isbn10": {
+                    "type": "string"
+                },
+                "isbn13": {
+                    "type": "string"
+                },
+                "language": {
+                    "type": "string"
+                },
+                "page_count": {
+                    "type": "integer"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "publication_year": {
+                    "type": "integer"
+                },
+                "publisher": {
+                    "type": "string"
+                },
+                "series_name": {
+                    "type": "string"
+                },
+                "series_number": {
+                    "type": "integer"
+                },
+                "subjects": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "title": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "description": "set on every change, including deletes",
+                    "type": "string"
+                },
+                "version": {
+                    "description": "bumped on every change, sent as the ETag",
+                    "type": "integer"
+                }
+            }
+        },
+        "Cart": {
+            "type": "object",
+            "properties": {
+                "item_count": {
+                    "type": "integer"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/CartItem"
+                    }
+                },
+                "total": {
+                    "type": "number"
+                }
+            }
+        },
+        "CartItem": {
+            "type": "object",
+            "properties": {
+                "image_url": {
+                    "type": "string"
+                },
+                "isbn10": {
+                    "type": "string"
+                },
+                "isbn13": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "subtotal": {
+                    "type": "number"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "Change": {
+            "type": "object",
+            "properties": {
+                "deleted": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "description": "ID of the book, author or publisher, kept when a book's ISBNs change",
+                    "type": "string"
+                },
+                "isbn": {
+                    "description": "current ISBN-13 of a book, or its ISBN-10 when it has none",
+                    "type": "string"
+                },
+                "type": {
+                    "description": "book, author or publisher",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "ChangeFeed": {
+            "type": "object",
+            "properties": {
+                "has_more": {
+                    "description": "more changes are ready to be read",
+                    "type": "boolean"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/Change"
+                    }
+                },
+                "since": {
+                    "description": "pass as since to read the changes that follow",
+                    "type": "string"
+                }
+            }
+        },
+        "CheckoutParams": {
+            "type": "object",
+            "required": [
+                "customer_name",
+                "email",
+                "shipping_address"
+            ],
+            "properties": {
+                "customer_name": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "shipping_address": {
+                    "type": "string"
+                }
+            }
+        },
+        "Contributor": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                }
+            }
+        },
+        "ContributorParams": {
+            "type": "object",
+            "required": [
+                "name",
+                "role"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "author",
+                        "illustrator",
+                        "translator",
+                        "editor",
+                        "colorist",
+                        "letterer"
+                    ]
                 }
             }
         },
-        "Book": {
+        "Cover": {
             "type": "object",
             "properties": {
-                "authors": {
+                "thumbnails": {
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/CoverThumbnail"
                     }
                 },
-                "edition": {
-                    "type": "string"
-                },
-                "image_url": {
-                    "type": "string"
-                },
-                "isbn10": {
+                "url": {
                     "type": "string"
-                },
-                "isbn13": {
+                }
+            }
+        },
+        "CoverThumbnail": {
+            "type": "object",
+            "properties": {
+                "url": {
                     "type": "string"
                 },
-                "price": {
-                    "type": "number"
-                },
-                "publication_year": {
+                "width": {
                     "type": "integer"
-                },
-                "publisher": {
-                    "type": "string"
-                },
-                "title": {
-                    "type": "string"
                 }
             }
         },
@@ -622,13 +1995,11 @@ const docTemplate = `{
         "CreateBookParams": {
             "type": "object",
             "required": [
-                "authors",
                 "publisher"
             ],
             "properties": {
                 "authors": {
                     "type": "array",
-                    "minItems": 1,
                     "items": {
                         "type": "string"
                     }
@@ -641,9 +2012,21 @@ const docTemplate = `{
                         "title"
                     ],
                     "properties": {
+                        "description": {
+                            "type": "string"
+                        },
                         "edition": {
                             "type": "string"
                         },
+                        "format": {
+                            "type": "string",
+                            "enum": [
+                                "hardcover",
+                                "paperback",
+                                "ebook",
+                                "audiobook"
+                            ]
+                        },
                         "image_url": {
                             "type": "string"
                         },
@@ -653,6 +2036,14 @@ const docTemplate = `{
                         "isbn13": {
                             "type": "string"
                         },
+                        "language": {
+                            "description": "BCP 47 language tag",
+                            "type": "string"
+                        },
+                        "page_count": {
+                            "type": "integer",
+                            "minimum": 1
+                        },
                         "price": {
                             "type": "number"
                         },
@@ -660,13 +2051,116 @@ const docTemplate = `{
                             "type": "integer",
                             "minimum": 1000
                         },
+                        "series_name": {
+                            "type": "string"
+                        },
+                        "series_number": {
+                            "type": "integer",
+                            "minimum": 1
+                        },
                         "title": {
                             "type": "string"
                         }
                     }
                 },
+                "contributors": {
+                    "description": "credited after the authors",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ContributorParams"
+                    }
+                },
                 "publisher": {
                     "type": "string"
+                },
+                "subjects": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "CreateWebhookParams": {
+            "type": "object",
+            "required": [
+                "events",
+                "url"
+            ],
+            "properties": {
+                "events": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/models.EventType"
+                    }
+                },
+                "secret": {
+                    "description": "signs the deliveries, generated when left out",
+                    "type": "string",
+                    "minLength": 16
+                },
+                "url": {
+                    "description": "a public http or https URL",
+                    "type": "string"
+                }
+            }
+        },
+        "Order": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "customer_name": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/OrderItem"
+                    }
+                },
+                "order_id": {
+                    "type": "integer"
+                },
+                "payment_ref": {
+                    "type": "string"
+                },
+                "shipping_address": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/models.OrderStatus"
+                },
+                "total": {
+                    "type": "number"
+                }
+            }
+        },
+        "OrderItem": {
+            "type": "object",
+            "properties": {
+                "isbn10": {
+                    "type": "string"
+                },
+                "isbn13": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "subtotal": {
+                    "type": "number"
+                },
+                "title": {
+                    "type": "string"
                 }
             }
         },
@@ -676,14 +2170,120 @@ const docTemplate = `{
         "PaginatedBooks": {
             "type": "object"
         },
+        "PaginatedOrders": {
+            "type": "object"
+        },
         "PaginatedPublishers": {
             "type": "object"
         },
+        "PaginatedWebhookDeliveries": {
+            "type": "object"
+        },
+        "PatchAuthorParams": {
+            "type": "object",
+            "properties": {
+                "first_name": {
+                    "type": "string",
+                    "minLength": 1
+                },
+                "last_name": {
+                    "type": "string",
+                    "minLength": 1
+                },
+                "middle_name": {
+                    "description": "null removes the middle name",
+                    "type": "string"
+                }
+            }
+        },
+        "PatchBookParams": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "format": {
+                    "type": "string",
+                    "enum": [
+                        "hardcover",
+                        "paperback",
+                        "ebook",
+                        "audiobook"
+                    ]
+                },
+                "image_url": {
+                    "type": "string"
+                },
+                "isbn10": {
+                    "type": "string"
+                },
+                "isbn13": {
+                    "type": "string"
+                },
+                "language": {
+                    "type": "string"
+                },
+                "page_count": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "price": {
+                    "description": "0 for giveaways",
+                    "type": "number",
+                    "minimum": 0
+                },
+                "publication_year": {
+                    "type": "integer",
+                    "minimum": 1000
+                },
+                "series_name": {
+                    "type": "string"
+                },
+                "series_number": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "subjects": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "title": {
+                    "type": "string",
+                    "minLength": 1
+                }
+            }
+        },
+        "PatchPublisherParams": {
+            "type": "object",
+            "properties": {
+                "publisher_name": {
+                    "type": "string",
+                    "minLength": 1
+                }
+            }
+        },
         "Publisher": {
             "type": "object",
             "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
                 "publisher_name": {
                     "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
                 }
             }
         },
@@ -707,6 +2307,18 @@ const docTemplate = `{
         "UpdateBookParams": {
             "type": "object",
             "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "format": {
+                    "type": "string",
+                    "enum": [
+                        "hardcover",
+                        "paperback",
+                        "ebook",
+                        "audiobook"
+                    ]
+                },
                 "image_url": {
                     "type": "string"
                 },
@@ -716,18 +2328,68 @@ const docTemplate = `{
                 "isbn13": {
                     "type": "string"
                 },
+                "language": {
+                    "type": "string"
+                },
+                "page_count": {
+                    "type": "integer",
+                    "minimum": 1
+                },
                 "price": {
                     "type": "number"
                 },
                 "publication_year": {
                     "type": "integer"
                 },
+                "series_name": {
+                    "type": "string"
+                },
+                "series_number": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "subjects": {
+                    "description": "replaces all subjects when given",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "title": {
                     "type": "string",
                     "minLength": 1
                 }
             }
         },
+        "UpdateCartItemParams": {
+            "type": "object",
+            "properties": {
+                "quantity": {
+                    "description": "zero removes the item",
+                    "type": "integer",
+                    "maximum": 99,
+                    "minimum": 0
+                }
+            }
+        },
+        "UpdateOrderStatusParams": {
+            "type": "object",
+            "required": [
+                "status"
+            ],
+            "properties": {
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "pending",
+                        "paid",
+                        "shipped",
+                        "delivered",
+                        "cancelled"
+                    ]
+                }
+            }
+        },
         "UpdatePublisherParams": {
             "type": "object",
             "properties": {
@@ -736,6 +2398,123 @@ const docTemplate = `{
                     "minLength": 1
                 }
             }
+        },
+        "Webhook": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.EventType"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "secret": {
+                    "description": "only returned when the webhook is created",
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "WebhookDelivery": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "event": {
+                    "$ref": "#/definitions/models.EventType"
+                },
+                "event_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "last_error": {
+                    "type": "string"
+                },
+                "next_attempt_at": {
+                    "description": "set on pending deliveries",
+                    "type": "string"
+                },
+                "payload": {
+                    "type": "object"
+                },
+                "status": {
+                    "$ref": "#/definitions/models.WebhookDeliveryStatus"
+                },
+                "webhook_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.EventType": {
+            "type": "string",
+            "enum": [
+                "book.created",
+                "book.updated",
+                "book.deleted",
+                "book.restored",
+                "price.changed"
+            ],
+            "x-enum-varnames": [
+                "EventBookCreated",
+                "EventBookUpdated",
+                "EventBookDeleted",
+                "EventBookRestored",
+                "EventPriceChanged"
+            ]
+        },
+        "models.OrderStatus": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "paid",
+                "shipped",
+                "delivered",
+                "cancelled"
+            ],
+            "x-enum-varnames": [
+                "OrderPending",
+                "OrderPaid",
+                "OrderShipped",
+                "OrderDelivered",
+                "OrderCancelled"
+            ]
+        },
+        "models.WebhookDeliveryStatus": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "delivered",
+                "dead"
+            ],
+            "x-enum-comments": {
+                "WebhookDeliveryDead": "ran out of attempts"
+            },
+            "x-enum-varnames": [
+                "WebhookDeliveryPending",
+                "WebhookDeliveryDelivered",
+                "WebhookDeliveryDead"
+            ]
+        }
+    },
+    "securityDefinitions": {
+        "AdminKey": {
+            "type": "apiKey",
+            "name": "X-API-Key",
+            "in": "header"
         }
     }
 }`
@@ -0,0 +1,181 @@
+package graph
+
+import (
+	"context"
+	"errors"
+
+	db "github.com/atsuyaourt/xyz-books/internal/db/sqlc"
+	"github.com/atsuyaourt/xyz-books/internal/models"
+	"github.com/atsuyaourt/xyz-books/internal/services"
+	"github.com/graph-gophers/graphql-go"
+)
+
+var errAuthorNotFound = errors.New("author not found")
+
+type authorResolver struct {
+	r      *Resolver
+	author models.Author
+}
+
+func (r *Resolver) newAuthorResolver(author models.Author) *authorResolver {
+	return &authorResolver{r: r, author: author}
+}
+
+func (a *authorResolver) ID() graphql.ID      { return formatID(a.author.ID) }
+func (a *authorResolver) FirstName() string   { return a.author.FirstName }
+func (a *authorResolver) MiddleName() *string { return optional(a.author.MiddleName) }
+func (a *authorResolver) LastName() string    { return a.author.LastName }
+func (a *authorResolver) CreatedAt() graphql.Time {
+	return graphql.Time{Time: a.author.CreatedAt}
+}
+func (a *authorResolver) UpdatedAt() graphql.Time {
+	return graphql.Time{Time: a.author.UpdatedAt}
+}
+func (a *authorResolver) DeletedAt() *graphql.Time { return optionalTime(a.author.DeletedAt) }
+func (a *authorResolver) Version() int32           { return int32(a.author.Version) }
+
+func (a *authorResolver) Books(ctx context.Context, args pageArgs) (*pageResolver[models.Book, *bookResolver], error) {
+	books, err := a.r.loaders(ctx).authorBooks.Load(ctx, a.author.ID)()
+	if err != nil {
+		return nil, err
+	}
+
+	page, err := paginate(books, args)
+	if err != nil {
+		return nil, err
+	}
+	if err := a.r.count(ctx, len(page.Items)); err != nil {
+		return nil, err
+	}
+
+	return newPageResolver(page, a.r.newBookResolver), nil
+}
+
+func (r *Resolver) Author(ctx context.Context, args struct {
+	ID             graphql.ID
+	IncludeDeleted bool
+}) (*authorResolver, error) {
+	id, err := parseID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	author, err := r.service.GetAuthor(ctx, id, args.IncludeDeleted)
+	if errors.Is(err, db.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return r.newAuthorResolver(*author), nil
+}
+
+func (r *Resolver) Authors(ctx context.Context, args struct {
+	UpdatedSince   *graphql.Time
+	IncludeDeleted bool
+	Page           int32
+	PerPage        int32
+}) (*pageResolver[models.Author, *authorResolver], error) {
+	req := services.ListAuthorsReq{
+		UpdatedSince:   since(args.UpdatedSince),
+		IncludeDeleted: args.IncludeDeleted,
+		Page:           args.Page,
+		PerPage:        args.PerPage,
+	}
+	if err := validate(req); err != nil {
+		return nil, err
+	}
+
+	authors, err := r.service.ListAuthors(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.count(ctx, len(authors.Items)); err != nil {
+		return nil, err
+	}
+
+	return newPageResolver(authors, r.newAuthorResolver), nil
+}
+
+type createAuthorInput struct {
+	FirstName  string
+	MiddleName *string
+	LastName   string
+}
+
+func (r *Resolver) CreateAuthor(ctx context.Context, args struct{ Input createAuthorInput }) (*authorResolver, error) {
+	req := services.CreateAuthorReq{
+		FirstName:  args.Input.FirstName,
+		MiddleName: value(args.Input.MiddleName),
+		LastName:   args.Input.LastName,
+	}
+	if err := validate(req); err != nil {
+		return nil, err
+	}
+
+	author, err := r.service.CreateAuthor(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.newAuthorResolver(*author), nil
+}
+
+type updateAuthorInput struct {
+	FirstName  *string
+	MiddleName *string
+	LastName   *string
+}
+
+func (r *Resolver) UpdateAuthor(ctx context.Context, args struct {
+	ID      graphql.ID
+	Version int32
+	Input   updateAuthorInput
+}) (*authorResolver, error) {
+	id, err := parseID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+	version, err := parseVersion(args.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	req := services.UpdateAuthorReq{
+		FirstName:  value(args.Input.FirstName),
+		MiddleName: value(args.Input.MiddleName),
+		LastName:   value(args.Input.LastName),
+	}
+	if err := validate(req); err != nil {
+		return nil, err
+	}
+
+	author, err := r.service.UpdateAuthor(ctx, id, version, req)
+	if err != nil {
+		return nil, writeError(err, errAuthorNotFound)
+	}
+
+	return r.newAuthorResolver(*author), nil
+}
+
+// DeleteAuthor answers true also when the author was already deleted
+func (r *Resolver) DeleteAuthor(ctx context.Context, args struct {
+	ID      graphql.ID
+	Version int32
+}) (bool, error) {
+	id, err := parseID(args.ID)
+	if err != nil {
+		return false, err
+	}
+	version, err := parseVersion(args.Version)
+	if err != nil {
+		return false, err
+	}
+
+	if err := r.service.DeleteAuthor(ctx, id, version); err != nil {
+		return false, writeError(err, errAuthorNotFound)
+	}
+
+	return true, nil
+}
@@ -0,0 +1,341 @@
+package graph
+
+import (
+	"context"
+	"errors"
+
+	db "github.com/atsuyaourt/xyz-books/internal/db/sqlc"
+	"github.com/atsuyaourt/xyz-books/internal/models"
+	"github.com/atsuyaourt/xyz-books/internal/services"
+	"github.com/graph-gophers/graphql-go"
+)
+
+var errBookNotFound = errors.New("book not found")
+
+type bookResolver struct {
+	r    *Resolver
+	book models.Book
+}
+
+func (r *Resolver) newBookResolver(book models.Book) *bookResolver {
+	return &bookResolver{r: r, book: book}
+}
+
+func (b *bookResolver) ISBN13() string         { return b.book.ISBN13 }
+func (b *bookResolver) ISBN10() *string        { return optional(b.book.ISBN10) }
+func (b *bookResolver) Title() string          { return b.book.Title }
+func (b *bookResolver) Price() float64         { return b.book.Price }
+func (b *bookResolver) PublicationYear() int32 { return int32(b.book.PublicationYear) }
+func (b *bookResolver) Edition() *string       { return optional(b.book.Edition) }
+func (b *bookResolver) Language() *string      { return optional(b.book.Language) }
+func (b *bookResolver) Format() *string        { return optional(b.book.Format) }
+func (b *bookResolver) PageCount() *int32      { return optional(int32(b.book.PageCount)) }
+func (b *bookResolver) SeriesName() *string    { return optional(b.book.SeriesName) }
+func (b *bookResolver) SeriesNumber() *int32   { return optional(int32(b.book.SeriesNumber)) }
+func (b *bookResolver) Description() *string   { return optional(b.book.Description) }
+func (b *bookResolver) ImageUrl() *string      { return optional(b.book.ImageUrl) }
+func (b *bookResolver) CreatedAt() graphql.Time {
+	return graphql.Time{Time: b.book.CreatedAt}
+}
+func (b *bookResolver) UpdatedAt() graphql.Time {
+	return graphql.Time{Time: b.book.UpdatedAt}
+}
+func (b *bookResolver) DeletedAt() *graphql.Time { return optionalTime(b.book.DeletedAt) }
+func (b *bookResolver) Version() int32           { return int32(b.book.Version) }
+
+func (b *bookResolver) Subjects() []string {
+	if b.book.Subjects == nil {
+		return []string{}
+	}
+
+	return b.book.Subjects
+}
+
+func (b *bookResolver) Contributors() []*contributorResolver {
+	res := make([]*contributorResolver, len(b.book.Contributors))
+	for i, c := range b.book.Contributors {
+		res[i] = &contributorResolver{c}
+	}
+
+	return res
+}
+
+func (b *bookResolver) Authors(ctx context.Context) ([]*authorResolver, error) {
+	authors, err := b.r.loaders(ctx).bookAuthors.Load(ctx, b.book.ID)()
+	if err != nil {
+		return nil, err
+	}
+	if err := b.r.count(ctx, len(authors)); err != nil {
+		return nil, err
+	}
+
+	res := make([]*authorResolver, len(authors))
+	for i, author := range authors {
+		res[i] = b.r.newAuthorResolver(author)
+	}
+
+	return res, nil
+}
+
+func (b *bookResolver) Publisher(ctx context.Context) (*publisherResolver, error) {
+	publisher, err := b.r.loaders(ctx).bookPublisher.Load(ctx, b.book.ID)()
+	if err != nil {
+		return nil, err
+	}
+	if publisher.ID == 0 {
+		return nil, errPublisherNotFound
+	}
+
+	return b.r.newPublisherResolver(publisher), nil
+}
+
+type contributorResolver struct {
+	contributor models.Contributor
+}
+
+func (c *contributorResolver) Name() string { return c.contributor.Name }
+func (c *contributorResolver) Role() string { return c.contributor.Role }
+
+func (r *Resolver) Book(ctx context.Context, args struct {
+	ISBN13         string
+	IncludeDeleted bool
+}) (*bookResolver, error) {
+	book, err := r.service.GetBook(ctx, args.ISBN13, args.IncludeDeleted)
+	if errors.Is(err, db.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return r.newBookResolver(*book), nil
+}
+
+type bookFilter struct {
+	Title              *string
+	MinPrice           *float64
+	MaxPrice           *float64
+	MinPublicationYear *int32
+	MaxPublicationYear *int32
+	Author             *string
+	Publisher          *string
+	Language           *string
+	Format             *string
+	MinPageCount       *int32
+	MaxPageCount       *int32
+	SeriesName         *string
+	SeriesNumber       *int32
+	Description        *string
+	Subject            *string
+	UpdatedSince       *graphql.Time
+	IncludeDeleted     *bool
+}
+
+// listBooksReq maps the filter to the query of the REST list, left out
+// bounds take the same -1 default
+func (f *bookFilter) listBooksReq(page, perPage int32) services.ListBooksReq {
+	req := services.ListBooksReq{
+		MinPrice:           -1,
+		MaxPrice:           -1,
+		MinPublicationYear: -1,
+		MaxPublicationYear: -1,
+		Page:               page,
+		PerPage:            perPage,
+	}
+	if f == nil {
+		return req
+	}
+
+	req.Title = value(f.Title)
+	if f.MinPrice != nil {
+		req.MinPrice = float32(*f.MinPrice)
+	}
+	if f.MaxPrice != nil {
+		req.MaxPrice = float32(*f.MaxPrice)
+	}
+	if f.MinPublicationYear != nil {
+		req.MinPublicationYear = *f.MinPublicationYear
+	}
+	if f.MaxPublicationYear != nil {
+		req.MaxPublicationYear = *f.MaxPublicationYear
+	}
+	req.Author = value(f.Author)
+	req.Publisher = value(f.Publisher)
+	req.Language = value(f.Language)
+	req.Format = value(f.Format)
+	req.MinPageCount = value(f.MinPageCount)
+	req.MaxPageCount = value(f.MaxPageCount)
+	req.SeriesName = value(f.SeriesName)
+	req.SeriesNumber = value(f.SeriesNumber)
+	req.Description = value(f.Description)
+	req.Subject = value(f.Subject)
+	req.UpdatedSince = since(f.UpdatedSince)
+	req.IncludeDeleted = value(f.IncludeDeleted)
+
+	return req
+}
+
+func (r *Resolver) Books(ctx context.Context, args struct {
+	Filter  *bookFilter
+	Page    int32
+	PerPage int32
+}) (*pageResolver[models.Book, *bookResolver], error) {
+	req := args.Filter.listBooksReq(args.Page, args.PerPage)
+	if err := validate(req); err != nil {
+		return nil, err
+	}
+
+	books, err := r.service.ListBooks(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.count(ctx, len(books.Items)); err != nil {
+		return nil, err
+	}
+
+	return newPageResolver(books, r.newBookResolver), nil
+}
+
+type contributorInput struct {
+	Name string
+	Role string
+}
+
+type createBookInput struct {
+	Title           string
+	ISBN13          *string
+	ISBN10          *string
+	Price           float64
+	PublicationYear int32
+	ImageUrl        *string
+	Edition         *string
+	Language        *string
+	Format          *string
+	PageCount       *int32
+	SeriesName      *string
+	SeriesNumber    *int32
+	Description     *string
+	Authors         *[]string
+	Contributors    *[]contributorInput
+	Publisher       string
+	Subjects        *[]string
+}
+
+func (r *Resolver) CreateBook(ctx context.Context, args struct{ Input createBookInput }) (*bookResolver, error) {
+	in := args.Input
+
+	var req services.CreateBookReq
+	req.Book.Title = in.Title
+	req.Book.ISBN13 = value(in.ISBN13)
+	req.Book.ISBN10 = value(in.ISBN10)
+	req.Book.Price = in.Price
+	req.Book.PublicationYear = int64(in.PublicationYear)
+	req.Book.ImageUrl = value(in.ImageUrl)
+	req.Book.Edition = value(in.Edition)
+	req.Book.Language = value(in.Language)
+	req.Book.Format = value(in.Format)
+	req.Book.PageCount = int64(value(in.PageCount))
+	req.Book.SeriesName = value(in.SeriesName)
+	req.Book.SeriesNumber = int64(value(in.SeriesNumber))
+	req.Book.Description = value(in.Description)
+	req.Authors = value(in.Authors)
+	for _, c := range value(in.Contributors) {
+		req.Contributors = append(req.Contributors, services.ContributorReq(c))
+	}
+	req.Publisher = in.Publisher
+	req.Subjects = value(in.Subjects)
+	if err := validate(req); err != nil {
+		return nil, err
+	}
+
+	book, err := r.service.CreateBook(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.newBookResolver(*book), nil
+}
+
+type updateBookInput struct {
+	Title           *string
+	ISBN13          *string
+	ISBN10          *string
+	Price           *float64
+	PublicationYear *int32
+	ImageUrl        *string
+	Language        *string
+	Format          *string
+	PageCount       *int32
+	SeriesName      *string
+	SeriesNumber    *int32
+	Description     *string
+	Subjects        *[]string
+}
+
+func (r *Resolver) UpdateBook(ctx context.Context, args struct {
+	ISBN13  string
+	Version int32
+	Input   updateBookInput
+}) (*bookResolver, error) {
+	version, err := parseVersion(args.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	in := args.Input
+	req := services.UpdateBookReq{
+		Title:           value(in.Title),
+		NewISBN13:       value(in.ISBN13),
+		NewISBN10:       value(in.ISBN10),
+		Price:           float32(value(in.Price)),
+		PublicationYear: value(in.PublicationYear),
+		ImageUrl:        value(in.ImageUrl),
+		Language:        value(in.Language),
+		Format:          value(in.Format),
+		PageCount:       int64(value(in.PageCount)),
+		SeriesName:      value(in.SeriesName),
+		SeriesNumber:    int64(value(in.SeriesNumber)),
+		Description:     value(in.Description),
+		Subjects:        value(in.Subjects),
+	}
+	if err := validate(req); err != nil {
+		return nil, err
+	}
+
+	book, err := r.service.UpdateBook(ctx, args.ISBN13, version, req)
+	if err != nil {
+		return nil, writeError(err, errBookNotFound)
+	}
+
+	return r.newBookResolver(*book), nil
+}
+
+// DeleteBook answers true also when the book was already deleted
+func (r *Resolver) DeleteBook(ctx context.Context, args struct {
+	ISBN13  string
+	Version int32
+}) (bool, error) {
+	version, err := parseVersion(args.Version)
+	if err != nil {
+		return false, err
+	}
+
+	err = r.service.DeleteBook(ctx, args.ISBN13, version)
+	if err != nil {
+		return false, writeError(err, errBookNotFound)
+	}
+
+	return true, nil
+}
+
+// writeError gives the errors of writes the messages of the REST API
+func writeError(err error, notFound error) error {
+	switch {
+	case errors.Is(err, db.ErrVersionChanged):
+		return errVersionMismatch
+	case errors.Is(err, db.ErrRecordNotFound):
+		return notFound
+	}
+
+	return err
+}
@@ -0,0 +1,78 @@
+package graph
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/atsuyaourt/xyz-books/internal/models"
+	"github.com/atsuyaourt/xyz-books/internal/services"
+	"github.com/graph-gophers/dataloader/v7"
+)
+
+type loadersKey struct{}
+
+// loaders collect the keys asked for while a query resolves, so that the
+// relationships of a list take one store query each instead of one per item
+type loaders struct {
+	bookAuthors    *dataloader.Loader[int64, []models.Author]
+	bookPublisher  *dataloader.Loader[int64, models.Publisher]
+	authorBooks    *dataloader.Loader[int64, []models.Book]
+	publisherBooks *dataloader.Loader[int64, []models.Book]
+
+	// items counts the records listed by the request, see count
+	items atomic.Int64
+}
+
+func newLoaders(service services.Service) *loaders {
+	return &loaders{
+		bookAuthors:    dataloader.NewBatchedLoader(batch(service.ListBookAuthors)),
+		bookPublisher:  dataloader.NewBatchedLoader(batch(service.ListBookPublishers)),
+		authorBooks:    dataloader.NewBatchedLoader(batch(service.ListAuthorBooks)),
+		publisherBooks: dataloader.NewBatchedLoader(batch(service.ListPublisherBooks)),
+	}
+}
+
+// WithLoaders returns a context to execute a query on. The loaders cache
+// what they load, so each request takes a new set.
+func WithLoaders(ctx context.Context, service services.Service) context.Context {
+	return context.WithValue(ctx, loadersKey{}, newLoaders(service))
+}
+
+// loaders returns the loaders of the request, or a new set when the query
+// was not run on a context from WithLoaders
+func (r *Resolver) loaders(ctx context.Context) *loaders {
+	if l, ok := ctx.Value(loadersKey{}).(*loaders); ok {
+		return l
+	}
+
+	return newLoaders(r.service)
+}
+
+// count adds n records listed by a field to those of the request, and fails
+// once the request lists more than maxItems
+func (r *Resolver) count(ctx context.Context, n int) error {
+	if r.loaders(ctx).items.Add(int64(n)) > maxItems {
+		return errTooManyItems
+	}
+
+	return nil
+}
+
+// batch turns a service call returning values keyed by ID into a batch
+// function, keys without a value get the zero value
+func batch[V any](list func(context.Context, []int64) (map[int64]V, error)) dataloader.BatchFunc[int64, V] {
+	return func(ctx context.Context, keys []int64) []*dataloader.Result[V] {
+		res := make([]*dataloader.Result[V], len(keys))
+
+		values, err := list(ctx, keys)
+		for i, key := range keys {
+			if err != nil {
+				res[i] = &dataloader.Result[V]{Error: err}
+				continue
+			}
+			res[i] = &dataloader.Result[V]{Data: values[key]}
+		}
+
+		return res
+	}
+}
@@ -0,0 +1,58 @@
+package graph
+
+import "github.com/atsuyaourt/xyz-books/internal/util"
+
+// pageArgs are the arguments of the books of an author or publisher
+type pageArgs struct {
+	Page    int32
+	PerPage int32
+}
+
+// paginate returns a page of the items loaded for a relationship
+func paginate[T any](items []T, args pageArgs) (*util.PaginatedList[T], error) {
+	if args.Page < 1 || args.PerPage < 1 || args.PerPage > maxPerPage {
+		return nil, errInvalidPage
+	}
+
+	total := int64(len(items))
+	start := min(int64(args.Page-1)*int64(args.PerPage), total)
+	end := min(start+int64(args.PerPage), total)
+	list := util.NewPaginatedList(args.Page, args.PerPage, int32(total), items[start:end])
+
+	return &list, nil
+}
+
+// pageResolver resolves the BookPage, AuthorPage and PublisherPage types
+type pageResolver[T, R any] struct {
+	list  *util.PaginatedList[T]
+	items []R
+}
+
+func newPageResolver[T, R any](list *util.PaginatedList[T], item func(T) R) *pageResolver[T, R] {
+	items := make([]R, len(list.Items))
+	for i, v := range list.Items {
+		items[i] = item(v)
+	}
+
+	return &pageResolver[T, R]{list: list, items: items}
+}
+
+func (p *pageResolver[T, R]) Items() []R {
+	return p.items
+}
+
+func (p *pageResolver[T, R]) CurrentPage() int32 {
+	return p.list.CurrentPage
+}
+
+func (p *pageResolver[T, R]) PerPage() int32 {
+	return p.list.PerPage
+}
+
+func (p *pageResolver[T, R]) TotalPages() int32 {
+	return p.list.TotalPages
+}
+
+func (p *pageResolver[T, R]) TotalItems() int32 {
+	return p.list.TotalItems
+}
@@ -0,0 +1,171 @@
+package graph
+
+import (
+	"context"
+	"errors"
+
+	db "github.com/atsuyaourt/xyz-books/internal/db/sqlc"
+	"github.com/atsuyaourt/xyz-books/internal/models"
+	"github.com/atsuyaourt/xyz-books/internal/services"
+	"github.com/graph-gophers/graphql-go"
+)
+
+var errPublisherNotFound = errors.New("publisher not found")
+
+type publisherResolver struct {
+	r         *Resolver
+	publisher models.Publisher
+}
+
+func (r *Resolver) newPublisherResolver(publisher models.Publisher) *publisherResolver {
+	return &publisherResolver{r: r, publisher: publisher}
+}
+
+func (p *publisherResolver) ID() graphql.ID { return formatID(p.publisher.ID) }
+func (p *publisherResolver) Name() string   { return p.publisher.PublisherName }
+func (p *publisherResolver) CreatedAt() graphql.Time {
+	return graphql.Time{Time: p.publisher.CreatedAt}
+}
+func (p *publisherResolver) UpdatedAt() graphql.Time {
+	return graphql.Time{Time: p.publisher.UpdatedAt}
+}
+func (p *publisherResolver) DeletedAt() *graphql.Time { return optionalTime(p.publisher.DeletedAt) }
+func (p *publisherResolver) Version() int32           { return int32(p.publisher.Version) }
+
+func (p *publisherResolver) Books(ctx context.Context, args pageArgs) (*pageResolver[models.Book, *bookResolver], error) {
+	books, err := p.r.loaders(ctx).publisherBooks.Load(ctx, p.publisher.ID)()
+	if err != nil {
+		return nil, err
+	}
+
+	page, err := paginate(books, args)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.r.count(ctx, len(page.Items)); err != nil {
+		return nil, err
+	}
+
+	return newPageResolver(page, p.r.newBookResolver), nil
+}
+
+func (r *Resolver) Publisher(ctx context.Context, args struct {
+	ID             graphql.ID
+	IncludeDeleted bool
+}) (*publisherResolver, error) {
+	id, err := parseID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	publisher, err := r.service.GetPublisher(ctx, id, args.IncludeDeleted)
+	if errors.Is(err, db.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return r.newPublisherResolver(*publisher), nil
+}
+
+func (r *Resolver) Publishers(ctx context.Context, args struct {
+	UpdatedSince   *graphql.Time
+	IncludeDeleted bool
+	Page           int32
+	PerPage        int32
+}) (*pageResolver[models.Publisher, *publisherResolver], error) {
+	req := services.ListPublishersReq{
+		UpdatedSince:   since(args.UpdatedSince),
+		IncludeDeleted: args.IncludeDeleted,
+		Page:           args.Page,
+		PerPage:        args.PerPage,
+	}
+	if err := validate(req); err != nil {
+		return nil, err
+	}
+
+	publishers, err := r.service.ListPublishers(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.count(ctx, len(publishers.Items)); err != nil {
+		return nil, err
+	}
+
+	return newPageResolver(publishers, r.newPublisherResolver), nil
+}
+
+type createPublisherInput struct {
+	Name string
+}
+
+func (r *Resolver) CreatePublisher(ctx context.Context, args struct{ Input createPublisherInput }) (*publisherResolver, error) {
+	req := services.CreatePublisherReq{
+		PublisherName: args.Input.Name,
+	}
+	if err := validate(req); err != nil {
+		return nil, err
+	}
+
+	publisher, err := r.service.CreatePublisher(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.newPublisherResolver(*publisher), nil
+}
+
+type updatePublisherInput struct {
+	Name *string
+}
+
+func (r *Resolver) UpdatePublisher(ctx context.Context, args struct {
+	ID      graphql.ID
+	Version int32
+	Input   updatePublisherInput
+}) (*publisherResolver, error) {
+	id, err := parseID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+	version, err := parseVersion(args.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	req := services.UpdatePublisherReq{
+		PublisherName: value(args.Input.Name),
+	}
+	if err := validate(req); err != nil {
+		return nil, err
+	}
+
+	publisher, err := r.service.UpdatePublisher(ctx, id, version, req)
+	if err != nil {
+		return nil, writeError(err, errPublisherNotFound)
+	}
+
+	return r.newPublisherResolver(*publisher), nil
+}
+
+// DeletePublisher answers true also when the publisher was already deleted
+func (r *Resolver) DeletePublisher(ctx context.Context, args struct {
+	ID      graphql.ID
+	Version int32
+}) (bool, error) {
+	id, err := parseID(args.ID)
+	if err != nil {
+		return false, err
+	}
+	version, err := parseVersion(args.Version)
+	if err != nil {
+		return false, err
+	}
+
+	if err := r.service.DeletePublisher(ctx, id, version); err != nil {
+		return false, writeError(err, errPublisherNotFound)
+	}
+
+	return true, nil
+}
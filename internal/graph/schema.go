@@ -0,0 +1,113 @@
+// Package graph serves the catalog over GraphQL. Resolvers call the same
+// service as the REST API, and the relationships between books, authors and
+// publishers are loaded in batches per request.
+package graph
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/atsuyaourt/xyz-books/internal/services"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/graph-gophers/graphql-go"
+)
+
+//go:embed schema.graphql
+var schema string
+
+const (
+	// maxDepth is the deepest a query may nest its selections, e.g.
+	// books { items { authors { books { items { title } } } } } is 6 deep
+	maxDepth = 8
+	// maxItems is the most books, authors and publishers a query may list,
+	// however its lists are nested or aliased
+	maxItems = 1000
+	// maxPerPage is the largest page of the books of an author or
+	// publisher, as for the lists of the REST API
+	maxPerPage = 30
+)
+
+var (
+	errInvalidID       = errors.New("invalid id")
+	errInvalidVersion  = errors.New("version must be at least 1")
+	errVersionMismatch = errors.New("record was changed, fetch it again")
+	errInvalidPage     = fmt.Errorf("page must be at least 1 and perPage between 1 and %d", maxPerPage)
+	errTooManyItems    = fmt.Errorf("query lists more than %d items", maxItems)
+)
+
+// Resolver is the root of the Query and Mutation types
+type Resolver struct {
+	service services.Service
+}
+
+// NewSchema parses the schema with the resolvers calling the given service.
+// Queries must run on a context from WithLoaders to batch relationships
+// and to count the items listed against maxItems.
+func NewSchema(service services.Service) (*graphql.Schema, error) {
+	return graphql.ParseSchema(schema, &Resolver{service: service}, graphql.MaxDepth(maxDepth))
+}
+
+// validate checks a service request against its binding tags, the way the
+// REST handlers do
+func validate(req any) error {
+	return binding.Validator.ValidateStruct(req)
+}
+
+func parseID(id graphql.ID) (int64, error) {
+	n, err := strconv.ParseInt(string(id), 10, 64)
+	if err != nil || n < 1 {
+		return 0, errInvalidID
+	}
+
+	return n, nil
+}
+
+func formatID(id int64) graphql.ID {
+	return graphql.ID(strconv.FormatInt(id, 10))
+}
+
+func parseVersion(version int32) (int64, error) {
+	if version < 1 {
+		return 0, errInvalidVersion
+	}
+
+	return int64(version), nil
+}
+
+// optional returns nil for the zero value, for the nullable fields
+func optional[T comparable](v T) *T {
+	var zero T
+	if v == zero {
+		return nil
+	}
+
+	return &v
+}
+
+func value[T any](p *T) T {
+	if p == nil {
+		var zero T
+		return zero
+	}
+
+	return *p
+}
+
+func optionalTime(t *time.Time) *graphql.Time {
+	if t == nil {
+		return nil
+	}
+
+	return &graphql.Time{Time: *t}
+}
+
+func since(t *graphql.Time) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+
+	return t.Time
+}
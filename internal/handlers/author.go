@@ -4,7 +4,9 @@ import (
 	"errors"
 	"net/http"
 
+	"github.com/atsuyaourt/xyz-books/internal/auth"
 	db "github.com/atsuyaourt/xyz-books/internal/db/sqlc"
+	_ "github.com/atsuyaourt/xyz-books/internal/models" // types named in the swag annotations
 	"github.com/atsuyaourt/xyz-books/internal/services"
 	"github.com/gin-gonic/gin"
 )
@@ -44,8 +46,11 @@ type getAuthorReq struct {
 //	@Tags		authors
 //	@Accept		json
 //	@Produce	json
-//	@Param		id	path		int	true	"author ID"
-//	@Success	200	{object}	models.Author
+//	@Param		id				path		int		true	"author ID"
+//	@Param		include_deleted	query		bool	false	"also find a deleted author, admins only"
+//	@Param		If-None-Match	header		string	false	"ETag of a cached copy"
+//	@Success	200				{object}	models.Author
+//	@Success	304
 //	@Router		/authors/{id} [get]
 func (h *DefaultHandler) GetAuthor(ctx *gin.Context) {
 	var req getAuthorReq
@@ -54,8 +59,18 @@ func (h *DefaultHandler) GetAuthor(ctx *gin.Context) {
 		return
 	}
 
-	res, err := h.service.GetAuthor(ctx, req.ID)
+	var query includeDeletedQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	res, err := h.service.GetAuthor(ctx, req.ID, query.IncludeDeleted)
 	if err != nil {
+		if errors.Is(err, auth.ErrAdminRequired) {
+			ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+			return
+		}
 		if errors.Is(err, db.ErrRecordNotFound) {
 			ctx.JSON(http.StatusBadRequest, errorResponse(errors.New("author not found")))
 			return
@@ -64,6 +79,10 @@ func (h *DefaultHandler) GetAuthor(ctx *gin.Context) {
 		return
 	}
 
+	if notModified(ctx, res.Version) {
+		return
+	}
+
 	ctx.JSON(http.StatusOK, res)
 }
 
@@ -85,6 +104,10 @@ func (h *DefaultHandler) ListAuthors(ctx *gin.Context) {
 
 	res, err := h.service.ListAuthors(ctx, req)
 	if err != nil {
+		if errors.Is(err, auth.ErrAdminRequired) {
+			ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
@@ -102,9 +125,12 @@ type updateAuthorUri struct {
 //	@Tags		authors
 //	@Accept		json
 //	@Produce	json
-//	@Param		id	path		int				true	"author ID"
-//	@Param		req	body		services.UpdateAuthorReq	true	"Update author parameters"
-//	@Success	200	{object}	models.Author
+//	@Param		id			path		int							true	"author ID"
+//	@Param		If-Match	header		string						true	"ETag of the author being changed"
+//	@Param		req			body		services.UpdateAuthorReq	true	"Update author parameters"
+//	@Success	200			{object}	models.Author
+//	@Failure	412
+//	@Failure	428
 //	@Router		/authors/{id} [put]
 func (h *DefaultHandler) UpdateAuthor(ctx *gin.Context) {
 	var uri updateAuthorUri
@@ -119,8 +145,17 @@ func (h *DefaultHandler) UpdateAuthor(ctx *gin.Context) {
 		return
 	}
 
-	res, err := h.service.UpdateAuthor(ctx, uri.ID, req)
+	version, ok := h.authorVersion(ctx, uri.ID)
+	if !ok {
+		return
+	}
+
+	res, err := h.service.UpdateAuthor(ctx, uri.ID, version, req)
 	if err != nil {
+		if errors.Is(err, db.ErrVersionChanged) {
+			ctx.JSON(http.StatusPreconditionFailed, errorResponse(errVersionMismatch))
+			return
+		}
 		if errors.Is(err, db.ErrRecordNotFound) {
 			ctx.JSON(http.StatusBadRequest, errorResponse(errors.New("author not found")))
 			return
@@ -129,6 +164,94 @@ func (h *DefaultHandler) UpdateAuthor(ctx *gin.Context) {
 		return
 	}
 
+	ctx.Header("ETag", entityTag(res.Version))
+	ctx.JSON(http.StatusOK, res)
+}
+
+// authorVersion checks the If-Match header of a write against the current
+// author and returns the version the write must still find
+func (h *DefaultHandler) authorVersion(ctx *gin.Context, id int64) (int64, bool) {
+	if !requireIfMatch(ctx) {
+		return 0, false
+	}
+
+	current, err := h.service.GetAuthor(ctx, id, false)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(errors.New("author not found")))
+			return 0, false
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return 0, false
+	}
+
+	if !ifMatch(ctx, current.Version) {
+		return 0, false
+	}
+
+	return current.Version, true
+}
+
+type patchAuthorUri struct {
+	ID int64 `uri:"id" binding:"required,numeric"`
+}
+
+// PatchAuthor
+//
+//	@Summary		Patch author
+//	@Description	Applies a JSON merge patch, members left out are unchanged and null clears a field
+//	@Tags			authors
+//	@Accept			application/merge-patch+json
+//	@Produce		json
+//	@Param			id			path		int						true	"author ID"
+//	@Param			If-Match	header		string					true	"ETag of the author being changed"
+//	@Param			req			body		services.PatchAuthorReq	true	"Patch author parameters"
+//	@Success		200			{object}	models.Author
+//	@Failure		412
+//	@Failure		415
+//	@Failure		428
+//	@Router			/authors/{id} [patch]
+func (h *DefaultHandler) PatchAuthor(ctx *gin.Context) {
+	var uri patchAuthorUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if !requireMergePatch(ctx) {
+		return
+	}
+
+	var req services.PatchAuthorReq
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	version, ok := h.authorVersion(ctx, uri.ID)
+	if !ok {
+		return
+	}
+
+	res, err := h.service.PatchAuthor(ctx, uri.ID, version, req)
+	if err != nil {
+		if errors.Is(err, db.ErrVersionChanged) {
+			ctx.JSON(http.StatusPreconditionFailed, errorResponse(errVersionMismatch))
+			return
+		}
+		if errors.Is(err, services.ErrInvalidPatch) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		if errors.Is(err, db.ErrRecordNotFound) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(errors.New("author not found")))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.Header("ETag", entityTag(res.Version))
 	ctx.JSON(http.StatusOK, res)
 }
 
@@ -142,8 +265,11 @@ type deleteAuthorUri struct {
 //	@Tags		authors
 //	@Accept		json
 //	@Produce	json
-//	@Param		id	path	int	true	"author ID"
+//	@Param		id			path	int		true	"author ID"
+//	@Param		If-Match	header	string	true	"ETag of the author being deleted"
 //	@Success	204
+//	@Failure	412
+//	@Failure	428
 //	@Router		/authors/{id} [delete]
 func (h *DefaultHandler) DeleteAuthor(ctx *gin.Context) {
 	var req deleteAuthorUri
@@ -152,11 +278,58 @@ func (h *DefaultHandler) DeleteAuthor(ctx *gin.Context) {
 		return
 	}
 
-	err := h.service.DeleteAuthor(ctx, req.ID)
+	version, ok := h.authorVersion(ctx, req.ID)
+	if !ok {
+		return
+	}
+
+	err := h.service.DeleteAuthor(ctx, req.ID, version)
 	if err != nil {
+		if errors.Is(err, db.ErrVersionChanged) {
+			ctx.JSON(http.StatusPreconditionFailed, errorResponse(errVersionMismatch))
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
 
 	ctx.JSON(http.StatusNoContent, nil)
 }
+
+type restoreAuthorUri struct {
+	ID int64 `uri:"id" binding:"required,numeric"`
+}
+
+// RestoreAuthor
+//
+//	@Summary	Restore a deleted author
+//	@Tags		authors
+//	@Accept		json
+//	@Produce	json
+//	@Param		id	path		int	true	"author ID"
+//	@Success	200	{object}	models.Author
+//	@Failure	409
+//	@Router		/authors/{id}/restore [post]
+func (h *DefaultHandler) RestoreAuthor(ctx *gin.Context) {
+	var req restoreAuthorUri
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	res, err := h.service.RestoreAuthor(ctx, req.ID)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(errors.New("author not found")))
+			return
+		}
+		if errors.Is(err, db.ErrNotDeleted) {
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
@@ -7,7 +7,9 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	db "github.com/atsuyaourt/xyz-books/internal/db/sqlc"
 	mockdb "github.com/atsuyaourt/xyz-books/internal/mocks/db"
@@ -35,7 +37,7 @@ func TestCreateAuthorAPI(t *testing.T) {
 				"middle_name": author.MiddleName,
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().CreateAuthor(mock.AnythingOfType("*gin.Context"), mock.Anything).
+				store.EXPECT().CreateAuthor(mock.Anything, mock.Anything).
 					Return(db.Author{}, nil)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
@@ -51,7 +53,7 @@ func TestCreateAuthorAPI(t *testing.T) {
 				"middle_name": author.MiddleName,
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().CreateAuthor(mock.AnythingOfType("*gin.Context"), mock.Anything).
+				store.EXPECT().CreateAuthor(mock.Anything, mock.Anything).
 					Return(db.Author{}, sql.ErrConnDone)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
@@ -66,7 +68,7 @@ func TestCreateAuthorAPI(t *testing.T) {
 				"last_name":  author.LastName,
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().CreateAuthor(mock.AnythingOfType("*gin.Context"), mock.Anything).
+				store.EXPECT().CreateAuthor(mock.Anything, mock.Anything).
 					Return(db.Author{}, nil)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
@@ -136,6 +138,7 @@ func TestGetAuthorAPI(t *testing.T) {
 	testCases := []struct {
 		name          string
 		id            int64
+		ifNoneMatch   string
 		buildStubs    func(store *mockdb.MockStore)
 		checkResponse func(recoder *httptest.ResponseRecorder, store *mockdb.MockStore)
 	}{
@@ -143,19 +146,33 @@ func TestGetAuthorAPI(t *testing.T) {
 			name: "Default",
 			id:   author.AuthorID,
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().GetAuthor(mock.AnythingOfType("*gin.Context"), mock.Anything).
-					Return(db.Author{}, nil)
+				store.EXPECT().GetAuthor(mock.Anything, mock.Anything).
+					Return(author, nil)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
 				store.AssertExpectations(t)
 				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Equal(t, `"1"`, recorder.Header().Get("ETag"))
+			},
+		},
+		{
+			name:        "NotModified",
+			id:          author.AuthorID,
+			ifNoneMatch: `"1"`,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAuthor(mock.Anything, mock.Anything).
+					Return(author, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
+				store.AssertExpectations(t)
+				require.Equal(t, http.StatusNotModified, recorder.Code)
 			},
 		},
 		{
 			name: "InternalError",
 			id:   author.AuthorID,
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().GetAuthor(mock.AnythingOfType("*gin.Context"), mock.Anything).
+				store.EXPECT().GetAuthor(mock.Anything, mock.Anything).
 					Return(db.Author{}, sql.ErrConnDone)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
@@ -167,7 +184,7 @@ func TestGetAuthorAPI(t *testing.T) {
 			name: "NotFound",
 			id:   author.AuthorID,
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().GetAuthor(mock.AnythingOfType("*gin.Context"), mock.Anything).
+				store.EXPECT().GetAuthor(mock.Anything, mock.Anything).
 					Return(db.Author{}, db.ErrRecordNotFound)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
@@ -194,6 +211,9 @@ func TestGetAuthorAPI(t *testing.T) {
 			url := fmt.Sprintf("/authors/%d", tc.id)
 			request, err := http.NewRequest(http.MethodGet, url, nil)
 			require.NoError(t, err)
+			if len(tc.ifNoneMatch) > 0 {
+				request.Header.Set("If-None-Match", tc.ifNoneMatch)
+			}
 
 			router.ServeHTTP(recorder, request)
 
@@ -222,9 +242,9 @@ func TestListAuthorsAPI(t *testing.T) {
 				PerPage: int32(n),
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().ListAuthors(mock.AnythingOfType("*gin.Context"), mock.Anything).
+				store.EXPECT().ListAuthors(mock.Anything, mock.Anything).
 					Return(authors, nil)
-				store.EXPECT().CountAuthors(mock.AnythingOfType("*gin.Context")).Return(int64(n), nil)
+				store.EXPECT().CountAuthors(mock.Anything, db.CountAuthorsParams{}).Return(int64(n), nil)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
 				store.AssertExpectations(t)
@@ -238,7 +258,7 @@ func TestListAuthorsAPI(t *testing.T) {
 				PerPage: int32(n),
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().ListAuthors(mock.AnythingOfType("*gin.Context"), mock.Anything).
+				store.EXPECT().ListAuthors(mock.Anything, mock.Anything).
 					Return([]db.Author{}, sql.ErrConnDone)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
@@ -255,7 +275,7 @@ func TestListAuthorsAPI(t *testing.T) {
 			buildStubs: func(store *mockdb.MockStore) {
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
-				store.AssertNotCalled(t, "ListAuthors", mock.AnythingOfType("*gin.Context"), mock.Anything)
+				store.AssertNotCalled(t, "ListAuthors", mock.Anything, mock.Anything)
 				require.Equal(t, http.StatusBadRequest, recorder.Code)
 			},
 		},
@@ -268,7 +288,7 @@ func TestListAuthorsAPI(t *testing.T) {
 			buildStubs: func(store *mockdb.MockStore) {
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
-				store.AssertNotCalled(t, "ListAuthors", mock.AnythingOfType("*gin.Context"), mock.Anything)
+				store.AssertNotCalled(t, "ListAuthors", mock.Anything, mock.Anything)
 				require.Equal(t, http.StatusBadRequest, recorder.Code)
 			},
 		},
@@ -279,9 +299,31 @@ func TestListAuthorsAPI(t *testing.T) {
 				PerPage: int32(n),
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().ListAuthors(mock.AnythingOfType("*gin.Context"), mock.Anything).
+				store.EXPECT().ListAuthors(mock.Anything, mock.Anything).
 					Return([]db.Author{}, nil)
-				store.EXPECT().CountAuthors(mock.AnythingOfType("*gin.Context")).Return(int64(n), nil)
+				store.EXPECT().CountAuthors(mock.Anything, db.CountAuthorsParams{}).Return(int64(n), nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
+				store.AssertExpectations(t)
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "UpdatedSince",
+			query: services.ListAuthorsReq{
+				UpdatedSince: time.Date(2024, 1, 2, 3, 4, 5, 6e6, time.UTC),
+				Page:         1,
+				PerPage:      int32(n),
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				since := sql.NullTime{Time: time.Date(2024, 1, 2, 3, 4, 5, 6e6+1, time.UTC), Valid: true}
+				store.EXPECT().ListAuthors(mock.Anything, db.ListAuthorsParams{
+					UpdatedSince: since,
+					Limit:        int64(n),
+				}).Return(authors, nil)
+				store.EXPECT().CountAuthors(mock.Anything, db.CountAuthorsParams{
+					UpdatedSince: since,
+				}).Return(int64(n), nil)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
 				store.AssertExpectations(t)
@@ -295,9 +337,9 @@ func TestListAuthorsAPI(t *testing.T) {
 				PerPage: int32(n),
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().ListAuthors(mock.AnythingOfType("*gin.Context"), mock.Anything).
+				store.EXPECT().ListAuthors(mock.Anything, mock.Anything).
 					Return([]db.Author{}, nil)
-				store.EXPECT().CountAuthors(mock.AnythingOfType("*gin.Context")).Return(0, sql.ErrConnDone)
+				store.EXPECT().CountAuthors(mock.Anything, db.CountAuthorsParams{}).Return(0, sql.ErrConnDone)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
 				store.AssertExpectations(t)
@@ -327,6 +369,9 @@ func TestListAuthorsAPI(t *testing.T) {
 			q := request.URL.Query()
 			q.Add("page", fmt.Sprintf("%d", tc.query.Page))
 			q.Add("per_page", fmt.Sprintf("%d", tc.query.PerPage))
+			if !tc.query.UpdatedSince.IsZero() {
+				q.Add("updated_since", tc.query.UpdatedSince.Format(time.RFC3339Nano))
+			}
 			request.URL.RawQuery = q.Encode()
 
 			router.ServeHTTP(recorder, request)
@@ -343,21 +388,30 @@ func TestUpdateAuthorAPI(t *testing.T) {
 	testCases := []struct {
 		name          string
 		id            int64
+		ifMatch       string
 		body          gin.H
 		buildStubs    func(store *mockdb.MockStore)
 		checkResponse func(recoder *httptest.ResponseRecorder, store *mockdb.MockStore)
 	}{
 		{
-			name: "Default",
-			id:   author.AuthorID,
+			name:    "Default",
+			id:      author.AuthorID,
+			ifMatch: `"1"`,
 			body: gin.H{
 				"first_name":  updatedAuthor.FirstName,
 				"last_name":   updatedAuthor.LastName,
 				"middle_name": updatedAuthor.MiddleName,
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().UpdateAuthor(mock.AnythingOfType("*gin.Context"), mock.Anything).
-					Return(db.Author{}, nil)
+				store.EXPECT().GetAuthor(mock.Anything, mock.Anything).
+					Return(author, nil)
+				expectExecTx(store)
+				store.EXPECT().UpdateAuthor(mock.Anything, mock.MatchedBy(func(arg db.UpdateAuthorParams) bool {
+					return arg.Version.Valid && arg.Version.Int64 == author.Version
+				})).
+					Return(author, nil)
+				store.EXPECT().BumpAuthorBookVersions(mock.Anything, author.AuthorID).
+					Return(nil)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
 				store.AssertExpectations(t)
@@ -365,15 +419,19 @@ func TestUpdateAuthorAPI(t *testing.T) {
 			},
 		},
 		{
-			name: "InternalError",
-			id:   updatedAuthor.AuthorID,
+			name:    "InternalError",
+			id:      updatedAuthor.AuthorID,
+			ifMatch: `"1"`,
 			body: gin.H{
 				"first_name":  updatedAuthor.FirstName,
 				"last_name":   updatedAuthor.LastName,
 				"middle_name": updatedAuthor.MiddleName,
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().UpdateAuthor(mock.AnythingOfType("*gin.Context"), mock.Anything).
+				store.EXPECT().GetAuthor(mock.Anything, mock.Anything).
+					Return(author, nil)
+				expectExecTx(store)
+				store.EXPECT().UpdateAuthor(mock.Anything, mock.Anything).
 					Return(db.Author{}, sql.ErrConnDone)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
@@ -382,15 +440,16 @@ func TestUpdateAuthorAPI(t *testing.T) {
 			},
 		},
 		{
-			name: "NotFound",
-			id:   updatedAuthor.AuthorID,
+			name:    "NotFound",
+			id:      updatedAuthor.AuthorID,
+			ifMatch: `"1"`,
 			body: gin.H{
 				"first_name":  updatedAuthor.FirstName,
 				"last_name":   updatedAuthor.LastName,
 				"middle_name": updatedAuthor.MiddleName,
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().UpdateAuthor(mock.AnythingOfType("*gin.Context"), mock.Anything).
+				store.EXPECT().GetAuthor(mock.Anything, mock.Anything).
 					Return(db.Author{}, db.ErrRecordNotFound)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
@@ -398,6 +457,35 @@ func TestUpdateAuthorAPI(t *testing.T) {
 				require.Equal(t, http.StatusBadRequest, recorder.Code)
 			},
 		},
+		{
+			name: "MissingIfMatch",
+			id:   author.AuthorID,
+			body: gin.H{
+				"first_name": updatedAuthor.FirstName,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
+				store.AssertExpectations(t)
+				require.Equal(t, http.StatusPreconditionRequired, recorder.Code)
+			},
+		},
+		{
+			name:    "StaleIfMatch",
+			id:      author.AuthorID,
+			ifMatch: `"0"`,
+			body: gin.H{
+				"first_name": updatedAuthor.FirstName,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAuthor(mock.Anything, mock.Anything).
+					Return(author, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
+				store.AssertExpectations(t)
+				require.Equal(t, http.StatusPreconditionFailed, recorder.Code)
+			},
+		},
 	}
 
 	for i := range testCases {
@@ -420,6 +508,78 @@ func TestUpdateAuthorAPI(t *testing.T) {
 			url := fmt.Sprintf("/authors/%d", tc.id)
 			request, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(data))
 			require.NoError(t, err)
+			if len(tc.ifMatch) > 0 {
+				request.Header.Set("If-Match", tc.ifMatch)
+			}
+
+			router.ServeHTTP(recorder, request)
+
+			tc.checkResponse(recorder, store)
+		})
+	}
+}
+
+func TestPatchAuthorAPI(t *testing.T) {
+	author := randomAuthor(t)
+
+	testCases := []struct {
+		name          string
+		body          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recoder *httptest.ResponseRecorder, store *mockdb.MockStore)
+	}{
+		{
+			name: "ClearMiddleName",
+			body: `{"middle_name": null}`,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAuthor(mock.Anything, mock.Anything).
+					Return(author, nil)
+				expectExecTx(store)
+				store.EXPECT().UpdateAuthor(mock.Anything, mock.MatchedBy(func(arg db.UpdateAuthorParams) bool {
+					return arg.MiddleName.Valid && arg.MiddleName.String == "" && !arg.FirstName.Valid && !arg.LastName.Valid
+				})).
+					Return(author, nil)
+				store.EXPECT().BumpAuthorBookVersions(mock.Anything, author.AuthorID).
+					Return(nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
+				store.AssertExpectations(t)
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "NullFirstName",
+			body: `{"first_name": null}`,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAuthor(mock.Anything, mock.Anything).
+					Return(author, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
+				store.AssertExpectations(t)
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			store := mockdb.NewMockStore(t)
+			tc.buildStubs(store)
+
+			handler := newTestHandler(t, store)
+
+			router := gin.Default()
+			router.PATCH("/authors/:id", handler.PatchAuthor)
+
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/authors/%d", author.AuthorID)
+			request, err := http.NewRequest(http.MethodPatch, url, strings.NewReader(tc.body))
+			require.NoError(t, err)
+			request.Header.Set("Content-Type", "application/merge-patch+json")
+			request.Header.Set("If-Match", `"1"`)
 
 			router.ServeHTTP(recorder, request)
 
@@ -434,15 +594,23 @@ func TestDeleteAuthorAPI(t *testing.T) {
 	testCases := []struct {
 		name          string
 		id            int64
+		ifMatch       string
 		buildStubs    func(store *mockdb.MockStore)
 		checkResponse func(recoder *httptest.ResponseRecorder, store *mockdb.MockStore)
 	}{
 		{
-			name: "Default",
-			id:   author.AuthorID,
+			name:    "Default",
+			id:      author.AuthorID,
+			ifMatch: `"1"`,
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().DeleteAuthor(mock.AnythingOfType("*gin.Context"), mock.Anything).
-					Return(nil)
+				store.EXPECT().GetAuthor(mock.Anything, mock.Anything).
+					Return(author, nil)
+				expectExecTx(store)
+				store.EXPECT().DeleteAuthor(mock.Anything, db.DeleteAuthorParams{
+					AuthorID: author.AuthorID,
+					Version:  sql.NullInt64{Int64: author.Version, Valid: true},
+				}).
+					Return(1, nil)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
 				store.AssertExpectations(t)
@@ -450,17 +618,31 @@ func TestDeleteAuthorAPI(t *testing.T) {
 			},
 		},
 		{
-			name: "InternalError",
-			id:   author.AuthorID,
+			name:    "InternalError",
+			id:      author.AuthorID,
+			ifMatch: `"1"`,
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().DeleteAuthor(mock.AnythingOfType("*gin.Context"), mock.Anything).
-					Return(sql.ErrConnDone)
+				store.EXPECT().GetAuthor(mock.Anything, mock.Anything).
+					Return(author, nil)
+				expectExecTx(store)
+				store.EXPECT().DeleteAuthor(mock.Anything, mock.Anything).
+					Return(0, sql.ErrConnDone)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
 				store.AssertExpectations(t)
 				require.Equal(t, http.StatusInternalServerError, recorder.Code)
 			},
 		},
+		{
+			name: "MissingIfMatch",
+			id:   author.AuthorID,
+			buildStubs: func(store *mockdb.MockStore) {
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
+				store.AssertExpectations(t)
+				require.Equal(t, http.StatusPreconditionRequired, recorder.Code)
+			},
+		},
 	}
 
 	for i := range testCases {
@@ -480,6 +662,9 @@ func TestDeleteAuthorAPI(t *testing.T) {
 			url := fmt.Sprintf("/authors/%d", tc.id)
 			request, err := http.NewRequest(http.MethodDelete, url, nil)
 			require.NoError(t, err)
+			if len(tc.ifMatch) > 0 {
+				request.Header.Set("If-Match", tc.ifMatch)
+			}
 
 			router.ServeHTTP(recorder, request)
 
@@ -494,5 +679,6 @@ func randomAuthor(t *testing.T) db.Author {
 		FirstName:  util.RandomString(12),
 		LastName:   util.RandomString(16),
 		MiddleName: util.RandomString(6),
+		Version:    1,
 	}
 }
@@ -4,7 +4,9 @@ import (
 	"errors"
 	"net/http"
 
+	"github.com/atsuyaourt/xyz-books/internal/auth"
 	db "github.com/atsuyaourt/xyz-books/internal/db/sqlc"
+	_ "github.com/atsuyaourt/xyz-books/internal/models" // types named in the swag annotations
 	"github.com/atsuyaourt/xyz-books/internal/services"
 	"github.com/gin-gonic/gin"
 )
@@ -17,6 +19,7 @@ import (
 //	@Produce	json
 //	@Param		req	body		services.CreateBookReq	true	"Create book parameters"
 //	@Success	201	{object}	models.Book
+//	@Failure	409
 //	@Router		/books [post]
 func (h *DefaultHandler) CreateBook(ctx *gin.Context) {
 	var req services.CreateBookReq
@@ -28,6 +31,10 @@ func (h *DefaultHandler) CreateBook(ctx *gin.Context) {
 
 	res, err := h.service.CreateBook(ctx, req)
 	if err != nil {
+		if errors.Is(err, db.ErrRecordDeleted) {
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
@@ -45,9 +52,13 @@ type getBookReq struct {
 //	@Tags		books
 //	@Accept		json
 //	@Produce	json
-//	@Param		isbn	path		string	true	"ISBN-13"
-//	@Success	200		{object}	models.Book
+//	@Param		isbn			path		string	true	"ISBN-13"
+//	@Param		include_deleted	query		bool	false	"also find a deleted book, admins only"
+//	@Param		If-None-Match	header		string	false	"ETag of a cached copy"
+//	@Success	200				{object}	models.Book
+//	@Success	304
 //	@Router		/books/{isbn} [get]
+//	@Deprecated
 func (h *DefaultHandler) GetBook(ctx *gin.Context) {
 	var req getBookReq
 	if err := ctx.ShouldBindUri(&req); err != nil {
@@ -55,8 +66,18 @@ func (h *DefaultHandler) GetBook(ctx *gin.Context) {
 		return
 	}
 
-	res, err := h.service.GetBook(ctx, req.ISBN13)
+	var query includeDeletedQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	res, err := h.service.GetBook(ctx, req.ISBN13, query.IncludeDeleted)
 	if err != nil {
+		if errors.Is(err, auth.ErrAdminRequired) {
+			ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+			return
+		}
 		if errors.Is(err, db.ErrRecordNotFound) {
 			ctx.JSON(http.StatusBadRequest, errorResponse(errors.New("book not found")))
 			return
@@ -65,6 +86,10 @@ func (h *DefaultHandler) GetBook(ctx *gin.Context) {
 		return
 	}
 
+	if notModified(ctx, res.Version) {
+		return
+	}
+
 	ctx.JSON(http.StatusOK, res)
 }
 
@@ -77,6 +102,7 @@ func (h *DefaultHandler) GetBook(ctx *gin.Context) {
 //	@Param		req	query		services.ListBooksReq	false	"List books parameters"
 //	@Success	200	{object}	models.PaginatedBooks
 //	@Router		/books [get]
+//	@Deprecated
 func (h *DefaultHandler) ListBooks(ctx *gin.Context) {
 	var req services.ListBooksReq
 	if err := ctx.ShouldBindQuery(&req); err != nil {
@@ -86,6 +112,10 @@ func (h *DefaultHandler) ListBooks(ctx *gin.Context) {
 
 	res, err := h.service.ListBooks(ctx, req)
 	if err != nil {
+		if errors.Is(err, auth.ErrAdminRequired) {
+			ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
@@ -103,9 +133,12 @@ type updateBookUri struct {
 //	@Tags		books
 //	@Accept		json
 //	@Produce	json
-//	@Param		isbn	path		string			true	"ISBN-13"
-//	@Param		req		body		services.UpdateBookReq	true	"Update book parameters"
-//	@Success	200		{object}	models.Book
+//	@Param		isbn		path		string					true	"ISBN-13"
+//	@Param		If-Match	header		string					true	"ETag of the book being changed"
+//	@Param		req			body		services.UpdateBookReq	true	"Update book parameters"
+//	@Success	200			{object}	models.Book
+//	@Failure	412
+//	@Failure	428
 //	@Router		/books/{isbn} [put]
 func (h *DefaultHandler) UpdateBook(ctx *gin.Context) {
 	var uri updateBookUri
@@ -120,8 +153,17 @@ func (h *DefaultHandler) UpdateBook(ctx *gin.Context) {
 		return
 	}
 
-	res, err := h.service.UpdateBook(ctx, uri.ISBN13, req)
+	version, ok := h.bookVersion(ctx, uri.ISBN13)
+	if !ok {
+		return
+	}
+
+	res, err := h.service.UpdateBook(ctx, uri.ISBN13, version, req)
 	if err != nil {
+		if errors.Is(err, db.ErrVersionChanged) {
+			ctx.JSON(http.StatusPreconditionFailed, errorResponse(errVersionMismatch))
+			return
+		}
 		if errors.Is(err, db.ErrRecordNotFound) {
 			ctx.JSON(http.StatusBadRequest, errorResponse(errors.New("book not found")))
 			return
@@ -130,6 +172,94 @@ func (h *DefaultHandler) UpdateBook(ctx *gin.Context) {
 		return
 	}
 
+	ctx.Header("ETag", entityTag(res.Version))
+	ctx.JSON(http.StatusOK, res)
+}
+
+// bookVersion checks the If-Match header of a write against the current
+// book and returns the version the write must still find
+func (h *DefaultHandler) bookVersion(ctx *gin.Context, isbn13 string) (int64, bool) {
+	if !requireIfMatch(ctx) {
+		return 0, false
+	}
+
+	current, err := h.service.GetBook(ctx, isbn13, false)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(errors.New("book not found")))
+			return 0, false
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return 0, false
+	}
+
+	if !ifMatch(ctx, current.Version) {
+		return 0, false
+	}
+
+	return current.Version, true
+}
+
+type patchBookUri struct {
+	ISBN13 string `uri:"isbn" binding:"required,isbn13"`
+}
+
+// PatchBook
+//
+//	@Summary		Patch book
+//	@Description	Applies a JSON merge patch, members left out are unchanged and null clears a field
+//	@Tags			books
+//	@Accept			application/merge-patch+json
+//	@Produce		json
+//	@Param			isbn		path		string					true	"ISBN-13"
+//	@Param			If-Match	header		string					true	"ETag of the book being changed"
+//	@Param			req			body		services.PatchBookReq	true	"Patch book parameters"
+//	@Success		200			{object}	models.Book
+//	@Failure		412
+//	@Failure		415
+//	@Failure		428
+//	@Router			/books/{isbn} [patch]
+func (h *DefaultHandler) PatchBook(ctx *gin.Context) {
+	var uri patchBookUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if !requireMergePatch(ctx) {
+		return
+	}
+
+	var req services.PatchBookReq
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	version, ok := h.bookVersion(ctx, uri.ISBN13)
+	if !ok {
+		return
+	}
+
+	res, err := h.service.PatchBook(ctx, uri.ISBN13, version, req)
+	if err != nil {
+		if errors.Is(err, db.ErrVersionChanged) {
+			ctx.JSON(http.StatusPreconditionFailed, errorResponse(errVersionMismatch))
+			return
+		}
+		if errors.Is(err, services.ErrInvalidPatch) || errors.Is(err, db.ErrMissingISBN) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		if errors.Is(err, db.ErrRecordNotFound) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(errors.New("book not found")))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.Header("ETag", entityTag(res.Version))
 	ctx.JSON(http.StatusOK, res)
 }
 
@@ -143,8 +273,11 @@ type deleteBookUri struct {
 //	@Tags		books
 //	@Accept		json
 //	@Produce	json
-//	@Param		isbn	path	string	true	"ISBN-13"
+//	@Param		isbn		path	string	true	"ISBN-13"
+//	@Param		If-Match	header	string	true	"ETag of the book being deleted"
 //	@Success	204
+//	@Failure	412
+//	@Failure	428
 //	@Router		/books/{isbn} [delete]
 func (h *DefaultHandler) DeleteBook(ctx *gin.Context) {
 	var req deleteBookUri
@@ -153,11 +286,58 @@ func (h *DefaultHandler) DeleteBook(ctx *gin.Context) {
 		return
 	}
 
-	err := h.service.DeleteBook(ctx, req.ISBN13)
+	version, ok := h.bookVersion(ctx, req.ISBN13)
+	if !ok {
+		return
+	}
+
+	err := h.service.DeleteBook(ctx, req.ISBN13, version)
 	if err != nil {
+		if errors.Is(err, db.ErrVersionChanged) {
+			ctx.JSON(http.StatusPreconditionFailed, errorResponse(errVersionMismatch))
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
 
 	ctx.JSON(http.StatusNoContent, nil)
 }
+
+type restoreBookUri struct {
+	ISBN13 string `uri:"isbn" binding:"required,isbn13"`
+}
+
+// RestoreBook
+//
+//	@Summary	Restore a deleted book
+//	@Tags		books
+//	@Accept		json
+//	@Produce	json
+//	@Param		isbn	path		string	true	"ISBN-13"
+//	@Success	200		{object}	models.Book
+//	@Failure	409
+//	@Router		/books/{isbn}/restore [post]
+func (h *DefaultHandler) RestoreBook(ctx *gin.Context) {
+	var req restoreBookUri
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	res, err := h.service.RestoreBook(ctx, req.ISBN13)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(errors.New("book not found")))
+			return
+		}
+		if errors.Is(err, db.ErrNotDeleted) {
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
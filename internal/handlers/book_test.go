@@ -9,9 +9,12 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/atsuyaourt/xyz-books/internal/auth"
 	db "github.com/atsuyaourt/xyz-books/internal/db/sqlc"
 	mockdb "github.com/atsuyaourt/xyz-books/internal/mocks/db"
+	"github.com/atsuyaourt/xyz-books/internal/models"
 	"github.com/atsuyaourt/xyz-books/internal/services"
 	"github.com/atsuyaourt/xyz-books/internal/util"
 	"github.com/gin-gonic/gin"
@@ -51,7 +54,7 @@ func TestCreateBookAPI(t *testing.T) {
 				"publisher": publisher,
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().CreateBookTx(mock.AnythingOfType("*gin.Context"), mock.Anything).
+				store.EXPECT().CreateBookTx(mock.Anything, mock.Anything).
 					Return(db.Book{}, nil)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
@@ -59,6 +62,178 @@ func TestCreateBookAPI(t *testing.T) {
 				require.Equal(t, http.StatusCreated, recorder.Code)
 			},
 		},
+		{
+			name: "WithMetadata",
+			body: gin.H{
+				"book": gin.H{
+					"title":            book.Title,
+					"isbn13":           book.Isbn13.String,
+					"price":            book.Price,
+					"publication_year": book.PublicationYear,
+					"language":         "en-us",
+					"format":           "paperback",
+					"page_count":       320,
+					"series_name":      "Discworld",
+					"series_number":    2,
+				},
+				"authors":   authors,
+				"publisher": publisher,
+				"subjects":  []string{" Fantasy ", "fantasy", "Humor"},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateBookTx(mock.Anything, mock.MatchedBy(func(arg db.CreateBookTxParams) bool {
+					return arg.Book.Language.String == "en-US" &&
+						arg.Book.Format.String == "paperback" &&
+						arg.Book.PageCount.Int64 == 320 &&
+						arg.Book.SeriesNumber.Int64 == 2 &&
+						len(arg.Subjects) == 2 && arg.Subjects[0] == "Fantasy" && arg.Subjects[1] == "Humor"
+				})).
+					Return(db.Book{}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
+				store.AssertExpectations(t)
+				require.Equal(t, http.StatusCreated, recorder.Code)
+			},
+		},
+		{
+			name: "WithContributors",
+			body: gin.H{
+				"book": gin.H{
+					"title":            book.Title,
+					"isbn13":           book.Isbn13.String,
+					"price":            book.Price,
+					"publication_year": book.PublicationYear,
+				},
+				"authors": authors[:1],
+				"contributors": []gin.H{
+					{"name": authors[1], "role": "illustrator"},
+					{"name": authors[2], "role": "author"},
+				},
+				"publisher": publisher,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateBookTx(mock.Anything, mock.MatchedBy(func(arg db.CreateBookTxParams) bool {
+					return len(arg.Authors) == 1 &&
+						len(arg.Contributors) == 2 &&
+						arg.Contributors[0].Role == "illustrator" &&
+						arg.Contributors[1].Role == db.ContributorRoleAuthor
+				})).
+					Return(db.Book{}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
+				store.AssertExpectations(t)
+				require.Equal(t, http.StatusCreated, recorder.Code)
+
+				var res models.Book
+				err := json.Unmarshal(recorder.Body.Bytes(), &res)
+				require.NoError(t, err)
+				require.Equal(t, []string{authors[0], authors[2]}, res.Authors)
+				require.Equal(t, []models.Contributor{
+					{Name: authors[0], Role: "author"},
+					{Name: authors[1], Role: "illustrator"},
+					{Name: authors[2], Role: "author"},
+				}, res.Contributors)
+			},
+		},
+		{
+			name: "InvalidContributorRole",
+			body: gin.H{
+				"book": gin.H{
+					"title":            book.Title,
+					"isbn13":           book.Isbn13.String,
+					"price":            book.Price,
+					"publication_year": book.PublicationYear,
+				},
+				"contributors": []gin.H{
+					{"name": authors[0], "role": "narrator"},
+				},
+				"publisher": publisher,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
+				store.AssertExpectations(t)
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "NoAuthors",
+			body: gin.H{
+				"book": gin.H{
+					"title":            book.Title,
+					"isbn13":           book.Isbn13.String,
+					"price":            book.Price,
+					"publication_year": book.PublicationYear,
+				},
+				"publisher": publisher,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
+				store.AssertExpectations(t)
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidLanguage",
+			body: gin.H{
+				"book": gin.H{
+					"title":            book.Title,
+					"isbn13":           book.Isbn13.String,
+					"price":            book.Price,
+					"publication_year": book.PublicationYear,
+					"language":         "not a language",
+				},
+				"authors":   authors,
+				"publisher": publisher,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
+				store.AssertExpectations(t)
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidFormat",
+			body: gin.H{
+				"book": gin.H{
+					"title":            book.Title,
+					"isbn13":           book.Isbn13.String,
+					"price":            book.Price,
+					"publication_year": book.PublicationYear,
+					"format":           "scroll",
+				},
+				"authors":   authors,
+				"publisher": publisher,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
+				store.AssertExpectations(t)
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "SeriesNumberWithoutName",
+			body: gin.H{
+				"book": gin.H{
+					"title":            book.Title,
+					"isbn13":           book.Isbn13.String,
+					"price":            book.Price,
+					"publication_year": book.PublicationYear,
+					"series_number":    1,
+				},
+				"authors":   authors,
+				"publisher": publisher,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
+				store.AssertExpectations(t)
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
 		{
 			name: "InternalError",
 			body: gin.H{
@@ -73,7 +248,7 @@ func TestCreateBookAPI(t *testing.T) {
 				"publisher": publisher,
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().CreateBookTx(mock.AnythingOfType("*gin.Context"), mock.Anything).
+				store.EXPECT().CreateBookTx(mock.Anything, mock.Anything).
 					Return(db.Book{}, sql.ErrConnDone)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
@@ -127,6 +302,8 @@ func TestGetBookAPI(t *testing.T) {
 	testCases := []struct {
 		name          string
 		isbn          string
+		ifNoneMatch   string
+		admin         bool
 		buildStubs    func(store *mockdb.MockStore)
 		checkResponse func(recoder *httptest.ResponseRecorder, store *mockdb.MockStore)
 	}{
@@ -134,13 +311,64 @@ func TestGetBookAPI(t *testing.T) {
 			name: "Default",
 			isbn: book.Isbn13.String,
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().GetBookByISBN(mock.AnythingOfType("*gin.Context"), mock.Anything).
+				store.EXPECT().GetBookByISBN(mock.Anything, mock.Anything).
 					Return(db.GetBookByISBNRow{
 						Book:          book,
 						Authors:       authorNames,
 						PublisherName: publisherName,
 					}, nil)
 			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
+				store.AssertExpectations(t)
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Equal(t, `"1"`, recorder.Header().Get("ETag"))
+
+				var res models.Book
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+				require.Equal(t, "/books/"+book.Isbn13.String+"/cover.svg", res.ImageUrl)
+			},
+		},
+		{
+			name: "ImageUrl",
+			isbn: book.Isbn13.String,
+			buildStubs: func(store *mockdb.MockStore) {
+				b := book
+				b.ImageUrl = sql.NullString{String: "https://example.com/cover.jpg", Valid: true}
+				store.EXPECT().GetBookByISBN(mock.Anything, mock.Anything).
+					Return(db.GetBookByISBNRow{Book: b}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
+				store.AssertExpectations(t)
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var res models.Book
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+				require.Equal(t, "https://example.com/cover.jpg", res.ImageUrl)
+			},
+		},
+		{
+			name:        "NotModified",
+			isbn:        book.Isbn13.String,
+			ifNoneMatch: `"0", W/"1"`,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetBookByISBN(mock.Anything, mock.Anything).
+					Return(db.GetBookByISBNRow{Book: book}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
+				store.AssertExpectations(t)
+				require.Equal(t, http.StatusNotModified, recorder.Code)
+				require.Equal(t, `"1"`, recorder.Header().Get("ETag"))
+				require.Empty(t, recorder.Body.Bytes())
+			},
+		},
+		{
+			name:        "Modified",
+			isbn:        book.Isbn13.String,
+			ifNoneMatch: `"0"`,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetBookByISBN(mock.Anything, mock.Anything).
+					Return(db.GetBookByISBNRow{Book: book}, nil)
+			},
 			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
 				store.AssertExpectations(t)
 				require.Equal(t, http.StatusOK, recorder.Code)
@@ -160,7 +388,7 @@ func TestGetBookAPI(t *testing.T) {
 			name: "InternalError",
 			isbn: book.Isbn13.String,
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().GetBookByISBN(mock.AnythingOfType("*gin.Context"), mock.Anything).
+				store.EXPECT().GetBookByISBN(mock.Anything, mock.Anything).
 					Return(db.GetBookByISBNRow{}, sql.ErrConnDone)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
@@ -172,7 +400,7 @@ func TestGetBookAPI(t *testing.T) {
 			name: "NotFound",
 			isbn: book.Isbn13.String,
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().GetBookByISBN(mock.AnythingOfType("*gin.Context"), mock.Anything).
+				store.EXPECT().GetBookByISBN(mock.Anything, mock.Anything).
 					Return(db.GetBookByISBNRow{}, db.ErrRecordNotFound)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
@@ -180,6 +408,40 @@ func TestGetBookAPI(t *testing.T) {
 				require.Equal(t, http.StatusBadRequest, recorder.Code)
 			},
 		},
+		{
+			name:  "IncludeDeleted",
+			isbn:  book.Isbn13.String + "?include_deleted=true",
+			admin: true,
+			buildStubs: func(store *mockdb.MockStore) {
+				deletedBook := book
+				deletedBook.DeletedAt = sql.NullTime{Time: time.Now(), Valid: true}
+				store.EXPECT().GetBookByISBN(mock.Anything, mock.MatchedBy(func(arg db.GetBookByISBNParams) bool {
+					return arg.IncludeDeleted
+				})).
+					Return(db.GetBookByISBNRow{
+						Book:          deletedBook,
+						Authors:       authorNames,
+						PublisherName: publisherName,
+					}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
+				store.AssertExpectations(t)
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var gotBook models.Book
+				err := json.Unmarshal(recorder.Body.Bytes(), &gotBook)
+				require.NoError(t, err)
+				require.NotNil(t, gotBook.DeletedAt)
+			},
+		},
+		{
+			name:       "IncludeDeletedUnauthorized",
+			isbn:       book.Isbn13.String + "?include_deleted=true",
+			buildStubs: func(store *mockdb.MockStore) {},
+			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
 	}
 
 	for i := range testCases {
@@ -191,7 +453,7 @@ func TestGetBookAPI(t *testing.T) {
 
 			handler := newTestHandler(t, store)
 
-			router := gin.Default()
+			router := newTestRouter()
 			router.GET("/books/:isbn", handler.GetBook)
 
 			recorder := httptest.NewRecorder()
@@ -199,6 +461,12 @@ func TestGetBookAPI(t *testing.T) {
 			url := fmt.Sprintf("/books/%s", tc.isbn)
 			request, err := http.NewRequest(http.MethodGet, url, nil)
 			require.NoError(t, err)
+			if len(tc.ifNoneMatch) > 0 {
+				request.Header.Set("If-None-Match", tc.ifNoneMatch)
+			}
+			if tc.admin {
+				request.Header.Set(auth.APIKeyHeader, testAdminKey)
+			}
 
 			router.ServeHTTP(recorder, request)
 
@@ -227,9 +495,32 @@ func TestListBooksAPI(t *testing.T) {
 				PerPage: int32(n),
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().ListBooks(mock.AnythingOfType("*gin.Context"), mock.Anything).
+				store.EXPECT().ListBooks(mock.Anything, mock.Anything).
 					Return([]db.ListBooksRow{}, nil)
-				store.EXPECT().CountBooks(mock.AnythingOfType("*gin.Context"), mock.Anything).Return(int64(n), nil)
+				store.EXPECT().CountBooks(mock.Anything, mock.Anything).Return(int64(n), nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
+				store.AssertExpectations(t)
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "PublicationYear",
+			query: services.ListBooksReq{
+				MinPublicationYear: 1990,
+				MaxPublicationYear: 2000,
+				Page:               1,
+				PerPage:            int32(n),
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListBooks(mock.Anything, mock.MatchedBy(func(arg db.ListBooksParams) bool {
+					return arg.MinPublicationYear.Int64 == 1990 && arg.MaxPublicationYear.Int64 == 2000
+				})).
+					Return([]db.ListBooksRow{}, nil)
+				store.EXPECT().CountBooks(mock.Anything, mock.MatchedBy(func(arg db.CountBooksParams) bool {
+					return arg.MinPublicationYear.Int64 == 1990 && arg.MaxPublicationYear.Int64 == 2000
+				})).
+					Return(int64(n), nil)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
 				store.AssertExpectations(t)
@@ -243,7 +534,7 @@ func TestListBooksAPI(t *testing.T) {
 				PerPage: int32(n),
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().ListBooks(mock.AnythingOfType("*gin.Context"), mock.Anything).
+				store.EXPECT().ListBooks(mock.Anything, mock.Anything).
 					Return([]db.ListBooksRow{}, sql.ErrConnDone)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
@@ -260,7 +551,7 @@ func TestListBooksAPI(t *testing.T) {
 			buildStubs: func(store *mockdb.MockStore) {
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
-				store.AssertNotCalled(t, "ListBooks", mock.AnythingOfType("*gin.Context"), mock.Anything)
+				store.AssertNotCalled(t, "ListBooks", mock.Anything, mock.Anything)
 				require.Equal(t, http.StatusBadRequest, recorder.Code)
 			},
 		},
@@ -273,7 +564,7 @@ func TestListBooksAPI(t *testing.T) {
 			buildStubs: func(store *mockdb.MockStore) {
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
-				store.AssertNotCalled(t, "ListBooks", mock.AnythingOfType("*gin.Context"), mock.Anything)
+				store.AssertNotCalled(t, "ListBooks", mock.Anything, mock.Anything)
 				require.Equal(t, http.StatusBadRequest, recorder.Code)
 			},
 		},
@@ -284,9 +575,9 @@ func TestListBooksAPI(t *testing.T) {
 				PerPage: int32(n),
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().ListBooks(mock.AnythingOfType("*gin.Context"), mock.Anything).
+				store.EXPECT().ListBooks(mock.Anything, mock.Anything).
 					Return([]db.ListBooksRow{}, nil)
-				store.EXPECT().CountBooks(mock.AnythingOfType("*gin.Context"), mock.Anything).Return(0, nil)
+				store.EXPECT().CountBooks(mock.Anything, mock.Anything).Return(0, nil)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
 				store.AssertExpectations(t)
@@ -300,9 +591,9 @@ func TestListBooksAPI(t *testing.T) {
 				PerPage: int32(n),
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().ListBooks(mock.AnythingOfType("*gin.Context"), mock.Anything).
+				store.EXPECT().ListBooks(mock.Anything, mock.Anything).
 					Return([]db.ListBooksRow{}, nil)
-				store.EXPECT().CountBooks(mock.AnythingOfType("*gin.Context"), mock.Anything).Return(0, sql.ErrConnDone)
+				store.EXPECT().CountBooks(mock.Anything, mock.Anything).Return(0, sql.ErrConnDone)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
 				store.AssertExpectations(t)
@@ -333,6 +624,12 @@ func TestListBooksAPI(t *testing.T) {
 			q := request.URL.Query()
 			q.Add("page", fmt.Sprintf("%d", tc.query.Page))
 			q.Add("per_page", fmt.Sprintf("%d", tc.query.PerPage))
+			if tc.query.MinPublicationYear > 0 {
+				q.Add("min_publication_year", fmt.Sprintf("%d", tc.query.MinPublicationYear))
+			}
+			if tc.query.MaxPublicationYear > 0 {
+				q.Add("max_publication_year", fmt.Sprintf("%d", tc.query.MaxPublicationYear))
+			}
 			request.URL.RawQuery = q.Encode()
 
 			router.ServeHTTP(recorder, request)
@@ -350,23 +647,25 @@ func TestUpdateBookAPI(t *testing.T) {
 	testCases := []struct {
 		name          string
 		isbn          string
+		ifMatch       string
 		body          gin.H
 		buildStubs    func(store *mockdb.MockStore)
 		checkResponse func(recoder *httptest.ResponseRecorder, store *mockdb.MockStore)
 	}{
 		{
-			name: "Default",
-			isbn: book.Isbn13.String,
+			name:    "Default",
+			isbn:    book.Isbn13.String,
+			ifMatch: `"1"`,
 			body: gin.H{
 				"title": updatedBook.Title,
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().UpdateBookByISBN(mock.AnythingOfType("*gin.Context"), mock.MatchedBy(func(arg db.UpdateBookByISBNParams) bool {
-					return !arg.NewIsbn13.Valid && !arg.NewIsbn10.Valid
+				store.EXPECT().UpdateBookTx(mock.Anything, mock.MatchedBy(func(arg db.UpdateBookTxParams) bool {
+					return !arg.Book.NewIsbn13.Valid && !arg.Book.NewIsbn10.Valid
 				})).
 					Return(db.Book{}, nil)
-				store.EXPECT().GetBookByISBN(mock.AnythingOfType("*gin.Context"), mock.Anything).
-					Return(db.GetBookByISBNRow{}, nil)
+				store.EXPECT().GetBookByISBN(mock.Anything, mock.Anything).
+					Return(db.GetBookByISBNRow{Book: book}, nil)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
 				store.AssertExpectations(t)
@@ -374,19 +673,20 @@ func TestUpdateBookAPI(t *testing.T) {
 			},
 		},
 		{
-			name: "UpdateISBN13",
-			isbn: book.Isbn13.String,
+			name:    "UpdateISBN13",
+			isbn:    book.Isbn13.String,
+			ifMatch: `"1"`,
 			body: gin.H{
 				"title":  updatedBook.Title,
 				"isbn13": updatedBook.Isbn13.String,
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().UpdateBookByISBN(mock.AnythingOfType("*gin.Context"), mock.MatchedBy(func(arg db.UpdateBookByISBNParams) bool {
-					return arg.NewIsbn13.Valid && arg.NewIsbn13.String == updatedBook.Isbn13.String && !arg.NewIsbn10.Valid
+				store.EXPECT().UpdateBookTx(mock.Anything, mock.MatchedBy(func(arg db.UpdateBookTxParams) bool {
+					return arg.Book.NewIsbn13.Valid && arg.Book.NewIsbn13.String == updatedBook.Isbn13.String && !arg.Book.NewIsbn10.Valid
 				})).
 					Return(db.Book{}, nil)
-				store.EXPECT().GetBookByISBN(mock.AnythingOfType("*gin.Context"), mock.Anything).
-					Return(db.GetBookByISBNRow{}, nil)
+				store.EXPECT().GetBookByISBN(mock.Anything, mock.Anything).
+					Return(db.GetBookByISBNRow{Book: book}, nil)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
 				store.AssertExpectations(t)
@@ -394,19 +694,20 @@ func TestUpdateBookAPI(t *testing.T) {
 			},
 		},
 		{
-			name: "UpdateISBN10",
-			isbn: book.Isbn13.String,
+			name:    "UpdateISBN10",
+			isbn:    book.Isbn13.String,
+			ifMatch: `"1"`,
 			body: gin.H{
 				"title":  updatedBook.Title,
 				"isbn10": updatedBook.Isbn10.String,
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().UpdateBookByISBN(mock.AnythingOfType("*gin.Context"), mock.MatchedBy(func(arg db.UpdateBookByISBNParams) bool {
-					return !arg.NewIsbn13.Valid && arg.NewIsbn10.Valid && arg.NewIsbn10.String == updatedBook.Isbn10.String
+				store.EXPECT().UpdateBookTx(mock.Anything, mock.MatchedBy(func(arg db.UpdateBookTxParams) bool {
+					return !arg.Book.NewIsbn13.Valid && arg.Book.NewIsbn10.Valid && arg.Book.NewIsbn10.String == updatedBook.Isbn10.String
 				})).
 					Return(db.Book{}, nil)
-				store.EXPECT().GetBookByISBN(mock.AnythingOfType("*gin.Context"), mock.Anything).
-					Return(db.GetBookByISBNRow{}, nil)
+				store.EXPECT().GetBookByISBN(mock.Anything, mock.Anything).
+					Return(db.GetBookByISBNRow{Book: book}, nil)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
 				store.AssertExpectations(t)
@@ -414,20 +715,21 @@ func TestUpdateBookAPI(t *testing.T) {
 			},
 		},
 		{
-			name: "UpdateBothISBN13AndISBN10",
-			isbn: book.Isbn13.String,
+			name:    "UpdateBothISBN13AndISBN10",
+			isbn:    book.Isbn13.String,
+			ifMatch: `"1"`,
 			body: gin.H{
 				"title":  updatedBook.Title,
 				"isbn13": updatedBook.Isbn13.String,
 				"isbn10": updatedBook.Isbn10.String,
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().UpdateBookByISBN(mock.AnythingOfType("*gin.Context"), mock.MatchedBy(func(arg db.UpdateBookByISBNParams) bool {
-					return arg.NewIsbn13.Valid && arg.NewIsbn13.String == updatedBook.Isbn13.String && arg.NewIsbn10.Valid && arg.NewIsbn10.String == updatedBook.Isbn10.String
+				store.EXPECT().UpdateBookTx(mock.Anything, mock.MatchedBy(func(arg db.UpdateBookTxParams) bool {
+					return arg.Book.NewIsbn13.Valid && arg.Book.NewIsbn13.String == updatedBook.Isbn13.String && arg.Book.NewIsbn10.Valid && arg.Book.NewIsbn10.String == updatedBook.Isbn10.String
 				})).
 					Return(db.Book{}, nil)
-				store.EXPECT().GetBookByISBN(mock.AnythingOfType("*gin.Context"), mock.Anything).
-					Return(db.GetBookByISBNRow{}, nil)
+				store.EXPECT().GetBookByISBN(mock.Anything, mock.Anything).
+					Return(db.GetBookByISBNRow{Book: book}, nil)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
 				store.AssertExpectations(t)
@@ -435,20 +737,21 @@ func TestUpdateBookAPI(t *testing.T) {
 			},
 		},
 		{
-			name: "UpdateMismatchedISBN13AndISBN10",
-			isbn: book.Isbn13.String,
+			name:    "UpdateMismatchedISBN13AndISBN10",
+			isbn:    book.Isbn13.String,
+			ifMatch: `"1"`,
 			body: gin.H{
 				"title":  updatedBook.Title,
 				"isbn13": updatedBook.Isbn13.String,
 				"isbn10": book2.Isbn10.String,
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().UpdateBookByISBN(mock.AnythingOfType("*gin.Context"), mock.MatchedBy(func(arg db.UpdateBookByISBNParams) bool {
-					return !arg.NewIsbn13.Valid && !arg.NewIsbn10.Valid
+				store.EXPECT().UpdateBookTx(mock.Anything, mock.MatchedBy(func(arg db.UpdateBookTxParams) bool {
+					return !arg.Book.NewIsbn13.Valid && !arg.Book.NewIsbn10.Valid
 				})).
 					Return(db.Book{}, nil)
-				store.EXPECT().GetBookByISBN(mock.AnythingOfType("*gin.Context"), mock.Anything).
-					Return(db.GetBookByISBNRow{}, nil)
+				store.EXPECT().GetBookByISBN(mock.Anything, mock.Anything).
+					Return(db.GetBookByISBNRow{Book: book}, nil)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
 				store.AssertExpectations(t)
@@ -456,8 +759,9 @@ func TestUpdateBookAPI(t *testing.T) {
 			},
 		},
 		{
-			name: "InvalidISBN13",
-			isbn: "INVALIDISBN13",
+			name:    "InvalidISBN13",
+			isbn:    "INVALIDISBN13",
+			ifMatch: `"1"`,
 			body: gin.H{
 				"title": updatedBook.Title,
 			},
@@ -469,13 +773,16 @@ func TestUpdateBookAPI(t *testing.T) {
 			},
 		},
 		{
-			name: "InternalError",
-			isbn: book.Isbn13.String,
+			name:    "InternalError",
+			isbn:    book.Isbn13.String,
+			ifMatch: `"1"`,
 			body: gin.H{
 				"title": updatedBook.Title,
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().UpdateBookByISBN(mock.AnythingOfType("*gin.Context"), mock.Anything).
+				store.EXPECT().GetBookByISBN(mock.Anything, mock.Anything).
+					Return(db.GetBookByISBNRow{Book: book}, nil)
+				store.EXPECT().UpdateBookTx(mock.Anything, mock.Anything).
 					Return(db.Book{}, sql.ErrConnDone)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
@@ -484,20 +791,68 @@ func TestUpdateBookAPI(t *testing.T) {
 			},
 		},
 		{
-			name: "NotFound",
-			isbn: book.Isbn13.String,
+			name:    "NotFound",
+			isbn:    book.Isbn13.String,
+			ifMatch: `"1"`,
 			body: gin.H{
 				"title": updatedBook.Title,
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().UpdateBookByISBN(mock.AnythingOfType("*gin.Context"), mock.Anything).
-					Return(db.Book{}, db.ErrRecordNotFound)
+				store.EXPECT().GetBookByISBN(mock.Anything, mock.Anything).
+					Return(db.GetBookByISBNRow{}, db.ErrRecordNotFound)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
 				store.AssertExpectations(t)
 				require.Equal(t, http.StatusBadRequest, recorder.Code)
 			},
 		},
+		{
+			name: "MissingIfMatch",
+			isbn: book.Isbn13.String,
+			body: gin.H{
+				"title": updatedBook.Title,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
+				store.AssertExpectations(t)
+				require.Equal(t, http.StatusPreconditionRequired, recorder.Code)
+			},
+		},
+		{
+			name:    "StaleIfMatch",
+			isbn:    book.Isbn13.String,
+			ifMatch: `"0"`,
+			body: gin.H{
+				"title": updatedBook.Title,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetBookByISBN(mock.Anything, mock.Anything).
+					Return(db.GetBookByISBNRow{Book: book}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
+				store.AssertExpectations(t)
+				require.Equal(t, http.StatusPreconditionFailed, recorder.Code)
+			},
+		},
+		{
+			name:    "ChangedMeanwhile",
+			isbn:    book.Isbn13.String,
+			ifMatch: "*",
+			body: gin.H{
+				"title": updatedBook.Title,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetBookByISBN(mock.Anything, mock.Anything).
+					Return(db.GetBookByISBNRow{Book: book}, nil)
+				store.EXPECT().UpdateBookTx(mock.Anything, mock.Anything).
+					Return(db.Book{}, db.ErrVersionChanged)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
+				store.AssertExpectations(t)
+				require.Equal(t, http.StatusPreconditionFailed, recorder.Code)
+			},
+		},
 	}
 
 	for i := range testCases {
@@ -520,6 +875,142 @@ func TestUpdateBookAPI(t *testing.T) {
 			url := fmt.Sprintf("/books/%s", tc.isbn)
 			request, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(data))
 			require.NoError(t, err)
+			if len(tc.ifMatch) > 0 {
+				request.Header.Set("If-Match", tc.ifMatch)
+			}
+
+			router.ServeHTTP(recorder, request)
+
+			tc.checkResponse(recorder, store)
+		})
+	}
+}
+
+func TestPatchBookAPI(t *testing.T) {
+	book := randomBook(t)
+
+	testCases := []struct {
+		name          string
+		contentType   string
+		body          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recoder *httptest.ResponseRecorder, store *mockdb.MockStore)
+	}{
+		{
+			name:        "Default",
+			contentType: "application/merge-patch+json",
+			body:        `{"price": 0, "image_url": null, "series_number": null, "subjects": null, "language": "EN-us"}`,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetBookByISBN(mock.Anything, mock.Anything).
+					Return(db.GetBookByISBNRow{Book: book}, nil)
+				store.EXPECT().UpdateBookTx(mock.Anything, mock.MatchedBy(func(arg db.UpdateBookTxParams) bool {
+					return arg.Book.Price.Valid && arg.Book.Price.Float64 == 0 &&
+						arg.Book.ClearImageUrl && !arg.Book.ImageUrl.Valid &&
+						arg.Book.ClearSeriesNumber && !arg.Book.ClearSeriesName &&
+						arg.Book.Language.String == "en-US" && !arg.Book.ClearLanguage &&
+						!arg.Book.Title.Valid && !arg.Book.ClearIsbn13 &&
+						arg.Subjects != nil && len(arg.Subjects) == 0 &&
+						arg.Book.Version.Int64 == book.Version
+				})).
+					Return(book, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
+				store.AssertExpectations(t)
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.NotEmpty(t, recorder.Header().Get("ETag"))
+			},
+		},
+		{
+			name:        "LeaveSubjects",
+			contentType: "application/merge-patch+json; charset=utf-8",
+			body:        `{"title": "New title"}`,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetBookByISBN(mock.Anything, mock.Anything).
+					Return(db.GetBookByISBNRow{Book: book}, nil)
+				store.EXPECT().UpdateBookTx(mock.Anything, mock.MatchedBy(func(arg db.UpdateBookTxParams) bool {
+					return arg.Book.Title.String == "New title" && arg.Subjects == nil && !arg.Book.Price.Valid
+				})).
+					Return(book, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
+				store.AssertExpectations(t)
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:        "NullTitle",
+			contentType: "application/merge-patch+json",
+			body:        `{"title": null}`,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetBookByISBN(mock.Anything, mock.Anything).
+					Return(db.GetBookByISBNRow{Book: book}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
+				store.AssertExpectations(t)
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "title cannot be null")
+			},
+		},
+		{
+			name:        "InvalidValue",
+			contentType: "application/merge-patch+json",
+			body:        `{"page_count": 0}`,
+			buildStubs: func(store *mockdb.MockStore) {
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
+				store.AssertExpectations(t)
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:        "MissingISBN",
+			contentType: "application/merge-patch+json",
+			body:        `{"isbn13": null}`,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetBookByISBN(mock.Anything, mock.Anything).
+					Return(db.GetBookByISBNRow{Book: book}, nil)
+				store.EXPECT().UpdateBookTx(mock.Anything, mock.MatchedBy(func(arg db.UpdateBookTxParams) bool {
+					return arg.Book.ClearIsbn13
+				})).
+					Return(db.Book{}, db.ErrMissingISBN)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
+				store.AssertExpectations(t)
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:        "UnsupportedMediaType",
+			contentType: "application/json",
+			body:        `{"title": "New title"}`,
+			buildStubs: func(store *mockdb.MockStore) {
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
+				store.AssertExpectations(t)
+				require.Equal(t, http.StatusUnsupportedMediaType, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			store := mockdb.NewMockStore(t)
+			tc.buildStubs(store)
+
+			handler := newTestHandler(t, store)
+
+			router := gin.Default()
+			router.PATCH("/books/:isbn", handler.PatchBook)
+
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/books/%s", book.Isbn13.String)
+			request, err := http.NewRequest(http.MethodPatch, url, strings.NewReader(tc.body))
+			require.NoError(t, err)
+			request.Header.Set("Content-Type", tc.contentType)
+			request.Header.Set("If-Match", `"1"`)
 
 			router.ServeHTTP(recorder, request)
 
@@ -531,6 +1022,131 @@ func TestUpdateBookAPI(t *testing.T) {
 func TestDeleteBookAPI(t *testing.T) {
 	book := randomBook(t)
 
+	testCases := []struct {
+		name          string
+		isbn          string
+		ifMatch       string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recoder *httptest.ResponseRecorder, store *mockdb.MockStore)
+	}{
+		{
+			name:    "Default",
+			isbn:    book.Isbn13.String,
+			ifMatch: `"1"`,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetBookByISBN(mock.Anything, mock.Anything).
+					Return(db.GetBookByISBNRow{Book: book}, nil)
+				expectExecTx(store)
+				store.EXPECT().DeleteBookByISBN(mock.Anything, mock.MatchedBy(func(arg db.DeleteBookByISBNParams) bool {
+					return arg.Version.Valid && arg.Version.Int64 == book.Version
+				})).
+					Return(1, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
+				store.AssertExpectations(t)
+				require.Equal(t, http.StatusNoContent, recorder.Code)
+			},
+		},
+		{
+			name:    "InvalidISBN13",
+			isbn:    "INVALIDISBN13",
+			ifMatch: `"1"`,
+			buildStubs: func(store *mockdb.MockStore) {
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
+				store.AssertExpectations(t)
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:    "InternalError",
+			isbn:    book.Isbn13.String,
+			ifMatch: `"1"`,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetBookByISBN(mock.Anything, mock.Anything).
+					Return(db.GetBookByISBNRow{Book: book}, nil)
+				expectExecTx(store)
+				store.EXPECT().DeleteBookByISBN(mock.Anything, mock.Anything).
+					Return(0, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
+				store.AssertExpectations(t)
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name: "MissingIfMatch",
+			isbn: book.Isbn13.String,
+			buildStubs: func(store *mockdb.MockStore) {
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
+				store.AssertExpectations(t)
+				require.Equal(t, http.StatusPreconditionRequired, recorder.Code)
+			},
+		},
+		{
+			name:    "StaleIfMatch",
+			isbn:    book.Isbn13.String,
+			ifMatch: `W/"1"`,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetBookByISBN(mock.Anything, mock.Anything).
+					Return(db.GetBookByISBNRow{Book: book}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
+				store.AssertExpectations(t)
+				require.Equal(t, http.StatusPreconditionFailed, recorder.Code)
+			},
+		},
+		{
+			name:    "ChangedMeanwhile",
+			isbn:    book.Isbn13.String,
+			ifMatch: `"1"`,
+			buildStubs: func(store *mockdb.MockStore) {
+				// still there after deleting nothing, so someone changed it
+				store.EXPECT().GetBookByISBN(mock.Anything, mock.Anything).
+					Return(db.GetBookByISBNRow{Book: book}, nil)
+				expectExecTx(store)
+				store.EXPECT().DeleteBookByISBN(mock.Anything, mock.Anything).
+					Return(0, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
+				store.AssertExpectations(t)
+				require.Equal(t, http.StatusPreconditionFailed, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			store := mockdb.NewMockStore(t)
+			tc.buildStubs(store)
+
+			handler := newTestHandler(t, store)
+
+			router := gin.Default()
+			router.DELETE("/books/:isbn", handler.DeleteBook)
+
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/books/%s", tc.isbn)
+			request, err := http.NewRequest(http.MethodDelete, url, nil)
+			require.NoError(t, err)
+			if len(tc.ifMatch) > 0 {
+				request.Header.Set("If-Match", tc.ifMatch)
+			}
+
+			router.ServeHTTP(recorder, request)
+
+			tc.checkResponse(recorder, store)
+		})
+	}
+}
+
+func TestRestoreBookAPI(t *testing.T) {
+	book := randomBook(t)
+
 	testCases := []struct {
 		name          string
 		isbn          string
@@ -541,12 +1157,17 @@ func TestDeleteBookAPI(t *testing.T) {
 			name: "Default",
 			isbn: book.Isbn13.String,
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().DeleteBookByISBN(mock.AnythingOfType("*gin.Context"), mock.Anything).
-					Return(nil)
+				expectExecTx(store)
+				store.EXPECT().RestoreBookByISBN(mock.Anything, mock.Anything).
+					Return(book, nil)
+				store.EXPECT().GetBookByISBN(mock.Anything, mock.MatchedBy(func(arg db.GetBookByISBNParams) bool {
+					return !arg.IncludeDeleted
+				})).
+					Return(db.GetBookByISBNRow{Book: book}, nil)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
 				store.AssertExpectations(t)
-				require.Equal(t, http.StatusNoContent, recorder.Code)
+				require.Equal(t, http.StatusOK, recorder.Code)
 			},
 		},
 		{
@@ -559,12 +1180,45 @@ func TestDeleteBookAPI(t *testing.T) {
 				require.Equal(t, http.StatusBadRequest, recorder.Code)
 			},
 		},
+		{
+			name: "NotFound",
+			isbn: book.Isbn13.String,
+			buildStubs: func(store *mockdb.MockStore) {
+				expectExecTx(store)
+				store.EXPECT().RestoreBookByISBN(mock.Anything, mock.Anything).
+					Return(db.Book{}, db.ErrRecordNotFound)
+				store.EXPECT().GetBookByISBN(mock.Anything, mock.Anything).
+					Return(db.GetBookByISBNRow{}, db.ErrRecordNotFound)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
+				store.AssertExpectations(t)
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "NotDeleted",
+			isbn: book.Isbn13.String,
+			buildStubs: func(store *mockdb.MockStore) {
+				expectExecTx(store)
+				store.EXPECT().RestoreBookByISBN(mock.Anything, mock.Anything).
+					Return(db.Book{}, db.ErrRecordNotFound)
+				store.EXPECT().GetBookByISBN(mock.Anything, mock.MatchedBy(func(arg db.GetBookByISBNParams) bool {
+					return !arg.IncludeDeleted
+				})).
+					Return(db.GetBookByISBNRow{Book: book}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
+				store.AssertExpectations(t)
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
 		{
 			name: "InternalError",
 			isbn: book.Isbn13.String,
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().DeleteBookByISBN(mock.AnythingOfType("*gin.Context"), mock.Anything).
-					Return(sql.ErrConnDone)
+				expectExecTx(store)
+				store.EXPECT().RestoreBookByISBN(mock.Anything, mock.Anything).
+					Return(db.Book{}, sql.ErrConnDone)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
 				store.AssertExpectations(t)
@@ -583,12 +1237,12 @@ func TestDeleteBookAPI(t *testing.T) {
 			handler := newTestHandler(t, store)
 
 			router := gin.Default()
-			router.DELETE("/books/:isbn", handler.DeleteBook)
+			router.POST("/books/:isbn/restore", handler.RestoreBook)
 
 			recorder := httptest.NewRecorder()
 
-			url := fmt.Sprintf("/books/%s", tc.isbn)
-			request, err := http.NewRequest(http.MethodDelete, url, nil)
+			url := fmt.Sprintf("/books/%s/restore", tc.isbn)
+			request, err := http.NewRequest(http.MethodPost, url, nil)
 			require.NoError(t, err)
 
 			router.ServeHTTP(recorder, request)
@@ -612,5 +1266,6 @@ func randomBook(t *testing.T) db.Book {
 		},
 		Price:           float64(util.RandomFloat(10.0, 1500.0)),
 		PublicationYear: util.RandomInt(1000, 9999),
+		Version:         1,
 	}
 }
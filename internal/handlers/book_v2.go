@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/atsuyaourt/xyz-books/internal/auth"
+	db "github.com/atsuyaourt/xyz-books/internal/db/sqlc"
+	"github.com/atsuyaourt/xyz-books/internal/services"
+	"github.com/gin-gonic/gin"
+)
+
+// The v2 handlers are described by the v2 OpenAPI document only, swag
+// documents v1.
+
+// GetBookV2 answers 404 when the book is not found
+func (h *DefaultHandler) GetBookV2(ctx *gin.Context) {
+	var req getBookReq
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var query includeDeletedQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	res, err := h.service.GetBookV2(ctx, req.ISBN13, query.IncludeDeleted)
+	if err != nil {
+		if errors.Is(err, auth.ErrAdminRequired) {
+			ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+			return
+		}
+		if errors.Is(err, db.ErrRecordNotFound) {
+			ctx.JSON(http.StatusNotFound, errorResponse(errors.New("book not found")))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if notModified(ctx, res.Version) {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (h *DefaultHandler) ListBooksV2(ctx *gin.Context) {
+	var req services.ListBooksV2Req
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	res, err := h.service.ListBooksV2(ctx, req)
+	if err != nil {
+		if errors.Is(err, auth.ErrAdminRequired) {
+			ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+			return
+		}
+		if errors.Is(err, services.ErrInvalidCursor) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,236 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	db "github.com/atsuyaourt/xyz-books/internal/db/sqlc"
+	mockdb "github.com/atsuyaourt/xyz-books/internal/mocks/db"
+	"github.com/atsuyaourt/xyz-books/internal/models"
+	"github.com/atsuyaourt/xyz-books/internal/util"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetBookV2API(t *testing.T) {
+	book := randomBook(t)
+	book.BookID = util.RandomInt(1, 111)
+	book.PublisherID = util.RandomInt(1, 111)
+	book.Price = 19.99
+	author := db.Author{
+		AuthorID:  util.RandomInt(1, 111),
+		FirstName: util.RandomString(12),
+		LastName:  util.RandomString(16),
+	}
+	publisherName := util.RandomString(12)
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recoder *httptest.ResponseRecorder, store *mockdb.MockStore)
+	}{
+		{
+			name: "Default",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetBookByISBN(mock.Anything, mock.Anything).
+					Return(db.GetBookByISBNRow{
+						Book:          book,
+						Contributors:  "author:" + author.FirstName + " " + author.LastName,
+						PublisherName: publisherName,
+					}, nil)
+				store.EXPECT().ListBookAuthors(mock.Anything, []int64{book.BookID}).
+					Return([]db.ListBookAuthorsRow{{BookID: book.BookID, Author: author}}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Equal(t, `"1"`, recorder.Header().Get("ETag"))
+
+				var got models.BookV2
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Equal(t, int64(1999), got.PriceCents)
+				require.Equal(t, []models.AuthorRef{{
+					ID:        author.AuthorID,
+					FirstName: author.FirstName,
+					LastName:  author.LastName,
+				}}, got.Authors)
+				require.Equal(t, models.PublisherRef{ID: book.PublisherID, Name: publisherName}, got.Publisher)
+			},
+		},
+		{
+			name: "NotFound",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetBookByISBN(mock.Anything, mock.Anything).
+					Return(db.GetBookByISBNRow{}, db.ErrRecordNotFound)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "InternalError",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetBookByISBN(mock.Anything, mock.Anything).
+					Return(db.GetBookByISBNRow{Book: book}, nil)
+				store.EXPECT().ListBookAuthors(mock.Anything, mock.Anything).
+					Return(nil, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			store := mockdb.NewMockStore(t)
+			tc.buildStubs(store)
+
+			handler := newTestHandler(t, store)
+
+			router := gin.Default()
+			router.GET("/books/:isbn", handler.GetBookV2)
+
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/books/%s", book.Isbn13.String)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			router.ServeHTTP(recorder, request)
+
+			tc.checkResponse(recorder, store)
+		})
+	}
+}
+
+func TestListBooksV2API(t *testing.T) {
+	n := 3
+	rows := make([]db.ListBooksRow, n)
+	for i := range rows {
+		rows[i].Book = randomBook(t)
+		rows[i].Book.BookID = int64(i + 1)
+	}
+	cursor := util.EncodeCursor(struct {
+		Title string `json:"title"`
+		ID    int64  `json:"id"`
+	}{rows[1].Book.Title, rows[1].Book.BookID})
+
+	testCases := []struct {
+		name          string
+		query         string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recoder *httptest.ResponseRecorder, store *mockdb.MockStore)
+	}{
+		{
+			name:  "FirstPage",
+			query: "limit=2",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListBooks(mock.Anything, mock.MatchedBy(func(arg db.ListBooksParams) bool {
+					return arg.Limit == 3 && arg.Offset == 0 && !arg.AfterTitle.Valid
+				})).Return(rows, nil)
+				store.EXPECT().ListBookAuthors(mock.Anything, []int64{1, 2}).
+					Return([]db.ListBookAuthorsRow{}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got util.CursorList[models.BookV2]
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Len(t, got.Items, 2)
+				require.Equal(t, []models.AuthorRef{}, got.Items[0].Authors)
+				require.Equal(t, cursor, got.NextCursor)
+			},
+		},
+		{
+			name:  "LastPage",
+			query: "limit=2&cursor=" + cursor,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListBooks(mock.Anything, mock.MatchedBy(func(arg db.ListBooksParams) bool {
+					return arg.AfterTitle.String == rows[1].Book.Title && arg.AfterID.Int64 == 2
+				})).Return(rows[2:], nil)
+				store.EXPECT().ListBookAuthors(mock.Anything, []int64{3}).
+					Return([]db.ListBookAuthorsRow{}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got util.CursorList[models.BookV2]
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Len(t, got.Items, 1)
+				require.Empty(t, got.NextCursor)
+			},
+		},
+		{
+			name:  "PriceCents",
+			query: "min_price_cents=1000&max_price_cents=1999",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListBooks(mock.Anything, mock.MatchedBy(func(arg db.ListBooksParams) bool {
+					return arg.MinPrice.Float64 == 10 && arg.MaxPrice.Float64 == 19.99
+				})).Return([]db.ListBooksRow{}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.JSONEq(t, `{"items":[],"next_cursor":""}`, recorder.Body.String())
+			},
+		},
+		{
+			name:       "InvalidCursor",
+			query:      "cursor=%21",
+			buildStubs: func(store *mockdb.MockStore) {},
+			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
+				store.AssertNotCalled(t, "ListBooks", mock.Anything, mock.Anything)
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:       "InvalidLimit",
+			query:      "limit=1000",
+			buildStubs: func(store *mockdb.MockStore) {},
+			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
+				store.AssertNotCalled(t, "ListBooks", mock.Anything, mock.Anything)
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "InternalError",
+			query: "",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListBooks(mock.Anything, mock.Anything).
+					Return(nil, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			store := mockdb.NewMockStore(t)
+			tc.buildStubs(store)
+
+			handler := newTestHandler(t, store)
+
+			router := gin.Default()
+			router.GET("/books", handler.ListBooksV2)
+
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodGet, "/books?"+tc.query, nil)
+			require.NoError(t, err)
+
+			router.ServeHTTP(recorder, request)
+
+			tc.checkResponse(recorder, store)
+		})
+	}
+}
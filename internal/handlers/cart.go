@@ -0,0 +1,157 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/atsuyaourt/xyz-books/internal/auth"
+	db "github.com/atsuyaourt/xyz-books/internal/db/sqlc"
+	_ "github.com/atsuyaourt/xyz-books/internal/models" // types named in the swag annotations
+	"github.com/atsuyaourt/xyz-books/internal/services"
+	"github.com/atsuyaourt/xyz-books/internal/util"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	cartCookieName   = "cart_token"
+	cartCookieMaxAge = 60 * 60 * 24 * 30
+)
+
+// isHTTPS reports whether the request was made over HTTPS, to the server or
+// to the proxy in front of it
+func isHTTPS(ctx *gin.Context) bool {
+	return ctx.Request.TLS != nil || ctx.GetHeader("X-Forwarded-Proto") == "https"
+}
+
+// cartOwner identifies the cart of the current visitor, issuing a cart
+// cookie to visitors that do not have one yet. Signed-in clients also own
+// the cart of their API key.
+func cartOwner(ctx *gin.Context) services.CartOwner {
+	token, err := ctx.Cookie(cartCookieName)
+	if err != nil || len(token) == 0 {
+		token = util.NewToken()
+		ctx.SetSameSite(http.SameSiteLaxMode)
+		ctx.SetCookie(cartCookieName, token, cartCookieMaxAge, "/", "", isHTTPS(ctx), true)
+	}
+
+	return services.CartOwner{
+		Token:  token,
+		UserID: auth.UserID(ctx),
+	}
+}
+
+// GetCart
+//
+//	@Summary	Get cart
+//	@Tags		cart
+//	@Accept		json
+//	@Produce	json
+//	@Success	200	{object}	models.Cart
+//	@Router		/cart [get]
+func (h *DefaultHandler) GetCart(ctx *gin.Context) {
+	res, err := h.service.GetCart(ctx, cartOwner(ctx))
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+// AddCartItem
+//
+//	@Summary	Add book to cart
+//	@Tags		cart
+//	@Accept		json
+//	@Produce	json
+//	@Param		req	body		services.AddCartItemReq	true	"Add cart item parameters"
+//	@Success	200	{object}	models.Cart
+//	@Router		/cart/items [post]
+func (h *DefaultHandler) AddCartItem(ctx *gin.Context) {
+	var req services.AddCartItemReq
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	res, err := h.service.AddCartItem(ctx, cartOwner(ctx), req)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(errors.New("book not found")))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+type cartItemUri struct {
+	ISBN13 string `uri:"isbn" binding:"required,isbn13"`
+}
+
+// UpdateCartItem
+//
+//	@Summary	Update cart item quantity
+//	@Tags		cart
+//	@Accept		json
+//	@Produce	json
+//	@Param		isbn	path		string						true	"ISBN-13"
+//	@Param		req		body		services.UpdateCartItemReq	true	"Update cart item parameters"
+//	@Success	200		{object}	models.Cart
+//	@Router		/cart/items/{isbn} [put]
+func (h *DefaultHandler) UpdateCartItem(ctx *gin.Context) {
+	var uri cartItemUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req services.UpdateCartItemReq
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	res, err := h.service.UpdateCartItem(ctx, cartOwner(ctx), uri.ISBN13, req)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(errors.New("cart item not found")))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+// RemoveCartItem
+//
+//	@Summary	Remove book from cart
+//	@Tags		cart
+//	@Accept		json
+//	@Produce	json
+//	@Param		isbn	path		string	true	"ISBN-13"
+//	@Success	200		{object}	models.Cart
+//	@Router		/cart/items/{isbn} [delete]
+func (h *DefaultHandler) RemoveCartItem(ctx *gin.Context) {
+	var uri cartItemUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	res, err := h.service.RemoveCartItem(ctx, cartOwner(ctx), uri.ISBN13)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(errors.New("book not found")))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,440 @@
+package handlers
+
+import (
+	"bytes"
+	"crypto/tls"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/atsuyaourt/xyz-books/internal/auth"
+	db "github.com/atsuyaourt/xyz-books/internal/db/sqlc"
+	mockdb "github.com/atsuyaourt/xyz-books/internal/mocks/db"
+	"github.com/atsuyaourt/xyz-books/internal/util"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddCartItemAPI(t *testing.T) {
+	book := randomBook(t)
+	book.BookID = util.RandomInt(1, 100)
+	cart := db.Cart{
+		CartID: util.RandomInt(1, 100),
+		Token:  util.RandomString(32),
+	}
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		cookie        *http.Cookie
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recoder *httptest.ResponseRecorder, store *mockdb.MockStore)
+	}{
+		{
+			name: "NewCart",
+			body: gin.H{
+				"isbn13":   book.Isbn13.String,
+				"quantity": 2,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetBookByISBN(mock.Anything, mock.Anything).
+					Return(db.GetBookByISBNRow{Book: book}, nil)
+				store.EXPECT().GetCartByToken(mock.Anything, mock.Anything).
+					Return(db.Cart{}, db.ErrRecordNotFound)
+				store.EXPECT().CreateCart(mock.Anything, mock.Anything).
+					Return(cart, nil)
+				store.EXPECT().AddCartItem(mock.Anything, db.AddCartItemParams{
+					CartID:   cart.CartID,
+					BookID:   book.BookID,
+					Quantity: 2,
+				}).Return(nil)
+				store.EXPECT().ListCartItems(mock.Anything, cart.CartID).
+					Return([]db.ListCartItemsRow{{Book: book, Quantity: 2}}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
+				store.AssertExpectations(t)
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Contains(t, recorder.Header().Get("Set-Cookie"), cartCookieName)
+				require.NotContains(t, recorder.Header().Get("Set-Cookie"), "Secure")
+
+				var res struct {
+					ItemCount int64   `json:"item_count"`
+					Total     float64 `json:"total"`
+				}
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+				require.Equal(t, int64(2), res.ItemCount)
+				require.InDelta(t, book.Price*2, res.Total, 0.01)
+			},
+		},
+		{
+			name: "ExistingCart",
+			body: gin.H{
+				"isbn13": book.Isbn13.String,
+			},
+			cookie: &http.Cookie{Name: cartCookieName, Value: cart.Token},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetBookByISBN(mock.Anything, mock.Anything).
+					Return(db.GetBookByISBNRow{Book: book}, nil)
+				store.EXPECT().GetCartByToken(mock.Anything, cart.Token).
+					Return(cart, nil)
+				store.EXPECT().AddCartItem(mock.Anything, db.AddCartItemParams{
+					CartID:   cart.CartID,
+					BookID:   book.BookID,
+					Quantity: 1,
+				}).Return(nil)
+				store.EXPECT().ListCartItems(mock.Anything, cart.CartID).
+					Return([]db.ListCartItemsRow{{Book: book, Quantity: 1}}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
+				store.AssertExpectations(t)
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Empty(t, recorder.Header().Get("Set-Cookie"))
+			},
+		},
+		{
+			name: "InvalidISBN13",
+			body: gin.H{
+				"isbn13": "INVALIDISBN13",
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
+				store.AssertExpectations(t)
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "BookNotFound",
+			body: gin.H{
+				"isbn13": book.Isbn13.String,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetBookByISBN(mock.Anything, mock.Anything).
+					Return(db.GetBookByISBNRow{}, db.ErrRecordNotFound)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
+				store.AssertExpectations(t)
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "InternalError",
+			body: gin.H{
+				"isbn13": book.Isbn13.String,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetBookByISBN(mock.Anything, mock.Anything).
+					Return(db.GetBookByISBNRow{Book: book}, nil)
+				store.EXPECT().GetCartByToken(mock.Anything, mock.Anything).
+					Return(db.Cart{}, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
+				store.AssertExpectations(t)
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			store := mockdb.NewMockStore(t)
+			tc.buildStubs(store)
+
+			handler := newTestHandler(t, store)
+
+			router := gin.Default()
+			router.POST("/cart/items", handler.AddCartItem)
+
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/cart/items", bytes.NewReader(data))
+			require.NoError(t, err)
+			if tc.cookie != nil {
+				request.AddCookie(tc.cookie)
+			}
+
+			router.ServeHTTP(recorder, request)
+
+			tc.checkResponse(recorder, store)
+		})
+	}
+}
+
+func TestUpdateCartItemAPI(t *testing.T) {
+	book := randomBook(t)
+	book.BookID = util.RandomInt(1, 100)
+	cart := db.Cart{
+		CartID: util.RandomInt(1, 100),
+		Token:  util.RandomString(32),
+	}
+
+	testCases := []struct {
+		name          string
+		isbn          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recoder *httptest.ResponseRecorder, store *mockdb.MockStore)
+	}{
+		{
+			name: "Default",
+			isbn: book.Isbn13.String,
+			body: gin.H{
+				"quantity": 5,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetBookByISBN(mock.Anything, mock.Anything).
+					Return(db.GetBookByISBNRow{Book: book}, nil)
+				store.EXPECT().GetCartByToken(mock.Anything, cart.Token).
+					Return(cart, nil)
+				store.EXPECT().SetCartItemQuantity(mock.Anything, db.SetCartItemQuantityParams{
+					CartID:   cart.CartID,
+					BookID:   book.BookID,
+					Quantity: 5,
+				}).Return(1, nil)
+				store.EXPECT().ListCartItems(mock.Anything, cart.CartID).
+					Return([]db.ListCartItemsRow{{Book: book, Quantity: 5}}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
+				store.AssertExpectations(t)
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "ZeroQuantityRemoves",
+			isbn: book.Isbn13.String,
+			body: gin.H{
+				"quantity": 0,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetBookByISBN(mock.Anything, mock.Anything).
+					Return(db.GetBookByISBNRow{Book: book}, nil)
+				store.EXPECT().GetCartByToken(mock.Anything, cart.Token).
+					Return(cart, nil)
+				store.EXPECT().DeleteCartItem(mock.Anything, db.DeleteCartItemParams{
+					CartID: cart.CartID,
+					BookID: book.BookID,
+				}).Return(nil)
+				store.EXPECT().ListCartItems(mock.Anything, cart.CartID).
+					Return([]db.ListCartItemsRow{}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
+				store.AssertExpectations(t)
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "NotInCart",
+			isbn: book.Isbn13.String,
+			body: gin.H{
+				"quantity": 5,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetBookByISBN(mock.Anything, mock.Anything).
+					Return(db.GetBookByISBNRow{Book: book}, nil)
+				store.EXPECT().GetCartByToken(mock.Anything, cart.Token).
+					Return(cart, nil)
+				store.EXPECT().SetCartItemQuantity(mock.Anything, mock.Anything).
+					Return(0, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
+				store.AssertExpectations(t)
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidQuantity",
+			isbn: book.Isbn13.String,
+			body: gin.H{
+				"quantity": -1,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
+				store.AssertExpectations(t)
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			store := mockdb.NewMockStore(t)
+			tc.buildStubs(store)
+
+			handler := newTestHandler(t, store)
+
+			router := gin.Default()
+			router.PUT("/cart/items/:isbn", handler.UpdateCartItem)
+
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/cart/items/%s", tc.isbn)
+			request, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(data))
+			require.NoError(t, err)
+			request.AddCookie(&http.Cookie{Name: cartCookieName, Value: cart.Token})
+
+			router.ServeHTTP(recorder, request)
+
+			tc.checkResponse(recorder, store)
+		})
+	}
+}
+
+func TestGetCartSignedInAPI(t *testing.T) {
+	book := randomBook(t)
+	anonCart := db.Cart{
+		CartID: util.RandomInt(1, 100),
+		Token:  util.RandomString(32),
+	}
+	userID, ok := testClientKeys.ID(testClientKey)
+	require.True(t, ok)
+	userCart := db.Cart{
+		CartID: anonCart.CartID + 1,
+		Token:  util.RandomString(32),
+		UserID: sql.NullString{String: userID, Valid: true},
+	}
+
+	testCases := []struct {
+		name          string
+		key           string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recoder *httptest.ResponseRecorder, store *mockdb.MockStore)
+	}{
+		{
+			name: "MergesAnonymousCart",
+			key:  testClientKey,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetCartByToken(mock.Anything, anonCart.Token).
+					Return(anonCart, nil)
+				store.EXPECT().GetCartByUserID(mock.Anything, userCart.UserID).
+					Return(userCart, nil)
+				store.EXPECT().MergeCartTx(mock.Anything, db.MergeCartTxParams{
+					FromCartID: anonCart.CartID,
+					ToCartID:   userCart.CartID,
+				}).Return(nil)
+				store.EXPECT().ListCartItems(mock.Anything, userCart.CartID).
+					Return([]db.ListCartItemsRow{{Book: book, Quantity: 3}}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
+				store.AssertExpectations(t)
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var res struct {
+					ItemCount int64 `json:"item_count"`
+				}
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+				require.Equal(t, int64(3), res.ItemCount)
+			},
+		},
+		{
+			name: "UnknownKey",
+			key:  "unknown-key",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetCartByToken(mock.Anything, anonCart.Token).
+					Return(anonCart, nil)
+				store.EXPECT().ListCartItems(mock.Anything, anonCart.CartID).
+					Return([]db.ListCartItemsRow{}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
+				store.AssertExpectations(t)
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			store := mockdb.NewMockStore(t)
+			tc.buildStubs(store)
+
+			handler := newTestHandler(t, store)
+
+			router := newTestRouter()
+			router.GET("/cart", handler.GetCart)
+
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodGet, "/cart", nil)
+			require.NoError(t, err)
+			request.AddCookie(&http.Cookie{Name: cartCookieName, Value: anonCart.Token})
+			request.Header.Set(auth.APIKeyHeader, tc.key)
+
+			router.ServeHTTP(recorder, request)
+
+			tc.checkResponse(recorder, store)
+		})
+	}
+}
+
+func TestCartCookieSecure(t *testing.T) {
+	testCases := []struct {
+		name   string
+		setup  func(request *http.Request)
+		secure bool
+	}{
+		{
+			name:  "HTTP",
+			setup: func(request *http.Request) {},
+		},
+		{
+			name: "TLS",
+			setup: func(request *http.Request) {
+				request.TLS = &tls.ConnectionState{}
+			},
+			secure: true,
+		},
+		{
+			name: "HTTPSProxy",
+			setup: func(request *http.Request) {
+				request.Header.Set("X-Forwarded-Proto", "https")
+			},
+			secure: true,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			store := mockdb.NewMockStore(t)
+			store.EXPECT().GetCartByToken(mock.Anything, mock.Anything).
+				Return(db.Cart{}, db.ErrRecordNotFound)
+
+			handler := newTestHandler(t, store)
+
+			router := newTestRouter()
+			router.GET("/cart", handler.GetCart)
+
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodGet, "/cart", nil)
+			require.NoError(t, err)
+			tc.setup(request)
+
+			router.ServeHTTP(recorder, request)
+			require.Equal(t, http.StatusOK, recorder.Code)
+
+			cookies := recorder.Result().Cookies()
+			require.Len(t, cookies, 1)
+			require.Equal(t, cartCookieName, cookies[0].Name)
+			require.Equal(t, tc.secure, cookies[0].Secure)
+			require.True(t, cookies[0].HttpOnly)
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package handlers
+
+import (
+	"net/http"
+
+	_ "github.com/atsuyaourt/xyz-books/internal/models" // types named in the swag annotations
+	"github.com/atsuyaourt/xyz-books/internal/services"
+	"github.com/gin-gonic/gin"
+)
+
+// ListChanges
+//
+//	@Summary		List changes
+//	@Description	Books, authors and publishers changed after a time, oldest first
+//	@Tags			changes
+//	@Accept			json
+//	@Produce		json
+//	@Param			req	query		services.ListChangesReq	false	"List changes parameters"
+//	@Success		200	{object}	models.ChangeFeed
+//	@Router			/changes [get]
+func (h *DefaultHandler) ListChanges(ctx *gin.Context) {
+	var req services.ListChangesReq
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	res, err := h.service.ListChanges(ctx, req)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
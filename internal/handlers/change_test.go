@@ -0,0 +1,169 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	db "github.com/atsuyaourt/xyz-books/internal/db/sqlc"
+	mockdb "github.com/atsuyaourt/xyz-books/internal/mocks/db"
+	"github.com/atsuyaourt/xyz-books/internal/models"
+	"github.com/atsuyaourt/xyz-books/internal/services"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListChangesAPI(t *testing.T) {
+	since := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	changes := []db.ListChangesRow{
+		{Entity: "book", EntityID: "1", Isbn: "9780000000002", Version: 2, UpdatedAt: since.Add(time.Second)},
+		{Entity: "author", EntityID: "1", Version: 3, UpdatedAt: since.Add(2 * time.Second)},
+		{Entity: "book", EntityID: "2", Isbn: "9780000000019", Version: 4, UpdatedAt: since.Add(2 * time.Second), Deleted: true},
+	}
+
+	testCases := []struct {
+		name          string
+		query         services.ListChangesReq
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore)
+	}{
+		{
+			name:  "Default",
+			query: services.ListChangesReq{Since: since, Limit: 10},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListChanges(mock.Anything, db.ListChangesParams{
+					Since: db.After(since),
+					Limit: 11,
+				}).Return(changes, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				res := requireChangeFeed(t, recorder)
+				require.Len(t, res.Items, 3)
+				require.Equal(t, "1", res.Items[0].ID)
+				require.Equal(t, "9780000000002", res.Items[0].ISBN)
+				require.Equal(t, "author", res.Items[1].Type)
+				require.Empty(t, res.Items[1].ISBN)
+				require.True(t, res.Items[2].Deleted)
+				require.True(t, res.Since.Equal(changes[2].UpdatedAt))
+				require.False(t, res.HasMore)
+			},
+		},
+		{
+			name:  "KeepsChangesAtSameTime",
+			query: services.ListChangesReq{Since: since, Limit: 2},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListChanges(mock.Anything, db.ListChangesParams{
+					Since: db.After(since),
+					Limit: 3,
+				}).Return(changes, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				res := requireChangeFeed(t, recorder)
+				require.Len(t, res.Items, 1)
+				require.True(t, res.Since.Equal(changes[0].UpdatedAt))
+				require.True(t, res.HasMore)
+			},
+		},
+		{
+			name:  "ReadsPastLimit",
+			query: services.ListChangesReq{Since: changes[0].UpdatedAt, Limit: 1},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListChanges(mock.Anything, db.ListChangesParams{
+					Since: db.After(changes[0].UpdatedAt),
+					Limit: 2,
+				}).Return(changes[1:], nil)
+				store.EXPECT().ListChanges(mock.Anything, db.ListChangesParams{
+					Since: db.After(changes[0].UpdatedAt),
+					Limit: 3,
+				}).Return(changes[1:], nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				res := requireChangeFeed(t, recorder)
+				require.Len(t, res.Items, 2)
+				require.False(t, res.HasMore)
+			},
+		},
+		{
+			name:  "NoChanges",
+			query: services.ListChangesReq{Since: since, Limit: 10},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListChanges(mock.Anything, mock.Anything).
+					Return([]db.ListChangesRow{}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				res := requireChangeFeed(t, recorder)
+				require.Empty(t, res.Items)
+				require.True(t, res.Since.Equal(since))
+			},
+		},
+		{
+			name:  "InvalidLimit",
+			query: services.ListChangesReq{Since: since, Limit: -1},
+			buildStubs: func(store *mockdb.MockStore) {
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
+				store.AssertNotCalled(t, "ListChanges", mock.Anything, mock.Anything)
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "InternalError",
+			query: services.ListChangesReq{Since: since, Limit: 10},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListChanges(mock.Anything, mock.Anything).
+					Return([]db.ListChangesRow{}, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			store := mockdb.NewMockStore(t)
+			tc.buildStubs(store)
+
+			handler := newTestHandler(t, store)
+
+			router := gin.Default()
+			router.GET("/changes", handler.ListChanges)
+
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodGet, "/changes", nil)
+			require.NoError(t, err)
+
+			q := request.URL.Query()
+			q.Add("since", tc.query.Since.Format(time.RFC3339Nano))
+			q.Add("limit", fmt.Sprintf("%d", tc.query.Limit))
+			request.URL.RawQuery = q.Encode()
+
+			router.ServeHTTP(recorder, request)
+
+			tc.checkResponse(recorder, store)
+		})
+	}
+}
+
+func requireChangeFeed(t *testing.T, recorder *httptest.ResponseRecorder) models.ChangeFeed {
+	var res models.ChangeFeed
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+
+	return res
+}
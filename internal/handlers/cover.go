@@ -0,0 +1,181 @@
+package handlers
+
+import (
+	"errors"
+	"image/png"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+
+	db "github.com/atsuyaourt/xyz-books/internal/db/sqlc"
+	_ "github.com/atsuyaourt/xyz-books/internal/models" // types named in the swag annotations
+	"github.com/atsuyaourt/xyz-books/internal/services"
+	"github.com/atsuyaourt/xyz-books/internal/storage"
+	"github.com/atsuyaourt/xyz-books/internal/util"
+	"github.com/atsuyaourt/xyz-books/internal/views/components"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	coverFormField = "cover"
+	// maxUploadSize caps the whole request body, the size of the
+	// image itself is limited by the service
+	maxUploadSize = 32 << 20
+)
+
+type bookCoverUri struct {
+	ISBN13 string `uri:"isbn" binding:"required,isbn13"`
+}
+
+// UploadBookCover
+//
+//	@Summary	Upload book cover
+//	@Tags		books
+//	@Accept		multipart/form-data
+//	@Produce	json
+//	@Param		isbn	path		string	true	"ISBN-13"
+//	@Param		cover	formData	file	true	"JPEG, PNG or WebP image"
+//	@Success	200		{object}	models.Book
+//	@Router		/books/{isbn}/cover [post]
+func (h *DefaultHandler) UploadBookCover(ctx *gin.Context) {
+	var uri bookCoverUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxUploadSize)
+	fh, err := ctx.FormFile(coverFormField)
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			ctx.JSON(http.StatusRequestEntityTooLarge, errorResponse(services.ErrCoverTooLarge))
+			return
+		}
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	f, err := fh.Open()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	defer f.Close()
+
+	res, err := h.service.UploadBookCover(ctx, uri.ISBN13, f)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(errors.New("book not found")))
+			return
+		}
+		if errors.Is(err, services.ErrCoverTooLarge) {
+			ctx.JSON(http.StatusRequestEntityTooLarge, errorResponse(err))
+			return
+		}
+		if errors.Is(err, services.ErrUnsupportedImage) {
+			ctx.JSON(http.StatusUnsupportedMediaType, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+// DeleteBookCover
+//
+//	@Summary	Delete book cover
+//	@Tags		books
+//	@Accept		json
+//	@Produce	json
+//	@Param		isbn	path	string	true	"ISBN-13"
+//	@Success	204
+//	@Router		/books/{isbn}/cover [delete]
+func (h *DefaultHandler) DeleteBookCover(ctx *gin.Context) {
+	var uri bookCoverUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	err := h.service.DeleteBookCover(ctx, uri.ISBN13)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(errors.New("book not found")))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// ShowCover serves uploaded cover images and thumbnails. Their names
+// change on every upload, so they can be cached indefinitely.
+func (h *DefaultHandler) ShowCover(ctx *gin.Context) {
+	name := strings.TrimPrefix(ctx.Param("name"), "/")
+
+	r, err := h.service.GetCoverImage(ctx, name)
+	if err != nil {
+		if errors.Is(err, storage.ErrBlobNotFound) || errors.Is(err, storage.ErrInvalidKey) {
+			ctx.Status(http.StatusNotFound)
+			return
+		}
+		ctx.Status(http.StatusInternalServerError)
+		return
+	}
+	defer r.Close()
+
+	ctx.Header("Cache-Control", "public, max-age=31536000, immutable")
+	ctx.Header("X-Content-Type-Options", "nosniff")
+	ctx.Header("Content-Type", mime.TypeByExtension(path.Ext(name)))
+	ctx.Status(http.StatusOK)
+	io.Copy(ctx.Writer, r)
+}
+
+// showCoverPlaceholder renders the generated cover of a book with the given encoder
+func (h *DefaultHandler) showCoverPlaceholder(ctx *gin.Context, contentType string, write func(c *util.CoverLayout) error) {
+	var req getBookReq
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	res, err := h.service.GetCoverPlaceholder(ctx, req.ISBN13)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			ctx.JSON(http.StatusNotFound, errorResponse(errors.New("book not found")))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.Header("Cache-Control", "public, max-age=86400")
+	ctx.Header("Content-Type", contentType)
+	ctx.Status(http.StatusOK)
+	if err := write(res); err != nil {
+		// the headers are out already, so only record the error
+		ctx.Error(err)
+		ctx.Abort()
+	}
+}
+
+// ShowCoverSVG renders the generated cover, used for books without a cover image
+func (h *DefaultHandler) ShowCoverSVG(ctx *gin.Context) {
+	h.showCoverPlaceholder(ctx, "image/svg+xml", func(c *util.CoverLayout) error {
+		return components.CoverPlaceholder(*c).Render(ctx.Request.Context(), ctx.Writer)
+	})
+}
+
+// ShowCoverPNG renders the generated cover at twice its size for high density screens
+func (h *DefaultHandler) ShowCoverPNG(ctx *gin.Context) {
+	h.showCoverPlaceholder(ctx, "image/png", func(c *util.CoverLayout) error {
+		return png.Encode(ctx.Writer, c.Rasterize(2))
+	})
+}
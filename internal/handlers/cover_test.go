@@ -0,0 +1,302 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	db "github.com/atsuyaourt/xyz-books/internal/db/sqlc"
+	mockdb "github.com/atsuyaourt/xyz-books/internal/mocks/db"
+	"github.com/atsuyaourt/xyz-books/internal/models"
+	"github.com/atsuyaourt/xyz-books/internal/services"
+	"github.com/atsuyaourt/xyz-books/internal/storage"
+	"github.com/atsuyaourt/xyz-books/internal/util"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUploadBookCoverAPI(t *testing.T) {
+	book := randomBook(t)
+	book.BookID = util.RandomInt(1, 100)
+	cover := randomPNG(t, 600, 900)
+
+	testCases := []struct {
+		name          string
+		isbn          string
+		data          []byte
+		maxSize       int64
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recoder *httptest.ResponseRecorder, store *mockdb.MockStore, blobs storage.BlobStore)
+	}{
+		{
+			name: "Default",
+			isbn: book.Isbn13.String,
+			data: cover,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetBookByISBN(mock.Anything, mock.Anything).
+					Return(db.GetBookByISBNRow{Book: book}, nil)
+				expectExecTx(store)
+				store.EXPECT().SetBookCover(mock.Anything, mock.MatchedBy(func(arg db.SetBookCoverParams) bool {
+					return arg.BookID == book.BookID && arg.CoverKey.Valid
+				})).
+					RunAndReturn(func(_ context.Context, arg db.SetBookCoverParams) (db.Book, error) {
+						b := book
+						b.CoverKey = arg.CoverKey
+						return b, nil
+					})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore, blobs storage.BlobStore) {
+				store.AssertExpectations(t)
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var res models.Book
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+				require.NotNil(t, res.Cover)
+				require.Equal(t, res.Cover.Url, res.ImageUrl)
+				require.Len(t, res.Cover.Thumbnails, len(services.CoverWidths))
+
+				for _, thumb := range res.Cover.Thumbnails {
+					r, err := blobs.Get(context.Background(), "covers/"+thumb.Url[len("/covers/"):])
+					require.NoError(t, err)
+					img, format, err := image.Decode(r)
+					r.Close()
+					require.NoError(t, err)
+					require.Equal(t, "jpeg", format)
+					require.Equal(t, thumb.Width, img.Bounds().Dx())
+					require.Equal(t, thumb.Width*3/2, img.Bounds().Dy())
+				}
+			},
+		},
+		{
+			name: "UnsupportedType",
+			isbn: book.Isbn13.String,
+			data: []byte("GIF89a not really an image"),
+			buildStubs: func(store *mockdb.MockStore) {
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore, blobs storage.BlobStore) {
+				store.AssertExpectations(t)
+				require.Equal(t, http.StatusUnsupportedMediaType, recorder.Code)
+			},
+		},
+		{
+			name: "CorruptImage",
+			isbn: book.Isbn13.String,
+			data: cover[:64],
+			buildStubs: func(store *mockdb.MockStore) {
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore, blobs storage.BlobStore) {
+				store.AssertExpectations(t)
+				require.Equal(t, http.StatusUnsupportedMediaType, recorder.Code)
+			},
+		},
+		{
+			name:    "TooLarge",
+			isbn:    book.Isbn13.String,
+			data:    cover,
+			maxSize: int64(len(cover) - 1),
+			buildStubs: func(store *mockdb.MockStore) {
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore, blobs storage.BlobStore) {
+				store.AssertExpectations(t)
+				require.Equal(t, http.StatusRequestEntityTooLarge, recorder.Code)
+			},
+		},
+		{
+			name: "NotFound",
+			isbn: book.Isbn13.String,
+			data: cover,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetBookByISBN(mock.Anything, mock.Anything).
+					Return(db.GetBookByISBNRow{}, db.ErrRecordNotFound)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore, blobs storage.BlobStore) {
+				store.AssertExpectations(t)
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "InternalError",
+			isbn: book.Isbn13.String,
+			data: cover,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetBookByISBN(mock.Anything, mock.Anything).
+					Return(db.GetBookByISBNRow{Book: book}, nil)
+				expectExecTx(store)
+				store.EXPECT().SetBookCover(mock.Anything, mock.Anything).
+					Return(db.Book{}, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore, blobs storage.BlobStore) {
+				store.AssertExpectations(t)
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidISBN13",
+			isbn: "INVALIDISBN13",
+			data: cover,
+			buildStubs: func(store *mockdb.MockStore) {
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore, blobs storage.BlobStore) {
+				store.AssertExpectations(t)
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			store := mockdb.NewMockStore(t)
+			tc.buildStubs(store)
+
+			blobs := storage.NewLocalStore(t.TempDir())
+			opts := []services.Option{services.WithBlobStore(blobs)}
+			if tc.maxSize > 0 {
+				opts = append(opts, services.WithCoverMaxSize(tc.maxSize))
+			}
+			handler, err := NewDefaultHandler(store, opts...)
+			require.NoError(t, err)
+
+			router := gin.Default()
+			router.POST("/books/:isbn/cover", handler.UploadBookCover)
+
+			recorder := httptest.NewRecorder()
+
+			var body bytes.Buffer
+			w := multipart.NewWriter(&body)
+			fw, err := w.CreateFormFile("cover", "cover.png")
+			require.NoError(t, err)
+			_, err = fw.Write(tc.data)
+			require.NoError(t, err)
+			require.NoError(t, w.Close())
+
+			url := fmt.Sprintf("/books/%s/cover", tc.isbn)
+			request, err := http.NewRequest(http.MethodPost, url, &body)
+			require.NoError(t, err)
+			request.Header.Set("Content-Type", w.FormDataContentType())
+
+			router.ServeHTTP(recorder, request)
+
+			tc.checkResponse(recorder, store, blobs)
+		})
+	}
+}
+
+func randomPNG(t *testing.T, width, height int) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	c := color.RGBA{
+		R: uint8(util.RandomInt(0, 255)),
+		G: uint8(util.RandomInt(0, 255)),
+		B: uint8(util.RandomInt(0, 255)),
+		A: 255,
+	}
+	for x := 0; x < width; x++ {
+		for y := 0; y < height; y++ {
+			img.Set(x, y, c)
+		}
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+
+	return buf.Bytes()
+}
+
+func TestShowCoverPlaceholder(t *testing.T) {
+	book := randomBook(t)
+	row := db.GetBookByISBNRow{
+		Book:          book,
+		Authors:       "Jane Doe,John Smith",
+		PublisherName: util.RandomString(12),
+	}
+
+	testCases := []struct {
+		name          string
+		path          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recoder *httptest.ResponseRecorder, store *mockdb.MockStore)
+	}{
+		{
+			name: "SVG",
+			path: fmt.Sprintf("/books/%s/cover.svg", book.Isbn13.String),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetBookByISBN(mock.Anything, mock.Anything).
+					Return(row, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
+				store.AssertExpectations(t)
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Equal(t, "image/svg+xml", recorder.Header().Get("Content-Type"))
+				body := recorder.Body.String()
+				require.Contains(t, body, "<svg")
+				require.Contains(t, body, util.CoverColor(book.Isbn13.String))
+				require.Contains(t, body, row.PublisherName)
+			},
+		},
+		{
+			name: "PNG",
+			path: fmt.Sprintf("/books/%s/cover.png", book.Isbn13.String),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetBookByISBN(mock.Anything, mock.Anything).
+					Return(row, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
+				store.AssertExpectations(t)
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Equal(t, "image/png", recorder.Header().Get("Content-Type"))
+				img, err := png.Decode(recorder.Body)
+				require.NoError(t, err)
+				require.Equal(t, util.CoverWidth*2, img.Bounds().Dx())
+				require.Equal(t, util.CoverHeight*2, img.Bounds().Dy())
+			},
+		},
+		{
+			name: "NotFound",
+			path: fmt.Sprintf("/books/%s/cover.svg", book.Isbn13.String),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetBookByISBN(mock.Anything, mock.Anything).
+					Return(db.GetBookByISBNRow{}, db.ErrRecordNotFound)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
+				store.AssertExpectations(t)
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			store := mockdb.NewMockStore(t)
+			tc.buildStubs(store)
+
+			handler := newTestHandler(t, store)
+
+			router := gin.Default()
+			router.GET("/books/:isbn", handler.ShowBook)
+			router.GET("/books/:isbn/cover.svg", handler.ShowCoverSVG)
+			router.GET("/books/:isbn/cover.png", handler.ShowCoverPNG)
+
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodGet, tc.path, nil)
+			require.NoError(t, err)
+
+			router.ServeHTTP(recorder, request)
+
+			tc.checkResponse(recorder, store)
+		})
+	}
+}
@@ -3,27 +3,41 @@ package handlers
 import (
 	"github.com/a-h/templ"
 	db "github.com/atsuyaourt/xyz-books/internal/db/sqlc"
+	"github.com/atsuyaourt/xyz-books/internal/graph"
 	"github.com/atsuyaourt/xyz-books/internal/services"
+	"github.com/graph-gophers/graphql-go"
 
 	"github.com/gin-gonic/gin"
 )
 
 type DefaultHandler struct {
 	service services.Service
+	graphql *graphql.Schema
 }
 
-func NewDefaultHandler(store db.Store) (*DefaultHandler, error) {
-	s, err := services.NewDefaultService(store)
+func NewDefaultHandler(store db.Store, opts ...services.Option) (*DefaultHandler, error) {
+	s, err := services.NewDefaultService(store, opts...)
+	if err != nil {
+		return nil, err
+	}
+	schema, err := graph.NewSchema(s)
 	if err != nil {
 		return nil, err
 	}
 	h := &DefaultHandler{
 		service: s,
+		graphql: schema,
 	}
 
 	return h, nil
 }
 
+// includeDeletedQuery is bound from the query of get requests, deleted
+// records are not found unless include_deleted=true
+type includeDeletedQuery struct {
+	IncludeDeleted bool `form:"include_deleted"`
+}
+
 func render(ctx *gin.Context, status int, template templ.Component) error {
 	ctx.Status(status)
 	return template.Render(ctx.Request.Context(), ctx.Writer)
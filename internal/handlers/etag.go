@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	errIfMatchRequired = errors.New("If-Match header is required")
+	errVersionMismatch = errors.New("record was changed, fetch it again")
+)
+
+// entityTag returns the ETag of a record version
+func entityTag(version int64) string {
+	return `"` + strconv.FormatInt(version, 10) + `"`
+}
+
+// etagMatches reports whether an If-Match or If-None-Match header lists
+// etag or is "*". Weak tags (W/"1") only match when weak is set, as
+// If-None-Match compares weakly and If-Match strongly.
+func etagMatches(header, etag string, weak bool) bool {
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" {
+			return true
+		}
+		if weak {
+			tag = strings.TrimPrefix(tag, "W/")
+		}
+		if tag == etag {
+			return true
+		}
+	}
+
+	return false
+}
+
+// notModified sets the ETag of a read and answers 304 when the If-None-Match
+// header holds the current version
+func notModified(ctx *gin.Context, version int64) bool {
+	etag := entityTag(version)
+	ctx.Header("ETag", etag)
+
+	header := ctx.GetHeader("If-None-Match")
+	if len(header) == 0 || !etagMatches(header, etag, true) {
+		return false
+	}
+
+	ctx.Status(http.StatusNotModified)
+	return true
+}
+
+// requireIfMatch answers 428 when a write has no If-Match header, so
+// clients cannot overwrite changes they have not seen
+func requireIfMatch(ctx *gin.Context) bool {
+	if len(ctx.GetHeader("If-Match")) > 0 {
+		return true
+	}
+
+	ctx.JSON(http.StatusPreconditionRequired, errorResponse(errIfMatchRequired))
+	return false
+}
+
+// ifMatch answers 412 when the If-Match header does not hold the current
+// version of the record
+func ifMatch(ctx *gin.Context, version int64) bool {
+	if etagMatches(ctx.GetHeader("If-Match"), entityTag(version), false) {
+		return true
+	}
+
+	ctx.JSON(http.StatusPreconditionFailed, errorResponse(errVersionMismatch))
+	return false
+}
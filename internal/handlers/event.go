@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/atsuyaourt/xyz-books/internal/services"
+	"github.com/gin-gonic/gin"
+)
+
+const eventStreamKeepAlive = 20 * time.Second
+
+// StreamEvents sends catalog events as Server-Sent Events. Each event is
+// named after its type, and book events are sent a second time as
+// "book-<isbn13>" for the pages showing that book.
+func (h *DefaultHandler) StreamEvents(ctx *gin.Context) {
+	events, err := h.service.SubscribeEvents(ctx.Request.Context())
+	if err != nil {
+		if errors.Is(err, services.ErrNoEventBus) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.Header("Content-Type", "text/event-stream")
+	ctx.Header("Cache-Control", "no-cache")
+	ctx.Header("X-Accel-Buffering", "no")
+	ctx.Status(http.StatusOK)
+	ctx.Writer.Flush()
+
+	ticker := time.NewTicker(eventStreamKeepAlive)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			ctx.SSEvent(string(event.Type), event)
+			if isbn13 := event.BookISBN13(); len(isbn13) > 0 {
+				ctx.SSEvent("book-"+isbn13, event)
+			}
+		case <-ticker.C:
+			// a comment keeps idle connections from being dropped
+			ctx.Writer.WriteString(": keep-alive\n\n")
+		}
+		ctx.Writer.Flush()
+	}
+}
@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	mockdb "github.com/atsuyaourt/xyz-books/internal/mocks/db"
+	"github.com/atsuyaourt/xyz-books/internal/models"
+	"github.com/atsuyaourt/xyz-books/internal/services"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStreamEventsAPI(t *testing.T) {
+	bus := services.NewEventBus()
+	handler, err := NewDefaultHandler(mockdb.NewMockStore(t), services.WithEventBus(bus))
+	require.NoError(t, err)
+
+	router := gin.Default()
+	router.GET("/events", handler.StreamEvents)
+
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/events", nil)
+	require.NoError(t, err)
+
+	res, err := http.DefaultClient.Do(request)
+	require.NoError(t, err)
+	defer res.Body.Close()
+
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	require.Equal(t, "text/event-stream", res.Header.Get("Content-Type"))
+
+	// the subscription is made before the headers are sent
+	event := models.Event{
+		ID:   "e1",
+		Type: models.EventPriceChanged,
+		Data: models.PriceChange{ISBN13: "9781891830853", OldPrice: 1000, Price: 900},
+	}
+	require.NoError(t, bus.Publish(context.Background(), event))
+
+	reader := bufio.NewReader(res.Body)
+	for _, name := range []string{"price.changed", "book-9781891830853"} {
+		require.Equal(t, "event:"+name, readLine(t, reader))
+
+		data, ok := strings.CutPrefix(readLine(t, reader), "data:")
+		require.True(t, ok)
+
+		var got models.Event
+		require.NoError(t, json.Unmarshal([]byte(data), &got))
+		require.Equal(t, event.ID, got.ID)
+		require.Equal(t, event.Type, got.Type)
+
+		require.Empty(t, readLine(t, reader))
+	}
+
+	// the stream ends with the bus
+	bus.Close()
+	_, err = reader.ReadString('\n')
+	require.Error(t, err)
+}
+
+func TestStreamEventsAPINoEventBus(t *testing.T) {
+	handler := newTestHandler(t, mockdb.NewMockStore(t))
+
+	router := gin.Default()
+	router.GET("/events", handler.StreamEvents)
+
+	recorder := httptest.NewRecorder()
+	request, err := http.NewRequest(http.MethodGet, "/events", nil)
+	require.NoError(t, err)
+
+	router.ServeHTTP(recorder, request)
+
+	require.Equal(t, http.StatusNotFound, recorder.Code)
+}
+
+func readLine(t *testing.T, r *bufio.Reader) string {
+	line, err := r.ReadString('\n')
+	require.NoError(t, err)
+
+	return strings.TrimRight(line, "\n")
+}
@@ -0,0 +1,30 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/atsuyaourt/xyz-books/internal/graph"
+	"github.com/gin-gonic/gin"
+)
+
+type graphQLReq struct {
+	Query         string         `json:"query" binding:"required"`
+	OperationName string         `json:"operationName"`
+	Variables     map[string]any `json:"variables"`
+}
+
+// GraphQL executes a query or mutation of the schema in internal/graph.
+// Errors of the query itself are part of the response, as GraphQL clients
+// expect.
+func (h *DefaultHandler) GraphQL(ctx *gin.Context) {
+	var req graphQLReq
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	c := graph.WithLoaders(ctx.Request.Context(), h.service)
+	res := h.graphql.Exec(c, req.Query, req.OperationName, req.Variables)
+
+	ctx.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,387 @@
+package handlers
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	db "github.com/atsuyaourt/xyz-books/internal/db/sqlc"
+	mockdb "github.com/atsuyaourt/xyz-books/internal/mocks/db"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type graphQLRes struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+func TestGraphQLBooksAPI(t *testing.T) {
+	n := 3
+	rows := make([]db.ListBooksRow, n)
+	authors := make([]db.ListBookAuthorsRow, n)
+	publishers := make([]db.ListBookPublishersRow, n)
+	for i := range rows {
+		book := randomBook(t)
+		book.BookID = int64(i + 1)
+		author := randomAuthor(t)
+		publisher := randomPublisher(t)
+		rows[i] = db.ListBooksRow{
+			Book:          book,
+			Authors:       author.FirstName + " " + author.LastName,
+			PublisherName: publisher.PublisherName,
+		}
+		authors[i] = db.ListBookAuthorsRow{BookID: book.BookID, Author: author}
+		publishers[i] = db.ListBookPublishersRow{BookID: book.BookID, Publisher: publisher}
+	}
+
+	query := `query($perPage: Int!) {
+		books(perPage: $perPage) {
+			totalItems
+			items { isbn13 title authors { firstName lastName } publisher { name } }
+		}
+	}`
+
+	testCases := []struct {
+		name          string
+		perPage       int32
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recoder *httptest.ResponseRecorder, store *mockdb.MockStore)
+	}{
+		{
+			name:    "OK",
+			perPage: int32(n),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListBooks(mock.Anything, mock.Anything).Return(rows, nil)
+				store.EXPECT().CountBooks(mock.Anything, mock.Anything).Return(int64(n), nil)
+				// the relationships of all the books take a single query each
+				store.EXPECT().ListBookAuthors(mock.Anything, mock.MatchedBy(func(ids []int64) bool {
+					return len(ids) == n
+				})).Return(authors, nil).Once()
+				store.EXPECT().ListBookPublishers(mock.Anything, mock.MatchedBy(func(ids []int64) bool {
+					return len(ids) == n
+				})).Return(publishers, nil).Once()
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
+				store.AssertExpectations(t)
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				res := decodeGraphQLRes(t, recorder)
+				require.Empty(t, res.Errors)
+
+				var data struct {
+					Books struct {
+						TotalItems int32 `json:"totalItems"`
+						Items      []struct {
+							ISBN13  string `json:"isbn13"`
+							Authors []struct {
+								FirstName string `json:"firstName"`
+							} `json:"authors"`
+							Publisher struct {
+								Name string `json:"name"`
+							} `json:"publisher"`
+						} `json:"items"`
+					} `json:"books"`
+				}
+				require.NoError(t, json.Unmarshal(res.Data, &data))
+				require.Equal(t, int32(n), data.Books.TotalItems)
+				require.Len(t, data.Books.Items, n)
+				for i, item := range data.Books.Items {
+					require.Equal(t, rows[i].Book.Isbn13.String, item.ISBN13)
+					require.Len(t, item.Authors, 1)
+					require.Equal(t, authors[i].Author.FirstName, item.Authors[0].FirstName)
+					require.Equal(t, publishers[i].Publisher.PublisherName, item.Publisher.Name)
+				}
+			},
+		},
+		{
+			name:    "InvalidPerPage",
+			perPage: 100,
+			buildStubs: func(store *mockdb.MockStore) {
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
+				store.AssertNotCalled(t, "ListBooks", mock.Anything, mock.Anything)
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.NotEmpty(t, decodeGraphQLRes(t, recorder).Errors)
+			},
+		},
+		{
+			name:    "InternalError",
+			perPage: int32(n),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListBooks(mock.Anything, mock.Anything).Return([]db.ListBooksRow{}, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
+				store.AssertExpectations(t)
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				res := decodeGraphQLRes(t, recorder)
+				require.Len(t, res.Errors, 1)
+				require.Equal(t, sql.ErrConnDone.Error(), res.Errors[0].Message)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			store := mockdb.NewMockStore(t)
+			tc.buildStubs(store)
+
+			recorder := serveGraphQL(t, store, gin.H{
+				"query":     query,
+				"variables": gin.H{"perPage": tc.perPage},
+			})
+
+			tc.checkResponse(recorder, store)
+		})
+	}
+}
+
+func TestGraphQLAuthorsAPI(t *testing.T) {
+	n := 2
+	authors := make([]db.Author, n)
+	books := make([]db.ListAuthorBooksRow, 0, 2*n)
+	for i := range authors {
+		authors[i] = randomAuthor(t)
+		authors[i].AuthorID = int64(i + 1)
+		for j := 0; j < 2; j++ {
+			books = append(books, db.ListAuthorBooksRow{
+				AuthorID: authors[i].AuthorID,
+				Book:     randomBook(t),
+			})
+		}
+	}
+
+	store := mockdb.NewMockStore(t)
+	store.EXPECT().ListAuthors(mock.Anything, mock.Anything).Return(authors, nil)
+	store.EXPECT().CountAuthors(mock.Anything, mock.Anything).Return(int64(n), nil)
+	store.EXPECT().ListAuthorBooks(mock.Anything, mock.MatchedBy(func(ids []int64) bool {
+		return len(ids) == n
+	})).Return(books, nil).Once()
+
+	recorder := serveGraphQL(t, store, gin.H{
+		"query": `{ authors { items { id books(perPage: 1) { totalItems items { isbn13 } } } } }`,
+	})
+	store.AssertExpectations(t)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	res := decodeGraphQLRes(t, recorder)
+	require.Empty(t, res.Errors)
+
+	var data struct {
+		Authors struct {
+			Items []struct {
+				ID    string `json:"id"`
+				Books struct {
+					TotalItems int32 `json:"totalItems"`
+					Items      []struct {
+						ISBN13 string `json:"isbn13"`
+					} `json:"items"`
+				} `json:"books"`
+			} `json:"items"`
+		} `json:"authors"`
+	}
+	require.NoError(t, json.Unmarshal(res.Data, &data))
+	require.Len(t, data.Authors.Items, n)
+	for i, item := range data.Authors.Items {
+		require.Equal(t, int32(2), item.Books.TotalItems)
+		require.Len(t, item.Books.Items, 1)
+		require.Equal(t, books[2*i].Book.Isbn13.String, item.Books.Items[0].ISBN13)
+	}
+}
+
+func TestGraphQLLimitsAPI(t *testing.T) {
+	book := randomBook(t)
+	rows := make([]db.ListBooksRow, 30)
+	for i := range rows {
+		rows[i] = db.ListBooksRow{Book: book}
+	}
+	// 34 pages of 30 books are more than maxItems
+	var aliases strings.Builder
+	for i := 0; i < 34; i++ {
+		fmt.Fprintf(&aliases, "b%d: books(perPage: 30) { items { isbn13 } } ", i)
+	}
+
+	testCases := []struct {
+		name       string
+		query      string
+		buildStubs func(store *mockdb.MockStore)
+		message    string
+	}{
+		{
+			name:       "TooDeep",
+			query:      `{ books { items { authors { books { items { authors { books { items { title } } } } } } } } }`,
+			buildStubs: func(store *mockdb.MockStore) {},
+			message:    "exceeds max depth 8",
+		},
+		{
+			name:  "TooManyItems",
+			query: "{ " + aliases.String() + "}",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListBooks(mock.Anything, mock.Anything).Return(rows, nil)
+				store.EXPECT().CountBooks(mock.Anything, mock.Anything).Return(int64(len(rows)), nil)
+			},
+			message: "query lists more than 1000 items",
+		},
+		{
+			name:  "InvalidPage",
+			query: `{ author(id: "1") { books(page: 0) { totalItems } } }`,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAuthor(mock.Anything, mock.Anything).Return(randomAuthor(t), nil)
+				store.EXPECT().ListAuthorBooks(mock.Anything, mock.Anything).Return(nil, nil)
+			},
+			message: "page must be at least 1",
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			store := mockdb.NewMockStore(t)
+			tc.buildStubs(store)
+
+			recorder := serveGraphQL(t, store, gin.H{"query": tc.query})
+			require.Equal(t, http.StatusOK, recorder.Code)
+
+			res := decodeGraphQLRes(t, recorder)
+			require.NotEmpty(t, res.Errors)
+			require.Contains(t, res.Errors[0].Message, tc.message)
+		})
+	}
+}
+
+func TestGraphQLUpdateAuthorAPI(t *testing.T) {
+	author := randomAuthor(t)
+
+	query := `mutation($id: ID!, $version: Int!) {
+		updateAuthor(id: $id, version: $version, input: {firstName: "Ursula"}) { firstName version }
+	}`
+
+	testCases := []struct {
+		name          string
+		version       int32
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(res graphQLRes, store *mockdb.MockStore)
+	}{
+		{
+			name:    "OK",
+			version: 1,
+			buildStubs: func(store *mockdb.MockStore) {
+				expectExecTx(store)
+				updated := author
+				updated.FirstName = "Ursula"
+				updated.Version = 2
+				store.EXPECT().UpdateAuthor(mock.Anything, mock.MatchedBy(func(arg db.UpdateAuthorParams) bool {
+					return arg.AuthorID == author.AuthorID && arg.Version.Int64 == 1 && arg.FirstName.String == "Ursula"
+				})).Return(updated, nil)
+				store.EXPECT().BumpAuthorBookVersions(mock.Anything, author.AuthorID).Return(nil)
+			},
+			checkResponse: func(res graphQLRes, store *mockdb.MockStore) {
+				store.AssertExpectations(t)
+				require.Empty(t, res.Errors)
+				require.JSONEq(t, `{"updateAuthor": {"firstName": "Ursula", "version": 2}}`, string(res.Data))
+			},
+		},
+		{
+			name:    "VersionChanged",
+			version: 1,
+			buildStubs: func(store *mockdb.MockStore) {
+				expectExecTx(store)
+				store.EXPECT().UpdateAuthor(mock.Anything, mock.Anything).Return(db.Author{}, db.ErrRecordNotFound)
+				store.EXPECT().GetAuthor(mock.Anything, mock.Anything).Return(author, nil)
+			},
+			checkResponse: func(res graphQLRes, store *mockdb.MockStore) {
+				store.AssertNotCalled(t, "BumpAuthorBookVersions", mock.Anything, mock.Anything)
+				require.Len(t, res.Errors, 1)
+				require.Equal(t, errVersionMismatch.Error(), res.Errors[0].Message)
+			},
+		},
+		{
+			name:    "NotFound",
+			version: 1,
+			buildStubs: func(store *mockdb.MockStore) {
+				expectExecTx(store)
+				store.EXPECT().UpdateAuthor(mock.Anything, mock.Anything).Return(db.Author{}, db.ErrRecordNotFound)
+				store.EXPECT().GetAuthor(mock.Anything, mock.Anything).Return(db.Author{}, db.ErrRecordNotFound)
+			},
+			checkResponse: func(res graphQLRes, store *mockdb.MockStore) {
+				require.Len(t, res.Errors, 1)
+				require.Equal(t, "author not found", res.Errors[0].Message)
+			},
+		},
+		{
+			name:    "InvalidVersion",
+			version: 0,
+			buildStubs: func(store *mockdb.MockStore) {
+			},
+			checkResponse: func(res graphQLRes, store *mockdb.MockStore) {
+				store.AssertNotCalled(t, "ExecTx", mock.Anything, mock.Anything)
+				require.Len(t, res.Errors, 1)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			store := mockdb.NewMockStore(t)
+			tc.buildStubs(store)
+
+			recorder := serveGraphQL(t, store, gin.H{
+				"query": query,
+				"variables": gin.H{
+					"id":      fmt.Sprint(author.AuthorID),
+					"version": tc.version,
+				},
+			})
+			require.Equal(t, http.StatusOK, recorder.Code)
+
+			tc.checkResponse(decodeGraphQLRes(t, recorder), store)
+		})
+	}
+}
+
+func TestGraphQLAPINoQuery(t *testing.T) {
+	store := mockdb.NewMockStore(t)
+
+	recorder := serveGraphQL(t, store, gin.H{"variables": gin.H{}})
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+}
+
+func serveGraphQL(t *testing.T, store *mockdb.MockStore, body gin.H) *httptest.ResponseRecorder {
+	handler := newTestHandler(t, store)
+
+	router := gin.Default()
+	router.POST("/graphql", handler.GraphQL)
+
+	data, err := json.Marshal(body)
+	require.NoError(t, err)
+
+	recorder := httptest.NewRecorder()
+	request, err := http.NewRequest(http.MethodPost, "/graphql", bytes.NewReader(data))
+	require.NoError(t, err)
+
+	router.ServeHTTP(recorder, request)
+
+	return recorder
+}
+
+func decodeGraphQLRes(t *testing.T, recorder *httptest.ResponseRecorder) graphQLRes {
+	var res graphQLRes
+	err := json.Unmarshal(recorder.Body.Bytes(), &res)
+	require.NoError(t, err)
+
+	return res
+}
@@ -9,22 +9,64 @@ type Handler interface {
 	ListBooks(ctx *gin.Context)
 	GetBook(ctx *gin.Context)
 	UpdateBook(ctx *gin.Context)
+	PatchBook(ctx *gin.Context)
 	DeleteBook(ctx *gin.Context)
+	RestoreBook(ctx *gin.Context)
+	UploadBookCover(ctx *gin.Context)
+	DeleteBookCover(ctx *gin.Context)
+	GetBookV2(ctx *gin.Context)
+	ListBooksV2(ctx *gin.Context)
 
 	CreateAuthor(ctx *gin.Context)
 	ListAuthors(ctx *gin.Context)
 	GetAuthor(ctx *gin.Context)
 	UpdateAuthor(ctx *gin.Context)
+	PatchAuthor(ctx *gin.Context)
 	DeleteAuthor(ctx *gin.Context)
+	RestoreAuthor(ctx *gin.Context)
 
 	CreatePublisher(ctx *gin.Context)
 	ListPublishers(ctx *gin.Context)
 	GetPublisher(ctx *gin.Context)
 	UpdatePublisher(ctx *gin.Context)
+	PatchPublisher(ctx *gin.Context)
 	DeletePublisher(ctx *gin.Context)
+	RestorePublisher(ctx *gin.Context)
+
+	ListChanges(ctx *gin.Context)
+	StreamEvents(ctx *gin.Context)
+	GraphQL(ctx *gin.Context)
+
+	CreateWebhook(ctx *gin.Context)
+	ListWebhooks(ctx *gin.Context)
+	DeleteWebhook(ctx *gin.Context)
+	ListWebhookDeliveries(ctx *gin.Context)
+	RedeliverWebhookDelivery(ctx *gin.Context)
+
+	GetCart(ctx *gin.Context)
+	AddCartItem(ctx *gin.Context)
+	UpdateCartItem(ctx *gin.Context)
+	RemoveCartItem(ctx *gin.Context)
+
+	CreateOrder(ctx *gin.Context)
+	ListOrders(ctx *gin.Context)
+	ListAllOrders(ctx *gin.Context)
+	GetOrder(ctx *gin.Context)
+	UpdateOrderStatus(ctx *gin.Context)
 
 	Index(ctx *gin.Context)
 
 	ShowBooks(ctx *gin.Context)
 	ShowBook(ctx *gin.Context)
+	ShowCover(ctx *gin.Context)
+	ShowCoverSVG(ctx *gin.Context)
+	ShowCoverPNG(ctx *gin.Context)
+
+	ShowCart(ctx *gin.Context)
+	AddToCart(ctx *gin.Context)
+	ChangeCartItem(ctx *gin.Context)
+	RemoveFromCart(ctx *gin.Context)
+	ShowCheckout(ctx *gin.Context)
+	SubmitCheckout(ctx *gin.Context)
+	ShowOrder(ctx *gin.Context)
 }
@@ -1,15 +1,47 @@
 package handlers
 
 import (
+	"context"
 	"testing"
 
+	"github.com/atsuyaourt/xyz-books/internal/auth"
 	db "github.com/atsuyaourt/xyz-books/internal/db/sqlc"
+	mockdb "github.com/atsuyaourt/xyz-books/internal/mocks/db"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
+const (
+	testAdminKey  = "test-admin-key"
+	testClientKey = "test-client-key"
+)
+
+// testClientKeys are the keys signing in clients, admins are clients too
+var testClientKeys = auth.NewKeys([]string{testAdminKey, testClientKey})
+
+// newTestRouter tells admins apart by testAdminKey and signs in the clients
+// of testClientKeys, and hands the services the context of the request like
+// the server does
+func newTestRouter() *gin.Engine {
+	router := gin.Default()
+	router.ContextWithFallback = true
+	router.Use(auth.Middleware(auth.NewKeys([]string{testAdminKey}), testClientKeys))
+
+	return router
+}
+
 func newTestHandler(t *testing.T, store db.Store) Handler {
 	h, err := NewDefaultHandler(store)
 	require.NoError(t, err)
 
 	return h
 }
+
+// expectExecTx runs the transactions of the service against the mock itself
+func expectExecTx(store *mockdb.MockStore) {
+	store.EXPECT().ExecTx(mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, fn func(db.Querier) error) error {
+			return fn(store)
+		})
+}
@@ -0,0 +1,172 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	db "github.com/atsuyaourt/xyz-books/internal/db/sqlc"
+	_ "github.com/atsuyaourt/xyz-books/internal/models" // types named in the swag annotations
+	"github.com/atsuyaourt/xyz-books/internal/services"
+	"github.com/gin-gonic/gin"
+)
+
+// CreateOrder
+//
+//	@Summary	Checkout the cart
+//	@Tags		orders
+//	@Accept		json
+//	@Produce	json
+//	@Param		req	body		services.CheckoutReq	true	"Checkout parameters"
+//	@Success	201	{object}	models.Order
+//	@Router		/orders [post]
+func (h *DefaultHandler) CreateOrder(ctx *gin.Context) {
+	var req services.CheckoutReq
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	res, err := h.service.Checkout(ctx, cartOwner(ctx), req)
+	if err != nil {
+		if errors.Is(err, db.ErrEmptyCart) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		if errors.Is(err, services.ErrPaymentFailed) {
+			ctx.JSON(http.StatusPaymentRequired, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, res)
+}
+
+type getOrderReq struct {
+	ID int64 `uri:"id" binding:"required,numeric"`
+}
+
+// orderOwner returns the owner of the cart of the request, who only sees
+// the orders checked out from it
+func orderOwner(ctx *gin.Context) *services.CartOwner {
+	owner := cartOwner(ctx)
+	return &owner
+}
+
+// GetOrder
+//
+//	@Summary	Get order
+//	@Tags		orders
+//	@Accept		json
+//	@Produce	json
+//	@Param		id	path		int	true	"order ID"
+//	@Success	200	{object}	models.Order
+//	@Router		/orders/{id} [get]
+func (h *DefaultHandler) GetOrder(ctx *gin.Context) {
+	var req getOrderReq
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	res, err := h.service.GetOrder(ctx, orderOwner(ctx), req.ID)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(errors.New("order not found")))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+// ListOrders
+//
+//	@Summary	List orders
+//	@Tags		orders
+//	@Accept		json
+//	@Produce	json
+//	@Param		req	query		services.ListOrdersReq	false	"List orders parameters"
+//	@Success	200	{object}	models.PaginatedOrders
+//	@Router		/orders [get]
+func (h *DefaultHandler) ListOrders(ctx *gin.Context) {
+	h.listOrders(ctx, orderOwner(ctx))
+}
+
+// ListAllOrders
+//
+//	@Summary	List the orders of every customer
+//	@Tags		admin
+//	@Accept		json
+//	@Produce	json
+//	@Param		req	query		services.ListOrdersReq	false	"List orders parameters"
+//	@Success	200	{object}	models.PaginatedOrders
+//	@Security	AdminKey
+//	@Router		/admin/orders [get]
+func (h *DefaultHandler) ListAllOrders(ctx *gin.Context) {
+	h.listOrders(ctx, nil)
+}
+
+func (h *DefaultHandler) listOrders(ctx *gin.Context, owner *services.CartOwner) {
+	var req services.ListOrdersReq
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	res, err := h.service.ListOrders(ctx, owner, req)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+type updateOrderStatusUri struct {
+	ID int64 `uri:"id" binding:"required,numeric"`
+}
+
+// UpdateOrderStatus
+//
+//	@Summary	Update order status
+//	@Tags		admin
+//	@Accept		json
+//	@Produce	json
+//	@Param		id	path		int								true	"order ID"
+//	@Param		req	body		services.UpdateOrderStatusReq	true	"Update order status parameters"
+//	@Success	200	{object}	models.Order
+//	@Security	AdminKey
+//	@Router		/admin/orders/{id}/status [put]
+func (h *DefaultHandler) UpdateOrderStatus(ctx *gin.Context) {
+	var uri updateOrderStatusUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req services.UpdateOrderStatusReq
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	res, err := h.service.UpdateOrderStatus(ctx, uri.ID, req)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(errors.New("order not found")))
+			return
+		}
+		if errors.Is(err, services.ErrInvalidStatusTransition) {
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
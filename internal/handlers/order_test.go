@@ -0,0 +1,377 @@
+package handlers
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	db "github.com/atsuyaourt/xyz-books/internal/db/sqlc"
+	mockdb "github.com/atsuyaourt/xyz-books/internal/mocks/db"
+	"github.com/atsuyaourt/xyz-books/internal/models"
+	"github.com/atsuyaourt/xyz-books/internal/services"
+	"github.com/atsuyaourt/xyz-books/internal/util"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateOrderAPI(t *testing.T) {
+	cart := db.Cart{
+		CartID: util.RandomInt(1, 100),
+		Token:  util.RandomString(32),
+	}
+	order := randomOrder(t)
+	body := gin.H{
+		"customer_name":    order.CustomerName,
+		"email":            order.Email,
+		"shipping_address": order.ShippingAddress,
+	}
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		payment       services.PaymentProvider
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recoder *httptest.ResponseRecorder, store *mockdb.MockStore)
+	}{
+		{
+			name:    "Default",
+			body:    body,
+			payment: services.NewFakePaymentProvider(),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetCartByToken(mock.Anything, cart.Token).
+					Return(cart, nil)
+				store.EXPECT().CheckoutTx(mock.Anything, mock.MatchedBy(func(arg db.CheckoutTxParams) bool {
+					return arg.CartID == cart.CartID && arg.Order.Email == order.Email &&
+						arg.Order.CartToken.String == cart.Token
+				})).
+					Return(db.CheckoutTxResult{Order: order}, nil)
+				expectExecTx(store)
+				store.EXPECT().UpdateOrderStatus(mock.Anything, mock.MatchedBy(func(arg db.UpdateOrderStatusParams) bool {
+					return arg.OrderID == order.OrderID && arg.Status == "paid" && arg.PaymentRef.Valid
+				})).
+					Return(db.Order{OrderID: order.OrderID, Status: "paid"}, nil)
+				store.EXPECT().ClearCartItems(mock.Anything, cart.CartID).
+					Return(nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
+				store.AssertExpectations(t)
+				require.Equal(t, http.StatusCreated, recorder.Code)
+			},
+		},
+		{
+			name:    "PaymentDeclined",
+			body:    body,
+			payment: &services.FakePaymentProvider{Decline: true},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetCartByToken(mock.Anything, cart.Token).
+					Return(cart, nil)
+				store.EXPECT().CheckoutTx(mock.Anything, mock.Anything).
+					Return(db.CheckoutTxResult{Order: order}, nil)
+				// the order is cancelled and the cart kept
+				store.EXPECT().UpdateOrderStatus(mock.Anything, db.UpdateOrderStatusParams{
+					OrderID:    order.OrderID,
+					Status:     "cancelled",
+					FromStatus: sql.NullString{String: "pending", Valid: true},
+				}).
+					Return(db.Order{OrderID: order.OrderID, Status: "cancelled"}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
+				store.AssertExpectations(t)
+				store.AssertNotCalled(t, "ClearCartItems", mock.Anything, mock.Anything)
+				require.Equal(t, http.StatusPaymentRequired, recorder.Code)
+			},
+		},
+		{
+			name:    "EmptyCart",
+			body:    body,
+			payment: services.NewFakePaymentProvider(),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetCartByToken(mock.Anything, cart.Token).
+					Return(cart, nil)
+				store.EXPECT().CheckoutTx(mock.Anything, mock.Anything).
+					Return(db.CheckoutTxResult{}, db.ErrEmptyCart)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
+				store.AssertExpectations(t)
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidEmail",
+			body: gin.H{
+				"customer_name":    order.CustomerName,
+				"email":            "not-an-email",
+				"shipping_address": order.ShippingAddress,
+			},
+			payment: services.NewFakePaymentProvider(),
+			buildStubs: func(store *mockdb.MockStore) {
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
+				store.AssertExpectations(t)
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			store := mockdb.NewMockStore(t)
+			tc.buildStubs(store)
+
+			handler, err := NewDefaultHandler(store, services.WithPaymentProvider(tc.payment))
+			require.NoError(t, err)
+
+			router := gin.Default()
+			router.POST("/orders", handler.CreateOrder)
+
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/orders", bytes.NewReader(data))
+			require.NoError(t, err)
+			request.AddCookie(&http.Cookie{Name: cartCookieName, Value: cart.Token})
+
+			router.ServeHTTP(recorder, request)
+
+			tc.checkResponse(recorder, store)
+		})
+	}
+}
+
+func TestGetOrderAPI(t *testing.T) {
+	token := util.RandomString(32)
+	order := randomOrder(t)
+	order.CartToken = sql.NullString{String: token, Valid: true}
+
+	testCases := []struct {
+		name       string
+		token      string
+		buildStubs func(store *mockdb.MockStore)
+		status     int
+	}{
+		{
+			name:  "Owner",
+			token: token,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetOrder(mock.Anything, order.OrderID).Return(order, nil)
+				store.EXPECT().ListOrderItems(mock.Anything, order.OrderID).Return([]db.OrderItem{}, nil)
+			},
+			status: http.StatusOK,
+		},
+		{
+			name:  "OtherCart",
+			token: util.RandomString(32),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetOrder(mock.Anything, order.OrderID).Return(order, nil)
+			},
+			status: http.StatusBadRequest,
+		},
+		{
+			name: "NoCart",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetOrder(mock.Anything, order.OrderID).Return(order, nil)
+			},
+			status: http.StatusBadRequest,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			store := mockdb.NewMockStore(t)
+			tc.buildStubs(store)
+
+			handler := newTestHandler(t, store)
+
+			router := gin.Default()
+			router.GET("/orders/:id", handler.GetOrder)
+
+			recorder := httptest.NewRecorder()
+			request, err := http.NewRequest(http.MethodGet, fmt.Sprintf("/orders/%d", order.OrderID), nil)
+			require.NoError(t, err)
+			if len(tc.token) > 0 {
+				request.AddCookie(&http.Cookie{Name: cartCookieName, Value: tc.token})
+			}
+
+			router.ServeHTTP(recorder, request)
+			require.Equal(t, tc.status, recorder.Code)
+		})
+	}
+}
+
+func TestListOrdersAPI(t *testing.T) {
+	token := util.RandomString(32)
+	orders := []db.Order{randomOrder(t), randomOrder(t)}
+	orders[1].OrderID = orders[0].OrderID + 1
+	items := []db.OrderItem{
+		{OrderID: orders[0].OrderID, Title: util.RandomString(12), Price: 10, Quantity: 1},
+		{OrderID: orders[1].OrderID, Title: util.RandomString(12), Price: 20, Quantity: 2},
+	}
+
+	store := mockdb.NewMockStore(t)
+	store.EXPECT().ListOrders(mock.Anything, mock.MatchedBy(func(arg db.ListOrdersParams) bool {
+		return arg.CartToken.String == token && !arg.UserID.Valid
+	})).Return(orders, nil)
+	store.EXPECT().ListOrderItemsByOrderIDs(mock.Anything, []int64{orders[0].OrderID, orders[1].OrderID}).
+		Return(items, nil).Once()
+	store.EXPECT().CountOrders(mock.Anything, mock.MatchedBy(func(arg db.CountOrdersParams) bool {
+		return arg.CartToken.String == token
+	})).Return(int64(2), nil)
+
+	handler := newTestHandler(t, store)
+	router := gin.Default()
+	router.GET("/orders", handler.ListOrders)
+
+	recorder := httptest.NewRecorder()
+	request, err := http.NewRequest(http.MethodGet, "/orders", nil)
+	require.NoError(t, err)
+	request.AddCookie(&http.Cookie{Name: cartCookieName, Value: token})
+	router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var res util.PaginatedList[models.Order]
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+	require.Len(t, res.Items, 2)
+	require.Len(t, res.Items[0].Items, 1)
+	require.Equal(t, items[1].Title, res.Items[1].Items[0].Title)
+}
+
+func TestUpdateOrderStatusAPI(t *testing.T) {
+	order := randomOrder(t)
+
+	testCases := []struct {
+		name          string
+		id            int64
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recoder *httptest.ResponseRecorder, store *mockdb.MockStore)
+	}{
+		{
+			name: "Default",
+			id:   order.OrderID,
+			body: gin.H{
+				"status": "paid",
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetOrder(mock.Anything, order.OrderID).
+					Return(order, nil)
+				store.EXPECT().UpdateOrderStatus(mock.Anything, mock.MatchedBy(func(arg db.UpdateOrderStatusParams) bool {
+					return arg.Status == "paid" && arg.FromStatus.String == "pending"
+				})).
+					Return(db.Order{OrderID: order.OrderID, Status: "paid"}, nil)
+				store.EXPECT().ListOrderItems(mock.Anything, order.OrderID).
+					Return([]db.OrderItem{}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
+				store.AssertExpectations(t)
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidTransition",
+			id:   order.OrderID,
+			body: gin.H{
+				"status": "delivered",
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetOrder(mock.Anything, order.OrderID).
+					Return(order, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
+				store.AssertExpectations(t)
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "UnknownStatus",
+			id:   order.OrderID,
+			body: gin.H{
+				"status": "lost",
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
+				store.AssertExpectations(t)
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "NotFound",
+			id:   order.OrderID,
+			body: gin.H{
+				"status": "paid",
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetOrder(mock.Anything, order.OrderID).
+					Return(db.Order{}, db.ErrRecordNotFound)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
+				store.AssertExpectations(t)
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "InternalError",
+			id:   order.OrderID,
+			body: gin.H{
+				"status": "paid",
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetOrder(mock.Anything, order.OrderID).
+					Return(db.Order{}, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
+				store.AssertExpectations(t)
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			store := mockdb.NewMockStore(t)
+			tc.buildStubs(store)
+
+			handler := newTestHandler(t, store)
+
+			router := gin.Default()
+			router.PUT("/orders/:id/status", handler.UpdateOrderStatus)
+
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/orders/%d/status", tc.id)
+			request, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			router.ServeHTTP(recorder, request)
+
+			tc.checkResponse(recorder, store)
+		})
+	}
+}
+
+func randomOrder(t *testing.T) db.Order {
+	return db.Order{
+		OrderID:         util.RandomInt(1, 1000),
+		CustomerName:    util.RandomString(12),
+		Email:           util.RandomString(8) + "@example.com",
+		ShippingAddress: util.RandomString(24),
+		Status:          "pending",
+		Total:           float64(util.RandomFloat(10.0, 1500.0)),
+	}
+}
@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+
+	"github.com/atsuyaourt/xyz-books/internal/util"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+const mergePatchContentType = "application/merge-patch+json"
+
+var errMergePatchRequired = errors.New("content type must be " + mergePatchContentType)
+
+func init() {
+	// binding tags on util.Patch members validate the value they set
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		v.RegisterCustomTypeFunc(patchValidationValue,
+			util.Patch[string]{},
+			util.Patch[int32]{},
+			util.Patch[int64]{},
+			util.Patch[float64]{},
+			util.Patch[[]string]{},
+		)
+	}
+}
+
+func patchValidationValue(field reflect.Value) any {
+	if p, ok := field.Interface().(interface{ ValidationValue() any }); ok {
+		return p.ValidationValue()
+	}
+
+	return nil
+}
+
+// requireMergePatch answers 415 unless the body is a JSON merge patch
+func requireMergePatch(ctx *gin.Context) bool {
+	if ctx.ContentType() == mergePatchContentType {
+		return true
+	}
+
+	ctx.JSON(http.StatusUnsupportedMediaType, errorResponse(errMergePatchRequired))
+	return false
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"net/http"
 
+	"github.com/atsuyaourt/xyz-books/internal/auth"
 	db "github.com/atsuyaourt/xyz-books/internal/db/sqlc"
 	"github.com/atsuyaourt/xyz-books/internal/models"
 	"github.com/atsuyaourt/xyz-books/internal/services"
@@ -51,8 +52,11 @@ type getPublisherReq struct {
 //	@Tags		publishers
 //	@Accept		json
 //	@Produce	json
-//	@Param		id	path		int	true	"publisher ID"
-//	@Success	200		{object}	models.Publisher
+//	@Param		id				path		int		true	"publisher ID"
+//	@Param		include_deleted	query		bool	false	"also find a deleted publisher, admins only"
+//	@Param		If-None-Match	header		string	false	"ETag of a cached copy"
+//	@Success	200				{object}	models.Publisher
+//	@Success	304
 //	@Router		/publishers/{id} [get]
 func (h *DefaultHandler) GetPublisher(ctx *gin.Context) {
 	var req getPublisherReq
@@ -61,8 +65,18 @@ func (h *DefaultHandler) GetPublisher(ctx *gin.Context) {
 		return
 	}
 
-	res, err := h.service.GetPublisher(ctx, req.ID)
+	var query includeDeletedQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	res, err := h.service.GetPublisher(ctx, req.ID, query.IncludeDeleted)
 	if err != nil {
+		if errors.Is(err, auth.ErrAdminRequired) {
+			ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+			return
+		}
 		if errors.Is(err, db.ErrRecordNotFound) {
 			ctx.JSON(http.StatusBadRequest, errorResponse(errors.New("publisher not found")))
 			return
@@ -71,6 +85,10 @@ func (h *DefaultHandler) GetPublisher(ctx *gin.Context) {
 		return
 	}
 
+	if notModified(ctx, res.Version) {
+		return
+	}
+
 	ctx.JSON(http.StatusOK, res)
 }
 
@@ -92,6 +110,10 @@ func (h *DefaultHandler) ListPublishers(ctx *gin.Context) {
 
 	res, err := h.service.ListPublishers(ctx, req)
 	if err != nil {
+		if errors.Is(err, auth.ErrAdminRequired) {
+			ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
@@ -109,9 +131,12 @@ type updatePublisherUri struct {
 //	@Tags		publishers
 //	@Accept		json
 //	@Produce	json
-//	@Param		id	path		int	true	"publisher ID"
-//	@Param		req		body		services.UpdatePublisherReq	true	"Update publisher parameters"
-//	@Success	200		{object}	models.Publisher
+//	@Param		id			path		int							true	"publisher ID"
+//	@Param		If-Match	header		string						true	"ETag of the publisher being changed"
+//	@Param		req			body		services.UpdatePublisherReq	true	"Update publisher parameters"
+//	@Success	200			{object}	models.Publisher
+//	@Failure	412
+//	@Failure	428
 //	@Router		/publishers/{id} [put]
 func (h *DefaultHandler) UpdatePublisher(ctx *gin.Context) {
 	var uri updatePublisherUri
@@ -126,8 +151,104 @@ func (h *DefaultHandler) UpdatePublisher(ctx *gin.Context) {
 		return
 	}
 
-	res, err := h.service.UpdatePublisher(ctx, uri.ID, req)
+	version, ok := h.publisherVersion(ctx, uri.ID)
+	if !ok {
+		return
+	}
+
+	res, err := h.service.UpdatePublisher(ctx, uri.ID, version, req)
+	if err != nil {
+		if errors.Is(err, db.ErrVersionChanged) {
+			ctx.JSON(http.StatusPreconditionFailed, errorResponse(errVersionMismatch))
+			return
+		}
+		if errors.Is(err, db.ErrRecordNotFound) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(errors.New("publisher not found")))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.Header("ETag", entityTag(res.Version))
+	ctx.JSON(http.StatusOK, res)
+}
+
+// publisherVersion checks the If-Match header of a write against the current
+// publisher and returns the version the write must still find
+func (h *DefaultHandler) publisherVersion(ctx *gin.Context, id int64) (int64, bool) {
+	if !requireIfMatch(ctx) {
+		return 0, false
+	}
+
+	current, err := h.service.GetPublisher(ctx, id, false)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(errors.New("publisher not found")))
+			return 0, false
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return 0, false
+	}
+
+	if !ifMatch(ctx, current.Version) {
+		return 0, false
+	}
+
+	return current.Version, true
+}
+
+type patchPublisherUri struct {
+	ID int64 `uri:"id" binding:"required,numeric"`
+}
+
+// PatchPublisher
+//
+//	@Summary		Patch publisher
+//	@Description	Applies a JSON merge patch, members left out are unchanged and null clears a field
+//	@Tags			publishers
+//	@Accept			application/merge-patch+json
+//	@Produce		json
+//	@Param			id			path		int							true	"publisher ID"
+//	@Param			If-Match	header		string						true	"ETag of the publisher being changed"
+//	@Param			req			body		services.PatchPublisherReq	true	"Patch publisher parameters"
+//	@Success		200			{object}	models.Publisher
+//	@Failure		412
+//	@Failure		415
+//	@Failure		428
+//	@Router			/publishers/{id} [patch]
+func (h *DefaultHandler) PatchPublisher(ctx *gin.Context) {
+	var uri patchPublisherUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if !requireMergePatch(ctx) {
+		return
+	}
+
+	var req services.PatchPublisherReq
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	version, ok := h.publisherVersion(ctx, uri.ID)
+	if !ok {
+		return
+	}
+
+	res, err := h.service.PatchPublisher(ctx, uri.ID, version, req)
 	if err != nil {
+		if errors.Is(err, db.ErrVersionChanged) {
+			ctx.JSON(http.StatusPreconditionFailed, errorResponse(errVersionMismatch))
+			return
+		}
+		if errors.Is(err, services.ErrInvalidPatch) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
 		if errors.Is(err, db.ErrRecordNotFound) {
 			ctx.JSON(http.StatusBadRequest, errorResponse(errors.New("publisher not found")))
 			return
@@ -136,6 +257,7 @@ func (h *DefaultHandler) UpdatePublisher(ctx *gin.Context) {
 		return
 	}
 
+	ctx.Header("ETag", entityTag(res.Version))
 	ctx.JSON(http.StatusOK, res)
 }
 
@@ -149,8 +271,11 @@ type deletePublisherUri struct {
 //	@Tags		publishers
 //	@Accept		json
 //	@Produce	json
-//	@Param		id	path		int	true	"publisher ID"
+//	@Param		id			path	int		true	"publisher ID"
+//	@Param		If-Match	header	string	true	"ETag of the publisher being deleted"
 //	@Success	204
+//	@Failure	412
+//	@Failure	428
 //	@Router		/publishers/{id} [delete]
 func (h *DefaultHandler) DeletePublisher(ctx *gin.Context) {
 	var req deletePublisherUri
@@ -159,11 +284,58 @@ func (h *DefaultHandler) DeletePublisher(ctx *gin.Context) {
 		return
 	}
 
-	err := h.service.DeletePublisher(ctx, req.ID)
+	version, ok := h.publisherVersion(ctx, req.ID)
+	if !ok {
+		return
+	}
+
+	err := h.service.DeletePublisher(ctx, req.ID, version)
 	if err != nil {
+		if errors.Is(err, db.ErrVersionChanged) {
+			ctx.JSON(http.StatusPreconditionFailed, errorResponse(errVersionMismatch))
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
 
 	ctx.JSON(http.StatusNoContent, nil)
 }
+
+type restorePublisherUri struct {
+	ID int64 `uri:"id" binding:"required,numeric"`
+}
+
+// RestorePublisher
+//
+//	@Summary	Restore a deleted publisher
+//	@Tags		publishers
+//	@Accept		json
+//	@Produce	json
+//	@Param		id	path		int	true	"publisher ID"
+//	@Success	200	{object}	models.Publisher
+//	@Failure	409
+//	@Router		/publishers/{id}/restore [post]
+func (h *DefaultHandler) RestorePublisher(ctx *gin.Context) {
+	var req restorePublisherUri
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	res, err := h.service.RestorePublisher(ctx, req.ID)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(errors.New("publisher not found")))
+			return
+		}
+		if errors.Is(err, db.ErrNotDeleted) {
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
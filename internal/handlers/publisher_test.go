@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	db "github.com/atsuyaourt/xyz-books/internal/db/sqlc"
@@ -33,7 +34,7 @@ func TestCreatePublisherAPI(t *testing.T) {
 				"publisher_name": publisher.PublisherName,
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().CreatePublisher(mock.AnythingOfType("*gin.Context"), mock.Anything).
+				store.EXPECT().CreatePublisher(mock.Anything, mock.Anything).
 					Return(db.Publisher{}, nil)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
@@ -47,7 +48,7 @@ func TestCreatePublisherAPI(t *testing.T) {
 				"publisher_name": publisher.PublisherName,
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().CreatePublisher(mock.AnythingOfType("*gin.Context"), mock.Anything).
+				store.EXPECT().CreatePublisher(mock.Anything, mock.Anything).
 					Return(db.Publisher{}, sql.ErrConnDone)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
@@ -92,6 +93,7 @@ func TestGetPublisherAPI(t *testing.T) {
 	testCases := []struct {
 		name          string
 		id            int64
+		ifNoneMatch   string
 		buildStubs    func(store *mockdb.MockStore)
 		checkResponse func(recoder *httptest.ResponseRecorder, store *mockdb.MockStore)
 	}{
@@ -99,19 +101,33 @@ func TestGetPublisherAPI(t *testing.T) {
 			name: "Default",
 			id:   publisher.PublisherID,
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().GetPublisher(mock.AnythingOfType("*gin.Context"), mock.Anything).
-					Return(db.Publisher{}, nil)
+				store.EXPECT().GetPublisher(mock.Anything, mock.Anything).
+					Return(publisher, nil)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
 				store.AssertExpectations(t)
 				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Equal(t, `"1"`, recorder.Header().Get("ETag"))
+			},
+		},
+		{
+			name:        "NotModified",
+			id:          publisher.PublisherID,
+			ifNoneMatch: `"1"`,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetPublisher(mock.Anything, mock.Anything).
+					Return(publisher, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
+				store.AssertExpectations(t)
+				require.Equal(t, http.StatusNotModified, recorder.Code)
 			},
 		},
 		{
 			name: "InternalError",
 			id:   publisher.PublisherID,
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().GetPublisher(mock.AnythingOfType("*gin.Context"), mock.Anything).
+				store.EXPECT().GetPublisher(mock.Anything, mock.Anything).
 					Return(db.Publisher{}, sql.ErrConnDone)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
@@ -123,7 +139,7 @@ func TestGetPublisherAPI(t *testing.T) {
 			name: "NotFound",
 			id:   publisher.PublisherID,
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().GetPublisher(mock.AnythingOfType("*gin.Context"), mock.Anything).
+				store.EXPECT().GetPublisher(mock.Anything, mock.Anything).
 					Return(db.Publisher{}, db.ErrRecordNotFound)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
@@ -150,6 +166,9 @@ func TestGetPublisherAPI(t *testing.T) {
 			url := fmt.Sprintf("/publishers/%d", tc.id)
 			request, err := http.NewRequest(http.MethodGet, url, nil)
 			require.NoError(t, err)
+			if len(tc.ifNoneMatch) > 0 {
+				request.Header.Set("If-None-Match", tc.ifNoneMatch)
+			}
 
 			router.ServeHTTP(recorder, request)
 
@@ -178,9 +197,9 @@ func TestListPublishersAPI(t *testing.T) {
 				PerPage: int32(n),
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().ListPublishers(mock.AnythingOfType("*gin.Context"), mock.Anything).
+				store.EXPECT().ListPublishers(mock.Anything, mock.Anything).
 					Return(publishers, nil)
-				store.EXPECT().CountPublishers(mock.AnythingOfType("*gin.Context")).Return(int64(n), nil)
+				store.EXPECT().CountPublishers(mock.Anything, db.CountPublishersParams{}).Return(int64(n), nil)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
 				store.AssertExpectations(t)
@@ -194,7 +213,7 @@ func TestListPublishersAPI(t *testing.T) {
 				PerPage: int32(n),
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().ListPublishers(mock.AnythingOfType("*gin.Context"), mock.Anything).
+				store.EXPECT().ListPublishers(mock.Anything, mock.Anything).
 					Return([]db.Publisher{}, sql.ErrConnDone)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
@@ -211,7 +230,7 @@ func TestListPublishersAPI(t *testing.T) {
 			buildStubs: func(store *mockdb.MockStore) {
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
-				store.AssertNotCalled(t, "ListPublishers", mock.AnythingOfType("*gin.Context"), mock.Anything)
+				store.AssertNotCalled(t, "ListPublishers", mock.Anything, mock.Anything)
 				require.Equal(t, http.StatusBadRequest, recorder.Code)
 			},
 		},
@@ -224,7 +243,7 @@ func TestListPublishersAPI(t *testing.T) {
 			buildStubs: func(store *mockdb.MockStore) {
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
-				store.AssertNotCalled(t, "ListPublishers", mock.AnythingOfType("*gin.Context"), mock.Anything)
+				store.AssertNotCalled(t, "ListPublishers", mock.Anything, mock.Anything)
 				require.Equal(t, http.StatusBadRequest, recorder.Code)
 			},
 		},
@@ -235,9 +254,9 @@ func TestListPublishersAPI(t *testing.T) {
 				PerPage: int32(n),
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().ListPublishers(mock.AnythingOfType("*gin.Context"), mock.Anything).
+				store.EXPECT().ListPublishers(mock.Anything, mock.Anything).
 					Return([]db.Publisher{}, nil)
-				store.EXPECT().CountPublishers(mock.AnythingOfType("*gin.Context")).Return(int64(n), nil)
+				store.EXPECT().CountPublishers(mock.Anything, db.CountPublishersParams{}).Return(int64(n), nil)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
 				store.AssertExpectations(t)
@@ -251,9 +270,9 @@ func TestListPublishersAPI(t *testing.T) {
 				PerPage: int32(n),
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().ListPublishers(mock.AnythingOfType("*gin.Context"), mock.Anything).
+				store.EXPECT().ListPublishers(mock.Anything, mock.Anything).
 					Return([]db.Publisher{}, nil)
-				store.EXPECT().CountPublishers(mock.AnythingOfType("*gin.Context")).Return(0, sql.ErrConnDone)
+				store.EXPECT().CountPublishers(mock.Anything, db.CountPublishersParams{}).Return(0, sql.ErrConnDone)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
 				store.AssertExpectations(t)
@@ -300,19 +319,28 @@ func TestUpdatePublisherAPI(t *testing.T) {
 	testCases := []struct {
 		name          string
 		id            int64
+		ifMatch       string
 		body          gin.H
 		buildStubs    func(store *mockdb.MockStore)
 		checkResponse func(recoder *httptest.ResponseRecorder, store *mockdb.MockStore)
 	}{
 		{
-			name: "Default",
-			id:   publisher.PublisherID,
+			name:    "Default",
+			id:      publisher.PublisherID,
+			ifMatch: `"1"`,
 			body: gin.H{
 				"publisher_name": updatedPublisher.PublisherName,
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().UpdatePublisher(mock.AnythingOfType("*gin.Context"), mock.Anything).
-					Return(db.Publisher{}, nil)
+				store.EXPECT().GetPublisher(mock.Anything, mock.Anything).
+					Return(publisher, nil)
+				expectExecTx(store)
+				store.EXPECT().UpdatePublisher(mock.Anything, mock.MatchedBy(func(arg db.UpdatePublisherParams) bool {
+					return arg.Version.Valid && arg.Version.Int64 == publisher.Version
+				})).
+					Return(publisher, nil)
+				store.EXPECT().BumpPublisherBookVersions(mock.Anything, publisher.PublisherID).
+					Return(nil)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
 				store.AssertExpectations(t)
@@ -320,13 +348,17 @@ func TestUpdatePublisherAPI(t *testing.T) {
 			},
 		},
 		{
-			name: "InternalError",
-			id:   publisher.PublisherID,
+			name:    "InternalError",
+			id:      publisher.PublisherID,
+			ifMatch: `"1"`,
 			body: gin.H{
 				"publisher_name": updatedPublisher.PublisherName,
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().UpdatePublisher(mock.AnythingOfType("*gin.Context"), mock.Anything).
+				store.EXPECT().GetPublisher(mock.Anything, mock.Anything).
+					Return(publisher, nil)
+				expectExecTx(store)
+				store.EXPECT().UpdatePublisher(mock.Anything, mock.Anything).
 					Return(db.Publisher{}, sql.ErrConnDone)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
@@ -335,13 +367,14 @@ func TestUpdatePublisherAPI(t *testing.T) {
 			},
 		},
 		{
-			name: "NotFound",
-			id:   publisher.PublisherID,
+			name:    "NotFound",
+			id:      publisher.PublisherID,
+			ifMatch: `"1"`,
 			body: gin.H{
 				"publisher_name": updatedPublisher.PublisherName,
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().UpdatePublisher(mock.AnythingOfType("*gin.Context"), mock.Anything).
+				store.EXPECT().GetPublisher(mock.Anything, mock.Anything).
 					Return(db.Publisher{}, db.ErrRecordNotFound)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
@@ -349,6 +382,35 @@ func TestUpdatePublisherAPI(t *testing.T) {
 				require.Equal(t, http.StatusBadRequest, recorder.Code)
 			},
 		},
+		{
+			name: "MissingIfMatch",
+			id:   publisher.PublisherID,
+			body: gin.H{
+				"publisher_name": updatedPublisher.PublisherName,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
+				store.AssertExpectations(t)
+				require.Equal(t, http.StatusPreconditionRequired, recorder.Code)
+			},
+		},
+		{
+			name:    "StaleIfMatch",
+			id:      publisher.PublisherID,
+			ifMatch: `"0"`,
+			body: gin.H{
+				"publisher_name": updatedPublisher.PublisherName,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetPublisher(mock.Anything, mock.Anything).
+					Return(publisher, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
+				store.AssertExpectations(t)
+				require.Equal(t, http.StatusPreconditionFailed, recorder.Code)
+			},
+		},
 	}
 
 	for i := range testCases {
@@ -372,6 +434,9 @@ func TestUpdatePublisherAPI(t *testing.T) {
 			url := fmt.Sprintf("/publishers/%d", tc.id)
 			request, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(data))
 			require.NoError(t, err)
+			if len(tc.ifMatch) > 0 {
+				request.Header.Set("If-Match", tc.ifMatch)
+			}
 
 			router.ServeHTTP(recorder, request)
 
@@ -380,39 +445,130 @@ func TestUpdatePublisherAPI(t *testing.T) {
 	}
 }
 
-func TestDeletePublisherAPI(t *testing.T) {
+func TestPatchPublisherAPI(t *testing.T) {
 	publisher := randomPublisher(t)
 
 	testCases := []struct {
 		name          string
-		id            int64
+		body          string
 		buildStubs    func(store *mockdb.MockStore)
 		checkResponse func(recoder *httptest.ResponseRecorder, store *mockdb.MockStore)
 	}{
 		{
 			name: "Default",
-			id:   publisher.PublisherID,
+			body: `{"publisher_name": "New name"}`,
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().DeletePublisher(mock.AnythingOfType("*gin.Context"), mock.Anything).
+				store.EXPECT().GetPublisher(mock.Anything, mock.Anything).
+					Return(publisher, nil)
+				expectExecTx(store)
+				store.EXPECT().UpdatePublisher(mock.Anything, mock.MatchedBy(func(arg db.UpdatePublisherParams) bool {
+					return arg.PublisherName.String == "New name"
+				})).
+					Return(publisher, nil)
+				store.EXPECT().BumpPublisherBookVersions(mock.Anything, publisher.PublisherID).
 					Return(nil)
 			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
+				store.AssertExpectations(t)
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "NullPublisherName",
+			body: `{"publisher_name": null}`,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetPublisher(mock.Anything, mock.Anything).
+					Return(publisher, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
+				store.AssertExpectations(t)
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			store := mockdb.NewMockStore(t)
+			tc.buildStubs(store)
+
+			handler := newTestHandler(t, store)
+
+			router := gin.Default()
+			router.PATCH("/publishers/:id", handler.PatchPublisher)
+
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/publishers/%d", publisher.PublisherID)
+			request, err := http.NewRequest(http.MethodPatch, url, strings.NewReader(tc.body))
+			require.NoError(t, err)
+			request.Header.Set("Content-Type", "application/merge-patch+json")
+			request.Header.Set("If-Match", `"1"`)
+
+			router.ServeHTTP(recorder, request)
+
+			tc.checkResponse(recorder, store)
+		})
+	}
+}
+
+func TestDeletePublisherAPI(t *testing.T) {
+	publisher := randomPublisher(t)
+
+	testCases := []struct {
+		name          string
+		id            int64
+		ifMatch       string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recoder *httptest.ResponseRecorder, store *mockdb.MockStore)
+	}{
+		{
+			name:    "Default",
+			id:      publisher.PublisherID,
+			ifMatch: `"1"`,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetPublisher(mock.Anything, mock.Anything).
+					Return(publisher, nil)
+				expectExecTx(store)
+				store.EXPECT().DeletePublisher(mock.Anything, db.DeletePublisherParams{
+					PublisherID: publisher.PublisherID,
+					Version:     sql.NullInt64{Int64: publisher.Version, Valid: true},
+				}).
+					Return(1, nil)
+			},
 			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
 				store.AssertExpectations(t)
 				require.Equal(t, http.StatusNoContent, recorder.Code)
 			},
 		},
 		{
-			name: "InternalError",
-			id:   publisher.PublisherID,
+			name:    "InternalError",
+			id:      publisher.PublisherID,
+			ifMatch: `"1"`,
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().DeletePublisher(mock.AnythingOfType("*gin.Context"), mock.Anything).
-					Return(sql.ErrConnDone)
+				store.EXPECT().GetPublisher(mock.Anything, mock.Anything).
+					Return(publisher, nil)
+				expectExecTx(store)
+				store.EXPECT().DeletePublisher(mock.Anything, mock.Anything).
+					Return(0, sql.ErrConnDone)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
 				store.AssertExpectations(t)
 				require.Equal(t, http.StatusInternalServerError, recorder.Code)
 			},
 		},
+		{
+			name: "MissingIfMatch",
+			id:   publisher.PublisherID,
+			buildStubs: func(store *mockdb.MockStore) {
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
+				store.AssertExpectations(t)
+				require.Equal(t, http.StatusPreconditionRequired, recorder.Code)
+			},
+		},
 	}
 
 	for i := range testCases {
@@ -432,6 +588,9 @@ func TestDeletePublisherAPI(t *testing.T) {
 			url := fmt.Sprintf("/publishers/%d", tc.id)
 			request, err := http.NewRequest(http.MethodDelete, url, nil)
 			require.NoError(t, err)
+			if len(tc.ifMatch) > 0 {
+				request.Header.Set("If-Match", tc.ifMatch)
+			}
 
 			router.ServeHTTP(recorder, request)
 
@@ -444,5 +603,6 @@ func randomPublisher(t *testing.T) db.Publisher {
 	return db.Publisher{
 		PublisherID:   util.RandomInt(1, 111),
 		PublisherName: util.RandomString(24),
+		Version:       1,
 	}
 }
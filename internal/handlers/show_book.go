@@ -19,6 +19,8 @@ func (h *DefaultHandler) ShowBooks(ctx *gin.Context) {
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
 	}
+	// the pages only show the books on sale
+	req.IncludeDeleted = false
 
 	res, err := h.service.ListBooks(ctx, req)
 	if err != nil {
@@ -46,7 +48,7 @@ func (h *DefaultHandler) ShowBook(ctx *gin.Context) {
 		return
 	}
 
-	res, err := h.service.GetBook(ctx, req.ISBN13)
+	res, err := h.service.GetBook(ctx, req.ISBN13, false)
 	if err != nil {
 		if errors.Is(err, db.ErrRecordNotFound) {
 			ctx.JSON(http.StatusBadRequest, errorResponse(errors.New("book not found")))
@@ -0,0 +1,137 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	db "github.com/atsuyaourt/xyz-books/internal/db/sqlc"
+	"github.com/atsuyaourt/xyz-books/internal/services"
+	"github.com/atsuyaourt/xyz-books/internal/views"
+	"github.com/atsuyaourt/xyz-books/internal/views/components"
+	"github.com/gin-gonic/gin"
+)
+
+func (h *DefaultHandler) ShowCart(ctx *gin.Context) {
+	res, err := h.service.GetCart(ctx, cartOwner(ctx))
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	render(ctx, http.StatusOK, views.Cart(res))
+}
+
+func (h *DefaultHandler) AddToCart(ctx *gin.Context) {
+	var req services.AddCartItemReq
+	if err := ctx.ShouldBind(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	res, err := h.service.AddCartItem(ctx, cartOwner(ctx), req)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(errors.New("book not found")))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	render(ctx, http.StatusOK, components.AddedToCart(*res))
+}
+
+func (h *DefaultHandler) ChangeCartItem(ctx *gin.Context) {
+	var uri cartItemUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req services.UpdateCartItemReq
+	if err := ctx.ShouldBind(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	res, err := h.service.UpdateCartItem(ctx, cartOwner(ctx), uri.ISBN13, req)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(errors.New("cart item not found")))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	render(ctx, http.StatusOK, components.Cart(*res))
+}
+
+func (h *DefaultHandler) RemoveFromCart(ctx *gin.Context) {
+	var uri cartItemUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	res, err := h.service.RemoveCartItem(ctx, cartOwner(ctx), uri.ISBN13)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(errors.New("book not found")))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	render(ctx, http.StatusOK, components.Cart(*res))
+}
+
+func (h *DefaultHandler) ShowCheckout(ctx *gin.Context) {
+	res, err := h.service.GetCart(ctx, cartOwner(ctx))
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	render(ctx, http.StatusOK, views.Checkout(res, ""))
+}
+
+func (h *DefaultHandler) SubmitCheckout(ctx *gin.Context) {
+	owner := cartOwner(ctx)
+
+	var req services.CheckoutReq
+	if err := ctx.ShouldBind(&req); err != nil {
+		cart, cartErr := h.service.GetCart(ctx, owner)
+		if cartErr != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(cartErr))
+			return
+		}
+		render(ctx, http.StatusBadRequest, views.Checkout(cart, "Please fill in your name, a valid email and a shipping address."))
+		return
+	}
+
+	res, err := h.service.Checkout(ctx, owner, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrPaymentFailed):
+			// the cart is kept, so that the customer can try again
+			cart, cartErr := h.service.GetCart(ctx, owner)
+			if cartErr != nil {
+				ctx.JSON(http.StatusInternalServerError, errorResponse(cartErr))
+				return
+			}
+			render(ctx, http.StatusPaymentRequired, views.Checkout(cart, "The payment was declined, please try again."))
+			return
+		case errors.Is(err, db.ErrEmptyCart):
+			ctx.Redirect(http.StatusSeeOther, "/cart")
+			return
+		default:
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+	}
+
+	ctx.Redirect(http.StatusSeeOther, fmt.Sprintf("/orders/%d", res.OrderID))
+}
@@ -14,6 +14,8 @@ func (h *DefaultHandler) Index(ctx *gin.Context) {
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
 	}
+	// the pages only show the books on sale
+	req.IncludeDeleted = false
 
 	res, err := h.service.ListBooks(ctx, req)
 	if err != nil {
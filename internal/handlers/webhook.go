@@ -0,0 +1,157 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	db "github.com/atsuyaourt/xyz-books/internal/db/sqlc"
+	_ "github.com/atsuyaourt/xyz-books/internal/models" // types named in the swag annotations
+	"github.com/atsuyaourt/xyz-books/internal/services"
+	"github.com/gin-gonic/gin"
+)
+
+// CreateWebhook
+//
+//	@Summary		Create webhook
+//	@Description	Subscribes a public http or https URL to catalog events. Deliveries are signed with the secret, which is only returned here.
+//	@Tags			webhooks
+//	@Accept			json
+//	@Produce		json
+//	@Param			req	body		services.CreateWebhookReq	true	"Create webhook parameters"
+//	@Success		201	{object}	models.Webhook
+//	@Security		AdminKey
+//	@Router			/webhooks [post]
+func (h *DefaultHandler) CreateWebhook(ctx *gin.Context) {
+	var req services.CreateWebhookReq
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	res, err := h.service.CreateWebhook(ctx, req)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidWebhookURL) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, res)
+}
+
+// ListWebhooks
+//
+//	@Summary	List webhooks
+//	@Tags		webhooks
+//	@Accept		json
+//	@Produce	json
+//	@Success	200	{array}	models.Webhook
+//	@Security	AdminKey
+//	@Router		/webhooks [get]
+func (h *DefaultHandler) ListWebhooks(ctx *gin.Context) {
+	res, err := h.service.ListWebhooks(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+type deleteWebhookUri struct {
+	ID int64 `uri:"id" binding:"required,numeric"`
+}
+
+// DeleteWebhook
+//
+//	@Summary	Delete webhook
+//	@Tags		webhooks
+//	@Accept		json
+//	@Produce	json
+//	@Param		id	path	int	true	"webhook ID"
+//	@Success	204
+//	@Security	AdminKey
+//	@Router		/webhooks/{id} [delete]
+func (h *DefaultHandler) DeleteWebhook(ctx *gin.Context) {
+	var req deleteWebhookUri
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	err := h.service.DeleteWebhook(ctx, req.ID)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(errors.New("webhook not found")))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusNoContent, nil)
+}
+
+// ListWebhookDeliveries
+//
+//	@Summary		List webhook deliveries
+//	@Description	Newest first, status=dead lists the deliveries that ran out of attempts
+//	@Tags			webhooks
+//	@Accept			json
+//	@Produce		json
+//	@Param			req	query		services.ListWebhookDeliveriesReq	false	"List webhook deliveries parameters"
+//	@Success		200	{object}	models.PaginatedWebhookDeliveries
+//	@Security		AdminKey
+//	@Router			/webhooks/deliveries [get]
+func (h *DefaultHandler) ListWebhookDeliveries(ctx *gin.Context) {
+	var req services.ListWebhookDeliveriesReq
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	res, err := h.service.ListWebhookDeliveries(ctx, req)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+type redeliverWebhookDeliveryUri struct {
+	ID int64 `uri:"id" binding:"required,numeric"`
+}
+
+// RedeliverWebhookDelivery
+//
+//	@Summary		Redeliver webhook delivery
+//	@Description	Queues a delivery, dead or not, to be sent again with a fresh set of attempts
+//	@Tags			webhooks
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		int	true	"delivery ID"
+//	@Success		202	{object}	models.WebhookDelivery
+//	@Security		AdminKey
+//	@Router			/webhooks/deliveries/{id}/redeliver [post]
+func (h *DefaultHandler) RedeliverWebhookDelivery(ctx *gin.Context) {
+	var req redeliverWebhookDeliveryUri
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	res, err := h.service.RedeliverWebhookDelivery(ctx, req.ID)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(errors.New("delivery not found")))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusAccepted, res)
+}
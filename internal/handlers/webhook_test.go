@@ -0,0 +1,355 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	db "github.com/atsuyaourt/xyz-books/internal/db/sqlc"
+	mockdb "github.com/atsuyaourt/xyz-books/internal/mocks/db"
+	"github.com/atsuyaourt/xyz-books/internal/models"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateWebhookAPI(t *testing.T) {
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore)
+	}{
+		{
+			name: "Default",
+			body: gin.H{
+				"url":    "https://example.com/hooks",
+				"events": []string{"price.changed", "book.created", "price.changed"},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateWebhook(mock.Anything, mock.MatchedBy(func(arg db.CreateWebhookParams) bool {
+					return arg.Url == "https://example.com/hooks" &&
+						arg.Events == "book.created,price.changed" &&
+						len(arg.Secret) > 0
+				})).RunAndReturn(func(_ context.Context, arg db.CreateWebhookParams) (db.Webhook, error) {
+					return db.Webhook{WebhookID: 1, Url: arg.Url, Secret: arg.Secret, Events: arg.Events}, nil
+				})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+
+				var res models.Webhook
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+				require.Equal(t, []models.EventType{models.EventBookCreated, models.EventPriceChanged}, res.Events)
+				require.NotEmpty(t, res.Secret)
+			},
+		},
+		{
+			name: "UnknownEvent",
+			body: gin.H{
+				"url":    "https://example.com/hooks",
+				"events": []string{"book.read"},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
+				store.AssertNotCalled(t, "CreateWebhook", mock.Anything, mock.Anything)
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "ShortSecret",
+			body: gin.H{
+				"url":    "https://example.com/hooks",
+				"secret": "short",
+				"events": []string{"book.created"},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
+				store.AssertNotCalled(t, "CreateWebhook", mock.Anything, mock.Anything)
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidUrl",
+			body: gin.H{
+				"url":    "example",
+				"events": []string{"book.created"},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
+				store.AssertNotCalled(t, "CreateWebhook", mock.Anything, mock.Anything)
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "PrivateUrl",
+			body: gin.H{
+				"url":    "http://169.254.169.254/latest/meta-data",
+				"events": []string{"book.created"},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
+				store.AssertNotCalled(t, "CreateWebhook", mock.Anything, mock.Anything)
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "InternalError",
+			body: gin.H{
+				"url":    "https://example.com/hooks",
+				"events": []string{"book.created"},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateWebhook(mock.Anything, mock.Anything).
+					Return(db.Webhook{}, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			store := mockdb.NewMockStore(t)
+			tc.buildStubs(store)
+
+			handler := newTestHandler(t, store)
+
+			router := gin.Default()
+			router.POST("/webhooks", handler.CreateWebhook)
+
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/webhooks", bytes.NewReader(data))
+			require.NoError(t, err)
+
+			router.ServeHTTP(recorder, request)
+
+			tc.checkResponse(recorder, store)
+		})
+	}
+}
+
+func TestDeleteWebhookAPI(t *testing.T) {
+	testCases := []struct {
+		name          string
+		id            int64
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore)
+	}{
+		{
+			name: "Default",
+			id:   1,
+			buildStubs: func(store *mockdb.MockStore) {
+				expectExecTx(store)
+				store.EXPECT().DeleteWebhookDeliveries(mock.Anything, int64(1)).
+					Return(nil)
+				store.EXPECT().DeleteWebhook(mock.Anything, int64(1)).
+					Return(1, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
+				require.Equal(t, http.StatusNoContent, recorder.Code)
+			},
+		},
+		{
+			name: "NotFound",
+			id:   2,
+			buildStubs: func(store *mockdb.MockStore) {
+				expectExecTx(store)
+				store.EXPECT().DeleteWebhookDeliveries(mock.Anything, int64(2)).
+					Return(nil)
+				store.EXPECT().DeleteWebhook(mock.Anything, int64(2)).
+					Return(0, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "webhook not found")
+			},
+		},
+		{
+			name: "InternalError",
+			id:   1,
+			buildStubs: func(store *mockdb.MockStore) {
+				expectExecTx(store)
+				store.EXPECT().DeleteWebhookDeliveries(mock.Anything, int64(1)).
+					Return(sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
+				store.AssertNotCalled(t, "DeleteWebhook", mock.Anything, mock.Anything)
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			store := mockdb.NewMockStore(t)
+			tc.buildStubs(store)
+
+			handler := newTestHandler(t, store)
+
+			router := gin.Default()
+			router.DELETE("/webhooks/:id", handler.DeleteWebhook)
+
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/webhooks/%d", tc.id)
+			request, err := http.NewRequest(http.MethodDelete, url, nil)
+			require.NoError(t, err)
+
+			router.ServeHTTP(recorder, request)
+
+			tc.checkResponse(recorder, store)
+		})
+	}
+}
+
+func TestListWebhookDeliveriesAPI(t *testing.T) {
+	testCases := []struct {
+		name          string
+		query         string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore)
+	}{
+		{
+			name:  "Dead",
+			query: "status=dead&page=2&per_page=10",
+			buildStubs: func(store *mockdb.MockStore) {
+				status := sql.NullString{String: "dead", Valid: true}
+				store.EXPECT().ListWebhookDeliveries(mock.Anything, db.ListWebhookDeliveriesParams{
+					Status: status,
+					Limit:  10,
+					Offset: 10,
+				}).Return([]db.WebhookDelivery{
+					{DeliveryID: 3, Status: "dead", Attempts: 8, Payload: `{"id":"e1"}`},
+				}, nil)
+				store.EXPECT().CountWebhookDeliveries(mock.Anything, db.CountWebhookDeliveriesParams{
+					Status: status,
+				}).Return(11, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var res models.PaginatedWebhookDeliveries
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+				require.Len(t, res.Items, 1)
+				require.Equal(t, models.WebhookDeliveryDead, res.Items[0].Status)
+				require.Nil(t, res.Items[0].NextAttemptAt)
+				require.JSONEq(t, `{"id":"e1"}`, string(res.Items[0].Payload))
+			},
+		},
+		{
+			name:  "InvalidStatus",
+			query: "status=lost",
+			buildStubs: func(store *mockdb.MockStore) {
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
+				store.AssertNotCalled(t, "ListWebhookDeliveries", mock.Anything, mock.Anything)
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			store := mockdb.NewMockStore(t)
+			tc.buildStubs(store)
+
+			handler := newTestHandler(t, store)
+
+			router := gin.Default()
+			router.GET("/webhooks/deliveries", handler.ListWebhookDeliveries)
+
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodGet, "/webhooks/deliveries?"+tc.query, nil)
+			require.NoError(t, err)
+
+			router.ServeHTTP(recorder, request)
+
+			tc.checkResponse(recorder, store)
+		})
+	}
+}
+
+func TestRedeliverWebhookDeliveryAPI(t *testing.T) {
+	testCases := []struct {
+		name          string
+		id            int64
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore)
+	}{
+		{
+			name: "Default",
+			id:   3,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().RedeliverWebhookDelivery(mock.Anything, mock.MatchedBy(func(arg db.RedeliverWebhookDeliveryParams) bool {
+					return arg.DeliveryID == 3
+				})).Return(db.WebhookDelivery{DeliveryID: 3, Status: "pending", Payload: "{}"}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
+				require.Equal(t, http.StatusAccepted, recorder.Code)
+
+				var res models.WebhookDelivery
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+				require.Equal(t, models.WebhookDeliveryPending, res.Status)
+				require.NotNil(t, res.NextAttemptAt)
+			},
+		},
+		{
+			name: "NotFound",
+			id:   4,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().RedeliverWebhookDelivery(mock.Anything, mock.Anything).
+					Return(db.WebhookDelivery{}, db.ErrRecordNotFound)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, store *mockdb.MockStore) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "delivery not found")
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			store := mockdb.NewMockStore(t)
+			tc.buildStubs(store)
+
+			handler := newTestHandler(t, store)
+
+			router := gin.Default()
+			router.POST("/webhooks/deliveries/:id/redeliver", handler.RedeliverWebhookDelivery)
+
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/webhooks/deliveries/%d/redeliver", tc.id)
+			request, err := http.NewRequest(http.MethodPost, url, nil)
+			require.NoError(t, err)
+
+			router.ServeHTTP(recorder, request)
+
+			tc.checkResponse(recorder, store)
+		})
+	}
+}
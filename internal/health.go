@@ -0,0 +1,80 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/atsuyaourt/xyz-books/internal/buildinfo"
+	"github.com/atsuyaourt/xyz-books/internal/logging"
+	"github.com/gin-gonic/gin"
+)
+
+// readyTimeout bounds the checks of a readiness probe
+const readyTimeout = 2 * time.Second
+
+const checkOK = "ok"
+
+type readiness struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks"`
+}
+
+// healthz answers as long as the process serves requests
+func (s *Server) healthz(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, gin.H{"status": checkOK})
+}
+
+// readyz answers 503 until the database can be reached, is migrated to the
+// schema the server was built for and can be written to. Failures are
+// logged, the response only names the checks that failed.
+func (s *Server) readyz(ctx *gin.Context) {
+	checkCtx, cancel := context.WithTimeout(ctx, readyTimeout)
+	defer cancel()
+
+	res := readiness{Status: "ready", Checks: map[string]string{
+		"database": checkOK,
+		"schema":   checkOK,
+		"writable": checkOK,
+	}}
+	fail := func(check, msg string, err error) {
+		res.Status = "unavailable"
+		res.Checks[check] = msg
+		if err != nil {
+			logging.FromContext(ctx).Warn("readiness check failed", slog.String("check", check), slog.Any("error", err))
+		}
+	}
+
+	if err := s.store.Ping(checkCtx); err != nil {
+		fail("database", "unreachable", err)
+		res.Checks["schema"] = "skipped"
+		res.Checks["writable"] = "skipped"
+	} else {
+		version, err := s.store.SchemaVersion(checkCtx)
+		switch {
+		case err != nil:
+			fail("schema", "unknown", err)
+		case version.Dirty:
+			fail("schema", fmt.Sprintf("migration %d failed", version.Version), nil)
+		case version.Version != s.schemaVersion:
+			fail("schema", fmt.Sprintf("version %d, expected %d", version.Version, s.schemaVersion), nil)
+		}
+
+		if err := s.store.CheckWritable(checkCtx); err != nil {
+			fail("writable", "read-only", err)
+		}
+	}
+
+	status := http.StatusOK
+	if res.Status != "ready" {
+		status = http.StatusServiceUnavailable
+	}
+	ctx.JSON(status, res)
+}
+
+// version describes the build of the server
+func (s *Server) version(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, buildinfo.Get(s.schemaVersion))
+}
@@ -0,0 +1,113 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	var buf bytes.Buffer
+
+	logger, err := New(&buf, "", "")
+	require.NoError(t, err)
+	logger.Debug("hidden")
+	logger.Info("shown", slog.Int("n", 1))
+
+	var line map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &line))
+	require.Equal(t, "shown", line["msg"])
+	require.Equal(t, "INFO", line["level"])
+
+	buf.Reset()
+	logger, err = New(&buf, "debug", "text")
+	require.NoError(t, err)
+	logger.Debug("shown")
+	require.Contains(t, buf.String(), "level=DEBUG msg=shown")
+
+	_, err = New(&buf, "loud", "json")
+	require.Error(t, err)
+	_, err = New(&buf, "info", "xml")
+	require.Error(t, err)
+}
+
+func TestFromContext(t *testing.T) {
+	require.Equal(t, slog.Default(), FromContext(context.Background()))
+
+	logger := slog.New(slog.NewTextHandler(&bytes.Buffer{}, nil))
+	require.Equal(t, logger, FromContext(WithLogger(context.Background(), logger)))
+}
+
+func TestMiddleware(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := New(&buf, "info", "json")
+	require.NoError(t, err)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.ContextWithFallback = true
+	router.Use(Middleware(logger), Recovery())
+	router.GET("/books", func(ctx *gin.Context) {
+		FromContext(ctx).Info("listing books")
+		ctx.Status(http.StatusOK)
+	})
+	router.GET("/panic", func(ctx *gin.Context) {
+		panic("boom")
+	})
+
+	// lines sends a request to path and returns the lines it logged
+	lines := func(path, id string) (*httptest.ResponseRecorder, []map[string]any) {
+		buf.Reset()
+		request := httptest.NewRequest(http.MethodGet, path, nil)
+		if len(id) > 0 {
+			request.Header.Set(RequestIDHeader, id)
+		}
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+
+		var res []map[string]any
+		for _, l := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+			var line map[string]any
+			require.NoError(t, json.Unmarshal([]byte(l), &line))
+			res = append(res, line)
+		}
+		return recorder, res
+	}
+
+	t.Run("Propagated", func(t *testing.T) {
+		recorder, logs := lines("/books", "abc-123")
+		require.Equal(t, "abc-123", recorder.Header().Get(RequestIDHeader))
+		require.Len(t, logs, 2)
+		require.Equal(t, "listing books", logs[0]["msg"])
+		require.Equal(t, "request", logs[1]["msg"])
+		require.Equal(t, "/books", logs[1]["route"])
+		require.EqualValues(t, http.StatusOK, logs[1]["status"])
+		for _, line := range logs {
+			require.Equal(t, "abc-123", line["request_id"])
+		}
+	})
+
+	t.Run("Generated", func(t *testing.T) {
+		recorder, logs := lines("/books", "not valid\n")
+		id := recorder.Header().Get(RequestIDHeader)
+		require.Len(t, id, 32)
+		require.Equal(t, id, logs[1]["request_id"])
+	})
+
+	t.Run("Panic", func(t *testing.T) {
+		recorder, logs := lines("/panic", "p-1")
+		require.Equal(t, http.StatusInternalServerError, recorder.Code)
+		require.Len(t, logs, 2)
+		require.Equal(t, "panic", logs[0]["msg"])
+		require.Equal(t, "ERROR", logs[1]["level"])
+		require.Equal(t, "p-1", logs[0]["request_id"])
+	})
+}
@@ -0,0 +1,73 @@
+package logging
+
+import (
+	"log/slog"
+	"net/http"
+	"regexp"
+	"time"
+
+	"github.com/atsuyaourt/xyz-books/internal/util"
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// RequestIDHeader names a request, it is taken from the client or made up
+const RequestIDHeader = "X-Request-ID"
+
+// validRequestID keeps clients from putting anything in the logs
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+func requestID(ctx *gin.Context) string {
+	if id := ctx.GetHeader(RequestIDHeader); validRequestID.MatchString(id) {
+		return id
+	}
+
+	return util.NewToken()
+}
+
+// Middleware gives each request an ID, sent back in the X-Request-ID header,
+// and a logger naming it and its trace, carried by the context of the
+// request. Each request is logged once it is answered.
+func Middleware(logger *slog.Logger) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		start := time.Now()
+
+		id := requestID(ctx)
+		ctx.Header(RequestIDHeader, id)
+		reqLogger := logger.With(slog.String("request_id", id))
+		if span := trace.SpanContextFromContext(ctx.Request.Context()); span.IsValid() {
+			reqLogger = reqLogger.With(slog.String("trace_id", span.TraceID().String()))
+		}
+		ctx.Request = ctx.Request.WithContext(WithLogger(ctx.Request.Context(), reqLogger))
+
+		ctx.Next()
+
+		status := ctx.Writer.Status()
+		level := slog.LevelInfo
+		if status >= http.StatusInternalServerError {
+			level = slog.LevelError
+		}
+		attrs := []slog.Attr{
+			slog.String("method", ctx.Request.Method),
+			slog.String("path", ctx.Request.URL.Path),
+			slog.String("route", ctx.FullPath()),
+			slog.Int("status", status),
+			slog.Duration("duration", time.Since(start)),
+			slog.Int("bytes", max(ctx.Writer.Size(), 0)),
+			slog.String("client_ip", ctx.ClientIP()),
+		}
+		if len(ctx.Errors) > 0 {
+			attrs = append(attrs, slog.String("error", ctx.Errors.String()))
+		}
+		reqLogger.LogAttrs(ctx.Request.Context(), level, "request", attrs...)
+	}
+}
+
+// Recovery answers requests whose handler panicked with 500, and logs the
+// panic with the logger of the request
+func Recovery() gin.HandlerFunc {
+	return gin.CustomRecoveryWithWriter(nil, func(ctx *gin.Context, err any) {
+		FromContext(ctx.Request.Context()).Error("panic", slog.Any("error", err))
+		ctx.AbortWithStatus(http.StatusInternalServerError)
+	})
+}
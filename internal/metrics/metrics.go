@@ -0,0 +1,158 @@
+// Package metrics holds the Prometheus metrics of the server.
+package metrics
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/atsuyaourt/xyz-books/internal/cache"
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "xyz_books"
+
+var (
+	httpRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "HTTP requests by route and status.",
+	}, []string{"method", "route", "status"})
+
+	httpRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "Time taken to answer HTTP requests by route and status.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	dbQueryDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "db_query_duration_seconds",
+		Help:      "Time taken by database queries until their first row, by sqlc query name.",
+		Buckets:   []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1},
+	}, []string{"query"})
+
+	isbnBooks = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "isbn_backfill_books_total",
+		Help:      "Books through each stage of the ISBN backfill.",
+	}, []string{"stage"})
+
+	mu    sync.Mutex
+	pools []prometheus.Collector
+)
+
+// The stages of the ISBN backfill
+const (
+	ISBNFetched   = "fetched"
+	ISBNConverted = "converted"
+	ISBNUpdated   = "updated"
+	ISBNFailed    = "failed"
+)
+
+// NewRegistry returns a registry of the metrics of the process. Each server
+// has its own, so that it can add the metrics it owns.
+func NewRegistry() *prometheus.Registry {
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		httpRequests,
+		httpRequestDuration,
+		dbQueryDuration,
+		isbnBooks,
+	)
+
+	mu.Lock()
+	defer mu.Unlock()
+	reg.MustRegister(pools...)
+
+	return reg
+}
+
+// Handler serves the metrics of reg in the Prometheus text format
+func Handler(reg *prometheus.Registry) http.Handler {
+	return promhttp.HandlerFor(reg, promhttp.HandlerOpts{Registry: reg})
+}
+
+// RegisterDB adds the connection pool stats of db, named name, to the
+// registries made from now on
+func RegisterDB(name string, db *sql.DB) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	pools = append(pools, collectors.NewDBStatsCollector(db, name))
+}
+
+// Middleware counts and times the requests by route. Requests that match no
+// route are counted together, their paths are up to the client.
+func Middleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		start := time.Now()
+		ctx.Next()
+
+		route := ctx.FullPath()
+		if len(route) == 0 {
+			route = "unmatched"
+		}
+		status := strconv.Itoa(ctx.Writer.Status())
+
+		httpRequests.WithLabelValues(ctx.Request.Method, route, status).Inc()
+		httpRequestDuration.WithLabelValues(ctx.Request.Method, route, status).Observe(time.Since(start).Seconds())
+	}
+}
+
+// ObserveQuery records the time taken by the query named name, which
+// started at start. Queries returning rows are observed once their first
+// row is ready.
+func ObserveQuery(name string, start time.Time) {
+	dbQueryDuration.WithLabelValues(name).Observe(time.Since(start).Seconds())
+}
+
+// CountISBN counts a book through a stage of the ISBN backfill
+func CountISBN(stage string) {
+	isbnBooks.WithLabelValues(stage).Inc()
+}
+
+// cacheCollector reports the stats of a cache when scraped
+type cacheCollector struct {
+	stats     func() cache.Stats
+	hits      *prometheus.Desc
+	misses    *prometheus.Desc
+	evictions *prometheus.Desc
+	entries   *prometheus.Desc
+}
+
+// NewCacheCollector reports the hits, misses, evictions and size of the
+// cache whose stats are returned by stats, named name
+func NewCacheCollector(name string, stats func() cache.Stats) prometheus.Collector {
+	labels := prometheus.Labels{"cache": name}
+	return &cacheCollector{
+		stats:     stats,
+		hits:      prometheus.NewDesc(namespace+"_cache_hits_total", "Lookups found in the cache.", nil, labels),
+		misses:    prometheus.NewDesc(namespace+"_cache_misses_total", "Lookups not found in the cache.", nil, labels),
+		evictions: prometheus.NewDesc(namespace+"_cache_evictions_total", "Entries dropped to make room.", nil, labels),
+		entries:   prometheus.NewDesc(namespace+"_cache_entries", "Entries in the cache.", nil, labels),
+	}
+}
+
+func (c *cacheCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.hits
+	ch <- c.misses
+	ch <- c.evictions
+	ch <- c.entries
+}
+
+func (c *cacheCollector) Collect(ch chan<- prometheus.Metric) {
+	stats := c.stats()
+	ch <- prometheus.MustNewConstMetric(c.hits, prometheus.CounterValue, float64(stats.Hits))
+	ch <- prometheus.MustNewConstMetric(c.misses, prometheus.CounterValue, float64(stats.Misses))
+	ch <- prometheus.MustNewConstMetric(c.evictions, prometheus.CounterValue, float64(stats.Evictions))
+	ch <- prometheus.MustNewConstMetric(c.entries, prometheus.GaugeValue, float64(stats.Size))
+}
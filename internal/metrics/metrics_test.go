@@ -0,0 +1,66 @@
+package metrics
+
+import (
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/atsuyaourt/xyz-books/internal/cache"
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(Middleware())
+	router.GET("/books/:isbn", func(ctx *gin.Context) {
+		ctx.Status(http.StatusNoContent)
+	})
+
+	requests := httpRequests.WithLabelValues(http.MethodGet, "/books/:isbn", "204")
+	unmatched := httpRequests.WithLabelValues(http.MethodGet, "unmatched", "404")
+	before, beforeUnmatched := testutil.ToFloat64(requests), testutil.ToFloat64(unmatched)
+
+	for _, path := range []string{"/books/1", "/books/2", "/nowhere"} {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	// requests are counted by route, not path
+	require.Equal(t, before+2, testutil.ToFloat64(requests))
+	require.Equal(t, beforeUnmatched+1, testutil.ToFloat64(unmatched))
+}
+
+func TestRegistry(t *testing.T) {
+	RegisterDB("test", &sql.DB{})
+	CountISBN(ISBNFetched)
+	ObserveQuery("GetBookByISBN", time.Now())
+
+	reg := NewRegistry()
+	reg.MustRegister(NewCacheCollector("books", func() cache.Stats {
+		return cache.Stats{Hits: 3, Misses: 1, Size: 1}
+	}))
+
+	recorder := httptest.NewRecorder()
+	Handler(reg).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	body := recorder.Body.String()
+	for _, metric := range []string{
+		`xyz_books_isbn_backfill_books_total{stage="fetched"}`,
+		`xyz_books_db_query_duration_seconds_count{query="GetBookByISBN"}`,
+		`xyz_books_cache_hits_total{cache="books"} 3`,
+		`xyz_books_cache_entries{cache="books"} 1`,
+		`go_sql_max_open_connections{db_name="test"}`,
+		`go_goroutines`,
+	} {
+		require.True(t, strings.Contains(body, metric), "%s is not served", metric)
+	}
+
+	// every server gets a registry
+	require.NotPanics(t, func() { NewRegistry() })
+}
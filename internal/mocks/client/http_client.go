@@ -75,60 +75,6 @@ func (_c *MockHTTPClient_Do_Call) RunAndReturn(run func(*http.Request) (*http.Re
 	return _c
 }
 
-// Get provides a mock function with given fields: url
-func (_m *MockHTTPClient) Get(url string) (*http.Response, error) {
-	ret := _m.Called(url)
-
-	var r0 *http.Response
-	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (*http.Response, error)); ok {
-		return rf(url)
-	}
-	if rf, ok := ret.Get(0).(func(string) *http.Response); ok {
-		r0 = rf(url)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*http.Response)
-		}
-	}
-
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(url)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
-// MockHTTPClient_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
-type MockHTTPClient_Get_Call struct {
-	*mock.Call
-}
-
-// Get is a helper method to define mock.On call
-//   - url string
-func (_e *MockHTTPClient_Expecter) Get(url interface{}) *MockHTTPClient_Get_Call {
-	return &MockHTTPClient_Get_Call{Call: _e.mock.On("Get", url)}
-}
-
-func (_c *MockHTTPClient_Get_Call) Run(run func(url string)) *MockHTTPClient_Get_Call {
-	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
-	})
-	return _c
-}
-
-func (_c *MockHTTPClient_Get_Call) Return(resp *http.Response, err error) *MockHTTPClient_Get_Call {
-	_c.Call.Return(resp, err)
-	return _c
-}
-
-func (_c *MockHTTPClient_Get_Call) RunAndReturn(run func(string) (*http.Response, error)) *MockHTTPClient_Get_Call {
-	_c.Call.Return(run)
-	return _c
-}
-
 // NewMockHTTPClient creates a new instance of MockHTTPClient. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockHTTPClient(t interface {
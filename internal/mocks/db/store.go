@@ -7,6 +7,10 @@ import (
 
 	db "github.com/atsuyaourt/xyz-books/internal/db/sqlc"
 	mock "github.com/stretchr/testify/mock"
+
+	sql "database/sql"
+
+	time "time"
 )
 
 // MockStore is an autogenerated mock type for the Store type
@@ -22,23 +26,290 @@ func (_m *MockStore) EXPECT() *MockStore_Expecter {
 	return &MockStore_Expecter{mock: &_m.Mock}
 }
 
-// CountAuthors provides a mock function with given fields: ctx
-func (_m *MockStore) CountAuthors(ctx context.Context) (int64, error) {
+// AddCartItem provides a mock function with given fields: ctx, arg
+func (_m *MockStore) AddCartItem(ctx context.Context, arg db.AddCartItemParams) error {
+	ret := _m.Called(ctx, arg)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.AddCartItemParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockStore_AddCartItem_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddCartItem'
+type MockStore_AddCartItem_Call struct {
+	*mock.Call
+}
+
+// AddCartItem is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.AddCartItemParams
+func (_e *MockStore_Expecter) AddCartItem(ctx interface{}, arg interface{}) *MockStore_AddCartItem_Call {
+	return &MockStore_AddCartItem_Call{Call: _e.mock.On("AddCartItem", ctx, arg)}
+}
+
+func (_c *MockStore_AddCartItem_Call) Run(run func(ctx context.Context, arg db.AddCartItemParams)) *MockStore_AddCartItem_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.AddCartItemParams))
+	})
+	return _c
+}
+
+func (_c *MockStore_AddCartItem_Call) Return(_a0 error) *MockStore_AddCartItem_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockStore_AddCartItem_Call) RunAndReturn(run func(context.Context, db.AddCartItemParams) error) *MockStore_AddCartItem_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// BumpAuthorBookVersions provides a mock function with given fields: ctx, authorID
+func (_m *MockStore) BumpAuthorBookVersions(ctx context.Context, authorID int64) error {
+	ret := _m.Called(ctx, authorID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, authorID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockStore_BumpAuthorBookVersions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BumpAuthorBookVersions'
+type MockStore_BumpAuthorBookVersions_Call struct {
+	*mock.Call
+}
+
+// BumpAuthorBookVersions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - authorID int64
+func (_e *MockStore_Expecter) BumpAuthorBookVersions(ctx interface{}, authorID interface{}) *MockStore_BumpAuthorBookVersions_Call {
+	return &MockStore_BumpAuthorBookVersions_Call{Call: _e.mock.On("BumpAuthorBookVersions", ctx, authorID)}
+}
+
+func (_c *MockStore_BumpAuthorBookVersions_Call) Run(run func(ctx context.Context, authorID int64)) *MockStore_BumpAuthorBookVersions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockStore_BumpAuthorBookVersions_Call) Return(_a0 error) *MockStore_BumpAuthorBookVersions_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockStore_BumpAuthorBookVersions_Call) RunAndReturn(run func(context.Context, int64) error) *MockStore_BumpAuthorBookVersions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// BumpPublisherBookVersions provides a mock function with given fields: ctx, publisherID
+func (_m *MockStore) BumpPublisherBookVersions(ctx context.Context, publisherID int64) error {
+	ret := _m.Called(ctx, publisherID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, publisherID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockStore_BumpPublisherBookVersions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BumpPublisherBookVersions'
+type MockStore_BumpPublisherBookVersions_Call struct {
+	*mock.Call
+}
+
+// BumpPublisherBookVersions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - publisherID int64
+func (_e *MockStore_Expecter) BumpPublisherBookVersions(ctx interface{}, publisherID interface{}) *MockStore_BumpPublisherBookVersions_Call {
+	return &MockStore_BumpPublisherBookVersions_Call{Call: _e.mock.On("BumpPublisherBookVersions", ctx, publisherID)}
+}
+
+func (_c *MockStore_BumpPublisherBookVersions_Call) Run(run func(ctx context.Context, publisherID int64)) *MockStore_BumpPublisherBookVersions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockStore_BumpPublisherBookVersions_Call) Return(_a0 error) *MockStore_BumpPublisherBookVersions_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockStore_BumpPublisherBookVersions_Call) RunAndReturn(run func(context.Context, int64) error) *MockStore_BumpPublisherBookVersions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CheckWritable provides a mock function with given fields: ctx
+func (_m *MockStore) CheckWritable(ctx context.Context) error {
 	ret := _m.Called(ctx)
 
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockStore_CheckWritable_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CheckWritable'
+type MockStore_CheckWritable_Call struct {
+	*mock.Call
+}
+
+// CheckWritable is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockStore_Expecter) CheckWritable(ctx interface{}) *MockStore_CheckWritable_Call {
+	return &MockStore_CheckWritable_Call{Call: _e.mock.On("CheckWritable", ctx)}
+}
+
+func (_c *MockStore_CheckWritable_Call) Run(run func(ctx context.Context)) *MockStore_CheckWritable_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockStore_CheckWritable_Call) Return(_a0 error) *MockStore_CheckWritable_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockStore_CheckWritable_Call) RunAndReturn(run func(context.Context) error) *MockStore_CheckWritable_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CheckoutTx provides a mock function with given fields: ctx, arg
+func (_m *MockStore) CheckoutTx(ctx context.Context, arg db.CheckoutTxParams) (db.CheckoutTxResult, error) {
+	ret := _m.Called(ctx, arg)
+
+	var r0 db.CheckoutTxResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.CheckoutTxParams) (db.CheckoutTxResult, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.CheckoutTxParams) db.CheckoutTxResult); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.CheckoutTxResult)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.CheckoutTxParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_CheckoutTx_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CheckoutTx'
+type MockStore_CheckoutTx_Call struct {
+	*mock.Call
+}
+
+// CheckoutTx is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.CheckoutTxParams
+func (_e *MockStore_Expecter) CheckoutTx(ctx interface{}, arg interface{}) *MockStore_CheckoutTx_Call {
+	return &MockStore_CheckoutTx_Call{Call: _e.mock.On("CheckoutTx", ctx, arg)}
+}
+
+func (_c *MockStore_CheckoutTx_Call) Run(run func(ctx context.Context, arg db.CheckoutTxParams)) *MockStore_CheckoutTx_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.CheckoutTxParams))
+	})
+	return _c
+}
+
+func (_c *MockStore_CheckoutTx_Call) Return(_a0 db.CheckoutTxResult, _a1 error) *MockStore_CheckoutTx_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_CheckoutTx_Call) RunAndReturn(run func(context.Context, db.CheckoutTxParams) (db.CheckoutTxResult, error)) *MockStore_CheckoutTx_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ClearCartItems provides a mock function with given fields: ctx, cartID
+func (_m *MockStore) ClearCartItems(ctx context.Context, cartID int64) error {
+	ret := _m.Called(ctx, cartID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, cartID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockStore_ClearCartItems_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClearCartItems'
+type MockStore_ClearCartItems_Call struct {
+	*mock.Call
+}
+
+// ClearCartItems is a helper method to define mock.On call
+//   - ctx context.Context
+//   - cartID int64
+func (_e *MockStore_Expecter) ClearCartItems(ctx interface{}, cartID interface{}) *MockStore_ClearCartItems_Call {
+	return &MockStore_ClearCartItems_Call{Call: _e.mock.On("ClearCartItems", ctx, cartID)}
+}
+
+func (_c *MockStore_ClearCartItems_Call) Run(run func(ctx context.Context, cartID int64)) *MockStore_ClearCartItems_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockStore_ClearCartItems_Call) Return(_a0 error) *MockStore_ClearCartItems_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockStore_ClearCartItems_Call) RunAndReturn(run func(context.Context, int64) error) *MockStore_ClearCartItems_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CountAuthors provides a mock function with given fields: ctx, arg
+func (_m *MockStore) CountAuthors(ctx context.Context, arg db.CountAuthorsParams) (int64, error) {
+	ret := _m.Called(ctx, arg)
+
 	var r0 int64
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context) (int64, error)); ok {
-		return rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, db.CountAuthorsParams) (int64, error)); ok {
+		return rf(ctx, arg)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context) int64); ok {
-		r0 = rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, db.CountAuthorsParams) int64); ok {
+		r0 = rf(ctx, arg)
 	} else {
 		r0 = ret.Get(0).(int64)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(ctx)
+	if rf, ok := ret.Get(1).(func(context.Context, db.CountAuthorsParams) error); ok {
+		r1 = rf(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -53,13 +324,14 @@ type MockStore_CountAuthors_Call struct {
 
 // CountAuthors is a helper method to define mock.On call
 //   - ctx context.Context
-func (_e *MockStore_Expecter) CountAuthors(ctx interface{}) *MockStore_CountAuthors_Call {
-	return &MockStore_CountAuthors_Call{Call: _e.mock.On("CountAuthors", ctx)}
+//   - arg db.CountAuthorsParams
+func (_e *MockStore_Expecter) CountAuthors(ctx interface{}, arg interface{}) *MockStore_CountAuthors_Call {
+	return &MockStore_CountAuthors_Call{Call: _e.mock.On("CountAuthors", ctx, arg)}
 }
 
-func (_c *MockStore_CountAuthors_Call) Run(run func(ctx context.Context)) *MockStore_CountAuthors_Call {
+func (_c *MockStore_CountAuthors_Call) Run(run func(ctx context.Context, arg db.CountAuthorsParams)) *MockStore_CountAuthors_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context))
+		run(args[0].(context.Context), args[1].(db.CountAuthorsParams))
 	})
 	return _c
 }
@@ -69,7 +341,7 @@ func (_c *MockStore_CountAuthors_Call) Return(_a0 int64, _a1 error) *MockStore_C
 	return _c
 }
 
-func (_c *MockStore_CountAuthors_Call) RunAndReturn(run func(context.Context) (int64, error)) *MockStore_CountAuthors_Call {
+func (_c *MockStore_CountAuthors_Call) RunAndReturn(run func(context.Context, db.CountAuthorsParams) (int64, error)) *MockStore_CountAuthors_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -110,40 +382,2653 @@ func (_e *MockStore_Expecter) CountBooks(ctx interface{}, arg interface{}) *Mock
 	return &MockStore_CountBooks_Call{Call: _e.mock.On("CountBooks", ctx, arg)}
 }
 
-func (_c *MockStore_CountBooks_Call) Run(run func(ctx context.Context, arg db.CountBooksParams)) *MockStore_CountBooks_Call {
+func (_c *MockStore_CountBooks_Call) Run(run func(ctx context.Context, arg db.CountBooksParams)) *MockStore_CountBooks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.CountBooksParams))
+	})
+	return _c
+}
+
+func (_c *MockStore_CountBooks_Call) Return(_a0 int64, _a1 error) *MockStore_CountBooks_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_CountBooks_Call) RunAndReturn(run func(context.Context, db.CountBooksParams) (int64, error)) *MockStore_CountBooks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CountOrders provides a mock function with given fields: ctx, arg
+func (_m *MockStore) CountOrders(ctx context.Context, arg db.CountOrdersParams) (int64, error) {
+	ret := _m.Called(ctx, arg)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.CountOrdersParams) (int64, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.CountOrdersParams) int64); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.CountOrdersParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_CountOrders_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountOrders'
+type MockStore_CountOrders_Call struct {
+	*mock.Call
+}
+
+// CountOrders is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.CountOrdersParams
+func (_e *MockStore_Expecter) CountOrders(ctx interface{}, arg interface{}) *MockStore_CountOrders_Call {
+	return &MockStore_CountOrders_Call{Call: _e.mock.On("CountOrders", ctx, arg)}
+}
+
+func (_c *MockStore_CountOrders_Call) Run(run func(ctx context.Context, arg db.CountOrdersParams)) *MockStore_CountOrders_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.CountOrdersParams))
+	})
+	return _c
+}
+
+func (_c *MockStore_CountOrders_Call) Return(_a0 int64, _a1 error) *MockStore_CountOrders_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_CountOrders_Call) RunAndReturn(run func(context.Context, db.CountOrdersParams) (int64, error)) *MockStore_CountOrders_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CountPublishers provides a mock function with given fields: ctx, arg
+func (_m *MockStore) CountPublishers(ctx context.Context, arg db.CountPublishersParams) (int64, error) {
+	ret := _m.Called(ctx, arg)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.CountPublishersParams) (int64, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.CountPublishersParams) int64); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.CountPublishersParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_CountPublishers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountPublishers'
+type MockStore_CountPublishers_Call struct {
+	*mock.Call
+}
+
+// CountPublishers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.CountPublishersParams
+func (_e *MockStore_Expecter) CountPublishers(ctx interface{}, arg interface{}) *MockStore_CountPublishers_Call {
+	return &MockStore_CountPublishers_Call{Call: _e.mock.On("CountPublishers", ctx, arg)}
+}
+
+func (_c *MockStore_CountPublishers_Call) Run(run func(ctx context.Context, arg db.CountPublishersParams)) *MockStore_CountPublishers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.CountPublishersParams))
+	})
+	return _c
+}
+
+func (_c *MockStore_CountPublishers_Call) Return(_a0 int64, _a1 error) *MockStore_CountPublishers_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_CountPublishers_Call) RunAndReturn(run func(context.Context, db.CountPublishersParams) (int64, error)) *MockStore_CountPublishers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CountWebhookDeliveries provides a mock function with given fields: ctx, arg
+func (_m *MockStore) CountWebhookDeliveries(ctx context.Context, arg db.CountWebhookDeliveriesParams) (int64, error) {
+	ret := _m.Called(ctx, arg)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.CountWebhookDeliveriesParams) (int64, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.CountWebhookDeliveriesParams) int64); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.CountWebhookDeliveriesParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_CountWebhookDeliveries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountWebhookDeliveries'
+type MockStore_CountWebhookDeliveries_Call struct {
+	*mock.Call
+}
+
+// CountWebhookDeliveries is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.CountWebhookDeliveriesParams
+func (_e *MockStore_Expecter) CountWebhookDeliveries(ctx interface{}, arg interface{}) *MockStore_CountWebhookDeliveries_Call {
+	return &MockStore_CountWebhookDeliveries_Call{Call: _e.mock.On("CountWebhookDeliveries", ctx, arg)}
+}
+
+func (_c *MockStore_CountWebhookDeliveries_Call) Run(run func(ctx context.Context, arg db.CountWebhookDeliveriesParams)) *MockStore_CountWebhookDeliveries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.CountWebhookDeliveriesParams))
+	})
+	return _c
+}
+
+func (_c *MockStore_CountWebhookDeliveries_Call) Return(_a0 int64, _a1 error) *MockStore_CountWebhookDeliveries_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_CountWebhookDeliveries_Call) RunAndReturn(run func(context.Context, db.CountWebhookDeliveriesParams) (int64, error)) *MockStore_CountWebhookDeliveries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateAuthor provides a mock function with given fields: ctx, arg
+func (_m *MockStore) CreateAuthor(ctx context.Context, arg db.CreateAuthorParams) (db.Author, error) {
+	ret := _m.Called(ctx, arg)
+
+	var r0 db.Author
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateAuthorParams) (db.Author, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateAuthorParams) db.Author); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.Author)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.CreateAuthorParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_CreateAuthor_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateAuthor'
+type MockStore_CreateAuthor_Call struct {
+	*mock.Call
+}
+
+// CreateAuthor is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.CreateAuthorParams
+func (_e *MockStore_Expecter) CreateAuthor(ctx interface{}, arg interface{}) *MockStore_CreateAuthor_Call {
+	return &MockStore_CreateAuthor_Call{Call: _e.mock.On("CreateAuthor", ctx, arg)}
+}
+
+func (_c *MockStore_CreateAuthor_Call) Run(run func(ctx context.Context, arg db.CreateAuthorParams)) *MockStore_CreateAuthor_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.CreateAuthorParams))
+	})
+	return _c
+}
+
+func (_c *MockStore_CreateAuthor_Call) Return(_a0 db.Author, _a1 error) *MockStore_CreateAuthor_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_CreateAuthor_Call) RunAndReturn(run func(context.Context, db.CreateAuthorParams) (db.Author, error)) *MockStore_CreateAuthor_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateAuthorBookRel provides a mock function with given fields: ctx, arg
+func (_m *MockStore) CreateAuthorBookRel(ctx context.Context, arg db.CreateAuthorBookRelParams) error {
+	ret := _m.Called(ctx, arg)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateAuthorBookRelParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockStore_CreateAuthorBookRel_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateAuthorBookRel'
+type MockStore_CreateAuthorBookRel_Call struct {
+	*mock.Call
+}
+
+// CreateAuthorBookRel is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.CreateAuthorBookRelParams
+func (_e *MockStore_Expecter) CreateAuthorBookRel(ctx interface{}, arg interface{}) *MockStore_CreateAuthorBookRel_Call {
+	return &MockStore_CreateAuthorBookRel_Call{Call: _e.mock.On("CreateAuthorBookRel", ctx, arg)}
+}
+
+func (_c *MockStore_CreateAuthorBookRel_Call) Run(run func(ctx context.Context, arg db.CreateAuthorBookRelParams)) *MockStore_CreateAuthorBookRel_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.CreateAuthorBookRelParams))
+	})
+	return _c
+}
+
+func (_c *MockStore_CreateAuthorBookRel_Call) Return(_a0 error) *MockStore_CreateAuthorBookRel_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockStore_CreateAuthorBookRel_Call) RunAndReturn(run func(context.Context, db.CreateAuthorBookRelParams) error) *MockStore_CreateAuthorBookRel_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateBook provides a mock function with given fields: ctx, arg
+func (_m *MockStore) CreateBook(ctx context.Context, arg db.CreateBookParams) (db.Book, error) {
+	ret := _m.Called(ctx, arg)
+
+	var r0 db.Book
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateBookParams) (db.Book, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateBookParams) db.Book); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.Book)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.CreateBookParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_CreateBook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateBook'
+type MockStore_CreateBook_Call struct {
+	*mock.Call
+}
+
+// CreateBook is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.CreateBookParams
+func (_e *MockStore_Expecter) CreateBook(ctx interface{}, arg interface{}) *MockStore_CreateBook_Call {
+	return &MockStore_CreateBook_Call{Call: _e.mock.On("CreateBook", ctx, arg)}
+}
+
+func (_c *MockStore_CreateBook_Call) Run(run func(ctx context.Context, arg db.CreateBookParams)) *MockStore_CreateBook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.CreateBookParams))
+	})
+	return _c
+}
+
+func (_c *MockStore_CreateBook_Call) Return(_a0 db.Book, _a1 error) *MockStore_CreateBook_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_CreateBook_Call) RunAndReturn(run func(context.Context, db.CreateBookParams) (db.Book, error)) *MockStore_CreateBook_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateBookSubjectRel provides a mock function with given fields: ctx, arg
+func (_m *MockStore) CreateBookSubjectRel(ctx context.Context, arg db.CreateBookSubjectRelParams) error {
+	ret := _m.Called(ctx, arg)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateBookSubjectRelParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockStore_CreateBookSubjectRel_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateBookSubjectRel'
+type MockStore_CreateBookSubjectRel_Call struct {
+	*mock.Call
+}
+
+// CreateBookSubjectRel is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.CreateBookSubjectRelParams
+func (_e *MockStore_Expecter) CreateBookSubjectRel(ctx interface{}, arg interface{}) *MockStore_CreateBookSubjectRel_Call {
+	return &MockStore_CreateBookSubjectRel_Call{Call: _e.mock.On("CreateBookSubjectRel", ctx, arg)}
+}
+
+func (_c *MockStore_CreateBookSubjectRel_Call) Run(run func(ctx context.Context, arg db.CreateBookSubjectRelParams)) *MockStore_CreateBookSubjectRel_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.CreateBookSubjectRelParams))
+	})
+	return _c
+}
+
+func (_c *MockStore_CreateBookSubjectRel_Call) Return(_a0 error) *MockStore_CreateBookSubjectRel_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockStore_CreateBookSubjectRel_Call) RunAndReturn(run func(context.Context, db.CreateBookSubjectRelParams) error) *MockStore_CreateBookSubjectRel_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateBookTx provides a mock function with given fields: ctx, arg
+func (_m *MockStore) CreateBookTx(ctx context.Context, arg db.CreateBookTxParams) (db.Book, error) {
+	ret := _m.Called(ctx, arg)
+
+	var r0 db.Book
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateBookTxParams) (db.Book, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateBookTxParams) db.Book); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.Book)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.CreateBookTxParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_CreateBookTx_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateBookTx'
+type MockStore_CreateBookTx_Call struct {
+	*mock.Call
+}
+
+// CreateBookTx is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.CreateBookTxParams
+func (_e *MockStore_Expecter) CreateBookTx(ctx interface{}, arg interface{}) *MockStore_CreateBookTx_Call {
+	return &MockStore_CreateBookTx_Call{Call: _e.mock.On("CreateBookTx", ctx, arg)}
+}
+
+func (_c *MockStore_CreateBookTx_Call) Run(run func(ctx context.Context, arg db.CreateBookTxParams)) *MockStore_CreateBookTx_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.CreateBookTxParams))
+	})
+	return _c
+}
+
+func (_c *MockStore_CreateBookTx_Call) Return(book db.Book, err error) *MockStore_CreateBookTx_Call {
+	_c.Call.Return(book, err)
+	return _c
+}
+
+func (_c *MockStore_CreateBookTx_Call) RunAndReturn(run func(context.Context, db.CreateBookTxParams) (db.Book, error)) *MockStore_CreateBookTx_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateCart provides a mock function with given fields: ctx, arg
+func (_m *MockStore) CreateCart(ctx context.Context, arg db.CreateCartParams) (db.Cart, error) {
+	ret := _m.Called(ctx, arg)
+
+	var r0 db.Cart
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateCartParams) (db.Cart, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateCartParams) db.Cart); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.Cart)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.CreateCartParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_CreateCart_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateCart'
+type MockStore_CreateCart_Call struct {
+	*mock.Call
+}
+
+// CreateCart is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.CreateCartParams
+func (_e *MockStore_Expecter) CreateCart(ctx interface{}, arg interface{}) *MockStore_CreateCart_Call {
+	return &MockStore_CreateCart_Call{Call: _e.mock.On("CreateCart", ctx, arg)}
+}
+
+func (_c *MockStore_CreateCart_Call) Run(run func(ctx context.Context, arg db.CreateCartParams)) *MockStore_CreateCart_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.CreateCartParams))
+	})
+	return _c
+}
+
+func (_c *MockStore_CreateCart_Call) Return(_a0 db.Cart, _a1 error) *MockStore_CreateCart_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_CreateCart_Call) RunAndReturn(run func(context.Context, db.CreateCartParams) (db.Cart, error)) *MockStore_CreateCart_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateOrder provides a mock function with given fields: ctx, arg
+func (_m *MockStore) CreateOrder(ctx context.Context, arg db.CreateOrderParams) (db.Order, error) {
+	ret := _m.Called(ctx, arg)
+
+	var r0 db.Order
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateOrderParams) (db.Order, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateOrderParams) db.Order); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.Order)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.CreateOrderParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_CreateOrder_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateOrder'
+type MockStore_CreateOrder_Call struct {
+	*mock.Call
+}
+
+// CreateOrder is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.CreateOrderParams
+func (_e *MockStore_Expecter) CreateOrder(ctx interface{}, arg interface{}) *MockStore_CreateOrder_Call {
+	return &MockStore_CreateOrder_Call{Call: _e.mock.On("CreateOrder", ctx, arg)}
+}
+
+func (_c *MockStore_CreateOrder_Call) Run(run func(ctx context.Context, arg db.CreateOrderParams)) *MockStore_CreateOrder_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.CreateOrderParams))
+	})
+	return _c
+}
+
+func (_c *MockStore_CreateOrder_Call) Return(_a0 db.Order, _a1 error) *MockStore_CreateOrder_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_CreateOrder_Call) RunAndReturn(run func(context.Context, db.CreateOrderParams) (db.Order, error)) *MockStore_CreateOrder_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateOrderItemsFromCart provides a mock function with given fields: ctx, arg
+func (_m *MockStore) CreateOrderItemsFromCart(ctx context.Context, arg db.CreateOrderItemsFromCartParams) error {
+	ret := _m.Called(ctx, arg)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateOrderItemsFromCartParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockStore_CreateOrderItemsFromCart_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateOrderItemsFromCart'
+type MockStore_CreateOrderItemsFromCart_Call struct {
+	*mock.Call
+}
+
+// CreateOrderItemsFromCart is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.CreateOrderItemsFromCartParams
+func (_e *MockStore_Expecter) CreateOrderItemsFromCart(ctx interface{}, arg interface{}) *MockStore_CreateOrderItemsFromCart_Call {
+	return &MockStore_CreateOrderItemsFromCart_Call{Call: _e.mock.On("CreateOrderItemsFromCart", ctx, arg)}
+}
+
+func (_c *MockStore_CreateOrderItemsFromCart_Call) Run(run func(ctx context.Context, arg db.CreateOrderItemsFromCartParams)) *MockStore_CreateOrderItemsFromCart_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.CreateOrderItemsFromCartParams))
+	})
+	return _c
+}
+
+func (_c *MockStore_CreateOrderItemsFromCart_Call) Return(_a0 error) *MockStore_CreateOrderItemsFromCart_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockStore_CreateOrderItemsFromCart_Call) RunAndReturn(run func(context.Context, db.CreateOrderItemsFromCartParams) error) *MockStore_CreateOrderItemsFromCart_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreatePublisher provides a mock function with given fields: ctx, publisherName
+func (_m *MockStore) CreatePublisher(ctx context.Context, publisherName string) (db.Publisher, error) {
+	ret := _m.Called(ctx, publisherName)
+
+	var r0 db.Publisher
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (db.Publisher, error)); ok {
+		return rf(ctx, publisherName)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) db.Publisher); ok {
+		r0 = rf(ctx, publisherName)
+	} else {
+		r0 = ret.Get(0).(db.Publisher)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, publisherName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_CreatePublisher_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreatePublisher'
+type MockStore_CreatePublisher_Call struct {
+	*mock.Call
+}
+
+// CreatePublisher is a helper method to define mock.On call
+//   - ctx context.Context
+//   - publisherName string
+func (_e *MockStore_Expecter) CreatePublisher(ctx interface{}, publisherName interface{}) *MockStore_CreatePublisher_Call {
+	return &MockStore_CreatePublisher_Call{Call: _e.mock.On("CreatePublisher", ctx, publisherName)}
+}
+
+func (_c *MockStore_CreatePublisher_Call) Run(run func(ctx context.Context, publisherName string)) *MockStore_CreatePublisher_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockStore_CreatePublisher_Call) Return(_a0 db.Publisher, _a1 error) *MockStore_CreatePublisher_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_CreatePublisher_Call) RunAndReturn(run func(context.Context, string) (db.Publisher, error)) *MockStore_CreatePublisher_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateWebhook provides a mock function with given fields: ctx, arg
+func (_m *MockStore) CreateWebhook(ctx context.Context, arg db.CreateWebhookParams) (db.Webhook, error) {
+	ret := _m.Called(ctx, arg)
+
+	var r0 db.Webhook
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateWebhookParams) (db.Webhook, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateWebhookParams) db.Webhook); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.Webhook)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.CreateWebhookParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_CreateWebhook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateWebhook'
+type MockStore_CreateWebhook_Call struct {
+	*mock.Call
+}
+
+// CreateWebhook is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.CreateWebhookParams
+func (_e *MockStore_Expecter) CreateWebhook(ctx interface{}, arg interface{}) *MockStore_CreateWebhook_Call {
+	return &MockStore_CreateWebhook_Call{Call: _e.mock.On("CreateWebhook", ctx, arg)}
+}
+
+func (_c *MockStore_CreateWebhook_Call) Run(run func(ctx context.Context, arg db.CreateWebhookParams)) *MockStore_CreateWebhook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.CreateWebhookParams))
+	})
+	return _c
+}
+
+func (_c *MockStore_CreateWebhook_Call) Return(_a0 db.Webhook, _a1 error) *MockStore_CreateWebhook_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_CreateWebhook_Call) RunAndReturn(run func(context.Context, db.CreateWebhookParams) (db.Webhook, error)) *MockStore_CreateWebhook_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteAuthor provides a mock function with given fields: ctx, arg
+func (_m *MockStore) DeleteAuthor(ctx context.Context, arg db.DeleteAuthorParams) (int64, error) {
+	ret := _m.Called(ctx, arg)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.DeleteAuthorParams) (int64, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.DeleteAuthorParams) int64); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.DeleteAuthorParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_DeleteAuthor_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteAuthor'
+type MockStore_DeleteAuthor_Call struct {
+	*mock.Call
+}
+
+// DeleteAuthor is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.DeleteAuthorParams
+func (_e *MockStore_Expecter) DeleteAuthor(ctx interface{}, arg interface{}) *MockStore_DeleteAuthor_Call {
+	return &MockStore_DeleteAuthor_Call{Call: _e.mock.On("DeleteAuthor", ctx, arg)}
+}
+
+func (_c *MockStore_DeleteAuthor_Call) Run(run func(ctx context.Context, arg db.DeleteAuthorParams)) *MockStore_DeleteAuthor_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.DeleteAuthorParams))
+	})
+	return _c
+}
+
+func (_c *MockStore_DeleteAuthor_Call) Return(_a0 int64, _a1 error) *MockStore_DeleteAuthor_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_DeleteAuthor_Call) RunAndReturn(run func(context.Context, db.DeleteAuthorParams) (int64, error)) *MockStore_DeleteAuthor_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteBookByISBN provides a mock function with given fields: ctx, arg
+func (_m *MockStore) DeleteBookByISBN(ctx context.Context, arg db.DeleteBookByISBNParams) (int64, error) {
+	ret := _m.Called(ctx, arg)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.DeleteBookByISBNParams) (int64, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.DeleteBookByISBNParams) int64); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.DeleteBookByISBNParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_DeleteBookByISBN_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteBookByISBN'
+type MockStore_DeleteBookByISBN_Call struct {
+	*mock.Call
+}
+
+// DeleteBookByISBN is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.DeleteBookByISBNParams
+func (_e *MockStore_Expecter) DeleteBookByISBN(ctx interface{}, arg interface{}) *MockStore_DeleteBookByISBN_Call {
+	return &MockStore_DeleteBookByISBN_Call{Call: _e.mock.On("DeleteBookByISBN", ctx, arg)}
+}
+
+func (_c *MockStore_DeleteBookByISBN_Call) Run(run func(ctx context.Context, arg db.DeleteBookByISBNParams)) *MockStore_DeleteBookByISBN_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.DeleteBookByISBNParams))
+	})
+	return _c
+}
+
+func (_c *MockStore_DeleteBookByISBN_Call) Return(_a0 int64, _a1 error) *MockStore_DeleteBookByISBN_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_DeleteBookByISBN_Call) RunAndReturn(run func(context.Context, db.DeleteBookByISBNParams) (int64, error)) *MockStore_DeleteBookByISBN_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteBookSubjectRels provides a mock function with given fields: ctx, bookID
+func (_m *MockStore) DeleteBookSubjectRels(ctx context.Context, bookID int64) error {
+	ret := _m.Called(ctx, bookID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, bookID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockStore_DeleteBookSubjectRels_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteBookSubjectRels'
+type MockStore_DeleteBookSubjectRels_Call struct {
+	*mock.Call
+}
+
+// DeleteBookSubjectRels is a helper method to define mock.On call
+//   - ctx context.Context
+//   - bookID int64
+func (_e *MockStore_Expecter) DeleteBookSubjectRels(ctx interface{}, bookID interface{}) *MockStore_DeleteBookSubjectRels_Call {
+	return &MockStore_DeleteBookSubjectRels_Call{Call: _e.mock.On("DeleteBookSubjectRels", ctx, bookID)}
+}
+
+func (_c *MockStore_DeleteBookSubjectRels_Call) Run(run func(ctx context.Context, bookID int64)) *MockStore_DeleteBookSubjectRels_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockStore_DeleteBookSubjectRels_Call) Return(_a0 error) *MockStore_DeleteBookSubjectRels_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockStore_DeleteBookSubjectRels_Call) RunAndReturn(run func(context.Context, int64) error) *MockStore_DeleteBookSubjectRels_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteCart provides a mock function with given fields: ctx, cartID
+func (_m *MockStore) DeleteCart(ctx context.Context, cartID int64) error {
+	ret := _m.Called(ctx, cartID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, cartID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockStore_DeleteCart_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteCart'
+type MockStore_DeleteCart_Call struct {
+	*mock.Call
+}
+
+// DeleteCart is a helper method to define mock.On call
+//   - ctx context.Context
+//   - cartID int64
+func (_e *MockStore_Expecter) DeleteCart(ctx interface{}, cartID interface{}) *MockStore_DeleteCart_Call {
+	return &MockStore_DeleteCart_Call{Call: _e.mock.On("DeleteCart", ctx, cartID)}
+}
+
+func (_c *MockStore_DeleteCart_Call) Run(run func(ctx context.Context, cartID int64)) *MockStore_DeleteCart_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockStore_DeleteCart_Call) Return(_a0 error) *MockStore_DeleteCart_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockStore_DeleteCart_Call) RunAndReturn(run func(context.Context, int64) error) *MockStore_DeleteCart_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteCartItem provides a mock function with given fields: ctx, arg
+func (_m *MockStore) DeleteCartItem(ctx context.Context, arg db.DeleteCartItemParams) error {
+	ret := _m.Called(ctx, arg)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.DeleteCartItemParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockStore_DeleteCartItem_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteCartItem'
+type MockStore_DeleteCartItem_Call struct {
+	*mock.Call
+}
+
+// DeleteCartItem is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.DeleteCartItemParams
+func (_e *MockStore_Expecter) DeleteCartItem(ctx interface{}, arg interface{}) *MockStore_DeleteCartItem_Call {
+	return &MockStore_DeleteCartItem_Call{Call: _e.mock.On("DeleteCartItem", ctx, arg)}
+}
+
+func (_c *MockStore_DeleteCartItem_Call) Run(run func(ctx context.Context, arg db.DeleteCartItemParams)) *MockStore_DeleteCartItem_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.DeleteCartItemParams))
+	})
+	return _c
+}
+
+func (_c *MockStore_DeleteCartItem_Call) Return(_a0 error) *MockStore_DeleteCartItem_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockStore_DeleteCartItem_Call) RunAndReturn(run func(context.Context, db.DeleteCartItemParams) error) *MockStore_DeleteCartItem_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeletePublisher provides a mock function with given fields: ctx, arg
+func (_m *MockStore) DeletePublisher(ctx context.Context, arg db.DeletePublisherParams) (int64, error) {
+	ret := _m.Called(ctx, arg)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.DeletePublisherParams) (int64, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.DeletePublisherParams) int64); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.DeletePublisherParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_DeletePublisher_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeletePublisher'
+type MockStore_DeletePublisher_Call struct {
+	*mock.Call
+}
+
+// DeletePublisher is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.DeletePublisherParams
+func (_e *MockStore_Expecter) DeletePublisher(ctx interface{}, arg interface{}) *MockStore_DeletePublisher_Call {
+	return &MockStore_DeletePublisher_Call{Call: _e.mock.On("DeletePublisher", ctx, arg)}
+}
+
+func (_c *MockStore_DeletePublisher_Call) Run(run func(ctx context.Context, arg db.DeletePublisherParams)) *MockStore_DeletePublisher_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.DeletePublisherParams))
+	})
+	return _c
+}
+
+func (_c *MockStore_DeletePublisher_Call) Return(_a0 int64, _a1 error) *MockStore_DeletePublisher_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_DeletePublisher_Call) RunAndReturn(run func(context.Context, db.DeletePublisherParams) (int64, error)) *MockStore_DeletePublisher_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeletePurgedAuthorBookRels provides a mock function with given fields: ctx, deletedBefore
+func (_m *MockStore) DeletePurgedAuthorBookRels(ctx context.Context, deletedBefore sql.NullTime) error {
+	ret := _m.Called(ctx, deletedBefore)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, sql.NullTime) error); ok {
+		r0 = rf(ctx, deletedBefore)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockStore_DeletePurgedAuthorBookRels_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeletePurgedAuthorBookRels'
+type MockStore_DeletePurgedAuthorBookRels_Call struct {
+	*mock.Call
+}
+
+// DeletePurgedAuthorBookRels is a helper method to define mock.On call
+//   - ctx context.Context
+//   - deletedBefore sql.NullTime
+func (_e *MockStore_Expecter) DeletePurgedAuthorBookRels(ctx interface{}, deletedBefore interface{}) *MockStore_DeletePurgedAuthorBookRels_Call {
+	return &MockStore_DeletePurgedAuthorBookRels_Call{Call: _e.mock.On("DeletePurgedAuthorBookRels", ctx, deletedBefore)}
+}
+
+func (_c *MockStore_DeletePurgedAuthorBookRels_Call) Run(run func(ctx context.Context, deletedBefore sql.NullTime)) *MockStore_DeletePurgedAuthorBookRels_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(sql.NullTime))
+	})
+	return _c
+}
+
+func (_c *MockStore_DeletePurgedAuthorBookRels_Call) Return(_a0 error) *MockStore_DeletePurgedAuthorBookRels_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockStore_DeletePurgedAuthorBookRels_Call) RunAndReturn(run func(context.Context, sql.NullTime) error) *MockStore_DeletePurgedAuthorBookRels_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeletePurgedBookSubjectRels provides a mock function with given fields: ctx, deletedBefore
+func (_m *MockStore) DeletePurgedBookSubjectRels(ctx context.Context, deletedBefore sql.NullTime) error {
+	ret := _m.Called(ctx, deletedBefore)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, sql.NullTime) error); ok {
+		r0 = rf(ctx, deletedBefore)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockStore_DeletePurgedBookSubjectRels_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeletePurgedBookSubjectRels'
+type MockStore_DeletePurgedBookSubjectRels_Call struct {
+	*mock.Call
+}
+
+// DeletePurgedBookSubjectRels is a helper method to define mock.On call
+//   - ctx context.Context
+//   - deletedBefore sql.NullTime
+func (_e *MockStore_Expecter) DeletePurgedBookSubjectRels(ctx interface{}, deletedBefore interface{}) *MockStore_DeletePurgedBookSubjectRels_Call {
+	return &MockStore_DeletePurgedBookSubjectRels_Call{Call: _e.mock.On("DeletePurgedBookSubjectRels", ctx, deletedBefore)}
+}
+
+func (_c *MockStore_DeletePurgedBookSubjectRels_Call) Run(run func(ctx context.Context, deletedBefore sql.NullTime)) *MockStore_DeletePurgedBookSubjectRels_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(sql.NullTime))
+	})
+	return _c
+}
+
+func (_c *MockStore_DeletePurgedBookSubjectRels_Call) Return(_a0 error) *MockStore_DeletePurgedBookSubjectRels_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockStore_DeletePurgedBookSubjectRels_Call) RunAndReturn(run func(context.Context, sql.NullTime) error) *MockStore_DeletePurgedBookSubjectRels_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeletePurgedCartItems provides a mock function with given fields: ctx, deletedBefore
+func (_m *MockStore) DeletePurgedCartItems(ctx context.Context, deletedBefore sql.NullTime) error {
+	ret := _m.Called(ctx, deletedBefore)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, sql.NullTime) error); ok {
+		r0 = rf(ctx, deletedBefore)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockStore_DeletePurgedCartItems_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeletePurgedCartItems'
+type MockStore_DeletePurgedCartItems_Call struct {
+	*mock.Call
+}
+
+// DeletePurgedCartItems is a helper method to define mock.On call
+//   - ctx context.Context
+//   - deletedBefore sql.NullTime
+func (_e *MockStore_Expecter) DeletePurgedCartItems(ctx interface{}, deletedBefore interface{}) *MockStore_DeletePurgedCartItems_Call {
+	return &MockStore_DeletePurgedCartItems_Call{Call: _e.mock.On("DeletePurgedCartItems", ctx, deletedBefore)}
+}
+
+func (_c *MockStore_DeletePurgedCartItems_Call) Run(run func(ctx context.Context, deletedBefore sql.NullTime)) *MockStore_DeletePurgedCartItems_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(sql.NullTime))
+	})
+	return _c
+}
+
+func (_c *MockStore_DeletePurgedCartItems_Call) Return(_a0 error) *MockStore_DeletePurgedCartItems_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockStore_DeletePurgedCartItems_Call) RunAndReturn(run func(context.Context, sql.NullTime) error) *MockStore_DeletePurgedCartItems_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteWebhook provides a mock function with given fields: ctx, webhookID
+func (_m *MockStore) DeleteWebhook(ctx context.Context, webhookID int64) (int64, error) {
+	ret := _m.Called(ctx, webhookID)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (int64, error)); ok {
+		return rf(ctx, webhookID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) int64); ok {
+		r0 = rf(ctx, webhookID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, webhookID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_DeleteWebhook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteWebhook'
+type MockStore_DeleteWebhook_Call struct {
+	*mock.Call
+}
+
+// DeleteWebhook is a helper method to define mock.On call
+//   - ctx context.Context
+//   - webhookID int64
+func (_e *MockStore_Expecter) DeleteWebhook(ctx interface{}, webhookID interface{}) *MockStore_DeleteWebhook_Call {
+	return &MockStore_DeleteWebhook_Call{Call: _e.mock.On("DeleteWebhook", ctx, webhookID)}
+}
+
+func (_c *MockStore_DeleteWebhook_Call) Run(run func(ctx context.Context, webhookID int64)) *MockStore_DeleteWebhook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockStore_DeleteWebhook_Call) Return(_a0 int64, _a1 error) *MockStore_DeleteWebhook_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_DeleteWebhook_Call) RunAndReturn(run func(context.Context, int64) (int64, error)) *MockStore_DeleteWebhook_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteWebhookDeliveries provides a mock function with given fields: ctx, webhookID
+func (_m *MockStore) DeleteWebhookDeliveries(ctx context.Context, webhookID int64) error {
+	ret := _m.Called(ctx, webhookID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, webhookID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockStore_DeleteWebhookDeliveries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteWebhookDeliveries'
+type MockStore_DeleteWebhookDeliveries_Call struct {
+	*mock.Call
+}
+
+// DeleteWebhookDeliveries is a helper method to define mock.On call
+//   - ctx context.Context
+//   - webhookID int64
+func (_e *MockStore_Expecter) DeleteWebhookDeliveries(ctx interface{}, webhookID interface{}) *MockStore_DeleteWebhookDeliveries_Call {
+	return &MockStore_DeleteWebhookDeliveries_Call{Call: _e.mock.On("DeleteWebhookDeliveries", ctx, webhookID)}
+}
+
+func (_c *MockStore_DeleteWebhookDeliveries_Call) Run(run func(ctx context.Context, webhookID int64)) *MockStore_DeleteWebhookDeliveries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockStore_DeleteWebhookDeliveries_Call) Return(_a0 error) *MockStore_DeleteWebhookDeliveries_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockStore_DeleteWebhookDeliveries_Call) RunAndReturn(run func(context.Context, int64) error) *MockStore_DeleteWebhookDeliveries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DetachPurgedOrderItems provides a mock function with given fields: ctx, deletedBefore
+func (_m *MockStore) DetachPurgedOrderItems(ctx context.Context, deletedBefore sql.NullTime) error {
+	ret := _m.Called(ctx, deletedBefore)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, sql.NullTime) error); ok {
+		r0 = rf(ctx, deletedBefore)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockStore_DetachPurgedOrderItems_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DetachPurgedOrderItems'
+type MockStore_DetachPurgedOrderItems_Call struct {
+	*mock.Call
+}
+
+// DetachPurgedOrderItems is a helper method to define mock.On call
+//   - ctx context.Context
+//   - deletedBefore sql.NullTime
+func (_e *MockStore_Expecter) DetachPurgedOrderItems(ctx interface{}, deletedBefore interface{}) *MockStore_DetachPurgedOrderItems_Call {
+	return &MockStore_DetachPurgedOrderItems_Call{Call: _e.mock.On("DetachPurgedOrderItems", ctx, deletedBefore)}
+}
+
+func (_c *MockStore_DetachPurgedOrderItems_Call) Run(run func(ctx context.Context, deletedBefore sql.NullTime)) *MockStore_DetachPurgedOrderItems_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(sql.NullTime))
+	})
+	return _c
+}
+
+func (_c *MockStore_DetachPurgedOrderItems_Call) Return(_a0 error) *MockStore_DetachPurgedOrderItems_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockStore_DetachPurgedOrderItems_Call) RunAndReturn(run func(context.Context, sql.NullTime) error) *MockStore_DetachPurgedOrderItems_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EnqueueWebhookDeliveries provides a mock function with given fields: ctx, arg
+func (_m *MockStore) EnqueueWebhookDeliveries(ctx context.Context, arg db.EnqueueWebhookDeliveriesParams) (int64, error) {
+	ret := _m.Called(ctx, arg)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.EnqueueWebhookDeliveriesParams) (int64, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.EnqueueWebhookDeliveriesParams) int64); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.EnqueueWebhookDeliveriesParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_EnqueueWebhookDeliveries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EnqueueWebhookDeliveries'
+type MockStore_EnqueueWebhookDeliveries_Call struct {
+	*mock.Call
+}
+
+// EnqueueWebhookDeliveries is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.EnqueueWebhookDeliveriesParams
+func (_e *MockStore_Expecter) EnqueueWebhookDeliveries(ctx interface{}, arg interface{}) *MockStore_EnqueueWebhookDeliveries_Call {
+	return &MockStore_EnqueueWebhookDeliveries_Call{Call: _e.mock.On("EnqueueWebhookDeliveries", ctx, arg)}
+}
+
+func (_c *MockStore_EnqueueWebhookDeliveries_Call) Run(run func(ctx context.Context, arg db.EnqueueWebhookDeliveriesParams)) *MockStore_EnqueueWebhookDeliveries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.EnqueueWebhookDeliveriesParams))
+	})
+	return _c
+}
+
+func (_c *MockStore_EnqueueWebhookDeliveries_Call) Return(_a0 int64, _a1 error) *MockStore_EnqueueWebhookDeliveries_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_EnqueueWebhookDeliveries_Call) RunAndReturn(run func(context.Context, db.EnqueueWebhookDeliveriesParams) (int64, error)) *MockStore_EnqueueWebhookDeliveries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ExecTx provides a mock function with given fields: ctx, fn
+func (_m *MockStore) ExecTx(ctx context.Context, fn func(db.Querier) error) error {
+	ret := _m.Called(ctx, fn)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, func(db.Querier) error) error); ok {
+		r0 = rf(ctx, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockStore_ExecTx_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExecTx'
+type MockStore_ExecTx_Call struct {
+	*mock.Call
+}
+
+// ExecTx is a helper method to define mock.On call
+//   - ctx context.Context
+//   - fn func(db.Querier) error
+func (_e *MockStore_Expecter) ExecTx(ctx interface{}, fn interface{}) *MockStore_ExecTx_Call {
+	return &MockStore_ExecTx_Call{Call: _e.mock.On("ExecTx", ctx, fn)}
+}
+
+func (_c *MockStore_ExecTx_Call) Run(run func(ctx context.Context, fn func(db.Querier) error)) *MockStore_ExecTx_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(func(db.Querier) error))
+	})
+	return _c
+}
+
+func (_c *MockStore_ExecTx_Call) Return(_a0 error) *MockStore_ExecTx_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockStore_ExecTx_Call) RunAndReturn(run func(context.Context, func(db.Querier) error) error) *MockStore_ExecTx_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAuthor provides a mock function with given fields: ctx, arg
+func (_m *MockStore) GetAuthor(ctx context.Context, arg db.GetAuthorParams) (db.Author, error) {
+	ret := _m.Called(ctx, arg)
+
+	var r0 db.Author
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.GetAuthorParams) (db.Author, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.GetAuthorParams) db.Author); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.Author)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.GetAuthorParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_GetAuthor_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAuthor'
+type MockStore_GetAuthor_Call struct {
+	*mock.Call
+}
+
+// GetAuthor is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.GetAuthorParams
+func (_e *MockStore_Expecter) GetAuthor(ctx interface{}, arg interface{}) *MockStore_GetAuthor_Call {
+	return &MockStore_GetAuthor_Call{Call: _e.mock.On("GetAuthor", ctx, arg)}
+}
+
+func (_c *MockStore_GetAuthor_Call) Run(run func(ctx context.Context, arg db.GetAuthorParams)) *MockStore_GetAuthor_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.GetAuthorParams))
+	})
+	return _c
+}
+
+func (_c *MockStore_GetAuthor_Call) Return(_a0 db.Author, _a1 error) *MockStore_GetAuthor_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_GetAuthor_Call) RunAndReturn(run func(context.Context, db.GetAuthorParams) (db.Author, error)) *MockStore_GetAuthor_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAuthorByName provides a mock function with given fields: ctx, arg
+func (_m *MockStore) GetAuthorByName(ctx context.Context, arg db.GetAuthorByNameParams) (db.Author, error) {
+	ret := _m.Called(ctx, arg)
+
+	var r0 db.Author
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.GetAuthorByNameParams) (db.Author, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.GetAuthorByNameParams) db.Author); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.Author)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.GetAuthorByNameParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_GetAuthorByName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAuthorByName'
+type MockStore_GetAuthorByName_Call struct {
+	*mock.Call
+}
+
+// GetAuthorByName is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.GetAuthorByNameParams
+func (_e *MockStore_Expecter) GetAuthorByName(ctx interface{}, arg interface{}) *MockStore_GetAuthorByName_Call {
+	return &MockStore_GetAuthorByName_Call{Call: _e.mock.On("GetAuthorByName", ctx, arg)}
+}
+
+func (_c *MockStore_GetAuthorByName_Call) Run(run func(ctx context.Context, arg db.GetAuthorByNameParams)) *MockStore_GetAuthorByName_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.GetAuthorByNameParams))
+	})
+	return _c
+}
+
+func (_c *MockStore_GetAuthorByName_Call) Return(_a0 db.Author, _a1 error) *MockStore_GetAuthorByName_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_GetAuthorByName_Call) RunAndReturn(run func(context.Context, db.GetAuthorByNameParams) (db.Author, error)) *MockStore_GetAuthorByName_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBookByISBN provides a mock function with given fields: ctx, arg
+func (_m *MockStore) GetBookByISBN(ctx context.Context, arg db.GetBookByISBNParams) (db.GetBookByISBNRow, error) {
+	ret := _m.Called(ctx, arg)
+
+	var r0 db.GetBookByISBNRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.GetBookByISBNParams) (db.GetBookByISBNRow, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.GetBookByISBNParams) db.GetBookByISBNRow); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.GetBookByISBNRow)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.GetBookByISBNParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_GetBookByISBN_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBookByISBN'
+type MockStore_GetBookByISBN_Call struct {
+	*mock.Call
+}
+
+// GetBookByISBN is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.GetBookByISBNParams
+func (_e *MockStore_Expecter) GetBookByISBN(ctx interface{}, arg interface{}) *MockStore_GetBookByISBN_Call {
+	return &MockStore_GetBookByISBN_Call{Call: _e.mock.On("GetBookByISBN", ctx, arg)}
+}
+
+func (_c *MockStore_GetBookByISBN_Call) Run(run func(ctx context.Context, arg db.GetBookByISBNParams)) *MockStore_GetBookByISBN_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.GetBookByISBNParams))
+	})
+	return _c
+}
+
+func (_c *MockStore_GetBookByISBN_Call) Return(_a0 db.GetBookByISBNRow, _a1 error) *MockStore_GetBookByISBN_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_GetBookByISBN_Call) RunAndReturn(run func(context.Context, db.GetBookByISBNParams) (db.GetBookByISBNRow, error)) *MockStore_GetBookByISBN_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCartByToken provides a mock function with given fields: ctx, token
+func (_m *MockStore) GetCartByToken(ctx context.Context, token string) (db.Cart, error) {
+	ret := _m.Called(ctx, token)
+
+	var r0 db.Cart
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (db.Cart, error)); ok {
+		return rf(ctx, token)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) db.Cart); ok {
+		r0 = rf(ctx, token)
+	} else {
+		r0 = ret.Get(0).(db.Cart)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_GetCartByToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCartByToken'
+type MockStore_GetCartByToken_Call struct {
+	*mock.Call
+}
+
+// GetCartByToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - token string
+func (_e *MockStore_Expecter) GetCartByToken(ctx interface{}, token interface{}) *MockStore_GetCartByToken_Call {
+	return &MockStore_GetCartByToken_Call{Call: _e.mock.On("GetCartByToken", ctx, token)}
+}
+
+func (_c *MockStore_GetCartByToken_Call) Run(run func(ctx context.Context, token string)) *MockStore_GetCartByToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockStore_GetCartByToken_Call) Return(_a0 db.Cart, _a1 error) *MockStore_GetCartByToken_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_GetCartByToken_Call) RunAndReturn(run func(context.Context, string) (db.Cart, error)) *MockStore_GetCartByToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCartByUserID provides a mock function with given fields: ctx, userID
+func (_m *MockStore) GetCartByUserID(ctx context.Context, userID sql.NullString) (db.Cart, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 db.Cart
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, sql.NullString) (db.Cart, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, sql.NullString) db.Cart); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(db.Cart)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, sql.NullString) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_GetCartByUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCartByUserID'
+type MockStore_GetCartByUserID_Call struct {
+	*mock.Call
+}
+
+// GetCartByUserID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID sql.NullString
+func (_e *MockStore_Expecter) GetCartByUserID(ctx interface{}, userID interface{}) *MockStore_GetCartByUserID_Call {
+	return &MockStore_GetCartByUserID_Call{Call: _e.mock.On("GetCartByUserID", ctx, userID)}
+}
+
+func (_c *MockStore_GetCartByUserID_Call) Run(run func(ctx context.Context, userID sql.NullString)) *MockStore_GetCartByUserID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(sql.NullString))
+	})
+	return _c
+}
+
+func (_c *MockStore_GetCartByUserID_Call) Return(_a0 db.Cart, _a1 error) *MockStore_GetCartByUserID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_GetCartByUserID_Call) RunAndReturn(run func(context.Context, sql.NullString) (db.Cart, error)) *MockStore_GetCartByUserID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetOrder provides a mock function with given fields: ctx, orderID
+func (_m *MockStore) GetOrder(ctx context.Context, orderID int64) (db.Order, error) {
+	ret := _m.Called(ctx, orderID)
+
+	var r0 db.Order
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.Order, error)); ok {
+		return rf(ctx, orderID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) db.Order); ok {
+		r0 = rf(ctx, orderID)
+	} else {
+		r0 = ret.Get(0).(db.Order)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, orderID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_GetOrder_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetOrder'
+type MockStore_GetOrder_Call struct {
+	*mock.Call
+}
+
+// GetOrder is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orderID int64
+func (_e *MockStore_Expecter) GetOrder(ctx interface{}, orderID interface{}) *MockStore_GetOrder_Call {
+	return &MockStore_GetOrder_Call{Call: _e.mock.On("GetOrder", ctx, orderID)}
+}
+
+func (_c *MockStore_GetOrder_Call) Run(run func(ctx context.Context, orderID int64)) *MockStore_GetOrder_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockStore_GetOrder_Call) Return(_a0 db.Order, _a1 error) *MockStore_GetOrder_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_GetOrder_Call) RunAndReturn(run func(context.Context, int64) (db.Order, error)) *MockStore_GetOrder_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPublisher provides a mock function with given fields: ctx, arg
+func (_m *MockStore) GetPublisher(ctx context.Context, arg db.GetPublisherParams) (db.Publisher, error) {
+	ret := _m.Called(ctx, arg)
+
+	var r0 db.Publisher
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.GetPublisherParams) (db.Publisher, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.GetPublisherParams) db.Publisher); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.Publisher)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.GetPublisherParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_GetPublisher_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPublisher'
+type MockStore_GetPublisher_Call struct {
+	*mock.Call
+}
+
+// GetPublisher is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.GetPublisherParams
+func (_e *MockStore_Expecter) GetPublisher(ctx interface{}, arg interface{}) *MockStore_GetPublisher_Call {
+	return &MockStore_GetPublisher_Call{Call: _e.mock.On("GetPublisher", ctx, arg)}
+}
+
+func (_c *MockStore_GetPublisher_Call) Run(run func(ctx context.Context, arg db.GetPublisherParams)) *MockStore_GetPublisher_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.GetPublisherParams))
+	})
+	return _c
+}
+
+func (_c *MockStore_GetPublisher_Call) Return(_a0 db.Publisher, _a1 error) *MockStore_GetPublisher_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_GetPublisher_Call) RunAndReturn(run func(context.Context, db.GetPublisherParams) (db.Publisher, error)) *MockStore_GetPublisher_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPublisherByName provides a mock function with given fields: ctx, arg
+func (_m *MockStore) GetPublisherByName(ctx context.Context, arg db.GetPublisherByNameParams) (db.Publisher, error) {
+	ret := _m.Called(ctx, arg)
+
+	var r0 db.Publisher
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.GetPublisherByNameParams) (db.Publisher, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.GetPublisherByNameParams) db.Publisher); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.Publisher)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.GetPublisherByNameParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_GetPublisherByName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPublisherByName'
+type MockStore_GetPublisherByName_Call struct {
+	*mock.Call
+}
+
+// GetPublisherByName is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.GetPublisherByNameParams
+func (_e *MockStore_Expecter) GetPublisherByName(ctx interface{}, arg interface{}) *MockStore_GetPublisherByName_Call {
+	return &MockStore_GetPublisherByName_Call{Call: _e.mock.On("GetPublisherByName", ctx, arg)}
+}
+
+func (_c *MockStore_GetPublisherByName_Call) Run(run func(ctx context.Context, arg db.GetPublisherByNameParams)) *MockStore_GetPublisherByName_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.GetPublisherByNameParams))
+	})
+	return _c
+}
+
+func (_c *MockStore_GetPublisherByName_Call) Return(_a0 db.Publisher, _a1 error) *MockStore_GetPublisherByName_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_GetPublisherByName_Call) RunAndReturn(run func(context.Context, db.GetPublisherByNameParams) (db.Publisher, error)) *MockStore_GetPublisherByName_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWebhook provides a mock function with given fields: ctx, webhookID
+func (_m *MockStore) GetWebhook(ctx context.Context, webhookID int64) (db.Webhook, error) {
+	ret := _m.Called(ctx, webhookID)
+
+	var r0 db.Webhook
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.Webhook, error)); ok {
+		return rf(ctx, webhookID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) db.Webhook); ok {
+		r0 = rf(ctx, webhookID)
+	} else {
+		r0 = ret.Get(0).(db.Webhook)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, webhookID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_GetWebhook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWebhook'
+type MockStore_GetWebhook_Call struct {
+	*mock.Call
+}
+
+// GetWebhook is a helper method to define mock.On call
+//   - ctx context.Context
+//   - webhookID int64
+func (_e *MockStore_Expecter) GetWebhook(ctx interface{}, webhookID interface{}) *MockStore_GetWebhook_Call {
+	return &MockStore_GetWebhook_Call{Call: _e.mock.On("GetWebhook", ctx, webhookID)}
+}
+
+func (_c *MockStore_GetWebhook_Call) Run(run func(ctx context.Context, webhookID int64)) *MockStore_GetWebhook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockStore_GetWebhook_Call) Return(_a0 db.Webhook, _a1 error) *MockStore_GetWebhook_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_GetWebhook_Call) RunAndReturn(run func(context.Context, int64) (db.Webhook, error)) *MockStore_GetWebhook_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LeaseWebhookDelivery provides a mock function with given fields: ctx, arg
+func (_m *MockStore) LeaseWebhookDelivery(ctx context.Context, arg db.LeaseWebhookDeliveryParams) (int64, error) {
+	ret := _m.Called(ctx, arg)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.LeaseWebhookDeliveryParams) (int64, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.LeaseWebhookDeliveryParams) int64); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.LeaseWebhookDeliveryParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_LeaseWebhookDelivery_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LeaseWebhookDelivery'
+type MockStore_LeaseWebhookDelivery_Call struct {
+	*mock.Call
+}
+
+// LeaseWebhookDelivery is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.LeaseWebhookDeliveryParams
+func (_e *MockStore_Expecter) LeaseWebhookDelivery(ctx interface{}, arg interface{}) *MockStore_LeaseWebhookDelivery_Call {
+	return &MockStore_LeaseWebhookDelivery_Call{Call: _e.mock.On("LeaseWebhookDelivery", ctx, arg)}
+}
+
+func (_c *MockStore_LeaseWebhookDelivery_Call) Run(run func(ctx context.Context, arg db.LeaseWebhookDeliveryParams)) *MockStore_LeaseWebhookDelivery_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.LeaseWebhookDeliveryParams))
+	})
+	return _c
+}
+
+func (_c *MockStore_LeaseWebhookDelivery_Call) Return(_a0 int64, _a1 error) *MockStore_LeaseWebhookDelivery_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_LeaseWebhookDelivery_Call) RunAndReturn(run func(context.Context, db.LeaseWebhookDeliveryParams) (int64, error)) *MockStore_LeaseWebhookDelivery_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListAuthorBooks provides a mock function with given fields: ctx, authorIds
+func (_m *MockStore) ListAuthorBooks(ctx context.Context, authorIds []int64) ([]db.ListAuthorBooksRow, error) {
+	ret := _m.Called(ctx, authorIds)
+
+	var r0 []db.ListAuthorBooksRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []int64) ([]db.ListAuthorBooksRow, error)); ok {
+		return rf(ctx, authorIds)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []int64) []db.ListAuthorBooksRow); ok {
+		r0 = rf(ctx, authorIds)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.ListAuthorBooksRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []int64) error); ok {
+		r1 = rf(ctx, authorIds)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_ListAuthorBooks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListAuthorBooks'
+type MockStore_ListAuthorBooks_Call struct {
+	*mock.Call
+}
+
+// ListAuthorBooks is a helper method to define mock.On call
+//   - ctx context.Context
+//   - authorIds []int64
+func (_e *MockStore_Expecter) ListAuthorBooks(ctx interface{}, authorIds interface{}) *MockStore_ListAuthorBooks_Call {
+	return &MockStore_ListAuthorBooks_Call{Call: _e.mock.On("ListAuthorBooks", ctx, authorIds)}
+}
+
+func (_c *MockStore_ListAuthorBooks_Call) Run(run func(ctx context.Context, authorIds []int64)) *MockStore_ListAuthorBooks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]int64))
+	})
+	return _c
+}
+
+func (_c *MockStore_ListAuthorBooks_Call) Return(_a0 []db.ListAuthorBooksRow, _a1 error) *MockStore_ListAuthorBooks_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_ListAuthorBooks_Call) RunAndReturn(run func(context.Context, []int64) ([]db.ListAuthorBooksRow, error)) *MockStore_ListAuthorBooks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListAuthors provides a mock function with given fields: ctx, arg
+func (_m *MockStore) ListAuthors(ctx context.Context, arg db.ListAuthorsParams) ([]db.Author, error) {
+	ret := _m.Called(ctx, arg)
+
+	var r0 []db.Author
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListAuthorsParams) ([]db.Author, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListAuthorsParams) []db.Author); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Author)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.ListAuthorsParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_ListAuthors_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListAuthors'
+type MockStore_ListAuthors_Call struct {
+	*mock.Call
+}
+
+// ListAuthors is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.ListAuthorsParams
+func (_e *MockStore_Expecter) ListAuthors(ctx interface{}, arg interface{}) *MockStore_ListAuthors_Call {
+	return &MockStore_ListAuthors_Call{Call: _e.mock.On("ListAuthors", ctx, arg)}
+}
+
+func (_c *MockStore_ListAuthors_Call) Run(run func(ctx context.Context, arg db.ListAuthorsParams)) *MockStore_ListAuthors_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.ListAuthorsParams))
+	})
+	return _c
+}
+
+func (_c *MockStore_ListAuthors_Call) Return(_a0 []db.Author, _a1 error) *MockStore_ListAuthors_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_ListAuthors_Call) RunAndReturn(run func(context.Context, db.ListAuthorsParams) ([]db.Author, error)) *MockStore_ListAuthors_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListAuthorsWithBookID provides a mock function with given fields: ctx, bookID
+func (_m *MockStore) ListAuthorsWithBookID(ctx context.Context, bookID int64) ([]db.ListAuthorsWithBookIDRow, error) {
+	ret := _m.Called(ctx, bookID)
+
+	var r0 []db.ListAuthorsWithBookIDRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]db.ListAuthorsWithBookIDRow, error)); ok {
+		return rf(ctx, bookID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []db.ListAuthorsWithBookIDRow); ok {
+		r0 = rf(ctx, bookID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.ListAuthorsWithBookIDRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, bookID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_ListAuthorsWithBookID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListAuthorsWithBookID'
+type MockStore_ListAuthorsWithBookID_Call struct {
+	*mock.Call
+}
+
+// ListAuthorsWithBookID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - bookID int64
+func (_e *MockStore_Expecter) ListAuthorsWithBookID(ctx interface{}, bookID interface{}) *MockStore_ListAuthorsWithBookID_Call {
+	return &MockStore_ListAuthorsWithBookID_Call{Call: _e.mock.On("ListAuthorsWithBookID", ctx, bookID)}
+}
+
+func (_c *MockStore_ListAuthorsWithBookID_Call) Run(run func(ctx context.Context, bookID int64)) *MockStore_ListAuthorsWithBookID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockStore_ListAuthorsWithBookID_Call) Return(_a0 []db.ListAuthorsWithBookIDRow, _a1 error) *MockStore_ListAuthorsWithBookID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_ListAuthorsWithBookID_Call) RunAndReturn(run func(context.Context, int64) ([]db.ListAuthorsWithBookIDRow, error)) *MockStore_ListAuthorsWithBookID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListBookAuthors provides a mock function with given fields: ctx, bookIds
+func (_m *MockStore) ListBookAuthors(ctx context.Context, bookIds []int64) ([]db.ListBookAuthorsRow, error) {
+	ret := _m.Called(ctx, bookIds)
+
+	var r0 []db.ListBookAuthorsRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []int64) ([]db.ListBookAuthorsRow, error)); ok {
+		return rf(ctx, bookIds)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []int64) []db.ListBookAuthorsRow); ok {
+		r0 = rf(ctx, bookIds)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.ListBookAuthorsRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []int64) error); ok {
+		r1 = rf(ctx, bookIds)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_ListBookAuthors_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListBookAuthors'
+type MockStore_ListBookAuthors_Call struct {
+	*mock.Call
+}
+
+// ListBookAuthors is a helper method to define mock.On call
+//   - ctx context.Context
+//   - bookIds []int64
+func (_e *MockStore_Expecter) ListBookAuthors(ctx interface{}, bookIds interface{}) *MockStore_ListBookAuthors_Call {
+	return &MockStore_ListBookAuthors_Call{Call: _e.mock.On("ListBookAuthors", ctx, bookIds)}
+}
+
+func (_c *MockStore_ListBookAuthors_Call) Run(run func(ctx context.Context, bookIds []int64)) *MockStore_ListBookAuthors_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]int64))
+	})
+	return _c
+}
+
+func (_c *MockStore_ListBookAuthors_Call) Return(_a0 []db.ListBookAuthorsRow, _a1 error) *MockStore_ListBookAuthors_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_ListBookAuthors_Call) RunAndReturn(run func(context.Context, []int64) ([]db.ListBookAuthorsRow, error)) *MockStore_ListBookAuthors_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListBookPublishers provides a mock function with given fields: ctx, bookIds
+func (_m *MockStore) ListBookPublishers(ctx context.Context, bookIds []int64) ([]db.ListBookPublishersRow, error) {
+	ret := _m.Called(ctx, bookIds)
+
+	var r0 []db.ListBookPublishersRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []int64) ([]db.ListBookPublishersRow, error)); ok {
+		return rf(ctx, bookIds)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []int64) []db.ListBookPublishersRow); ok {
+		r0 = rf(ctx, bookIds)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.ListBookPublishersRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []int64) error); ok {
+		r1 = rf(ctx, bookIds)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_ListBookPublishers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListBookPublishers'
+type MockStore_ListBookPublishers_Call struct {
+	*mock.Call
+}
+
+// ListBookPublishers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - bookIds []int64
+func (_e *MockStore_Expecter) ListBookPublishers(ctx interface{}, bookIds interface{}) *MockStore_ListBookPublishers_Call {
+	return &MockStore_ListBookPublishers_Call{Call: _e.mock.On("ListBookPublishers", ctx, bookIds)}
+}
+
+func (_c *MockStore_ListBookPublishers_Call) Run(run func(ctx context.Context, bookIds []int64)) *MockStore_ListBookPublishers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]int64))
+	})
+	return _c
+}
+
+func (_c *MockStore_ListBookPublishers_Call) Return(_a0 []db.ListBookPublishersRow, _a1 error) *MockStore_ListBookPublishers_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_ListBookPublishers_Call) RunAndReturn(run func(context.Context, []int64) ([]db.ListBookPublishersRow, error)) *MockStore_ListBookPublishers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListBooks provides a mock function with given fields: ctx, arg
+func (_m *MockStore) ListBooks(ctx context.Context, arg db.ListBooksParams) ([]db.ListBooksRow, error) {
+	ret := _m.Called(ctx, arg)
+
+	var r0 []db.ListBooksRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListBooksParams) ([]db.ListBooksRow, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListBooksParams) []db.ListBooksRow); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.ListBooksRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.ListBooksParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_ListBooks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListBooks'
+type MockStore_ListBooks_Call struct {
+	*mock.Call
+}
+
+// ListBooks is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.ListBooksParams
+func (_e *MockStore_Expecter) ListBooks(ctx interface{}, arg interface{}) *MockStore_ListBooks_Call {
+	return &MockStore_ListBooks_Call{Call: _e.mock.On("ListBooks", ctx, arg)}
+}
+
+func (_c *MockStore_ListBooks_Call) Run(run func(ctx context.Context, arg db.ListBooksParams)) *MockStore_ListBooks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.ListBooksParams))
+	})
+	return _c
+}
+
+func (_c *MockStore_ListBooks_Call) Return(_a0 []db.ListBooksRow, _a1 error) *MockStore_ListBooks_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_ListBooks_Call) RunAndReturn(run func(context.Context, db.ListBooksParams) ([]db.ListBooksRow, error)) *MockStore_ListBooks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListCartItems provides a mock function with given fields: ctx, cartID
+func (_m *MockStore) ListCartItems(ctx context.Context, cartID int64) ([]db.ListCartItemsRow, error) {
+	ret := _m.Called(ctx, cartID)
+
+	var r0 []db.ListCartItemsRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]db.ListCartItemsRow, error)); ok {
+		return rf(ctx, cartID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []db.ListCartItemsRow); ok {
+		r0 = rf(ctx, cartID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.ListCartItemsRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, cartID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_ListCartItems_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListCartItems'
+type MockStore_ListCartItems_Call struct {
+	*mock.Call
+}
+
+// ListCartItems is a helper method to define mock.On call
+//   - ctx context.Context
+//   - cartID int64
+func (_e *MockStore_Expecter) ListCartItems(ctx interface{}, cartID interface{}) *MockStore_ListCartItems_Call {
+	return &MockStore_ListCartItems_Call{Call: _e.mock.On("ListCartItems", ctx, cartID)}
+}
+
+func (_c *MockStore_ListCartItems_Call) Run(run func(ctx context.Context, cartID int64)) *MockStore_ListCartItems_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockStore_ListCartItems_Call) Return(_a0 []db.ListCartItemsRow, _a1 error) *MockStore_ListCartItems_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_ListCartItems_Call) RunAndReturn(run func(context.Context, int64) ([]db.ListCartItemsRow, error)) *MockStore_ListCartItems_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListChanges provides a mock function with given fields: ctx, arg
+func (_m *MockStore) ListChanges(ctx context.Context, arg db.ListChangesParams) ([]db.ListChangesRow, error) {
+	ret := _m.Called(ctx, arg)
+
+	var r0 []db.ListChangesRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListChangesParams) ([]db.ListChangesRow, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListChangesParams) []db.ListChangesRow); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.ListChangesRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.ListChangesParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_ListChanges_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListChanges'
+type MockStore_ListChanges_Call struct {
+	*mock.Call
+}
+
+// ListChanges is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.ListChangesParams
+func (_e *MockStore_Expecter) ListChanges(ctx interface{}, arg interface{}) *MockStore_ListChanges_Call {
+	return &MockStore_ListChanges_Call{Call: _e.mock.On("ListChanges", ctx, arg)}
+}
+
+func (_c *MockStore_ListChanges_Call) Run(run func(ctx context.Context, arg db.ListChangesParams)) *MockStore_ListChanges_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.ListChangesParams))
+	})
+	return _c
+}
+
+func (_c *MockStore_ListChanges_Call) Return(_a0 []db.ListChangesRow, _a1 error) *MockStore_ListChanges_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_ListChanges_Call) RunAndReturn(run func(context.Context, db.ListChangesParams) ([]db.ListChangesRow, error)) *MockStore_ListChanges_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListDueWebhookDeliveries provides a mock function with given fields: ctx, arg
+func (_m *MockStore) ListDueWebhookDeliveries(ctx context.Context, arg db.ListDueWebhookDeliveriesParams) ([]db.ListDueWebhookDeliveriesRow, error) {
+	ret := _m.Called(ctx, arg)
+
+	var r0 []db.ListDueWebhookDeliveriesRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListDueWebhookDeliveriesParams) ([]db.ListDueWebhookDeliveriesRow, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListDueWebhookDeliveriesParams) []db.ListDueWebhookDeliveriesRow); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.ListDueWebhookDeliveriesRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.ListDueWebhookDeliveriesParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_ListDueWebhookDeliveries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListDueWebhookDeliveries'
+type MockStore_ListDueWebhookDeliveries_Call struct {
+	*mock.Call
+}
+
+// ListDueWebhookDeliveries is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.ListDueWebhookDeliveriesParams
+func (_e *MockStore_Expecter) ListDueWebhookDeliveries(ctx interface{}, arg interface{}) *MockStore_ListDueWebhookDeliveries_Call {
+	return &MockStore_ListDueWebhookDeliveries_Call{Call: _e.mock.On("ListDueWebhookDeliveries", ctx, arg)}
+}
+
+func (_c *MockStore_ListDueWebhookDeliveries_Call) Run(run func(ctx context.Context, arg db.ListDueWebhookDeliveriesParams)) *MockStore_ListDueWebhookDeliveries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.ListDueWebhookDeliveriesParams))
+	})
+	return _c
+}
+
+func (_c *MockStore_ListDueWebhookDeliveries_Call) Return(_a0 []db.ListDueWebhookDeliveriesRow, _a1 error) *MockStore_ListDueWebhookDeliveries_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_ListDueWebhookDeliveries_Call) RunAndReturn(run func(context.Context, db.ListDueWebhookDeliveriesParams) ([]db.ListDueWebhookDeliveriesRow, error)) *MockStore_ListDueWebhookDeliveries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListOrderItems provides a mock function with given fields: ctx, orderID
+func (_m *MockStore) ListOrderItems(ctx context.Context, orderID int64) ([]db.OrderItem, error) {
+	ret := _m.Called(ctx, orderID)
+
+	var r0 []db.OrderItem
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]db.OrderItem, error)); ok {
+		return rf(ctx, orderID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []db.OrderItem); ok {
+		r0 = rf(ctx, orderID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.OrderItem)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, orderID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_ListOrderItems_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListOrderItems'
+type MockStore_ListOrderItems_Call struct {
+	*mock.Call
+}
+
+// ListOrderItems is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orderID int64
+func (_e *MockStore_Expecter) ListOrderItems(ctx interface{}, orderID interface{}) *MockStore_ListOrderItems_Call {
+	return &MockStore_ListOrderItems_Call{Call: _e.mock.On("ListOrderItems", ctx, orderID)}
+}
+
+func (_c *MockStore_ListOrderItems_Call) Run(run func(ctx context.Context, orderID int64)) *MockStore_ListOrderItems_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockStore_ListOrderItems_Call) Return(_a0 []db.OrderItem, _a1 error) *MockStore_ListOrderItems_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_ListOrderItems_Call) RunAndReturn(run func(context.Context, int64) ([]db.OrderItem, error)) *MockStore_ListOrderItems_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListOrderItemsByOrderIDs provides a mock function with given fields: ctx, orderIds
+func (_m *MockStore) ListOrderItemsByOrderIDs(ctx context.Context, orderIds []int64) ([]db.OrderItem, error) {
+	ret := _m.Called(ctx, orderIds)
+
+	var r0 []db.OrderItem
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []int64) ([]db.OrderItem, error)); ok {
+		return rf(ctx, orderIds)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []int64) []db.OrderItem); ok {
+		r0 = rf(ctx, orderIds)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.OrderItem)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []int64) error); ok {
+		r1 = rf(ctx, orderIds)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_ListOrderItemsByOrderIDs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListOrderItemsByOrderIDs'
+type MockStore_ListOrderItemsByOrderIDs_Call struct {
+	*mock.Call
+}
+
+// ListOrderItemsByOrderIDs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orderIds []int64
+func (_e *MockStore_Expecter) ListOrderItemsByOrderIDs(ctx interface{}, orderIds interface{}) *MockStore_ListOrderItemsByOrderIDs_Call {
+	return &MockStore_ListOrderItemsByOrderIDs_Call{Call: _e.mock.On("ListOrderItemsByOrderIDs", ctx, orderIds)}
+}
+
+func (_c *MockStore_ListOrderItemsByOrderIDs_Call) Run(run func(ctx context.Context, orderIds []int64)) *MockStore_ListOrderItemsByOrderIDs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]int64))
+	})
+	return _c
+}
+
+func (_c *MockStore_ListOrderItemsByOrderIDs_Call) Return(_a0 []db.OrderItem, _a1 error) *MockStore_ListOrderItemsByOrderIDs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_ListOrderItemsByOrderIDs_Call) RunAndReturn(run func(context.Context, []int64) ([]db.OrderItem, error)) *MockStore_ListOrderItemsByOrderIDs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListOrders provides a mock function with given fields: ctx, arg
+func (_m *MockStore) ListOrders(ctx context.Context, arg db.ListOrdersParams) ([]db.Order, error) {
+	ret := _m.Called(ctx, arg)
+
+	var r0 []db.Order
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListOrdersParams) ([]db.Order, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListOrdersParams) []db.Order); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Order)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.ListOrdersParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_ListOrders_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListOrders'
+type MockStore_ListOrders_Call struct {
+	*mock.Call
+}
+
+// ListOrders is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.ListOrdersParams
+func (_e *MockStore_Expecter) ListOrders(ctx interface{}, arg interface{}) *MockStore_ListOrders_Call {
+	return &MockStore_ListOrders_Call{Call: _e.mock.On("ListOrders", ctx, arg)}
+}
+
+func (_c *MockStore_ListOrders_Call) Run(run func(ctx context.Context, arg db.ListOrdersParams)) *MockStore_ListOrders_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.ListOrdersParams))
+	})
+	return _c
+}
+
+func (_c *MockStore_ListOrders_Call) Return(_a0 []db.Order, _a1 error) *MockStore_ListOrders_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_ListOrders_Call) RunAndReturn(run func(context.Context, db.ListOrdersParams) ([]db.Order, error)) *MockStore_ListOrders_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListPublisherBooks provides a mock function with given fields: ctx, publisherIds
+func (_m *MockStore) ListPublisherBooks(ctx context.Context, publisherIds []int64) ([]db.ListPublisherBooksRow, error) {
+	ret := _m.Called(ctx, publisherIds)
+
+	var r0 []db.ListPublisherBooksRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []int64) ([]db.ListPublisherBooksRow, error)); ok {
+		return rf(ctx, publisherIds)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []int64) []db.ListPublisherBooksRow); ok {
+		r0 = rf(ctx, publisherIds)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.ListPublisherBooksRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []int64) error); ok {
+		r1 = rf(ctx, publisherIds)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_ListPublisherBooks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListPublisherBooks'
+type MockStore_ListPublisherBooks_Call struct {
+	*mock.Call
+}
+
+// ListPublisherBooks is a helper method to define mock.On call
+//   - ctx context.Context
+//   - publisherIds []int64
+func (_e *MockStore_Expecter) ListPublisherBooks(ctx interface{}, publisherIds interface{}) *MockStore_ListPublisherBooks_Call {
+	return &MockStore_ListPublisherBooks_Call{Call: _e.mock.On("ListPublisherBooks", ctx, publisherIds)}
+}
+
+func (_c *MockStore_ListPublisherBooks_Call) Run(run func(ctx context.Context, publisherIds []int64)) *MockStore_ListPublisherBooks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]int64))
+	})
+	return _c
+}
+
+func (_c *MockStore_ListPublisherBooks_Call) Return(_a0 []db.ListPublisherBooksRow, _a1 error) *MockStore_ListPublisherBooks_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_ListPublisherBooks_Call) RunAndReturn(run func(context.Context, []int64) ([]db.ListPublisherBooksRow, error)) *MockStore_ListPublisherBooks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListPublishers provides a mock function with given fields: ctx, arg
+func (_m *MockStore) ListPublishers(ctx context.Context, arg db.ListPublishersParams) ([]db.Publisher, error) {
+	ret := _m.Called(ctx, arg)
+
+	var r0 []db.Publisher
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListPublishersParams) ([]db.Publisher, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListPublishersParams) []db.Publisher); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Publisher)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.ListPublishersParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_ListPublishers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListPublishers'
+type MockStore_ListPublishers_Call struct {
+	*mock.Call
+}
+
+// ListPublishers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.ListPublishersParams
+func (_e *MockStore_Expecter) ListPublishers(ctx interface{}, arg interface{}) *MockStore_ListPublishers_Call {
+	return &MockStore_ListPublishers_Call{Call: _e.mock.On("ListPublishers", ctx, arg)}
+}
+
+func (_c *MockStore_ListPublishers_Call) Run(run func(ctx context.Context, arg db.ListPublishersParams)) *MockStore_ListPublishers_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(db.CountBooksParams))
+		run(args[0].(context.Context), args[1].(db.ListPublishersParams))
 	})
 	return _c
 }
 
-func (_c *MockStore_CountBooks_Call) Return(_a0 int64, _a1 error) *MockStore_CountBooks_Call {
+func (_c *MockStore_ListPublishers_Call) Return(_a0 []db.Publisher, _a1 error) *MockStore_ListPublishers_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockStore_CountBooks_Call) RunAndReturn(run func(context.Context, db.CountBooksParams) (int64, error)) *MockStore_CountBooks_Call {
+func (_c *MockStore_ListPublishers_Call) RunAndReturn(run func(context.Context, db.ListPublishersParams) ([]db.Publisher, error)) *MockStore_ListPublishers_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CountPublishers provides a mock function with given fields: ctx
-func (_m *MockStore) CountPublishers(ctx context.Context) (int64, error) {
-	ret := _m.Called(ctx)
+// ListWebhookDeliveries provides a mock function with given fields: ctx, arg
+func (_m *MockStore) ListWebhookDeliveries(ctx context.Context, arg db.ListWebhookDeliveriesParams) ([]db.WebhookDelivery, error) {
+	ret := _m.Called(ctx, arg)
 
-	var r0 int64
+	var r0 []db.WebhookDelivery
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context) (int64, error)); ok {
-		return rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListWebhookDeliveriesParams) ([]db.WebhookDelivery, error)); ok {
+		return rf(ctx, arg)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context) int64); ok {
-		r0 = rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListWebhookDeliveriesParams) []db.WebhookDelivery); ok {
+		r0 = rf(ctx, arg)
 	} else {
-		r0 = ret.Get(0).(int64)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.WebhookDelivery)
+		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(ctx)
+	if rf, ok := ret.Get(1).(func(context.Context, db.ListWebhookDeliveriesParams) error); ok {
+		r1 = rf(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -151,51 +3036,54 @@ func (_m *MockStore) CountPublishers(ctx context.Context) (int64, error) {
 	return r0, r1
 }
 
-// MockStore_CountPublishers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountPublishers'
-type MockStore_CountPublishers_Call struct {
+// MockStore_ListWebhookDeliveries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListWebhookDeliveries'
+type MockStore_ListWebhookDeliveries_Call struct {
 	*mock.Call
 }
 
-// CountPublishers is a helper method to define mock.On call
+// ListWebhookDeliveries is a helper method to define mock.On call
 //   - ctx context.Context
-func (_e *MockStore_Expecter) CountPublishers(ctx interface{}) *MockStore_CountPublishers_Call {
-	return &MockStore_CountPublishers_Call{Call: _e.mock.On("CountPublishers", ctx)}
+//   - arg db.ListWebhookDeliveriesParams
+func (_e *MockStore_Expecter) ListWebhookDeliveries(ctx interface{}, arg interface{}) *MockStore_ListWebhookDeliveries_Call {
+	return &MockStore_ListWebhookDeliveries_Call{Call: _e.mock.On("ListWebhookDeliveries", ctx, arg)}
 }
 
-func (_c *MockStore_CountPublishers_Call) Run(run func(ctx context.Context)) *MockStore_CountPublishers_Call {
+func (_c *MockStore_ListWebhookDeliveries_Call) Run(run func(ctx context.Context, arg db.ListWebhookDeliveriesParams)) *MockStore_ListWebhookDeliveries_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context))
+		run(args[0].(context.Context), args[1].(db.ListWebhookDeliveriesParams))
 	})
 	return _c
 }
 
-func (_c *MockStore_CountPublishers_Call) Return(_a0 int64, _a1 error) *MockStore_CountPublishers_Call {
+func (_c *MockStore_ListWebhookDeliveries_Call) Return(_a0 []db.WebhookDelivery, _a1 error) *MockStore_ListWebhookDeliveries_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockStore_CountPublishers_Call) RunAndReturn(run func(context.Context) (int64, error)) *MockStore_CountPublishers_Call {
+func (_c *MockStore_ListWebhookDeliveries_Call) RunAndReturn(run func(context.Context, db.ListWebhookDeliveriesParams) ([]db.WebhookDelivery, error)) *MockStore_ListWebhookDeliveries_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateAuthor provides a mock function with given fields: ctx, arg
-func (_m *MockStore) CreateAuthor(ctx context.Context, arg db.CreateAuthorParams) (db.Author, error) {
-	ret := _m.Called(ctx, arg)
+// ListWebhooks provides a mock function with given fields: ctx
+func (_m *MockStore) ListWebhooks(ctx context.Context) ([]db.Webhook, error) {
+	ret := _m.Called(ctx)
 
-	var r0 db.Author
+	var r0 []db.Webhook
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, db.CreateAuthorParams) (db.Author, error)); ok {
-		return rf(ctx, arg)
+	if rf, ok := ret.Get(0).(func(context.Context) ([]db.Webhook, error)); ok {
+		return rf(ctx)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, db.CreateAuthorParams) db.Author); ok {
-		r0 = rf(ctx, arg)
+	if rf, ok := ret.Get(0).(func(context.Context) []db.Webhook); ok {
+		r0 = rf(ctx)
 	} else {
-		r0 = ret.Get(0).(db.Author)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Webhook)
+		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, db.CreateAuthorParams) error); ok {
-		r1 = rf(ctx, arg)
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -203,41 +3091,40 @@ func (_m *MockStore) CreateAuthor(ctx context.Context, arg db.CreateAuthorParams
 	return r0, r1
 }
 
-// MockStore_CreateAuthor_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateAuthor'
-type MockStore_CreateAuthor_Call struct {
+// MockStore_ListWebhooks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListWebhooks'
+type MockStore_ListWebhooks_Call struct {
 	*mock.Call
 }
 
-// CreateAuthor is a helper method to define mock.On call
+// ListWebhooks is a helper method to define mock.On call
 //   - ctx context.Context
-//   - arg db.CreateAuthorParams
-func (_e *MockStore_Expecter) CreateAuthor(ctx interface{}, arg interface{}) *MockStore_CreateAuthor_Call {
-	return &MockStore_CreateAuthor_Call{Call: _e.mock.On("CreateAuthor", ctx, arg)}
+func (_e *MockStore_Expecter) ListWebhooks(ctx interface{}) *MockStore_ListWebhooks_Call {
+	return &MockStore_ListWebhooks_Call{Call: _e.mock.On("ListWebhooks", ctx)}
 }
 
-func (_c *MockStore_CreateAuthor_Call) Run(run func(ctx context.Context, arg db.CreateAuthorParams)) *MockStore_CreateAuthor_Call {
+func (_c *MockStore_ListWebhooks_Call) Run(run func(ctx context.Context)) *MockStore_ListWebhooks_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(db.CreateAuthorParams))
+		run(args[0].(context.Context))
 	})
 	return _c
 }
 
-func (_c *MockStore_CreateAuthor_Call) Return(_a0 db.Author, _a1 error) *MockStore_CreateAuthor_Call {
+func (_c *MockStore_ListWebhooks_Call) Return(_a0 []db.Webhook, _a1 error) *MockStore_ListWebhooks_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockStore_CreateAuthor_Call) RunAndReturn(run func(context.Context, db.CreateAuthorParams) (db.Author, error)) *MockStore_CreateAuthor_Call {
+func (_c *MockStore_ListWebhooks_Call) RunAndReturn(run func(context.Context) ([]db.Webhook, error)) *MockStore_ListWebhooks_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateAuthorBookRel provides a mock function with given fields: ctx, arg
-func (_m *MockStore) CreateAuthorBookRel(ctx context.Context, arg db.CreateAuthorBookRelParams) error {
+// MergeCartItems provides a mock function with given fields: ctx, arg
+func (_m *MockStore) MergeCartItems(ctx context.Context, arg db.MergeCartItemsParams) error {
 	ret := _m.Called(ctx, arg)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, db.CreateAuthorBookRelParams) error); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, db.MergeCartItemsParams) error); ok {
 		r0 = rf(ctx, arg)
 	} else {
 		r0 = ret.Error(0)
@@ -246,158 +3133,137 @@ func (_m *MockStore) CreateAuthorBookRel(ctx context.Context, arg db.CreateAutho
 	return r0
 }
 
-// MockStore_CreateAuthorBookRel_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateAuthorBookRel'
-type MockStore_CreateAuthorBookRel_Call struct {
+// MockStore_MergeCartItems_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MergeCartItems'
+type MockStore_MergeCartItems_Call struct {
 	*mock.Call
 }
 
-// CreateAuthorBookRel is a helper method to define mock.On call
+// MergeCartItems is a helper method to define mock.On call
 //   - ctx context.Context
-//   - arg db.CreateAuthorBookRelParams
-func (_e *MockStore_Expecter) CreateAuthorBookRel(ctx interface{}, arg interface{}) *MockStore_CreateAuthorBookRel_Call {
-	return &MockStore_CreateAuthorBookRel_Call{Call: _e.mock.On("CreateAuthorBookRel", ctx, arg)}
+//   - arg db.MergeCartItemsParams
+func (_e *MockStore_Expecter) MergeCartItems(ctx interface{}, arg interface{}) *MockStore_MergeCartItems_Call {
+	return &MockStore_MergeCartItems_Call{Call: _e.mock.On("MergeCartItems", ctx, arg)}
 }
 
-func (_c *MockStore_CreateAuthorBookRel_Call) Run(run func(ctx context.Context, arg db.CreateAuthorBookRelParams)) *MockStore_CreateAuthorBookRel_Call {
+func (_c *MockStore_MergeCartItems_Call) Run(run func(ctx context.Context, arg db.MergeCartItemsParams)) *MockStore_MergeCartItems_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(db.CreateAuthorBookRelParams))
+		run(args[0].(context.Context), args[1].(db.MergeCartItemsParams))
 	})
 	return _c
 }
 
-func (_c *MockStore_CreateAuthorBookRel_Call) Return(_a0 error) *MockStore_CreateAuthorBookRel_Call {
+func (_c *MockStore_MergeCartItems_Call) Return(_a0 error) *MockStore_MergeCartItems_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockStore_CreateAuthorBookRel_Call) RunAndReturn(run func(context.Context, db.CreateAuthorBookRelParams) error) *MockStore_CreateAuthorBookRel_Call {
+func (_c *MockStore_MergeCartItems_Call) RunAndReturn(run func(context.Context, db.MergeCartItemsParams) error) *MockStore_MergeCartItems_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateBook provides a mock function with given fields: ctx, arg
-func (_m *MockStore) CreateBook(ctx context.Context, arg db.CreateBookParams) (db.Book, error) {
+// MergeCartTx provides a mock function with given fields: ctx, arg
+func (_m *MockStore) MergeCartTx(ctx context.Context, arg db.MergeCartTxParams) error {
 	ret := _m.Called(ctx, arg)
 
-	var r0 db.Book
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, db.CreateBookParams) (db.Book, error)); ok {
-		return rf(ctx, arg)
-	}
-	if rf, ok := ret.Get(0).(func(context.Context, db.CreateBookParams) db.Book); ok {
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.MergeCartTxParams) error); ok {
 		r0 = rf(ctx, arg)
 	} else {
-		r0 = ret.Get(0).(db.Book)
-	}
-
-	if rf, ok := ret.Get(1).(func(context.Context, db.CreateBookParams) error); ok {
-		r1 = rf(ctx, arg)
-	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// MockStore_CreateBook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateBook'
-type MockStore_CreateBook_Call struct {
+// MockStore_MergeCartTx_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MergeCartTx'
+type MockStore_MergeCartTx_Call struct {
 	*mock.Call
 }
 
-// CreateBook is a helper method to define mock.On call
+// MergeCartTx is a helper method to define mock.On call
 //   - ctx context.Context
-//   - arg db.CreateBookParams
-func (_e *MockStore_Expecter) CreateBook(ctx interface{}, arg interface{}) *MockStore_CreateBook_Call {
-	return &MockStore_CreateBook_Call{Call: _e.mock.On("CreateBook", ctx, arg)}
+//   - arg db.MergeCartTxParams
+func (_e *MockStore_Expecter) MergeCartTx(ctx interface{}, arg interface{}) *MockStore_MergeCartTx_Call {
+	return &MockStore_MergeCartTx_Call{Call: _e.mock.On("MergeCartTx", ctx, arg)}
 }
 
-func (_c *MockStore_CreateBook_Call) Run(run func(ctx context.Context, arg db.CreateBookParams)) *MockStore_CreateBook_Call {
+func (_c *MockStore_MergeCartTx_Call) Run(run func(ctx context.Context, arg db.MergeCartTxParams)) *MockStore_MergeCartTx_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(db.CreateBookParams))
+		run(args[0].(context.Context), args[1].(db.MergeCartTxParams))
 	})
 	return _c
 }
 
-func (_c *MockStore_CreateBook_Call) Return(_a0 db.Book, _a1 error) *MockStore_CreateBook_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *MockStore_MergeCartTx_Call) Return(_a0 error) *MockStore_MergeCartTx_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockStore_CreateBook_Call) RunAndReturn(run func(context.Context, db.CreateBookParams) (db.Book, error)) *MockStore_CreateBook_Call {
+func (_c *MockStore_MergeCartTx_Call) RunAndReturn(run func(context.Context, db.MergeCartTxParams) error) *MockStore_MergeCartTx_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateBookTx provides a mock function with given fields: ctx, arg
-func (_m *MockStore) CreateBookTx(ctx context.Context, arg db.CreateBookTxParams) (db.Book, error) {
-	ret := _m.Called(ctx, arg)
-
-	var r0 db.Book
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, db.CreateBookTxParams) (db.Book, error)); ok {
-		return rf(ctx, arg)
-	}
-	if rf, ok := ret.Get(0).(func(context.Context, db.CreateBookTxParams) db.Book); ok {
-		r0 = rf(ctx, arg)
-	} else {
-		r0 = ret.Get(0).(db.Book)
-	}
+// Ping provides a mock function with given fields: ctx
+func (_m *MockStore) Ping(ctx context.Context) error {
+	ret := _m.Called(ctx)
 
-	if rf, ok := ret.Get(1).(func(context.Context, db.CreateBookTxParams) error); ok {
-		r1 = rf(ctx, arg)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// MockStore_CreateBookTx_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateBookTx'
-type MockStore_CreateBookTx_Call struct {
+// MockStore_Ping_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Ping'
+type MockStore_Ping_Call struct {
 	*mock.Call
 }
 
-// CreateBookTx is a helper method to define mock.On call
+// Ping is a helper method to define mock.On call
 //   - ctx context.Context
-//   - arg db.CreateBookTxParams
-func (_e *MockStore_Expecter) CreateBookTx(ctx interface{}, arg interface{}) *MockStore_CreateBookTx_Call {
-	return &MockStore_CreateBookTx_Call{Call: _e.mock.On("CreateBookTx", ctx, arg)}
+func (_e *MockStore_Expecter) Ping(ctx interface{}) *MockStore_Ping_Call {
+	return &MockStore_Ping_Call{Call: _e.mock.On("Ping", ctx)}
 }
 
-func (_c *MockStore_CreateBookTx_Call) Run(run func(ctx context.Context, arg db.CreateBookTxParams)) *MockStore_CreateBookTx_Call {
+func (_c *MockStore_Ping_Call) Run(run func(ctx context.Context)) *MockStore_Ping_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(db.CreateBookTxParams))
+		run(args[0].(context.Context))
 	})
 	return _c
 }
 
-func (_c *MockStore_CreateBookTx_Call) Return(book db.Book, err error) *MockStore_CreateBookTx_Call {
-	_c.Call.Return(book, err)
+func (_c *MockStore_Ping_Call) Return(_a0 error) *MockStore_Ping_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockStore_CreateBookTx_Call) RunAndReturn(run func(context.Context, db.CreateBookTxParams) (db.Book, error)) *MockStore_CreateBookTx_Call {
+func (_c *MockStore_Ping_Call) RunAndReturn(run func(context.Context) error) *MockStore_Ping_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreatePublisher provides a mock function with given fields: ctx, publisherName
-func (_m *MockStore) CreatePublisher(ctx context.Context, publisherName string) (db.Publisher, error) {
-	ret := _m.Called(ctx, publisherName)
+// PurgeAuthors provides a mock function with given fields: ctx, deletedBefore
+func (_m *MockStore) PurgeAuthors(ctx context.Context, deletedBefore sql.NullTime) (int64, error) {
+	ret := _m.Called(ctx, deletedBefore)
 
-	var r0 db.Publisher
+	var r0 int64
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (db.Publisher, error)); ok {
-		return rf(ctx, publisherName)
+	if rf, ok := ret.Get(0).(func(context.Context, sql.NullTime) (int64, error)); ok {
+		return rf(ctx, deletedBefore)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) db.Publisher); ok {
-		r0 = rf(ctx, publisherName)
+	if rf, ok := ret.Get(0).(func(context.Context, sql.NullTime) int64); ok {
+		r0 = rf(ctx, deletedBefore)
 	} else {
-		r0 = ret.Get(0).(db.Publisher)
+		r0 = ret.Get(0).(int64)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, publisherName)
+	if rf, ok := ret.Get(1).(func(context.Context, sql.NullTime) error); ok {
+		r1 = rf(ctx, deletedBefore)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -405,181 +3271,211 @@ func (_m *MockStore) CreatePublisher(ctx context.Context, publisherName string)
 	return r0, r1
 }
 
-// MockStore_CreatePublisher_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreatePublisher'
-type MockStore_CreatePublisher_Call struct {
+// MockStore_PurgeAuthors_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PurgeAuthors'
+type MockStore_PurgeAuthors_Call struct {
 	*mock.Call
 }
 
-// CreatePublisher is a helper method to define mock.On call
+// PurgeAuthors is a helper method to define mock.On call
 //   - ctx context.Context
-//   - publisherName string
-func (_e *MockStore_Expecter) CreatePublisher(ctx interface{}, publisherName interface{}) *MockStore_CreatePublisher_Call {
-	return &MockStore_CreatePublisher_Call{Call: _e.mock.On("CreatePublisher", ctx, publisherName)}
+//   - deletedBefore sql.NullTime
+func (_e *MockStore_Expecter) PurgeAuthors(ctx interface{}, deletedBefore interface{}) *MockStore_PurgeAuthors_Call {
+	return &MockStore_PurgeAuthors_Call{Call: _e.mock.On("PurgeAuthors", ctx, deletedBefore)}
 }
 
-func (_c *MockStore_CreatePublisher_Call) Run(run func(ctx context.Context, publisherName string)) *MockStore_CreatePublisher_Call {
+func (_c *MockStore_PurgeAuthors_Call) Run(run func(ctx context.Context, deletedBefore sql.NullTime)) *MockStore_PurgeAuthors_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string))
+		run(args[0].(context.Context), args[1].(sql.NullTime))
 	})
 	return _c
 }
 
-func (_c *MockStore_CreatePublisher_Call) Return(_a0 db.Publisher, _a1 error) *MockStore_CreatePublisher_Call {
+func (_c *MockStore_PurgeAuthors_Call) Return(_a0 int64, _a1 error) *MockStore_PurgeAuthors_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockStore_CreatePublisher_Call) RunAndReturn(run func(context.Context, string) (db.Publisher, error)) *MockStore_CreatePublisher_Call {
+func (_c *MockStore_PurgeAuthors_Call) RunAndReturn(run func(context.Context, sql.NullTime) (int64, error)) *MockStore_PurgeAuthors_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeleteAuthor provides a mock function with given fields: ctx, authorID
-func (_m *MockStore) DeleteAuthor(ctx context.Context, authorID int64) error {
-	ret := _m.Called(ctx, authorID)
+// PurgeBooks provides a mock function with given fields: ctx, deletedBefore
+func (_m *MockStore) PurgeBooks(ctx context.Context, deletedBefore sql.NullTime) (int64, error) {
+	ret := _m.Called(ctx, deletedBefore)
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
-		r0 = rf(ctx, authorID)
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, sql.NullTime) (int64, error)); ok {
+		return rf(ctx, deletedBefore)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, sql.NullTime) int64); ok {
+		r0 = rf(ctx, deletedBefore)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(int64)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, sql.NullTime) error); ok {
+		r1 = rf(ctx, deletedBefore)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// MockStore_DeleteAuthor_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteAuthor'
-type MockStore_DeleteAuthor_Call struct {
+// MockStore_PurgeBooks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PurgeBooks'
+type MockStore_PurgeBooks_Call struct {
 	*mock.Call
 }
 
-// DeleteAuthor is a helper method to define mock.On call
+// PurgeBooks is a helper method to define mock.On call
 //   - ctx context.Context
-//   - authorID int64
-func (_e *MockStore_Expecter) DeleteAuthor(ctx interface{}, authorID interface{}) *MockStore_DeleteAuthor_Call {
-	return &MockStore_DeleteAuthor_Call{Call: _e.mock.On("DeleteAuthor", ctx, authorID)}
+//   - deletedBefore sql.NullTime
+func (_e *MockStore_Expecter) PurgeBooks(ctx interface{}, deletedBefore interface{}) *MockStore_PurgeBooks_Call {
+	return &MockStore_PurgeBooks_Call{Call: _e.mock.On("PurgeBooks", ctx, deletedBefore)}
 }
 
-func (_c *MockStore_DeleteAuthor_Call) Run(run func(ctx context.Context, authorID int64)) *MockStore_DeleteAuthor_Call {
+func (_c *MockStore_PurgeBooks_Call) Run(run func(ctx context.Context, deletedBefore sql.NullTime)) *MockStore_PurgeBooks_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(int64))
+		run(args[0].(context.Context), args[1].(sql.NullTime))
 	})
 	return _c
 }
 
-func (_c *MockStore_DeleteAuthor_Call) Return(_a0 error) *MockStore_DeleteAuthor_Call {
-	_c.Call.Return(_a0)
+func (_c *MockStore_PurgeBooks_Call) Return(_a0 int64, _a1 error) *MockStore_PurgeBooks_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockStore_DeleteAuthor_Call) RunAndReturn(run func(context.Context, int64) error) *MockStore_DeleteAuthor_Call {
+func (_c *MockStore_PurgeBooks_Call) RunAndReturn(run func(context.Context, sql.NullTime) (int64, error)) *MockStore_PurgeBooks_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeleteBookByISBN provides a mock function with given fields: ctx, arg
-func (_m *MockStore) DeleteBookByISBN(ctx context.Context, arg db.DeleteBookByISBNParams) error {
-	ret := _m.Called(ctx, arg)
+// PurgePublishers provides a mock function with given fields: ctx, deletedBefore
+func (_m *MockStore) PurgePublishers(ctx context.Context, deletedBefore sql.NullTime) (int64, error) {
+	ret := _m.Called(ctx, deletedBefore)
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, db.DeleteBookByISBNParams) error); ok {
-		r0 = rf(ctx, arg)
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, sql.NullTime) (int64, error)); ok {
+		return rf(ctx, deletedBefore)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, sql.NullTime) int64); ok {
+		r0 = rf(ctx, deletedBefore)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(int64)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, sql.NullTime) error); ok {
+		r1 = rf(ctx, deletedBefore)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// MockStore_DeleteBookByISBN_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteBookByISBN'
-type MockStore_DeleteBookByISBN_Call struct {
+// MockStore_PurgePublishers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PurgePublishers'
+type MockStore_PurgePublishers_Call struct {
 	*mock.Call
 }
 
-// DeleteBookByISBN is a helper method to define mock.On call
+// PurgePublishers is a helper method to define mock.On call
 //   - ctx context.Context
-//   - arg db.DeleteBookByISBNParams
-func (_e *MockStore_Expecter) DeleteBookByISBN(ctx interface{}, arg interface{}) *MockStore_DeleteBookByISBN_Call {
-	return &MockStore_DeleteBookByISBN_Call{Call: _e.mock.On("DeleteBookByISBN", ctx, arg)}
+//   - deletedBefore sql.NullTime
+func (_e *MockStore_Expecter) PurgePublishers(ctx interface{}, deletedBefore interface{}) *MockStore_PurgePublishers_Call {
+	return &MockStore_PurgePublishers_Call{Call: _e.mock.On("PurgePublishers", ctx, deletedBefore)}
 }
 
-func (_c *MockStore_DeleteBookByISBN_Call) Run(run func(ctx context.Context, arg db.DeleteBookByISBNParams)) *MockStore_DeleteBookByISBN_Call {
+func (_c *MockStore_PurgePublishers_Call) Run(run func(ctx context.Context, deletedBefore sql.NullTime)) *MockStore_PurgePublishers_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(db.DeleteBookByISBNParams))
+		run(args[0].(context.Context), args[1].(sql.NullTime))
 	})
 	return _c
 }
 
-func (_c *MockStore_DeleteBookByISBN_Call) Return(_a0 error) *MockStore_DeleteBookByISBN_Call {
-	_c.Call.Return(_a0)
+func (_c *MockStore_PurgePublishers_Call) Return(_a0 int64, _a1 error) *MockStore_PurgePublishers_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockStore_DeleteBookByISBN_Call) RunAndReturn(run func(context.Context, db.DeleteBookByISBNParams) error) *MockStore_DeleteBookByISBN_Call {
+func (_c *MockStore_PurgePublishers_Call) RunAndReturn(run func(context.Context, sql.NullTime) (int64, error)) *MockStore_PurgePublishers_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeletePublisher provides a mock function with given fields: ctx, publisherID
-func (_m *MockStore) DeletePublisher(ctx context.Context, publisherID int64) error {
-	ret := _m.Called(ctx, publisherID)
+// PurgeTx provides a mock function with given fields: ctx, deletedBefore
+func (_m *MockStore) PurgeTx(ctx context.Context, deletedBefore time.Time) (db.PurgeTxResult, error) {
+	ret := _m.Called(ctx, deletedBefore)
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
-		r0 = rf(ctx, publisherID)
+	var r0 db.PurgeTxResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (db.PurgeTxResult, error)); ok {
+		return rf(ctx, deletedBefore)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) db.PurgeTxResult); ok {
+		r0 = rf(ctx, deletedBefore)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(db.PurgeTxResult)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, deletedBefore)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// MockStore_DeletePublisher_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeletePublisher'
-type MockStore_DeletePublisher_Call struct {
+// MockStore_PurgeTx_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PurgeTx'
+type MockStore_PurgeTx_Call struct {
 	*mock.Call
 }
 
-// DeletePublisher is a helper method to define mock.On call
+// PurgeTx is a helper method to define mock.On call
 //   - ctx context.Context
-//   - publisherID int64
-func (_e *MockStore_Expecter) DeletePublisher(ctx interface{}, publisherID interface{}) *MockStore_DeletePublisher_Call {
-	return &MockStore_DeletePublisher_Call{Call: _e.mock.On("DeletePublisher", ctx, publisherID)}
+//   - deletedBefore time.Time
+func (_e *MockStore_Expecter) PurgeTx(ctx interface{}, deletedBefore interface{}) *MockStore_PurgeTx_Call {
+	return &MockStore_PurgeTx_Call{Call: _e.mock.On("PurgeTx", ctx, deletedBefore)}
 }
 
-func (_c *MockStore_DeletePublisher_Call) Run(run func(ctx context.Context, publisherID int64)) *MockStore_DeletePublisher_Call {
+func (_c *MockStore_PurgeTx_Call) Run(run func(ctx context.Context, deletedBefore time.Time)) *MockStore_PurgeTx_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(int64))
+		run(args[0].(context.Context), args[1].(time.Time))
 	})
 	return _c
 }
 
-func (_c *MockStore_DeletePublisher_Call) Return(_a0 error) *MockStore_DeletePublisher_Call {
-	_c.Call.Return(_a0)
+func (_c *MockStore_PurgeTx_Call) Return(_a0 db.PurgeTxResult, _a1 error) *MockStore_PurgeTx_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockStore_DeletePublisher_Call) RunAndReturn(run func(context.Context, int64) error) *MockStore_DeletePublisher_Call {
+func (_c *MockStore_PurgeTx_Call) RunAndReturn(run func(context.Context, time.Time) (db.PurgeTxResult, error)) *MockStore_PurgeTx_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetAuthor provides a mock function with given fields: ctx, authorID
-func (_m *MockStore) GetAuthor(ctx context.Context, authorID int64) (db.Author, error) {
-	ret := _m.Called(ctx, authorID)
+// RedeliverWebhookDelivery provides a mock function with given fields: ctx, arg
+func (_m *MockStore) RedeliverWebhookDelivery(ctx context.Context, arg db.RedeliverWebhookDeliveryParams) (db.WebhookDelivery, error) {
+	ret := _m.Called(ctx, arg)
 
-	var r0 db.Author
+	var r0 db.WebhookDelivery
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.Author, error)); ok {
-		return rf(ctx, authorID)
+	if rf, ok := ret.Get(0).(func(context.Context, db.RedeliverWebhookDeliveryParams) (db.WebhookDelivery, error)); ok {
+		return rf(ctx, arg)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, int64) db.Author); ok {
-		r0 = rf(ctx, authorID)
+	if rf, ok := ret.Get(0).(func(context.Context, db.RedeliverWebhookDeliveryParams) db.WebhookDelivery); ok {
+		r0 = rf(ctx, arg)
 	} else {
-		r0 = ret.Get(0).(db.Author)
+		r0 = ret.Get(0).(db.WebhookDelivery)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
-		r1 = rf(ctx, authorID)
+	if rf, ok := ret.Get(1).(func(context.Context, db.RedeliverWebhookDeliveryParams) error); ok {
+		r1 = rf(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -587,52 +3483,52 @@ func (_m *MockStore) GetAuthor(ctx context.Context, authorID int64) (db.Author,
 	return r0, r1
 }
 
-// MockStore_GetAuthor_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAuthor'
-type MockStore_GetAuthor_Call struct {
+// MockStore_RedeliverWebhookDelivery_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RedeliverWebhookDelivery'
+type MockStore_RedeliverWebhookDelivery_Call struct {
 	*mock.Call
 }
 
-// GetAuthor is a helper method to define mock.On call
+// RedeliverWebhookDelivery is a helper method to define mock.On call
 //   - ctx context.Context
-//   - authorID int64
-func (_e *MockStore_Expecter) GetAuthor(ctx interface{}, authorID interface{}) *MockStore_GetAuthor_Call {
-	return &MockStore_GetAuthor_Call{Call: _e.mock.On("GetAuthor", ctx, authorID)}
+//   - arg db.RedeliverWebhookDeliveryParams
+func (_e *MockStore_Expecter) RedeliverWebhookDelivery(ctx interface{}, arg interface{}) *MockStore_RedeliverWebhookDelivery_Call {
+	return &MockStore_RedeliverWebhookDelivery_Call{Call: _e.mock.On("RedeliverWebhookDelivery", ctx, arg)}
 }
 
-func (_c *MockStore_GetAuthor_Call) Run(run func(ctx context.Context, authorID int64)) *MockStore_GetAuthor_Call {
+func (_c *MockStore_RedeliverWebhookDelivery_Call) Run(run func(ctx context.Context, arg db.RedeliverWebhookDeliveryParams)) *MockStore_RedeliverWebhookDelivery_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(int64))
+		run(args[0].(context.Context), args[1].(db.RedeliverWebhookDeliveryParams))
 	})
 	return _c
 }
 
-func (_c *MockStore_GetAuthor_Call) Return(_a0 db.Author, _a1 error) *MockStore_GetAuthor_Call {
+func (_c *MockStore_RedeliverWebhookDelivery_Call) Return(_a0 db.WebhookDelivery, _a1 error) *MockStore_RedeliverWebhookDelivery_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockStore_GetAuthor_Call) RunAndReturn(run func(context.Context, int64) (db.Author, error)) *MockStore_GetAuthor_Call {
+func (_c *MockStore_RedeliverWebhookDelivery_Call) RunAndReturn(run func(context.Context, db.RedeliverWebhookDeliveryParams) (db.WebhookDelivery, error)) *MockStore_RedeliverWebhookDelivery_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetAuthorByName provides a mock function with given fields: ctx, arg
-func (_m *MockStore) GetAuthorByName(ctx context.Context, arg db.GetAuthorByNameParams) (db.Author, error) {
-	ret := _m.Called(ctx, arg)
+// RestoreAuthor provides a mock function with given fields: ctx, authorID
+func (_m *MockStore) RestoreAuthor(ctx context.Context, authorID int64) (db.Author, error) {
+	ret := _m.Called(ctx, authorID)
 
 	var r0 db.Author
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, db.GetAuthorByNameParams) (db.Author, error)); ok {
-		return rf(ctx, arg)
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.Author, error)); ok {
+		return rf(ctx, authorID)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, db.GetAuthorByNameParams) db.Author); ok {
-		r0 = rf(ctx, arg)
+	if rf, ok := ret.Get(0).(func(context.Context, int64) db.Author); ok {
+		r0 = rf(ctx, authorID)
 	} else {
 		r0 = ret.Get(0).(db.Author)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, db.GetAuthorByNameParams) error); ok {
-		r1 = rf(ctx, arg)
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, authorID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -640,51 +3536,51 @@ func (_m *MockStore) GetAuthorByName(ctx context.Context, arg db.GetAuthorByName
 	return r0, r1
 }
 
-// MockStore_GetAuthorByName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAuthorByName'
-type MockStore_GetAuthorByName_Call struct {
+// MockStore_RestoreAuthor_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestoreAuthor'
+type MockStore_RestoreAuthor_Call struct {
 	*mock.Call
 }
 
-// GetAuthorByName is a helper method to define mock.On call
+// RestoreAuthor is a helper method to define mock.On call
 //   - ctx context.Context
-//   - arg db.GetAuthorByNameParams
-func (_e *MockStore_Expecter) GetAuthorByName(ctx interface{}, arg interface{}) *MockStore_GetAuthorByName_Call {
-	return &MockStore_GetAuthorByName_Call{Call: _e.mock.On("GetAuthorByName", ctx, arg)}
+//   - authorID int64
+func (_e *MockStore_Expecter) RestoreAuthor(ctx interface{}, authorID interface{}) *MockStore_RestoreAuthor_Call {
+	return &MockStore_RestoreAuthor_Call{Call: _e.mock.On("RestoreAuthor", ctx, authorID)}
 }
 
-func (_c *MockStore_GetAuthorByName_Call) Run(run func(ctx context.Context, arg db.GetAuthorByNameParams)) *MockStore_GetAuthorByName_Call {
+func (_c *MockStore_RestoreAuthor_Call) Run(run func(ctx context.Context, authorID int64)) *MockStore_RestoreAuthor_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(db.GetAuthorByNameParams))
+		run(args[0].(context.Context), args[1].(int64))
 	})
 	return _c
 }
 
-func (_c *MockStore_GetAuthorByName_Call) Return(_a0 db.Author, _a1 error) *MockStore_GetAuthorByName_Call {
+func (_c *MockStore_RestoreAuthor_Call) Return(_a0 db.Author, _a1 error) *MockStore_RestoreAuthor_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockStore_GetAuthorByName_Call) RunAndReturn(run func(context.Context, db.GetAuthorByNameParams) (db.Author, error)) *MockStore_GetAuthorByName_Call {
+func (_c *MockStore_RestoreAuthor_Call) RunAndReturn(run func(context.Context, int64) (db.Author, error)) *MockStore_RestoreAuthor_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetBookByISBN provides a mock function with given fields: ctx, arg
-func (_m *MockStore) GetBookByISBN(ctx context.Context, arg db.GetBookByISBNParams) (db.GetBookByISBNRow, error) {
+// RestoreBookByISBN provides a mock function with given fields: ctx, arg
+func (_m *MockStore) RestoreBookByISBN(ctx context.Context, arg db.RestoreBookByISBNParams) (db.Book, error) {
 	ret := _m.Called(ctx, arg)
 
-	var r0 db.GetBookByISBNRow
+	var r0 db.Book
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, db.GetBookByISBNParams) (db.GetBookByISBNRow, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, db.RestoreBookByISBNParams) (db.Book, error)); ok {
 		return rf(ctx, arg)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, db.GetBookByISBNParams) db.GetBookByISBNRow); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, db.RestoreBookByISBNParams) db.Book); ok {
 		r0 = rf(ctx, arg)
 	} else {
-		r0 = ret.Get(0).(db.GetBookByISBNRow)
+		r0 = ret.Get(0).(db.Book)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, db.GetBookByISBNParams) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, db.RestoreBookByISBNParams) error); ok {
 		r1 = rf(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
@@ -693,37 +3589,37 @@ func (_m *MockStore) GetBookByISBN(ctx context.Context, arg db.GetBookByISBNPara
 	return r0, r1
 }
 
-// MockStore_GetBookByISBN_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBookByISBN'
-type MockStore_GetBookByISBN_Call struct {
+// MockStore_RestoreBookByISBN_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestoreBookByISBN'
+type MockStore_RestoreBookByISBN_Call struct {
 	*mock.Call
 }
 
-// GetBookByISBN is a helper method to define mock.On call
+// RestoreBookByISBN is a helper method to define mock.On call
 //   - ctx context.Context
-//   - arg db.GetBookByISBNParams
-func (_e *MockStore_Expecter) GetBookByISBN(ctx interface{}, arg interface{}) *MockStore_GetBookByISBN_Call {
-	return &MockStore_GetBookByISBN_Call{Call: _e.mock.On("GetBookByISBN", ctx, arg)}
+//   - arg db.RestoreBookByISBNParams
+func (_e *MockStore_Expecter) RestoreBookByISBN(ctx interface{}, arg interface{}) *MockStore_RestoreBookByISBN_Call {
+	return &MockStore_RestoreBookByISBN_Call{Call: _e.mock.On("RestoreBookByISBN", ctx, arg)}
 }
 
-func (_c *MockStore_GetBookByISBN_Call) Run(run func(ctx context.Context, arg db.GetBookByISBNParams)) *MockStore_GetBookByISBN_Call {
+func (_c *MockStore_RestoreBookByISBN_Call) Run(run func(ctx context.Context, arg db.RestoreBookByISBNParams)) *MockStore_RestoreBookByISBN_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(db.GetBookByISBNParams))
+		run(args[0].(context.Context), args[1].(db.RestoreBookByISBNParams))
 	})
 	return _c
 }
 
-func (_c *MockStore_GetBookByISBN_Call) Return(_a0 db.GetBookByISBNRow, _a1 error) *MockStore_GetBookByISBN_Call {
+func (_c *MockStore_RestoreBookByISBN_Call) Return(_a0 db.Book, _a1 error) *MockStore_RestoreBookByISBN_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockStore_GetBookByISBN_Call) RunAndReturn(run func(context.Context, db.GetBookByISBNParams) (db.GetBookByISBNRow, error)) *MockStore_GetBookByISBN_Call {
+func (_c *MockStore_RestoreBookByISBN_Call) RunAndReturn(run func(context.Context, db.RestoreBookByISBNParams) (db.Book, error)) *MockStore_RestoreBookByISBN_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetPublisher provides a mock function with given fields: ctx, publisherID
-func (_m *MockStore) GetPublisher(ctx context.Context, publisherID int64) (db.Publisher, error) {
+// RestorePublisher provides a mock function with given fields: ctx, publisherID
+func (_m *MockStore) RestorePublisher(ctx context.Context, publisherID int64) (db.Publisher, error) {
 	ret := _m.Called(ctx, publisherID)
 
 	var r0 db.Publisher
@@ -746,52 +3642,52 @@ func (_m *MockStore) GetPublisher(ctx context.Context, publisherID int64) (db.Pu
 	return r0, r1
 }
 
-// MockStore_GetPublisher_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPublisher'
-type MockStore_GetPublisher_Call struct {
+// MockStore_RestorePublisher_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestorePublisher'
+type MockStore_RestorePublisher_Call struct {
 	*mock.Call
 }
 
-// GetPublisher is a helper method to define mock.On call
+// RestorePublisher is a helper method to define mock.On call
 //   - ctx context.Context
 //   - publisherID int64
-func (_e *MockStore_Expecter) GetPublisher(ctx interface{}, publisherID interface{}) *MockStore_GetPublisher_Call {
-	return &MockStore_GetPublisher_Call{Call: _e.mock.On("GetPublisher", ctx, publisherID)}
+func (_e *MockStore_Expecter) RestorePublisher(ctx interface{}, publisherID interface{}) *MockStore_RestorePublisher_Call {
+	return &MockStore_RestorePublisher_Call{Call: _e.mock.On("RestorePublisher", ctx, publisherID)}
 }
 
-func (_c *MockStore_GetPublisher_Call) Run(run func(ctx context.Context, publisherID int64)) *MockStore_GetPublisher_Call {
+func (_c *MockStore_RestorePublisher_Call) Run(run func(ctx context.Context, publisherID int64)) *MockStore_RestorePublisher_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(context.Context), args[1].(int64))
 	})
 	return _c
 }
 
-func (_c *MockStore_GetPublisher_Call) Return(_a0 db.Publisher, _a1 error) *MockStore_GetPublisher_Call {
+func (_c *MockStore_RestorePublisher_Call) Return(_a0 db.Publisher, _a1 error) *MockStore_RestorePublisher_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockStore_GetPublisher_Call) RunAndReturn(run func(context.Context, int64) (db.Publisher, error)) *MockStore_GetPublisher_Call {
+func (_c *MockStore_RestorePublisher_Call) RunAndReturn(run func(context.Context, int64) (db.Publisher, error)) *MockStore_RestorePublisher_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetPublisherByName provides a mock function with given fields: ctx, publisherName
-func (_m *MockStore) GetPublisherByName(ctx context.Context, publisherName string) (db.Publisher, error) {
-	ret := _m.Called(ctx, publisherName)
+// SchemaVersion provides a mock function with given fields: ctx
+func (_m *MockStore) SchemaVersion(ctx context.Context) (db.SchemaVersion, error) {
+	ret := _m.Called(ctx)
 
-	var r0 db.Publisher
+	var r0 db.SchemaVersion
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (db.Publisher, error)); ok {
-		return rf(ctx, publisherName)
+	if rf, ok := ret.Get(0).(func(context.Context) (db.SchemaVersion, error)); ok {
+		return rf(ctx)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) db.Publisher); ok {
-		r0 = rf(ctx, publisherName)
+	if rf, ok := ret.Get(0).(func(context.Context) db.SchemaVersion); ok {
+		r0 = rf(ctx)
 	} else {
-		r0 = ret.Get(0).(db.Publisher)
+		r0 = ret.Get(0).(db.SchemaVersion)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, publisherName)
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -799,53 +3695,50 @@ func (_m *MockStore) GetPublisherByName(ctx context.Context, publisherName strin
 	return r0, r1
 }
 
-// MockStore_GetPublisherByName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPublisherByName'
-type MockStore_GetPublisherByName_Call struct {
+// MockStore_SchemaVersion_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SchemaVersion'
+type MockStore_SchemaVersion_Call struct {
 	*mock.Call
 }
 
-// GetPublisherByName is a helper method to define mock.On call
+// SchemaVersion is a helper method to define mock.On call
 //   - ctx context.Context
-//   - publisherName string
-func (_e *MockStore_Expecter) GetPublisherByName(ctx interface{}, publisherName interface{}) *MockStore_GetPublisherByName_Call {
-	return &MockStore_GetPublisherByName_Call{Call: _e.mock.On("GetPublisherByName", ctx, publisherName)}
+func (_e *MockStore_Expecter) SchemaVersion(ctx interface{}) *MockStore_SchemaVersion_Call {
+	return &MockStore_SchemaVersion_Call{Call: _e.mock.On("SchemaVersion", ctx)}
 }
 
-func (_c *MockStore_GetPublisherByName_Call) Run(run func(ctx context.Context, publisherName string)) *MockStore_GetPublisherByName_Call {
+func (_c *MockStore_SchemaVersion_Call) Run(run func(ctx context.Context)) *MockStore_SchemaVersion_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string))
+		run(args[0].(context.Context))
 	})
 	return _c
 }
 
-func (_c *MockStore_GetPublisherByName_Call) Return(_a0 db.Publisher, _a1 error) *MockStore_GetPublisherByName_Call {
+func (_c *MockStore_SchemaVersion_Call) Return(_a0 db.SchemaVersion, _a1 error) *MockStore_SchemaVersion_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockStore_GetPublisherByName_Call) RunAndReturn(run func(context.Context, string) (db.Publisher, error)) *MockStore_GetPublisherByName_Call {
+func (_c *MockStore_SchemaVersion_Call) RunAndReturn(run func(context.Context) (db.SchemaVersion, error)) *MockStore_SchemaVersion_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ListAuthors provides a mock function with given fields: ctx, arg
-func (_m *MockStore) ListAuthors(ctx context.Context, arg db.ListAuthorsParams) ([]db.Author, error) {
+// SetBookCover provides a mock function with given fields: ctx, arg
+func (_m *MockStore) SetBookCover(ctx context.Context, arg db.SetBookCoverParams) (db.Book, error) {
 	ret := _m.Called(ctx, arg)
 
-	var r0 []db.Author
+	var r0 db.Book
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, db.ListAuthorsParams) ([]db.Author, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, db.SetBookCoverParams) (db.Book, error)); ok {
 		return rf(ctx, arg)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, db.ListAuthorsParams) []db.Author); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, db.SetBookCoverParams) db.Book); ok {
 		r0 = rf(ctx, arg)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.Author)
-		}
+		r0 = ret.Get(0).(db.Book)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, db.ListAuthorsParams) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, db.SetBookCoverParams) error); ok {
 		r1 = rf(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
@@ -854,54 +3747,52 @@ func (_m *MockStore) ListAuthors(ctx context.Context, arg db.ListAuthorsParams)
 	return r0, r1
 }
 
-// MockStore_ListAuthors_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListAuthors'
-type MockStore_ListAuthors_Call struct {
+// MockStore_SetBookCover_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetBookCover'
+type MockStore_SetBookCover_Call struct {
 	*mock.Call
 }
 
-// ListAuthors is a helper method to define mock.On call
+// SetBookCover is a helper method to define mock.On call
 //   - ctx context.Context
-//   - arg db.ListAuthorsParams
-func (_e *MockStore_Expecter) ListAuthors(ctx interface{}, arg interface{}) *MockStore_ListAuthors_Call {
-	return &MockStore_ListAuthors_Call{Call: _e.mock.On("ListAuthors", ctx, arg)}
+//   - arg db.SetBookCoverParams
+func (_e *MockStore_Expecter) SetBookCover(ctx interface{}, arg interface{}) *MockStore_SetBookCover_Call {
+	return &MockStore_SetBookCover_Call{Call: _e.mock.On("SetBookCover", ctx, arg)}
 }
 
-func (_c *MockStore_ListAuthors_Call) Run(run func(ctx context.Context, arg db.ListAuthorsParams)) *MockStore_ListAuthors_Call {
+func (_c *MockStore_SetBookCover_Call) Run(run func(ctx context.Context, arg db.SetBookCoverParams)) *MockStore_SetBookCover_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(db.ListAuthorsParams))
+		run(args[0].(context.Context), args[1].(db.SetBookCoverParams))
 	})
 	return _c
 }
 
-func (_c *MockStore_ListAuthors_Call) Return(_a0 []db.Author, _a1 error) *MockStore_ListAuthors_Call {
+func (_c *MockStore_SetBookCover_Call) Return(_a0 db.Book, _a1 error) *MockStore_SetBookCover_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockStore_ListAuthors_Call) RunAndReturn(run func(context.Context, db.ListAuthorsParams) ([]db.Author, error)) *MockStore_ListAuthors_Call {
+func (_c *MockStore_SetBookCover_Call) RunAndReturn(run func(context.Context, db.SetBookCoverParams) (db.Book, error)) *MockStore_SetBookCover_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ListAuthorsWithBookID provides a mock function with given fields: ctx, bookID
-func (_m *MockStore) ListAuthorsWithBookID(ctx context.Context, bookID int64) ([]db.ListAuthorsWithBookIDRow, error) {
-	ret := _m.Called(ctx, bookID)
+// SetCartItemQuantity provides a mock function with given fields: ctx, arg
+func (_m *MockStore) SetCartItemQuantity(ctx context.Context, arg db.SetCartItemQuantityParams) (int64, error) {
+	ret := _m.Called(ctx, arg)
 
-	var r0 []db.ListAuthorsWithBookIDRow
+	var r0 int64
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]db.ListAuthorsWithBookIDRow, error)); ok {
-		return rf(ctx, bookID)
+	if rf, ok := ret.Get(0).(func(context.Context, db.SetCartItemQuantityParams) (int64, error)); ok {
+		return rf(ctx, arg)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, int64) []db.ListAuthorsWithBookIDRow); ok {
-		r0 = rf(ctx, bookID)
+	if rf, ok := ret.Get(0).(func(context.Context, db.SetCartItemQuantityParams) int64); ok {
+		r0 = rf(ctx, arg)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.ListAuthorsWithBookIDRow)
-		}
+		r0 = ret.Get(0).(int64)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
-		r1 = rf(ctx, bookID)
+	if rf, ok := ret.Get(1).(func(context.Context, db.SetCartItemQuantityParams) error); ok {
+		r1 = rf(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -909,53 +3800,51 @@ func (_m *MockStore) ListAuthorsWithBookID(ctx context.Context, bookID int64) ([
 	return r0, r1
 }
 
-// MockStore_ListAuthorsWithBookID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListAuthorsWithBookID'
-type MockStore_ListAuthorsWithBookID_Call struct {
+// MockStore_SetCartItemQuantity_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetCartItemQuantity'
+type MockStore_SetCartItemQuantity_Call struct {
 	*mock.Call
 }
 
-// ListAuthorsWithBookID is a helper method to define mock.On call
+// SetCartItemQuantity is a helper method to define mock.On call
 //   - ctx context.Context
-//   - bookID int64
-func (_e *MockStore_Expecter) ListAuthorsWithBookID(ctx interface{}, bookID interface{}) *MockStore_ListAuthorsWithBookID_Call {
-	return &MockStore_ListAuthorsWithBookID_Call{Call: _e.mock.On("ListAuthorsWithBookID", ctx, bookID)}
+//   - arg db.SetCartItemQuantityParams
+func (_e *MockStore_Expecter) SetCartItemQuantity(ctx interface{}, arg interface{}) *MockStore_SetCartItemQuantity_Call {
+	return &MockStore_SetCartItemQuantity_Call{Call: _e.mock.On("SetCartItemQuantity", ctx, arg)}
 }
 
-func (_c *MockStore_ListAuthorsWithBookID_Call) Run(run func(ctx context.Context, bookID int64)) *MockStore_ListAuthorsWithBookID_Call {
+func (_c *MockStore_SetCartItemQuantity_Call) Run(run func(ctx context.Context, arg db.SetCartItemQuantityParams)) *MockStore_SetCartItemQuantity_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(int64))
+		run(args[0].(context.Context), args[1].(db.SetCartItemQuantityParams))
 	})
 	return _c
 }
 
-func (_c *MockStore_ListAuthorsWithBookID_Call) Return(_a0 []db.ListAuthorsWithBookIDRow, _a1 error) *MockStore_ListAuthorsWithBookID_Call {
+func (_c *MockStore_SetCartItemQuantity_Call) Return(_a0 int64, _a1 error) *MockStore_SetCartItemQuantity_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockStore_ListAuthorsWithBookID_Call) RunAndReturn(run func(context.Context, int64) ([]db.ListAuthorsWithBookIDRow, error)) *MockStore_ListAuthorsWithBookID_Call {
+func (_c *MockStore_SetCartItemQuantity_Call) RunAndReturn(run func(context.Context, db.SetCartItemQuantityParams) (int64, error)) *MockStore_SetCartItemQuantity_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ListBooks provides a mock function with given fields: ctx, arg
-func (_m *MockStore) ListBooks(ctx context.Context, arg db.ListBooksParams) ([]db.ListBooksRow, error) {
+// SetCartUserID provides a mock function with given fields: ctx, arg
+func (_m *MockStore) SetCartUserID(ctx context.Context, arg db.SetCartUserIDParams) (db.Cart, error) {
 	ret := _m.Called(ctx, arg)
 
-	var r0 []db.ListBooksRow
+	var r0 db.Cart
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, db.ListBooksParams) ([]db.ListBooksRow, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, db.SetCartUserIDParams) (db.Cart, error)); ok {
 		return rf(ctx, arg)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, db.ListBooksParams) []db.ListBooksRow); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, db.SetCartUserIDParams) db.Cart); ok {
 		r0 = rf(ctx, arg)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.ListBooksRow)
-		}
+		r0 = ret.Get(0).(db.Cart)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, db.ListBooksParams) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, db.SetCartUserIDParams) error); ok {
 		r1 = rf(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
@@ -964,53 +3853,51 @@ func (_m *MockStore) ListBooks(ctx context.Context, arg db.ListBooksParams) ([]d
 	return r0, r1
 }
 
-// MockStore_ListBooks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListBooks'
-type MockStore_ListBooks_Call struct {
+// MockStore_SetCartUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetCartUserID'
+type MockStore_SetCartUserID_Call struct {
 	*mock.Call
 }
 
-// ListBooks is a helper method to define mock.On call
+// SetCartUserID is a helper method to define mock.On call
 //   - ctx context.Context
-//   - arg db.ListBooksParams
-func (_e *MockStore_Expecter) ListBooks(ctx interface{}, arg interface{}) *MockStore_ListBooks_Call {
-	return &MockStore_ListBooks_Call{Call: _e.mock.On("ListBooks", ctx, arg)}
+//   - arg db.SetCartUserIDParams
+func (_e *MockStore_Expecter) SetCartUserID(ctx interface{}, arg interface{}) *MockStore_SetCartUserID_Call {
+	return &MockStore_SetCartUserID_Call{Call: _e.mock.On("SetCartUserID", ctx, arg)}
 }
 
-func (_c *MockStore_ListBooks_Call) Run(run func(ctx context.Context, arg db.ListBooksParams)) *MockStore_ListBooks_Call {
+func (_c *MockStore_SetCartUserID_Call) Run(run func(ctx context.Context, arg db.SetCartUserIDParams)) *MockStore_SetCartUserID_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(db.ListBooksParams))
+		run(args[0].(context.Context), args[1].(db.SetCartUserIDParams))
 	})
 	return _c
 }
 
-func (_c *MockStore_ListBooks_Call) Return(_a0 []db.ListBooksRow, _a1 error) *MockStore_ListBooks_Call {
+func (_c *MockStore_SetCartUserID_Call) Return(_a0 db.Cart, _a1 error) *MockStore_SetCartUserID_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockStore_ListBooks_Call) RunAndReturn(run func(context.Context, db.ListBooksParams) ([]db.ListBooksRow, error)) *MockStore_ListBooks_Call {
+func (_c *MockStore_SetCartUserID_Call) RunAndReturn(run func(context.Context, db.SetCartUserIDParams) (db.Cart, error)) *MockStore_SetCartUserID_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ListPublishers provides a mock function with given fields: ctx, arg
-func (_m *MockStore) ListPublishers(ctx context.Context, arg db.ListPublishersParams) ([]db.Publisher, error) {
+// SetWebhookDeliveryResult provides a mock function with given fields: ctx, arg
+func (_m *MockStore) SetWebhookDeliveryResult(ctx context.Context, arg db.SetWebhookDeliveryResultParams) (db.WebhookDelivery, error) {
 	ret := _m.Called(ctx, arg)
 
-	var r0 []db.Publisher
+	var r0 db.WebhookDelivery
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, db.ListPublishersParams) ([]db.Publisher, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, db.SetWebhookDeliveryResultParams) (db.WebhookDelivery, error)); ok {
 		return rf(ctx, arg)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, db.ListPublishersParams) []db.Publisher); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, db.SetWebhookDeliveryResultParams) db.WebhookDelivery); ok {
 		r0 = rf(ctx, arg)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.Publisher)
-		}
+		r0 = ret.Get(0).(db.WebhookDelivery)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, db.ListPublishersParams) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, db.SetWebhookDeliveryResultParams) error); ok {
 		r1 = rf(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
@@ -1019,31 +3906,31 @@ func (_m *MockStore) ListPublishers(ctx context.Context, arg db.ListPublishersPa
 	return r0, r1
 }
 
-// MockStore_ListPublishers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListPublishers'
-type MockStore_ListPublishers_Call struct {
+// MockStore_SetWebhookDeliveryResult_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetWebhookDeliveryResult'
+type MockStore_SetWebhookDeliveryResult_Call struct {
 	*mock.Call
 }
 
-// ListPublishers is a helper method to define mock.On call
+// SetWebhookDeliveryResult is a helper method to define mock.On call
 //   - ctx context.Context
-//   - arg db.ListPublishersParams
-func (_e *MockStore_Expecter) ListPublishers(ctx interface{}, arg interface{}) *MockStore_ListPublishers_Call {
-	return &MockStore_ListPublishers_Call{Call: _e.mock.On("ListPublishers", ctx, arg)}
+//   - arg db.SetWebhookDeliveryResultParams
+func (_e *MockStore_Expecter) SetWebhookDeliveryResult(ctx interface{}, arg interface{}) *MockStore_SetWebhookDeliveryResult_Call {
+	return &MockStore_SetWebhookDeliveryResult_Call{Call: _e.mock.On("SetWebhookDeliveryResult", ctx, arg)}
 }
 
-func (_c *MockStore_ListPublishers_Call) Run(run func(ctx context.Context, arg db.ListPublishersParams)) *MockStore_ListPublishers_Call {
+func (_c *MockStore_SetWebhookDeliveryResult_Call) Run(run func(ctx context.Context, arg db.SetWebhookDeliveryResultParams)) *MockStore_SetWebhookDeliveryResult_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(db.ListPublishersParams))
+		run(args[0].(context.Context), args[1].(db.SetWebhookDeliveryResultParams))
 	})
 	return _c
 }
 
-func (_c *MockStore_ListPublishers_Call) Return(_a0 []db.Publisher, _a1 error) *MockStore_ListPublishers_Call {
+func (_c *MockStore_SetWebhookDeliveryResult_Call) Return(_a0 db.WebhookDelivery, _a1 error) *MockStore_SetWebhookDeliveryResult_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockStore_ListPublishers_Call) RunAndReturn(run func(context.Context, db.ListPublishersParams) ([]db.Publisher, error)) *MockStore_ListPublishers_Call {
+func (_c *MockStore_SetWebhookDeliveryResult_Call) RunAndReturn(run func(context.Context, db.SetWebhookDeliveryResultParams) (db.WebhookDelivery, error)) *MockStore_SetWebhookDeliveryResult_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -1154,6 +4041,112 @@ func (_c *MockStore_UpdateBookByISBN_Call) RunAndReturn(run func(context.Context
 	return _c
 }
 
+// UpdateBookTx provides a mock function with given fields: ctx, arg
+func (_m *MockStore) UpdateBookTx(ctx context.Context, arg db.UpdateBookTxParams) (db.Book, error) {
+	ret := _m.Called(ctx, arg)
+
+	var r0 db.Book
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateBookTxParams) (db.Book, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateBookTxParams) db.Book); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.Book)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.UpdateBookTxParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_UpdateBookTx_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateBookTx'
+type MockStore_UpdateBookTx_Call struct {
+	*mock.Call
+}
+
+// UpdateBookTx is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.UpdateBookTxParams
+func (_e *MockStore_Expecter) UpdateBookTx(ctx interface{}, arg interface{}) *MockStore_UpdateBookTx_Call {
+	return &MockStore_UpdateBookTx_Call{Call: _e.mock.On("UpdateBookTx", ctx, arg)}
+}
+
+func (_c *MockStore_UpdateBookTx_Call) Run(run func(ctx context.Context, arg db.UpdateBookTxParams)) *MockStore_UpdateBookTx_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.UpdateBookTxParams))
+	})
+	return _c
+}
+
+func (_c *MockStore_UpdateBookTx_Call) Return(book db.Book, err error) *MockStore_UpdateBookTx_Call {
+	_c.Call.Return(book, err)
+	return _c
+}
+
+func (_c *MockStore_UpdateBookTx_Call) RunAndReturn(run func(context.Context, db.UpdateBookTxParams) (db.Book, error)) *MockStore_UpdateBookTx_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateOrderStatus provides a mock function with given fields: ctx, arg
+func (_m *MockStore) UpdateOrderStatus(ctx context.Context, arg db.UpdateOrderStatusParams) (db.Order, error) {
+	ret := _m.Called(ctx, arg)
+
+	var r0 db.Order
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateOrderStatusParams) (db.Order, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateOrderStatusParams) db.Order); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.Order)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.UpdateOrderStatusParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_UpdateOrderStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateOrderStatus'
+type MockStore_UpdateOrderStatus_Call struct {
+	*mock.Call
+}
+
+// UpdateOrderStatus is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.UpdateOrderStatusParams
+func (_e *MockStore_Expecter) UpdateOrderStatus(ctx interface{}, arg interface{}) *MockStore_UpdateOrderStatus_Call {
+	return &MockStore_UpdateOrderStatus_Call{Call: _e.mock.On("UpdateOrderStatus", ctx, arg)}
+}
+
+func (_c *MockStore_UpdateOrderStatus_Call) Run(run func(ctx context.Context, arg db.UpdateOrderStatusParams)) *MockStore_UpdateOrderStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.UpdateOrderStatusParams))
+	})
+	return _c
+}
+
+func (_c *MockStore_UpdateOrderStatus_Call) Return(_a0 db.Order, _a1 error) *MockStore_UpdateOrderStatus_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_UpdateOrderStatus_Call) RunAndReturn(run func(context.Context, db.UpdateOrderStatusParams) (db.Order, error)) *MockStore_UpdateOrderStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UpdatePublisher provides a mock function with given fields: ctx, arg
 func (_m *MockStore) UpdatePublisher(ctx context.Context, arg db.UpdatePublisherParams) (db.Publisher, error) {
 	ret := _m.Called(ctx, arg)
@@ -1207,6 +4200,59 @@ func (_c *MockStore_UpdatePublisher_Call) RunAndReturn(run func(context.Context,
 	return _c
 }
 
+// UpsertSubject provides a mock function with given fields: ctx, subjectName
+func (_m *MockStore) UpsertSubject(ctx context.Context, subjectName string) (db.Subject, error) {
+	ret := _m.Called(ctx, subjectName)
+
+	var r0 db.Subject
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (db.Subject, error)); ok {
+		return rf(ctx, subjectName)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) db.Subject); ok {
+		r0 = rf(ctx, subjectName)
+	} else {
+		r0 = ret.Get(0).(db.Subject)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, subjectName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_UpsertSubject_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpsertSubject'
+type MockStore_UpsertSubject_Call struct {
+	*mock.Call
+}
+
+// UpsertSubject is a helper method to define mock.On call
+//   - ctx context.Context
+//   - subjectName string
+func (_e *MockStore_Expecter) UpsertSubject(ctx interface{}, subjectName interface{}) *MockStore_UpsertSubject_Call {
+	return &MockStore_UpsertSubject_Call{Call: _e.mock.On("UpsertSubject", ctx, subjectName)}
+}
+
+func (_c *MockStore_UpsertSubject_Call) Run(run func(ctx context.Context, subjectName string)) *MockStore_UpsertSubject_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockStore_UpsertSubject_Call) Return(_a0 db.Subject, _a1 error) *MockStore_UpsertSubject_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_UpsertSubject_Call) RunAndReturn(run func(context.Context, string) (db.Subject, error)) *MockStore_UpsertSubject_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockStore creates a new instance of MockStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockStore(t interface {
@@ -1,11 +1,20 @@
 package models
 
-import "github.com/atsuyaourt/xyz-books/internal/util"
+import (
+	"time"
+
+	"github.com/atsuyaourt/xyz-books/internal/util"
+)
 
 type Author struct {
-	FirstName  string `json:"first_name"`
-	LastName   string `json:"last_name"`
-	MiddleName string `json:"middle_name"`
+	ID         int64      `json:"id"`
+	FirstName  string     `json:"first_name"`
+	LastName   string     `json:"last_name"`
+	MiddleName string     `json:"middle_name"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+	DeletedAt  *time.Time `json:"deleted_at,omitempty"`
+	Version    int64      `json:"version"`
 } //@name Author
 
 type PaginatedAuthors = util.PaginatedList[Author] //@name PaginatedAuthors
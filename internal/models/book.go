@@ -1,17 +1,132 @@
 package models
 
-import "github.com/atsuyaourt/xyz-books/internal/util"
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/atsuyaourt/xyz-books/internal/util"
+)
 
 type Book struct {
-	Title           string   `json:"title"`
-	ISBN13          string   `json:"isbn13"`
-	ISBN10          string   `json:"isbn10"`
-	Price           float64  `json:"price"`
-	PublicationYear int64    `json:"publication_year"`
-	ImageUrl        string   `json:"image_url"`
-	Edition         string   `json:"edition"`
-	Authors         []string `json:"authors"`
-	Publisher       string   `json:"publisher"`
+	ID              int64         `json:"-"` // key in the store, books are addressed by ISBN
+	Title           string        `json:"title"`
+	ISBN13          string        `json:"isbn13"`
+	ISBN10          string        `json:"isbn10"`
+	Price           float64       `json:"price"`
+	PublicationYear int64         `json:"publication_year"`
+	ImageUrl        string        `json:"image_url"`       // the uploaded cover, else the image set by clients, else the generated cover
+	Cover           *Cover        `json:"cover,omitempty"` // the uploaded cover
+	Edition         string        `json:"edition"`
+	Language        string        `json:"language"`
+	Format          string        `json:"format"`
+	PageCount       int64         `json:"page_count"`
+	SeriesName      string        `json:"series_name"`
+	SeriesNumber    int64         `json:"series_number"`
+	Description     string        `json:"description"`
+	Authors         []string      `json:"authors"`
+	Contributors    []Contributor `json:"contributors"` // authors and other contributors, in credit order
+	Publisher       string        `json:"publisher"`
+	Subjects        []string      `json:"subjects"`
+	CreatedAt       time.Time     `json:"created_at"`
+	UpdatedAt       time.Time     `json:"updated_at"`           // set on every change, including deletes
+	DeletedAt       *time.Time    `json:"deleted_at,omitempty"` // set on deleted books, which are only listed on request
+	Version         int64         `json:"version"`              // bumped on every change, sent as the ETag
 } //@name Book
 
+type Contributor struct {
+	Name string `json:"name"`
+	Role string `json:"role"`
+} //@name Contributor
+
+// ContributorGroup is a role and the names credited with it
+type ContributorGroup struct {
+	Role  string
+	Names []string
+}
+
+// OtherContributors groups the contributors that are not authors by role,
+// in the order each role is first credited.
+func (b Book) OtherContributors() []ContributorGroup {
+	var groups []ContributorGroup
+	index := make(map[string]int)
+	for _, c := range b.Contributors {
+		if c.Role == "author" {
+			continue
+		}
+		i, ok := index[c.Role]
+		if !ok {
+			i = len(groups)
+			index[c.Role] = i
+			groups = append(groups, ContributorGroup{Role: c.Role})
+		}
+		groups[i].Names = append(groups[i].Names, c.Name)
+	}
+
+	return groups
+}
+
 type PaginatedBooks = util.PaginatedList[Book] //@name PaginatedBooks
+
+// BookV2 is a book as served by the v2 API, which names its authors and
+// publisher by record and prices it in integer cents
+type BookV2 struct {
+	ID              int64         `json:"-"`
+	Title           string        `json:"title"`
+	ISBN13          string        `json:"isbn13"`
+	ISBN10          string        `json:"isbn10"`
+	PriceCents      int64         `json:"price_cents"`
+	PublicationYear int64         `json:"publication_year"`
+	ImageUrl        string        `json:"image_url"`
+	Cover           *Cover        `json:"cover,omitempty"`
+	Edition         string        `json:"edition"`
+	Language        string        `json:"language"`
+	Format          string        `json:"format"`
+	PageCount       int64         `json:"page_count"`
+	SeriesName      string        `json:"series_name"`
+	SeriesNumber    int64         `json:"series_number"`
+	Description     string        `json:"description"`
+	Authors         []AuthorRef   `json:"authors"` // in credit order
+	Contributors    []Contributor `json:"contributors"`
+	Publisher       PublisherRef  `json:"publisher"`
+	Subjects        []string      `json:"subjects"`
+	CreatedAt       time.Time     `json:"created_at"`
+	UpdatedAt       time.Time     `json:"updated_at"`
+	DeletedAt       *time.Time    `json:"deleted_at,omitempty"`
+	Version         int64         `json:"version"`
+}
+
+// AuthorRef is an author credited on a book
+type AuthorRef struct {
+	ID         int64  `json:"id"`
+	FirstName  string `json:"first_name"`
+	MiddleName string `json:"middle_name"`
+	LastName   string `json:"last_name"`
+}
+
+// PublisherRef is the publisher of a book
+type PublisherRef struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+// Cover is an uploaded cover image and its generated thumbnails
+type Cover struct {
+	Url        string           `json:"url"`
+	Thumbnails []CoverThumbnail `json:"thumbnails"`
+} //@name Cover
+
+type CoverThumbnail struct {
+	Width int    `json:"width"`
+	Url   string `json:"url"`
+} //@name CoverThumbnail
+
+// SrcSet lists the thumbnails in the format of the img srcset attribute
+func (c Cover) SrcSet() string {
+	s := make([]string, len(c.Thumbnails))
+	for i, t := range c.Thumbnails {
+		s[i] = fmt.Sprintf("%s %dw", t.Url, t.Width)
+	}
+
+	return strings.Join(s, ", ")
+}
@@ -0,0 +1,17 @@
+package models
+
+type CartItem struct {
+	Title    string  `json:"title"`
+	ISBN13   string  `json:"isbn13"`
+	ISBN10   string  `json:"isbn10"`
+	ImageUrl string  `json:"image_url"`
+	Price    float64 `json:"price"`
+	Quantity int64   `json:"quantity"`
+	Subtotal float64 `json:"subtotal"`
+} //@name CartItem
+
+type Cart struct {
+	Items     []CartItem `json:"items"`
+	ItemCount int64      `json:"item_count"`
+	Total     float64    `json:"total"`
+} //@name Cart
@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// Change is the latest change to a book, author or publisher
+type Change struct {
+	Type      string    `json:"type"`           // book, author or publisher
+	ID        string    `json:"id"`             // ID of the book, author or publisher, kept when a book's ISBNs change
+	ISBN      string    `json:"isbn,omitempty"` // current ISBN-13 of a book, or its ISBN-10 when it has none
+	Version   int64     `json:"version"`
+	UpdatedAt time.Time `json:"updated_at"`
+	Deleted   bool      `json:"deleted"`
+} //@name Change
+
+type ChangeFeed struct {
+	Items   []Change  `json:"items"`
+	Since   time.Time `json:"since"`    // pass as since to read the changes that follow
+	HasMore bool      `json:"has_more"` // more changes are ready to be read
+} //@name ChangeFeed
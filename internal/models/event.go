@@ -0,0 +1,57 @@
+package models
+
+import "time"
+
+type EventType string
+
+const (
+	EventBookCreated  EventType = "book.created"
+	EventBookUpdated  EventType = "book.updated"
+	EventBookDeleted  EventType = "book.deleted"
+	EventBookRestored EventType = "book.restored"
+	EventPriceChanged EventType = "price.changed"
+)
+
+// EventTypes lists every event a webhook can subscribe to
+var EventTypes = []EventType{
+	EventBookCreated,
+	EventBookUpdated,
+	EventBookDeleted,
+	EventBookRestored,
+	EventPriceChanged,
+}
+
+// Event is a change to the catalog. Data is the book for book events, a
+// BookRef for book.deleted and a PriceChange for price.changed.
+type Event struct {
+	ID        string    `json:"id"`
+	Type      EventType `json:"type"`
+	CreatedAt time.Time `json:"created_at"`
+	Data      any       `json:"data"`
+} //@name Event
+
+type BookRef struct {
+	ISBN13 string `json:"isbn13"`
+} //@name BookRef
+
+type PriceChange struct {
+	ISBN13   string  `json:"isbn13"`
+	ISBN10   string  `json:"isbn10"`
+	Title    string  `json:"title"`
+	OldPrice float64 `json:"old_price"`
+	Price    float64 `json:"price"`
+} //@name PriceChange
+
+// BookISBN13 returns the ISBN-13 of the book the event is about
+func (e Event) BookISBN13() string {
+	switch data := e.Data.(type) {
+	case Book:
+		return data.ISBN13
+	case BookRef:
+		return data.ISBN13
+	case PriceChange:
+		return data.ISBN13
+	}
+
+	return ""
+}
@@ -0,0 +1,52 @@
+package models
+
+import (
+	"slices"
+	"time"
+
+	"github.com/atsuyaourt/xyz-books/internal/util"
+)
+
+type OrderStatus string
+
+const (
+	OrderPending   OrderStatus = "pending"
+	OrderPaid      OrderStatus = "paid"
+	OrderShipped   OrderStatus = "shipped"
+	OrderDelivered OrderStatus = "delivered"
+	OrderCancelled OrderStatus = "cancelled"
+)
+
+var orderTransitions = map[OrderStatus][]OrderStatus{
+	OrderPending: {OrderPaid, OrderCancelled},
+	OrderPaid:    {OrderShipped, OrderCancelled},
+	OrderShipped: {OrderDelivered},
+}
+
+// CanTransitionTo reports whether an order may move from s to next
+func (s OrderStatus) CanTransitionTo(next OrderStatus) bool {
+	return slices.Contains(orderTransitions[s], next)
+}
+
+type OrderItem struct {
+	Title    string  `json:"title"`
+	ISBN13   string  `json:"isbn13"`
+	ISBN10   string  `json:"isbn10"`
+	Price    float64 `json:"price"`
+	Quantity int64   `json:"quantity"`
+	Subtotal float64 `json:"subtotal"`
+} //@name OrderItem
+
+type Order struct {
+	OrderID         int64       `json:"order_id"`
+	CustomerName    string      `json:"customer_name"`
+	Email           string      `json:"email"`
+	ShippingAddress string      `json:"shipping_address"`
+	Status          OrderStatus `json:"status"`
+	Total           float64     `json:"total"`
+	PaymentRef      string      `json:"payment_ref"`
+	CreatedAt       time.Time   `json:"created_at"`
+	Items           []OrderItem `json:"items"`
+} //@name Order
+
+type PaginatedOrders = util.PaginatedList[Order] //@name PaginatedOrders
@@ -1,9 +1,18 @@
 package models
 
-import "github.com/atsuyaourt/xyz-books/internal/util"
+import (
+	"time"
+
+	"github.com/atsuyaourt/xyz-books/internal/util"
+)
 
 type Publisher struct {
-	PublisherName string `json:"publisher_name"`
+	ID            int64      `json:"id"`
+	PublisherName string     `json:"publisher_name"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+	DeletedAt     *time.Time `json:"deleted_at,omitempty"`
+	Version       int64      `json:"version"`
 } //@name Publisher
 
 type PaginatedPublishers = util.PaginatedList[Publisher] //@name PaginatedPublishers
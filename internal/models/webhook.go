@@ -0,0 +1,39 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/atsuyaourt/xyz-books/internal/util"
+)
+
+type Webhook struct {
+	ID        int64       `json:"id"`
+	Url       string      `json:"url"`
+	Events    []EventType `json:"events"`
+	Secret    string      `json:"secret,omitempty"` // only returned when the webhook is created
+	CreatedAt time.Time   `json:"created_at"`
+} //@name Webhook
+
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending   WebhookDeliveryStatus = "pending"
+	WebhookDeliveryDelivered WebhookDeliveryStatus = "delivered"
+	WebhookDeliveryDead      WebhookDeliveryStatus = "dead" // ran out of attempts
+)
+
+type WebhookDelivery struct {
+	ID            int64                 `json:"id"`
+	WebhookID     int64                 `json:"webhook_id"`
+	EventID       string                `json:"event_id"`
+	Event         EventType             `json:"event"`
+	Status        WebhookDeliveryStatus `json:"status"`
+	Attempts      int64                 `json:"attempts"`
+	NextAttemptAt *time.Time            `json:"next_attempt_at,omitempty"` // set on pending deliveries
+	LastError     string                `json:"last_error,omitempty"`
+	Payload       json.RawMessage       `json:"payload" swaggertype:"object"`
+	CreatedAt     time.Time             `json:"created_at"`
+} //@name WebhookDelivery
+
+type PaginatedWebhookDeliveries = util.PaginatedList[WebhookDelivery] //@name PaginatedWebhookDeliveries
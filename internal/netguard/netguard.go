@@ -0,0 +1,87 @@
+// Package netguard keeps the requests the server makes on behalf of its
+// clients, such as webhook deliveries, away from its own networks: loopback,
+// private, link-local and other non-public addresses are refused.
+package netguard
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"net/url"
+	"strings"
+	"syscall"
+)
+
+// ErrForbiddenAddress is returned for URLs and connections to an address
+// that is not public
+var ErrForbiddenAddress = errors.New("address is not public")
+
+// nonPublic are the ranges that net/netip has no predicate for
+var nonPublic = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),     // this network
+	netip.MustParsePrefix("100.64.0.0/10"), // carrier-grade NAT
+	netip.MustParsePrefix("192.0.0.0/24"),  // protocol assignments
+	netip.MustParsePrefix("198.18.0.0/15"), // benchmarking
+	netip.MustParsePrefix("240.0.0.0/4"),   // reserved, and broadcast
+	netip.MustParsePrefix("64:ff9b::/96"),  // NAT64, maps onto IPv4
+}
+
+// Public reports whether ip is a public unicast address
+func Public(ip netip.Addr) bool {
+	ip = ip.Unmap()
+	if !ip.IsValid() || ip.IsUnspecified() || ip.IsLoopback() || ip.IsPrivate() ||
+		ip.IsLinkLocalUnicast() || ip.IsMulticast() {
+		return false
+	}
+	for _, p := range nonPublic {
+		if p.Contains(ip) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// CheckURL refuses URLs that are not http or https, and those whose host is
+// localhost or an address that is not public. Other host names are only
+// resolved when connecting, where Control checks them.
+func CheckURL(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("unsupported scheme %q", u.Scheme)
+	}
+
+	host := strings.TrimSuffix(strings.ToLower(u.Hostname()), ".")
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return fmt.Errorf("%s: %w", host, ErrForbiddenAddress)
+	}
+	if ip, err := netip.ParseAddr(host); err == nil && !Public(ip) {
+		return fmt.Errorf("%s: %w", host, ErrForbiddenAddress)
+	}
+
+	return nil
+}
+
+// Control is a net.Dialer Control that refuses to connect to an address that
+// is not public. It runs after the host name is resolved, so names pointing
+// at private addresses are refused too.
+func Control(network, address string, c syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	ip, err := netip.ParseAddr(host)
+	if err != nil {
+		return err
+	}
+	if !Public(ip) {
+		return fmt.Errorf("%s: %w", ip, ErrForbiddenAddress)
+	}
+
+	return nil
+}
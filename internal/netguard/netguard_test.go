@@ -0,0 +1,53 @@
+package netguard
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPublic(t *testing.T) {
+	for _, addr := range []string{"93.184.215.14", "2606:2800:21f:cb07:6820:80da:af6b:8b2c", "8.8.8.8"} {
+		require.True(t, Public(netip.MustParseAddr(addr)), addr)
+	}
+
+	for _, addr := range []string{
+		"127.0.0.1", "::1", "10.1.2.3", "172.16.0.1", "192.168.1.1", "169.254.169.254",
+		"0.0.0.0", "::", "100.64.0.1", "fd00::1", "fe80::1", "::ffff:127.0.0.1", "255.255.255.255",
+	} {
+		require.False(t, Public(netip.MustParseAddr(addr)), addr)
+	}
+}
+
+func TestCheckURL(t *testing.T) {
+	testCases := []struct {
+		url string
+		ok  bool
+	}{
+		{url: "https://example.com/hooks", ok: true},
+		{url: "http://93.184.215.14:8080/hooks", ok: true},
+		{url: "http://localhost:8080/hooks"},
+		{url: "http://api.localhost/hooks"},
+		{url: "http://127.0.0.1/hooks"},
+		{url: "http://[::1]/hooks"},
+		{url: "http://169.254.169.254/latest/meta-data"},
+		{url: "http://10.0.0.5/hooks"},
+		{url: "ftp://example.com/hooks"},
+	}
+
+	for _, tc := range testCases {
+		err := CheckURL(tc.url)
+		if tc.ok {
+			require.NoError(t, err, tc.url)
+		} else {
+			require.Error(t, err, tc.url)
+		}
+	}
+}
+
+func TestControl(t *testing.T) {
+	require.NoError(t, Control("tcp4", "93.184.215.14:443", nil))
+	require.ErrorIs(t, Control("tcp4", "127.0.0.1:8080", nil), ErrForbiddenAddress)
+	require.ErrorIs(t, Control("tcp6", "[::1]:8080", nil), ErrForbiddenAddress)
+}
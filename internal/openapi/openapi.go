@@ -0,0 +1,35 @@
+// Package openapi holds the OpenAPI 3.1 documents of the versions of the
+// JSON API, serves them and checks the requests and responses of the API
+// against them.
+package openapi
+
+import (
+	"embed"
+	"net/http"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gin-gonic/gin"
+)
+
+//go:embed v1.yaml v2.yaml
+var documents embed.FS
+
+// Load parses the OpenAPI document of an API version, e.g. v1, and resolves
+// its references. The document is not validated as a whole: kin-openapi
+// checks documents by the 3.0 rules, which reject the "null" type of 3.1.
+func Load(version string) (*openapi3.T, error) {
+	data, err := documents.ReadFile(version + ".yaml")
+	if err != nil {
+		return nil, err
+	}
+
+	loader := openapi3.NewLoader()
+	return loader.LoadFromData(data)
+}
+
+// Handler serves the document as JSON
+func Handler(doc *openapi3.T) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		ctx.JSON(http.StatusOK, doc)
+	}
+}
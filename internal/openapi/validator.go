@@ -0,0 +1,196 @@
+package openapi
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+	"github.com/gin-gonic/gin"
+)
+
+type validator struct {
+	router            routers.Router
+	validateResponses bool
+}
+
+type ValidatorOption func(*validator)
+
+// WithResponseValidation also checks the responses of the handlers, which
+// are answered with 500 when the document does not describe them. Responses
+// are buffered, so this is meant for tests.
+func WithResponseValidation() ValidatorOption {
+	return func(v *validator) {
+		v.validateResponses = true
+	}
+}
+
+// Validator checks the requests to the operations of the document, invalid
+// requests are answered with 400, or 415 when the body has a content type
+// the operation does not take. Requests to other routes are passed on.
+func Validator(doc *openapi3.T, opts ...ValidatorOption) (gin.HandlerFunc, error) {
+	router, err := gorillamux.NewRouter(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	v := &validator{router: router}
+	for _, opt := range opts {
+		opt(v)
+	}
+
+	return v.handle, nil
+}
+
+func (v *validator) handle(ctx *gin.Context) {
+	route, pathParams, err := v.router.FindRoute(ctx.Request)
+	if err != nil {
+		ctx.Next()
+		return
+	}
+
+	if !acceptsContentType(route.Operation, ctx.Request) {
+		ctx.AbortWithStatusJSON(http.StatusUnsupportedMediaType, errorResponse(
+			fmt.Errorf("content type %q is not supported", ctx.ContentType())))
+		return
+	}
+
+	options := &openapi3filter.Options{
+		AuthenticationFunc:    openapi3filter.NoopAuthenticationFunc,
+		IncludeResponseStatus: true,
+		// the handlers apply the defaults themselves
+		SkipSettingDefaults: true,
+		// uploads are checked by their handler, which limits their size
+		ExcludeRequestBody: ctx.ContentType() == gin.MIMEMultipartPOSTForm,
+	}
+	options.WithCustomSchemaErrorFunc(func(err *openapi3.SchemaError) string {
+		return err.Reason
+	})
+
+	input := &openapi3filter.RequestValidationInput{
+		Request:    ctx.Request,
+		PathParams: pathParams,
+		Route:      route,
+		Options:    options,
+	}
+	if err := openapi3filter.ValidateRequest(ctx, input); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(requestError(err)))
+		return
+	}
+
+	if !v.validateResponses {
+		ctx.Next()
+		return
+	}
+
+	w := &bufferedWriter{ResponseWriter: ctx.Writer, status: http.StatusOK}
+	ctx.Writer = w
+	ctx.Next()
+	ctx.Writer = w.ResponseWriter
+
+	err = openapi3filter.ValidateResponse(ctx, &openapi3filter.ResponseValidationInput{
+		RequestValidationInput: input,
+		Status:                 w.status,
+		Header:                 w.Header(),
+		Body:                   io.NopCloser(bytes.NewReader(w.body.Bytes())),
+		Options:                options,
+	})
+	if err != nil {
+		w.Header().Del("ETag")
+		ctx.JSON(http.StatusInternalServerError, errorResponse(
+			fmt.Errorf("%s %s answered %d, which the API document does not describe: %w",
+				ctx.Request.Method, route.Path, w.status, err)))
+		return
+	}
+
+	w.ResponseWriter.WriteHeader(w.status)
+	w.ResponseWriter.WriteHeaderNow()
+	w.ResponseWriter.Write(w.body.Bytes())
+}
+
+// acceptsContentType reports whether the request has no body or one the
+// operation takes
+func acceptsContentType(op *openapi3.Operation, req *http.Request) bool {
+	if op.RequestBody == nil || op.RequestBody.Value == nil || req.ContentLength == 0 || req.Body == http.NoBody {
+		return true
+	}
+
+	mediaType, _, err := mime.ParseMediaType(req.Header.Get("Content-Type"))
+	if err != nil {
+		return false
+	}
+
+	return op.RequestBody.Value.Content.Get(mediaType) != nil
+}
+
+// requestError drops the route details from the errors of the requests,
+// they repeat what the client sent
+func requestError(err error) error {
+	var reqErr *openapi3filter.RequestError
+	if !errors.As(err, &reqErr) {
+		return err
+	}
+
+	if reqErr.Parameter != nil {
+		return fmt.Errorf("invalid %s parameter %s: %w", reqErr.Parameter.In, reqErr.Parameter.Name, reqErr.Err)
+	}
+	if reqErr.Err != nil {
+		return fmt.Errorf("invalid request body: %w", reqErr.Err)
+	}
+
+	return errors.New(reqErr.Reason)
+}
+
+func errorResponse(err error) gin.H {
+	return gin.H{"error": err.Error()}
+}
+
+// bufferedWriter holds the response of a handler until it is validated
+type bufferedWriter struct {
+	gin.ResponseWriter
+	status  int
+	body    bytes.Buffer
+	written bool
+}
+
+func (w *bufferedWriter) WriteHeader(code int) {
+	if code > 0 && !w.written {
+		w.status = code
+	}
+}
+
+func (w *bufferedWriter) WriteHeaderNow() {
+	w.written = true
+}
+
+func (w *bufferedWriter) Write(data []byte) (int, error) {
+	w.written = true
+	return w.body.Write(data)
+}
+
+func (w *bufferedWriter) WriteString(s string) (int, error) {
+	w.written = true
+	return w.body.WriteString(s)
+}
+
+func (w *bufferedWriter) Status() int {
+	return w.status
+}
+
+func (w *bufferedWriter) Size() int {
+	if !w.written {
+		return -1
+	}
+
+	return w.body.Len()
+}
+
+func (w *bufferedWriter) Written() bool {
+	return w.written
+}
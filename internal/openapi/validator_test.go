@@ -0,0 +1,146 @@
+package openapi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRouter(t *testing.T, handler gin.HandlerFunc, opts ...ValidatorOption) *gin.Engine {
+	doc, err := Load("v1")
+	require.NoError(t, err)
+	doc.Servers = openapi3.Servers{{URL: "/api/v1"}}
+
+	validator, err := Validator(doc, opts...)
+	require.NoError(t, err)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	api := router.Group("/api/v1", validator)
+	api.GET("/books", handler)
+	api.PATCH("/books/:isbn", handler)
+	api.GET("/undocumented", handler)
+
+	return router
+}
+
+func TestValidator(t *testing.T) {
+	ok := func(ctx *gin.Context) {
+		ctx.JSON(http.StatusOK, gin.H{
+			"current_page": 1, "per_page": 20, "total_pages": 0, "next_page": 0, "prev_page": 0, "total_items": 0,
+			"items": []any{},
+		})
+	}
+	teapot := func(ctx *gin.Context) {
+		ctx.JSON(http.StatusTeapot, gin.H{"error": "teapot"})
+	}
+	noItems := func(ctx *gin.Context) {
+		ctx.JSON(http.StatusOK, gin.H{"current_page": 1})
+	}
+
+	testCases := []struct {
+		name          string
+		method        string
+		path          string
+		contentType   string
+		body          string
+		handler       gin.HandlerFunc
+		opts          []ValidatorOption
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:    "OK",
+			method:  http.MethodGet,
+			path:    "/api/v1/books?per_page=20",
+			handler: ok,
+			opts:    []ValidatorOption{WithResponseValidation()},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+				require.Contains(t, recorder.Body.String(), `"items":[]`)
+			},
+		},
+		{
+			name:    "InvalidQuery",
+			method:  http.MethodGet,
+			path:    "/api/v1/books?per_page=100",
+			handler: ok,
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "invalid query parameter per_page")
+			},
+		},
+		{
+			name:        "UnsupportedContentType",
+			method:      http.MethodPatch,
+			path:        "/api/v1/books/9780000000002",
+			contentType: "application/json",
+			body:        `{"title":"New Title"}`,
+			handler:     ok,
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnsupportedMediaType, recorder.Code)
+			},
+		},
+		{
+			name:    "UndocumentedRoute",
+			method:  http.MethodGet,
+			path:    "/api/v1/undocumented",
+			handler: teapot,
+			opts:    []ValidatorOption{WithResponseValidation()},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusTeapot, recorder.Code)
+			},
+		},
+		{
+			name:    "UndocumentedStatus",
+			method:  http.MethodGet,
+			path:    "/api/v1/books",
+			handler: teapot,
+			opts:    []ValidatorOption{WithResponseValidation()},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "answered 418")
+			},
+		},
+		{
+			name:    "InvalidResponseBody",
+			method:  http.MethodGet,
+			path:    "/api/v1/books",
+			handler: noItems,
+			opts:    []ValidatorOption{WithResponseValidation()},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name:    "ResponsesNotValidated",
+			method:  http.MethodGet,
+			path:    "/api/v1/books",
+			handler: teapot,
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusTeapot, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			router := newTestRouter(t, tc.handler, tc.opts...)
+
+			req := httptest.NewRequest(tc.method, tc.path, strings.NewReader(tc.body))
+			if tc.contentType != "" {
+				req.Header.Set("Content-Type", tc.contentType)
+			}
+
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, req)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
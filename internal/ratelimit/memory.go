@@ -0,0 +1,71 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// MemoryStore is a Store that keeps the buckets in memory. A bucket is kept
+// as the time it is full again rather than a count of requests, so it never
+// has to be refilled. Full buckets are dropped now and then, so clients that
+// stop sending cost nothing.
+type MemoryStore struct {
+	mu        sync.Mutex
+	fullAt    map[string]time.Time
+	now       func() time.Time
+	lastSweep time.Time
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		fullAt: make(map[string]time.Time),
+		now:    time.Now,
+	}
+}
+
+func (s *MemoryStore) Take(ctx context.Context, key string, limit Limit) (Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.sweep(now, limit.Window)
+
+	// each request pushes the time the bucket is full again one interval
+	// further, up to a window ahead, which is an empty bucket
+	interval := limit.interval()
+	fullAt := s.fullAt[key]
+	if fullAt.Before(now) {
+		fullAt = now
+	}
+	next := fullAt.Add(interval)
+	latest := now.Add(limit.Window)
+
+	if next.After(latest) {
+		return Result{
+			Reset:      fullAt.Sub(now),
+			RetryAfter: next.Sub(latest),
+		}, nil
+	}
+
+	s.fullAt[key] = next
+	return Result{
+		Allowed:   true,
+		Remaining: int(latest.Sub(next) / interval),
+		Reset:     next.Sub(now),
+	}, nil
+}
+
+// sweep drops the full buckets, at most once a window
+func (s *MemoryStore) sweep(now time.Time, window time.Duration) {
+	if now.Sub(s.lastSweep) < window {
+		return
+	}
+	s.lastSweep = now
+
+	for key, fullAt := range s.fullAt {
+		if !fullAt.After(now) {
+			delete(s.fullAt, key)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package ratelimit
+
+import (
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/atsuyaourt/xyz-books/internal/auth"
+	"github.com/atsuyaourt/xyz-books/internal/logging"
+	"github.com/gin-gonic/gin"
+)
+
+// Limiter hands out the middleware of the route groups, whose clients each
+// get a bucket per group
+type Limiter struct {
+	store   Store
+	clients auth.Keys
+}
+
+// NewLimiter limits the requests sent with one of the API keys of clients
+// by key, and the others by IP. Other keys do not name a client, so that
+// making one up does not get a fresh bucket.
+func NewLimiter(store Store, clients auth.Keys) *Limiter {
+	return &Limiter{store: store, clients: clients}
+}
+
+// clientKey returns the API key of a request when it is known, or the IP of
+// the client
+func (l *Limiter) clientKey(ctx *gin.Context) string {
+	if key := ctx.GetHeader(auth.APIKeyHeader); l.clients.Contains(key) {
+		return "key:" + key
+	}
+
+	return "ip:" + ctx.ClientIP()
+}
+
+// seconds rounds a duration up to whole seconds
+func seconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
+
+// Limit limits the requests of each client to a route group. Requests over
+// the limit are answered with 429 and a Retry-After header, and every
+// response carries the RateLimit headers of the bucket. The requests are let
+// through when the store fails, a broken store should not take the API down.
+func (l *Limiter) Limit(group string, limit Limit) gin.HandlerFunc {
+	policy := strconv.Itoa(limit.Requests) + ";w=" + seconds(limit.Window)
+
+	return func(ctx *gin.Context) {
+		res, err := l.store.Take(ctx, group+":"+l.clientKey(ctx), limit)
+		if err != nil {
+			logging.FromContext(ctx.Request.Context()).Error("cannot rate limit",
+				slog.String("group", group), slog.Any("error", err))
+			ctx.Next()
+			return
+		}
+
+		ctx.Header("RateLimit-Policy", policy)
+		ctx.Header("RateLimit-Limit", strconv.Itoa(limit.Requests))
+		ctx.Header("RateLimit-Remaining", strconv.Itoa(res.Remaining))
+		ctx.Header("RateLimit-Reset", seconds(res.Reset))
+
+		if !res.Allowed {
+			ctx.Header("Retry-After", seconds(res.RetryAfter))
+			ctx.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"error": "rate limit exceeded, retry later"})
+			return
+		}
+
+		ctx.Next()
+	}
+}
@@ -0,0 +1,63 @@
+// Package ratelimit limits the requests of each client with token buckets.
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInvalidLimit = errors.New("invalid rate limit, expected requests/window such as 60/1m")
+
+// Limit allows Requests per Window. A client may use them all at once, after
+// which they are handed back evenly over the window.
+type Limit struct {
+	Requests int
+	Window   time.Duration
+}
+
+// ParseLimit reads a limit written as requests/window, e.g. 60/1m
+func ParseLimit(s string) (Limit, error) {
+	requests, window, ok := strings.Cut(s, "/")
+	if !ok {
+		return Limit{}, ErrInvalidLimit
+	}
+
+	n, err := strconv.Atoi(strings.TrimSpace(requests))
+	if err != nil || n < 1 {
+		return Limit{}, ErrInvalidLimit
+	}
+	d, err := time.ParseDuration(strings.TrimSpace(window))
+	if err != nil || d <= 0 {
+		return Limit{}, ErrInvalidLimit
+	}
+
+	return Limit{Requests: n, Window: d}, nil
+}
+
+func (l Limit) String() string {
+	return fmt.Sprintf("%d/%s", l.Requests, l.Window)
+}
+
+// interval is the time it takes to hand back one request
+func (l Limit) interval() time.Duration {
+	return l.Window / time.Duration(l.Requests)
+}
+
+// Result is the state of a bucket after a request was taken from it
+type Result struct {
+	Allowed    bool
+	Remaining  int           // requests left in the bucket
+	Reset      time.Duration // until the bucket is full again
+	RetryAfter time.Duration // until the next request is allowed, zero when allowed
+}
+
+// Store keeps the buckets of the clients. The in-memory store only limits
+// the requests to one server, a shared store is needed to limit a fleet.
+type Store interface {
+	// Take takes a request from the bucket of key, which holds limit
+	Take(ctx context.Context, key string, limit Limit) (Result, error)
+}
@@ -0,0 +1,170 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/atsuyaourt/xyz-books/internal/auth"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLimit(t *testing.T) {
+	testCases := []struct {
+		name  string
+		s     string
+		limit Limit
+		err   error
+	}{
+		{name: "Minute", s: "60/1m", limit: Limit{Requests: 60, Window: time.Minute}},
+		{name: "Spaces", s: " 5 / 10s ", limit: Limit{Requests: 5, Window: 10 * time.Second}},
+		{name: "NoWindow", s: "60", err: ErrInvalidLimit},
+		{name: "ZeroRequests", s: "0/1m", err: ErrInvalidLimit},
+		{name: "InvalidWindow", s: "60/minute", err: ErrInvalidLimit},
+		{name: "NegativeWindow", s: "60/-1m", err: ErrInvalidLimit},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			limit, err := ParseLimit(tc.s)
+			require.ErrorIs(t, err, tc.err)
+			require.Equal(t, tc.limit, limit)
+		})
+	}
+}
+
+func TestMemoryStore(t *testing.T) {
+	ctx := context.Background()
+	limit := Limit{Requests: 3, Window: 3 * time.Second}
+
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	store := NewMemoryStore()
+	store.now = func() time.Time { return now }
+
+	// the bucket starts full
+	for remaining := 2; remaining >= 0; remaining-- {
+		res, err := store.Take(ctx, "a", limit)
+		require.NoError(t, err)
+		require.True(t, res.Allowed)
+		require.Equal(t, remaining, res.Remaining)
+		require.Equal(t, time.Duration(3-remaining)*time.Second, res.Reset)
+	}
+
+	res, err := store.Take(ctx, "a", limit)
+	require.NoError(t, err)
+	require.False(t, res.Allowed)
+	require.Equal(t, 3*time.Second, res.Reset)
+	require.Equal(t, time.Second, res.RetryAfter)
+
+	// other keys have their own bucket
+	res, err = store.Take(ctx, "b", limit)
+	require.NoError(t, err)
+	require.True(t, res.Allowed)
+
+	// a request is handed back each interval
+	now = now.Add(time.Second)
+	res, err = store.Take(ctx, "a", limit)
+	require.NoError(t, err)
+	require.True(t, res.Allowed)
+	require.Equal(t, 0, res.Remaining)
+
+	// the full buckets are dropped
+	now = now.Add(time.Hour)
+	_, err = store.Take(ctx, "c", limit)
+	require.NoError(t, err)
+	require.Len(t, store.fullAt, 1)
+}
+
+type failingStore struct{}
+
+func (failingStore) Take(ctx context.Context, key string, limit Limit) (Result, error) {
+	return Result{}, errors.New("store is down")
+}
+
+func TestLimiter(t *testing.T) {
+	limit := Limit{Requests: 2, Window: time.Minute}
+
+	newRouter := func(store Store) *gin.Engine {
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+		router.GET("/books", NewLimiter(store, auth.NewKeys([]string{"k1", "k2"})).Limit("books", limit), func(ctx *gin.Context) {
+			ctx.Status(http.StatusOK)
+		})
+		return router
+	}
+	get := func(router *gin.Engine, apiKey, ip string) *httptest.ResponseRecorder {
+		request, err := http.NewRequest(http.MethodGet, "/books", nil)
+		require.NoError(t, err)
+		if len(apiKey) > 0 {
+			request.Header.Set(auth.APIKeyHeader, apiKey)
+		}
+		request.RemoteAddr = ip + ":1234"
+
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	t.Run("Headers", func(t *testing.T) {
+		router := newRouter(NewMemoryStore())
+
+		recorder := get(router, "", "10.0.0.1")
+		require.Equal(t, http.StatusOK, recorder.Code)
+		require.Equal(t, "2;w=60", recorder.Header().Get("RateLimit-Policy"))
+		require.Equal(t, "2", recorder.Header().Get("RateLimit-Limit"))
+		require.Equal(t, "1", recorder.Header().Get("RateLimit-Remaining"))
+		require.Equal(t, "30", recorder.Header().Get("RateLimit-Reset"))
+		require.Empty(t, recorder.Header().Get("Retry-After"))
+	})
+
+	t.Run("TooManyRequests", func(t *testing.T) {
+		router := newRouter(NewMemoryStore())
+
+		get(router, "", "10.0.0.1")
+		get(router, "", "10.0.0.1")
+		recorder := get(router, "", "10.0.0.1")
+		require.Equal(t, http.StatusTooManyRequests, recorder.Code)
+		require.Equal(t, "0", recorder.Header().Get("RateLimit-Remaining"))
+		require.Equal(t, "30", recorder.Header().Get("Retry-After"))
+		require.JSONEq(t, `{"error":"rate limit exceeded, retry later"}`, recorder.Body.String())
+
+		// other clients are not limited
+		require.Equal(t, http.StatusOK, get(router, "", "10.0.0.2").Code)
+	})
+
+	t.Run("APIKey", func(t *testing.T) {
+		router := newRouter(NewMemoryStore())
+
+		get(router, "k1", "10.0.0.1")
+		get(router, "k1", "10.0.0.2")
+		require.Equal(t, http.StatusTooManyRequests, get(router, "k1", "10.0.0.3").Code)
+
+		// the key, not the IP, identifies the client
+		require.Equal(t, http.StatusOK, get(router, "k2", "10.0.0.1").Code)
+		require.Equal(t, http.StatusOK, get(router, "", "10.0.0.1").Code)
+	})
+
+	t.Run("UnknownAPIKey", func(t *testing.T) {
+		router := newRouter(NewMemoryStore())
+
+		// made up keys are limited by IP
+		get(router, "made-up-1", "10.0.0.1")
+		get(router, "made-up-2", "10.0.0.1")
+		require.Equal(t, http.StatusTooManyRequests, get(router, "made-up-3", "10.0.0.1").Code)
+		require.Equal(t, http.StatusTooManyRequests, get(router, "", "10.0.0.1").Code)
+	})
+
+	t.Run("StoreError", func(t *testing.T) {
+		router := newRouter(failingStore{})
+
+		recorder := get(router, "", "10.0.0.1")
+		require.Equal(t, http.StatusOK, recorder.Code)
+		require.Empty(t, recorder.Header().Get("RateLimit-Limit"))
+	})
+}
@@ -0,0 +1,118 @@
+package rpc
+
+import (
+	"context"
+
+	catalogv1 "github.com/atsuyaourt/xyz-books/api/catalog/v1"
+	"github.com/atsuyaourt/xyz-books/internal/models"
+	"github.com/atsuyaourt/xyz-books/internal/services"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const authorNotFound = "author not found"
+
+func newAuthor(author *models.Author) *catalogv1.Author {
+	return &catalogv1.Author{
+		Id:         author.ID,
+		FirstName:  author.FirstName,
+		MiddleName: author.MiddleName,
+		LastName:   author.LastName,
+		CreatedAt:  timestamppb.New(author.CreatedAt),
+		UpdatedAt:  timestamppb.New(author.UpdatedAt),
+		DeletedAt:  newTimestamp(author.DeletedAt),
+		Version:    author.Version,
+	}
+}
+
+func (s *CatalogServer) CreateAuthor(ctx context.Context, in *catalogv1.CreateAuthorRequest) (*catalogv1.Author, error) {
+	req := services.CreateAuthorReq{
+		FirstName:  in.GetFirstName(),
+		MiddleName: in.GetMiddleName(),
+		LastName:   in.GetLastName(),
+	}
+	if err := validate(req); err != nil {
+		return nil, err
+	}
+
+	author, err := s.service.CreateAuthor(ctx, req)
+	if err != nil {
+		return nil, statusError(err, authorNotFound)
+	}
+
+	return newAuthor(author), nil
+}
+
+func (s *CatalogServer) GetAuthor(ctx context.Context, in *catalogv1.GetAuthorRequest) (*catalogv1.Author, error) {
+	author, err := s.service.GetAuthor(ctx, in.GetId(), in.GetIncludeDeleted())
+	if err != nil {
+		return nil, statusError(err, authorNotFound)
+	}
+
+	return newAuthor(author), nil
+}
+
+func (s *CatalogServer) ListAuthors(ctx context.Context, in *catalogv1.ListAuthorsRequest) (*catalogv1.ListAuthorsResponse, error) {
+	req := services.ListAuthorsReq{
+		UpdatedSince:   since(in.GetUpdatedSince()),
+		IncludeDeleted: in.GetIncludeDeleted(),
+	}
+	req.Page, req.PerPage = pageOrDefault(in.GetPage(), in.GetPerPage())
+	if err := validate(req); err != nil {
+		return nil, err
+	}
+
+	authors, err := s.service.ListAuthors(ctx, req)
+	if err != nil {
+		return nil, statusError(err, authorNotFound)
+	}
+
+	items := make([]*catalogv1.Author, len(authors.Items))
+	for i := range authors.Items {
+		items[i] = newAuthor(&authors.Items[i])
+	}
+
+	return &catalogv1.ListAuthorsResponse{Items: items, Page: newPage(authors)}, nil
+}
+
+func (s *CatalogServer) UpdateAuthor(ctx context.Context, in *catalogv1.UpdateAuthorRequest) (*catalogv1.Author, error) {
+	if in.GetVersion() < 1 {
+		return nil, errVersionRequired
+	}
+
+	req := services.UpdateAuthorReq{
+		FirstName:  in.GetFirstName(),
+		MiddleName: in.GetMiddleName(),
+		LastName:   in.GetLastName(),
+	}
+	if err := validate(req); err != nil {
+		return nil, err
+	}
+
+	author, err := s.service.UpdateAuthor(ctx, in.GetId(), in.GetVersion(), req)
+	if err != nil {
+		return nil, statusError(err, authorNotFound)
+	}
+
+	return newAuthor(author), nil
+}
+
+func (s *CatalogServer) DeleteAuthor(ctx context.Context, in *catalogv1.DeleteAuthorRequest) (*catalogv1.DeleteResponse, error) {
+	if in.GetVersion() < 1 {
+		return nil, errVersionRequired
+	}
+
+	if err := s.service.DeleteAuthor(ctx, in.GetId(), in.GetVersion()); err != nil {
+		return nil, statusError(err, authorNotFound)
+	}
+
+	return &catalogv1.DeleteResponse{}, nil
+}
+
+func (s *CatalogServer) RestoreAuthor(ctx context.Context, in *catalogv1.RestoreAuthorRequest) (*catalogv1.Author, error) {
+	author, err := s.service.RestoreAuthor(ctx, in.GetId())
+	if err != nil {
+		return nil, statusError(err, authorNotFound)
+	}
+
+	return newAuthor(author), nil
+}
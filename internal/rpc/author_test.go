@@ -0,0 +1,108 @@
+package rpc
+
+import (
+	"context"
+	"testing"
+
+	catalogv1 "github.com/atsuyaourt/xyz-books/api/catalog/v1"
+	db "github.com/atsuyaourt/xyz-books/internal/db/sqlc"
+	mockdb "github.com/atsuyaourt/xyz-books/internal/mocks/db"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+func TestUpdateAuthor(t *testing.T) {
+	author := randomAuthor(t)
+
+	testCases := []struct {
+		name       string
+		version    int64
+		buildStubs func(store *mockdb.MockStore)
+		check      func(res *catalogv1.Author, err error)
+	}{
+		{
+			name:    "OK",
+			version: 1,
+			buildStubs: func(store *mockdb.MockStore) {
+				expectExecTx(store)
+				updated := author
+				updated.FirstName = "Ursula"
+				updated.Version = 2
+				store.EXPECT().UpdateAuthor(mock.Anything, mock.MatchedBy(func(arg db.UpdateAuthorParams) bool {
+					return arg.AuthorID == author.AuthorID && arg.Version.Int64 == 1 && arg.FirstName.String == "Ursula"
+				})).Return(updated, nil)
+				store.EXPECT().BumpAuthorBookVersions(mock.Anything, author.AuthorID).Return(nil)
+			},
+			check: func(res *catalogv1.Author, err error) {
+				require.NoError(t, err)
+				require.Equal(t, author.AuthorID, res.GetId())
+				require.Equal(t, "Ursula", res.GetFirstName())
+				require.Equal(t, int64(2), res.GetVersion())
+			},
+		},
+		{
+			name:    "NoVersion",
+			version: 0,
+			buildStubs: func(store *mockdb.MockStore) {
+			},
+			check: func(res *catalogv1.Author, err error) {
+				requireCode(t, codes.InvalidArgument, err)
+			},
+		},
+		{
+			name:    "VersionChanged",
+			version: 1,
+			buildStubs: func(store *mockdb.MockStore) {
+				expectExecTx(store)
+				store.EXPECT().UpdateAuthor(mock.Anything, mock.Anything).Return(db.Author{}, db.ErrRecordNotFound)
+				store.EXPECT().GetAuthor(mock.Anything, mock.Anything).Return(author, nil)
+			},
+			check: func(res *catalogv1.Author, err error) {
+				requireCode(t, codes.FailedPrecondition, err)
+			},
+		},
+		{
+			name:    "NotFound",
+			version: 1,
+			buildStubs: func(store *mockdb.MockStore) {
+				expectExecTx(store)
+				store.EXPECT().UpdateAuthor(mock.Anything, mock.Anything).Return(db.Author{}, db.ErrRecordNotFound)
+				store.EXPECT().GetAuthor(mock.Anything, mock.Anything).Return(db.Author{}, db.ErrRecordNotFound)
+			},
+			check: func(res *catalogv1.Author, err error) {
+				requireCode(t, codes.NotFound, err)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			store := mockdb.NewMockStore(t)
+			tc.buildStubs(store)
+
+			client := newTestClient(t, store)
+			res, err := client.UpdateAuthor(context.Background(), &catalogv1.UpdateAuthorRequest{
+				Id:        author.AuthorID,
+				Version:   tc.version,
+				FirstName: "Ursula",
+			})
+			tc.check(res, err)
+		})
+	}
+}
+
+func TestDeleteAuthor(t *testing.T) {
+	author := randomAuthor(t)
+
+	store := mockdb.NewMockStore(t)
+	expectExecTx(store)
+	store.EXPECT().DeleteAuthor(mock.Anything, mock.Anything).Return(0, nil)
+	store.EXPECT().GetAuthor(mock.Anything, mock.Anything).Return(author, nil)
+
+	client := newTestClient(t, store)
+	_, err := client.DeleteAuthor(context.Background(), &catalogv1.DeleteAuthorRequest{Id: author.AuthorID, Version: 1})
+	requireCode(t, codes.FailedPrecondition, err)
+}
@@ -0,0 +1,188 @@
+package rpc
+
+import (
+	"context"
+
+	catalogv1 "github.com/atsuyaourt/xyz-books/api/catalog/v1"
+	"github.com/atsuyaourt/xyz-books/internal/models"
+	"github.com/atsuyaourt/xyz-books/internal/services"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const bookNotFound = "book not found"
+
+func newBook(book *models.Book) *catalogv1.Book {
+	contributors := make([]*catalogv1.Contributor, len(book.Contributors))
+	for i, c := range book.Contributors {
+		contributors[i] = &catalogv1.Contributor{Name: c.Name, Role: c.Role}
+	}
+
+	return &catalogv1.Book{
+		Isbn13:          book.ISBN13,
+		Isbn10:          book.ISBN10,
+		Title:           book.Title,
+		Price:           book.Price,
+		PublicationYear: book.PublicationYear,
+		ImageUrl:        book.ImageUrl,
+		Edition:         book.Edition,
+		Language:        book.Language,
+		Format:          book.Format,
+		PageCount:       book.PageCount,
+		SeriesName:      book.SeriesName,
+		SeriesNumber:    book.SeriesNumber,
+		Description:     book.Description,
+		Authors:         book.Authors,
+		Contributors:    contributors,
+		Publisher:       book.Publisher,
+		Subjects:        book.Subjects,
+		CreatedAt:       timestamppb.New(book.CreatedAt),
+		UpdatedAt:       timestamppb.New(book.UpdatedAt),
+		DeletedAt:       newTimestamp(book.DeletedAt),
+		Version:         book.Version,
+	}
+}
+
+func (s *CatalogServer) CreateBook(ctx context.Context, in *catalogv1.CreateBookRequest) (*catalogv1.Book, error) {
+	var req services.CreateBookReq
+	req.Book.Title = in.GetTitle()
+	req.Book.ISBN13 = in.GetIsbn13()
+	req.Book.ISBN10 = in.GetIsbn10()
+	req.Book.Price = in.GetPrice()
+	req.Book.PublicationYear = in.GetPublicationYear()
+	req.Book.ImageUrl = in.GetImageUrl()
+	req.Book.Edition = in.GetEdition()
+	req.Book.Language = in.GetLanguage()
+	req.Book.Format = in.GetFormat()
+	req.Book.PageCount = in.GetPageCount()
+	req.Book.SeriesName = in.GetSeriesName()
+	req.Book.SeriesNumber = in.GetSeriesNumber()
+	req.Book.Description = in.GetDescription()
+	req.Authors = in.GetAuthors()
+	for _, c := range in.GetContributors() {
+		req.Contributors = append(req.Contributors, services.ContributorReq{Name: c.GetName(), Role: c.GetRole()})
+	}
+	req.Publisher = in.GetPublisher()
+	req.Subjects = in.GetSubjects()
+	if err := validate(req); err != nil {
+		return nil, err
+	}
+
+	book, err := s.service.CreateBook(ctx, req)
+	if err != nil {
+		return nil, statusError(err, bookNotFound)
+	}
+
+	return newBook(book), nil
+}
+
+func (s *CatalogServer) GetBook(ctx context.Context, in *catalogv1.GetBookRequest) (*catalogv1.Book, error) {
+	book, err := s.service.GetBook(ctx, in.GetIsbn13(), in.GetIncludeDeleted())
+	if err != nil {
+		return nil, statusError(err, bookNotFound)
+	}
+
+	return newBook(book), nil
+}
+
+func (s *CatalogServer) ListBooks(ctx context.Context, in *catalogv1.ListBooksRequest) (*catalogv1.ListBooksResponse, error) {
+	req := services.ListBooksReq{
+		Title:              in.GetTitle(),
+		MinPrice:           -1,
+		MaxPrice:           -1,
+		MinPublicationYear: -1,
+		MaxPublicationYear: -1,
+		Author:             in.GetAuthor(),
+		Publisher:          in.GetPublisher(),
+		Language:           in.GetLanguage(),
+		Format:             in.GetFormat(),
+		MinPageCount:       in.GetMinPageCount(),
+		MaxPageCount:       in.GetMaxPageCount(),
+		SeriesName:         in.GetSeriesName(),
+		SeriesNumber:       in.GetSeriesNumber(),
+		Description:        in.GetDescription(),
+		Subject:            in.GetSubject(),
+		UpdatedSince:       since(in.GetUpdatedSince()),
+		IncludeDeleted:     in.GetIncludeDeleted(),
+	}
+	if in.MinPrice != nil {
+		req.MinPrice = float32(in.GetMinPrice())
+	}
+	if in.MaxPrice != nil {
+		req.MaxPrice = float32(in.GetMaxPrice())
+	}
+	if in.MinPublicationYear != nil {
+		req.MinPublicationYear = in.GetMinPublicationYear()
+	}
+	if in.MaxPublicationYear != nil {
+		req.MaxPublicationYear = in.GetMaxPublicationYear()
+	}
+	req.Page, req.PerPage = pageOrDefault(in.GetPage(), in.GetPerPage())
+	if err := validate(req); err != nil {
+		return nil, err
+	}
+
+	books, err := s.service.ListBooks(ctx, req)
+	if err != nil {
+		return nil, statusError(err, bookNotFound)
+	}
+
+	items := make([]*catalogv1.Book, len(books.Items))
+	for i := range books.Items {
+		items[i] = newBook(&books.Items[i])
+	}
+
+	return &catalogv1.ListBooksResponse{Items: items, Page: newPage(books)}, nil
+}
+
+func (s *CatalogServer) UpdateBook(ctx context.Context, in *catalogv1.UpdateBookRequest) (*catalogv1.Book, error) {
+	if in.GetVersion() < 1 {
+		return nil, errVersionRequired
+	}
+
+	req := services.UpdateBookReq{
+		Title:           in.GetTitle(),
+		NewISBN13:       in.GetNewIsbn13(),
+		NewISBN10:       in.GetNewIsbn10(),
+		Price:           float32(in.GetPrice()),
+		PublicationYear: in.GetPublicationYear(),
+		ImageUrl:        in.GetImageUrl(),
+		Language:        in.GetLanguage(),
+		Format:          in.GetFormat(),
+		PageCount:       in.GetPageCount(),
+		SeriesName:      in.GetSeriesName(),
+		SeriesNumber:    in.GetSeriesNumber(),
+		Description:     in.GetDescription(),
+		Subjects:        in.GetSubjects(),
+	}
+	if err := validate(req); err != nil {
+		return nil, err
+	}
+
+	book, err := s.service.UpdateBook(ctx, in.GetIsbn13(), in.GetVersion(), req)
+	if err != nil {
+		return nil, statusError(err, bookNotFound)
+	}
+
+	return newBook(book), nil
+}
+
+func (s *CatalogServer) DeleteBook(ctx context.Context, in *catalogv1.DeleteBookRequest) (*catalogv1.DeleteResponse, error) {
+	if in.GetVersion() < 1 {
+		return nil, errVersionRequired
+	}
+
+	if err := s.service.DeleteBook(ctx, in.GetIsbn13(), in.GetVersion()); err != nil {
+		return nil, statusError(err, bookNotFound)
+	}
+
+	return &catalogv1.DeleteResponse{}, nil
+}
+
+func (s *CatalogServer) RestoreBook(ctx context.Context, in *catalogv1.RestoreBookRequest) (*catalogv1.Book, error) {
+	book, err := s.service.RestoreBook(ctx, in.GetIsbn13())
+	if err != nil {
+		return nil, statusError(err, bookNotFound)
+	}
+
+	return newBook(book), nil
+}
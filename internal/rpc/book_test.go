@@ -0,0 +1,359 @@
+package rpc
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+	"time"
+
+	catalogv1 "github.com/atsuyaourt/xyz-books/api/catalog/v1"
+	"github.com/atsuyaourt/xyz-books/internal/auth"
+	db "github.com/atsuyaourt/xyz-books/internal/db/sqlc"
+	mockdb "github.com/atsuyaourt/xyz-books/internal/mocks/db"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestCreateBook(t *testing.T) {
+	book := randomBook(t)
+	publisher := randomPublisher(t)
+
+	testCases := []struct {
+		name       string
+		req        *catalogv1.CreateBookRequest
+		buildStubs func(store *mockdb.MockStore)
+		check      func(res *catalogv1.Book, err error)
+	}{
+		{
+			name: "OK",
+			req: &catalogv1.CreateBookRequest{
+				Title:           book.Title,
+				Isbn13:          book.Isbn13.String,
+				Price:           book.Price,
+				PublicationYear: book.PublicationYear,
+				Authors:         []string{"Ursula K. Le Guin"},
+				Publisher:       publisher.PublisherName,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateBookTx(mock.Anything, mock.MatchedBy(func(arg db.CreateBookTxParams) bool {
+					return arg.Book.Title == book.Title && strings.EqualFold(arg.Publisher, publisher.PublisherName)
+				})).Return(book, nil)
+			},
+			check: func(res *catalogv1.Book, err error) {
+				require.NoError(t, err)
+				require.Equal(t, book.Isbn13.String, res.GetIsbn13())
+				require.Equal(t, book.Title, res.GetTitle())
+			},
+		},
+		{
+			name: "NoTitle",
+			req: &catalogv1.CreateBookRequest{
+				Isbn13:          book.Isbn13.String,
+				Price:           book.Price,
+				PublicationYear: book.PublicationYear,
+				Authors:         []string{"Ursula K. Le Guin"},
+				Publisher:       publisher.PublisherName,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+			},
+			check: func(res *catalogv1.Book, err error) {
+				requireCode(t, codes.InvalidArgument, err)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			store := mockdb.NewMockStore(t)
+			tc.buildStubs(store)
+
+			client := newTestClient(t, store)
+			res, err := client.CreateBook(context.Background(), tc.req)
+			tc.check(res, err)
+		})
+	}
+}
+
+func TestGetBook(t *testing.T) {
+	book := randomBook(t)
+
+	testCases := []struct {
+		name       string
+		buildStubs func(store *mockdb.MockStore)
+		check      func(res *catalogv1.Book, err error)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetBookByISBN(mock.Anything, mock.MatchedBy(func(arg db.GetBookByISBNParams) bool {
+					return arg.Isbn13.String == book.Isbn13.String
+				})).Return(db.GetBookByISBNRow{
+					Book:         book,
+					Authors:      "Zed Zulu",
+					Contributors: "author:Zed Zulu,illustrator:Amy Adams",
+				}, nil)
+			},
+			check: func(res *catalogv1.Book, err error) {
+				require.NoError(t, err)
+				require.Equal(t, book.Isbn10.String, res.GetIsbn10())
+				require.Equal(t, []string{"Zed Zulu"}, res.GetAuthors())
+				require.Len(t, res.GetContributors(), 2)
+				require.Equal(t, "illustrator", res.GetContributors()[1].GetRole())
+				require.Equal(t, book.Version, res.GetVersion())
+				require.Nil(t, res.GetDeletedAt())
+			},
+		},
+		{
+			name: "NotFound",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetBookByISBN(mock.Anything, mock.Anything).Return(db.GetBookByISBNRow{}, db.ErrRecordNotFound)
+			},
+			check: func(res *catalogv1.Book, err error) {
+				requireCode(t, codes.NotFound, err)
+			},
+		},
+		{
+			name: "InternalError",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetBookByISBN(mock.Anything, mock.Anything).Return(db.GetBookByISBNRow{}, sql.ErrConnDone)
+			},
+			check: func(res *catalogv1.Book, err error) {
+				requireCode(t, codes.Internal, err)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			store := mockdb.NewMockStore(t)
+			tc.buildStubs(store)
+
+			client := newTestClient(t, store)
+			res, err := client.GetBook(context.Background(), &catalogv1.GetBookRequest{Isbn13: book.Isbn13.String})
+			tc.check(res, err)
+		})
+	}
+}
+
+func TestGetDeletedBook(t *testing.T) {
+	book := randomBook(t)
+	book.DeletedAt = sql.NullTime{Time: time.Now(), Valid: true}
+
+	testCases := []struct {
+		name       string
+		apiKey     string
+		buildStubs func(store *mockdb.MockStore)
+		check      func(res *catalogv1.Book, err error)
+	}{
+		{
+			name:   "Admin",
+			apiKey: testAdminKey,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetBookByISBN(mock.Anything, mock.MatchedBy(func(arg db.GetBookByISBNParams) bool {
+					return arg.IncludeDeleted
+				})).Return(db.GetBookByISBNRow{Book: book}, nil)
+			},
+			check: func(res *catalogv1.Book, err error) {
+				require.NoError(t, err)
+				require.NotNil(t, res.GetDeletedAt())
+			},
+		},
+		{
+			name:       "NoKey",
+			buildStubs: func(store *mockdb.MockStore) {},
+			check: func(res *catalogv1.Book, err error) {
+				requireCode(t, codes.Unauthenticated, err)
+			},
+		},
+		{
+			name:       "OtherKey",
+			apiKey:     "other-key",
+			buildStubs: func(store *mockdb.MockStore) {},
+			check: func(res *catalogv1.Book, err error) {
+				requireCode(t, codes.Unauthenticated, err)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			store := mockdb.NewMockStore(t)
+			tc.buildStubs(store)
+
+			ctx := context.Background()
+			if len(tc.apiKey) > 0 {
+				ctx = metadata.AppendToOutgoingContext(ctx, auth.APIKeyHeader, tc.apiKey)
+			}
+
+			client := newTestClient(t, store)
+			res, err := client.GetBook(ctx, &catalogv1.GetBookRequest{Isbn13: book.Isbn13.String, IncludeDeleted: true})
+			tc.check(res, err)
+		})
+	}
+}
+
+func TestListBooks(t *testing.T) {
+	n := 3
+	rows := make([]db.ListBooksRow, n)
+	for i := range rows {
+		rows[i] = db.ListBooksRow{Book: randomBook(t)}
+	}
+	minPrice := 100.0
+
+	testCases := []struct {
+		name       string
+		req        *catalogv1.ListBooksRequest
+		buildStubs func(store *mockdb.MockStore)
+		check      func(res *catalogv1.ListBooksResponse, err error)
+	}{
+		{
+			name: "Defaults",
+			req:  &catalogv1.ListBooksRequest{},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListBooks(mock.Anything, mock.MatchedBy(func(arg db.ListBooksParams) bool {
+					return arg.Limit == 5 && arg.Offset == 0 && !arg.MinPrice.Valid && !arg.MinPublicationYear.Valid
+				})).Return(rows, nil)
+				store.EXPECT().CountBooks(mock.Anything, mock.Anything).Return(int64(n), nil)
+			},
+			check: func(res *catalogv1.ListBooksResponse, err error) {
+				require.NoError(t, err)
+				require.Len(t, res.GetItems(), n)
+				require.Equal(t, int32(1), res.GetPage().GetCurrentPage())
+				require.Equal(t, int32(n), res.GetPage().GetTotalItems())
+			},
+		},
+		{
+			name: "Filters",
+			req:  &catalogv1.ListBooksRequest{MinPrice: &minPrice, Author: "Zulu", Page: 2, PerPage: 10},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListBooks(mock.Anything, mock.MatchedBy(func(arg db.ListBooksParams) bool {
+					return arg.Limit == 10 && arg.Offset == 10 && arg.MinPrice.Valid && arg.MinPrice.Float64 == minPrice &&
+						arg.Author.String == "Zulu"
+				})).Return([]db.ListBooksRow{}, nil)
+				store.EXPECT().CountBooks(mock.Anything, mock.Anything).Return(int64(n), nil)
+			},
+			check: func(res *catalogv1.ListBooksResponse, err error) {
+				require.NoError(t, err)
+				require.Empty(t, res.GetItems())
+				require.Equal(t, int32(2), res.GetPage().GetCurrentPage())
+			},
+		},
+		{
+			name: "InvalidPerPage",
+			req:  &catalogv1.ListBooksRequest{PerPage: 100},
+			buildStubs: func(store *mockdb.MockStore) {
+			},
+			check: func(res *catalogv1.ListBooksResponse, err error) {
+				requireCode(t, codes.InvalidArgument, err)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			store := mockdb.NewMockStore(t)
+			tc.buildStubs(store)
+
+			client := newTestClient(t, store)
+			res, err := client.ListBooks(context.Background(), tc.req)
+			tc.check(res, err)
+		})
+	}
+}
+
+func TestUpdateBook(t *testing.T) {
+	book := randomBook(t)
+
+	testCases := []struct {
+		name       string
+		req        *catalogv1.UpdateBookRequest
+		buildStubs func(store *mockdb.MockStore)
+		check      func(res *catalogv1.Book, err error)
+	}{
+		{
+			name: "OK",
+			req:  &catalogv1.UpdateBookRequest{Isbn13: book.Isbn13.String, Version: 1, Title: "New Title"},
+			buildStubs: func(store *mockdb.MockStore) {
+				updated := book
+				updated.Title = "New Title"
+				updated.Version = 2
+				store.EXPECT().UpdateBookTx(mock.Anything, mock.MatchedBy(func(arg db.UpdateBookTxParams) bool {
+					return arg.Book.Version.Int64 == 1 && arg.Book.Title.String == "New Title"
+				})).Return(updated, nil)
+				store.EXPECT().GetBookByISBN(mock.Anything, mock.Anything).Return(db.GetBookByISBNRow{Book: updated}, nil)
+			},
+			check: func(res *catalogv1.Book, err error) {
+				require.NoError(t, err)
+				require.Equal(t, "New Title", res.GetTitle())
+				require.Equal(t, int64(2), res.GetVersion())
+			},
+		},
+		{
+			name: "NoVersion",
+			req:  &catalogv1.UpdateBookRequest{Isbn13: book.Isbn13.String, Title: "New Title"},
+			buildStubs: func(store *mockdb.MockStore) {
+			},
+			check: func(res *catalogv1.Book, err error) {
+				requireCode(t, codes.InvalidArgument, err)
+			},
+		},
+		{
+			name: "VersionChanged",
+			req:  &catalogv1.UpdateBookRequest{Isbn13: book.Isbn13.String, Version: 1, Title: "New Title"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateBookTx(mock.Anything, mock.Anything).Return(db.Book{}, db.ErrVersionChanged)
+			},
+			check: func(res *catalogv1.Book, err error) {
+				requireCode(t, codes.FailedPrecondition, err)
+			},
+		},
+		{
+			name: "NotFound",
+			req:  &catalogv1.UpdateBookRequest{Isbn13: book.Isbn13.String, Version: 1, Title: "New Title"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateBookTx(mock.Anything, mock.Anything).Return(db.Book{}, db.ErrRecordNotFound)
+			},
+			check: func(res *catalogv1.Book, err error) {
+				requireCode(t, codes.NotFound, err)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			store := mockdb.NewMockStore(t)
+			tc.buildStubs(store)
+
+			client := newTestClient(t, store)
+			res, err := client.UpdateBook(context.Background(), tc.req)
+			tc.check(res, err)
+		})
+	}
+}
+
+func TestDeleteBook(t *testing.T) {
+	book := randomBook(t)
+
+	store := mockdb.NewMockStore(t)
+	expectExecTx(store)
+	store.EXPECT().DeleteBookByISBN(mock.Anything, mock.MatchedBy(func(arg db.DeleteBookByISBNParams) bool {
+		return arg.Isbn13.String == book.Isbn13.String && arg.Version.Int64 == 1
+	})).Return(1, nil)
+
+	client := newTestClient(t, store)
+	_, err := client.DeleteBook(context.Background(), &catalogv1.DeleteBookRequest{Isbn13: book.Isbn13.String, Version: 1})
+	require.NoError(t, err)
+}
@@ -0,0 +1,93 @@
+package rpc
+
+import (
+	"context"
+	"database/sql"
+	"net"
+	"testing"
+
+	catalogv1 "github.com/atsuyaourt/xyz-books/api/catalog/v1"
+	"github.com/atsuyaourt/xyz-books/internal/auth"
+	db "github.com/atsuyaourt/xyz-books/internal/db/sqlc"
+	mockdb "github.com/atsuyaourt/xyz-books/internal/mocks/db"
+	"github.com/atsuyaourt/xyz-books/internal/util"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+const testAdminKey = "test-admin-key"
+
+// newTestConn serves the catalog over an in-memory listener and returns a
+// connection to it
+func newTestConn(t *testing.T, store db.Store) *grpc.ClientConn {
+	catalog, err := NewCatalogServer(store)
+	require.NoError(t, err)
+
+	lis := bufconn.Listen(1 << 20)
+	server := NewGRPCServer(catalog, grpc.UnaryInterceptor(AdminInterceptor(auth.NewKeys([]string{testAdminKey}))))
+	go server.Serve(lis)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return conn
+}
+
+func newTestClient(t *testing.T, store db.Store) catalogv1.CatalogServiceClient {
+	return catalogv1.NewCatalogServiceClient(newTestConn(t, store))
+}
+
+// expectExecTx runs the transactions of the service against the mock itself
+func expectExecTx(store *mockdb.MockStore) {
+	store.EXPECT().ExecTx(mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, fn func(db.Querier) error) error {
+			return fn(store)
+		})
+}
+
+func requireCode(t *testing.T, code codes.Code, err error) {
+	require.Error(t, err)
+	require.Equal(t, code, status.Code(err), err.Error())
+}
+
+func randomBook(t *testing.T) db.Book {
+	isbn := util.NewISBN(util.RandomISBN13())
+	return db.Book{
+		BookID:          util.RandomInt(1, 111),
+		Title:           util.RandomString(24),
+		Isbn13:          sql.NullString{String: isbn.ISBN13, Valid: true},
+		Isbn10:          sql.NullString{String: isbn.ISBN10, Valid: true},
+		Price:           float64(util.RandomFloat(10.0, 1500.0)),
+		PublicationYear: util.RandomInt(1000, 9999),
+		Version:         1,
+	}
+}
+
+func randomAuthor(t *testing.T) db.Author {
+	return db.Author{
+		AuthorID:  util.RandomInt(1, 111),
+		FirstName: util.RandomString(12),
+		LastName:  util.RandomString(16),
+		Version:   1,
+	}
+}
+
+func randomPublisher(t *testing.T) db.Publisher {
+	return db.Publisher{
+		PublisherID:   util.RandomInt(1, 111),
+		PublisherName: util.RandomString(24),
+		Version:       1,
+	}
+}
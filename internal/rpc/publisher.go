@@ -0,0 +1,108 @@
+package rpc
+
+import (
+	"context"
+
+	catalogv1 "github.com/atsuyaourt/xyz-books/api/catalog/v1"
+	"github.com/atsuyaourt/xyz-books/internal/models"
+	"github.com/atsuyaourt/xyz-books/internal/services"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const publisherNotFound = "publisher not found"
+
+func newPublisher(publisher *models.Publisher) *catalogv1.Publisher {
+	return &catalogv1.Publisher{
+		Id:        publisher.ID,
+		Name:      publisher.PublisherName,
+		CreatedAt: timestamppb.New(publisher.CreatedAt),
+		UpdatedAt: timestamppb.New(publisher.UpdatedAt),
+		DeletedAt: newTimestamp(publisher.DeletedAt),
+		Version:   publisher.Version,
+	}
+}
+
+func (s *CatalogServer) CreatePublisher(ctx context.Context, in *catalogv1.CreatePublisherRequest) (*catalogv1.Publisher, error) {
+	req := services.CreatePublisherReq{PublisherName: in.GetName()}
+	if err := validate(req); err != nil {
+		return nil, err
+	}
+
+	publisher, err := s.service.CreatePublisher(ctx, req)
+	if err != nil {
+		return nil, statusError(err, publisherNotFound)
+	}
+
+	return newPublisher(publisher), nil
+}
+
+func (s *CatalogServer) GetPublisher(ctx context.Context, in *catalogv1.GetPublisherRequest) (*catalogv1.Publisher, error) {
+	publisher, err := s.service.GetPublisher(ctx, in.GetId(), in.GetIncludeDeleted())
+	if err != nil {
+		return nil, statusError(err, publisherNotFound)
+	}
+
+	return newPublisher(publisher), nil
+}
+
+func (s *CatalogServer) ListPublishers(ctx context.Context, in *catalogv1.ListPublishersRequest) (*catalogv1.ListPublishersResponse, error) {
+	req := services.ListPublishersReq{
+		UpdatedSince:   since(in.GetUpdatedSince()),
+		IncludeDeleted: in.GetIncludeDeleted(),
+	}
+	req.Page, req.PerPage = pageOrDefault(in.GetPage(), in.GetPerPage())
+	if err := validate(req); err != nil {
+		return nil, err
+	}
+
+	publishers, err := s.service.ListPublishers(ctx, req)
+	if err != nil {
+		return nil, statusError(err, publisherNotFound)
+	}
+
+	items := make([]*catalogv1.Publisher, len(publishers.Items))
+	for i := range publishers.Items {
+		items[i] = newPublisher(&publishers.Items[i])
+	}
+
+	return &catalogv1.ListPublishersResponse{Items: items, Page: newPage(publishers)}, nil
+}
+
+func (s *CatalogServer) UpdatePublisher(ctx context.Context, in *catalogv1.UpdatePublisherRequest) (*catalogv1.Publisher, error) {
+	if in.GetVersion() < 1 {
+		return nil, errVersionRequired
+	}
+
+	req := services.UpdatePublisherReq{PublisherName: in.GetName()}
+	if err := validate(req); err != nil {
+		return nil, err
+	}
+
+	publisher, err := s.service.UpdatePublisher(ctx, in.GetId(), in.GetVersion(), req)
+	if err != nil {
+		return nil, statusError(err, publisherNotFound)
+	}
+
+	return newPublisher(publisher), nil
+}
+
+func (s *CatalogServer) DeletePublisher(ctx context.Context, in *catalogv1.DeletePublisherRequest) (*catalogv1.DeleteResponse, error) {
+	if in.GetVersion() < 1 {
+		return nil, errVersionRequired
+	}
+
+	if err := s.service.DeletePublisher(ctx, in.GetId(), in.GetVersion()); err != nil {
+		return nil, statusError(err, publisherNotFound)
+	}
+
+	return &catalogv1.DeleteResponse{}, nil
+}
+
+func (s *CatalogServer) RestorePublisher(ctx context.Context, in *catalogv1.RestorePublisherRequest) (*catalogv1.Publisher, error) {
+	publisher, err := s.service.RestorePublisher(ctx, in.GetId())
+	if err != nil {
+		return nil, statusError(err, publisherNotFound)
+	}
+
+	return newPublisher(publisher), nil
+}
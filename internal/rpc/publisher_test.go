@@ -0,0 +1,57 @@
+package rpc
+
+import (
+	"context"
+	"testing"
+
+	catalogv1 "github.com/atsuyaourt/xyz-books/api/catalog/v1"
+	"github.com/atsuyaourt/xyz-books/internal/auth"
+	db "github.com/atsuyaourt/xyz-books/internal/db/sqlc"
+	mockdb "github.com/atsuyaourt/xyz-books/internal/mocks/db"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestCreatePublisher(t *testing.T) {
+	publisher := randomPublisher(t)
+
+	store := mockdb.NewMockStore(t)
+	store.EXPECT().CreatePublisher(mock.Anything, publisher.PublisherName).Return(publisher, nil)
+
+	client := newTestClient(t, store)
+	res, err := client.CreatePublisher(context.Background(), &catalogv1.CreatePublisherRequest{Name: publisher.PublisherName})
+	require.NoError(t, err)
+	require.Equal(t, publisher.PublisherID, res.GetId())
+	require.Equal(t, publisher.PublisherName, res.GetName())
+
+	_, err = client.CreatePublisher(context.Background(), &catalogv1.CreatePublisherRequest{})
+	requireCode(t, codes.InvalidArgument, err)
+}
+
+func TestListPublishers(t *testing.T) {
+	n := 5
+	publishers := make([]db.Publisher, n)
+	for i := range publishers {
+		publishers[i] = randomPublisher(t)
+	}
+
+	store := mockdb.NewMockStore(t)
+	store.EXPECT().ListPublishers(mock.Anything, mock.MatchedBy(func(arg db.ListPublishersParams) bool {
+		return arg.Limit == 5 && arg.Offset == 5 && arg.IncludeDeleted
+	})).Return(publishers, nil)
+	store.EXPECT().CountPublishers(mock.Anything, mock.Anything).Return(int64(2*n), nil)
+
+	// only admins list deleted publishers
+	ctx := metadata.AppendToOutgoingContext(context.Background(), auth.APIKeyHeader, testAdminKey)
+	client := newTestClient(t, store)
+	res, err := client.ListPublishers(ctx, &catalogv1.ListPublishersRequest{
+		IncludeDeleted: true,
+		Page:           2,
+	})
+	require.NoError(t, err)
+	require.Len(t, res.GetItems(), n)
+	require.Equal(t, int32(2), res.GetPage().GetTotalPages())
+	require.Equal(t, int32(1), res.GetPage().GetPrevPage())
+}
@@ -0,0 +1,136 @@
+// Package rpc serves the catalog over gRPC, with the service definition and
+// the generated client in api/catalog/v1.
+package rpc
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	catalogv1 "github.com/atsuyaourt/xyz-books/api/catalog/v1"
+	"github.com/atsuyaourt/xyz-books/internal/auth"
+	db "github.com/atsuyaourt/xyz-books/internal/db/sqlc"
+	"github.com/atsuyaourt/xyz-books/internal/services"
+	"github.com/atsuyaourt/xyz-books/internal/util"
+	"github.com/gin-gonic/gin/binding"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var (
+	errVersionRequired = status.Error(codes.InvalidArgument, "version is required, fetch the record first")
+	errVersionMismatch = status.Error(codes.FailedPrecondition, "record was changed, fetch it again")
+)
+
+// CatalogServer implements catalogv1.CatalogServiceServer over the same
+// service as the JSON API
+type CatalogServer struct {
+	catalogv1.UnimplementedCatalogServiceServer
+	service services.Service
+}
+
+func NewCatalogServer(store db.Store, opts ...services.Option) (*CatalogServer, error) {
+	s, err := services.NewDefaultService(store, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CatalogServer{service: s}, nil
+}
+
+// NewGRPCServer registers the catalog and server reflection, so that tools
+// like grpcurl can list the methods
+func NewGRPCServer(catalog catalogv1.CatalogServiceServer, opts ...grpc.ServerOption) *grpc.Server {
+	s := grpc.NewServer(opts...)
+	catalogv1.RegisterCatalogServiceServer(s, catalog)
+	reflection.Register(s)
+
+	return s
+}
+
+// AdminInterceptor marks the calls made with one of adminKeys in the
+// x-api-key metadata as made by an admin, like auth.Middleware does for HTTP
+func AdminInterceptor(adminKeys auth.Keys) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		if keys := md.Get(auth.APIKeyHeader); len(keys) > 0 && adminKeys.Contains(keys[0]) {
+			ctx = auth.NewContext(ctx, true)
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// validate checks a service request against its binding tags, the way the
+// JSON handlers do
+func validate(req any) error {
+	if err := binding.Validator.ValidateStruct(req); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return nil
+}
+
+// statusError maps the errors of the service to status codes, not found
+// errors get the given message
+func statusError(err error, notFound string) error {
+	switch {
+	case errors.Is(err, db.ErrRecordNotFound):
+		return status.Error(codes.NotFound, notFound)
+	case errors.Is(err, db.ErrVersionChanged):
+		return errVersionMismatch
+	case errors.Is(err, db.ErrRecordDeleted):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, db.ErrNotDeleted):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, auth.ErrAdminRequired):
+		return status.Error(codes.Unauthenticated, err.Error())
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return status.FromContextError(err).Err()
+	}
+
+	return status.Error(codes.Internal, err.Error())
+}
+
+func newPage[T any](list *util.PaginatedList[T]) *catalogv1.Page {
+	return &catalogv1.Page{
+		CurrentPage: list.CurrentPage,
+		PerPage:     list.PerPage,
+		TotalPages:  list.TotalPages,
+		NextPage:    list.NextPage,
+		PrevPage:    list.PrevPage,
+		TotalItems:  list.TotalItems,
+	}
+}
+
+// pageOrDefault takes the defaults of the JSON API for unset page fields
+func pageOrDefault(page, perPage int32) (int32, int32) {
+	if page == 0 {
+		page = 1
+	}
+	if perPage == 0 {
+		perPage = 5
+	}
+
+	return page, perPage
+}
+
+func newTimestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+
+	return timestamppb.New(*t)
+}
+
+func since(t *timestamppb.Timestamp) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+
+	return t.AsTime()
+}
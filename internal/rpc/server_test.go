@@ -0,0 +1,31 @@
+package rpc
+
+import (
+	"context"
+	"testing"
+
+	mockdb "github.com/atsuyaourt/xyz-books/internal/mocks/db"
+	"github.com/stretchr/testify/require"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+)
+
+func TestReflection(t *testing.T) {
+	conn := newTestConn(t, mockdb.NewMockStore(t))
+
+	stream, err := reflectionpb.NewServerReflectionClient(conn).ServerReflectionInfo(context.Background())
+	require.NoError(t, err)
+
+	err = stream.Send(&reflectionpb.ServerReflectionRequest{
+		MessageRequest: &reflectionpb.ServerReflectionRequest_ListServices{},
+	})
+	require.NoError(t, err)
+
+	res, err := stream.Recv()
+	require.NoError(t, err)
+
+	var names []string
+	for _, s := range res.GetListServicesResponse().GetService() {
+		names = append(names, s.GetName())
+	}
+	require.Contains(t, names, "catalog.v1.CatalogService")
+}
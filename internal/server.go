@@ -2,13 +2,35 @@ package internal
 
 import (
 	"context"
-	"log"
+	"fmt"
+	"log/slog"
+	"net"
 	"net/http"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/atsuyaourt/xyz-books/internal/auth"
+	"github.com/atsuyaourt/xyz-books/internal/cache"
+	sqlitemigrations "github.com/atsuyaourt/xyz-books/internal/db/migrations"
+	postgresmigrations "github.com/atsuyaourt/xyz-books/internal/db/postgres/migrations"
 	db "github.com/atsuyaourt/xyz-books/internal/db/sqlc"
 	"github.com/atsuyaourt/xyz-books/internal/handlers"
+	"github.com/atsuyaourt/xyz-books/internal/logging"
+	"github.com/atsuyaourt/xyz-books/internal/metrics"
+	"github.com/atsuyaourt/xyz-books/internal/openapi"
+	"github.com/atsuyaourt/xyz-books/internal/ratelimit"
+	"github.com/atsuyaourt/xyz-books/internal/rpc"
+	"github.com/atsuyaourt/xyz-books/internal/services"
+	"github.com/atsuyaourt/xyz-books/internal/storage"
+	"github.com/atsuyaourt/xyz-books/internal/tracing"
 	"github.com/atsuyaourt/xyz-books/internal/util"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/prometheus/client_golang/prometheus"
 	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
@@ -17,40 +39,183 @@ import (
 )
 
 type Server struct {
-	config  util.Config
-	router  *gin.Engine
-	store   db.Store
-	handler handlers.Handler
+	config   util.Config
+	router   *gin.Engine
+	store    db.Store
+	handler  handlers.Handler
+	webhooks *services.WebhookWorker
+	events   *services.EventBus
+	grpc     *grpc.Server
+	openapi  map[string]*openapi3.T // documents of the API versions
+	// v1 of the API is deprecated since, and removed at the sunset when set
+	v1DeprecatedAt time.Time
+	v1Sunset       time.Time
+	limiter        *ratelimit.Limiter
+	limits         map[string]ratelimit.Limit // rate limits of the API route groups
+	cache          *cache.LRU                 // book reads, nil when not cached
+	metrics        *prometheus.Registry
+	// the last migration the server was built with, the database is not
+	// ready until it is migrated to it
+	schemaVersion uint
 }
 
+// the API route groups with their own rate limits
+const (
+	rateLimitAPI    = "api"
+	rateLimitBooks  = "books"
+	rateLimitOrders = "orders"
+)
+
+// v2ReleasedAt is when v2 of the API was released, v1 is deprecated since
+// unless API_V1_DEPRECATED_AT says otherwise
+var v2ReleasedAt = time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC)
+
 // NewServer creates a new HTTP server and setup routing
 func NewServer(config util.Config, store db.Store) (*Server, error) {
 	server := &Server{
-		config: config,
-		store:  store,
+		config:         config,
+		store:          store,
+		openapi:        make(map[string]*openapi3.T),
+		v1DeprecatedAt: v2ReleasedAt,
+	}
+	if len(config.APIV1DeprecatedAt) > 0 {
+		deprecatedAt, err := time.Parse(time.DateOnly, config.APIV1DeprecatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("invalid API_V1_DEPRECATED_AT: %w", err)
+		}
+		server.v1DeprecatedAt = deprecatedAt
+	}
+	if len(config.APIV1Sunset) > 0 {
+		sunset, err := time.Parse(time.DateOnly, config.APIV1Sunset)
+		if err != nil {
+			return nil, fmt.Errorf("invalid API_V1_SUNSET: %w", err)
+		}
+		server.v1Sunset = sunset
+	}
+
+	migrations := sqlitemigrations.FS
+	if config.DBDriver == util.DBDriverPostgres {
+		migrations = postgresmigrations.FS
+	}
+	schemaVersion, err := util.LatestMigration(migrations)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read migrations: %w", err)
+	}
+	server.schemaVersion = schemaVersion
+
+	limits, err := rateLimits(config)
+	if err != nil {
+		return nil, err
 	}
+	server.limits = limits
+	// admins are clients too
+	clientKeys := auth.NewKeys(slices.Concat(config.APIKeys, config.AdminAPIKeys))
+	server.limiter = ratelimit.NewLimiter(ratelimit.NewMemoryStore(), clientKeys)
+
 	gin.SetMode(config.GinMode)
-	server.router = gin.Default()
+	// services and the store get the context of the gin request, which
+	// carries its logger
+	server.router = gin.New()
+	server.router.ContextWithFallback = true
+	// rate limits tell clients apart by IP, which is only read from
+	// X-Forwarded-For when the request comes through one of the proxies
+	if err := server.router.SetTrustedProxies(config.TrustedProxies); err != nil {
+		return nil, fmt.Errorf("invalid TRUSTED_PROXIES: %w", err)
+	}
+	server.router.Use(tracing.Middleware(), logging.Middleware(slog.Default()), logging.Recovery(), metrics.Middleware(),
+		auth.Middleware(auth.NewKeys(config.AdminAPIKeys), clientKeys))
+	server.metrics = metrics.NewRegistry()
+
+	opts := []services.Option{}
+	if len(config.BlobStorePath) > 0 {
+		opts = append(opts, services.WithBlobStore(storage.NewLocalStore(config.BlobStorePath)))
+	}
+	if config.CoverMaxSize > 0 {
+		opts = append(opts, services.WithCoverMaxSize(config.CoverMaxSize))
+	}
+	opts = append(opts, services.WithEventOutbox(services.NewWebhookPublisher()))
+	if config.CacheSize > 0 {
+		server.cache = cache.NewLRU(config.CacheSize, config.CacheTTL)
+		opts = append(opts, services.WithCache(server.cache))
+		server.metrics.MustRegister(metrics.NewCacheCollector("books", server.cache.Stats))
+	}
+
+	server.events = services.NewEventBus()
+	opts = append(opts, services.WithEventBus(server.events))
 
-	handler, err := handlers.NewDefaultHandler(store)
+	server.webhooks = services.NewWebhookWorker(store, services.WebhookWorkerOptions{
+		PollInterval: config.WebhookPollInterval,
+		MaxAttempts:  config.WebhookMaxAttempts,
+		RetryDelay:   config.WebhookRetryDelay,
+		Timeout:      config.WebhookTimeout,
+	})
+
+	handler, err := handlers.NewDefaultHandler(store, opts...)
 	if err != nil {
 		return nil, err
 	}
 	server.handler = handler
 
+	catalog, err := rpc.NewCatalogServer(store, opts...)
+	if err != nil {
+		return nil, err
+	}
+	server.grpc = rpc.NewGRPCServer(catalog,
+		grpc.UnaryInterceptor(rpc.AdminInterceptor(auth.NewKeys(config.AdminAPIKeys))))
+
 	server.setupCORS()
 	server.setupRouter()
-	server.setupAPIRouter()
+	if err := server.setupAPIRouter(); err != nil {
+		return nil, err
+	}
 
 	return server, nil
 }
 
+// rateLimits reads the rate limits of the API route groups, which default
+// to RATE_LIMIT. Groups without a limit are not limited.
+func rateLimits(config util.Config) (map[string]ratelimit.Limit, error) {
+	limits := make(map[string]ratelimit.Limit)
+	for group, limit := range map[string]string{
+		rateLimitAPI:    config.RateLimit,
+		rateLimitBooks:  config.RateLimitBooks,
+		rateLimitOrders: config.RateLimitOrders,
+	} {
+		if len(limit) == 0 {
+			limit = config.RateLimit
+		}
+		if len(limit) == 0 {
+			continue
+		}
+
+		l, err := ratelimit.ParseLimit(limit)
+		if err != nil {
+			return nil, fmt.Errorf("rate limit of %s: %w", group, err)
+		}
+		limits[group] = l
+	}
+
+	return limits, nil
+}
+
+// rateLimit returns the middleware limiting the requests to a route group
+func (s *Server) rateLimit(group string) gin.HandlerFunc {
+	limit, ok := s.limits[group]
+	if !ok {
+		return func(ctx *gin.Context) { ctx.Next() }
+	}
+
+	return s.limiter.Limit(group, limit)
+}
+
 func (s *Server) setupCORS() {
 	corsConfig := cors.DefaultConfig()
 	corsConfig.AllowAllOrigins = true
 	corsConfig.AllowCredentials = true
 	corsConfig.AddAllowMethods("OPTIONS")
-	corsConfig.AddAllowHeaders("Authorization")
+	corsConfig.AddAllowHeaders("Authorization", auth.APIKeyHeader, logging.RequestIDHeader, "traceparent", "tracestate")
+	corsConfig.AddExposeHeaders(logging.RequestIDHeader, "Deprecation", "Sunset", "Link",
+		"RateLimit-Policy", "RateLimit-Limit", "RateLimit-Remaining", "RateLimit-Reset", "Retry-After")
 	s.router.Use(cors.New(corsConfig))
 }
 
@@ -58,65 +223,273 @@ func (s *Server) setupRouter() {
 	r := s.router
 
 	r.Static("/assets", "internal/assets")
-	r.GET("/", s.handler.Index)
-	r.GET("/:isbn", s.handler.ShowBook)
+	// the pages of the catalog read the books like the API does
+	books := s.rateLimit(rateLimitBooks)
+	catalog := s.catalogCacheControl()
+	r.GET("/", books, catalog, s.handler.Index)
+	r.GET("/:isbn", books, catalog, s.handler.ShowBook)
+
+	r.GET("/books", books, catalog, s.handler.ShowBooks)
+	r.GET("/books/:isbn", books, catalog, s.handler.ShowBook)
+	r.GET("/books/:isbn/cover.svg", s.handler.ShowCoverSVG)
+	r.GET("/books/:isbn/cover.png", s.handler.ShowCoverPNG)
+	r.GET("/covers/*name", s.handler.ShowCover)
+
+	private := cacheControl("no-store")
+	r.GET("/cart", private, s.handler.ShowCart)
+	r.POST("/cart/items", s.handler.AddToCart)
+	r.PUT("/cart/items/:isbn", s.handler.ChangeCartItem)
+	r.DELETE("/cart/items/:isbn", s.handler.RemoveFromCart)
 
-	r.GET("/books", s.handler.ShowBooks)
-	r.GET("/books/:isbn", s.handler.ShowBook)
+	r.GET("/checkout", private, s.handler.ShowCheckout)
+	r.POST("/checkout", s.handler.SubmitCheckout)
+	r.GET("/orders/:id", private, s.handler.ShowOrder)
+
+	r.GET("/events", s.handler.StreamEvents)
+	r.POST("/graphql", books, s.handler.GraphQL)
+
+	r.GET("/metrics", gin.WrapH(metrics.Handler(s.metrics)))
+
+	probes := r.Group("", private)
+	{
+		probes.GET("/healthz", s.healthz)
+		probes.GET("/readyz", s.readyz)
+		probes.GET("/version", s.version)
+	}
+}
+
+// cacheControlWriter keeps error responses from being stored
+type cacheControlWriter struct {
+	gin.ResponseWriter
 }
 
-func (s *Server) setupAPIRouter() {
-	api := s.router.Group(s.config.APIBasePath)
+func (w cacheControlWriter) WriteHeader(code int) {
+	if code >= http.StatusBadRequest {
+		w.Header().Set("Cache-Control", "no-store")
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+// cacheControl sets the Cache-Control header of the GET responses of a route,
+// handlers may still set their own
+func cacheControl(value string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if ctx.Request.Method != http.MethodGet && ctx.Request.Method != http.MethodHead {
+			return
+		}
+
+		ctx.Header("Cache-Control", value)
+		ctx.Writer = cacheControlWriter{ctx.Writer}
+	}
+}
 
-	books := api.Group("/books")
+// catalogCacheControl lets clients reuse the reads of the catalog for
+// HTTP_CACHE_MAX_AGE, or makes them revalidate every time, which is cheap
+// for reads with an ETag
+func (s *Server) catalogCacheControl() gin.HandlerFunc {
+	if s.config.HTTPCacheMaxAge <= 0 {
+		return cacheControl("no-cache")
+	}
+
+	return cacheControl("public, max-age=" + strconv.Itoa(int(s.config.HTTPCacheMaxAge.Seconds())))
+}
+
+// apiGroup mounts a version of the API, e.g. v1, at its base path. Its
+// OpenAPI document is served at openapi.json and checked on every request.
+func (s *Server) apiGroup(version, basePath string, middleware ...gin.HandlerFunc) (*gin.RouterGroup, error) {
+	doc, err := openapi.Load(version)
+	if err != nil {
+		return nil, err
+	}
+	doc.Servers = openapi3.Servers{{URL: basePath}}
+	s.openapi[version] = doc
+
+	// responses are only checked in tests, as they have to be buffered
+	var opts []openapi.ValidatorOption
+	if s.config.GinMode == gin.TestMode {
+		opts = append(opts, openapi.WithResponseValidation())
+	}
+	validator, err := openapi.Validator(doc, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	api := s.router.Group(basePath, append(middleware, validator)...)
+	api.GET("/openapi.json", openapi.Handler(doc))
+
+	return api, nil
+}
+
+// v2BasePath is where v2 of the API is mounted, next to v1 unless
+// API_V2_BASE_PATH is set
+func (s *Server) v2BasePath() string {
+	if len(s.config.APIV2BasePath) > 0 {
+		return s.config.APIV2BasePath
+	}
+
+	return path.Join(path.Dir(path.Clean("/"+s.config.APIBasePath)), "v2")
+}
+
+// deprecated marks the responses of a deprecated route (RFC 9745), with the
+// date it will be removed when known (RFC 8594) and a link to the same
+// resource in the version that replaces it
+func deprecated(since, sunset time.Time, basePath, successorBasePath string) gin.HandlerFunc {
+	deprecation := "@" + strconv.FormatInt(since.Unix(), 10)
+
+	return func(ctx *gin.Context) {
+		ctx.Header("Deprecation", deprecation)
+		if !sunset.IsZero() {
+			ctx.Header("Sunset", sunset.UTC().Format(http.TimeFormat))
+		}
+		successor := path.Join(successorBasePath, strings.TrimPrefix(ctx.Request.URL.Path, basePath))
+		ctx.Header("Link", fmt.Sprintf(`<%s>; rel="successor-version"`, successor))
+	}
+}
+
+func (s *Server) setupAPIRouter() error {
+	v1, err := s.apiGroup("v1", s.config.APIBasePath)
+	if err != nil {
+		return err
+	}
+	s.setupAPIV1Router(v1)
+
+	v2, err := s.apiGroup("v2", s.v2BasePath())
+	if err != nil {
+		return err
+	}
+	s.setupAPIV2Router(v2)
+
+	return nil
+}
+
+func (s *Server) setupAPIV1Router(api *gin.RouterGroup) {
+	// only the reads of the books have a successor in v2
+	replaced := deprecated(s.v1DeprecatedAt, s.v1Sunset, api.BasePath(), s.v2BasePath())
+
+	books := api.Group("/books", s.rateLimit(rateLimitBooks), s.catalogCacheControl())
 	{
-		books.GET("", s.handler.ListBooks)
-		books.GET(":isbn", s.handler.GetBook)
+		books.GET("", replaced, s.handler.ListBooks)
+		books.GET(":isbn", replaced, s.handler.GetBook)
 		books.POST("", s.handler.CreateBook)
 		books.PUT(":isbn", s.handler.UpdateBook)
+		books.PATCH(":isbn", s.handler.PatchBook)
 		books.DELETE(":isbn", s.handler.DeleteBook)
+		books.POST(":isbn/restore", s.handler.RestoreBook)
+		books.POST(":isbn/cover", s.handler.UploadBookCover)
+		books.DELETE(":isbn/cover", s.handler.DeleteBookCover)
 	}
 
-	authors := api.Group("/authors")
+	authors := api.Group("/authors", s.rateLimit(rateLimitAPI), s.catalogCacheControl())
 	{
 		authors.GET("", s.handler.ListAuthors)
 		authors.GET(":id", s.handler.GetAuthor)
 		authors.POST("", s.handler.CreateAuthor)
 		authors.PUT(":id", s.handler.UpdateAuthor)
+		authors.PATCH(":id", s.handler.PatchAuthor)
 		authors.DELETE(":id", s.handler.DeleteAuthor)
+		authors.POST(":id/restore", s.handler.RestoreAuthor)
 	}
 
-	publishers := api.Group("/publishers")
+	publishers := api.Group("/publishers", s.rateLimit(rateLimitAPI), s.catalogCacheControl())
 	{
 		publishers.GET("", s.handler.ListPublishers)
 		publishers.GET(":id", s.handler.GetPublisher)
 		publishers.POST("", s.handler.CreatePublisher)
 		publishers.PUT(":id", s.handler.UpdatePublisher)
+		publishers.PATCH(":id", s.handler.PatchPublisher)
 		publishers.DELETE(":id", s.handler.DeletePublisher)
+		publishers.POST(":id/restore", s.handler.RestorePublisher)
+	}
+
+	api.GET("/changes", s.rateLimit(rateLimitAPI), s.handler.ListChanges)
+
+	webhooks := api.Group("/webhooks", s.rateLimit(rateLimitAPI), auth.RequireAdmin())
+	{
+		webhooks.GET("", s.handler.ListWebhooks)
+		webhooks.POST("", s.handler.CreateWebhook)
+		webhooks.DELETE(":id", s.handler.DeleteWebhook)
+		webhooks.GET("/deliveries", s.handler.ListWebhookDeliveries)
+		webhooks.POST("/deliveries/:id/redeliver", s.handler.RedeliverWebhookDelivery)
+	}
+
+	cart := api.Group("/cart", s.rateLimit(rateLimitOrders), cacheControl("no-store"))
+	{
+		cart.GET("", s.handler.GetCart)
+		cart.POST("/items", s.handler.AddCartItem)
+		cart.PUT("/items/:isbn", s.handler.UpdateCartItem)
+		cart.DELETE("/items/:isbn", s.handler.RemoveCartItem)
+	}
+
+	orders := api.Group("/orders", s.rateLimit(rateLimitOrders), cacheControl("no-store"))
+	{
+		orders.GET("", s.handler.ListOrders)
+		orders.GET(":id", s.handler.GetOrder)
+		orders.POST("", s.handler.CreateOrder)
+	}
+
+	admin := api.Group("/admin", s.rateLimit(rateLimitAPI), auth.RequireAdmin(), cacheControl("no-store"))
+	{
+		admin.GET("/orders", s.handler.ListAllOrders)
+		admin.PUT("/orders/:id/status", s.handler.UpdateOrderStatus)
 	}
 
 	api.GET("/docs/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 }
 
+// setupAPIV2Router mounts the endpoints changed by v2, the others are still
+// served by v1
+func (s *Server) setupAPIV2Router(api *gin.RouterGroup) {
+	books := api.Group("/books", s.rateLimit(rateLimitBooks), s.catalogCacheControl())
+	{
+		books.GET("", s.handler.ListBooksV2)
+		books.GET(":isbn", s.handler.GetBookV2)
+	}
+}
+
 func (s *Server) Start(ctx context.Context, g *errgroup.Group) *http.Server {
 	srv := &http.Server{
 		Addr:    s.config.HTTPServerAddress,
 		Handler: s.router,
 	}
+	// event streams only finish when their subscription ends
+	srv.RegisterOnShutdown(s.events.Close)
 
 	g.Go(func() error {
-		log.Printf("starting gin server: %s", srv.Addr)
+		slog.Info("starting gin server", slog.String("address", srv.Addr))
 		err := srv.ListenAndServe()
 		if err != nil && err != http.ErrServerClosed {
-			log.Fatalf("failed to run gin server: %s", err)
+			slog.Error("failed to run gin server", slog.Any("error", err))
 			return err
 		}
 		return nil
 	})
 
+	g.Go(func() error {
+		return s.webhooks.Run(ctx)
+	})
+
+	if len(s.config.GRPCServerAddress) > 0 {
+		g.Go(func() error {
+			lis, err := net.Listen("tcp", s.config.GRPCServerAddress)
+			if err != nil {
+				slog.Error("cannot listen for grpc", slog.Any("error", err))
+				return err
+			}
+			slog.Info("starting grpc server", slog.String("address", lis.Addr().String()))
+			return s.grpc.Serve(lis)
+		})
+
+		g.Go(func() error {
+			<-ctx.Done()
+			slog.Info("shutting down grpc server")
+			s.grpc.GracefulStop()
+			return nil
+		})
+	}
+
 	g.Go(func() error {
 		<-ctx.Done()
-		log.Print("shutting down gin server")
+		slog.Info("shutting down gin server")
 		return srv.Shutdown(context.Background())
 	})
 
@@ -0,0 +1,837 @@
+package internal
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/atsuyaourt/xyz-books/internal/buildinfo"
+	db "github.com/atsuyaourt/xyz-books/internal/db/sqlc"
+	mockdb "github.com/atsuyaourt/xyz-books/internal/mocks/db"
+	"github.com/atsuyaourt/xyz-books/internal/models"
+	"github.com/atsuyaourt/xyz-books/internal/ratelimit"
+	"github.com/atsuyaourt/xyz-books/internal/tracing/tracingtest"
+	"github.com/atsuyaourt/xyz-books/internal/util"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	testAPIRoot     = "/api" // the API versions are mounted below it
+	testAPIBasePath = testAPIRoot + "/v1"
+	testAdminKey    = "test-admin-key"
+)
+
+func newTestServer(t *testing.T, store db.Store) *Server {
+	server, err := NewServer(util.Config{
+		GinMode:      gin.TestMode,
+		APIBasePath:  testAPIBasePath,
+		AdminAPIKeys: []string{testAdminKey},
+	}, store)
+	require.NoError(t, err)
+
+	return server
+}
+
+// TestOpenAPIRoutes fails when a route of an API version is not in its
+// OpenAPI document, or an operation of the document has no route
+func TestOpenAPIRoutes(t *testing.T) {
+	server := newTestServer(t, mockdb.NewMockStore(t))
+	require.Len(t, server.openapi, 2)
+
+	param := regexp.MustCompile(`:(\w+)`)
+	routes := map[string]bool{}
+	for _, r := range server.router.Routes() {
+		path, ok := strings.CutPrefix(r.Path, testAPIRoot)
+		if !ok || strings.HasSuffix(path, "/docs/*any") {
+			continue
+		}
+		routes[r.Method+" "+param.ReplaceAllString(path, "{$1}")] = true
+	}
+
+	operations := map[string]bool{}
+	for version, doc := range server.openapi {
+		for path, item := range doc.Paths.Map() {
+			for method := range item.Operations() {
+				operations[method+" /"+version+path] = true
+			}
+		}
+	}
+
+	for route := range routes {
+		require.True(t, operations[route], "%s is not in the OpenAPI document", route)
+	}
+	for op := range operations {
+		require.True(t, routes[op], "%s of the OpenAPI document has no route", op)
+	}
+}
+
+// TestOpenAPIResponses runs requests through the API with response
+// validation on, so answers the documents do not describe fail with 500
+func TestOpenAPIResponses(t *testing.T) {
+	book := randomBook(t)
+	author := randomAuthor(t)
+	publisher := randomPublisher(t)
+	isbn := book.Isbn13.String
+
+	testCases := []struct {
+		name       string
+		method     string
+		path       string
+		header     http.Header
+		body       any
+		buildStubs func(store *mockdb.MockStore)
+		wantStatus int
+	}{
+		{
+			name:   "ListBooks",
+			method: http.MethodGet,
+			path:   "/v1/books?title=a&min_price=10&page=2",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListBooks(mock.Anything, mock.Anything).Return([]db.ListBooksRow{{Book: book}}, nil)
+				store.EXPECT().CountBooks(mock.Anything, mock.Anything).Return(int64(6), nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "ListBooksInvalidPerPage",
+			method:     http.MethodGet,
+			path:       "/v1/books?per_page=100",
+			buildStubs: func(store *mockdb.MockStore) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "CreateBook",
+			method: http.MethodPost,
+			path:   "/v1/books",
+			body: gin.H{
+				"book":      gin.H{"title": book.Title, "isbn13": isbn, "price": book.Price, "publication_year": book.PublicationYear},
+				"authors":   []string{"Zed Zulu"},
+				"publisher": publisher.PublisherName,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				// deliveries are queued in the transaction of the book
+				store.EXPECT().CreateBookTx(mock.Anything, mock.Anything).
+					RunAndReturn(func(_ context.Context, arg db.CreateBookTxParams) (db.Book, error) {
+						return book, arg.AfterCreate(store, book)
+					})
+				store.EXPECT().EnqueueWebhookDeliveries(mock.Anything, mock.MatchedBy(func(arg db.EnqueueWebhookDeliveriesParams) bool {
+					return arg.Event == string(models.EventBookCreated)
+				})).Return(0, nil)
+			},
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name:   "CreateDeletedBook",
+			method: http.MethodPost,
+			path:   "/v1/books",
+			body: gin.H{
+				"book":      gin.H{"title": book.Title, "isbn13": isbn, "price": book.Price, "publication_year": book.PublicationYear},
+				"authors":   []string{"Zed Zulu"},
+				"publisher": publisher.PublisherName,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateBookTx(mock.Anything, mock.Anything).Return(db.Book{}, db.ErrRecordDeleted)
+			},
+			wantStatus: http.StatusConflict,
+		},
+		{
+			name:       "CreateBookNoTitle",
+			method:     http.MethodPost,
+			path:       "/v1/books",
+			body:       gin.H{"book": gin.H{"isbn13": isbn}, "publisher": publisher.PublisherName},
+			buildStubs: func(store *mockdb.MockStore) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "GetBook",
+			method: http.MethodGet,
+			path:   "/v1/books/" + isbn,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetBookByISBN(mock.Anything, mock.Anything).Return(db.GetBookByISBNRow{
+					Book:         book,
+					Authors:      "Zed Zulu",
+					Contributors: "author:Zed Zulu,illustrator:Amy Adams",
+					Subjects:     "fantasy",
+				}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:   "GetBookNotModified",
+			method: http.MethodGet,
+			path:   "/v1/books/" + isbn,
+			header: http.Header{"If-None-Match": []string{`"1"`}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetBookByISBN(mock.Anything, mock.Anything).Return(db.GetBookByISBNRow{Book: book}, nil)
+			},
+			wantStatus: http.StatusNotModified,
+		},
+		{
+			name:   "GetBookNotFound",
+			method: http.MethodGet,
+			path:   "/v1/books/" + isbn,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetBookByISBN(mock.Anything, mock.Anything).Return(db.GetBookByISBNRow{}, db.ErrRecordNotFound)
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "GetBookInternalError",
+			method: http.MethodGet,
+			path:   "/v1/books/" + isbn,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetBookByISBN(mock.Anything, mock.Anything).Return(db.GetBookByISBNRow{}, sql.ErrConnDone)
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+		{
+			name:       "GetDeletedBookUnauthorized",
+			method:     http.MethodGet,
+			path:       "/v1/books/" + isbn + "?include_deleted=true",
+			buildStubs: func(store *mockdb.MockStore) {},
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:   "GetDeletedBook",
+			method: http.MethodGet,
+			path:   "/v1/books/" + isbn + "?include_deleted=true",
+			header: http.Header{"X-Api-Key": {testAdminKey}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetBookByISBN(mock.Anything, mock.MatchedBy(func(arg db.GetBookByISBNParams) bool {
+					return arg.IncludeDeleted
+				})).Return(db.GetBookByISBNRow{Book: book}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "ListDeletedAuthorsUnauthorized",
+			method:     http.MethodGet,
+			path:       "/v1/authors?include_deleted=true",
+			buildStubs: func(store *mockdb.MockStore) {},
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "GetBookInvalidISBN",
+			method:     http.MethodGet,
+			path:       "/v1/books/123",
+			buildStubs: func(store *mockdb.MockStore) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "UpdateBookNoIfMatch",
+			method:     http.MethodPut,
+			path:       "/v1/books/" + isbn,
+			body:       gin.H{"title": "New Title"},
+			buildStubs: func(store *mockdb.MockStore) {},
+			wantStatus: http.StatusPreconditionRequired,
+		},
+		{
+			name:   "UpdateBookVersionMismatch",
+			method: http.MethodPut,
+			path:   "/v1/books/" + isbn,
+			header: http.Header{"If-Match": []string{`"3"`}},
+			body:   gin.H{"title": "New Title"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetBookByISBN(mock.Anything, mock.Anything).Return(db.GetBookByISBNRow{Book: book}, nil)
+			},
+			wantStatus: http.StatusPreconditionFailed,
+		},
+		{
+			name:       "PatchBookNotMergePatch",
+			method:     http.MethodPatch,
+			path:       "/v1/books/" + isbn,
+			header:     http.Header{"If-Match": []string{`"1"`}},
+			body:       gin.H{"title": "New Title"},
+			buildStubs: func(store *mockdb.MockStore) {},
+			wantStatus: http.StatusUnsupportedMediaType,
+		},
+		{
+			name:   "ListAuthors",
+			method: http.MethodGet,
+			path:   "/v1/authors",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListAuthors(mock.Anything, mock.Anything).Return([]db.Author{author}, nil)
+				store.EXPECT().CountAuthors(mock.Anything, mock.Anything).Return(int64(1), nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:   "CreateAuthor",
+			method: http.MethodPost,
+			path:   "/v1/authors",
+			body:   gin.H{"first_name": author.FirstName, "last_name": author.LastName},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateAuthor(mock.Anything, mock.Anything).Return(author, nil)
+			},
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name:   "GetAuthor",
+			method: http.MethodGet,
+			path:   "/v1/authors/1",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAuthor(mock.Anything, mock.Anything).Return(author, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:   "GetPublisher",
+			method: http.MethodGet,
+			path:   "/v1/publishers/1",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetPublisher(mock.Anything, mock.Anything).Return(publisher, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:   "ListChanges",
+			method: http.MethodGet,
+			path:   "/v1/changes?since=2024-01-01T00:00:00Z",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListChanges(mock.Anything, mock.Anything).Return([]db.ListChangesRow{}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "ListWebhooksUnauthorized",
+			method:     http.MethodGet,
+			path:       "/v1/webhooks",
+			buildStubs: func(store *mockdb.MockStore) {},
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:   "ListWebhooks",
+			method: http.MethodGet,
+			path:   "/v1/webhooks",
+			header: http.Header{"X-Api-Key": {testAdminKey}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListWebhooks(mock.Anything).Return([]db.Webhook{{
+					WebhookID: 1,
+					Url:       "https://example.com/hook",
+					Events:    "book.created,price.changed",
+				}}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:   "GetCart",
+			method: http.MethodGet,
+			path:   "/v1/cart",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetCartByToken(mock.Anything, mock.Anything).Return(db.Cart{}, db.ErrRecordNotFound)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:   "ListOrders",
+			method: http.MethodGet,
+			path:   "/v1/orders?status=paid",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListOrders(mock.Anything, mock.Anything).Return([]db.Order{}, nil)
+				store.EXPECT().CountOrders(mock.Anything, mock.Anything).Return(int64(0), nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "ListAllOrdersUnauthorized",
+			method:     http.MethodGet,
+			path:       "/v1/admin/orders",
+			buildStubs: func(store *mockdb.MockStore) {},
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:   "ListAllOrders",
+			method: http.MethodGet,
+			path:   "/v1/admin/orders",
+			header: http.Header{"X-Api-Key": {testAdminKey}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListOrders(mock.Anything, db.ListOrdersParams{Limit: 5}).Return([]db.Order{}, nil)
+				store.EXPECT().CountOrders(mock.Anything, mock.Anything).Return(int64(0), nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:   "UpdateOrderStatus",
+			method: http.MethodPut,
+			path:   "/v1/admin/orders/1/status",
+			header: http.Header{"X-Api-Key": {testAdminKey}},
+			body:   gin.H{"status": "cancelled"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetOrder(mock.Anything, int64(1)).Return(db.Order{OrderID: 1, Status: "pending"}, nil)
+				store.EXPECT().UpdateOrderStatus(mock.Anything, mock.Anything).Return(db.Order{OrderID: 1, Status: "cancelled"}, nil)
+				store.EXPECT().ListOrderItems(mock.Anything, int64(1)).Return([]db.OrderItem{}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "OpenAPI",
+			method:     http.MethodGet,
+			path:       "/v1/openapi.json",
+			buildStubs: func(store *mockdb.MockStore) {},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:   "ListBooksV2",
+			method: http.MethodGet,
+			path:   "/v2/books?limit=1&min_price_cents=1000",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListBooks(mock.Anything, mock.Anything).Return([]db.ListBooksRow{{Book: book}, {Book: book}}, nil)
+				store.EXPECT().ListBookAuthors(mock.Anything, mock.Anything).Return([]db.ListBookAuthorsRow{{BookID: book.BookID, Author: author}}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "ListBooksV2InvalidCursor",
+			method:     http.MethodGet,
+			path:       "/v2/books?cursor=%21",
+			buildStubs: func(store *mockdb.MockStore) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "GetDeletedBookV2Unauthorized",
+			method:     http.MethodGet,
+			path:       "/v2/books/" + isbn + "?include_deleted=true",
+			buildStubs: func(store *mockdb.MockStore) {},
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:   "GetBookV2",
+			method: http.MethodGet,
+			path:   "/v2/books/" + isbn,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetBookByISBN(mock.Anything, mock.Anything).Return(db.GetBookByISBNRow{Book: book}, nil)
+				store.EXPECT().ListBookAuthors(mock.Anything, mock.Anything).Return([]db.ListBookAuthorsRow{}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:   "GetBookV2NotFound",
+			method: http.MethodGet,
+			path:   "/v2/books/" + isbn,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetBookByISBN(mock.Anything, mock.Anything).Return(db.GetBookByISBNRow{}, db.ErrRecordNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "OpenAPIV2",
+			method:     http.MethodGet,
+			path:       "/v2/openapi.json",
+			buildStubs: func(store *mockdb.MockStore) {},
+			wantStatus: http.StatusOK,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			store := mockdb.NewMockStore(t)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+
+			var body io.Reader
+			if tc.body != nil {
+				data, err := json.Marshal(tc.body)
+				require.NoError(t, err)
+				body = bytes.NewReader(data)
+			}
+			req := httptest.NewRequest(tc.method, testAPIRoot+tc.path, body)
+			if tc.body != nil {
+				req.Header.Set("Content-Type", "application/json")
+			}
+			for k, v := range tc.header {
+				req.Header[k] = v
+			}
+
+			recorder := httptest.NewRecorder()
+			server.router.ServeHTTP(recorder, req)
+
+			require.NotContains(t, recorder.Body.String(), "API document does not describe")
+			require.Equal(t, tc.wantStatus, recorder.Code, recorder.Body.String())
+		})
+	}
+}
+
+func TestDeprecationHeaders(t *testing.T) {
+	book := randomBook(t)
+	isbn := book.Isbn13.String
+
+	store := mockdb.NewMockStore(t)
+	store.EXPECT().GetBookByISBN(mock.Anything, mock.Anything).Return(db.GetBookByISBNRow{Book: book}, nil)
+	server, err := NewServer(util.Config{
+		GinMode:           gin.TestMode,
+		APIBasePath:       testAPIBasePath,
+		APIV1DeprecatedAt: "2026-11-02",
+		APIV1Sunset:       "2027-04-30",
+	}, store)
+	require.NoError(t, err)
+
+	// the v1 reads of books have a successor in v2
+	recorder := httptest.NewRecorder()
+	server.router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/v1/books/"+isbn, nil))
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Equal(t, "@1793577600", recorder.Header().Get("Deprecation"))
+	require.Equal(t, "Fri, 30 Apr 2027 00:00:00 GMT", recorder.Header().Get("Sunset"))
+	require.Equal(t, `</api/v2/books/`+isbn+`>; rel="successor-version"`, recorder.Header().Get("Link"))
+
+	// the other v1 routes have none
+	for _, path := range []string{"/api/v1/openapi.json", "/api/v2/openapi.json"} {
+		recorder = httptest.NewRecorder()
+		server.router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+		require.Equal(t, http.StatusOK, recorder.Code)
+		require.Empty(t, recorder.Header().Get("Deprecation"), path)
+		require.Empty(t, recorder.Header().Get("Sunset"), path)
+		require.Empty(t, recorder.Header().Get("Link"), path)
+	}
+
+	for _, config := range []util.Config{
+		{GinMode: gin.TestMode, APIBasePath: testAPIBasePath, APIV1Sunset: "soon"},
+		{GinMode: gin.TestMode, APIBasePath: testAPIBasePath, APIV1DeprecatedAt: "2026-13-01"},
+	} {
+		_, err = NewServer(config, mockdb.NewMockStore(t))
+		require.Error(t, err)
+	}
+}
+
+func TestAPIBasePaths(t *testing.T) {
+	testCases := []struct {
+		name   string
+		config util.Config
+		v1, v2 string
+	}{
+		{
+			name:   "NextToV1",
+			config: util.Config{APIBasePath: "/api/v1"},
+			v1:     "/api/v1",
+			v2:     "/api/v2",
+		},
+		{
+			name:   "V2BasePath",
+			config: util.Config{APIBasePath: "/v1", APIV2BasePath: "/api/next"},
+			v1:     "/v1",
+			v2:     "/api/next",
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			tc.config.GinMode = gin.TestMode
+			server, err := NewServer(tc.config, mockdb.NewMockStore(t))
+			require.NoError(t, err)
+
+			for _, path := range []string{tc.v1 + "/openapi.json", tc.v2 + "/openapi.json"} {
+				recorder := httptest.NewRecorder()
+				server.router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+				require.Equal(t, http.StatusOK, recorder.Code, path)
+			}
+		})
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	store := mockdb.NewMockStore(t)
+	store.EXPECT().GetBookByISBN(mock.Anything, mock.Anything).
+		Return(db.GetBookByISBNRow{}, db.ErrRecordNotFound).Once()
+
+	server, err := NewServer(util.Config{
+		GinMode:        gin.TestMode,
+		APIBasePath:    testAPIBasePath,
+		RateLimitBooks: "1/1m",
+	}, store)
+	require.NoError(t, err)
+
+	path := "/api/v2/books/" + util.RandomISBN13()
+
+	recorder := httptest.NewRecorder()
+	server.router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+	require.Equal(t, http.StatusNotFound, recorder.Code)
+	require.Equal(t, "0", recorder.Header().Get("RateLimit-Remaining"))
+
+	// the response is validated against the document in test mode
+	recorder = httptest.NewRecorder()
+	server.router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+	require.Equal(t, http.StatusTooManyRequests, recorder.Code, recorder.Body.String())
+	require.Equal(t, "60", recorder.Header().Get("Retry-After"))
+
+	// neither a made up API key nor a forwarded IP names another client
+	request := httptest.NewRequest(http.MethodGet, path, nil)
+	request.Header.Set("X-API-Key", "made-up")
+	request.Header.Set("X-Forwarded-For", "203.0.113.7")
+	recorder = httptest.NewRecorder()
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusTooManyRequests, recorder.Code, recorder.Body.String())
+
+	// the documents are not limited
+	recorder = httptest.NewRecorder()
+	server.router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/v2/openapi.json", nil))
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	// GraphQL reads the books too
+	for _, status := range []int{http.StatusOK, http.StatusTooManyRequests} {
+		request := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(`{"query":"{__typename}"}`))
+		request.Header.Set("Content-Type", "application/json")
+		request.RemoteAddr = "192.0.2.2:1234"
+		recorder = httptest.NewRecorder()
+		server.router.ServeHTTP(recorder, request)
+		require.Equal(t, status, recorder.Code, recorder.Body.String())
+	}
+
+	_, err = NewServer(util.Config{
+		GinMode:     gin.TestMode,
+		APIBasePath: testAPIBasePath,
+		RateLimit:   "often",
+	}, mockdb.NewMockStore(t))
+	require.ErrorIs(t, err, ratelimit.ErrInvalidLimit)
+
+	_, err = NewServer(util.Config{
+		GinMode:        gin.TestMode,
+		APIBasePath:    testAPIBasePath,
+		TrustedProxies: []string{"not an address"},
+	}, mockdb.NewMockStore(t))
+	require.Error(t, err)
+}
+
+func TestCaching(t *testing.T) {
+	book := randomBook(t)
+	isbn := book.Isbn13.String
+
+	store := mockdb.NewMockStore(t)
+	store.EXPECT().GetBookByISBN(mock.Anything, mock.Anything).Return(db.GetBookByISBNRow{Book: book}, nil).Once()
+	store.EXPECT().ListBookAuthors(mock.Anything, mock.Anything).Return([]db.ListBookAuthorsRow{}, nil).Once()
+
+	server, err := NewServer(util.Config{
+		GinMode:         gin.TestMode,
+		APIBasePath:     testAPIBasePath,
+		CacheSize:       10,
+		HTTPCacheMaxAge: time.Minute,
+	}, store)
+	require.NoError(t, err)
+
+	// the second read is served from the cache
+	for i := 0; i < 2; i++ {
+		recorder := httptest.NewRecorder()
+		server.router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/v2/books/"+isbn, nil))
+		require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+		require.Equal(t, "public, max-age=60", recorder.Header().Get("Cache-Control"))
+	}
+
+	recorder := httptest.NewRecorder()
+	server.router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Contains(t, recorder.Body.String(), `xyz_books_cache_hits_total{cache="books"} 1`)
+	require.Contains(t, recorder.Body.String(), `xyz_books_cache_misses_total{cache="books"} 1`)
+
+	// catalog reads are revalidated when no max age is set, and error
+	// responses are not stored
+	store = mockdb.NewMockStore(t)
+	store.EXPECT().GetBookByISBN(mock.Anything, mock.Anything).Return(db.GetBookByISBNRow{Book: book}, nil).Once()
+	store.EXPECT().GetBookByISBN(mock.Anything, mock.Anything).Return(db.GetBookByISBNRow{}, db.ErrRecordNotFound).Once()
+	server = newTestServer(t, store)
+
+	recorder = httptest.NewRecorder()
+	server.router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/v1/books/"+isbn, nil))
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+	require.Equal(t, "no-cache", recorder.Header().Get("Cache-Control"))
+
+	recorder = httptest.NewRecorder()
+	server.router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/v1/books/"+isbn, nil))
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+	require.Equal(t, "no-store", recorder.Header().Get("Cache-Control"))
+}
+
+func TestMetrics(t *testing.T) {
+	server, err := NewServer(util.Config{
+		GinMode:     gin.TestMode,
+		APIBasePath: testAPIBasePath,
+		CacheSize:   10,
+	}, mockdb.NewMockStore(t))
+	require.NoError(t, err)
+
+	recorder := httptest.NewRecorder()
+	server.router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/v2/openapi.json", nil))
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	recorder = httptest.NewRecorder()
+	server.router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Contains(t, recorder.Body.String(), `xyz_books_http_requests_total{method="GET",route="/api/v2/openapi.json",status="200"}`)
+	require.Contains(t, recorder.Body.String(), `xyz_books_cache_misses_total{cache="books"} 0`)
+}
+
+func TestRequestID(t *testing.T) {
+	server := newTestServer(t, mockdb.NewMockStore(t))
+
+	request := httptest.NewRequest(http.MethodGet, "/api/v2/openapi.json", nil)
+	request.Header.Set("X-Request-ID", "req-1")
+	recorder := httptest.NewRecorder()
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, "req-1", recorder.Header().Get("X-Request-ID"))
+
+	recorder = httptest.NewRecorder()
+	server.router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/v2/openapi.json", nil))
+	require.NotEmpty(t, recorder.Header().Get("X-Request-ID"))
+}
+
+func TestTracing(t *testing.T) {
+	recorder := tracingtest.Record(t)
+	book := randomBook(t)
+
+	// the trace of the client is continued, down to the store
+	traceID := "4bf92f3577b34da6a3ce929d0e0e4736"
+	store := mockdb.NewMockStore(t)
+	store.EXPECT().GetBookByISBN(mock.MatchedBy(func(ctx context.Context) bool {
+		return trace.SpanContextFromContext(ctx).TraceID().String() == traceID
+	}), mock.Anything).Return(db.GetBookByISBNRow{Book: book}, nil).Once()
+	server := newTestServer(t, store)
+
+	request := httptest.NewRequest(http.MethodGet, "/api/v1/books/"+book.Isbn13.String, nil)
+	request.Header.Set("traceparent", "00-"+traceID+"-00f067aa0ba902b7-01")
+	res := httptest.NewRecorder()
+	server.router.ServeHTTP(res, request)
+	require.Equal(t, http.StatusOK, res.Code, res.Body.String())
+
+	spans := recorder.Ended()
+	require.ElementsMatch(t, []string{"GET /api/v1/books/:isbn", "DefaultService.GetBook"}, tracingtest.Names(spans))
+	for _, span := range spans {
+		require.Equal(t, traceID, span.SpanContext().TraceID().String())
+	}
+}
+
+func TestHealth(t *testing.T) {
+	server := newTestServer(t, mockdb.NewMockStore(t))
+
+	recorder := httptest.NewRecorder()
+	server.router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Equal(t, "no-store", recorder.Header().Get("Cache-Control"))
+
+	var info buildinfo.Info
+	recorder = httptest.NewRecorder()
+	server.router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/version", nil))
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &info))
+	require.Equal(t, server.schemaVersion, info.SchemaVersion)
+	require.NotZero(t, info.SchemaVersion)
+	require.NotEmpty(t, info.Commit)
+
+	testCases := []struct {
+		name       string
+		buildStubs func(store *mockdb.MockStore)
+		status     int
+		checks     map[string]string
+	}{
+		{
+			name: "Ready",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().Ping(mock.Anything).Return(nil)
+				store.EXPECT().SchemaVersion(mock.Anything).Return(db.SchemaVersion{Version: server.schemaVersion}, nil)
+				store.EXPECT().CheckWritable(mock.Anything).Return(nil)
+			},
+			status: http.StatusOK,
+			checks: map[string]string{"database": "ok", "schema": "ok", "writable": "ok"},
+		},
+		{
+			name: "Unreachable",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().Ping(mock.Anything).Return(sql.ErrConnDone)
+			},
+			status: http.StatusServiceUnavailable,
+			checks: map[string]string{"database": "unreachable", "schema": "skipped", "writable": "skipped"},
+		},
+		{
+			name: "NotMigrated",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().Ping(mock.Anything).Return(nil)
+				store.EXPECT().SchemaVersion(mock.Anything).Return(db.SchemaVersion{Version: server.schemaVersion - 1}, nil)
+				store.EXPECT().CheckWritable(mock.Anything).Return(nil)
+			},
+			status: http.StatusServiceUnavailable,
+			checks: map[string]string{"database": "ok", "schema": fmt.Sprintf("version %d, expected %d", server.schemaVersion-1, server.schemaVersion), "writable": "ok"},
+		},
+		{
+			name: "Dirty",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().Ping(mock.Anything).Return(nil)
+				store.EXPECT().SchemaVersion(mock.Anything).Return(db.SchemaVersion{Version: server.schemaVersion, Dirty: true}, nil)
+				store.EXPECT().CheckWritable(mock.Anything).Return(nil)
+			},
+			status: http.StatusServiceUnavailable,
+			checks: map[string]string{"database": "ok", "schema": fmt.Sprintf("migration %d failed", server.schemaVersion), "writable": "ok"},
+		},
+		{
+			name: "ReadOnly",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().Ping(mock.Anything).Return(nil)
+				store.EXPECT().SchemaVersion(mock.Anything).Return(db.SchemaVersion{Version: server.schemaVersion}, nil)
+				store.EXPECT().CheckWritable(mock.Anything).Return(errors.New("attempt to write a readonly database"))
+			},
+			status: http.StatusServiceUnavailable,
+			checks: map[string]string{"database": "ok", "schema": "ok", "writable": "read-only"},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			store := mockdb.NewMockStore(t)
+			tc.buildStubs(store)
+			server := newTestServer(t, store)
+
+			recorder := httptest.NewRecorder()
+			server.router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+			require.Equal(t, tc.status, recorder.Code)
+
+			var res readiness
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+			require.Equal(t, tc.checks, res.Checks)
+		})
+	}
+}
+
+func randomBook(t *testing.T) db.Book {
+	isbn := util.NewISBN(util.RandomISBN13())
+	return db.Book{
+		BookID:          util.RandomInt(1, 111),
+		Title:           util.RandomString(24),
+		Isbn13:          sql.NullString{String: isbn.ISBN13, Valid: true},
+		Isbn10:          sql.NullString{String: isbn.ISBN10, Valid: true},
+		Price:           float64(util.RandomFloat(10.0, 1500.0)),
+		PublicationYear: util.RandomInt(1000, 9999),
+		Version:         1,
+	}
+}
+
+func randomAuthor(t *testing.T) db.Author {
+	return db.Author{
+		AuthorID:  util.RandomInt(1, 111),
+		FirstName: util.RandomString(12),
+		LastName:  util.RandomString(16),
+		Version:   1,
+	}
+}
+
+func randomPublisher(t *testing.T) db.Publisher {
+	return db.Publisher{
+		PublisherID:   util.RandomInt(1, 111),
+		PublisherName: util.RandomString(24),
+		Version:       1,
+	}
+}
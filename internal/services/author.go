@@ -2,18 +2,26 @@ package services
 
 import (
 	"database/sql"
+	"errors"
+	"time"
 
 	db "github.com/atsuyaourt/xyz-books/internal/db/sqlc"
 	"github.com/atsuyaourt/xyz-books/internal/models"
+	"github.com/atsuyaourt/xyz-books/internal/tracing"
 	"github.com/atsuyaourt/xyz-books/internal/util"
 	"golang.org/x/net/context"
 )
 
 func newAuthor(arg db.Author) models.Author {
 	return models.Author{
+		ID:         arg.AuthorID,
 		FirstName:  arg.FirstName,
 		LastName:   arg.LastName,
-		MiddleName: arg.LastName,
+		MiddleName: arg.MiddleName,
+		CreatedAt:  arg.CreatedAt,
+		UpdatedAt:  arg.UpdatedAt,
+		DeletedAt:  deletedAt(arg.DeletedAt),
+		Version:    arg.Version,
 	}
 }
 
@@ -23,7 +31,10 @@ type CreateAuthorReq struct {
 	MiddleName string `json:"middle_name" binding:"omitempty,min=1"`
 } //@name CreateAuthorParams
 
-func (s *DefaultService) CreateAuthor(ctx context.Context, req CreateAuthorReq) (*models.Author, error) {
+func (s *DefaultService) CreateAuthor(ctx context.Context, req CreateAuthorReq) (_ *models.Author, err error) {
+	ctx, span := tracing.Start(ctx, "DefaultService.CreateAuthor")
+	defer func() { tracing.End(span, err) }()
+
 	arg := db.CreateAuthorParams(req)
 
 	author, err := s.store.CreateAuthor(ctx, arg)
@@ -36,8 +47,18 @@ func (s *DefaultService) CreateAuthor(ctx context.Context, req CreateAuthorReq)
 	return &res, nil
 }
 
-func (s *DefaultService) GetAuthor(ctx context.Context, id int64) (*models.Author, error) {
-	author, err := s.store.GetAuthor(ctx, id)
+func (s *DefaultService) GetAuthor(ctx context.Context, id int64, includeDeleted bool) (_ *models.Author, err error) {
+	ctx, span := tracing.Start(ctx, "DefaultService.GetAuthor")
+	defer func() { tracing.End(span, err) }()
+
+	if err := checkIncludeDeleted(ctx, includeDeleted); err != nil {
+		return nil, err
+	}
+
+	author, err := s.store.GetAuthor(ctx, db.GetAuthorParams{
+		AuthorID:       id,
+		IncludeDeleted: includeDeleted,
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -48,16 +69,27 @@ func (s *DefaultService) GetAuthor(ctx context.Context, id int64) (*models.Autho
 }
 
 type ListAuthorsReq struct {
-	Page    int32 `form:"page,default=1" binding:"omitempty,min=1"`     // page number
-	PerPage int32 `form:"per_page,default=5" binding:"omitempty,min=1"` // limit
+	UpdatedSince   time.Time `form:"updated_since"`                                // only list authors changed after this time (RFC 3339)
+	IncludeDeleted bool      `form:"include_deleted"`                              // also list deleted authors
+	Page           int32     `form:"page,default=1" binding:"omitempty,min=1"`     // page number
+	PerPage        int32     `form:"per_page,default=5" binding:"omitempty,min=1"` // limit
 } //@name ListAuthorsParams
 
-func (s *DefaultService) ListAuthors(ctx context.Context, req ListAuthorsReq) (*util.PaginatedList[models.Author], error) {
+func (s *DefaultService) ListAuthors(ctx context.Context, req ListAuthorsReq) (_ *util.PaginatedList[models.Author], err error) {
+	ctx, span := tracing.Start(ctx, "DefaultService.ListAuthors")
+	defer func() { tracing.End(span, err) }()
+
+	if err := checkIncludeDeleted(ctx, req.IncludeDeleted); err != nil {
+		return nil, err
+	}
+
 	offset := (req.Page - 1) * req.PerPage
 
 	arg := db.ListAuthorsParams{
-		Limit:  int64(req.PerPage),
-		Offset: int64(offset),
+		IncludeDeleted: req.IncludeDeleted,
+		UpdatedSince:   updatedSince(req.UpdatedSince),
+		Limit:          int64(req.PerPage),
+		Offset:         int64(offset),
 	}
 	authors, err := s.store.ListAuthors(ctx, arg)
 	if err != nil {
@@ -70,7 +102,10 @@ func (s *DefaultService) ListAuthors(ctx context.Context, req ListAuthorsReq) (*
 		items[i] = newAuthor(author)
 	}
 
-	count, err := s.store.CountAuthors(ctx)
+	count, err := s.store.CountAuthors(ctx, db.CountAuthorsParams{
+		IncludeDeleted: arg.IncludeDeleted,
+		UpdatedSince:   arg.UpdatedSince,
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -80,15 +115,38 @@ func (s *DefaultService) ListAuthors(ctx context.Context, req ListAuthorsReq) (*
 	return &res, nil
 }
 
+// ListBookAuthors returns the authors of each of the given books, keyed by
+// book ID, in credit order
+func (s *DefaultService) ListBookAuthors(ctx context.Context, bookIDs []int64) (_ map[int64][]models.Author, err error) {
+	ctx, span := tracing.Start(ctx, "DefaultService.ListBookAuthors")
+	defer func() { tracing.End(span, err) }()
+
+	rows, err := s.store.ListBookAuthors(ctx, bookIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(map[int64][]models.Author, len(bookIDs))
+	for _, row := range rows {
+		res[row.BookID] = append(res[row.BookID], newAuthor(row.Author))
+	}
+
+	return res, nil
+}
+
 type UpdateAuthorReq struct {
 	FirstName  string `json:"first_name" binding:"omitempty,min=1"`
 	LastName   string `json:"last_name" binding:"omitempty,min=1"`
 	MiddleName string `json:"middle_name" binding:"omitempty,min=1"`
 } //@name UpdateAuthorParams
 
-func (s *DefaultService) UpdateAuthor(ctx context.Context, oldID int64, req UpdateAuthorReq) (*models.Author, error) {
+func (s *DefaultService) UpdateAuthor(ctx context.Context, oldID int64, version int64, req UpdateAuthorReq) (_ *models.Author, err error) {
+	ctx, span := tracing.Start(ctx, "DefaultService.UpdateAuthor")
+	defer func() { tracing.End(span, err) }()
+
 	arg := db.UpdateAuthorParams{
 		AuthorID: oldID,
+		Version:  expectedVersion(version),
 		FirstName: sql.NullString{
 			String: req.FirstName,
 			Valid:  len(req.FirstName) > 0,
@@ -103,16 +161,141 @@ func (s *DefaultService) UpdateAuthor(ctx context.Context, oldID int64, req Upda
 		},
 	}
 
-	author, err := s.store.UpdateAuthor(ctx, arg)
+	return s.updateAuthor(ctx, arg)
+}
+
+type PatchAuthorReq struct {
+	FirstName  util.Patch[string] `json:"first_name" binding:"omitempty,min=1" swaggertype:"string"`
+	LastName   util.Patch[string] `json:"last_name" binding:"omitempty,min=1" swaggertype:"string"`
+	MiddleName util.Patch[string] `json:"middle_name" swaggertype:"string"` // null removes the middle name
+} //@name PatchAuthorParams
+
+// PatchAuthor applies a JSON merge patch, members left out are unchanged
+func (s *DefaultService) PatchAuthor(ctx context.Context, id int64, version int64, req PatchAuthorReq) (_ *models.Author, err error) {
+	ctx, span := tracing.Start(ctx, "DefaultService.PatchAuthor")
+	defer func() { tracing.End(span, err) }()
+
+	err = notNullable(map[string]bool{
+		"first_name": req.FirstName.Null,
+		"last_name":  req.LastName.Null,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	arg := db.UpdateAuthorParams{
+		AuthorID:  id,
+		Version:   expectedVersion(version),
+		FirstName: patchString(req.FirstName),
+		LastName:  patchString(req.LastName),
+		MiddleName: sql.NullString{
+			String: req.MiddleName.Value,
+			Valid:  req.MiddleName.Set,
+		},
+	}
+
+	return s.updateAuthor(ctx, arg)
+}
+
+// updateAuthor runs an author update, which also changes the version of
+// their books as the name is part of them
+func (s *DefaultService) updateAuthor(ctx context.Context, arg db.UpdateAuthorParams) (*models.Author, error) {
+	defer s.invalidate()
+
+	var (
+		author db.Author
+		events []models.Event
+	)
+	err := s.store.ExecTx(ctx, func(q db.Querier) (err error) {
+		author, err = q.UpdateAuthor(ctx, arg)
+		if errors.Is(err, db.ErrRecordNotFound) && arg.Version.Valid {
+			if _, getErr := q.GetAuthor(ctx, db.GetAuthorParams{AuthorID: arg.AuthorID}); getErr == nil {
+				return db.ErrVersionChanged
+			}
+		}
+		if err != nil {
+			return err
+		}
+
+		err = q.BumpAuthorBookVersions(ctx, arg.AuthorID)
+		if err != nil || !s.publishing() {
+			return err
+		}
+
+		// the books carry the name of the author
+		books, err := q.ListAuthorBooks(ctx, []int64{arg.AuthorID})
+		if err != nil {
+			return err
+		}
+		events = make([]models.Event, len(books))
+		for i, row := range books {
+			events[i] = newEvent(models.EventBookUpdated, newBook(newBookArg{
+				Book:         row.Book,
+				Authors:      splitList(row.Authors),
+				Contributors: splitContributors(row.Contributors),
+				Publisher:    row.PublisherName,
+				Subjects:     splitList(row.Subjects),
+			}))
+		}
+
+		return s.enqueue(ctx, q, events...)
+	})
 	if err != nil {
 		return nil, err
 	}
 
+	s.publish(ctx, events...)
+
 	res := newAuthor(author)
 
 	return &res, nil
 }
 
-func (s *DefaultService) DeleteAuthor(ctx context.Context, id int64) error {
-	return s.store.DeleteAuthor(ctx, id)
+func (s *DefaultService) DeleteAuthor(ctx context.Context, id int64, version int64) (err error) {
+	ctx, span := tracing.Start(ctx, "DefaultService.DeleteAuthor")
+	defer func() { tracing.End(span, err) }()
+	defer s.invalidate()
+
+	arg := db.DeleteAuthorParams{
+		AuthorID: id,
+		Version:  expectedVersion(version),
+	}
+
+	return s.store.ExecTx(ctx, func(q db.Querier) error {
+		n, err := q.DeleteAuthor(ctx, arg)
+		if err != nil || n > 0 || !arg.Version.Valid {
+			return err
+		}
+
+		// nothing deleted, either already gone or changed in between
+		_, err = q.GetAuthor(ctx, db.GetAuthorParams{AuthorID: id})
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		return db.ErrVersionChanged
+	})
+}
+
+func (s *DefaultService) RestoreAuthor(ctx context.Context, id int64) (_ *models.Author, err error) {
+	ctx, span := tracing.Start(ctx, "DefaultService.RestoreAuthor")
+	defer func() { tracing.End(span, err) }()
+	defer s.invalidate()
+
+	author, err := s.store.RestoreAuthor(ctx, id)
+	if errors.Is(err, db.ErrRecordNotFound) {
+		// only deleted authors are restored, a live one is left as is
+		if _, getErr := s.store.GetAuthor(ctx, db.GetAuthorParams{AuthorID: id}); getErr == nil {
+			err = db.ErrNotDeleted
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	res := newAuthor(author)
+
+	return &res, nil
 }
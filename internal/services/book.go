@@ -2,10 +2,15 @@ package services
 
 import (
 	"database/sql"
+	"errors"
+	"fmt"
 	"strings"
+	"time"
 
+	"github.com/atsuyaourt/xyz-books/internal/auth"
 	db "github.com/atsuyaourt/xyz-books/internal/db/sqlc"
 	"github.com/atsuyaourt/xyz-books/internal/models"
+	"github.com/atsuyaourt/xyz-books/internal/tracing"
 	"github.com/atsuyaourt/xyz-books/internal/util"
 	"golang.org/x/net/context"
 	"golang.org/x/text/cases"
@@ -13,18 +18,117 @@ import (
 )
 
 type newBookArg struct {
-	Book      db.Book
-	Publisher string
-	Authors   []string
+	Book         db.Book
+	Publisher    string
+	Authors      []string
+	Contributors []models.Contributor
+	Subjects     []string
+}
+
+// splitList splits a GROUP_CONCAT column, which is empty when there are no rows
+func splitList(s string) []string {
+	if len(s) == 0 {
+		return []string{}
+	}
+
+	return strings.Split(s, ",")
+}
+
+// splitContributors parses the contributors column, a list of role:name entries
+func splitContributors(s string) []models.Contributor {
+	entries := splitList(s)
+	res := make([]models.Contributor, 0, len(entries))
+	for _, entry := range entries {
+		role, name, _ := strings.Cut(entry, ":")
+		res = append(res, models.Contributor{Name: name, Role: role})
+	}
+
+	return res
+}
+
+// deletedAt returns the deletion time of a soft deleted row, nil for live rows
+func deletedAt(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+
+	return &t.Time
+}
+
+// checkIncludeDeleted lets only admins find deleted records
+func checkIncludeDeleted(ctx context.Context, includeDeleted bool) error {
+	if includeDeleted && !auth.FromContext(ctx) {
+		return auth.ErrAdminRequired
+	}
+
+	return nil
+}
+
+// updatedSince returns the change time a list filters on, zero lists
+// everything
+func updatedSince(t time.Time) sql.NullTime {
+	if t.IsZero() {
+		return sql.NullTime{}
+	}
+
+	return sql.NullTime{Time: db.After(t), Valid: true}
+}
+
+// expectedVersion returns the version a conditional write must match,
+// zero writes unconditionally
+func expectedVersion(version int64) sql.NullInt64 {
+	return sql.NullInt64{
+		Int64: version,
+		Valid: version > 0,
+	}
+}
+
+// normalizeLanguage returns the canonical form of a BCP 47 language tag
+func normalizeLanguage(tag string) string {
+	t, err := language.Parse(tag)
+	if err != nil {
+		return tag
+	}
+
+	return t.String()
+}
+
+// normalizeSubjects trims subjects and drops duplicates, a nil slice stays nil
+func normalizeSubjects(subjects []string) []string {
+	if subjects == nil {
+		return nil
+	}
+
+	res := make([]string, 0, len(subjects))
+	seen := make(map[string]bool)
+	for _, subject := range subjects {
+		subject = strings.Join(strings.Fields(subject), " ")
+		key := strings.ToLower(subject)
+		if len(subject) == 0 || seen[key] {
+			continue
+		}
+		seen[key] = true
+		res = append(res, subject)
+	}
+
+	return res
 }
 
 func newBook(arg newBookArg) models.Book {
 	res := models.Book{
+		ID:              arg.Book.BookID,
 		Title:           arg.Book.Title,
 		Price:           arg.Book.Price,
 		PublicationYear: arg.Book.PublicationYear,
 		Publisher:       arg.Publisher,
 		Authors:         arg.Authors,
+		Contributors:    arg.Contributors,
+		ImageUrl:        bookImageUrl(arg.Book),
+		Subjects:        arg.Subjects,
+		CreatedAt:       arg.Book.CreatedAt,
+		UpdatedAt:       arg.Book.UpdatedAt,
+		DeletedAt:       deletedAt(arg.Book.DeletedAt),
+		Version:         arg.Book.Version,
 	}
 
 	if arg.Book.Isbn13.Valid {
@@ -34,12 +138,36 @@ func newBook(arg newBookArg) models.Book {
 		res.ISBN10 = arg.Book.Isbn10.String
 	}
 
-	if arg.Book.ImageUrl.Valid {
-		res.ImageUrl = arg.Book.ImageUrl.String
+	if arg.Book.CoverKey.Valid {
+		res.Cover = newCover(arg.Book.CoverKey.String)
 	}
 	if arg.Book.Edition.Valid {
 		res.Edition = arg.Book.Edition.String
 	}
+	if arg.Book.Language.Valid {
+		res.Language = arg.Book.Language.String
+	}
+	if arg.Book.Format.Valid {
+		res.Format = arg.Book.Format.String
+	}
+	if arg.Book.PageCount.Valid {
+		res.PageCount = arg.Book.PageCount.Int64
+	}
+	if arg.Book.SeriesName.Valid {
+		res.SeriesName = arg.Book.SeriesName.String
+	}
+	if arg.Book.SeriesNumber.Valid {
+		res.SeriesNumber = arg.Book.SeriesNumber.Int64
+	}
+	if arg.Book.Description.Valid {
+		res.Description = arg.Book.Description.String
+	}
+	if res.Contributors == nil {
+		res.Contributors = []models.Contributor{}
+	}
+	if res.Subjects == nil {
+		res.Subjects = []string{}
+	}
 
 	return res
 }
@@ -53,17 +181,50 @@ type CreateBookReq struct {
 		PublicationYear int64   `json:"publication_year" binding:"required,numeric,min=1000"`
 		ImageUrl        string  `json:"image_url" binding:"omitempty,url"`
 		Edition         string  `json:"edition" binding:"omitempty"`
+		Language        string  `json:"language" binding:"omitempty,bcp47_language_tag"` // BCP 47 language tag
+		Format          string  `json:"format" binding:"omitempty,oneof=hardcover paperback ebook audiobook"`
+		PageCount       int64   `json:"page_count" binding:"omitempty,min=1"`
+		SeriesName      string  `json:"series_name" binding:"omitempty"`
+		SeriesNumber    int64   `json:"series_number" binding:"omitempty,min=1,excluded_without=SeriesName"`
+		Description     string  `json:"description" binding:"omitempty"`
 	} `json:"book"`
-	Authors   []string `json:"authors" binding:"required,min=1"`
-	Publisher string   `json:"publisher" binding:"required"`
+	Authors      []string         `json:"authors" binding:"required_without=Contributors"`
+	Contributors []ContributorReq `json:"contributors" binding:"required_without=Authors,omitempty,dive"` // credited after the authors
+	Publisher    string           `json:"publisher" binding:"required"`
+	Subjects     []string         `json:"subjects" binding:"omitempty,dive,max=64,excludesall=0x2C"`
 } //@name CreateBookParams
 
-func (s *DefaultService) CreateBook(ctx context.Context, req CreateBookReq) (*models.Book, error) {
+type ContributorReq struct {
+	Name string `json:"name" binding:"required,excludesall=0x2C"`
+	Role string `json:"role" binding:"required,oneof=author illustrator translator editor colorist letterer"`
+} //@name ContributorParams
+
+func (s *DefaultService) CreateBook(ctx context.Context, req CreateBookReq) (_ *models.Book, err error) {
+	ctx, span := tracing.Start(ctx, "DefaultService.CreateBook")
+	defer func() { tracing.End(span, err) }()
+	defer s.invalidate()
+
 	var authors []util.Name
+	var credits []models.Contributor
 	for i := range req.Authors {
 		n := util.NewName(req.Authors[i])
 		if n.Valid() {
 			authors = append(authors, *n)
+			credits = append(credits, models.Contributor{Name: req.Authors[i], Role: db.ContributorRoleAuthor})
+		}
+	}
+
+	authorNames := req.Authors
+	var contributors []db.Contributor
+	for _, c := range req.Contributors {
+		n := util.NewName(c.Name)
+		if !n.Valid() {
+			continue
+		}
+		contributors = append(contributors, db.Contributor{Name: *n, Role: c.Role})
+		credits = append(credits, models.Contributor{Name: c.Name, Role: c.Role})
+		if c.Role == db.ContributorRoleAuthor {
+			authorNames = append(authorNames, c.Name)
 		}
 	}
 
@@ -90,9 +251,53 @@ func (s *DefaultService) CreateBook(ctx context.Context, req CreateBookReq) (*mo
 				String: req.Book.Edition,
 				Valid:  len(req.Book.Edition) > 0,
 			},
+			Language: sql.NullString{
+				String: normalizeLanguage(req.Book.Language),
+				Valid:  len(req.Book.Language) > 0,
+			},
+			Format: sql.NullString{
+				String: req.Book.Format,
+				Valid:  len(req.Book.Format) > 0,
+			},
+			PageCount: sql.NullInt64{
+				Int64: req.Book.PageCount,
+				Valid: req.Book.PageCount > 0,
+			},
+			SeriesName: sql.NullString{
+				String: req.Book.SeriesName,
+				Valid:  len(req.Book.SeriesName) > 0,
+			},
+			SeriesNumber: sql.NullInt64{
+				Int64: req.Book.SeriesNumber,
+				Valid: req.Book.SeriesNumber > 0,
+			},
+			Description: sql.NullString{
+				String: req.Book.Description,
+				Valid:  len(req.Book.Description) > 0,
+			},
 		},
-		Publisher: publisher,
-		Authors:   authors,
+		Publisher:    publisher,
+		Authors:      authors,
+		Contributors: contributors,
+		Subjects:     normalizeSubjects(req.Subjects),
+	}
+
+	created := func(book db.Book) models.Book {
+		return newBook(newBookArg{
+			Book:         book,
+			Authors:      authorNames,
+			Contributors: credits,
+			Publisher:    publisher,
+			Subjects:     arg.Subjects,
+		})
+	}
+
+	var events []models.Event
+	if s.publishing() {
+		arg.AfterCreate = func(q db.Querier, book db.Book) error {
+			events = []models.Event{newEvent(models.EventBookCreated, created(book))}
+			return s.enqueue(ctx, q, events...)
+		}
 	}
 
 	book, err := s.store.CreateBookTx(ctx, arg)
@@ -100,16 +305,13 @@ func (s *DefaultService) CreateBook(ctx context.Context, req CreateBookReq) (*mo
 		return nil, err
 	}
 
-	res := newBook(newBookArg{
-		Book:      book,
-		Authors:   req.Authors,
-		Publisher: publisher,
-	})
+	res := created(book)
+	s.publish(ctx, events...)
 
 	return &res, nil
 }
 
-func (s *DefaultService) GetBook(ctx context.Context, isbn13 string) (*models.Book, error) {
+func (s *DefaultService) getBookByISBN(ctx context.Context, isbn13 string, includeDeleted bool) (*db.GetBookByISBNRow, error) {
 	isbn := util.NewISBN(isbn13)
 
 	book, err := s.store.GetBookByISBN(ctx, db.GetBookByISBNParams{
@@ -121,38 +323,66 @@ func (s *DefaultService) GetBook(ctx context.Context, isbn13 string) (*models.Bo
 			String: isbn.ISBN10,
 			Valid:  true,
 		},
+		IncludeDeleted: includeDeleted,
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	res := newBook(newBookArg{
-		Book:      book.Book,
-		Authors:   strings.Split(book.Authors, ","),
-		Publisher: book.PublisherName,
-	})
+	return &book, nil
+}
 
-	return &res, nil
+func (s *DefaultService) GetBook(ctx context.Context, isbn13 string, includeDeleted bool) (_ *models.Book, err error) {
+	ctx, span := tracing.Start(ctx, "DefaultService.GetBook")
+	defer func() { tracing.End(span, err) }()
+
+	if err := checkIncludeDeleted(ctx, includeDeleted); err != nil {
+		return nil, err
+	}
+
+	return cached(s, cacheKey("GetBook", isbn13, includeDeleted), func() (*models.Book, error) {
+		book, err := s.getBookByISBN(ctx, isbn13, includeDeleted)
+		if err != nil {
+			return nil, err
+		}
+
+		res := newBook(newBookArg{
+			Book:         book.Book,
+			Authors:      splitList(book.Authors),
+			Contributors: splitContributors(book.Contributors),
+			Publisher:    book.PublisherName,
+			Subjects:     splitList(book.Subjects),
+		})
+
+		return &res, nil
+	})
 }
 
 type ListBooksReq struct {
-	Title              string  `form:"title" binding:"omitempty"`
-	MinPrice           float32 `form:"min_price,default=-1.0" binding:"omitempty,numeric"`
-	MaxPrice           float32 `form:"max_price,default=-1.0" binding:"omitempty,numeric"`
-	MinPublicationYear int32   `form:"min_publication_year,default=-1" binding:"omitempty,numeric"`
-	MaxPublicationYear int32   `form:"max_publication_year,default=-1" binding:"omitempty,numeric"`
-	Author             string  `form:"author" binding:"omitempty"`
-	Publisher          string  `form:"publisher" binding:"omitempty"`
-	Page               int32   `form:"page,default=1" binding:"omitempty,min=1"`            // page number
-	PerPage            int32   `form:"per_page,default=5" binding:"omitempty,min=1,max=30"` // limit
+	Title              string    `form:"title" binding:"omitempty"`
+	MinPrice           float32   `form:"min_price,default=-1.0" binding:"omitempty,numeric"`
+	MaxPrice           float32   `form:"max_price,default=-1.0" binding:"omitempty,numeric"`
+	MinPublicationYear int32     `form:"min_publication_year,default=-1" binding:"omitempty,numeric"`
+	MaxPublicationYear int32     `form:"max_publication_year,default=-1" binding:"omitempty,numeric"`
+	Author             string    `form:"author" binding:"omitempty"`
+	Publisher          string    `form:"publisher" binding:"omitempty"`
+	Language           string    `form:"language" binding:"omitempty,bcp47_language_tag"` // matches more specific tags too, en matches en-US
+	Format             string    `form:"format" binding:"omitempty,oneof=hardcover paperback ebook audiobook"`
+	MinPageCount       int32     `form:"min_page_count" binding:"omitempty,min=1"`
+	MaxPageCount       int32     `form:"max_page_count" binding:"omitempty,min=1"`
+	SeriesName         string    `form:"series_name" binding:"omitempty"`
+	SeriesNumber       int32     `form:"series_number" binding:"omitempty,min=1"`
+	Description        string    `form:"description" binding:"omitempty"`
+	Subject            string    `form:"subject" binding:"omitempty"`
+	UpdatedSince       time.Time `form:"updated_since"`                                       // only list books changed after this time (RFC 3339)
+	IncludeDeleted     bool      `form:"include_deleted"`                                     // also list deleted books
+	Page               int32     `form:"page,default=1" binding:"omitempty,min=1"`            // page number
+	PerPage            int32     `form:"per_page,default=5" binding:"omitempty,min=1,max=30"` // limit
 } //@name ListBooksParams
 
-func (s *DefaultService) ListBooks(ctx context.Context, req ListBooksReq) (*util.PaginatedList[models.Book], error) {
-	offset := (req.Page - 1) * req.PerPage
-
-	arg := db.ListBooksParams{
-		Limit:  int64(req.PerPage),
-		Offset: int64(offset),
+// listBooksParams returns the filters of a list of books
+func listBooksParams(req ListBooksReq) db.ListBooksParams {
+	return db.ListBooksParams{
 		Title: sql.NullString{
 			String: req.Title,
 			Valid:  len(req.Title) > 0,
@@ -181,52 +411,175 @@ func (s *DefaultService) ListBooks(ctx context.Context, req ListBooksReq) (*util
 			Int64: int64(req.MaxPublicationYear),
 			Valid: req.MaxPublicationYear > req.MinPublicationYear,
 		},
+		Language: sql.NullString{
+			String: normalizeLanguage(req.Language),
+			Valid:  len(req.Language) > 0,
+		},
+		Format: sql.NullString{
+			String: req.Format,
+			Valid:  len(req.Format) > 0,
+		},
+		MinPageCount: sql.NullInt64{
+			Int64: int64(req.MinPageCount),
+			Valid: req.MinPageCount > 0,
+		},
+		MaxPageCount: sql.NullInt64{
+			Int64: int64(req.MaxPageCount),
+			Valid: req.MaxPageCount > 0,
+		},
+		SeriesName: sql.NullString{
+			String: req.SeriesName,
+			Valid:  len(req.SeriesName) > 0,
+		},
+		SeriesNumber: sql.NullInt64{
+			Int64: int64(req.SeriesNumber),
+			Valid: req.SeriesNumber > 0,
+		},
+		Description: sql.NullString{
+			String: req.Description,
+			Valid:  len(req.Description) > 0,
+		},
+		Subject: sql.NullString{
+			String: strings.TrimSpace(req.Subject),
+			Valid:  len(strings.TrimSpace(req.Subject)) > 0,
+		},
+		UpdatedSince:   updatedSince(req.UpdatedSince),
+		IncludeDeleted: req.IncludeDeleted,
+	}
+}
+
+func (s *DefaultService) ListBooks(ctx context.Context, req ListBooksReq) (_ *util.PaginatedList[models.Book], err error) {
+	ctx, span := tracing.Start(ctx, "DefaultService.ListBooks")
+	defer func() { tracing.End(span, err) }()
+
+	if err := checkIncludeDeleted(ctx, req.IncludeDeleted); err != nil {
+		return nil, err
 	}
-	rows, err := s.store.ListBooks(ctx, arg)
+
+	return cached(s, cacheKey("ListBooks", req), func() (*util.PaginatedList[models.Book], error) {
+		arg := listBooksParams(req)
+		arg.Limit = int64(req.PerPage)
+		arg.Offset = int64((req.Page - 1) * req.PerPage)
+
+		rows, err := s.store.ListBooks(ctx, arg)
+		if err != nil {
+			return nil, err
+		}
+
+		var items []models.Book
+		for _, row := range rows {
+			items = append(items, newBook(newBookArg{
+				Book:         row.Book,
+				Authors:      splitList(row.Authors),
+				Contributors: splitContributors(row.Contributors),
+				Publisher:    row.PublisherName,
+				Subjects:     splitList(row.Subjects),
+			}))
+		}
+
+		count, err := s.store.CountBooks(ctx, db.CountBooksParams{
+			Title:              arg.Title,
+			Author:             arg.Author,
+			Publisher:          arg.Publisher,
+			MinPrice:           arg.MinPrice,
+			MaxPrice:           arg.MaxPrice,
+			MinPublicationYear: arg.MinPublicationYear,
+			MaxPublicationYear: arg.MaxPublicationYear,
+			Language:           arg.Language,
+			Format:             arg.Format,
+			MinPageCount:       arg.MinPageCount,
+			MaxPageCount:       arg.MaxPageCount,
+			SeriesName:         arg.SeriesName,
+			SeriesNumber:       arg.SeriesNumber,
+			Description:        arg.Description,
+			Subject:            arg.Subject,
+			IncludeDeleted:     arg.IncludeDeleted,
+			UpdatedSince:       arg.UpdatedSince,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		res := util.NewPaginatedList(req.Page, req.PerPage, int32(count), items)
+
+		return &res, nil
+	})
+}
+
+// ListAuthorBooks returns the books written by each of the given authors,
+// keyed by author ID. Deleted books are left out.
+func (s *DefaultService) ListAuthorBooks(ctx context.Context, authorIDs []int64) (_ map[int64][]models.Book, err error) {
+	ctx, span := tracing.Start(ctx, "DefaultService.ListAuthorBooks")
+	defer func() { tracing.End(span, err) }()
+
+	rows, err := s.store.ListAuthorBooks(ctx, authorIDs)
 	if err != nil {
 		return nil, err
 	}
 
-	var items []models.Book
+	res := make(map[int64][]models.Book, len(authorIDs))
 	for _, row := range rows {
-		items = append(items, newBook(newBookArg{
-			Book:      row.Book,
-			Authors:   strings.Split(row.Authors, ","),
-			Publisher: row.PublisherName,
+		res[row.AuthorID] = append(res[row.AuthorID], newBook(newBookArg{
+			Book:         row.Book,
+			Authors:      splitList(row.Authors),
+			Contributors: splitContributors(row.Contributors),
+			Publisher:    row.PublisherName,
+			Subjects:     splitList(row.Subjects),
 		}))
 	}
 
-	count, err := s.store.CountBooks(ctx, db.CountBooksParams{
-		Title:              arg.Title,
-		Author:             arg.Author,
-		Publisher:          arg.Publisher,
-		MinPrice:           arg.MinPrice,
-		MaxPrice:           arg.MaxPrice,
-		MinPublicationYear: arg.MinPublicationYear,
-		MaxPublicationYear: arg.MinPublicationYear,
-	})
+	return res, nil
+}
+
+// ListPublisherBooks returns the books of each of the given publishers,
+// keyed by publisher ID. Deleted books are left out.
+func (s *DefaultService) ListPublisherBooks(ctx context.Context, publisherIDs []int64) (_ map[int64][]models.Book, err error) {
+	ctx, span := tracing.Start(ctx, "DefaultService.ListPublisherBooks")
+	defer func() { tracing.End(span, err) }()
+
+	rows, err := s.store.ListPublisherBooks(ctx, publisherIDs)
 	if err != nil {
 		return nil, err
 	}
 
-	res := util.NewPaginatedList(req.Page, req.PerPage, int32(count), items)
+	res := make(map[int64][]models.Book, len(publisherIDs))
+	for _, row := range rows {
+		res[row.PublisherID] = append(res[row.PublisherID], newBook(newBookArg{
+			Book:         row.Book,
+			Authors:      splitList(row.Authors),
+			Contributors: splitContributors(row.Contributors),
+			Publisher:    row.PublisherName,
+			Subjects:     splitList(row.Subjects),
+		}))
+	}
 
-	return &res, nil
+	return res, nil
 }
 
 type UpdateBookReq struct {
-	Title           string  `json:"title" binding:"omitempty,min=1"`
-	NewISBN13       string  `json:"isbn13" binding:"omitempty,isbn13"`
-	NewISBN10       string  `json:"isbn10" binding:"omitempty,isbn10"`
-	Price           float32 `json:"price" binding:"omitempty,numeric"`
-	PublicationYear int32   `json:"publication_year"  binding:"omitempty,numeric"`
-	ImageUrl        string  `json:"image_url"  binding:"omitempty,url"`
+	Title           string   `json:"title" binding:"omitempty,min=1"`
+	NewISBN13       string   `json:"isbn13" binding:"omitempty,isbn13"`
+	NewISBN10       string   `json:"isbn10" binding:"omitempty,isbn10"`
+	Price           float32  `json:"price" binding:"omitempty,numeric"`
+	PublicationYear int32    `json:"publication_year"  binding:"omitempty,numeric"`
+	ImageUrl        string   `json:"image_url"  binding:"omitempty,url"`
+	Language        string   `json:"language" binding:"omitempty,bcp47_language_tag"`
+	Format          string   `json:"format" binding:"omitempty,oneof=hardcover paperback ebook audiobook"`
+	PageCount       int64    `json:"page_count" binding:"omitempty,min=1"`
+	SeriesName      string   `json:"series_name" binding:"omitempty"`
+	SeriesNumber    int64    `json:"series_number" binding:"omitempty,min=1"`
+	Description     string   `json:"description" binding:"omitempty"`
+	Subjects        []string `json:"subjects" binding:"omitempty,dive,max=64,excludesall=0x2C"` // replaces all subjects when given
 } //@name UpdateBookParams
 
-func (s *DefaultService) UpdateBook(ctx context.Context, oldISBN13 string, req UpdateBookReq) (*models.Book, error) {
+func (s *DefaultService) UpdateBook(ctx context.Context, oldISBN13 string, version int64, req UpdateBookReq) (_ *models.Book, err error) {
+	ctx, span := tracing.Start(ctx, "DefaultService.UpdateBook")
+	defer func() { tracing.End(span, err) }()
+
 	isbn := util.NewISBN(oldISBN13)
 
 	arg := db.UpdateBookByISBNParams{
+		Version: expectedVersion(version),
 		Isbn13: sql.NullString{
 			String: isbn.ISBN13,
 			Valid:  true,
@@ -251,6 +604,30 @@ func (s *DefaultService) UpdateBook(ctx context.Context, oldISBN13 string, req U
 			String: req.ImageUrl,
 			Valid:  len(req.ImageUrl) > 0,
 		},
+		Language: sql.NullString{
+			String: normalizeLanguage(req.Language),
+			Valid:  len(req.Language) > 0,
+		},
+		Format: sql.NullString{
+			String: req.Format,
+			Valid:  len(req.Format) > 0,
+		},
+		PageCount: sql.NullInt64{
+			Int64: req.PageCount,
+			Valid: req.PageCount > 0,
+		},
+		SeriesName: sql.NullString{
+			String: req.SeriesName,
+			Valid:  len(req.SeriesName) > 0,
+		},
+		SeriesNumber: sql.NullInt64{
+			Int64: req.SeriesNumber,
+			Valid: req.SeriesNumber > 0,
+		},
+		Description: sql.NullString{
+			String: req.Description,
+			Valid:  len(req.Description) > 0,
+		},
 	}
 
 	if (len(req.NewISBN13) == 13) && (len(req.NewISBN10) == 10) {
@@ -279,32 +656,258 @@ func (s *DefaultService) UpdateBook(ctx context.Context, oldISBN13 string, req U
 		}
 	}
 
-	_, err := s.store.UpdateBookByISBN(ctx, arg)
+	return s.updateBook(ctx, arg, normalizeSubjects(req.Subjects))
+}
+
+type PatchBookReq struct {
+	Title           util.Patch[string]   `json:"title" binding:"omitempty,min=1" swaggertype:"string"`
+	NewISBN13       util.Patch[string]   `json:"isbn13" binding:"omitempty,isbn13" swaggertype:"string"`
+	NewISBN10       util.Patch[string]   `json:"isbn10" binding:"omitempty,isbn10" swaggertype:"string"`
+	Price           util.Patch[float64]  `json:"price" binding:"omitempty,min=0" swaggertype:"number"` // 0 for giveaways
+	PublicationYear util.Patch[int32]    `json:"publication_year" binding:"omitempty,min=1000" swaggertype:"integer"`
+	ImageUrl        util.Patch[string]   `json:"image_url" binding:"omitempty,url" swaggertype:"string"`
+	Language        util.Patch[string]   `json:"language" binding:"omitempty,bcp47_language_tag" swaggertype:"string"`
+	Format          util.Patch[string]   `json:"format" binding:"omitempty,oneof=hardcover paperback ebook audiobook" swaggertype:"string"`
+	PageCount       util.Patch[int64]    `json:"page_count" binding:"omitempty,min=1" swaggertype:"integer"`
+	SeriesName      util.Patch[string]   `json:"series_name" swaggertype:"string"`
+	SeriesNumber    util.Patch[int64]    `json:"series_number" binding:"omitempty,min=1" swaggertype:"integer"`
+	Description     util.Patch[string]   `json:"description" swaggertype:"string"`
+	Subjects        util.Patch[[]string] `json:"subjects" binding:"omitempty,dive,max=64,excludesall=0x2C" swaggertype:"array,string"`
+} //@name PatchBookParams
+
+// PatchBook applies a JSON merge patch, members left out are unchanged and
+// null members clear the field
+func (s *DefaultService) PatchBook(ctx context.Context, isbn13 string, version int64, req PatchBookReq) (_ *models.Book, err error) {
+	ctx, span := tracing.Start(ctx, "DefaultService.PatchBook")
+	defer func() { tracing.End(span, err) }()
+
+	err = notNullable(map[string]bool{
+		"title":            req.Title.Null,
+		"price":            req.Price.Null,
+		"publication_year": req.PublicationYear.Null,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if req.NewISBN13.Valid() && req.NewISBN10.Valid() && util.NewISBN(req.NewISBN13.Value).ISBN10 != req.NewISBN10.Value {
+		return nil, fmt.Errorf("%w: isbn13 and isbn10 are different books", ErrInvalidPatch)
+	}
+
+	isbn := util.NewISBN(isbn13)
+
+	arg := db.UpdateBookByISBNParams{
+		Version: expectedVersion(version),
+		Isbn13: sql.NullString{
+			String: isbn.ISBN13,
+			Valid:  true,
+		},
+		Isbn10: sql.NullString{
+			String: isbn.ISBN10,
+			Valid:  true,
+		},
+		Title: patchString(req.Title),
+		Price: sql.NullFloat64{
+			Float64: req.Price.Value,
+			Valid:   req.Price.Valid(),
+		},
+		PublicationYear: sql.NullInt64{
+			Int64: int64(req.PublicationYear.Value),
+			Valid: req.PublicationYear.Valid(),
+		},
+		NewIsbn13:         patchString(req.NewISBN13),
+		ClearIsbn13:       req.NewISBN13.Null,
+		NewIsbn10:         patchString(req.NewISBN10),
+		ClearIsbn10:       req.NewISBN10.Null,
+		ImageUrl:          patchString(req.ImageUrl),
+		ClearImageUrl:     req.ImageUrl.Null,
+		Format:            patchString(req.Format),
+		ClearFormat:       req.Format.Null,
+		PageCount:         patchInt64(req.PageCount),
+		ClearPageCount:    req.PageCount.Null,
+		SeriesName:        patchString(req.SeriesName),
+		ClearSeriesName:   req.SeriesName.Null,
+		SeriesNumber:      patchInt64(req.SeriesNumber),
+		ClearSeriesNumber: req.SeriesNumber.Null,
+		Description:       patchString(req.Description),
+		ClearDescription:  req.Description.Null,
+		ClearLanguage:     req.Language.Null,
+	}
+	if req.Language.Valid() {
+		arg.Language = sql.NullString{
+			String: normalizeLanguage(req.Language.Value),
+			Valid:  true,
+		}
+	}
+
+	var subjects []string
+	if req.Subjects.Set {
+		// null removes all subjects
+		subjects = normalizeSubjects(append([]string{}, req.Subjects.Value...))
+	}
+
+	return s.updateBook(ctx, arg, subjects)
+}
+
+// updateBook runs a book update, subjects are replaced unless nil
+func (s *DefaultService) updateBook(ctx context.Context, arg db.UpdateBookByISBNParams, subjects []string) (*models.Book, error) {
+	defer s.invalidate()
+
+	txArg := db.UpdateBookTxParams{
+		Book:     arg,
+		Subjects: subjects,
+	}
+
+	// the events are built from the book as saved in the transaction
+	var events []models.Event
+	if s.publishing() {
+		txArg.AfterUpdate = func(q db.Querier, old, updated db.Book) error {
+			book, err := q.GetBookByISBN(ctx, db.GetBookByISBNParams{
+				Isbn13: updated.Isbn13,
+				Isbn10: updated.Isbn10,
+			})
+			if err != nil {
+				return err
+			}
+
+			res := newBook(newBookArg{
+				Book:         book.Book,
+				Authors:      splitList(book.Authors),
+				Contributors: splitContributors(book.Contributors),
+				Publisher:    book.PublisherName,
+				Subjects:     splitList(book.Subjects),
+			})
+			events = []models.Event{newEvent(models.EventBookUpdated, res)}
+			if old.Price != res.Price {
+				events = append(events, newEvent(models.EventPriceChanged, models.PriceChange{
+					ISBN13:   res.ISBN13,
+					ISBN10:   res.ISBN10,
+					Title:    res.Title,
+					OldPrice: old.Price,
+					Price:    res.Price,
+				}))
+			}
+
+			return s.enqueue(ctx, q, events...)
+		}
+	}
+
+	updated, err := s.store.UpdateBookTx(ctx, txArg)
 	if err != nil {
 		return nil, err
 	}
 
+	// the ISBNs may have changed
 	book, err := s.store.GetBookByISBN(ctx, db.GetBookByISBNParams{
-		Isbn13: arg.Isbn13,
+		Isbn13: updated.Isbn13,
+		Isbn10: updated.Isbn10,
 	})
 	if err != nil {
 		return nil, err
 	}
 
 	res := newBook(newBookArg{
-		Book:      book.Book,
-		Authors:   strings.Split(book.Authors, ","),
-		Publisher: book.PublisherName,
+		Book:         book.Book,
+		Authors:      splitList(book.Authors),
+		Contributors: splitContributors(book.Contributors),
+		Publisher:    book.PublisherName,
+		Subjects:     splitList(book.Subjects),
 	})
+	s.publish(ctx, events...)
 
 	return &res, nil
 }
 
-func (s *DefaultService) DeleteBook(ctx context.Context, isbn13 string) error {
-	return s.store.DeleteBookByISBN(ctx, db.DeleteBookByISBNParams{
+func (s *DefaultService) DeleteBook(ctx context.Context, isbn13 string, version int64) (err error) {
+	ctx, span := tracing.Start(ctx, "DefaultService.DeleteBook")
+	defer func() { tracing.End(span, err) }()
+	defer s.invalidate()
+
+	arg := db.DeleteBookByISBNParams{
 		Isbn13: sql.NullString{
 			String: isbn13,
 			Valid:  true,
 		},
+		Version: expectedVersion(version),
+	}
+
+	var events []models.Event
+	err = s.store.ExecTx(ctx, func(q db.Querier) error {
+		n, err := q.DeleteBookByISBN(ctx, arg)
+		if err != nil {
+			return err
+		}
+		if n > 0 {
+			events = []models.Event{newEvent(models.EventBookDeleted, models.BookRef{ISBN13: isbn13})}
+			return s.enqueue(ctx, q, events...)
+		}
+		if !arg.Version.Valid {
+			return nil
+		}
+
+		// nothing deleted, either already gone or changed in between
+		_, err = q.GetBookByISBN(ctx, db.GetBookByISBNParams{Isbn13: arg.Isbn13})
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		return db.ErrVersionChanged
+	})
+	if err != nil {
+		return err
+	}
+	s.publish(ctx, events...)
+
+	return nil
+}
+
+func (s *DefaultService) RestoreBook(ctx context.Context, isbn13 string) (_ *models.Book, err error) {
+	ctx, span := tracing.Start(ctx, "DefaultService.RestoreBook")
+	defer func() { tracing.End(span, err) }()
+	defer s.invalidate()
+
+	var events []models.Event
+	err = s.store.ExecTx(ctx, func(q db.Querier) error {
+		book, err := q.RestoreBookByISBN(ctx, db.RestoreBookByISBNParams{
+			Isbn13: sql.NullString{
+				String: isbn13,
+				Valid:  true,
+			},
+		})
+		if err != nil || !s.publishing() {
+			return err
+		}
+
+		row, err := q.GetBookByISBN(ctx, db.GetBookByISBNParams{Isbn13: book.Isbn13})
+		if err != nil {
+			return err
+		}
+		events = []models.Event{newEvent(models.EventBookRestored, newBook(newBookArg{
+			Book:         row.Book,
+			Authors:      splitList(row.Authors),
+			Contributors: splitContributors(row.Contributors),
+			Publisher:    row.PublisherName,
+			Subjects:     splitList(row.Subjects),
+		}))}
+
+		return s.enqueue(ctx, q, events...)
 	})
+	if errors.Is(err, db.ErrRecordNotFound) {
+		// only deleted books are restored, a live one is left as is
+		if _, getErr := s.getBookByISBN(ctx, isbn13, false); getErr == nil {
+			err = db.ErrNotDeleted
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := s.GetBook(ctx, isbn13, false)
+	if err != nil {
+		return nil, err
+	}
+	s.publish(ctx, events...)
+
+	return res, nil
 }
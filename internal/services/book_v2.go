@@ -0,0 +1,206 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"math"
+	"time"
+
+	"github.com/atsuyaourt/xyz-books/internal/models"
+	"github.com/atsuyaourt/xyz-books/internal/tracing"
+	"github.com/atsuyaourt/xyz-books/internal/util"
+	"golang.org/x/net/context"
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// cents returns a price in integer cents
+func cents(price float64) int64 {
+	return int64(math.Round(price * 100))
+}
+
+func newBookV2(arg newBookArg, authors []models.Author) models.BookV2 {
+	book := newBook(arg)
+	res := models.BookV2{
+		ID:              book.ID,
+		Title:           book.Title,
+		ISBN13:          book.ISBN13,
+		ISBN10:          book.ISBN10,
+		PriceCents:      cents(book.Price),
+		PublicationYear: book.PublicationYear,
+		ImageUrl:        book.ImageUrl,
+		Cover:           book.Cover,
+		Edition:         book.Edition,
+		Language:        book.Language,
+		Format:          book.Format,
+		PageCount:       book.PageCount,
+		SeriesName:      book.SeriesName,
+		SeriesNumber:    book.SeriesNumber,
+		Description:     book.Description,
+		Authors:         make([]models.AuthorRef, len(authors)),
+		Contributors:    book.Contributors,
+		Publisher: models.PublisherRef{
+			ID:   arg.Book.PublisherID,
+			Name: arg.Publisher,
+		},
+		Subjects:  book.Subjects,
+		CreatedAt: book.CreatedAt,
+		UpdatedAt: book.UpdatedAt,
+		DeletedAt: book.DeletedAt,
+		Version:   book.Version,
+	}
+	for i, author := range authors {
+		res.Authors[i] = models.AuthorRef{
+			ID:         author.ID,
+			FirstName:  author.FirstName,
+			MiddleName: author.MiddleName,
+			LastName:   author.LastName,
+		}
+	}
+
+	return res
+}
+
+func (s *DefaultService) GetBookV2(ctx context.Context, isbn13 string, includeDeleted bool) (_ *models.BookV2, err error) {
+	ctx, span := tracing.Start(ctx, "DefaultService.GetBookV2")
+	defer func() { tracing.End(span, err) }()
+
+	if err := checkIncludeDeleted(ctx, includeDeleted); err != nil {
+		return nil, err
+	}
+
+	return cached(s, cacheKey("GetBookV2", isbn13, includeDeleted), func() (*models.BookV2, error) {
+		book, err := s.getBookByISBN(ctx, isbn13, includeDeleted)
+		if err != nil {
+			return nil, err
+		}
+
+		authors, err := s.ListBookAuthors(ctx, []int64{book.Book.BookID})
+		if err != nil {
+			return nil, err
+		}
+
+		res := newBookV2(newBookArg{
+			Book:         book.Book,
+			Contributors: splitContributors(book.Contributors),
+			Publisher:    book.PublisherName,
+			Subjects:     splitList(book.Subjects),
+		}, authors[book.Book.BookID])
+
+		return &res, nil
+	})
+}
+
+type ListBooksV2Req struct {
+	Title              string    `form:"title" binding:"omitempty"`
+	MinPriceCents      int64     `form:"min_price_cents,default=-1" binding:"omitempty"`
+	MaxPriceCents      int64     `form:"max_price_cents,default=-1" binding:"omitempty"`
+	MinPublicationYear int32     `form:"min_publication_year,default=-1" binding:"omitempty,numeric"`
+	MaxPublicationYear int32     `form:"max_publication_year,default=-1" binding:"omitempty,numeric"`
+	Author             string    `form:"author" binding:"omitempty"`
+	Publisher          string    `form:"publisher" binding:"omitempty"`
+	Language           string    `form:"language" binding:"omitempty,bcp47_language_tag"`
+	Format             string    `form:"format" binding:"omitempty,oneof=hardcover paperback ebook audiobook"`
+	MinPageCount       int32     `form:"min_page_count" binding:"omitempty,min=1"`
+	MaxPageCount       int32     `form:"max_page_count" binding:"omitempty,min=1"`
+	SeriesName         string    `form:"series_name" binding:"omitempty"`
+	SeriesNumber       int32     `form:"series_number" binding:"omitempty,min=1"`
+	Description        string    `form:"description" binding:"omitempty"`
+	Subject            string    `form:"subject" binding:"omitempty"`
+	UpdatedSince       time.Time `form:"updated_since"`
+	IncludeDeleted     bool      `form:"include_deleted"`
+	Cursor             string    `form:"cursor"`                                             // next_cursor of the previous page
+	Limit              int32     `form:"limit,default=20" binding:"omitempty,min=1,max=100"` // most books listed
+}
+
+// bookCursor is the position of a book in a list, which is ordered by title
+type bookCursor struct {
+	Title string `json:"title"`
+	ID    int64  `json:"id"`
+}
+
+// ListBooksV2 lists books by title, a page at a time
+func (s *DefaultService) ListBooksV2(ctx context.Context, req ListBooksV2Req) (_ *util.CursorList[models.BookV2], err error) {
+	ctx, span := tracing.Start(ctx, "DefaultService.ListBooksV2")
+	defer func() { tracing.End(span, err) }()
+
+	if err := checkIncludeDeleted(ctx, req.IncludeDeleted); err != nil {
+		return nil, err
+	}
+
+	return cached(s, cacheKey("ListBooksV2", req), func() (*util.CursorList[models.BookV2], error) {
+		arg := listBooksParams(ListBooksReq{
+			Title:              req.Title,
+			MinPublicationYear: req.MinPublicationYear,
+			MaxPublicationYear: req.MaxPublicationYear,
+			Author:             req.Author,
+			Publisher:          req.Publisher,
+			Language:           req.Language,
+			Format:             req.Format,
+			MinPageCount:       req.MinPageCount,
+			MaxPageCount:       req.MaxPageCount,
+			SeriesName:         req.SeriesName,
+			SeriesNumber:       req.SeriesNumber,
+			Description:        req.Description,
+			Subject:            req.Subject,
+			UpdatedSince:       req.UpdatedSince,
+			IncludeDeleted:     req.IncludeDeleted,
+		})
+		arg.MinPrice = sql.NullFloat64{
+			Float64: float64(req.MinPriceCents) / 100,
+			Valid:   req.MinPriceCents >= 0,
+		}
+		arg.MaxPrice = sql.NullFloat64{
+			Float64: float64(req.MaxPriceCents) / 100,
+			Valid:   req.MaxPriceCents > req.MinPriceCents,
+		}
+		// one more than the limit tells whether there is a next page
+		arg.Limit = int64(req.Limit) + 1
+
+		if len(req.Cursor) > 0 {
+			var cursor bookCursor
+			if err := util.DecodeCursor(req.Cursor, &cursor); err != nil {
+				return nil, ErrInvalidCursor
+			}
+			arg.AfterTitle = sql.NullString{String: cursor.Title, Valid: true}
+			arg.AfterID = sql.NullInt64{Int64: cursor.ID, Valid: true}
+		}
+
+		rows, err := s.store.ListBooks(ctx, arg)
+		if err != nil {
+			return nil, err
+		}
+
+		res := util.CursorList[models.BookV2]{
+			Items: make([]models.BookV2, 0, len(rows)),
+		}
+		if len(rows) > int(req.Limit) {
+			rows = rows[:req.Limit]
+			last := rows[len(rows)-1].Book
+			res.NextCursor = util.EncodeCursor(bookCursor{Title: last.Title, ID: last.BookID})
+		}
+		if len(rows) == 0 {
+			return &res, nil
+		}
+
+		bookIDs := make([]int64, len(rows))
+		for i, row := range rows {
+			bookIDs[i] = row.Book.BookID
+		}
+		authors, err := s.ListBookAuthors(ctx, bookIDs)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, row := range rows {
+			res.Items = append(res.Items, newBookV2(newBookArg{
+				Book:         row.Book,
+				Contributors: splitContributors(row.Contributors),
+				Publisher:    row.PublisherName,
+				Subjects:     splitList(row.Subjects),
+			}, authors[row.Book.BookID]))
+		}
+
+		return &res, nil
+	})
+}
@@ -0,0 +1,46 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/atsuyaourt/xyz-books/internal/cache"
+)
+
+// WithCache caches the book reads, none are cached by default. Services given
+// the same cache share it, a write through any of them empties it.
+func WithCache(c *cache.LRU) Option {
+	return func(s *DefaultService) {
+		s.cache = c
+	}
+}
+
+// cached returns the value of key from the cache, or loads it. The lists
+// depend on every book, and the books on their authors and publisher, so
+// entries are not invalidated one by one.
+func cached[T any](s *DefaultService, key string, load func() (T, error)) (T, error) {
+	if s.cache == nil {
+		return load()
+	}
+
+	value, err := s.cache.Load(key, func() (any, error) {
+		return load()
+	})
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+
+	return value.(T), nil
+}
+
+// cacheKey returns the key of a read, named after the method
+func cacheKey(method string, args ...any) string {
+	return fmt.Sprintf("%s%+v", method, args)
+}
+
+// invalidate empties the cache after a write
+func (s *DefaultService) invalidate() {
+	if s.cache != nil {
+		s.cache.Purge()
+	}
+}
@@ -0,0 +1,54 @@
+package services
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/atsuyaourt/xyz-books/internal/cache"
+	db "github.com/atsuyaourt/xyz-books/internal/db/sqlc"
+	mockdb "github.com/atsuyaourt/xyz-books/internal/mocks/db"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+)
+
+func TestCachedBookReads(t *testing.T) {
+	ctx := context.Background()
+	isbn13 := "9780000000002"
+	book := db.GetBookByISBNRow{Book: db.Book{
+		BookID:  1,
+		Title:   "Cached",
+		Isbn13:  sql.NullString{String: isbn13, Valid: true},
+		Version: 1,
+	}}
+
+	store := mockdb.NewMockStore(t)
+	store.EXPECT().GetBookByISBN(mock.Anything, mock.Anything).Return(book, nil).Times(2)
+	store.EXPECT().ListBooks(mock.Anything, mock.Anything).Return([]db.ListBooksRow{{Book: book.Book}}, nil).Times(2)
+	store.EXPECT().CountBooks(mock.Anything, mock.Anything).Return(int64(1), nil).Times(2)
+	store.EXPECT().ExecTx(mock.Anything, mock.Anything).Return(nil).Once()
+
+	c := cache.NewLRU(10, 0)
+	service, err := NewDefaultService(store, WithCache(c))
+	require.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		res, err := service.GetBook(ctx, isbn13, false)
+		require.NoError(t, err)
+		require.Equal(t, "Cached", res.Title)
+
+		list, err := service.ListBooks(ctx, ListBooksReq{Page: 1, PerPage: 5})
+		require.NoError(t, err)
+		require.Len(t, list.Items, 1)
+	}
+	require.Equal(t, cache.Stats{Hits: 2, Misses: 2, Size: 2}, c.Stats())
+
+	// a write empties the cache
+	require.NoError(t, service.DeleteBook(ctx, isbn13, 0))
+	require.Equal(t, 0, c.Stats().Size)
+
+	_, err = service.GetBook(ctx, isbn13, false)
+	require.NoError(t, err)
+	_, err = service.ListBooks(ctx, ListBooksReq{Page: 1, PerPage: 5})
+	require.NoError(t, err)
+}
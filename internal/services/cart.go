@@ -0,0 +1,264 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"math"
+
+	db "github.com/atsuyaourt/xyz-books/internal/db/sqlc"
+	"github.com/atsuyaourt/xyz-books/internal/models"
+	"github.com/atsuyaourt/xyz-books/internal/tracing"
+	"github.com/atsuyaourt/xyz-books/internal/util"
+	"golang.org/x/net/context"
+)
+
+// CartOwner identifies a cart. Anonymous visitors only have a Token
+// (from a cookie), signed-in users also have a UserID.
+type CartOwner struct {
+	Token  string
+	UserID string
+}
+
+func newCart(rows []db.ListCartItemsRow) models.Cart {
+	res := models.Cart{
+		Items: make([]models.CartItem, len(rows)),
+	}
+
+	for i, row := range rows {
+		item := models.CartItem{
+			Title:    row.Book.Title,
+			Price:    row.Book.Price,
+			Quantity: row.Quantity,
+			ImageUrl: bookImageUrl(row.Book),
+			Subtotal: roundPrice(row.Book.Price * float64(row.Quantity)),
+		}
+		if row.Book.Isbn13.Valid {
+			item.ISBN13 = row.Book.Isbn13.String
+		}
+		if row.Book.Isbn10.Valid {
+			item.ISBN10 = row.Book.Isbn10.String
+		}
+
+		res.Items[i] = item
+		res.ItemCount += item.Quantity
+		res.Total += item.Subtotal
+	}
+	res.Total = roundPrice(res.Total)
+
+	return res
+}
+
+func roundPrice(p float64) float64 {
+	return math.Round(p*100) / 100
+}
+
+// findCart returns the cart of the owner. An anonymous cart is claimed by,
+// or merged into, the user's cart once the owner has a UserID.
+// If the owner has no cart yet, one is created only when create is set.
+func (s *DefaultService) findCart(ctx context.Context, owner CartOwner, create bool) (*db.Cart, error) {
+	var tokenCart *db.Cart
+	if len(owner.Token) > 0 {
+		cart, err := s.store.GetCartByToken(ctx, owner.Token)
+		if err != nil && !errors.Is(err, db.ErrRecordNotFound) {
+			return nil, err
+		}
+		if err == nil {
+			tokenCart = &cart
+		}
+	}
+
+	if len(owner.UserID) == 0 {
+		if tokenCart != nil || !create {
+			return tokenCart, nil
+		}
+		cart, err := s.store.CreateCart(ctx, db.CreateCartParams{Token: owner.Token})
+		if err != nil {
+			return nil, err
+		}
+		return &cart, nil
+	}
+
+	userID := sql.NullString{String: owner.UserID, Valid: true}
+	if tokenCart != nil && tokenCart.UserID == userID {
+		return tokenCart, nil
+	}
+
+	userCart, err := s.store.GetCartByUserID(ctx, userID)
+	if err != nil && !errors.Is(err, db.ErrRecordNotFound) {
+		return nil, err
+	}
+	hasUserCart := err == nil
+	isAnonymous := tokenCart != nil && !tokenCart.UserID.Valid
+
+	switch {
+	case hasUserCart && isAnonymous:
+		err = s.store.MergeCartTx(ctx, db.MergeCartTxParams{
+			FromCartID: tokenCart.CartID,
+			ToCartID:   userCart.CartID,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return &userCart, nil
+	case hasUserCart:
+		return &userCart, nil
+	case isAnonymous:
+		cart, err := s.store.SetCartUserID(ctx, db.SetCartUserIDParams{
+			CartID: tokenCart.CartID,
+			UserID: userID,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return &cart, nil
+	case !create:
+		return nil, nil
+	}
+
+	token := owner.Token
+	if tokenCart != nil || len(token) == 0 {
+		// the token already belongs to someone else's cart
+		token = util.NewToken()
+	}
+	cart, err := s.store.CreateCart(ctx, db.CreateCartParams{
+		Token:  token,
+		UserID: userID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &cart, nil
+}
+
+func (s *DefaultService) getCart(ctx context.Context, cartID int64) (*models.Cart, error) {
+	rows, err := s.store.ListCartItems(ctx, cartID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := newCart(rows)
+
+	return &res, nil
+}
+
+func (s *DefaultService) GetCart(ctx context.Context, owner CartOwner) (_ *models.Cart, err error) {
+	ctx, span := tracing.Start(ctx, "DefaultService.GetCart")
+	defer func() { tracing.End(span, err) }()
+
+	cart, err := s.findCart(ctx, owner, false)
+	if err != nil {
+		return nil, err
+	}
+	if cart == nil {
+		res := newCart(nil)
+		return &res, nil
+	}
+
+	return s.getCart(ctx, cart.CartID)
+}
+
+type AddCartItemReq struct {
+	ISBN13   string `json:"isbn13" form:"isbn13" binding:"required,isbn13"`
+	Quantity int64  `json:"quantity" form:"quantity" binding:"omitempty,min=1,max=99"`
+} //@name AddCartItemParams
+
+func (s *DefaultService) AddCartItem(ctx context.Context, owner CartOwner, req AddCartItemReq) (_ *models.Cart, err error) {
+	ctx, span := tracing.Start(ctx, "DefaultService.AddCartItem")
+	defer func() { tracing.End(span, err) }()
+
+	book, err := s.getBookByISBN(ctx, req.ISBN13, false)
+	if err != nil {
+		return nil, err
+	}
+
+	cart, err := s.findCart(ctx, owner, true)
+	if err != nil {
+		return nil, err
+	}
+
+	quantity := req.Quantity
+	if quantity < 1 {
+		quantity = 1
+	}
+
+	err = s.store.AddCartItem(ctx, db.AddCartItemParams{
+		CartID:   cart.CartID,
+		BookID:   book.Book.BookID,
+		Quantity: quantity,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.getCart(ctx, cart.CartID)
+}
+
+type UpdateCartItemReq struct {
+	Quantity int64 `json:"quantity" form:"quantity" binding:"min=0,max=99"` // zero removes the item
+} //@name UpdateCartItemParams
+
+func (s *DefaultService) UpdateCartItem(ctx context.Context, owner CartOwner, isbn13 string, req UpdateCartItemReq) (_ *models.Cart, err error) {
+	ctx, span := tracing.Start(ctx, "DefaultService.UpdateCartItem")
+	defer func() { tracing.End(span, err) }()
+
+	if req.Quantity == 0 {
+		return s.RemoveCartItem(ctx, owner, isbn13)
+	}
+
+	book, err := s.getBookByISBN(ctx, isbn13, false)
+	if err != nil {
+		return nil, err
+	}
+
+	cart, err := s.findCart(ctx, owner, false)
+	if err != nil {
+		return nil, err
+	}
+	if cart == nil {
+		return nil, db.ErrRecordNotFound
+	}
+
+	n, err := s.store.SetCartItemQuantity(ctx, db.SetCartItemQuantityParams{
+		CartID:   cart.CartID,
+		BookID:   book.Book.BookID,
+		Quantity: req.Quantity,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if n == 0 {
+		return nil, db.ErrRecordNotFound
+	}
+
+	return s.getCart(ctx, cart.CartID)
+}
+
+func (s *DefaultService) RemoveCartItem(ctx context.Context, owner CartOwner, isbn13 string) (_ *models.Cart, err error) {
+	ctx, span := tracing.Start(ctx, "DefaultService.RemoveCartItem")
+	defer func() { tracing.End(span, err) }()
+
+	book, err := s.getBookByISBN(ctx, isbn13, false)
+	if err != nil {
+		return nil, err
+	}
+
+	cart, err := s.findCart(ctx, owner, false)
+	if err != nil {
+		return nil, err
+	}
+	if cart == nil {
+		res := newCart(nil)
+		return &res, nil
+	}
+
+	err = s.store.DeleteCartItem(ctx, db.DeleteCartItemParams{
+		CartID: cart.CartID,
+		BookID: book.Book.BookID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.getCart(ctx, cart.CartID)
+}
@@ -0,0 +1,71 @@
+package services
+
+import (
+	"time"
+
+	db "github.com/atsuyaourt/xyz-books/internal/db/sqlc"
+	"github.com/atsuyaourt/xyz-books/internal/models"
+	"github.com/atsuyaourt/xyz-books/internal/tracing"
+	"golang.org/x/net/context"
+)
+
+type ListChangesReq struct {
+	Since time.Time `form:"since"`                                                // only list changes after this time (RFC 3339)
+	Limit int32     `form:"limit,default=100" binding:"omitempty,min=1,max=1000"` // most changes listed
+} //@name ListChangesParams
+
+// ListChanges lists the books, authors and publishers changed after a time,
+// oldest first. Changes made at the same time are never split across reads,
+// a read may return more than the limit to keep them together.
+func (s *DefaultService) ListChanges(ctx context.Context, req ListChangesReq) (_ *models.ChangeFeed, err error) {
+	ctx, span := tracing.Start(ctx, "DefaultService.ListChanges")
+	defer func() { tracing.End(span, err) }()
+
+	limit := int(req.Limit)
+	for {
+		rows, err := s.store.ListChanges(ctx, db.ListChangesParams{
+			Since: db.After(req.Since),
+			Limit: int64(limit + 1),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if len(rows) <= limit {
+			return newChangeFeed(req.Since, rows, false), nil
+		}
+
+		// leave out the changes made at the same time as the first one over
+		// the limit, unless that would leave nothing to read
+		n := limit
+		for n > 0 && rows[n-1].UpdatedAt.Equal(rows[limit].UpdatedAt) {
+			n--
+		}
+		if n > 0 {
+			return newChangeFeed(req.Since, rows[:n], true), nil
+		}
+
+		limit *= 2
+	}
+}
+
+func newChangeFeed(since time.Time, rows []db.ListChangesRow, hasMore bool) *models.ChangeFeed {
+	items := make([]models.Change, len(rows))
+	for i, row := range rows {
+		items[i] = models.Change{
+			Type:      row.Entity,
+			ID:        row.EntityID,
+			ISBN:      row.Isbn,
+			Version:   row.Version,
+			UpdatedAt: row.UpdatedAt,
+			Deleted:   row.Deleted,
+		}
+		since = row.UpdatedAt
+	}
+
+	return &models.ChangeFeed{
+		Items:   items,
+		Since:   since,
+		HasMore: hasMore,
+	}
+}
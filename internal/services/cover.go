@@ -0,0 +1,299 @@
+package services
+
+import (
+	"bytes"
+	"database/sql"
+	"errors"
+	"fmt"
+	"image"
+	"image/jpeg"
+	_ "image/png"
+	"io"
+	"net/http"
+	"strings"
+
+	db "github.com/atsuyaourt/xyz-books/internal/db/sqlc"
+	"github.com/atsuyaourt/xyz-books/internal/models"
+	"github.com/atsuyaourt/xyz-books/internal/tracing"
+	"github.com/atsuyaourt/xyz-books/internal/util"
+	_ "golang.org/x/image/webp"
+	"golang.org/x/net/context"
+)
+
+var (
+	ErrCoverTooLarge    = errors.New("cover image is too large")
+	ErrUnsupportedImage = errors.New("unsupported image type")
+)
+
+const (
+	defaultCoverMaxSize = 5 << 20
+	// maxCoverPixels guards against small files that decode to huge images
+	maxCoverPixels = 50_000_000
+
+	coverKeyPrefix = "covers/"
+	coverBaseUrl   = "/covers/"
+
+	placeholderUrlFormat = "/books/%s/cover.svg"
+)
+
+// CoverWidths are the widths, in pixels, of the generated cover thumbnails
+var CoverWidths = []int{112, 224, 448}
+
+// coverTypes maps the accepted sniffed content types to file extensions
+var coverTypes = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/webp": ".webp",
+}
+
+// coverThumbnailKey derives the key of a thumbnail from the key of the original image
+func coverThumbnailKey(key string, width int) string {
+	if i := strings.LastIndex(key, "."); i > strings.LastIndex(key, "/") {
+		key = key[:i]
+	}
+
+	return fmt.Sprintf("%s_%d.jpg", key, width)
+}
+
+func coverKeys(key string) []string {
+	keys := []string{key}
+	for _, w := range CoverWidths {
+		keys = append(keys, coverThumbnailKey(key, w))
+	}
+
+	return keys
+}
+
+func newCover(key string) *models.Cover {
+	res := &models.Cover{
+		Url:        coverBaseUrl + strings.TrimPrefix(key, coverKeyPrefix),
+		Thumbnails: make([]models.CoverThumbnail, len(CoverWidths)),
+	}
+
+	for i, w := range CoverWidths {
+		res.Thumbnails[i] = models.CoverThumbnail{
+			Width: w,
+			Url:   coverBaseUrl + strings.TrimPrefix(coverThumbnailKey(key, w), coverKeyPrefix),
+		}
+	}
+
+	return res
+}
+
+// bookImageUrl picks the uploaded cover, then the external image
+// and finally the generated placeholder
+func bookImageUrl(book db.Book) string {
+	if book.CoverKey.Valid {
+		return newCover(book.CoverKey.String).Url
+	}
+	if book.ImageUrl.Valid && len(book.ImageUrl.String) > 0 {
+		return book.ImageUrl.String
+	}
+
+	return placeholderUrl(book)
+}
+
+// placeholderUrl is the generated cover of a book, empty when it has no ISBN
+func placeholderUrl(book db.Book) string {
+	isbn := util.NewISBN(book.Isbn13.String)
+	if len(isbn.ISBN13) == 0 {
+		isbn = util.NewISBN(book.Isbn10.String)
+	}
+	if len(isbn.ISBN13) == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf(placeholderUrlFormat, isbn.ISBN13)
+}
+
+// readCover reads and validates an uploaded cover image, going by its
+// content rather than by the name or content type given by the client.
+func (s *DefaultService) readCover(r io.Reader) ([]byte, string, image.Image, error) {
+	data, err := io.ReadAll(io.LimitReader(r, s.coverMaxSize+1))
+	if err != nil {
+		return nil, "", nil, err
+	}
+	if int64(len(data)) > s.coverMaxSize {
+		return nil, "", nil, ErrCoverTooLarge
+	}
+
+	ext, ok := coverTypes[http.DetectContentType(data)]
+	if !ok {
+		return nil, "", nil, ErrUnsupportedImage
+	}
+
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", nil, fmt.Errorf("%w: %v", ErrUnsupportedImage, err)
+	}
+	if cfg.Width*cfg.Height > maxCoverPixels {
+		return nil, "", nil, ErrCoverTooLarge
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", nil, fmt.Errorf("%w: %v", ErrUnsupportedImage, err)
+	}
+
+	return data, ext, img, nil
+}
+
+func (s *DefaultService) putCover(ctx context.Context, key string, data []byte, img image.Image) error {
+	if err := s.blobs.Put(ctx, key, bytes.NewReader(data)); err != nil {
+		return err
+	}
+
+	for _, w := range CoverWidths {
+		var buf bytes.Buffer
+		err := jpeg.Encode(&buf, util.ResizeToWidth(img, w), &jpeg.Options{Quality: 85})
+		if err != nil {
+			return err
+		}
+		if err = s.blobs.Put(ctx, coverThumbnailKey(key, w), &buf); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *DefaultService) deleteCover(ctx context.Context, key string) {
+	for _, k := range coverKeys(key) {
+		s.blobs.Delete(ctx, k)
+	}
+}
+
+func (s *DefaultService) UploadBookCover(ctx context.Context, isbn13 string, r io.Reader) (_ *models.Book, err error) {
+	ctx, span := tracing.Start(ctx, "DefaultService.UploadBookCover")
+	defer func() { tracing.End(span, err) }()
+	defer s.invalidate()
+
+	data, ext, img, err := s.readCover(r)
+	if err != nil {
+		return nil, err
+	}
+
+	book, err := s.getBookByISBN(ctx, isbn13, false)
+	if err != nil {
+		return nil, err
+	}
+
+	// every upload gets a new key so that the images can be cached forever
+	key := fmt.Sprintf("%s%d/%s%s", coverKeyPrefix, book.Book.BookID, util.NewToken()[:16], ext)
+	if err = s.putCover(ctx, key, data, img); err != nil {
+		s.deleteCover(ctx, key)
+		return nil, err
+	}
+
+	var (
+		res    models.Book
+		events []models.Event
+	)
+	err = s.store.ExecTx(ctx, func(q db.Querier) error {
+		updated, err := q.SetBookCover(ctx, db.SetBookCoverParams{
+			BookID: book.Book.BookID,
+			CoverKey: sql.NullString{
+				String: key,
+				Valid:  true,
+			},
+		})
+		if err != nil {
+			return err
+		}
+
+		res = newBook(newBookArg{
+			Book:         updated,
+			Authors:      splitList(book.Authors),
+			Contributors: splitContributors(book.Contributors),
+			Publisher:    book.PublisherName,
+			Subjects:     splitList(book.Subjects),
+		})
+		if !s.publishing() {
+			return nil
+		}
+
+		events = []models.Event{newEvent(models.EventBookUpdated, res)}
+		return s.enqueue(ctx, q, events...)
+	})
+	if err != nil {
+		s.deleteCover(ctx, key)
+		return nil, err
+	}
+
+	if book.Book.CoverKey.Valid {
+		s.deleteCover(ctx, book.Book.CoverKey.String)
+	}
+	s.publish(ctx, events...)
+
+	return &res, nil
+}
+
+func (s *DefaultService) DeleteBookCover(ctx context.Context, isbn13 string) (err error) {
+	ctx, span := tracing.Start(ctx, "DefaultService.DeleteBookCover")
+	defer func() { tracing.End(span, err) }()
+	defer s.invalidate()
+
+	book, err := s.getBookByISBN(ctx, isbn13, false)
+	if err != nil {
+		return err
+	}
+	if !book.Book.CoverKey.Valid {
+		return nil
+	}
+
+	var events []models.Event
+	err = s.store.ExecTx(ctx, func(q db.Querier) error {
+		updated, err := q.SetBookCover(ctx, db.SetBookCoverParams{
+			BookID: book.Book.BookID,
+		})
+		if err != nil || !s.publishing() {
+			return err
+		}
+
+		events = []models.Event{newEvent(models.EventBookUpdated, newBook(newBookArg{
+			Book:         updated,
+			Authors:      splitList(book.Authors),
+			Contributors: splitContributors(book.Contributors),
+			Publisher:    book.PublisherName,
+			Subjects:     splitList(book.Subjects),
+		}))}
+		return s.enqueue(ctx, q, events...)
+	})
+	if err != nil {
+		return err
+	}
+
+	s.deleteCover(ctx, book.Book.CoverKey.String)
+	s.publish(ctx, events...)
+
+	return nil
+}
+
+// GetCoverImage opens a cover image or thumbnail by the path under which it is served
+func (s *DefaultService) GetCoverImage(ctx context.Context, name string) (_ io.ReadCloser, err error) {
+	ctx, span := tracing.Start(ctx, "DefaultService.GetCoverImage")
+	defer func() { tracing.End(span, err) }()
+
+	return s.blobs.Get(ctx, coverKeyPrefix+name)
+}
+
+// GetCoverPlaceholder lays out a generated cover for books without an image
+func (s *DefaultService) GetCoverPlaceholder(ctx context.Context, isbn13 string) (_ *util.CoverLayout, err error) {
+	ctx, span := tracing.Start(ctx, "DefaultService.GetCoverPlaceholder")
+	defer func() { tracing.End(span, err) }()
+
+	book, err := s.GetBook(ctx, isbn13, false)
+	if err != nil {
+		return nil, err
+	}
+
+	res := util.NewCoverLayout(util.CoverInfo{
+		Seed:      book.ISBN13,
+		Title:     book.Title,
+		Authors:   book.Authors,
+		Edition:   book.Edition,
+		Publisher: book.Publisher,
+	})
+
+	return &res, nil
+}
@@ -1,16 +1,58 @@
 package services
 
 import (
+	"github.com/atsuyaourt/xyz-books/internal/cache"
 	db "github.com/atsuyaourt/xyz-books/internal/db/sqlc"
+	"github.com/atsuyaourt/xyz-books/internal/storage"
 )
 
+const defaultBlobStorePath = "tmp/blobs"
+
 type DefaultService struct {
-	store db.Store
+	store        db.Store
+	payment      PaymentProvider
+	blobs        storage.BlobStore
+	coverMaxSize int64
+	events       []EventPublisher
+	outbox       []TxEventPublisher
+	bus          *EventBus
+	cache        *cache.LRU
 }
 
-func NewDefaultService(store db.Store) (*DefaultService, error) {
+type Option func(*DefaultService)
+
+// WithPaymentProvider replaces the default fake payment provider
+func WithPaymentProvider(p PaymentProvider) Option {
+	return func(s *DefaultService) {
+		s.payment = p
+	}
+}
+
+// WithBlobStore sets where uploaded images are stored,
+// a local directory is used by default
+func WithBlobStore(b storage.BlobStore) Option {
+	return func(s *DefaultService) {
+		s.blobs = b
+	}
+}
+
+// WithCoverMaxSize sets the maximum size, in bytes, of an uploaded cover image
+func WithCoverMaxSize(n int64) Option {
+	return func(s *DefaultService) {
+		s.coverMaxSize = n
+	}
+}
+
+func NewDefaultService(store db.Store, opts ...Option) (*DefaultService, error) {
 	s := &DefaultService{
-		store: store,
+		store:        store,
+		payment:      NewFakePaymentProvider(),
+		blobs:        storage.NewLocalStore(defaultBlobStorePath),
+		coverMaxSize: defaultCoverMaxSize,
+	}
+
+	for _, opt := range opts {
+		opt(s)
 	}
 
 	return s, nil
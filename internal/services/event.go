@@ -0,0 +1,185 @@
+package services
+
+import (
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	db "github.com/atsuyaourt/xyz-books/internal/db/sqlc"
+	"github.com/atsuyaourt/xyz-books/internal/logging"
+	"github.com/atsuyaourt/xyz-books/internal/models"
+	"github.com/atsuyaourt/xyz-books/internal/tracing"
+	"github.com/atsuyaourt/xyz-books/internal/util"
+	"golang.org/x/net/context"
+)
+
+const eventBusBufferSize = 16
+
+// ErrNoEventBus is returned when events are subscribed to without an event bus
+var ErrNoEventBus = errors.New("events are not streamed")
+
+// EventPublisher receives the catalog events of the service once their
+// change is committed. An event is lost if the process stops in between.
+type EventPublisher interface {
+	Publish(ctx context.Context, event models.Event) error
+}
+
+// TxEventPublisher queues the catalog events of the service in the
+// transaction of their change (a transactional outbox), so that an event is
+// kept if and only if its change is committed
+type TxEventPublisher interface {
+	PublishTx(ctx context.Context, q db.Querier, event models.Event) error
+}
+
+// WithEventPublisher publishes catalog events, none are published by default.
+// It can be given more than once, events go to every publisher.
+func WithEventPublisher(p EventPublisher) Option {
+	return func(s *DefaultService) {
+		s.events = append(s.events, p)
+	}
+}
+
+// WithEventOutbox queues catalog events in the transaction of their change.
+// It can be given more than once, events go to every outbox.
+func WithEventOutbox(p TxEventPublisher) Option {
+	return func(s *DefaultService) {
+		s.outbox = append(s.outbox, p)
+	}
+}
+
+// WithEventBus publishes catalog events to an in-process bus, whose
+// subscribers are served by SubscribeEvents
+func WithEventBus(b *EventBus) Option {
+	return func(s *DefaultService) {
+		s.bus = b
+		s.events = append(s.events, b)
+	}
+}
+
+func newEvent(typ models.EventType, data any) models.Event {
+	return models.Event{
+		ID:        util.NewToken(),
+		Type:      typ,
+		CreatedAt: time.Now().UTC(),
+		Data:      data,
+	}
+}
+
+// publishing reports whether the events of a change go anywhere, so that
+// building them can be skipped
+func (s *DefaultService) publishing() bool {
+	return len(s.outbox) > 0 || len(s.events) > 0
+}
+
+// enqueue adds events to the outboxes, q is the transaction of the change
+// they describe. A failure rolls the change back.
+func (s *DefaultService) enqueue(ctx context.Context, q db.Querier, events ...models.Event) error {
+	for _, p := range s.outbox {
+		for _, event := range events {
+			if err := p.PublishTx(ctx, q, event); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// publish sends events once their change is committed. The change stands
+// even if an event cannot be published, so failures are only logged.
+func (s *DefaultService) publish(ctx context.Context, events ...models.Event) {
+	for _, event := range events {
+		for _, p := range s.events {
+			if err := p.Publish(ctx, event); err != nil {
+				logging.FromContext(ctx).Error("cannot publish event",
+					slog.String("type", string(event.Type)), slog.Any("error", err))
+			}
+		}
+	}
+}
+
+// SubscribeEvents returns the events published from now on, until ctx is
+// done or the event bus is closed
+func (s *DefaultService) SubscribeEvents(ctx context.Context) (_ <-chan models.Event, err error) {
+	ctx, span := tracing.Start(ctx, "DefaultService.SubscribeEvents")
+	defer func() { tracing.End(span, err) }()
+
+	if s.bus == nil {
+		return nil, ErrNoEventBus
+	}
+
+	return s.bus.Subscribe(ctx), nil
+}
+
+// EventBus passes events to the subscribers in the same process. It never
+// blocks the publisher: a subscriber that falls behind misses events.
+type EventBus struct {
+	mu     sync.Mutex
+	subs   map[chan models.Event]struct{}
+	closed bool
+}
+
+func NewEventBus() *EventBus {
+	return &EventBus{
+		subs: make(map[chan models.Event]struct{}),
+	}
+}
+
+func (b *EventBus) Publish(ctx context.Context, event models.Event) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for ch := range b.subs {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+
+	return nil
+}
+
+// Subscribe returns a channel of the events published from now on. It is
+// closed when ctx is done or the bus is closed.
+func (b *EventBus) Subscribe(ctx context.Context) <-chan models.Event {
+	ch := make(chan models.Event, eventBusBufferSize)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		close(ch)
+		return ch
+	}
+	b.subs[ch] = struct{}{}
+
+	go func() {
+		<-ctx.Done()
+		b.unsubscribe(ch)
+	}()
+
+	return ch
+}
+
+func (b *EventBus) unsubscribe(ch chan models.Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, ok := b.subs[ch]; ok {
+		delete(b.subs, ch)
+		close(ch)
+	}
+}
+
+// Close ends every subscription, so that long-lived streams can finish
+func (b *EventBus) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.closed = true
+	for ch := range b.subs {
+		delete(b.subs, ch)
+		close(ch)
+	}
+}
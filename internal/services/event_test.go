@@ -0,0 +1,201 @@
+package services
+
+import (
+	"bytes"
+	"database/sql"
+	"errors"
+	"image"
+	"image/png"
+	"testing"
+	"time"
+
+	db "github.com/atsuyaourt/xyz-books/internal/db/sqlc"
+	mockdb "github.com/atsuyaourt/xyz-books/internal/mocks/db"
+	"github.com/atsuyaourt/xyz-books/internal/models"
+	"github.com/atsuyaourt/xyz-books/internal/storage"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+)
+
+func TestEventBus(t *testing.T) {
+	bus := NewEventBus()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	first := bus.Subscribe(ctx)
+	second := bus.Subscribe(context.Background())
+
+	event := models.Event{ID: "e1", Type: models.EventBookCreated}
+	require.NoError(t, bus.Publish(context.Background(), event))
+	require.Equal(t, event, <-first)
+	require.Equal(t, event, <-second)
+
+	// a subscription ends with its context
+	cancel()
+	requireClosed(t, first)
+
+	bus.Close()
+	requireClosed(t, second)
+	requireClosed(t, bus.Subscribe(context.Background()))
+	require.NoError(t, bus.Publish(context.Background(), event))
+}
+
+func TestEventBusSlowSubscriber(t *testing.T) {
+	bus := NewEventBus()
+	events := bus.Subscribe(context.Background())
+
+	// publishing does not wait for a full subscriber
+	for i := 0; i < eventBusBufferSize+5; i++ {
+		require.NoError(t, bus.Publish(context.Background(), models.Event{Type: models.EventBookUpdated}))
+	}
+	require.Len(t, events, eventBusBufferSize)
+}
+
+// eventRecorder keeps the events queued in a transaction of the mock store,
+// and the ones published once it is committed
+type eventRecorder struct {
+	queued    []models.Event
+	published []models.Event
+}
+
+func (r *eventRecorder) PublishTx(_ context.Context, q db.Querier, event models.Event) error {
+	if _, ok := q.(*mockdb.MockStore); !ok {
+		return errors.New("not in the transaction")
+	}
+	r.queued = append(r.queued, event)
+
+	return nil
+}
+
+func (r *eventRecorder) Publish(_ context.Context, event models.Event) error {
+	r.published = append(r.published, event)
+
+	return nil
+}
+
+func TestBookUpdatedEvents(t *testing.T) {
+	book := db.Book{
+		BookID:   1,
+		Title:    "The Hobbit",
+		Isbn13:   sql.NullString{String: "9780261102217", Valid: true},
+		Version:  2,
+		CoverKey: sql.NullString{String: "covers/1/old.png", Valid: true},
+	}
+	other := db.Book{
+		BookID: 2,
+		Title:  "The Silmarillion",
+		Isbn13: sql.NullString{String: "9780261102736", Valid: true},
+	}
+
+	var cover bytes.Buffer
+	require.NoError(t, png.Encode(&cover, image.NewRGBA(image.Rect(0, 0, 60, 90))))
+
+	testCases := []struct {
+		name       string
+		buildStubs func(store *mockdb.MockStore)
+		run        func(s *DefaultService) error
+		wantISBNs  []string
+	}{
+		{
+			name: "UpdateAuthor",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateAuthor(mock.Anything, mock.Anything).Return(db.Author{AuthorID: 1}, nil)
+				store.EXPECT().BumpAuthorBookVersions(mock.Anything, int64(1)).Return(nil)
+				store.EXPECT().ListAuthorBooks(mock.Anything, []int64{1}).Return([]db.ListAuthorBooksRow{
+					{AuthorID: 1, Book: book, Authors: "J R R Tolkien"},
+					{AuthorID: 1, Book: other, Authors: "J R R Tolkien"},
+				}, nil)
+			},
+			run: func(s *DefaultService) error {
+				_, err := s.UpdateAuthor(context.Background(), 1, 0, UpdateAuthorReq{LastName: "Tolkien"})
+				return err
+			},
+			wantISBNs: []string{book.Isbn13.String, other.Isbn13.String},
+		},
+		{
+			name: "UpdatePublisher",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdatePublisher(mock.Anything, mock.Anything).Return(db.Publisher{PublisherID: 1}, nil)
+				store.EXPECT().BumpPublisherBookVersions(mock.Anything, int64(1)).Return(nil)
+				store.EXPECT().ListPublisherBooks(mock.Anything, []int64{1}).Return([]db.ListPublisherBooksRow{
+					{PublisherID: 1, Book: book, PublisherName: "HarperCollins"},
+				}, nil)
+			},
+			run: func(s *DefaultService) error {
+				_, err := s.UpdatePublisher(context.Background(), 1, 0, UpdatePublisherReq{PublisherName: "HarperCollins"})
+				return err
+			},
+			wantISBNs: []string{book.Isbn13.String},
+		},
+		{
+			name: "UploadBookCover",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetBookByISBN(mock.Anything, mock.Anything).Return(db.GetBookByISBNRow{Book: book}, nil)
+				store.EXPECT().SetBookCover(mock.Anything, mock.Anything).
+					RunAndReturn(func(_ context.Context, arg db.SetBookCoverParams) (db.Book, error) {
+						b := book
+						b.CoverKey = arg.CoverKey
+						return b, nil
+					})
+			},
+			run: func(s *DefaultService) error {
+				_, err := s.UploadBookCover(context.Background(), book.Isbn13.String, bytes.NewReader(cover.Bytes()))
+				return err
+			},
+			wantISBNs: []string{book.Isbn13.String},
+		},
+		{
+			name: "DeleteBookCover",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetBookByISBN(mock.Anything, mock.Anything).Return(db.GetBookByISBNRow{Book: book}, nil)
+				store.EXPECT().SetBookCover(mock.Anything, db.SetBookCoverParams{BookID: book.BookID}).Return(other, nil)
+			},
+			run: func(s *DefaultService) error {
+				return s.DeleteBookCover(context.Background(), book.Isbn13.String)
+			},
+			wantISBNs: []string{other.Isbn13.String},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			store := mockdb.NewMockStore(t)
+			store.EXPECT().ExecTx(mock.Anything, mock.Anything).
+				RunAndReturn(func(ctx context.Context, fn func(db.Querier) error) error {
+					return fn(store)
+				})
+			tc.buildStubs(store)
+
+			recorder := &eventRecorder{}
+			s, err := NewDefaultService(store,
+				WithEventOutbox(recorder),
+				WithEventPublisher(recorder),
+				WithBlobStore(storage.NewLocalStore(t.TempDir())),
+			)
+			require.NoError(t, err)
+
+			require.NoError(t, tc.run(s))
+
+			// the books changed along are queued in the transaction, and
+			// published once it is committed
+			var isbns []string
+			for _, event := range recorder.queued {
+				require.Equal(t, models.EventBookUpdated, event.Type)
+				isbns = append(isbns, event.Data.(models.Book).ISBN13)
+			}
+			require.Equal(t, tc.wantISBNs, isbns)
+			require.Equal(t, recorder.queued, recorder.published)
+		})
+	}
+}
+
+func requireClosed(t *testing.T, events <-chan models.Event) {
+	select {
+	case _, ok := <-events:
+		require.False(t, ok)
+	case <-time.After(time.Second):
+		t.Fatal("subscription was not closed")
+	}
+}
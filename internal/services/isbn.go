@@ -1,32 +1,30 @@
 package services
 
 import (
-	"bytes"
-	"encoding/json"
+	"context"
 	"fmt"
-	"log"
+	"log/slog"
 	"net/http"
+	"os"
 
-	"github.com/atsuyaourt/xyz-books/internal/models"
+	"github.com/atsuyaourt/xyz-books/client"
+	"github.com/atsuyaourt/xyz-books/internal/metrics"
+	"github.com/atsuyaourt/xyz-books/internal/tracing"
 	"github.com/atsuyaourt/xyz-books/internal/util"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 )
 
-type HTTPClient interface {
-	Get(url string) (resp *http.Response, err error)
-	Do(req *http.Request) (*http.Response, error)
-}
-
 type ISBNService struct {
-	apiBasePath string
-	client      HTTPClient
-	csvWriter   util.Writer
+	client    *client.Client
+	csvWriter util.Writer
 }
 
 // NewService creates a new instance of the Service
 func NewISBNService(serverAddress string, outputPath string) *ISBNService {
 	config, err := util.LoadConfig(".")
 	if err != nil {
-		log.Fatalf("cannot load config: %s", err)
+		slog.Error("cannot load config", slog.Any("error", err))
+		os.Exit(1)
 	}
 
 	outputCSV := fmt.Sprintf("%s/isbn.csv", outputPath)
@@ -34,14 +32,15 @@ func NewISBNService(serverAddress string, outputPath string) *ISBNService {
 	cw, _ := util.NewCsvWriter(outputCSV)
 
 	return &ISBNService{
-		apiBasePath: fmt.Sprintf("http://%s%s", serverAddress, config.APIBasePath),
-		client:      &http.Client{},
-		csvWriter:   cw,
+		// the requests are traced and carry the trace context to the server
+		client: client.New(fmt.Sprintf("http://%s%s", serverAddress, config.APIBasePath),
+			client.WithHTTPClient(&http.Client{Transport: otelhttp.NewTransport(http.DefaultTransport)})),
+		csvWriter: cw,
 	}
 }
 
 func (s *ISBNService) Run() {
-	bookChan := make(chan models.Book)
+	bookChan := make(chan client.Book)
 	isbnChan := make(chan util.ISBN)
 	updateErrorChan := make(chan error)
 	csvWriteSuccessChan := make(chan bool)
@@ -67,40 +66,30 @@ func (s *ISBNService) Run() {
 
 	s.csvWriter.Flush()
 	if err := s.csvWriter.Error(); err != nil {
-		fmt.Println("Error closing CSV writer:", err)
+		slog.Error("cannot close CSV writer", slog.Any("error", err))
 	}
 }
 
 // fetchBooks Fetch books from the index endpoint
-func (s *ISBNService) fetchBooks(outChan chan<- models.Book) {
+func (s *ISBNService) fetchBooks(outChan chan<- client.Book) {
 	defer close(outChan)
 
-	nextPage := int32(1)
-
-	for nextPage != 0 {
-		res, err := s.client.Get(fmt.Sprintf("%s/books?page=%d", s.apiBasePath, nextPage))
-		if err != nil {
-			log.Println("Error making HTTP request:", err)
-			return
-		}
-		defer res.Body.Close()
+	var err error
+	ctx, span := tracing.Start(context.Background(), "ISBNService.fetchBooks")
+	defer func() { tracing.End(span, err) }()
 
-		var data models.PaginatedBooks
-		if err := json.NewDecoder(res.Body).Decode(&data); err != nil {
-			log.Println("Error decoding JSON:", err)
-			return
-		}
-
-		for _, book := range data.Items {
-			outChan <- book
-		}
-
-		nextPage = data.NextPage
+	it := s.client.IterBooks(ctx, client.ListBooksParams{})
+	for it.Next() {
+		metrics.CountISBN(metrics.ISBNFetched)
+		outChan <- it.Value()
+	}
+	if err = it.Err(); err != nil {
+		slog.Error("cannot list books", slog.Any("error", err))
 	}
 }
 
 // convertISBN Convert ISBN-10 <=> ISBN-13
-func (s *ISBNService) convertISBN(inChan <-chan models.Book, outChan chan<- util.ISBN) {
+func (s *ISBNService) convertISBN(inChan <-chan client.Book, outChan chan<- util.ISBN) {
 	defer close(outChan)
 
 	for book := range inChan {
@@ -113,6 +102,7 @@ func (s *ISBNService) convertISBN(inChan <-chan models.Book, outChan chan<- util
 			continue
 		}
 
+		metrics.CountISBN(metrics.ISBNConverted)
 		outChan <- isbn
 	}
 }
@@ -122,33 +112,36 @@ func (s *ISBNService) updateISBN(inChan <-chan util.ISBN, outChan chan<- error)
 	defer close(outChan)
 
 	for isbn := range inChan {
-		url := fmt.Sprintf("%s/books/%s", s.apiBasePath, isbn.ISBN13)
-		data, err := json.Marshal(isbn)
-		if err != nil {
-			log.Printf("error encoding data: %v\n", err)
-			outChan <- err
-			continue
-		}
-		req, err := http.NewRequest(http.MethodPut, url, bytes.NewBuffer(data))
-		if err != nil {
-			log.Printf("error making HTTP PUT request: %v\n", err)
+		if err := s.updateBook(isbn); err != nil {
+			metrics.CountISBN(metrics.ISBNFailed)
 			outChan <- err
 			continue
 		}
-		req.Header.Set("Content-Type", "application/json")
-		res, err := s.client.Do(req)
-		if err != nil {
-			log.Printf("error making HTTP PUT request: %v\n", err)
-			outChan <- err
-			continue
-		}
-		defer res.Body.Close()
-		if res.StatusCode != http.StatusOK {
-			log.Printf("received non-OK status code: %d\n", res.StatusCode)
-			outChan <- fmt.Errorf("error: received non-OK status code: %d", res.StatusCode)
-			continue
-		}
+		metrics.CountISBN(metrics.ISBNUpdated)
+	}
+}
+
+// updateBook sets both ISBNs of a book, each book is traced on its own
+func (s *ISBNService) updateBook(isbn util.ISBN) (err error) {
+	ctx, span := tracing.Start(context.Background(), "ISBNService.updateBook")
+	defer func() { tracing.End(span, err) }()
+
+	// updates must send back the current version of the book
+	book, err := s.client.GetBook(ctx, isbn.ISBN13)
+	if err != nil {
+		slog.Error("cannot get book version", slog.String("isbn13", isbn.ISBN13), slog.Any("error", err))
+		return err
 	}
+	_, err = s.client.UpdateBook(ctx, isbn.ISBN13, book.Version, client.UpdateBookParams{
+		NewISBN13: isbn.ISBN13,
+		NewISBN10: isbn.ISBN10,
+	})
+	if err != nil {
+		slog.Error("cannot update book", slog.String("isbn13", isbn.ISBN13), slog.Any("error", err))
+		return err
+	}
+
+	return nil
 }
 
 // appendToCSV Append new ISBNs to a CSV file
@@ -12,9 +12,9 @@ import (
 	"strings"
 	"testing"
 
-	mockhttp "github.com/atsuyaourt/xyz-books/internal/mocks/service"
+	"github.com/atsuyaourt/xyz-books/client"
+	mockhttp "github.com/atsuyaourt/xyz-books/internal/mocks/client"
 	mockutil "github.com/atsuyaourt/xyz-books/internal/mocks/util"
-	"github.com/atsuyaourt/xyz-books/internal/models"
 	"github.com/atsuyaourt/xyz-books/internal/util"
 
 	"github.com/stretchr/testify/mock"
@@ -23,9 +23,8 @@ import (
 
 func newMockISBNService(t *testing.T, mClient *mockhttp.MockHTTPClient, mWriter *mockutil.MockWriter) *ISBNService {
 	return &ISBNService{
-		apiBasePath: "http://test.com/api",
-		client:      mClient,
-		csvWriter:   mWriter,
+		client:    client.New("http://test.com/api", client.WithHTTPClient(mClient), client.WithRetries(0, 0)),
+		csvWriter: mWriter,
 	}
 }
 
@@ -37,14 +36,14 @@ func TestFetchBooks(t *testing.T) {
 	nextPage := 1
 	for nextPage != 0 {
 		data, res, err := mockGetFunc(nextPage, 3, books)
-		mClient.EXPECT().Get(mock.Anything).Return(res, err).Once()
+		mClient.EXPECT().Do(mock.Anything).Return(res, err).Once()
 		nextPage = int(data.NextPage)
 	}
-	ch := make(chan models.Book)
+	ch := make(chan client.Book)
 
 	go s.fetchBooks(ch)
 
-	var receivedBooks []models.Book
+	var receivedBooks []client.Book
 	for book := range ch {
 		receivedBooks = append(receivedBooks, book)
 	}
@@ -54,7 +53,7 @@ func TestFetchBooks(t *testing.T) {
 }
 
 func TestConvertISBN(t *testing.T) {
-	inChan := make(chan models.Book)
+	inChan := make(chan client.Book)
 	outChan := make(chan util.ISBN)
 
 	s := newMockISBNService(t, nil, nil)
@@ -62,7 +61,7 @@ func TestConvertISBN(t *testing.T) {
 	go s.convertISBN(inChan, outChan)
 
 	booksWithMissingISBN := loadBooksFromFile("test_books_missing_isbn.json")
-	go func(books []models.Book) {
+	go func(books []client.Book) {
 		for _, b := range books {
 			inChan <- b
 		}
@@ -70,11 +69,11 @@ func TestConvertISBN(t *testing.T) {
 	}(booksWithMissingISBN)
 
 	books := loadBooksFromFile("test_books.json")
-	slices.SortFunc(books, func(a, b models.Book) int { return cmp.Compare(a.ISBN13, b.ISBN13) })
+	slices.SortFunc(books, func(a, b client.Book) int { return cmp.Compare(a.ISBN13, b.ISBN13) })
 	var actualISBNs []util.ISBN
 	for isbn := range outChan {
 		actualISBNs = append(actualISBNs, isbn)
-		idx, found := slices.BinarySearchFunc(books, isbn, func(b models.Book, i util.ISBN) int { return cmp.Compare(b.ISBN13, i.ISBN13) })
+		idx, found := slices.BinarySearchFunc(books, isbn, func(b client.Book, i util.ISBN) int { return cmp.Compare(b.ISBN13, i.ISBN13) })
 		require.True(t, found)
 		require.Positive(t, idx)
 	}
@@ -103,23 +102,44 @@ func TestUpdateISBN(t *testing.T) {
 				{ISBN13: "9780987654321"},
 			},
 			buildStubs: func(mClient *mockhttp.MockHTTPClient) {
-				mClient.EXPECT().Do(mock.Anything).Return(&http.Response{
-					StatusCode: http.StatusOK,
-					Body:       io.NopCloser(bytes.NewBufferString("")),
-				}, nil)
+				mockGetETag(mClient, http.StatusOK)
+				mClient.EXPECT().Do(mock.MatchedBy(func(req *http.Request) bool {
+					return req.Method == http.MethodPut && req.Header.Get("If-Match") == `"1"`
+				})).RunAndReturn(func(req *http.Request) (*http.Response, error) {
+					return jsonResponse(http.StatusOK, `{"version":2}`), nil
+				})
 			},
 			wantErrors: 0,
 		},
+		{
+			name: "GetError",
+			inputISBNs: []util.ISBN{
+				{ISBN13: "9781234567890"},
+			},
+			buildStubs: func(mClient *mockhttp.MockHTTPClient) {
+				mockGetETag(mClient, http.StatusBadRequest)
+			},
+			wantErrors: 1,
+		},
+		{
+			name: "PreconditionFailed",
+			inputISBNs: []util.ISBN{
+				{ISBN13: "9781234567890"},
+			},
+			buildStubs: func(mClient *mockhttp.MockHTTPClient) {
+				mockGetETag(mClient, http.StatusOK)
+				mClient.EXPECT().Do(mock.Anything).Return(jsonResponse(http.StatusPreconditionFailed, `{"error":"record was changed"}`), nil)
+			},
+			wantErrors: 1,
+		},
 		{
 			name: "ServerError",
 			inputISBNs: []util.ISBN{
 				{ISBN13: "9781234567890"},
 			},
 			buildStubs: func(mClient *mockhttp.MockHTTPClient) {
-				mClient.EXPECT().Do(mock.Anything).Return(&http.Response{
-					StatusCode: http.StatusInternalServerError,
-					Body:       io.NopCloser(bytes.NewBufferString("")),
-				}, nil)
+				mockGetETag(mClient, http.StatusOK)
+				mClient.EXPECT().Do(mock.Anything).Return(jsonResponse(http.StatusInternalServerError, ""), nil)
 			},
 			wantErrors: 1,
 		},
@@ -129,6 +149,7 @@ func TestUpdateISBN(t *testing.T) {
 				{ISBN13: "9781234567890"},
 			},
 			buildStubs: func(mClient *mockhttp.MockHTTPClient) {
+				mockGetETag(mClient, http.StatusOK)
 				mClient.EXPECT().Do(mock.Anything).Return(nil, fmt.Errorf("request error"))
 			},
 			wantErrors: 1,
@@ -237,7 +258,24 @@ func mockGetFunc[T any](page, perPage int, items []T) (*util.PaginatedList[T], *
 	}, nil
 }
 
-func loadBooksFromFile(fileName string) []models.Book {
+// mockGetETag answers the book lookup made before each update
+func mockGetETag(mClient *mockhttp.MockHTTPClient, status int) {
+	mClient.EXPECT().Do(mock.MatchedBy(func(req *http.Request) bool {
+		return req.Method == http.MethodGet
+	})).RunAndReturn(func(req *http.Request) (*http.Response, error) {
+		return jsonResponse(status, `{"version":1}`), nil
+	})
+}
+
+func jsonResponse(status int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: status,
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+	}
+}
+
+func loadBooksFromFile(fileName string) []client.Book {
 	// Read the JSON file
 	file, err := os.Open(fileName)
 	if err != nil {
@@ -254,7 +292,7 @@ func loadBooksFromFile(fileName string) []models.Book {
 	}
 
 	// Unmarshal the JSON into a slice of Book structs
-	var books []models.Book
+	var books []client.Book
 	err = json.Unmarshal(byteValue, &books)
 	if err != nil {
 		fmt.Printf("Error unmarshalling JSON: %v\n", err)
@@ -0,0 +1,281 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	db "github.com/atsuyaourt/xyz-books/internal/db/sqlc"
+	"github.com/atsuyaourt/xyz-books/internal/models"
+	"github.com/atsuyaourt/xyz-books/internal/tracing"
+	"github.com/atsuyaourt/xyz-books/internal/util"
+	"golang.org/x/net/context"
+)
+
+var (
+	ErrPaymentFailed           = errors.New("payment failed")
+	ErrInvalidStatusTransition = errors.New("invalid order status transition")
+)
+
+func newOrder(order db.Order, items []db.OrderItem) models.Order {
+	res := models.Order{
+		OrderID:         order.OrderID,
+		CustomerName:    order.CustomerName,
+		Email:           order.Email,
+		ShippingAddress: order.ShippingAddress,
+		Status:          models.OrderStatus(order.Status),
+		Total:           order.Total,
+		CreatedAt:       order.CreatedAt,
+		Items:           make([]models.OrderItem, len(items)),
+	}
+
+	if order.PaymentRef.Valid {
+		res.PaymentRef = order.PaymentRef.String
+	}
+
+	for i, item := range items {
+		res.Items[i] = models.OrderItem{
+			Title:    item.Title,
+			ISBN13:   item.Isbn13.String,
+			ISBN10:   item.Isbn10.String,
+			Price:    item.Price,
+			Quantity: item.Quantity,
+			Subtotal: roundPrice(item.Price * float64(item.Quantity)),
+		}
+	}
+
+	return res
+}
+
+type CheckoutReq struct {
+	CustomerName    string `json:"customer_name" form:"customer_name" binding:"required"`
+	Email           string `json:"email" form:"email" binding:"required,email"`
+	ShippingAddress string `json:"shipping_address" form:"shipping_address" binding:"required"`
+} //@name CheckoutParams
+
+// Checkout turns the owner's cart into an order and charges for it, emptying
+// the cart once paid. If the charge fails the order is cancelled and the cart
+// kept, so that the customer can check out again, and ErrPaymentFailed is
+// returned.
+func (s *DefaultService) Checkout(ctx context.Context, owner CartOwner, req CheckoutReq) (_ *models.Order, err error) {
+	ctx, span := tracing.Start(ctx, "DefaultService.Checkout")
+	defer func() { tracing.End(span, err) }()
+
+	cart, err := s.findCart(ctx, owner, false)
+	if err != nil {
+		return nil, err
+	}
+	if cart == nil {
+		return nil, db.ErrEmptyCart
+	}
+
+	tx, err := s.store.CheckoutTx(ctx, db.CheckoutTxParams{
+		CartID: cart.CartID,
+		Order: db.CreateOrderParams{
+			UserID:          cart.UserID,
+			CustomerName:    req.CustomerName,
+			Email:           req.Email,
+			ShippingAddress: req.ShippingAddress,
+			CartToken:       sql.NullString{String: cart.Token, Valid: true},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	charge, err := s.payment.Charge(ctx, ChargeReq{
+		OrderID: tx.Order.OrderID,
+		Amount:  tx.Order.Total,
+		Email:   tx.Order.Email,
+	})
+	if err != nil {
+		_, cancelErr := s.store.UpdateOrderStatus(ctx, db.UpdateOrderStatusParams{
+			OrderID: tx.Order.OrderID,
+			Status:  string(models.OrderCancelled),
+			FromStatus: sql.NullString{
+				String: string(models.OrderPending),
+				Valid:  true,
+			},
+		})
+		return nil, errors.Join(fmt.Errorf("%w: %v", ErrPaymentFailed, err), cancelErr)
+	}
+
+	var order db.Order
+	err = s.store.ExecTx(ctx, func(q db.Querier) (err error) {
+		order, err = q.UpdateOrderStatus(ctx, db.UpdateOrderStatusParams{
+			OrderID: tx.Order.OrderID,
+			Status:  string(models.OrderPaid),
+			FromStatus: sql.NullString{
+				String: string(models.OrderPending),
+				Valid:  true,
+			},
+			PaymentRef: sql.NullString{
+				String: charge.Reference,
+				Valid:  true,
+			},
+		})
+		if err != nil {
+			return err
+		}
+
+		return q.ClearCartItems(ctx, cart.CartID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res := newOrder(order, tx.Items)
+
+	return &res, nil
+}
+
+// ownsOrder reports whether an order was placed by owner, either as the
+// signed-in user or from the cart of its token. A nil owner owns every order.
+func ownsOrder(order db.Order, owner *CartOwner) bool {
+	if owner == nil {
+		return true
+	}
+
+	return (len(owner.UserID) > 0 && order.UserID.Valid && order.UserID.String == owner.UserID) ||
+		(len(owner.Token) > 0 && order.CartToken.Valid && order.CartToken.String == owner.Token)
+}
+
+// GetOrder returns an order of owner, the orders of others are not found.
+// Only admins may pass a nil owner.
+func (s *DefaultService) GetOrder(ctx context.Context, owner *CartOwner, id int64) (_ *models.Order, err error) {
+	ctx, span := tracing.Start(ctx, "DefaultService.GetOrder")
+	defer func() { tracing.End(span, err) }()
+
+	order, err := s.store.GetOrder(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !ownsOrder(order, owner) {
+		return nil, db.ErrRecordNotFound
+	}
+
+	items, err := s.store.ListOrderItems(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	res := newOrder(order, items)
+
+	return &res, nil
+}
+
+type ListOrdersReq struct {
+	Status  string `form:"status" binding:"omitempty,oneof=pending paid shipped delivered cancelled"`
+	Page    int32  `form:"page,default=1" binding:"omitempty,min=1"`            // page number
+	PerPage int32  `form:"per_page,default=5" binding:"omitempty,min=1,max=30"` // limit
+} //@name ListOrdersParams
+
+// ListOrders lists the orders of owner, or every order for admins, who pass
+// a nil owner
+func (s *DefaultService) ListOrders(ctx context.Context, owner *CartOwner, req ListOrdersReq) (_ *util.PaginatedList[models.Order], err error) {
+	ctx, span := tracing.Start(ctx, "DefaultService.ListOrders")
+	defer func() { tracing.End(span, err) }()
+
+	if owner != nil && len(owner.UserID) == 0 && len(owner.Token) == 0 {
+		res := util.NewPaginatedList(req.Page, req.PerPage, 0, []models.Order{})
+		return &res, nil
+	}
+
+	offset := (req.Page - 1) * req.PerPage
+
+	arg := db.ListOrdersParams{
+		Limit:  int64(req.PerPage),
+		Offset: int64(offset),
+		Status: sql.NullString{
+			String: req.Status,
+			Valid:  len(req.Status) > 0,
+		},
+	}
+	if owner != nil {
+		arg.UserID = sql.NullString{String: owner.UserID, Valid: len(owner.UserID) > 0}
+		arg.CartToken = sql.NullString{String: owner.Token, Valid: len(owner.Token) > 0}
+	}
+	orders, err := s.store.ListOrders(ctx, arg)
+	if err != nil {
+		return nil, err
+	}
+
+	// the items of the whole page are read at once
+	itemsByOrder := make(map[int64][]db.OrderItem, len(orders))
+	if len(orders) > 0 {
+		orderIDs := make([]int64, len(orders))
+		for i, order := range orders {
+			orderIDs[i] = order.OrderID
+		}
+		orderItems, err := s.store.ListOrderItemsByOrderIDs(ctx, orderIDs)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range orderItems {
+			itemsByOrder[item.OrderID] = append(itemsByOrder[item.OrderID], item)
+		}
+	}
+
+	items := make([]models.Order, len(orders))
+	for i, order := range orders {
+		items[i] = newOrder(order, itemsByOrder[order.OrderID])
+	}
+
+	count, err := s.store.CountOrders(ctx, db.CountOrdersParams{
+		UserID:    arg.UserID,
+		CartToken: arg.CartToken,
+		Status:    arg.Status,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res := util.NewPaginatedList(req.Page, req.PerPage, int32(count), items)
+
+	return &res, nil
+}
+
+type UpdateOrderStatusReq struct {
+	Status string `json:"status" binding:"required,oneof=pending paid shipped delivered cancelled"`
+} //@name UpdateOrderStatusParams
+
+// UpdateOrderStatus moves an order along its lifecycle, it is only for admins
+func (s *DefaultService) UpdateOrderStatus(ctx context.Context, id int64, req UpdateOrderStatusReq) (_ *models.Order, err error) {
+	ctx, span := tracing.Start(ctx, "DefaultService.UpdateOrderStatus")
+	defer func() { tracing.End(span, err) }()
+
+	order, err := s.store.GetOrder(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	next := models.OrderStatus(req.Status)
+	if !models.OrderStatus(order.Status).CanTransitionTo(next) {
+		return nil, fmt.Errorf("%w: %s to %s", ErrInvalidStatusTransition, order.Status, next)
+	}
+
+	order, err = s.store.UpdateOrderStatus(ctx, db.UpdateOrderStatusParams{
+		OrderID: id,
+		Status:  string(next),
+		FromStatus: sql.NullString{
+			String: order.Status,
+			Valid:  true,
+		},
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			// the status was changed by someone else in the meantime
+			return nil, ErrInvalidStatusTransition
+		}
+		return nil, err
+	}
+
+	items, err := s.store.ListOrderItems(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	res := newOrder(order, items)
+
+	return &res, nil
+}
@@ -0,0 +1,46 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/atsuyaourt/xyz-books/internal/util"
+)
+
+var ErrInvalidPatch = errors.New("invalid patch")
+
+// notNullable fails a patch that clears any of the given required fields,
+// keyed by their JSON name
+func notNullable(nulls map[string]bool) error {
+	var names []string
+	for name, null := range nulls {
+		if null {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return nil
+	}
+
+	slices.Sort(names)
+	return fmt.Errorf("%w: %s cannot be null", ErrInvalidPatch, strings.Join(names, ", "))
+}
+
+// patchString returns the value a patch member sets, invalid when it leaves
+// the column unchanged or clears it
+func patchString(p util.Patch[string]) sql.NullString {
+	return sql.NullString{
+		String: p.Value,
+		Valid:  p.Valid(),
+	}
+}
+
+func patchInt64(p util.Patch[int64]) sql.NullInt64 {
+	return sql.NullInt64{
+		Int64: p.Value,
+		Valid: p.Valid(),
+	}
+}
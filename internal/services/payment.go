@@ -0,0 +1,46 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/atsuyaourt/xyz-books/internal/util"
+	"golang.org/x/net/context"
+)
+
+var ErrPaymentDeclined = errors.New("payment declined")
+
+type ChargeReq struct {
+	OrderID int64
+	Amount  float64
+	Email   string
+}
+
+type ChargeRes struct {
+	Reference string
+}
+
+// PaymentProvider charges customers for their orders
+type PaymentProvider interface {
+	Charge(ctx context.Context, req ChargeReq) (*ChargeRes, error)
+}
+
+// FakePaymentProvider accepts every charge without contacting anyone.
+// Set Decline to simulate a rejected payment.
+type FakePaymentProvider struct {
+	Decline bool
+}
+
+func NewFakePaymentProvider() *FakePaymentProvider {
+	return &FakePaymentProvider{}
+}
+
+func (p *FakePaymentProvider) Charge(ctx context.Context, req ChargeReq) (*ChargeRes, error) {
+	if p.Decline {
+		return nil, ErrPaymentDeclined
+	}
+
+	return &ChargeRes{
+		Reference: fmt.Sprintf("fake_%d_%s", req.OrderID, util.NewToken()[:12]),
+	}, nil
+}
@@ -2,16 +2,24 @@ package services
 
 import (
 	"database/sql"
+	"errors"
+	"time"
 
 	db "github.com/atsuyaourt/xyz-books/internal/db/sqlc"
 	"github.com/atsuyaourt/xyz-books/internal/models"
+	"github.com/atsuyaourt/xyz-books/internal/tracing"
 	"github.com/atsuyaourt/xyz-books/internal/util"
 	"golang.org/x/net/context"
 )
 
 func newPublisher(arg db.Publisher) models.Publisher {
 	return models.Publisher{
+		ID:            arg.PublisherID,
 		PublisherName: arg.PublisherName,
+		CreatedAt:     arg.CreatedAt,
+		UpdatedAt:     arg.UpdatedAt,
+		DeletedAt:     deletedAt(arg.DeletedAt),
+		Version:       arg.Version,
 	}
 }
 
@@ -19,7 +27,10 @@ type CreatePublisherReq struct {
 	PublisherName string `json:"publisher_name" binding:"required,min=1"`
 } //@name CreatePublisherParams
 
-func (s *DefaultService) CreatePublisher(ctx context.Context, req CreatePublisherReq) (*models.Publisher, error) {
+func (s *DefaultService) CreatePublisher(ctx context.Context, req CreatePublisherReq) (_ *models.Publisher, err error) {
+	ctx, span := tracing.Start(ctx, "DefaultService.CreatePublisher")
+	defer func() { tracing.End(span, err) }()
+
 	publisher, err := s.store.CreatePublisher(ctx, req.PublisherName)
 	if err != nil {
 		return nil, err
@@ -30,8 +41,18 @@ func (s *DefaultService) CreatePublisher(ctx context.Context, req CreatePublishe
 	return &res, nil
 }
 
-func (s *DefaultService) GetPublisher(ctx context.Context, id int64) (*models.Publisher, error) {
-	publisher, err := s.store.GetPublisher(ctx, id)
+func (s *DefaultService) GetPublisher(ctx context.Context, id int64, includeDeleted bool) (_ *models.Publisher, err error) {
+	ctx, span := tracing.Start(ctx, "DefaultService.GetPublisher")
+	defer func() { tracing.End(span, err) }()
+
+	if err := checkIncludeDeleted(ctx, includeDeleted); err != nil {
+		return nil, err
+	}
+
+	publisher, err := s.store.GetPublisher(ctx, db.GetPublisherParams{
+		PublisherID:    id,
+		IncludeDeleted: includeDeleted,
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -42,16 +63,27 @@ func (s *DefaultService) GetPublisher(ctx context.Context, id int64) (*models.Pu
 }
 
 type ListPublishersReq struct {
-	Page    int32 `form:"page,default=1" binding:"omitempty,min=1"`            // page number
-	PerPage int32 `form:"per_page,default=5" binding:"omitempty,min=1,max=30"` // limit
+	UpdatedSince   time.Time `form:"updated_since"`                                       // only list publishers changed after this time (RFC 3339)
+	IncludeDeleted bool      `form:"include_deleted"`                                     // also list deleted publishers
+	Page           int32     `form:"page,default=1" binding:"omitempty,min=1"`            // page number
+	PerPage        int32     `form:"per_page,default=5" binding:"omitempty,min=1,max=30"` // limit
 } //@name ListPublishersParams
 
-func (s *DefaultService) ListPublishers(ctx context.Context, req ListPublishersReq) (*util.PaginatedList[models.Publisher], error) {
+func (s *DefaultService) ListPublishers(ctx context.Context, req ListPublishersReq) (_ *util.PaginatedList[models.Publisher], err error) {
+	ctx, span := tracing.Start(ctx, "DefaultService.ListPublishers")
+	defer func() { tracing.End(span, err) }()
+
+	if err := checkIncludeDeleted(ctx, req.IncludeDeleted); err != nil {
+		return nil, err
+	}
+
 	offset := (req.Page - 1) * req.PerPage
 
 	arg := db.ListPublishersParams{
-		Limit:  int64(req.PerPage),
-		Offset: int64(offset),
+		IncludeDeleted: req.IncludeDeleted,
+		UpdatedSince:   updatedSince(req.UpdatedSince),
+		Limit:          int64(req.PerPage),
+		Offset:         int64(offset),
 	}
 	publishers, err := s.store.ListPublishers(ctx, arg)
 	if err != nil {
@@ -64,7 +96,10 @@ func (s *DefaultService) ListPublishers(ctx context.Context, req ListPublishersR
 		items[i] = newPublisher(publisher)
 	}
 
-	count, err := s.store.CountPublishers(ctx)
+	count, err := s.store.CountPublishers(ctx, db.CountPublishersParams{
+		IncludeDeleted: arg.IncludeDeleted,
+		UpdatedSince:   arg.UpdatedSince,
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -74,29 +109,169 @@ func (s *DefaultService) ListPublishers(ctx context.Context, req ListPublishersR
 	return &res, nil
 }
 
+// ListBookPublishers returns the publisher of each of the given books, keyed
+// by book ID
+func (s *DefaultService) ListBookPublishers(ctx context.Context, bookIDs []int64) (_ map[int64]models.Publisher, err error) {
+	ctx, span := tracing.Start(ctx, "DefaultService.ListBookPublishers")
+	defer func() { tracing.End(span, err) }()
+
+	rows, err := s.store.ListBookPublishers(ctx, bookIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(map[int64]models.Publisher, len(rows))
+	for _, row := range rows {
+		res[row.BookID] = newPublisher(row.Publisher)
+	}
+
+	return res, nil
+}
+
 type UpdatePublisherReq struct {
 	PublisherName string `json:"publisher_name" binding:"omitempty,min=1"`
 } //@name UpdatePublisherParams
 
-func (s *DefaultService) UpdatePublisher(ctx context.Context, oldID int64, req UpdatePublisherReq) (*models.Publisher, error) {
+func (s *DefaultService) UpdatePublisher(ctx context.Context, oldID int64, version int64, req UpdatePublisherReq) (_ *models.Publisher, err error) {
+	ctx, span := tracing.Start(ctx, "DefaultService.UpdatePublisher")
+	defer func() { tracing.End(span, err) }()
+
 	arg := db.UpdatePublisherParams{
 		PublisherID: oldID,
+		Version:     expectedVersion(version),
 		PublisherName: sql.NullString{
 			String: req.PublisherName,
 			Valid:  len(req.PublisherName) > 0,
 		},
 	}
 
-	publisher, err := s.store.UpdatePublisher(ctx, arg)
+	return s.updatePublisher(ctx, arg)
+}
+
+type PatchPublisherReq struct {
+	PublisherName util.Patch[string] `json:"publisher_name" binding:"omitempty,min=1" swaggertype:"string"`
+} //@name PatchPublisherParams
+
+// PatchPublisher applies a JSON merge patch, members left out are unchanged
+func (s *DefaultService) PatchPublisher(ctx context.Context, id int64, version int64, req PatchPublisherReq) (_ *models.Publisher, err error) {
+	ctx, span := tracing.Start(ctx, "DefaultService.PatchPublisher")
+	defer func() { tracing.End(span, err) }()
+
+	err = notNullable(map[string]bool{
+		"publisher_name": req.PublisherName.Null,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	arg := db.UpdatePublisherParams{
+		PublisherID:   id,
+		Version:       expectedVersion(version),
+		PublisherName: patchString(req.PublisherName),
+	}
+
+	return s.updatePublisher(ctx, arg)
+}
+
+// updatePublisher runs a publisher update, which also changes the version
+// of its books as the name is part of them
+func (s *DefaultService) updatePublisher(ctx context.Context, arg db.UpdatePublisherParams) (*models.Publisher, error) {
+	defer s.invalidate()
+
+	var (
+		publisher db.Publisher
+		events    []models.Event
+	)
+	err := s.store.ExecTx(ctx, func(q db.Querier) (err error) {
+		publisher, err = q.UpdatePublisher(ctx, arg)
+		if errors.Is(err, db.ErrRecordNotFound) && arg.Version.Valid {
+			if _, getErr := q.GetPublisher(ctx, db.GetPublisherParams{PublisherID: arg.PublisherID}); getErr == nil {
+				return db.ErrVersionChanged
+			}
+		}
+		if err != nil {
+			return err
+		}
+
+		err = q.BumpPublisherBookVersions(ctx, arg.PublisherID)
+		if err != nil || !s.publishing() {
+			return err
+		}
+
+		// the books carry the name of the publisher
+		books, err := q.ListPublisherBooks(ctx, []int64{arg.PublisherID})
+		if err != nil {
+			return err
+		}
+		events = make([]models.Event, len(books))
+		for i, row := range books {
+			events[i] = newEvent(models.EventBookUpdated, newBook(newBookArg{
+				Book:         row.Book,
+				Authors:      splitList(row.Authors),
+				Contributors: splitContributors(row.Contributors),
+				Publisher:    row.PublisherName,
+				Subjects:     splitList(row.Subjects),
+			}))
+		}
+
+		return s.enqueue(ctx, q, events...)
+	})
 	if err != nil {
 		return nil, err
 	}
 
+	s.publish(ctx, events...)
+
 	res := newPublisher(publisher)
 
 	return &res, nil
 }
 
-func (s *DefaultService) DeletePublisher(ctx context.Context, id int64) error {
-	return s.store.DeletePublisher(ctx, id)
+func (s *DefaultService) DeletePublisher(ctx context.Context, id int64, version int64) (err error) {
+	ctx, span := tracing.Start(ctx, "DefaultService.DeletePublisher")
+	defer func() { tracing.End(span, err) }()
+	defer s.invalidate()
+
+	arg := db.DeletePublisherParams{
+		PublisherID: id,
+		Version:     expectedVersion(version),
+	}
+
+	return s.store.ExecTx(ctx, func(q db.Querier) error {
+		n, err := q.DeletePublisher(ctx, arg)
+		if err != nil || n > 0 || !arg.Version.Valid {
+			return err
+		}
+
+		// nothing deleted, either already gone or changed in between
+		_, err = q.GetPublisher(ctx, db.GetPublisherParams{PublisherID: id})
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		return db.ErrVersionChanged
+	})
+}
+
+func (s *DefaultService) RestorePublisher(ctx context.Context, id int64) (_ *models.Publisher, err error) {
+	ctx, span := tracing.Start(ctx, "DefaultService.RestorePublisher")
+	defer func() { tracing.End(span, err) }()
+	defer s.invalidate()
+
+	publisher, err := s.store.RestorePublisher(ctx, id)
+	if errors.Is(err, db.ErrRecordNotFound) {
+		// only deleted publishers are restored, a live one is left as is
+		if _, getErr := s.store.GetPublisher(ctx, db.GetPublisherParams{PublisherID: id}); getErr == nil {
+			err = db.ErrNotDeleted
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	res := newPublisher(publisher)
+
+	return &res, nil
 }
@@ -1,6 +1,8 @@
 package services
 
 import (
+	"io"
+
 	"github.com/atsuyaourt/xyz-books/internal/models"
 	"github.com/atsuyaourt/xyz-books/internal/util"
 	"golang.org/x/net/context"
@@ -8,20 +10,58 @@ import (
 
 type Service interface {
 	CreateBook(ctx context.Context, req CreateBookReq) (*models.Book, error)
-	GetBook(ctx context.Context, isbn13 string) (*models.Book, error)
+	GetBook(ctx context.Context, isbn13 string, includeDeleted bool) (*models.Book, error)
 	ListBooks(ctx context.Context, req ListBooksReq) (*util.PaginatedList[models.Book], error)
-	UpdateBook(ctx context.Context, oldISBN13 string, req UpdateBookReq) (*models.Book, error)
-	DeleteBook(ctx context.Context, isbn13 string) error
+	ListAuthorBooks(ctx context.Context, authorIDs []int64) (map[int64][]models.Book, error)
+	ListPublisherBooks(ctx context.Context, publisherIDs []int64) (map[int64][]models.Book, error)
+	UpdateBook(ctx context.Context, oldISBN13 string, version int64, req UpdateBookReq) (*models.Book, error)
+	PatchBook(ctx context.Context, isbn13 string, version int64, req PatchBookReq) (*models.Book, error)
+	DeleteBook(ctx context.Context, isbn13 string, version int64) error
+	RestoreBook(ctx context.Context, isbn13 string) (*models.Book, error)
+
+	GetBookV2(ctx context.Context, isbn13 string, includeDeleted bool) (*models.BookV2, error)
+	ListBooksV2(ctx context.Context, req ListBooksV2Req) (*util.CursorList[models.BookV2], error)
+
+	UploadBookCover(ctx context.Context, isbn13 string, r io.Reader) (*models.Book, error)
+	DeleteBookCover(ctx context.Context, isbn13 string) error
+	GetCoverImage(ctx context.Context, name string) (io.ReadCloser, error)
+	GetCoverPlaceholder(ctx context.Context, isbn13 string) (*util.CoverLayout, error)
 
 	CreateAuthor(ctx context.Context, req CreateAuthorReq) (*models.Author, error)
-	GetAuthor(ctx context.Context, id int64) (*models.Author, error)
+	GetAuthor(ctx context.Context, id int64, includeDeleted bool) (*models.Author, error)
 	ListAuthors(ctx context.Context, req ListAuthorsReq) (*util.PaginatedList[models.Author], error)
-	UpdateAuthor(ctx context.Context, oldID int64, req UpdateAuthorReq) (*models.Author, error)
-	DeleteAuthor(ctx context.Context, id int64) error
+	ListBookAuthors(ctx context.Context, bookIDs []int64) (map[int64][]models.Author, error)
+	UpdateAuthor(ctx context.Context, oldID int64, version int64, req UpdateAuthorReq) (*models.Author, error)
+	PatchAuthor(ctx context.Context, id int64, version int64, req PatchAuthorReq) (*models.Author, error)
+	DeleteAuthor(ctx context.Context, id int64, version int64) error
+	RestoreAuthor(ctx context.Context, id int64) (*models.Author, error)
 
 	CreatePublisher(ctx context.Context, req CreatePublisherReq) (*models.Publisher, error)
-	GetPublisher(ctx context.Context, id int64) (*models.Publisher, error)
+	GetPublisher(ctx context.Context, id int64, includeDeleted bool) (*models.Publisher, error)
 	ListPublishers(ctx context.Context, req ListPublishersReq) (*util.PaginatedList[models.Publisher], error)
-	UpdatePublisher(ctx context.Context, oldID int64, req UpdatePublisherReq) (*models.Publisher, error)
-	DeletePublisher(ctx context.Context, id int64) error
+	ListBookPublishers(ctx context.Context, bookIDs []int64) (map[int64]models.Publisher, error)
+	UpdatePublisher(ctx context.Context, oldID int64, version int64, req UpdatePublisherReq) (*models.Publisher, error)
+	PatchPublisher(ctx context.Context, id int64, version int64, req PatchPublisherReq) (*models.Publisher, error)
+	DeletePublisher(ctx context.Context, id int64, version int64) error
+	RestorePublisher(ctx context.Context, id int64) (*models.Publisher, error)
+
+	ListChanges(ctx context.Context, req ListChangesReq) (*models.ChangeFeed, error)
+
+	CreateWebhook(ctx context.Context, req CreateWebhookReq) (*models.Webhook, error)
+	ListWebhooks(ctx context.Context) ([]models.Webhook, error)
+	DeleteWebhook(ctx context.Context, id int64) error
+	ListWebhookDeliveries(ctx context.Context, req ListWebhookDeliveriesReq) (*util.PaginatedList[models.WebhookDelivery], error)
+	RedeliverWebhookDelivery(ctx context.Context, id int64) (*models.WebhookDelivery, error)
+
+	SubscribeEvents(ctx context.Context) (<-chan models.Event, error)
+
+	GetCart(ctx context.Context, owner CartOwner) (*models.Cart, error)
+	AddCartItem(ctx context.Context, owner CartOwner, req AddCartItemReq) (*models.Cart, error)
+	UpdateCartItem(ctx context.Context, owner CartOwner, isbn13 string, req UpdateCartItemReq) (*models.Cart, error)
+	RemoveCartItem(ctx context.Context, owner CartOwner, isbn13 string) (*models.Cart, error)
+
+	Checkout(ctx context.Context, owner CartOwner, req CheckoutReq) (*models.Order, error)
+	GetOrder(ctx context.Context, owner *CartOwner, id int64) (*models.Order, error)
+	ListOrders(ctx context.Context, owner *CartOwner, req ListOrdersReq) (*util.PaginatedList[models.Order], error)
+	UpdateOrderStatus(ctx context.Context, id int64, req UpdateOrderStatusReq) (*models.Order, error)
 }
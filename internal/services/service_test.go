@@ -0,0 +1,35 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	db "github.com/atsuyaourt/xyz-books/internal/db/sqlc"
+	mockdb "github.com/atsuyaourt/xyz-books/internal/mocks/db"
+	"github.com/atsuyaourt/xyz-books/internal/tracing/tracingtest"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+)
+
+func TestServiceSpanStatus(t *testing.T) {
+	recorder := tracingtest.Record(t)
+
+	store := mockdb.NewMockStore(t)
+	store.EXPECT().GetAuthor(mock.Anything, mock.Anything).Return(db.Author{AuthorID: 1}, nil).Once()
+	store.EXPECT().GetAuthor(mock.Anything, mock.Anything).Return(db.Author{}, db.ErrRecordNotFound).Once()
+
+	service, err := NewDefaultService(store)
+	require.NoError(t, err)
+
+	_, err = service.GetAuthor(context.Background(), 1, false)
+	require.NoError(t, err)
+	_, err = service.GetAuthor(context.Background(), 2, false)
+	require.ErrorIs(t, err, db.ErrRecordNotFound)
+
+	spans := recorder.Ended()
+	require.Equal(t, []string{"DefaultService.GetAuthor", "DefaultService.GetAuthor"}, tracingtest.Names(spans))
+	require.Equal(t, codes.Unset, spans[0].Status().Code)
+	require.Equal(t, codes.Error, spans[1].Status().Code)
+	require.Len(t, spans[1].Events(), 1) // the recorded error
+}
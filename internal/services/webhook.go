@@ -0,0 +1,230 @@
+package services
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	db "github.com/atsuyaourt/xyz-books/internal/db/sqlc"
+	"github.com/atsuyaourt/xyz-books/internal/models"
+	"github.com/atsuyaourt/xyz-books/internal/netguard"
+	"github.com/atsuyaourt/xyz-books/internal/tracing"
+	"github.com/atsuyaourt/xyz-books/internal/util"
+	"golang.org/x/net/context"
+)
+
+// ErrInvalidWebhookURL is returned for webhook URLs the server must not call,
+// such as those on its own networks
+var ErrInvalidWebhookURL = errors.New("invalid webhook url")
+
+func newWebhook(arg db.Webhook) models.Webhook {
+	events := strings.Split(arg.Events, ",")
+	res := models.Webhook{
+		ID:        arg.WebhookID,
+		Url:       arg.Url,
+		Events:    make([]models.EventType, len(events)),
+		CreatedAt: arg.CreatedAt,
+	}
+	for i, e := range events {
+		res.Events[i] = models.EventType(e)
+	}
+
+	return res
+}
+
+func newWebhookDelivery(arg db.WebhookDelivery) models.WebhookDelivery {
+	res := models.WebhookDelivery{
+		ID:        arg.DeliveryID,
+		WebhookID: arg.WebhookID,
+		EventID:   arg.EventID,
+		Event:     models.EventType(arg.Event),
+		Status:    models.WebhookDeliveryStatus(arg.Status),
+		Attempts:  arg.Attempts,
+		LastError: arg.LastError.String,
+		Payload:   json.RawMessage(arg.Payload),
+		CreatedAt: arg.CreatedAt,
+	}
+	if res.Status == models.WebhookDeliveryPending {
+		t := time.UnixMilli(arg.NextAttemptAt).UTC()
+		res.NextAttemptAt = &t
+	}
+
+	return res
+}
+
+type CreateWebhookReq struct {
+	Url    string             `json:"url" binding:"required,url"`        // a public http or https URL
+	Secret string             `json:"secret" binding:"omitempty,min=16"` // signs the deliveries, generated when left out
+	Events []models.EventType `json:"events" binding:"required,min=1,dive,oneof=book.created book.updated book.deleted book.restored price.changed"`
+} //@name CreateWebhookParams
+
+func (s *DefaultService) CreateWebhook(ctx context.Context, req CreateWebhookReq) (_ *models.Webhook, err error) {
+	ctx, span := tracing.Start(ctx, "DefaultService.CreateWebhook")
+	defer func() { tracing.End(span, err) }()
+
+	if err := netguard.CheckURL(req.Url); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidWebhookURL, err)
+	}
+
+	secret := req.Secret
+	if len(secret) == 0 {
+		secret = util.NewToken()
+	}
+
+	events := make([]string, len(req.Events))
+	for i, e := range req.Events {
+		events[i] = string(e)
+	}
+	slices.Sort(events)
+
+	webhook, err := s.store.CreateWebhook(ctx, db.CreateWebhookParams{
+		Url:    req.Url,
+		Secret: secret,
+		Events: strings.Join(slices.Compact(events), ","),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res := newWebhook(webhook)
+	res.Secret = webhook.Secret
+
+	return &res, nil
+}
+
+func (s *DefaultService) ListWebhooks(ctx context.Context) (_ []models.Webhook, err error) {
+	ctx, span := tracing.Start(ctx, "DefaultService.ListWebhooks")
+	defer func() { tracing.End(span, err) }()
+
+	webhooks, err := s.store.ListWebhooks(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]models.Webhook, len(webhooks))
+	for i, webhook := range webhooks {
+		res[i] = newWebhook(webhook)
+	}
+
+	return res, nil
+}
+
+// DeleteWebhook removes a webhook along with its deliveries
+func (s *DefaultService) DeleteWebhook(ctx context.Context, id int64) (err error) {
+	ctx, span := tracing.Start(ctx, "DefaultService.DeleteWebhook")
+	defer func() { tracing.End(span, err) }()
+
+	return s.store.ExecTx(ctx, func(q db.Querier) error {
+		err := q.DeleteWebhookDeliveries(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		n, err := q.DeleteWebhook(ctx, id)
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			return db.ErrRecordNotFound
+		}
+
+		return nil
+	})
+}
+
+type ListWebhookDeliveriesReq struct {
+	WebhookID int64  `form:"webhook_id" binding:"omitempty,min=1"`
+	Status    string `form:"status" binding:"omitempty,oneof=pending delivered dead"` // dead lists the deliveries that ran out of attempts
+	Page      int32  `form:"page,default=1" binding:"omitempty,min=1"`                // page number
+	PerPage   int32  `form:"per_page,default=5" binding:"omitempty,min=1,max=100"`    // limit
+} //@name ListWebhookDeliveriesParams
+
+// ListWebhookDeliveries lists deliveries, newest first
+func (s *DefaultService) ListWebhookDeliveries(ctx context.Context, req ListWebhookDeliveriesReq) (_ *util.PaginatedList[models.WebhookDelivery], err error) {
+	ctx, span := tracing.Start(ctx, "DefaultService.ListWebhookDeliveries")
+	defer func() { tracing.End(span, err) }()
+
+	offset := (req.Page - 1) * req.PerPage
+
+	arg := db.ListWebhookDeliveriesParams{
+		WebhookID: sql.NullInt64{
+			Int64: req.WebhookID,
+			Valid: req.WebhookID > 0,
+		},
+		Status: sql.NullString{
+			String: req.Status,
+			Valid:  len(req.Status) > 0,
+		},
+		Limit:  int64(req.PerPage),
+		Offset: int64(offset),
+	}
+	deliveries, err := s.store.ListWebhookDeliveries(ctx, arg)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]models.WebhookDelivery, len(deliveries))
+	for i, delivery := range deliveries {
+		items[i] = newWebhookDelivery(delivery)
+	}
+
+	count, err := s.store.CountWebhookDeliveries(ctx, db.CountWebhookDeliveriesParams{
+		WebhookID: arg.WebhookID,
+		Status:    arg.Status,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res := util.NewPaginatedList(req.Page, req.PerPage, int32(count), items)
+
+	return &res, nil
+}
+
+// RedeliverWebhookDelivery queues a delivery to be sent again straight away,
+// with a fresh set of attempts
+func (s *DefaultService) RedeliverWebhookDelivery(ctx context.Context, id int64) (_ *models.WebhookDelivery, err error) {
+	ctx, span := tracing.Start(ctx, "DefaultService.RedeliverWebhookDelivery")
+	defer func() { tracing.End(span, err) }()
+
+	delivery, err := s.store.RedeliverWebhookDelivery(ctx, db.RedeliverWebhookDeliveryParams{
+		DeliveryID:    id,
+		NextAttemptAt: time.Now().UnixMilli(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res := newWebhookDelivery(delivery)
+
+	return &res, nil
+}
+
+// WebhookPublisher queues a delivery of each event to every webhook
+// subscribed to it, in the transaction of the change. The WebhookWorker
+// sends them.
+type WebhookPublisher struct{}
+
+func NewWebhookPublisher() *WebhookPublisher {
+	return &WebhookPublisher{}
+}
+
+func (p *WebhookPublisher) PublishTx(ctx context.Context, q db.Querier, event models.Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	_, err = q.EnqueueWebhookDeliveries(ctx, db.EnqueueWebhookDeliveriesParams{
+		EventID:       event.ID,
+		Event:         string(event.Type),
+		Payload:       string(payload),
+		NextAttemptAt: event.CreatedAt.UnixMilli(),
+	})
+
+	return err
+}
@@ -0,0 +1,248 @@
+package services
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	db "github.com/atsuyaourt/xyz-books/internal/db/sqlc"
+	"github.com/atsuyaourt/xyz-books/internal/logging"
+	"github.com/atsuyaourt/xyz-books/internal/models"
+	"github.com/atsuyaourt/xyz-books/internal/netguard"
+	"golang.org/x/net/context"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	defaultWebhookPollInterval = time.Second
+	defaultWebhookMaxAttempts  = 8
+	defaultWebhookRetryDelay   = 30 * time.Second
+	defaultWebhookTimeout      = 10 * time.Second
+	maxWebhookRetryDelay       = 6 * time.Hour
+	webhookBatchSize           = 50
+	webhookConcurrency         = 4
+)
+
+// Headers sent with every delivery
+const (
+	WebhookEventHeader     = "X-Webhook-Event"
+	WebhookDeliveryHeader  = "X-Webhook-Delivery"
+	WebhookTimestampHeader = "X-Webhook-Timestamp"
+	WebhookSignatureHeader = "X-Webhook-Signature"
+)
+
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// WebhookWorkerOptions controls how deliveries are sent, zero values keep
+// the defaults
+type WebhookWorkerOptions struct {
+	PollInterval time.Duration // how often due deliveries are looked up
+	MaxAttempts  int           // attempts before a delivery is dead
+	RetryDelay   time.Duration // backoff before the first retry, doubled after each
+	Timeout      time.Duration // how long a receiver has to answer
+}
+
+// WebhookWorker sends the deliveries queued by the WebhookPublisher. A
+// delivery is retried with exponential backoff until the receiver answers
+// with a 2xx status, and marked dead after the last attempt. Receivers are
+// only reached on public addresses, and redirects are not followed.
+type WebhookWorker struct {
+	store  db.Store
+	client HTTPClient
+	opts   WebhookWorkerOptions
+	now    func() time.Time
+}
+
+func NewWebhookWorker(store db.Store, opts WebhookWorkerOptions) *WebhookWorker {
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = defaultWebhookPollInterval
+	}
+	if opts.MaxAttempts <= 0 {
+		opts.MaxAttempts = defaultWebhookMaxAttempts
+	}
+	if opts.RetryDelay <= 0 {
+		opts.RetryDelay = defaultWebhookRetryDelay
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = defaultWebhookTimeout
+	}
+
+	return &WebhookWorker{
+		store:  store,
+		client: newWebhookClient(opts.Timeout),
+		opts:   opts,
+		now:    time.Now,
+	}
+}
+
+// newWebhookClient returns a client that refuses to connect to addresses
+// that are not public, whatever the URL resolves to, and answers redirects
+// with the redirect itself, which counts as a failed attempt
+func newWebhookClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: netguard.Control,
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{
+		Transport: transport,
+		Timeout:   timeout,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// Run sends due deliveries until ctx is done
+func (w *WebhookWorker) Run(ctx context.Context) error {
+	logger := logging.FromContext(ctx)
+	logger.Info("starting webhook worker")
+
+	ticker := time.NewTicker(w.opts.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		_, err := w.DeliverDue(ctx)
+		if err != nil && ctx.Err() == nil {
+			logger.Error("cannot deliver webhooks", slog.Any("error", err))
+		}
+
+		select {
+		case <-ctx.Done():
+			logger.Info("shutting down webhook worker")
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// DeliverDue sends a batch of the deliveries that are due and returns how
+// many were found
+func (w *WebhookWorker) DeliverDue(ctx context.Context) (int, error) {
+	rows, err := w.store.ListDueWebhookDeliveries(ctx, db.ListDueWebhookDeliveriesParams{
+		Now:   w.now().UnixMilli(),
+		Limit: webhookBatchSize,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	var g errgroup.Group
+	g.SetLimit(webhookConcurrency)
+	for _, row := range rows {
+		row := row
+		g.Go(func() error {
+			return w.deliver(ctx, row)
+		})
+	}
+
+	return len(rows), g.Wait()
+}
+
+func (w *WebhookWorker) deliver(ctx context.Context, row db.ListDueWebhookDeliveriesRow) error {
+	delivery := row.WebhookDelivery
+
+	// hold the delivery while it is sent, so that other workers skip it
+	n, err := w.store.LeaseWebhookDelivery(ctx, db.LeaseWebhookDeliveryParams{
+		DeliveryID:    delivery.DeliveryID,
+		NextAttemptAt: delivery.NextAttemptAt,
+		LeaseUntil:    w.now().Add(2 * w.opts.Timeout).UnixMilli(),
+	})
+	if err != nil || n == 0 {
+		return err
+	}
+
+	sendErr := w.send(ctx, row)
+	if sendErr != nil && ctx.Err() != nil {
+		// shutting down, the lease runs out and the attempt is made again
+		return nil
+	}
+
+	now := w.now()
+	arg := db.SetWebhookDeliveryResultParams{
+		DeliveryID:    delivery.DeliveryID,
+		Status:        string(models.WebhookDeliveryDelivered),
+		NextAttemptAt: now.UnixMilli(),
+	}
+	if sendErr != nil {
+		attempts := delivery.Attempts + 1
+		arg.LastError = sql.NullString{
+			String: sendErr.Error(),
+			Valid:  true,
+		}
+		if attempts >= int64(w.opts.MaxAttempts) {
+			arg.Status = string(models.WebhookDeliveryDead)
+		} else {
+			arg.Status = string(models.WebhookDeliveryPending)
+			arg.NextAttemptAt = now.Add(w.retryDelay(attempts)).UnixMilli()
+		}
+	}
+
+	_, err = w.store.SetWebhookDeliveryResult(ctx, arg)
+	return err
+}
+
+// retryDelay returns the backoff after the given number of failed attempts
+func (w *WebhookWorker) retryDelay(attempts int64) time.Duration {
+	delay := w.opts.RetryDelay
+	for i := int64(1); i < attempts && delay < maxWebhookRetryDelay; i++ {
+		delay *= 2
+	}
+
+	return min(delay, maxWebhookRetryDelay)
+}
+
+func (w *WebhookWorker) send(ctx context.Context, row db.ListDueWebhookDeliveriesRow) error {
+	body := []byte(row.WebhookDelivery.Payload)
+	timestamp := w.now().Unix()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, row.Url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "xyz-books-webhooks")
+	req.Header.Set(WebhookEventHeader, row.WebhookDelivery.Event)
+	req.Header.Set(WebhookDeliveryHeader, strconv.FormatInt(row.WebhookDelivery.DeliveryID, 10))
+	req.Header.Set(WebhookTimestampHeader, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(WebhookSignatureHeader, "sha256="+WebhookSignature(row.Secret, timestamp, body))
+
+	res, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(res.Body, 64<<10))
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return fmt.Errorf("receiver answered %s", res.Status)
+	}
+
+	return nil
+}
+
+// WebhookSignature returns the hex HMAC-SHA256 of the timestamp and body of a
+// delivery, joined by a dot. Receivers compute it with the webhook secret and
+// compare it with the X-Webhook-Signature header, after the "sha256=" prefix.
+func WebhookSignature(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(mac, "%d.", timestamp)
+	mac.Write(body)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
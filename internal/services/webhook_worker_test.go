@@ -0,0 +1,249 @@
+package services
+
+import (
+	"database/sql"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	db "github.com/atsuyaourt/xyz-books/internal/db/sqlc"
+	mockdb "github.com/atsuyaourt/xyz-books/internal/mocks/db"
+	"github.com/atsuyaourt/xyz-books/internal/models"
+	"github.com/atsuyaourt/xyz-books/internal/netguard"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+)
+
+func TestWebhookWorkerDeliverDue(t *testing.T) {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	payload := `{"id":"e1","type":"book.created","data":{"title":"Dune"}}`
+
+	testCases := []struct {
+		name       string
+		status     int // answered by the receiver
+		attempts   int64
+		leased     int64
+		wantResult *db.SetWebhookDeliveryResultParams
+	}{
+		{
+			name:   "Delivered",
+			status: http.StatusNoContent,
+			leased: 1,
+			wantResult: &db.SetWebhookDeliveryResultParams{
+				DeliveryID:    7,
+				Status:        string(models.WebhookDeliveryDelivered),
+				NextAttemptAt: now.UnixMilli(),
+			},
+		},
+		{
+			name:     "Retried",
+			status:   http.StatusInternalServerError,
+			attempts: 2,
+			leased:   1,
+			wantResult: &db.SetWebhookDeliveryResultParams{
+				DeliveryID:    7,
+				Status:        string(models.WebhookDeliveryPending),
+				NextAttemptAt: now.Add(4 * time.Minute).UnixMilli(),
+				LastError: sql.NullString{
+					String: "receiver answered 500 Internal Server Error",
+					Valid:  true,
+				},
+			},
+		},
+		{
+			name:     "Dead",
+			status:   http.StatusGone,
+			attempts: 4,
+			leased:   1,
+			wantResult: &db.SetWebhookDeliveryResultParams{
+				DeliveryID:    7,
+				Status:        string(models.WebhookDeliveryDead),
+				NextAttemptAt: now.UnixMilli(),
+				LastError: sql.NullString{
+					String: "receiver answered 410 Gone",
+					Valid:  true,
+				},
+			},
+		},
+		{
+			name:   "TakenByAnotherWorker",
+			status: http.StatusOK,
+			leased: 0,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			received := 0
+			receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				received++
+
+				body, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+				require.Equal(t, payload, string(body))
+
+				timestamp, err := strconv.ParseInt(r.Header.Get(WebhookTimestampHeader), 10, 64)
+				require.NoError(t, err)
+				require.Equal(t, now.Unix(), timestamp)
+				require.Equal(t, "sha256="+WebhookSignature("s3cret-s3cret-s3cret", timestamp, body), r.Header.Get(WebhookSignatureHeader))
+				require.Equal(t, "book.created", r.Header.Get(WebhookEventHeader))
+				require.Equal(t, "7", r.Header.Get(WebhookDeliveryHeader))
+
+				w.WriteHeader(tc.status)
+			}))
+			defer receiver.Close()
+
+			store := mockdb.NewMockStore(t)
+			store.EXPECT().ListDueWebhookDeliveries(mock.Anything, db.ListDueWebhookDeliveriesParams{
+				Now:   now.UnixMilli(),
+				Limit: webhookBatchSize,
+			}).Return([]db.ListDueWebhookDeliveriesRow{
+				{
+					WebhookDelivery: db.WebhookDelivery{
+						DeliveryID:    7,
+						WebhookID:     3,
+						Event:         "book.created",
+						Payload:       payload,
+						Status:        string(models.WebhookDeliveryPending),
+						Attempts:      tc.attempts,
+						NextAttemptAt: now.Add(-time.Second).UnixMilli(),
+					},
+					Url:    receiver.URL,
+					Secret: "s3cret-s3cret-s3cret",
+				},
+			}, nil)
+			store.EXPECT().LeaseWebhookDelivery(mock.Anything, db.LeaseWebhookDeliveryParams{
+				DeliveryID:    7,
+				NextAttemptAt: now.Add(-time.Second).UnixMilli(),
+				LeaseUntil:    now.Add(2 * time.Second).UnixMilli(),
+			}).Return(tc.leased, nil)
+			if tc.wantResult != nil {
+				store.EXPECT().SetWebhookDeliveryResult(mock.Anything, *tc.wantResult).
+					Return(db.WebhookDelivery{}, nil)
+			}
+
+			w := NewWebhookWorker(store, WebhookWorkerOptions{
+				MaxAttempts: 5,
+				RetryDelay:  time.Minute,
+				Timeout:     time.Second,
+			})
+			w.now = func() time.Time { return now }
+			allowLoopback(w)
+
+			n, err := w.DeliverDue(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, 1, n)
+			require.Equal(t, int(tc.leased), received)
+		})
+	}
+}
+
+func TestWebhookWorkerUnreachable(t *testing.T) {
+	receiver := httptest.NewServer(http.NotFoundHandler())
+	receiver.Close()
+
+	store := mockdb.NewMockStore(t)
+	store.EXPECT().ListDueWebhookDeliveries(mock.Anything, mock.Anything).
+		Return([]db.ListDueWebhookDeliveriesRow{
+			{
+				WebhookDelivery: db.WebhookDelivery{DeliveryID: 1, Payload: "{}"},
+				Url:             receiver.URL,
+			},
+		}, nil)
+	store.EXPECT().LeaseWebhookDelivery(mock.Anything, mock.Anything).Return(1, nil)
+	store.EXPECT().SetWebhookDeliveryResult(mock.Anything, mock.MatchedBy(func(arg db.SetWebhookDeliveryResultParams) bool {
+		return arg.Status == string(models.WebhookDeliveryPending) && arg.LastError.Valid
+	})).Return(db.WebhookDelivery{}, nil)
+
+	w := NewWebhookWorker(store, WebhookWorkerOptions{})
+	_, err := w.DeliverDue(context.Background())
+	require.NoError(t, err)
+}
+
+func TestWebhookWorkerPrivateReceiver(t *testing.T) {
+	received := false
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = true
+	}))
+	defer receiver.Close()
+
+	store := mockdb.NewMockStore(t)
+	store.EXPECT().ListDueWebhookDeliveries(mock.Anything, mock.Anything).
+		Return([]db.ListDueWebhookDeliveriesRow{
+			{
+				WebhookDelivery: db.WebhookDelivery{DeliveryID: 1, Payload: "{}"},
+				Url:             receiver.URL,
+			},
+		}, nil)
+	store.EXPECT().LeaseWebhookDelivery(mock.Anything, mock.Anything).Return(1, nil)
+	store.EXPECT().SetWebhookDeliveryResult(mock.Anything, mock.MatchedBy(func(arg db.SetWebhookDeliveryResultParams) bool {
+		return arg.Status == string(models.WebhookDeliveryPending) &&
+			strings.Contains(arg.LastError.String, netguard.ErrForbiddenAddress.Error())
+	})).Return(db.WebhookDelivery{}, nil)
+
+	w := NewWebhookWorker(store, WebhookWorkerOptions{})
+	_, err := w.DeliverDue(context.Background())
+	require.NoError(t, err)
+	require.False(t, received)
+}
+
+func TestWebhookWorkerRedirect(t *testing.T) {
+	redirected := false
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		redirected = true
+	}))
+	defer target.Close()
+	receiver := httptest.NewServer(http.RedirectHandler(target.URL, http.StatusTemporaryRedirect))
+	defer receiver.Close()
+
+	store := mockdb.NewMockStore(t)
+	store.EXPECT().ListDueWebhookDeliveries(mock.Anything, mock.Anything).
+		Return([]db.ListDueWebhookDeliveriesRow{
+			{
+				WebhookDelivery: db.WebhookDelivery{DeliveryID: 1, Payload: "{}"},
+				Url:             receiver.URL,
+			},
+		}, nil)
+	store.EXPECT().LeaseWebhookDelivery(mock.Anything, mock.Anything).Return(1, nil)
+	store.EXPECT().SetWebhookDeliveryResult(mock.Anything, mock.MatchedBy(func(arg db.SetWebhookDeliveryResultParams) bool {
+		return arg.Status == string(models.WebhookDeliveryPending) &&
+			arg.LastError.String == "receiver answered 307 Temporary Redirect"
+	})).Return(db.WebhookDelivery{}, nil)
+
+	w := NewWebhookWorker(store, WebhookWorkerOptions{})
+	allowLoopback(w)
+	_, err := w.DeliverDue(context.Background())
+	require.NoError(t, err)
+	require.False(t, redirected)
+}
+
+// allowLoopback lets the worker reach the test receivers, which listen on
+// loopback, keeping its other settings
+func allowLoopback(w *WebhookWorker) {
+	client := w.client.(*http.Client)
+	client.Transport = http.DefaultTransport
+}
+
+func TestWebhookRetryDelay(t *testing.T) {
+	w := NewWebhookWorker(nil, WebhookWorkerOptions{RetryDelay: time.Minute})
+
+	require.Equal(t, time.Minute, w.retryDelay(1))
+	require.Equal(t, 2*time.Minute, w.retryDelay(2))
+	require.Equal(t, 16*time.Minute, w.retryDelay(5))
+	require.Equal(t, maxWebhookRetryDelay, w.retryDelay(40))
+}
+
+func TestWebhookSignature(t *testing.T) {
+	// printf '1700000000.{}' | openssl dgst -sha256 -hmac secret
+	require.Equal(t,
+		"b8569b78799ff9e3cbff0fc2d63a33a2b57f3282abd07c37ae5e8e7d79a5f163",
+		WebhookSignature("secret", 1700000000, []byte("{}")),
+	)
+}
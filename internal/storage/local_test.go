@@ -0,0 +1,59 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalStore(t *testing.T) {
+	ctx := context.Background()
+	store := NewLocalStore(t.TempDir())
+
+	key := "covers/1/cover.png"
+	err := store.Put(ctx, key, strings.NewReader("data"))
+	require.NoError(t, err)
+
+	r, err := store.Get(ctx, key)
+	require.NoError(t, err)
+	b, err := io.ReadAll(r)
+	require.NoError(t, err)
+	require.NoError(t, r.Close())
+	require.Equal(t, "data", string(b))
+
+	err = store.Put(ctx, key, strings.NewReader("new data"))
+	require.NoError(t, err)
+
+	r, err = store.Get(ctx, key)
+	require.NoError(t, err)
+	b, err = io.ReadAll(r)
+	require.NoError(t, err)
+	require.NoError(t, r.Close())
+	require.Equal(t, "new data", string(b))
+
+	err = store.Delete(ctx, key)
+	require.NoError(t, err)
+
+	_, err = store.Get(ctx, key)
+	require.ErrorIs(t, err, ErrBlobNotFound)
+
+	err = store.Delete(ctx, key)
+	require.NoError(t, err)
+}
+
+func TestLocalStoreInvalidKey(t *testing.T) {
+	ctx := context.Background()
+	store := NewLocalStore(t.TempDir())
+
+	keys := []string{"", "/abs", "../escape", "a/../../b", "a//b", "a/", `a\b`}
+	for _, key := range keys {
+		err := store.Put(ctx, key, strings.NewReader("data"))
+		require.ErrorIs(t, err, ErrInvalidKey, key)
+
+		_, err = store.Get(ctx, key)
+		require.ErrorIs(t, err, ErrInvalidKey, key)
+	}
+}
@@ -0,0 +1,57 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/atsuyaourt/xyz-books/internal/tracing/tracingtest"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestStartUntraced(t *testing.T) {
+	ctx := context.WithValue(context.Background(), struct{}{}, 1)
+
+	spanCtx, span := Start(ctx, "untraced")
+	defer span.End()
+	require.Equal(t, span, trace.SpanFromContext(spanCtx))
+	require.Equal(t, 1, spanCtx.Value(struct{}{}))
+	require.False(t, span.IsRecording())
+}
+
+func TestMiddleware(t *testing.T) {
+	recorder := tracingtest.Record(t)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(Middleware())
+	router.GET("/books/:isbn", func(ctx *gin.Context) {
+		_, span := Start(ctx.Request.Context(), "child")
+		End(span, errors.New("failed"))
+		ctx.Status(http.StatusInternalServerError)
+	})
+
+	traceID := "4bf92f3577b34da6a3ce929d0e0e4736"
+	request := httptest.NewRequest(http.MethodGet, "/books/9780000000002", nil)
+	request.Header.Set("traceparent", "00-"+traceID+"-00f067aa0ba902b7-01")
+	router.ServeHTTP(httptest.NewRecorder(), request)
+
+	spans := recorder.Ended()
+	require.Equal(t, []string{"child", "GET /books/:isbn"}, tracingtest.Names(spans))
+	child, server := spans[0], spans[1]
+
+	// the trace of the client is continued
+	require.Equal(t, traceID, server.SpanContext().TraceID().String())
+	require.Equal(t, server.SpanContext().SpanID(), child.Parent().SpanID())
+	require.Equal(t, trace.SpanKindServer, server.SpanKind())
+	require.Contains(t, server.Attributes(), attribute.String("http.route", "/books/:isbn"))
+	require.Contains(t, server.Attributes(), attribute.Int("http.response.status_code", http.StatusInternalServerError))
+	require.Equal(t, codes.Error, server.Status().Code)
+	require.Equal(t, codes.Error, child.Status().Code)
+}
@@ -1,18 +1,47 @@
 package util
 
 import (
+	"time"
+
 	"github.com/spf13/viper"
 )
 
 type Config struct {
-	GinMode           string `mapstructure:"GIN_MODE"`
-	DBDriver          string `mapstructure:"DB_DRIVER"`
-	DBSource          string `mapstructure:"DB_SOURCE"`
-	MigrationSrc      string `mapstructure:"MIGRATION_SRC"`
-	HTTPServerAddress string `mapstructure:"HTTP_SERVER_ADDRESS"`
-	APIBasePath       string `mapstructure:"API_BASE_PATH"`
-	OutputPath        string `mapstructure:"OUTPUT_PATH"`
-	WebDistPath       string `mapstructure:"WEB_DIST_PATH"`
+	GinMode             string        `mapstructure:"GIN_MODE"`
+	LogLevel            string        `mapstructure:"LOG_LEVEL"`      // debug, info, warn or error, info when empty
+	LogFormat           string        `mapstructure:"LOG_FORMAT"`     // json or text, json when empty
+	TraceExporter       string        `mapstructure:"TRACE_EXPORTER"` // otlp or stdout, spans are not recorded when empty
+	DBDriver            string        `mapstructure:"DB_DRIVER"`
+	DBSource            string        `mapstructure:"DB_SOURCE"`
+	DBBusyTimeout       time.Duration `mapstructure:"DB_BUSY_TIMEOUT"`   // how long SQLite waits for a lock
+	DBMaxReadConns      int           `mapstructure:"DB_MAX_READ_CONNS"` // size of the SQLite read pool
+	DBTxMaxRetries      int           `mapstructure:"DB_TX_MAX_RETRIES"` // retries of a transaction after a lock or serialization conflict, 3 when zero, none when negative
+	DBTxRetryDelay      time.Duration `mapstructure:"DB_TX_RETRY_DELAY"` // backoff before the first retry
+	DBTxIsolation       string        `mapstructure:"DB_TX_ISOLATION"`   // isolation level of PostgreSQL transactions, e.g. repeatable read, the database default when empty
+	MigrationSrc        string        `mapstructure:"MIGRATION_SRC"`
+	HTTPServerAddress   string        `mapstructure:"HTTP_SERVER_ADDRESS"`
+	GRPCServerAddress   string        `mapstructure:"GRPC_SERVER_ADDRESS"`  // the gRPC server is not started when empty
+	APIBasePath         string        `mapstructure:"API_BASE_PATH"`        // where v1 of the API is mounted, e.g. /api/v1
+	APIV2BasePath       string        `mapstructure:"API_V2_BASE_PATH"`     // where v2 of the API is mounted, next to v1 (e.g. /api/v2) when empty
+	APIV1DeprecatedAt   string        `mapstructure:"API_V1_DEPRECATED_AT"` // date v1 of the API was deprecated (YYYY-MM-DD), the v2 release date when empty
+	APIV1Sunset         string        `mapstructure:"API_V1_SUNSET"`        // date v1 of the API will be removed (YYYY-MM-DD), left out when unknown
+	APIKeys             []string      `mapstructure:"API_KEYS"`             // API keys of the clients, rate limited by key instead of IP
+	AdminAPIKeys        []string      `mapstructure:"ADMIN_API_KEYS"`       // API keys of the admins, sent in X-API-Key
+	TrustedProxies      []string      `mapstructure:"TRUSTED_PROXIES"`      // addresses or CIDRs of the proxies whose X-Forwarded-For is trusted, none when empty
+	RateLimit           string        `mapstructure:"RATE_LIMIT"`           // requests/window of an API client, e.g. 120/1m, unlimited when empty
+	RateLimitBooks      string        `mapstructure:"RATE_LIMIT_BOOKS"`     // for the books endpoints, RATE_LIMIT when empty
+	RateLimitOrders     string        `mapstructure:"RATE_LIMIT_ORDERS"`    // for the cart and order endpoints, RATE_LIMIT when empty
+	CacheSize           int           `mapstructure:"CACHE_SIZE"`           // book reads kept in memory, none when zero
+	CacheTTL            time.Duration `mapstructure:"CACHE_TTL"`            // how long a read is kept, until evicted when zero
+	HTTPCacheMaxAge     time.Duration `mapstructure:"HTTP_CACHE_MAX_AGE"`   // how long clients may reuse catalog reads, always revalidated when zero
+	OutputPath          string        `mapstructure:"OUTPUT_PATH"`
+	WebDistPath         string        `mapstructure:"WEB_DIST_PATH"`
+	BlobStorePath       string        `mapstructure:"BLOB_STORE_PATH"`
+	CoverMaxSize        int64         `mapstructure:"COVER_MAX_SIZE"`
+	WebhookPollInterval time.Duration `mapstructure:"WEBHOOK_POLL_INTERVAL"` // how often due deliveries are sent
+	WebhookMaxAttempts  int           `mapstructure:"WEBHOOK_MAX_ATTEMPTS"`  // attempts before a delivery is dead
+	WebhookRetryDelay   time.Duration `mapstructure:"WEBHOOK_RETRY_DELAY"`   // backoff before the first retry, doubled after each
+	WebhookTimeout      time.Duration `mapstructure:"WEBHOOK_TIMEOUT"`       // how long a receiver has to answer
 }
 
 // LoadConfig reads configuration from file or environment variables.
@@ -0,0 +1,322 @@
+package util
+
+import (
+	"fmt"
+	"hash/fnv"
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+	"strings"
+	"sync"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/gomedium"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+)
+
+const (
+	CoverWidth  = 224
+	CoverHeight = 320
+
+	coverPadding = 16
+	coverDark    = "#374151"
+	coverLight   = "#e5e7eb"
+	coverText    = "#1f2937"
+)
+
+type CoverRect struct {
+	X, Y, W, H float64
+	Fill       string // empty for no fill
+	Stroke     string // empty for no border
+}
+
+// CoverText is a single line of centered text, Y is its baseline
+type CoverText struct {
+	Text   string
+	X, Y   float64
+	Size   float64
+	Weight int
+	Color  string
+}
+
+// CoverLayout is a placeholder cover, laid out once so that it can be
+// drawn the same way as SVG and as a raster image.
+type CoverLayout struct {
+	Width  int
+	Height int
+	Rects  []CoverRect
+	Texts  []CoverText
+}
+
+type CoverInfo struct {
+	Seed      string // picks the background color
+	Title     string
+	Authors   []string
+	Edition   string
+	Publisher string
+}
+
+// NewCoverLayout lays out a placeholder cover mimicking a printed book:
+// a dark title box near the top and the publisher at the bottom.
+func NewCoverLayout(info CoverInfo) CoverLayout {
+	c := CoverLayout{
+		Width:  CoverWidth,
+		Height: CoverHeight,
+	}
+
+	c.Rects = append(c.Rects, CoverRect{W: CoverWidth, H: CoverHeight, Fill: CoverColor(info.Seed)})
+
+	inner := float64(CoverWidth - 2*coverPadding)
+	center := float64(CoverWidth) / 2
+
+	// title box, 11/12 of the inner width with a 1px border and a 4px margin
+	boxW := inner * 11 / 12
+	boxX := center - boxW/2
+	darkX, darkW := boxX+5, boxW-10
+	textTop := float64(coverPadding) + 5 + 6
+
+	y := textTop
+	for _, line := range WrapText(info.Title, 700, 18, darkW-8, 4) {
+		c.addText(line, center, y, 18, 28, 700, coverLight)
+		y += 28
+	}
+	y += 8
+	for _, line := range WrapText(strings.Join(info.Authors, ", "), 500, 12, darkW-8, 2) {
+		c.addText(line, center, y, 12, 16, 500, coverLight)
+		y += 16
+	}
+	darkBottom := y + 6
+
+	c.Rects = append(c.Rects,
+		CoverRect{X: boxX, Y: coverPadding, W: boxW, H: darkBottom + 5 - coverPadding, Stroke: coverDark},
+		CoverRect{X: darkX, Y: coverPadding + 5, W: darkW, H: darkBottom - coverPadding - 5, Fill: coverDark},
+	)
+
+	y = darkBottom + 5
+	for _, line := range WrapText(info.Edition, 400, 12, inner, 1) {
+		c.addText(line, center, y, 12, 16, 400, coverDark)
+	}
+
+	publisher := WrapText(info.Publisher, 400, 16, inner*4/5, 2)
+	y = float64(CoverHeight-coverPadding) - float64(len(publisher))*24
+	c.Rects = append(c.Rects, CoverRect{X: center - inner*2/5, Y: y - 12 - 4, W: inner * 4 / 5, H: 4, Stroke: coverDark})
+	for _, line := range publisher {
+		c.addText(line, center, y, 16, 24, 400, coverText)
+		y += 24
+	}
+
+	return c
+}
+
+func (c *CoverLayout) addText(text string, x, top, size, lineHeight float64, weight int, color string) {
+	c.Texts = append(c.Texts, CoverText{
+		Text:   text,
+		X:      x,
+		Y:      top + lineHeight/2 + size*0.35,
+		Size:   size,
+		Weight: weight,
+		Color:  color,
+	})
+}
+
+// CoverColor derives a light background color from seed, so that the same
+// book always gets the same color
+func CoverColor(seed string) string {
+	h := fnv.New32a()
+	h.Write([]byte(seed))
+	hue := float64(h.Sum32() % 360)
+
+	r, g, b := hslToRGB(hue, 0.6, 0.75)
+
+	return fmt.Sprintf("#%02x%02x%02x", r, g, b)
+}
+
+func hslToRGB(h, s, l float64) (uint8, uint8, uint8) {
+	c := (1 - math.Abs(2*l-1)) * s
+	x := c * (1 - math.Abs(math.Mod(h/60, 2)-1))
+	m := l - c/2
+
+	var r, g, b float64
+	switch {
+	case h < 60:
+		r, g, b = c, x, 0
+	case h < 120:
+		r, g, b = x, c, 0
+	case h < 180:
+		r, g, b = 0, c, x
+	case h < 240:
+		r, g, b = 0, x, c
+	case h < 300:
+		r, g, b = x, 0, c
+	default:
+		r, g, b = c, 0, x
+	}
+
+	return uint8(math.Round((r + m) * 255)), uint8(math.Round((g + m) * 255)), uint8(math.Round((b + m) * 255))
+}
+
+var (
+	fontsOnce sync.Once
+	fonts     map[int]*opentype.Font
+)
+
+// fontFace returns a new face each time, as faces are not safe for concurrent use
+func fontFace(weight int, size float64) font.Face {
+	fontsOnce.Do(func() {
+		fonts = map[int]*opentype.Font{}
+		for w, ttf := range map[int][]byte{400: goregular.TTF, 500: gomedium.TTF, 700: gobold.TTF} {
+			f, err := opentype.Parse(ttf)
+			if err != nil {
+				panic(err)
+			}
+			fonts[w] = f
+		}
+	})
+
+	f, ok := fonts[weight]
+	if !ok {
+		f = fonts[400]
+	}
+
+	face, err := opentype.NewFace(f, &opentype.FaceOptions{
+		Size:    size,
+		DPI:     72,
+		Hinting: font.HintingNone,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return face
+}
+
+type faceKey struct {
+	weight int
+	size   float64
+}
+
+// measureFace is a face kept for measuring text, locked as faces are not
+// safe for concurrent use
+type measureFace struct {
+	mu   sync.Mutex
+	face font.Face
+}
+
+// measureFaces caches the faces textWidth uses, as layouts measure the
+// same few weights and sizes over and over
+var measureFaces sync.Map // faceKey -> *measureFace
+
+func textWidth(text string, weight int, size float64) float64 {
+	key := faceKey{weight: weight, size: size}
+	v, ok := measureFaces.Load(key)
+	if !ok {
+		v, _ = measureFaces.LoadOrStore(key, &measureFace{face: fontFace(weight, size)})
+	}
+	f := v.(*measureFace)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return float64(font.MeasureString(f.face, text)) / 64
+}
+
+// WrapText breaks text into at most maxLines lines no wider than width,
+// ending with an ellipsis when it does not fit.
+func WrapText(text string, weight int, size, width float64, maxLines int) []string {
+	var lines []string
+	var line string
+
+	for _, word := range strings.Fields(text) {
+		next := word
+		if len(line) > 0 {
+			next = line + " " + word
+		}
+		if len(line) == 0 || textWidth(next, weight, size) <= width {
+			line = next
+			continue
+		}
+		lines = append(lines, line)
+		line = word
+	}
+	if len(line) > 0 {
+		lines = append(lines, line)
+	}
+
+	truncated := len(lines) > maxLines
+	if truncated {
+		lines = lines[:maxLines]
+	}
+	for i, l := range lines {
+		if textWidth(l, weight, size) > width || (truncated && i == len(lines)-1) {
+			lines[i] = ellipsize(l, weight, size, width)
+		}
+	}
+
+	return lines
+}
+
+func ellipsize(text string, weight int, size, width float64) string {
+	r := []rune(text)
+	for len(r) > 0 && textWidth(string(r)+"…", weight, size) > width {
+		r = r[:len(r)-1]
+	}
+
+	return strings.TrimRight(string(r), " ") + "…"
+}
+
+func parseHexColor(s string) color.RGBA {
+	var c color.RGBA
+	c.A = 255
+	fmt.Sscanf(s, "#%02x%02x%02x", &c.R, &c.G, &c.B)
+
+	return c
+}
+
+// Rasterize draws the cover at the given scale
+func (c CoverLayout) Rasterize(scale float64) image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, int(float64(c.Width)*scale), int(float64(c.Height)*scale)))
+
+	px := func(v float64) int {
+		return int(math.Round(v * scale))
+	}
+	stroke := int(math.Max(1, math.Round(scale)))
+
+	for _, r := range c.Rects {
+		rect := image.Rect(px(r.X), px(r.Y), px(r.X+r.W), px(r.Y+r.H))
+		if len(r.Fill) > 0 {
+			draw.Draw(img, rect, image.NewUniform(parseHexColor(r.Fill)), image.Point{}, draw.Src)
+		}
+		if len(r.Stroke) > 0 {
+			src := image.NewUniform(parseHexColor(r.Stroke))
+			for _, edge := range []image.Rectangle{
+				image.Rect(rect.Min.X, rect.Min.Y, rect.Max.X, rect.Min.Y+stroke),
+				image.Rect(rect.Min.X, rect.Max.Y-stroke, rect.Max.X, rect.Max.Y),
+				image.Rect(rect.Min.X, rect.Min.Y, rect.Min.X+stroke, rect.Max.Y),
+				image.Rect(rect.Max.X-stroke, rect.Min.Y, rect.Max.X, rect.Max.Y),
+			} {
+				draw.Draw(img, edge, src, image.Point{}, draw.Src)
+			}
+		}
+	}
+
+	for _, t := range c.Texts {
+		face := fontFace(t.Weight, t.Size*scale)
+		d := font.Drawer{
+			Dst:  img,
+			Src:  image.NewUniform(parseHexColor(t.Color)),
+			Face: face,
+		}
+		w := d.MeasureString(t.Text)
+		d.Dot = fixed.Point26_6{
+			X: fixed.Int26_6(t.X*scale*64) - w/2,
+			Y: fixed.Int26_6(t.Y * scale * 64),
+		}
+		d.DrawString(t.Text)
+	}
+
+	return img
+}
@@ -0,0 +1,80 @@
+package util
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCoverColor(t *testing.T) {
+	isbn := RandomISBN13()
+
+	require.Equal(t, CoverColor(isbn), CoverColor(isbn))
+	require.Regexp(t, "^#[0-9a-f]{6}$", CoverColor(isbn))
+}
+
+func TestWrapText(t *testing.T) {
+	testCases := []struct {
+		name        string
+		input       string
+		maxLines    int
+		checkResult func(lines []string)
+	}{
+		{
+			name:     "Short",
+			input:    "Go",
+			maxLines: 2,
+			checkResult: func(lines []string) {
+				require.Equal(t, []string{"Go"}, lines)
+			},
+		},
+		{
+			name:     "Wrapped",
+			input:    "The Go Programming Language",
+			maxLines: 4,
+			checkResult: func(lines []string) {
+				require.Greater(t, len(lines), 1)
+				require.Equal(t, "The Go Programming Language", strings.Join(lines, " "))
+			},
+		},
+		{
+			name:     "Truncated",
+			input:    "The Go Programming Language",
+			maxLines: 1,
+			checkResult: func(lines []string) {
+				require.Len(t, lines, 1)
+				require.True(t, strings.HasSuffix(lines[0], "…"))
+			},
+		},
+		{
+			name:     "LongWord",
+			input:    strings.Repeat("x", 100),
+			maxLines: 2,
+			checkResult: func(lines []string) {
+				require.Len(t, lines, 1)
+				require.True(t, strings.HasSuffix(lines[0], "…"))
+			},
+		},
+		{
+			name:     "Empty",
+			input:    "",
+			maxLines: 2,
+			checkResult: func(lines []string) {
+				require.Empty(t, lines)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			lines := WrapText(tc.input, 700, 18, 120, tc.maxLines)
+			for _, line := range lines {
+				require.LessOrEqual(t, textWidth(line, 700, 18), 120.0)
+			}
+			tc.checkResult(lines)
+		})
+	}
+}
@@ -1,11 +1,33 @@
 package util
 
 import (
+	"errors"
+	"io/fs"
+	"net/url"
+
 	"github.com/golang-migrate/migrate/v4"
+	_ "github.com/golang-migrate/migrate/v4/database/pgx/v5"
 	_ "github.com/golang-migrate/migrate/v4/database/sqlite"
 	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
 )
 
+// DBDriverPostgres is the DB_DRIVER value that selects PostgreSQL, any other
+// value is used as the name of a SQLite driver
+const DBDriverPostgres = "postgres"
+
+// PostgresMigrationSource turns a postgres:// connection URL into the
+// source expected by the golang-migrate pgx driver
+func PostgresMigrationSource(dbSource string) (string, error) {
+	u, err := url.Parse(dbSource)
+	if err != nil {
+		return "", err
+	}
+	u.Scheme = "pgx5"
+
+	return u.String(), nil
+}
+
 func DBMigrationUp(migrationPath string, dbSource string) error {
 	migration, err := migrate.New("file://"+migrationPath, dbSource)
 	if err != nil {
@@ -31,3 +53,26 @@ func DBMigrationDown(migrationPath string, dbSource string) error {
 
 	return nil
 }
+
+// LatestMigration returns the version of the last migration in fsys, 0 when
+// it has none
+func LatestMigration(fsys fs.FS) (uint, error) {
+	src, err := iofs.New(fsys, ".")
+	if err != nil {
+		return 0, err
+	}
+	defer src.Close()
+
+	version, err := src.First()
+	for err == nil {
+		var next uint
+		if next, err = src.Next(version); err == nil {
+			version = next
+		}
+	}
+	if !errors.Is(err, fs.ErrNotExist) {
+		return 0, err
+	}
+
+	return version, nil
+}
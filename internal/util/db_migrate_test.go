@@ -0,0 +1,27 @@
+package util
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLatestMigration(t *testing.T) {
+	fsys := fstest.MapFS{
+		"000001_init.up.sql":       {},
+		"000001_init.down.sql":     {},
+		"000002_books.up.sql":      {},
+		"000002_books.down.sql":    {},
+		"000010_webhooks.up.sql":   {},
+		"000010_webhooks.down.sql": {},
+		"README.md":                {},
+	}
+	version, err := LatestMigration(fsys)
+	require.NoError(t, err)
+	require.EqualValues(t, 10, version)
+
+	version, err = LatestMigration(fstest.MapFS{})
+	require.NoError(t, err)
+	require.Zero(t, version)
+}
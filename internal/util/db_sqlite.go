@@ -0,0 +1,89 @@
+package util
+
+import (
+	"database/sql"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	defaultBusyTimeout  = 5 * time.Second
+	defaultMaxReadConns = 4
+)
+
+// SQLiteDB is a SQLite database in WAL mode opened as two pools. SQLite
+// allows a single writer at a time, so Write has one connection and the
+// readers, which WAL does not block, get their own pool.
+type SQLiteDB struct {
+	Write *sql.DB
+	Read  *sql.DB
+}
+
+// OpenSQLite opens the SQLite database at config.DBSource, switching it to
+// WAL mode. A zero busy timeout or read pool size uses the defaults.
+func OpenSQLite(config Config) (*SQLiteDB, error) {
+	busyTimeout := config.DBBusyTimeout
+	if busyTimeout <= 0 {
+		busyTimeout = defaultBusyTimeout
+	}
+	maxReadConns := config.DBMaxReadConns
+	if maxReadConns <= 0 {
+		maxReadConns = defaultMaxReadConns
+	}
+
+	busyTimeoutPragma := fmt.Sprintf("busy_timeout(%d)", busyTimeout.Milliseconds())
+
+	// transactions take the write lock when they begin, so that they wait
+	// for the busy timeout instead of failing when upgrading a read lock
+	write, err := sql.Open(config.DBDriver, sqliteDSN(config.DBSource, url.Values{
+		"_pragma": {busyTimeoutPragma, "journal_mode(WAL)"},
+		"_txlock": {"immediate"},
+	}))
+	if err != nil {
+		return nil, err
+	}
+	write.SetMaxOpenConns(1)
+	write.SetMaxIdleConns(1)
+
+	// the journal mode is stored in the database file, set it before the
+	// readers connect
+	if err = write.Ping(); err != nil {
+		write.Close()
+		return nil, err
+	}
+
+	read, err := sql.Open(config.DBDriver, sqliteDSN(config.DBSource, url.Values{
+		"_pragma": {busyTimeoutPragma, "query_only(1)"},
+	}))
+	if err != nil {
+		write.Close()
+		return nil, err
+	}
+	read.SetMaxOpenConns(maxReadConns)
+	read.SetMaxIdleConns(maxReadConns)
+
+	return &SQLiteDB{Write: write, Read: read}, nil
+}
+
+func (db *SQLiteDB) Close() error {
+	rErr := db.Read.Close()
+	wErr := db.Write.Close()
+	if wErr != nil {
+		return wErr
+	}
+
+	return rErr
+}
+
+// sqliteDSN adds connection parameters to the database path, each _pragma
+// is run on every new connection
+func sqliteDSN(source string, q url.Values) string {
+	sep := "?"
+	if strings.Contains(source, "?") {
+		sep = "&"
+	}
+
+	return source + sep + q.Encode()
+}
@@ -0,0 +1,59 @@
+package util
+
+import (
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenSQLite(t *testing.T) {
+	conn, err := OpenSQLite(Config{
+		DBDriver: "sqlite",
+		DBSource: filepath.Join(t.TempDir(), "test.db"),
+	})
+	require.NoError(t, err)
+	defer conn.Close()
+
+	var mode string
+	err = conn.Read.QueryRow("PRAGMA journal_mode").Scan(&mode)
+	require.NoError(t, err)
+	require.Equal(t, "wal", mode)
+
+	require.Equal(t, 1, conn.Write.Stats().MaxOpenConnections)
+	require.Equal(t, defaultMaxReadConns, conn.Read.Stats().MaxOpenConnections)
+
+	_, err = conn.Write.Exec("CREATE TABLE counters (id INTEGER PRIMARY KEY, n INTEGER NOT NULL)")
+	require.NoError(t, err)
+
+	_, err = conn.Read.Exec("INSERT INTO counters (n) VALUES (1)")
+	require.Error(t, err, "the read pool is read only")
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 40)
+	for i := 0; i < 20; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			_, err := conn.Write.Exec("INSERT INTO counters (n) VALUES (1)")
+			errs <- err
+		}()
+		go func() {
+			defer wg.Done()
+			var n int
+			errs <- conn.Read.QueryRow("SELECT count(*) FROM counters").Scan(&n)
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	var n int
+	err = conn.Read.QueryRow("SELECT count(*) FROM counters").Scan(&n)
+	require.NoError(t, err)
+	require.Equal(t, 20, n)
+}
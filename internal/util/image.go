@@ -0,0 +1,27 @@
+package util
+
+import (
+	"image"
+	"image/color"
+
+	"golang.org/x/image/draw"
+)
+
+// ResizeToWidth scales img down to the given width keeping its aspect ratio.
+// Transparent areas are flattened onto a white background.
+func ResizeToWidth(img image.Image, width int) image.Image {
+	b := img.Bounds()
+	if width > b.Dx() {
+		width = b.Dx()
+	}
+	height := b.Dy() * width / b.Dx()
+	if height < 1 {
+		height = 1
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(dst, dst.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.CatmullRom.Scale(dst, dst.Bounds(), img, b, draw.Over, nil)
+
+	return dst
+}
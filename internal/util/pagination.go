@@ -1,5 +1,10 @@
 package util
 
+import (
+	"encoding/base64"
+	"encoding/json"
+)
+
 type PaginatedList[T any] struct {
 	CurrentPage int32 `json:"current_page"`
 	PerPage     int32 `json:"per_page"`
@@ -45,3 +50,30 @@ func (p *PaginatedList[T]) setPrevPage() {
 		p.PrevPage = prevPage
 	}
 }
+
+// CursorList is a page of a list read by cursor. Unlike page numbers, a
+// cursor does not skip or repeat items when the list changes between reads.
+type CursorList[T any] struct {
+	Items      []T    `json:"items"`
+	NextCursor string `json:"next_cursor"` // pass as cursor to read the next page, empty on the last page
+}
+
+// EncodeCursor returns the opaque cursor of a position in a list
+func EncodeCursor(position any) string {
+	data, err := json.Marshal(position)
+	if err != nil {
+		panic(err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor reads a cursor returned by EncodeCursor into position
+func DecodeCursor(cursor string, position any) error {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, position)
+}
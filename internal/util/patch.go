@@ -0,0 +1,51 @@
+package util
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// Patch is a member of a JSON merge patch (RFC 7396). Set tells a member
+// that is present apart from one left out, and Null a member sent as null,
+// which clears the field.
+type Patch[T any] struct {
+	Value T
+	Set   bool
+	Null  bool
+}
+
+// NewPatch returns a member setting the field to value
+func NewPatch[T any](value T) Patch[T] {
+	return Patch[T]{Value: value, Set: true}
+}
+
+// NullPatch returns a member clearing the field
+func NullPatch[T any]() Patch[T] {
+	return Patch[T]{Set: true, Null: true}
+}
+
+func (p *Patch[T]) UnmarshalJSON(data []byte) error {
+	// only called for members present in the document
+	p.Set = true
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		p.Null = true
+		return nil
+	}
+
+	return json.Unmarshal(data, &p.Value)
+}
+
+// Valid reports whether the member sets a value
+func (p Patch[T]) Valid() bool {
+	return p.Set && !p.Null
+}
+
+// ValidationValue returns the value to validate, nil unless the member sets
+// one, so that omitempty skips members that are left out or null
+func (p Patch[T]) ValidationValue() any {
+	if !p.Valid() {
+		return nil
+	}
+
+	return &p.Value
+}
@@ -0,0 +1,39 @@
+package util
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPatchUnmarshal(t *testing.T) {
+	var doc struct {
+		Title    Patch[string]   `json:"title"`
+		Price    Patch[float64]  `json:"price"`
+		ImageUrl Patch[string]   `json:"image_url"`
+		Subjects Patch[[]string] `json:"subjects"`
+	}
+
+	err := json.Unmarshal([]byte(`{"price": 0, "image_url": null, "subjects": ["Humor"]}`), &doc)
+	require.NoError(t, err)
+
+	require.False(t, doc.Title.Set, "left out")
+	require.False(t, doc.Title.Valid())
+	require.Nil(t, doc.Title.ValidationValue())
+
+	require.True(t, doc.Price.Valid(), "zero is a value")
+	require.Equal(t, 0.0, doc.Price.Value)
+	require.Equal(t, 0.0, *doc.Price.ValidationValue().(*float64))
+
+	require.True(t, doc.ImageUrl.Set)
+	require.True(t, doc.ImageUrl.Null)
+	require.False(t, doc.ImageUrl.Valid())
+	require.Nil(t, doc.ImageUrl.ValidationValue())
+
+	require.True(t, doc.Subjects.Valid())
+	require.Equal(t, []string{"Humor"}, doc.Subjects.Value)
+
+	err = json.Unmarshal([]byte(`{"price": "free"}`), &doc)
+	require.Error(t, err)
+}
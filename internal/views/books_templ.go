@@ -61,7 +61,7 @@ func Books(books util.PaginatedList[models.Book]) templ.Component {
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString("</form>")
+		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString("</form><div hx-ext=\"sse\" sse-connect=\"/events\" hx-get=\"/books\" hx-trigger=\"sse:book.created delay:500ms, sse:book.updated delay:500ms, sse:book.deleted delay:500ms, sse:book.restored delay:500ms\" hx-swap=\"outerHTML\" hx-target=\"#books\" hx-include=\"#page,#per-page,#title,#author,#publisher\">")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
@@ -69,7 +69,7 @@ func Books(books util.PaginatedList[models.Book]) templ.Component {
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString("</div></body></html>")
+		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString("</div></div></body></html>")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
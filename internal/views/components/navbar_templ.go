@@ -23,7 +23,7 @@ func Navbar() templ.Component {
 			templ_7745c5c3_Var1 = templ.NopComponent
 		}
 		ctx = templ.ClearChildren(ctx)
-		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString("<nav class=\"bg-white border-gray-200 dark:bg-gray-900\"><div class=\"flex flex-wrap items-center justify-between mx-auto p-4\"><a href=\"/\" class=\"flex items-center space-x-3 \"><img src=\"assets/book.svg\" class=\"h-8\" alt=\"XYZ Books Logo\"> <span class=\"self-center text-2xl font-semibold whitespace-nowrap dark:text-white\">XYZ Books</span></a></div></nav>")
+		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString("<nav class=\"bg-white border-gray-200 dark:bg-gray-900\"><div class=\"flex flex-wrap items-center justify-between mx-auto p-4\"><a href=\"/\" class=\"flex items-center space-x-3 \"><img src=\"/assets/book.svg\" class=\"h-8\" alt=\"XYZ Books Logo\"> <span class=\"self-center text-2xl font-semibold whitespace-nowrap dark:text-white\">XYZ Books</span></a> <a href=\"/cart\" class=\"text-lg font-semibold text-gray-700 hover:text-blue-700 dark:text-white\">Cart</a></div></nav>")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}